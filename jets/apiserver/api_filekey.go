@@ -49,6 +49,7 @@ func (server *Server) DoRegisterFileKeyAction(w http.ResponseWriter, r *http.Req
 	}
 	if err != nil {
 		log.Printf("Error: %v", err)
+		server.auditClientAccess(err)
 		ERROR(w, code, err)
 		return
 	}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/artisoft-io/jetstore/jets/datatable"
+	"github.com/artisoft-io/jetstore/jets/user"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// Management of the API keys of the service accounts, reserved to the administrator:
+//   GET    /api/v1/api-keys           list the api keys
+//   POST   /api/v1/api-keys           create an api key, the key is returned once
+//   DELETE /api/v1/api-keys/{key_id}  revoke an api key
+
+// apiKeyAdminPermission is the permission required to manage the api keys
+var apiKeyAdminPermission = datatable.SqlInsertDefinition{
+	AdminOnly:  true,
+	Capability: "none",
+}
+
+// apiKeyRequest is the request to create an api key
+type apiKeyRequest struct {
+	ServiceAccount string   `json:"service_account"`
+	Description    string   `json:"description"`
+	Capabilities   []string `json:"capabilities"`
+}
+
+// addApiKeyRoutes adds the routes managing the api keys
+func (server *Server) addApiKeyRoutes() {
+	apiKeyOptions := OptionConfig{Origin: "",
+		AllowedMethods: "GET, POST, DELETE, OPTIONS",
+		AllowedHeaders: "Content-Type, Authorization"}
+	path := apiV1Prefix + "/api-keys"
+	server.Router.HandleFunc(path, apiKeyOptions.options).Methods("OPTIONS")
+	server.Router.HandleFunc(path, jsonh(corsh(authh(server.ListApiKeys)))).Methods("GET")
+	server.Router.HandleFunc(path, jsonh(corsh(authh(server.CreateApiKey)))).Methods("POST")
+	server.Router.HandleFunc(path+"/{key_id}", apiKeyOptions.options).Methods("OPTIONS")
+	server.Router.HandleFunc(path+"/{key_id}", jsonh(corsh(authh(server.RevokeApiKey)))).Methods("DELETE")
+}
+
+// verifyApiKeyAdmin returns the email of the user of the request when it is the administrator
+func (server *Server) verifyApiKeyAdmin(r *http.Request) (string, error) {
+	ctx := datatable.NewDataTableContext(server.dbpool, globalDevMode, *usingSshTunnel, unitTestDir, adminEmail)
+	u, err := ctx.VerifyUserPermission(&apiKeyAdminPermission, user.ExtractToken(r))
+	if err != nil {
+		log.Printf("while VerifyUserPermission for api keys: %v", err)
+		return "", fmt.Errorf("error: unauthorized, only admin can manage api keys")
+	}
+	return u.Email, nil
+}
+
+// ListApiKeys ------------------------------------------------------
+func (server *Server) ListApiKeys(w http.ResponseWriter, r *http.Request) {
+	if _, err := server.verifyApiKeyAdmin(r); err != nil {
+		ERROR(w, http.StatusUnauthorized, err)
+		return
+	}
+	keys, err := user.ListApiKeys(server.dbpool)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		ERROR(w, http.StatusInternalServerError, err)
+		return
+	}
+	JSON(w, http.StatusOK, map[string]any{"items": keys})
+}
+
+// CreateApiKey ------------------------------------------------------
+func (server *Server) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	userEmail, err := server.verifyApiKeyAdmin(r)
+	if err != nil {
+		ERROR(w, http.StatusUnauthorized, err)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		ERROR(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	var request apiKeyRequest
+	err = json.Unmarshal(body, &request)
+	if err != nil {
+		ERROR(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	apiKey, key, err := user.CreateApiKey(server.dbpool, request.ServiceAccount, request.Description,
+		request.Capabilities, userEmail)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		ERROR(w, http.StatusBadRequest, err)
+		return
+	}
+	server.AuditLogger.Info("api key created", zap.String("user", userEmail),
+		zap.String("key_id", key.KeyId), zap.String("service_account", key.ServiceAccount),
+		zap.Strings("capabilities", key.Capabilities), zap.String("time", time.Now().Format(time.RFC3339)))
+	JSON(w, http.StatusCreated, map[string]any{
+		"api_key":         apiKey,
+		"key_id":          key.KeyId,
+		"service_account": key.ServiceAccount,
+		"capabilities":    key.Capabilities,
+	})
+}
+
+// RevokeApiKey ------------------------------------------------------
+func (server *Server) RevokeApiKey(w http.ResponseWriter, r *http.Request) {
+	userEmail, err := server.verifyApiKeyAdmin(r)
+	if err != nil {
+		ERROR(w, http.StatusUnauthorized, err)
+		return
+	}
+	keyId := mux.Vars(r)["key_id"]
+	err = user.RevokeApiKey(server.dbpool, keyId)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		ERROR(w, http.StatusNotFound, err)
+		return
+	}
+	server.AuditLogger.Info("api key revoked", zap.String("user", userEmail), zap.String("key_id", keyId),
+		zap.String("time", time.Now().Format(time.RFC3339)))
+	JSON(w, http.StatusOK, map[string]any{"key_id": keyId, "is_revoked": 1})
+}
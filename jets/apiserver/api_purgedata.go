@@ -49,6 +49,15 @@ func (server *Server) DoPurgeDataAction(w http.ResponseWriter, r *http.Request)
 		ERROR(w, http.StatusUnprocessableEntity, err)
 		return
 	}
+	// Purge data affects all clients
+	ctx := datatable.NewDataTableContext(server.dbpool, globalDevMode, *usingSshTunnel, unitTestDir, adminEmail)
+	err = ctx.VerifyAllClientsAccess(action.Action, token)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		server.auditClientAccess(err)
+		ERROR(w, http.StatusUnauthorized, err)
+		return
+	}
 	// Intercept specific dataTable action
 	switch action.Action {
 	case "reset_domain_tables":
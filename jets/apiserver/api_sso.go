@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artisoft-io/jetstore/jets/oidc"
+	"github.com/artisoft-io/jetstore/jets/user"
+	"go.uber.org/zap"
+)
+
+// Single sign-on using the OpenID Connect authorization code flow:
+//   GET /oidc/login     redirects the user to the identity provider
+//   GET /oidc/callback  redirect_uri of the identity provider, logs in the user
+// The user is created on first login, its roles are mapped from the groups of the
+// identity provider (see package oidc for the configuration). The local login
+// remains available, the administrator always uses the local login.
+// When JETS_OIDC_POST_LOGIN_URL is set, the callback redirects to it with the login
+// response, base64url encoded, in the url fragment: <url>#login=<data>,
+// otherwise the login response is returned as json.
+
+const oidcStateCookie = "jets_oidc_state"
+
+// ssoProvider is the identity provider, discovered on first use
+var ssoProvider struct {
+	sync.Mutex
+	config   *oidc.Config
+	provider *oidc.Provider
+}
+
+// addOidcRoutes adds the sso routes when sso is configured
+func (server *Server) addOidcRoutes() error {
+	config, err := oidc.ConfigFromEnv()
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		log.Println("SSO is not configured (JETS_OIDC_ISSUER not set), using local login only")
+		return nil
+	}
+	log.Println("SSO using OpenID Connect issuer:", config.Issuer)
+	ssoProvider.config = config
+	server.Router.HandleFunc("/oidc/login", server.OidcLogin).Methods("GET")
+	server.Router.HandleFunc("/oidc/callback", server.OidcCallback).Methods("GET")
+	return nil
+}
+
+// getSsoProvider returns the identity provider, discovery is attempted again on the
+// next login when the provider is not available
+func getSsoProvider(ctx context.Context) (*oidc.Provider, error) {
+	ssoProvider.Lock()
+	defer ssoProvider.Unlock()
+	if ssoProvider.provider == nil {
+		provider, err := oidc.NewProvider(ctx, ssoProvider.config, nil)
+		if err != nil {
+			return nil, err
+		}
+		ssoProvider.provider = provider
+	}
+	return ssoProvider.provider, nil
+}
+
+// OidcLogin ------------------------------------------------------
+func (server *Server) OidcLogin(w http.ResponseWriter, r *http.Request) {
+	provider, err := getSsoProvider(r.Context())
+	if err != nil {
+		log.Printf("while getting sso provider: %v", err)
+		ERROR(w, http.StatusServiceUnavailable, errors.New("error: sso provider is not available"))
+		return
+	}
+	state, nonce, err := oidc.NewState(user.ApiSecret)
+	if err != nil {
+		ERROR(w, http.StatusInternalServerError, fmt.Errorf("while creating sso login state: %v", err))
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/oidc",
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(provider.Config().RedirectUrl, "https"),
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, provider.AuthCodeUrl(state, nonce), http.StatusFound)
+}
+
+// OidcCallback ------------------------------------------------------
+func (server *Server) OidcCallback(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	query := r.URL.Query()
+	if idpError := query.Get("error"); idpError != "" {
+		log.Printf("sso login error from identity provider: %s %s", idpError, query.Get("error_description"))
+		ERROR(w, http.StatusUnauthorized, fmt.Errorf("error: sso login failed: %s", idpError))
+		return
+	}
+	// The state must be the one of the login of this browser
+	state := query.Get("state")
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil || state == "" || cookie.Value != state {
+		ERROR(w, http.StatusUnauthorized, errors.New("error: invalid sso login state"))
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: oidcStateCookie, Path: "/oidc", MaxAge: -1})
+	nonce, err := oidc.VerifyState(user.ApiSecret, state)
+	if err != nil {
+		log.Println(err)
+		ERROR(w, http.StatusUnauthorized, errors.New("error: invalid sso login state"))
+		return
+	}
+	provider, err := getSsoProvider(r.Context())
+	if err != nil {
+		log.Printf("while getting sso provider: %v", err)
+		ERROR(w, http.StatusServiceUnavailable, errors.New("error: sso provider is not available"))
+		return
+	}
+	idToken, err := provider.Exchange(r.Context(), query.Get("code"))
+	if err != nil {
+		log.Println(err)
+		ERROR(w, http.StatusUnauthorized, errors.New("error: sso login failed"))
+		return
+	}
+	identity, err := provider.VerifyIdToken(r.Context(), idToken, nonce)
+	if err != nil {
+		log.Println(err)
+		ERROR(w, http.StatusUnauthorized, errors.New("error: sso login failed"))
+		return
+	}
+	roles := provider.Config().RolesFromGroups(identity.Groups)
+	server.AuditLogger.Info("user sso login", zap.String("user", identity.Email),
+		zap.Strings("groups", identity.Groups), zap.Strings("roles", roles),
+		zap.String("time", time.Now().Format(time.RFC3339)))
+
+	jetsUser, err := user.UpsertSsoUser(server.dbpool, identity.Name, identity.Email, roles)
+	if err != nil {
+		ERROR(w, http.StatusUnauthorized, FormatError(err.Error()))
+		return
+	}
+	if jetsUser.IsActive != 1 {
+		ERROR(w, http.StatusUnauthorized, errors.New("User is not active, please contact your Administrator"))
+		return
+	}
+	jetsUser.Password = ""
+	data, err := loginData(jetsUser)
+	if err != nil {
+		ERROR(w, http.StatusUnprocessableEntity, FormatError(err.Error()))
+		return
+	}
+	postLoginUrl := os.Getenv("JETS_OIDC_POST_LOGIN_URL")
+	if postLoginUrl == "" {
+		JSON(w, http.StatusOK, data)
+		return
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		ERROR(w, http.StatusInternalServerError, err)
+		return
+	}
+	http.Redirect(w, r, postLoginUrl+"#login="+base64.RawURLEncoding.EncodeToString(b), http.StatusFound)
+}
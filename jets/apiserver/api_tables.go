@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -87,6 +88,13 @@ func (server *Server) DoDataTableAction(w http.ResponseWriter, r *http.Request)
 			ERROR(w, 400, err)
 			return
 		}
+		err = ctx.VerifySessionClientAccess(dataTableAction.Action, sid, token)
+		if err != nil {
+			log.Printf("Error: %v", err)
+			server.auditClientAccess(err)
+			ERROR(w, http.StatusUnauthorized, err)
+			return
+		}
 		newSessionId, err := datatable.ReserveSessionId(server.dbpool)
 		if err != nil {
 			log.Printf("Error: %v", err)
@@ -169,6 +177,8 @@ func (server *Server) DoDataTableAction(w http.ResponseWriter, r *http.Request)
 		results, code, err = ctx.DoPreviewFileAction(&dataTableAction, token)
 	case "drop_table":
 		results, code, err = ctx.DropTable(&dataTableAction, token)
+	case "pipeline_dependency_graph":
+		results, code, err = ctx.GetPipelineDependencyGraph(&dataTableAction, token)
 	case "refresh_token":
 		results = &map[string]any{}
 		code = http.StatusOK
@@ -188,6 +198,7 @@ func (server *Server) DoDataTableAction(w http.ResponseWriter, r *http.Request)
 	}
 	if err != nil {
 		log.Printf("Error: %v", err)
+		server.auditClientAccess(err)
 		ERROR(w, code, err)
 		return
 	}
@@ -201,3 +212,13 @@ func addToken(r *http.Request, results *map[string]any) {
 		(*results)["token"] = token[0]
 	}
 }
+
+// auditClientAccess logs the access denied to a client in the audit log, see user.ClientAccessError
+func (server *Server) auditClientAccess(err error) {
+	var accessErr *user.ClientAccessError
+	if errors.As(err, &accessErr) {
+		server.AuditLogger.Warn("client access denied", zap.String("user", accessErr.UserEmail),
+			zap.String("action", accessErr.Action), zap.String("client", accessErr.Client),
+			zap.String("org", accessErr.Org), zap.String("time", time.Now().Format(time.RFC3339)))
+	}
+}
@@ -74,26 +74,36 @@ func (server *Server) Login(w http.ResponseWriter, r *http.Request) {
 		ERROR(w, http.StatusUnprocessableEntity, errors.New("Invalid User or Password"))
 		return
 	}
-	jetsUser.Token, err = user.CreateToken(jetsUser.Email)
+	data, err := loginData(jetsUser)
 	if err != nil {
 		ERROR(w, http.StatusUnprocessableEntity, FormatError(err.Error()))
 		return
 	}
+	JSON(w, http.StatusOK, data)
+}
+
+// loginData creates the token of the logged in jetsUser and returns the login response
+func loginData(jetsUser *user.User) (map[string]interface{}, error) {
+	var err error
+	jetsUser.Token, err = user.CreateToken(jetsUser.Email)
+	if err != nil {
+		return nil, err
+	}
 	if globalDevMode {
 		jetsUser.DevMode = "true"
 	}
-	data := map[string]interface{}{
+	return map[string]interface{}{
 		"name":             jetsUser.Name,
 		"user_email":       jetsUser.Email,
 		"is_admin":         jetsUser.IsAdmin(),
 		"is_active":        jetsUser.IsActive,
 		"dev_mode":         jetsUser.DevMode,
 		"capabilities":     jetsUser.GetCapabilities(),
+		"client_scopes":    jetsUser.ClientScopes(),
 		"token":            jetsUser.Token,
 		"gitProfile":       jetsUser.UserGitProfile,
 		"jetstore_version": os.Getenv("JETS_VERSION"),
-	}
-	JSON(w, http.StatusOK, data)
+	}, nil
 }
 
 func IsDuplicateUserError(err string) bool {
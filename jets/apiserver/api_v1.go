@@ -0,0 +1,527 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artisoft-io/jetstore/jets/datatable"
+	"github.com/artisoft-io/jetstore/jets/user"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// Versioned REST API, resource-oriented alternative to the /dataTable action endpoint.
+// Each resource is backed by a jetsapi table:
+//   GET  /api/v1/{resource}        list, with pagination (limit, offset), sorting (sort, order)
+//                                  and filtering (equality on the resource filter columns,
+//                                  repeat the parameter for a list of values)
+//   GET  /api/v1/{resource}/{key}  get by key
+//   POST /api/v1/{resource}        create, for the resources having an insert statement
+//   GET  /api/v1/openapi.json      the OpenAPI document of the API
+// The requests are executed by the DataTableContext, the reads are authorized with the
+// capability of the resource, the creates with the capability of the insert statement.
+// The filter and key values are validated against the column type and are bound as
+// query arguments.
+
+const apiV1Prefix = "/api/v1"
+const apiV1DefaultLimit = 50
+const apiV1MaxLimit = 1000
+
+// apiV1Resource describes a resource of the /api/v1 surface
+// ReadPermission is the capability required to list and get the resource.
+// InsertTable is the key of the insert statement (see sqlInsertStmts) used to create
+// the resource, empty when the resource is read-only.
+// ColumnTypes is the type of the key and filter columns that are not text: int or bool.
+type apiV1Resource struct {
+	Name           string
+	Description    string
+	Table          string
+	KeyColumn      string
+	Columns        []string
+	Filters        []string
+	ColumnTypes    map[string]string
+	ReadPermission datatable.SqlInsertDefinition
+	InsertTable    string
+}
+
+var apiV1Resources = []*apiV1Resource{
+	{
+		Name:        "pipelines",
+		Description: "Pipeline configurations",
+		Table:       "pipeline_config",
+		KeyColumn:   "key",
+		Columns: []string{"key", "process_name", "client", "process_config_key", "main_process_input_key",
+			"merged_process_input_keys", "injected_process_input_keys", "main_object_type", "main_source_type",
+			"source_period_type", "automated", "max_rete_sessions_saved", "rule_config_json", "description",
+			"user_email", "last_update"},
+		Filters:        []string{"process_name", "client", "main_object_type", "automated"},
+		ColumnTypes:    map[string]string{"key": "int", "automated": "bool"},
+		ReadPermission: datatable.SqlInsertDefinition{Capability: "run_pipelines"},
+		InsertTable:    "pipeline_config",
+	},
+	{
+		Name:        "pipeline-executions",
+		Description: "Pipeline executions, creating a pipeline execution starts the pipeline",
+		Table:       "pipeline_execution_status",
+		KeyColumn:   "key",
+		Columns: []string{"key", "pipeline_config_key", "main_input_registry_key", "main_input_file_key",
+			"merged_input_registry_keys", "client", "process_name", "main_object_type", "input_session_id",
+			"session_id", "source_period_key", "status", "failure_details", "user_email", "start_time", "last_update"},
+		Filters:        []string{"pipeline_config_key", "client", "process_name", "session_id", "status", "user_email"},
+		ColumnTypes:    map[string]string{"key": "int", "pipeline_config_key": "int"},
+		ReadPermission: datatable.SqlInsertDefinition{Capability: "run_pipelines"},
+		InsertTable:    "pipeline_execution_status",
+	},
+	{
+		Name:        "file-keys",
+		Description: "File keys staged for loading, files are registered with /registerFileKey",
+		Table:       "file_key_staging",
+		KeyColumn:   "key",
+		Columns: []string{"key", "client", "org", "object_type", "file_key", "file_size", "source_period_key",
+			"last_update"},
+		Filters:        []string{"client", "org", "object_type", "file_key", "source_period_key"},
+		ColumnTypes:    map[string]string{"key": "int", "source_period_key": "int"},
+		ReadPermission: datatable.SqlInsertDefinition{Capability: "run_pipelines"},
+	},
+	{
+		Name:        "source-configs",
+		Description: "Source configurations of the client files",
+		Table:       "source_config",
+		KeyColumn:   "key",
+		Columns: []string{"key", "object_type", "client", "org", "automated", "table_name", "domain_keys_json",
+			"code_values_mapping_json", "input_columns_json", "input_columns_positions_csv", "input_format",
+			"compression", "input_format_data_json", "is_part_files", "schema_provider_json", "user_email",
+			"last_update"},
+		Filters:        []string{"object_type", "client", "org", "table_name", "input_format"},
+		ColumnTypes:    map[string]string{"key": "int"},
+		ReadPermission: datatable.SqlInsertDefinition{Capability: "client_config"},
+		InsertTable:    "source_config",
+	},
+	{
+		Name:        "workspaces",
+		Description: "Workspaces registry",
+		Table:       "workspace_registry",
+		KeyColumn:   "key",
+		Columns: []string{"key", "workspace_name", "workspace_uri", "workspace_branch", "feature_branch",
+			"description", "last_git_log", "status", "user_email", "last_update"},
+		Filters:        []string{"workspace_name", "workspace_branch", "status"},
+		ColumnTypes:    map[string]string{"key": "int"},
+		ReadPermission: datatable.SqlInsertDefinition{Capability: "workspace_ide"},
+		InsertTable:    "workspace_registry",
+	},
+	{
+		Name:        "users",
+		Description: "Users, reserved to the administrator, users are created with /register",
+		Table:       "users",
+		KeyColumn:   "user_email",
+		Columns:     []string{"user_email", "name", "roles", "is_active", "git_name", "git_email", "last_update"},
+		Filters:     []string{"name", "is_active"},
+		ColumnTypes: map[string]string{"is_active": "int"},
+		ReadPermission: datatable.SqlInsertDefinition{
+			AdminOnly:  true,
+			Capability: "none",
+		},
+	},
+}
+
+// apiV1Page is the response of the list routes
+type apiV1Page struct {
+	Items  []map[string]any `json:"items"`
+	Total  int              `json:"total"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+}
+
+// apiV1Store is the data access of the /api/v1 handlers, implemented by
+// datatable.DataTableContext
+type apiV1Store interface {
+	VerifyUserPermission(sqlStmt *datatable.SqlInsertDefinition, token string) (*user.User, error)
+	DoReadAction(dataTableAction *datatable.DataTableAction, token string) (*map[string]any, int, error)
+	InsertRows(dataTableAction *datatable.DataTableAction, token string) (*map[string]any, int, error)
+}
+
+// apiV1Store returns the data access of the /api/v1 handlers
+func (server *Server) apiV1Store() apiV1Store {
+	return datatable.NewDataTableContext(server.dbpool, globalDevMode, *usingSshTunnel, unitTestDir, adminEmail)
+}
+
+// addApiV1Routes adds the routes of the /api/v1 surface to the server router
+func (server *Server) addApiV1Routes() {
+	openApiOptions := OptionConfig{Origin: "",
+		AllowedMethods: "GET, OPTIONS",
+		AllowedHeaders: "Content-Type"}
+	server.Router.HandleFunc(apiV1Prefix+"/openapi.json", openApiOptions.options).Methods("OPTIONS")
+	server.Router.HandleFunc(apiV1Prefix+"/openapi.json", jsonh(corsh(server.ApiV1OpenApi))).Methods("GET")
+	server.addApiKeyRoutes()
+
+	for _, res := range apiV1Resources {
+		methods := "GET, OPTIONS"
+		if res.InsertTable != "" {
+			methods = "GET, POST, OPTIONS"
+		}
+		resourceOptions := OptionConfig{Origin: "",
+			AllowedMethods: methods,
+			AllowedHeaders: "Content-Type, Authorization"}
+		path := fmt.Sprintf("%s/%s", apiV1Prefix, res.Name)
+		server.Router.HandleFunc(path, resourceOptions.options).Methods("OPTIONS")
+		server.Router.HandleFunc(path, jsonh(corsh(authh(apiV1List(res, server.apiV1Store))))).Methods("GET")
+		server.Router.HandleFunc(path+"/{key}", resourceOptions.options).Methods("OPTIONS")
+		server.Router.HandleFunc(path+"/{key}", jsonh(corsh(authh(apiV1Get(res, server.apiV1Store))))).Methods("GET")
+		if res.InsertTable != "" {
+			server.Router.HandleFunc(path, jsonh(corsh(authh(apiV1Create(res, server.apiV1Store, server.AuditLogger))))).Methods("POST")
+		}
+	}
+}
+
+// validateValue returns an error when value is not a valid value of column
+func (res *apiV1Resource) validateValue(column, value string) error {
+	switch res.ColumnTypes[column] {
+	case "int":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("error: %s must be an integer", column)
+		}
+	case "bool":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("error: %s must be a boolean", column)
+		}
+	}
+	return nil
+}
+
+// readAction returns the read action of the resource for the query parameters of the request
+func (res *apiV1Resource) readAction(query url.Values) (*datatable.DataTableAction, error) {
+	action := &datatable.DataTableAction{
+		Action:        "read",
+		FromClauses:   []datatable.FromClause{{Schema: "jetsapi", Table: res.Table}},
+		SortColumn:    res.KeyColumn,
+		SortAscending: true,
+		Limit:         apiV1DefaultLimit,
+		Parameterized: true,
+	}
+	for _, column := range res.Columns {
+		action.Columns = append(action.Columns, datatable.Column{Column: column})
+	}
+	for param, values := range query {
+		value := values[0]
+		switch param {
+		case "token":
+			// authentication token, see user.ExtractToken
+		case "limit":
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit < 1 || limit > apiV1MaxLimit {
+				return nil, fmt.Errorf("error: limit must be between 1 and %d", apiV1MaxLimit)
+			}
+			action.Limit = limit
+		case "offset":
+			offset, err := strconv.Atoi(value)
+			if err != nil || offset < 0 {
+				return nil, fmt.Errorf("error: offset must be a positive integer")
+			}
+			action.Offset = offset
+		case "sort":
+			if !slices.Contains(res.Columns, value) || value == "roles" {
+				return nil, fmt.Errorf("error: cannot sort %s by %s", res.Name, value)
+			}
+			action.SortColumn = value
+		case "order":
+			switch value {
+			case "asc":
+				action.SortAscending = true
+			case "desc":
+				action.SortAscending = false
+			default:
+				return nil, fmt.Errorf("error: order must be asc or desc")
+			}
+		default:
+			if !slices.Contains(res.Filters, param) {
+				return nil, fmt.Errorf("error: unknown query parameter %s for %s", param, res.Name)
+			}
+			for _, v := range values {
+				if err := res.validateValue(param, v); err != nil {
+					return nil, err
+				}
+			}
+			action.WhereClauses = append(action.WhereClauses, datatable.WhereClause{
+				Column: param,
+				Values: values,
+			})
+		}
+	}
+	return action, nil
+}
+
+// readItems reads the resource with action, returns the items and the total number of rows
+func (res *apiV1Resource) readItems(ctx apiV1Store, action *datatable.DataTableAction, token string) ([]map[string]any, int, int, error) {
+	results, code, err := ctx.DoReadAction(action, token)
+	if err != nil {
+		return nil, 0, code, err
+	}
+	rows, _ := (*results)["rows"].(*[][]any)
+	total, _ := (*results)["totalRowCount"].(int)
+	items := make([]map[string]any, 0)
+	if rows != nil {
+		for _, row := range *rows {
+			item := make(map[string]any, len(res.Columns))
+			for i, column := range res.Columns {
+				item[column] = row[i]
+			}
+			items = append(items, item)
+		}
+	}
+	return items, total, http.StatusOK, nil
+}
+
+// verifyReadPermission returns an error when the user of the token cannot read res
+func (res *apiV1Resource) verifyReadPermission(ctx apiV1Store, token string) error {
+	_, err := ctx.VerifyUserPermission(&res.ReadPermission, token)
+	if err != nil {
+		log.Printf("while VerifyUserPermission for %s: %v", res.Name, err)
+		return fmt.Errorf("error: unauthorized, cannot get user info or does not have permission")
+	}
+	return nil
+}
+
+// apiV1List returns the handler listing the resource
+func apiV1List(res *apiV1Resource, newStore func() apiV1Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := user.ExtractToken(r)
+		ctx := newStore()
+		err := res.verifyReadPermission(ctx, token)
+		if err != nil {
+			ERROR(w, http.StatusUnauthorized, err)
+			return
+		}
+		action, err := res.readAction(r.URL.Query())
+		if err != nil {
+			ERROR(w, http.StatusBadRequest, err)
+			return
+		}
+		items, total, code, err := res.readItems(ctx, action, token)
+		if err != nil {
+			log.Printf("Error: %v", err)
+			ERROR(w, code, err)
+			return
+		}
+		JSON(w, http.StatusOK, apiV1Page{
+			Items:  items,
+			Total:  total,
+			Limit:  action.Limit,
+			Offset: action.Offset,
+		})
+	}
+}
+
+// apiV1Get returns the handler getting the resource by key
+func apiV1Get(res *apiV1Resource, newStore func() apiV1Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := user.ExtractToken(r)
+		ctx := newStore()
+		err := res.verifyReadPermission(ctx, token)
+		if err != nil {
+			ERROR(w, http.StatusUnauthorized, err)
+			return
+		}
+		action, err := res.readAction(url.Values{})
+		if err != nil {
+			ERROR(w, http.StatusBadRequest, err)
+			return
+		}
+		key := mux.Vars(r)["key"]
+		if err = res.validateValue(res.KeyColumn, key); err != nil {
+			ERROR(w, http.StatusBadRequest, err)
+			return
+		}
+		action.Limit = 1
+		action.WhereClauses = []datatable.WhereClause{{
+			Column: res.KeyColumn,
+			Values: []string{key},
+		}}
+		items, _, code, err := res.readItems(ctx, action, token)
+		if err != nil {
+			log.Printf("Error: %v", err)
+			ERROR(w, code, err)
+			return
+		}
+		if len(items) == 0 {
+			ERROR(w, http.StatusNotFound, fmt.Errorf("error: %s %s not found", res.Name, key))
+			return
+		}
+		JSON(w, http.StatusOK, items[0])
+	}
+}
+
+// apiV1Create returns the handler creating the resource from the json object in the request body
+func apiV1Create(res *apiV1Resource, newStore func() apiV1Store, auditLogger *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			ERROR(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		token := user.ExtractToken(r)
+		userEmail, _ := user.ExtractTokenID(token)
+		auditLogger.Info(string(body), zap.String("user", userEmail), zap.String("resource", res.Name),
+			zap.String("time", time.Now().Format(time.RFC3339)))
+		item := make(map[string]any)
+		err = json.Unmarshal(body, &item)
+		if err != nil {
+			ERROR(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		item["user_email"] = userEmail
+		action := &datatable.DataTableAction{
+			Action:      "insert_rows",
+			FromClauses: []datatable.FromClause{{Schema: "jetsapi", Table: res.InsertTable}},
+			Data:        []map[string]any{item},
+		}
+		results, code, err := newStore().InsertRows(action, token)
+		if err != nil {
+			log.Printf("Error: %v", err)
+			ERROR(w, code, err)
+			return
+		}
+		response := map[string]any{}
+		if keys, ok := (*results)["returned_keys"].(*[]int); ok && len(*keys) > 0 && (*keys)[0] > 0 {
+			response["key"] = (*keys)[0]
+		}
+		JSON(w, http.StatusCreated, response)
+	}
+}
+
+// ApiV1OpenApi returns the OpenAPI document of the /api/v1 surface
+func (server *Server) ApiV1OpenApi(w http.ResponseWriter, r *http.Request) {
+	JSON(w, http.StatusOK, apiV1OpenApiDocument())
+}
+
+// apiV1OpenApiDocument builds the OpenAPI document from the resource definitions
+func apiV1OpenApiDocument() map[string]any {
+	errorResponse := func(description string) map[string]any {
+		return map[string]any{
+			"description": description,
+			"content": map[string]any{"application/json": map[string]any{
+				"schema": map[string]any{"$ref": "#/components/schemas/Error"}}},
+		}
+	}
+	queryParam := func(name, description, typ string) map[string]any {
+		return map[string]any{
+			"name": name, "in": "query", "required": false, "description": description,
+			"schema": map[string]any{"type": typ},
+		}
+	}
+	schemas := map[string]any{
+		"Error": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"error": map[string]any{"type": "string"}},
+		},
+	}
+	paths := map[string]any{}
+	for _, res := range apiV1Resources {
+		schemaName := strings.ReplaceAll(res.Name, "-", "_")
+		properties := map[string]any{}
+		for _, column := range res.Columns {
+			properties[column] = map[string]any{"type": "string", "nullable": true}
+		}
+		schemas[schemaName] = map[string]any{"type": "object", "properties": properties}
+		itemRef := map[string]any{"$ref": "#/components/schemas/" + schemaName}
+
+		parameters := []any{
+			queryParam("limit", fmt.Sprintf("Maximum number of items, default %d, max %d", apiV1DefaultLimit, apiV1MaxLimit), "integer"),
+			queryParam("offset", "Number of items to skip", "integer"),
+			queryParam("sort", "Column to sort by, default "+res.KeyColumn, "string"),
+			queryParam("order", "Sort order: asc or desc", "string"),
+		}
+		for _, filter := range res.Filters {
+			parameters = append(parameters, queryParam(filter, "Filter on "+filter+", repeat the parameter for a list of values", "string"))
+		}
+		collection := map[string]any{
+			"get": map[string]any{
+				"summary":     "List " + res.Description,
+				"operationId": "list_" + schemaName,
+				"tags":        []string{res.Name},
+				"parameters":  parameters,
+				"responses": map[string]any{
+					"200": map[string]any{
+						"description": "A page of " + res.Name,
+						"content": map[string]any{"application/json": map[string]any{
+							"schema": map[string]any{
+								"type": "object",
+								"properties": map[string]any{
+									"items":  map[string]any{"type": "array", "items": itemRef},
+									"total":  map[string]any{"type": "integer"},
+									"limit":  map[string]any{"type": "integer"},
+									"offset": map[string]any{"type": "integer"},
+								},
+							}}},
+					},
+					"400": errorResponse("Invalid query parameter"),
+					"401": errorResponse("Unauthorized"),
+				},
+			},
+		}
+		if res.InsertTable != "" {
+			collection["post"] = map[string]any{
+				"summary":     "Create " + res.Description,
+				"operationId": "create_" + schemaName,
+				"tags":        []string{res.Name},
+				"requestBody": map[string]any{
+					"required": true,
+					"content":  map[string]any{"application/json": map[string]any{"schema": itemRef}},
+				},
+				"responses": map[string]any{
+					"201": map[string]any{
+						"description": "Created",
+						"content": map[string]any{"application/json": map[string]any{
+							"schema": map[string]any{
+								"type":       "object",
+								"properties": map[string]any{"key": map[string]any{"type": "integer"}},
+							}}},
+					},
+					"401": errorResponse("Unauthorized"),
+					"409": errorResponse("Duplicate key value"),
+				},
+			}
+		}
+		paths[fmt.Sprintf("%s/%s", apiV1Prefix, res.Name)] = collection
+		paths[fmt.Sprintf("%s/%s/{key}", apiV1Prefix, res.Name)] = map[string]any{
+			"get": map[string]any{
+				"summary":     "Get " + res.Description + " by " + res.KeyColumn,
+				"operationId": "get_" + schemaName,
+				"tags":        []string{res.Name},
+				"parameters": []any{map[string]any{
+					"name": "key", "in": "path", "required": true, "schema": map[string]any{"type": "string"},
+				}},
+				"responses": map[string]any{
+					"200": map[string]any{
+						"description": "The " + res.Name + " item",
+						"content":     map[string]any{"application/json": map[string]any{"schema": itemRef}},
+					},
+					"401": errorResponse("Unauthorized"),
+					"404": errorResponse("Not found"),
+				},
+			},
+		}
+	}
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "JetStore API",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+		"security": []any{map[string]any{"bearerAuth": []string{}}},
+	}
+}
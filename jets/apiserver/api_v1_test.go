@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/artisoft-io/jetstore/jets/datatable"
+	"github.com/artisoft-io/jetstore/jets/user"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// fakeApiV1Store records the actions of the /api/v1 handlers
+type fakeApiV1Store struct {
+	permissionErr error
+	rows          [][]any
+	insertedKey   int
+	readActions   []*datatable.DataTableAction
+	insertActions []*datatable.DataTableAction
+}
+
+func (s *fakeApiV1Store) VerifyUserPermission(sqlStmt *datatable.SqlInsertDefinition, token string) (*user.User, error) {
+	if s.permissionErr != nil {
+		return nil, s.permissionErr
+	}
+	return user.NewUser("user@client.com"), nil
+}
+
+func (s *fakeApiV1Store) DoReadAction(action *datatable.DataTableAction, token string) (*map[string]any, int, error) {
+	s.readActions = append(s.readActions, action)
+	rows := s.rows
+	return &map[string]any{"rows": &rows, "totalRowCount": len(rows) + action.Offset}, http.StatusOK, nil
+}
+
+func (s *fakeApiV1Store) InsertRows(action *datatable.DataTableAction, token string) (*map[string]any, int, error) {
+	s.insertActions = append(s.insertActions, action)
+	keys := []int{s.insertedKey}
+	return &map[string]any{"returned_keys": &keys}, http.StatusOK, nil
+}
+
+func (s *fakeApiV1Store) newStore() apiV1Store {
+	return s
+}
+
+func apiV1TestResource(t *testing.T, name string) *apiV1Resource {
+	for _, res := range apiV1Resources {
+		if res.Name == name {
+			return res
+		}
+	}
+	t.Fatalf("unknown resource %s", name)
+	return nil
+}
+
+// apiV1TestRow returns a row of res with the values of the first columns
+func apiV1TestRow(res *apiV1Resource, values ...any) []any {
+	row := make([]any, len(res.Columns))
+	copy(row, values)
+	return row
+}
+
+func serveApiV1(handler http.HandlerFunc, req *http.Request) (int, map[string]any) {
+	w := httptest.NewRecorder()
+	handler(w, req)
+	body := make(map[string]any)
+	json.Unmarshal(w.Body.Bytes(), &body)
+	return w.Code, body
+}
+
+func TestApiV1List(t *testing.T) {
+	res := apiV1TestResource(t, "pipelines")
+	store := &fakeApiV1Store{rows: [][]any{
+		apiV1TestRow(res, "1", "load", "ACME"),
+		apiV1TestRow(res, "2", "load", "Globex"),
+	}}
+	req := httptest.NewRequest("GET",
+		"/api/v1/pipelines?client=ACME&client=O'Neil&automated=true&limit=2&offset=4&sort=process_name&order=desc", nil)
+	code, body := serveApiV1(apiV1List(res, store.newStore), req)
+	if code != http.StatusOK {
+		t.Fatalf("expecting 200, got %d: %v", code, body)
+	}
+	if body["total"] != float64(6) || body["limit"] != float64(2) || body["offset"] != float64(4) {
+		t.Errorf("unexpected page %v", body)
+	}
+	items, _ := body["items"].([]any)
+	if len(items) != 2 || items[1].(map[string]any)["client"] != "Globex" {
+		t.Errorf("unexpected items %v", items)
+	}
+
+	action := store.readActions[0]
+	if !action.Parameterized || action.Limit != 2 || action.Offset != 4 ||
+		action.SortColumn != "process_name" || action.SortAscending {
+		t.Errorf("unexpected read action %+v", action)
+	}
+	filters := make(map[string][]string)
+	for _, wc := range action.WhereClauses {
+		filters[wc.Column] = wc.Values
+	}
+	expected := map[string][]string{"client": {"ACME", "O'Neil"}, "automated": {"true"}}
+	if !reflect.DeepEqual(filters, expected) {
+		t.Errorf("expecting filters %v, got %v", expected, filters)
+	}
+}
+
+func TestApiV1List_Defaults(t *testing.T) {
+	res := apiV1TestResource(t, "users")
+	store := &fakeApiV1Store{}
+	code, body := serveApiV1(apiV1List(res, store.newStore), httptest.NewRequest("GET", "/api/v1/users?token=abc", nil))
+	if code != http.StatusOK {
+		t.Fatalf("expecting 200, got %d: %v", code, body)
+	}
+	action := store.readActions[0]
+	if action.Limit != apiV1DefaultLimit || action.Offset != 0 || action.SortColumn != "user_email" ||
+		!action.SortAscending || len(action.WhereClauses) != 0 {
+		t.Errorf("unexpected default read action %+v", action)
+	}
+	if items, ok := body["items"].([]any); !ok || len(items) != 0 {
+		t.Errorf("expecting an empty list of items, got %v", body["items"])
+	}
+}
+
+func TestApiV1List_InvalidParameters(t *testing.T) {
+	tests := []struct {
+		resource string
+		query    string
+		errText  string
+	}{
+		{"pipelines", "limit=0", "limit must be between"},
+		{"pipelines", "limit=1001", "limit must be between"},
+		{"pipelines", "limit=ten", "limit must be between"},
+		{"pipelines", "offset=-1", "offset must be"},
+		{"pipelines", "sort=password", "cannot sort pipelines by password"},
+		{"users", "sort=roles", "cannot sort users by roles"},
+		{"pipelines", "order=up", "order must be asc or desc"},
+		{"pipelines", "password=x", "unknown query parameter password"},
+		{"users", "user_email=admin", "unknown query parameter user_email"},
+		{"pipelines", "automated=maybe", "automated must be a boolean"},
+		{"pipeline-executions", "pipeline_config_key=1&pipeline_config_key=1%27%20OR%201=1", "pipeline_config_key must be an integer"},
+		{"users", "is_active=yes", "is_active must be an integer"},
+	}
+	for _, tt := range tests {
+		res := apiV1TestResource(t, tt.resource)
+		store := &fakeApiV1Store{}
+		req := httptest.NewRequest("GET", "/api/v1/"+tt.resource+"?"+tt.query, nil)
+		code, body := serveApiV1(apiV1List(res, store.newStore), req)
+		errText, _ := body["error"].(string)
+		if code != http.StatusBadRequest || !strings.Contains(errText, tt.errText) {
+			t.Errorf("%s?%s: expecting 400 with %q, got %d: %v", tt.resource, tt.query, tt.errText, code, body)
+		}
+		if len(store.readActions) > 0 {
+			t.Errorf("%s?%s: expecting no read", tt.resource, tt.query)
+		}
+	}
+}
+
+func TestApiV1List_Unauthorized(t *testing.T) {
+	res := apiV1TestResource(t, "users")
+	store := &fakeApiV1Store{permissionErr: errors.New("error: unauthorized, only admin can perform statement")}
+	code, _ := serveApiV1(apiV1List(res, store.newStore), httptest.NewRequest("GET", "/api/v1/users", nil))
+	if code != http.StatusUnauthorized || len(store.readActions) > 0 {
+		t.Errorf("expecting 401 without read, got %d", code)
+	}
+}
+
+func TestApiV1Get(t *testing.T) {
+	res := apiV1TestResource(t, "workspaces")
+	store := &fakeApiV1Store{rows: [][]any{apiV1TestRow(res, "3", "ws1")}}
+	req := mux.SetURLVars(httptest.NewRequest("GET", "/api/v1/workspaces/3", nil), map[string]string{"key": "3"})
+	code, body := serveApiV1(apiV1Get(res, store.newStore), req)
+	if code != http.StatusOK || body["key"] != "3" || body["workspace_name"] != "ws1" {
+		t.Fatalf("expecting workspace 3, got %d: %v", code, body)
+	}
+	action := store.readActions[0]
+	expected := []datatable.WhereClause{{Column: "key", Values: []string{"3"}}}
+	if !action.Parameterized || action.Limit != 1 || !reflect.DeepEqual(action.WhereClauses, expected) {
+		t.Errorf("unexpected read action %+v", action)
+	}
+
+	// Not found
+	store = &fakeApiV1Store{}
+	code, _ = serveApiV1(apiV1Get(res, store.newStore), req)
+	if code != http.StatusNotFound {
+		t.Errorf("expecting 404, got %d", code)
+	}
+
+	// Key of the wrong type
+	req = mux.SetURLVars(httptest.NewRequest("GET", "/api/v1/workspaces/x", nil), map[string]string{"key": "3' OR '1'='1"})
+	code, _ = serveApiV1(apiV1Get(res, store.newStore), req)
+	if code != http.StatusBadRequest || len(store.readActions) > 1 {
+		t.Errorf("expecting 400 without read, got %d", code)
+	}
+
+	// Text key is bound as is
+	res = apiV1TestResource(t, "users")
+	store = &fakeApiV1Store{rows: [][]any{apiV1TestRow(res, "o'neil@client.com")}}
+	req = mux.SetURLVars(httptest.NewRequest("GET", "/api/v1/users/x", nil), map[string]string{"key": "o'neil@client.com"})
+	code, _ = serveApiV1(apiV1Get(res, store.newStore), req)
+	if code != http.StatusOK || store.readActions[0].WhereClauses[0].Values[0] != "o'neil@client.com" {
+		t.Errorf("expecting the user by email, got %d: %+v", code, store.readActions[0].WhereClauses)
+	}
+}
+
+func TestApiV1Create(t *testing.T) {
+	user.ApiSecret = "api_v1_test"
+	user.TokenExpiration = 5
+	token, err := user.CreateToken("user@client.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := apiV1TestResource(t, "pipelines")
+	store := &fakeApiV1Store{insertedKey: 7}
+	handler := apiV1Create(res, store.newStore, zap.NewNop())
+
+	req := httptest.NewRequest("POST", "/api/v1/pipelines", strings.NewReader(`{"process_name": "load", "client": "ACME"}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	code, body := serveApiV1(handler, req)
+	if code != http.StatusCreated || body["key"] != float64(7) {
+		t.Fatalf("expecting 201 with key 7, got %d: %v", code, body)
+	}
+	action := store.insertActions[0]
+	expected := []map[string]any{{"process_name": "load", "client": "ACME", "user_email": "user@client.com"}}
+	if action.Action != "insert_rows" || action.FromClauses[0].Table != "pipeline_config" ||
+		!reflect.DeepEqual(action.Data, expected) {
+		t.Errorf("unexpected insert action %+v", action)
+	}
+
+	// Invalid json
+	req = httptest.NewRequest("POST", "/api/v1/pipelines", strings.NewReader(`{"process_name": `))
+	req.Header.Set("Authorization", "Bearer "+token)
+	code, _ = serveApiV1(handler, req)
+	if code != http.StatusUnprocessableEntity || len(store.insertActions) > 1 {
+		t.Errorf("expecting 422 without insert, got %d", code)
+	}
+}
@@ -44,6 +44,24 @@ func jsonh(next http.HandlerFunc) http.HandlerFunc {
 // Middleware Function for validating jwt token
 func authh(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// API key of a service account, exchanged for a token of the service account
+		if apiKey := user.ExtractToken(r); user.IsApiKey(apiKey) {
+			key, err := user.ValidateApiKey(server.dbpool, apiKey)
+			if err != nil {
+				log.Println("authh for", r.URL.Path, ":", err)
+				ERROR(w, http.StatusUnauthorized, errors.New("Unauthorized"))
+				return
+			}
+			token, err := user.CreateApiKeyToken(key)
+			if err != nil {
+				ERROR(w, http.StatusInternalServerError, errors.New("TokenGenError"))
+				return
+			}
+			setRequestToken(r, token)
+			r.Header["Token"] = []string{token}
+			next(w, r)
+			return
+		}
 		user_id, err := user.TokenValid(r)
 		if err != nil {
 			// DEV
@@ -64,6 +82,17 @@ func authh(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// setRequestToken replaces the credential of the request with token, where user.ExtractToken
+// reads it, so the handlers get the token of the service account rather than its api key
+func setRequestToken(r *http.Request, token string) {
+	query := r.URL.Query()
+	if query.Get("token") != "" {
+		query.Set("token", token)
+		r.URL.RawQuery = query.Encode()
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+}
+
 // Middleware Function for allowing selected cors client
 func corsh(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -508,6 +537,11 @@ func listenAndServe() error {
 	server.Router.HandleFunc("/login", loginOptions.options).Methods("OPTIONS")
 	server.Router.HandleFunc("/login", jsonh(corsh(server.Login))).Methods("POST")
 
+	// Single sign-on routes
+	if err = server.addOidcRoutes(); err != nil {
+		return fmt.Errorf("while configuring sso: %v", err)
+	}
+
 	// Register route
 	registerOptions := OptionConfig{Origin: "",
 		AllowedMethods: "POST, OPTIONS",
@@ -536,6 +570,9 @@ func listenAndServe() error {
 	server.Router.HandleFunc("/purgeData", purgeDataOptions.options).Methods("OPTIONS")
 	server.Router.HandleFunc("/purgeData", jsonh(corsh(authh(server.DoPurgeDataAction)))).Methods("POST")
 
+	// Versioned REST API routes
+	server.addApiV1Routes()
+
 	// //* Currently not used
 	// //* TODO add options and corrs check - Users routes
 	// // server.Router.HandleFunc("/register", jsonh(server.CreateUser)).Methods("POST")
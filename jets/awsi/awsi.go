@@ -407,9 +407,9 @@ func NewDownloader(region string) (*manager.Downloader, error) {
 	return manager.NewDownloader(s3Client), nil
 }
 
-// Use a shared Downloader to download obj from s3 into fileHd (must be writable), return size of download in bytes
-func DownloadFromS3v2(downloader *manager.Downloader, bucket, objKey string, byteRange *string, fileHd *os.File) (int64, error) {
-	nsz, err := downloader.Download(context.TODO(), fileHd, &s3.GetObjectInput{Bucket: &bucket, Key: &objKey, Range: byteRange})
+// Use a shared Downloader to download obj from s3 into w (e.g. a writable *os.File), return size of download in bytes
+func DownloadFromS3v2(downloader *manager.Downloader, bucket, objKey string, byteRange *string, w io.WriterAt) (int64, error) {
+	nsz, err := downloader.Download(context.TODO(), w, &s3.GetObjectInput{Bucket: &bucket, Key: &objKey, Range: byteRange})
 	if err != nil {
 		return 0, fmt.Errorf("failed to download file from s3 bucket '%s': %v", bucket, err)
 	}
@@ -454,21 +454,38 @@ func UploadToS3(bucket, region, objKey string, fileHd *os.File) error {
 
 // upload object to S3, reading the obj from reader (from current position to EOF)
 func UploadToS3FromReader(externalBucket, objKey string, reader io.Reader) error {
-	s3Client, err := NewS3Client()
+	uploader, err := NewUploader()
 	if err != nil {
-		return fmt.Errorf("while creating s3 client: %v", err)
+		return err
 	}
+	return UploadToS3WithUploader(uploader, externalBucket, objKey, reader)
+}
 
-	// check if we write to an external bucket
-	if externalBucket == "" {
-		externalBucket = jetstoreOwnBucket
+// NewUploader creates an uploader with 64MB parts and a concurrency of 10
+func NewUploader() (*manager.Uploader, error) {
+	s3Client, err := NewS3Client()
+	if err != nil {
+		return nil, fmt.Errorf("while creating s3 client: %v", err)
 	}
+	return NewUploaderWithClient(s3Client), nil
+}
 
+// NewUploaderWithClient creates an uploader using s3Client with 64MB parts and a concurrency of 10
+func NewUploaderWithClient(s3Client *s3.Client) *manager.Uploader {
 	// Create an uploader with the client and custom options
-	uploader := manager.NewUploader(s3Client, func(u *manager.Uploader) {
+	return manager.NewUploader(s3Client, func(u *manager.Uploader) {
 		u.PartSize = 64 * 1024 * 1024 // 64MB per part
 		u.Concurrency = 10
 	})
+}
+
+// Use a shared Uploader to upload object to S3, reading the obj from reader (from current position to EOF)
+func UploadToS3WithUploader(uploader *manager.Uploader, externalBucket, objKey string, reader io.Reader) error {
+	// check if we write to an external bucket
+	if externalBucket == "" {
+		externalBucket = jetstoreOwnBucket
+	}
+	var err error
 	retry := 0
 do_retry:
 	putObjInput := &s3.PutObjectInput{
@@ -492,6 +509,23 @@ do_retry:
 	return nil
 }
 
+// DeleteS3Object deletes the object from the bucket, deleting a key that
+// does not exist is not an error.
+// Delete from externalBucket if not empty, otherwise from jetstore default bucket
+func DeleteS3Object(s3Client *s3.Client, externalBucket, objKey string) error {
+	if externalBucket == "" {
+		externalBucket = jetstoreOwnBucket
+	}
+	_, err := s3Client.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
+		Bucket: &externalBucket,
+		Key:    &objKey,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete s3 file 's3://%s/%s': %v", externalBucket, objKey, err)
+	}
+	return nil
+}
+
 // upload buf to S3, reading the obj from in-memory buffer
 func UploadBufToS3(bucket, objKey string, buf []byte) error {
 	return UploadToS3FromReader(bucket, objKey, bytes.NewReader(buf))
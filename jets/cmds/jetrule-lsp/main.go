@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/artisoft-io/jetstore/jets/compilerv2/lsp"
+)
+
+// Language server for the JetRule files, using compilerv2.
+// The server communicates with the editor using stdin / stdout,
+// the import statements are relative to the workspace root folder.
+
+// Command Line Arguments
+// --------------------------------------------------------------------------------------
+var logFile = flag.String("log", "", "Log file (optional), stdout is reserved for the protocol")
+var autoAddResources = flag.Bool("a", false, "Enable automatic resource addition when an identifier is not defined")
+
+func main() {
+	flag.Parse()
+	if *logFile != "" {
+		f, err := os.OpenFile(*logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			log.Fatalf("while opening log file: %v", err)
+		}
+		defer f.Close()
+		log.SetOutput(f)
+	} else {
+		log.SetOutput(io.Discard)
+	}
+	log.Println("Starting jetrule-lsp, autoAddResources:", *autoAddResources)
+	err := lsp.NewServer(os.Stdin, os.Stdout, *autoAddResources).Run()
+	if err != nil {
+		log.Printf("jetrule-lsp terminated with error: %v", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/artisoft-io/jetstore/jets/compilerv2/formatter"
+)
+
+// Utility to format the JetRule files (.jr) in the canonical layout.
+// Usage: jrfmt [-w | -check] [-s] <file or directory>...
+// The directories are searched recursively for .jr files.
+// Without -w or -check, the formatted rule file is written to stdout.
+
+// Command Line Arguments
+// --------------------------------------------------------------------------------------
+var write = flag.Bool("w", false, "Write the formatted rule files in place")
+var check = flag.Bool("check", false, "List the rule files that are not formatted and exit with status 1 when there are any")
+var sortDataProperties = flag.Bool("s", false, "Sort the class data properties by name")
+
+func main() {
+	flag.Parse()
+	if flag.NArg() == 0 {
+		panic("Must provide at least one .jr file or directory")
+	}
+	if *write && *check {
+		panic("Must provide only one of -w or -check")
+	}
+	var fileNames []string
+	for _, path := range flag.Args() {
+		names, err := findRuleFiles(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fileNames = append(fileNames, names...)
+	}
+	options := &formatter.Options{SortDataProperties: *sortDataProperties}
+	hasErr := false
+	notFormatted := 0
+	for _, fileName := range fileNames {
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		source := string(data)
+		formatted, err := formatter.Format(source, options)
+		if err != nil {
+			log.Printf("while formatting %s: %v", fileName, err)
+			hasErr = true
+			continue
+		}
+		switch {
+		case *check:
+			if formatted != source {
+				fmt.Println(fileName)
+				notFormatted++
+			}
+		case *write:
+			if formatted != source {
+				err = os.WriteFile(fileName, []byte(formatted), 0644)
+				if err != nil {
+					log.Fatalf("while writing %s: %v", fileName, err)
+				}
+			}
+		default:
+			fmt.Print(formatted)
+		}
+	}
+	if hasErr || notFormatted > 0 {
+		os.Exit(1)
+	}
+}
+
+// findRuleFiles returns the .jr files of path
+func findRuleFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	var fileNames []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(p, ".jr") {
+			fileNames = append(fileNames, p)
+		}
+		return nil
+	})
+	return fileNames, err
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/jrtest"
+	"github.com/artisoft-io/jetstore/jets/jetrules/rete"
+)
+
+// Utility to run the JetRule unit tests (.jrtest files) with the go rete engine.
+// The main rule files are compiled with compilerv2, no database is needed.
+// The main_rule_file of the .jrtest files are relative to the workspace path.
+
+// Env variable:
+// WORKSPACES_HOME Home dir of workspaces
+// WORKSPACE Workspace currently in use
+// Note: the workspace control is read from workspace_control.json of the workspace path when present.
+// Note: the lookup tables are read from lookup.db of the workspace path.
+
+// Command Line Arguments
+// --------------------------------------------------------------------------------------
+var testPath = flag.String("f", "", ".jrtest file or directory containing .jrtest files (required)")
+var workspacePath = flag.String("w", "", "Workspace path, default is $WORKSPACES_HOME/$WORKSPACE")
+var junitPath = flag.String("junit", "", "Write the JUnit xml report to this file (optional)")
+var trace = flag.Bool("t", false, "Enable compiler trace logging")
+var autoAddResources = flag.Bool("a", false, "Enable automatic resource addition when an identifier is not defined")
+
+func main() {
+	flag.Parse()
+	if *testPath == "" {
+		panic("Must provide -f .jrtest file or directory")
+	}
+	if *workspacePath == "" {
+		*workspacePath = fmt.Sprintf("%s/%s", os.Getenv("WORKSPACES_HOME"), os.Getenv("WORKSPACE"))
+	}
+	fileNames, err := findTestFiles(*testPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(fileNames) == 0 {
+		log.Fatalf("No .jrtest files found in %s", *testPath)
+	}
+
+	// Compile the main rule files using compilerv2
+	runner := jrtest.NewRunner(jrtest.CompilerModelLoader(*workspacePath, *trace, *autoAddResources))
+	runner.SetLookupDbPath(filepath.Join(*workspacePath, "lookup.db"))
+
+	wcPath := filepath.Join(*workspacePath, "workspace_control.json")
+	if _, err := os.Stat(wcPath); err == nil {
+		workspaceControl, err := rete.LoadWorkspaceControl(wcPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		runner.SetWorkspaceControl(workspaceControl)
+	}
+
+	var suites []*jrtest.SuiteResult
+	for _, fileName := range fileNames {
+		testFile, err := jrtest.LoadTestFile(fileName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		suites = append(suites, runner.RunFile(testFile))
+	}
+
+	passed := jrtest.WriteSummary(os.Stdout, suites)
+	if *junitPath != "" {
+		f, err := os.Create(*junitPath)
+		if err != nil {
+			log.Fatalf("while creating junit report file: %v", err)
+		}
+		err = jrtest.WriteJUnitReport(f, suites)
+		f.Close()
+		if err != nil {
+			log.Fatalf("while writing junit report: %v", err)
+		}
+	}
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+// findTestFiles returns the .jrtest files of path, sorted by name
+func findTestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	var fileNames []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(p, ".jrtest") {
+			fileNames = append(fileNames, p)
+		}
+		return nil
+	})
+	return fileNames, err
+}
@@ -187,7 +187,7 @@ columnDefinitions:
 // --------------------------------------------------------------------------------------
 jetRuleStmt: '[' ruleName=Identifier ruleProperties* ']' ':' 
     COMMENT*
-    (antecedent COMMENT*)+ 
+    ((antecedent | orAntecedent) COMMENT*)+ 
     '->' 
     COMMENT*
     (consequent COMMENT*)+
@@ -196,8 +196,21 @@ jetRuleStmt: '[' ruleName=Identifier ruleProperties* ']' ':'
 ruleProperties: ',' key=Identifier ASSIGN valCtx=propertyValue ;
 propertyValue: ( val=STRING | val=TRUE | val=FALSE | intval=intExpr ) ;
 
-antecedent: n=NOT? '(' s=atom p=atom o=objectAtom ')' '.'? ( '[' f=exprTerm ']' '.'? )? ;
-consequent: '(' s=atom p=atom o=exprTerm ')' '.'? ;
+// An aggregation antecedent binds the aggregate value to a variable,
+// e.g. (?n = count(?line where (?c hc:has_line ?line)))
+antecedent: n=NOT? '(' (s=atom p=atom o=objectAtom | v=atom ASSIGN agg=aggregateTerm) ')' '.'? ( '[' f=exprTerm ']' '.'? )? ;
+
+// Disjunction of antecedents, e.g. or { (?c hc:code "A") } { (?c hc:alt_code "A") }
+// the rule is expanded into one rule per branch
+orAntecedent: OR orBranch orBranch+ '.'? ;
+orBranch: '{' COMMENT* (antecedent COMMENT*)+ '}' ;
+
+consequent: '(' s=atom p=atom (o=exprTerm | agg=aggregateTerm) ')' '.'? ;
+
+// Aggregate term of an aggregation antecedent or of a consequent,
+// e.g. count(?line where (?c hc:has_line ?line))
+// where is a contextual keyword, it is checked by the listener and remains a valid identifier
+aggregateTerm: op=Identifier '(' aggVar=atom kw=Identifier '(' s=atom p=atom o=objectAtom ')' ')' ;
 
 atom
   : '?' Identifier
@@ -0,0 +1,163 @@
+package compiler
+
+import (
+	_ "embed"
+
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"runtime/debug"
+	"slices"
+)
+
+// This file contains the build cache used for the incremental compilation of a workspace.
+// The cache records, for each main rule file, the content hash of the rule files it
+// reads (the main rule file and all its imports) and the compiler options. A main rule
+// file is up to date when none of these files changed, it does not need to be recompiled.
+// The cache also records the hash of each lookup table, i.e. the hash of its definition
+// and of its csv file, so the lookup tables are repackaged only when they change.
+// The cache is recorded with the version of the compiler, a new compiler or grammar
+// invalidates the whole cache.
+
+// buildCacheVersion is changed when the compiled model changes, to invalidate the cache
+const buildCacheVersion = 1
+
+//go:embed JetRule.g4
+var jetRuleGrammar string
+
+// CompilerVersion identifies the compiler recorded in the build cache: the hash of the
+// cache version, the JetRule grammar and the vcs revision of the binary when available
+var CompilerVersion = compilerVersion()
+
+func compilerVersion() string {
+	revision := ""
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" || setting.Key == "vcs.modified" {
+				revision += setting.Value
+			}
+		}
+	}
+	return ContentHash(fmt.Sprintf("%d|%s|%s", buildCacheVersion, jetRuleGrammar, revision))
+}
+
+type BuildCache struct {
+	Version         int                      `json:"version"`
+	CompilerVersion string                   `json:"compiler_version"`
+	RuleSets        map[string]*RuleSetBuild `json:"rule_sets"`
+	LookupTables    map[string]string        `json:"lookup_tables"`
+}
+
+// RuleSetBuild is the build information of a main rule file: the compiler options
+// and the content hash of the files read by the compiler, by file name
+type RuleSetBuild struct {
+	Options    string            `json:"options"`
+	FileHashes map[string]string `json:"file_hashes"`
+}
+
+func NewBuildCache() *BuildCache {
+	return &BuildCache{
+		Version:         buildCacheVersion,
+		CompilerVersion: CompilerVersion,
+		RuleSets:        make(map[string]*RuleSetBuild),
+		LookupTables:    make(map[string]string),
+	}
+}
+
+// LoadBuildCache reads the build cache at fpath, returns an empty cache when the file
+// does not exist or is not a valid cache of the current version and compiler
+func LoadBuildCache(fpath string) *BuildCache {
+	data, err := os.ReadFile(fpath)
+	if err != nil {
+		return NewBuildCache()
+	}
+	bc := NewBuildCache()
+	if err = json.Unmarshal(data, bc); err != nil || bc.Version != buildCacheVersion ||
+		bc.CompilerVersion != CompilerVersion {
+		return NewBuildCache()
+	}
+	if bc.RuleSets == nil {
+		bc.RuleSets = make(map[string]*RuleSetBuild)
+	}
+	if bc.LookupTables == nil {
+		bc.LookupTables = make(map[string]string)
+	}
+	return bc
+}
+
+// Save writes the build cache to fpath
+func (bc *BuildCache) Save(fpath string) error {
+	data, err := json.Marshal(bc)
+	if err != nil {
+		return fmt.Errorf("while converting build cache to json: %v", err)
+	}
+	err = os.WriteFile(fpath, data, 0644)
+	if err != nil {
+		return fmt.Errorf("while saving build cache: %v", err)
+	}
+	return nil
+}
+
+// IsUpToDate returns true when mainRuleFileName was compiled with options and none
+// of the files it read changed since. hashFile returns the content hash of a file,
+// by file name relative to the workspace.
+func (bc *BuildCache) IsUpToDate(mainRuleFileName, options string, hashFile func(fileName string) (string, error)) bool {
+	build := bc.RuleSets[mainRuleFileName]
+	if build == nil || build.Options != options || len(build.FileHashes) == 0 {
+		return false
+	}
+	for fileName, hash := range build.FileHashes {
+		h, err := hashFile(fileName)
+		if err != nil || h != hash {
+			return false
+		}
+	}
+	return true
+}
+
+// SetRuleSet records the build of mainRuleFileName, fileHashes is Compiler.SourceFileHashes
+func (bc *BuildCache) SetRuleSet(mainRuleFileName, options string, fileHashes map[string]string) {
+	bc.RuleSets[mainRuleFileName] = &RuleSetBuild{
+		Options:    options,
+		FileHashes: maps.Clone(fileHashes),
+	}
+}
+
+// RemoveRuleSet removes the build of mainRuleFileName, e.g. when the compilation failed
+func (bc *BuildCache) RemoveRuleSet(mainRuleFileName string) {
+	delete(bc.RuleSets, mainRuleFileName)
+}
+
+// IsLookupTableUpToDate returns true when the lookup table name was packaged with hash
+func (bc *BuildCache) IsLookupTableUpToDate(name, hash string) bool {
+	h, ok := bc.LookupTables[name]
+	return ok && h == hash
+}
+
+// SetLookupTable records the hash of the lookup table name that was packaged
+func (bc *BuildCache) SetLookupTable(name, hash string) {
+	bc.LookupTables[name] = hash
+}
+
+// PruneLookupTables removes the lookup tables that are not in names, i.e. that were
+// deleted from the workspace, returns the names of the removed lookup tables
+func (bc *BuildCache) PruneLookupTables(names map[string]bool) []string {
+	var removed []string
+	for name := range bc.LookupTables {
+		if !names[name] {
+			delete(bc.LookupTables, name)
+			removed = append(removed, name)
+		}
+	}
+	slices.Sort(removed)
+	return removed
+}
+
+// ContentHash returns the hash of the content of a file, as recorded in the build cache
+func ContentHash(content string) string {
+	h := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,116 @@
+package compiler
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// This file contains test cases for the build cache of the incremental compilation
+
+func TestBuildCache(t *testing.T) {
+	files := map[string]string{
+		"main.jr": `import "common/resources.jr"
+resource hc:Open = "hc:Open";`,
+		"common/resources.jr": `resource hc:status = "hc:status";`,
+	}
+	readFile := func(filePath string) (string, error) {
+		content, ok := files[strings.TrimPrefix(filePath, "ws/")]
+		if !ok {
+			return "", fmt.Errorf("file not found: %s", filePath)
+		}
+		return content, nil
+	}
+	hashFile := func(fileName string) (string, error) {
+		content, err := readFile("ws/" + fileName)
+		return ContentHash(content), err
+	}
+
+	jrCompiler := NewCompiler("ws", "main.jr", false, false, false)
+	jrCompiler.SetReadFile(readFile)
+	if err := jrCompiler.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	fileHashes := jrCompiler.SourceFileHashes()
+	if len(fileHashes) != 2 || fileHashes["common/resources.jr"] != ContentHash(files["common/resources.jr"]) {
+		t.Fatalf("unexpected source file hashes: %v", fileHashes)
+	}
+
+	bc := NewBuildCache()
+	if bc.IsUpToDate("main.jr", "opt", hashFile) {
+		t.Error("expecting main.jr not up to date with an empty cache")
+	}
+	bc.SetRuleSet("main.jr", "opt", fileHashes)
+	bc.SetLookupTable("hc:Lookup", "h1")
+
+	// Save and reload the cache
+	fpath := filepath.Join(t.TempDir(), "build_cache.json")
+	if err := bc.Save(fpath); err != nil {
+		t.Fatal(err)
+	}
+	bc = LoadBuildCache(fpath)
+	switch {
+	case !bc.IsUpToDate("main.jr", "opt", hashFile):
+		t.Error("expecting main.jr up to date")
+	case bc.IsUpToDate("main.jr", "other", hashFile):
+		t.Error("expecting main.jr not up to date with other compiler options")
+	case !bc.IsLookupTableUpToDate("hc:Lookup", "h1"):
+		t.Error("expecting hc:Lookup up to date")
+	case bc.IsLookupTableUpToDate("hc:Lookup", "h2"):
+		t.Error("expecting hc:Lookup not up to date with a new hash")
+	}
+
+	// Changing an imported file invalidates the main rule file
+	files["common/resources.jr"] += "\nresource hc:Closed = \"hc:Closed\";"
+	if bc.IsUpToDate("main.jr", "opt", hashFile) {
+		t.Error("expecting main.jr not up to date when an import changed")
+	}
+
+	// An invalid cache file is an empty cache
+	if bc = LoadBuildCache(filepath.Join(t.TempDir(), "missing.json")); len(bc.RuleSets) != 0 {
+		t.Error("expecting an empty cache when the file does not exist")
+	}
+}
+
+func TestBuildCache_CompilerVersion(t *testing.T) {
+	if CompilerVersion == "" || CompilerVersion != compilerVersion() {
+		t.Fatalf("unexpected compiler version %q", CompilerVersion)
+	}
+	bc := NewBuildCache()
+	bc.SetRuleSet("main.jr", "opt", map[string]string{"main.jr": "h1"})
+	fpath := filepath.Join(t.TempDir(), "build_cache.json")
+	if err := bc.Save(fpath); err != nil {
+		t.Fatal(err)
+	}
+	if bc = LoadBuildCache(fpath); len(bc.RuleSets) != 1 {
+		t.Fatal("expecting the cache of the same compiler to be loaded")
+	}
+
+	// A cache saved by another compiler or grammar is an empty cache
+	bc.CompilerVersion = "other"
+	if err := bc.Save(fpath); err != nil {
+		t.Fatal(err)
+	}
+	if bc = LoadBuildCache(fpath); len(bc.RuleSets) != 0 || bc.CompilerVersion != CompilerVersion {
+		t.Error("expecting an empty cache for another compiler version")
+	}
+}
+
+func TestBuildCache_PruneLookupTables(t *testing.T) {
+	bc := NewBuildCache()
+	bc.SetLookupTable("hc:Lookup1", "h1")
+	bc.SetLookupTable("hc:Lookup2", "h2")
+	bc.SetLookupTable("hc:Lookup3", "h3")
+	removed := bc.PruneLookupTables(map[string]bool{"hc:Lookup2": true})
+	if !reflect.DeepEqual(removed, []string{"hc:Lookup1", "hc:Lookup3"}) {
+		t.Errorf("unexpected removed lookup tables %v", removed)
+	}
+	if len(bc.LookupTables) != 1 || !bc.IsLookupTableUpToDate("hc:Lookup2", "h2") {
+		t.Errorf("unexpected lookup tables %v", bc.LookupTables)
+	}
+	if removed = bc.PruneLookupTables(map[string]bool{"hc:Lookup2": true}); len(removed) != 0 {
+		t.Errorf("expecting nothing to prune, got %v", removed)
+	}
+}
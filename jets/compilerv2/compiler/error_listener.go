@@ -18,6 +18,8 @@ type CustomErrorListener struct {
 	ParseLog                   *strings.Builder
 	ErrorLog                   *strings.Builder
 	Trace                      bool
+	// Syntax errors with their position, line is relative to the combined content
+	Diagnostics []Diagnostic
 }
 
 func NewCustomErrorListener(parseLog, errorLog *strings.Builder, trace bool) *CustomErrorListener {
@@ -37,6 +39,7 @@ func (l *CustomErrorListener) SyntaxError(
 ) {
 	// allways report syntax errors
 	fmt.Fprintf(l.ErrorLog, "Syntax error at line %d:%d - %s\n", line, column, msg)
+	l.Diagnostics = append(l.Diagnostics, Diagnostic{Line: line, Column: column, Message: msg})
 }
 
 func (l *CustomErrorListener) ReportAmbiguity(
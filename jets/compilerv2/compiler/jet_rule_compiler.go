@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -18,6 +19,11 @@ type Compiler struct {
 	listener *JetRuleListener
 	saveJson bool
 	autoAddResources bool
+	// readFile reads the rule files, default reads from the file system
+	readFile readFileFunc
+	// fileReader maps the combined content line numbers to the source files
+	fileReader   *RuleFileReader
+	syntaxErrors []Diagnostic
 }
 
 func NewCompiler(basePath string, mainRuleFileName string, saveJson, trace, autoAddResources bool) *Compiler {
@@ -25,6 +31,7 @@ func NewCompiler(basePath string, mainRuleFileName string, saveJson, trace, auto
 		listener: NewJetRuleListener(basePath, mainRuleFileName),
 		saveJson: saveJson,
 		autoAddResources: autoAddResources,
+		readFile: readRuleFile,
 	}
 	c.listener.trace = trace
 	c.listener.autoAddResources = autoAddResources
@@ -33,7 +40,8 @@ func NewCompiler(basePath string, mainRuleFileName string, saveJson, trace, auto
 
 func (c *Compiler) Compile() error {
 	// Read all rule files and imports
-	ruleFileReader := NewRuleFileReader(c.listener.basePath, c.listener.mainRuleFileName, readRuleFile)
+	ruleFileReader := NewRuleFileReader(c.listener.basePath, c.listener.mainRuleFileName, c.readFile)
+	c.fileReader = ruleFileReader
 
 	// Read all files recursively
 	combinedContent, err := ruleFileReader.ReadAll()
@@ -63,6 +71,7 @@ func (c *Compiler) CompileBuffer(combinedContent string) error {
 
 	// Build the tree
 	tree := p.Jetrule()
+	c.syntaxErrors = errorListener.Diagnostics
 
 	// Finally walk the tree
 	antlr.ParseTreeWalkerDefault.Walk(c.listener, tree)
@@ -100,17 +109,36 @@ func (c *Compiler) SaveModel() error {
 			return fmt.Errorf("while saving json: %w", err)
 		}
 		// Save to workspace.db file
-		wDb, err := NewWorkspaceDB(context.TODO(), c.listener.basePath)
-		if err != nil {
-			log.Println("** ERROR creating workspace.db:", err.Error())
-			return fmt.Errorf("while creating workspace.db: %w", err)
-		}
-		err = wDb.SaveJetRuleModel(context.TODO(), c.listener.jetRuleModel)
-		if err != nil {
-			log.Println("** ERROR saving to workspace.db:", err.Error())
-			return fmt.Errorf("while saving to workspace.db: %w", err)
-		}
-		return nil
+		return SaveModelToWorkspaceDB(c.listener.basePath, c.listener.jetRuleModel)
+}
+
+// SaveModelToWorkspaceDB saves the compiled model to the workspace.db file in basePath
+func SaveModelToWorkspaceDB(basePath string, model *rete.JetruleModel) error {
+	wDb, err := NewWorkspaceDB(context.TODO(), basePath)
+	if err != nil {
+		log.Println("** ERROR creating workspace.db:", err.Error())
+		return fmt.Errorf("while creating workspace.db: %w", err)
+	}
+	err = wDb.SaveJetRuleModel(context.TODO(), model)
+	if err != nil {
+		log.Println("** ERROR saving to workspace.db:", err.Error())
+		return fmt.Errorf("while saving to workspace.db: %w", err)
+	}
+	return nil
+}
+
+// LoadModel reads the compiled model of mainRuleFileName saved by SaveModel in basePath
+func LoadModel(basePath string, mainRuleFileName string) (*rete.JetruleModel, error) {
+	data, err := os.ReadFile(fmt.Sprintf("%s/%s", basePath, outJsonFileName(mainRuleFileName)))
+	if err != nil {
+		return nil, fmt.Errorf("while reading compiled model of %s: %w", mainRuleFileName, err)
+	}
+	model := &rete.JetruleModel{}
+	err = json.Unmarshal(data, model)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing compiled model of %s: %w", mainRuleFileName, err)
+	}
+	return model, nil
 }
 
 func (c *Compiler) Trace() bool {
@@ -133,6 +161,61 @@ func (c *Compiler) OutJsonFileName() string {
 	return c.listener.outJsonFileName
 }
 
+// SetReadFile overrides the function reading the rule files, e.g. to compile
+// the unsaved content of the files opened in an editor
+func (c *Compiler) SetReadFile(readFile func(filePath string) (string, error)) {
+	c.readFile = readFile
+}
+
+// SourceFileHashes returns the content hash of the rule files read by Compile, by file name,
+// i.e. the main rule file and all its imports. Returns nil when the rules are not read from files.
+func (c *Compiler) SourceFileHashes() map[string]string {
+	if c.fileReader == nil {
+		return nil
+	}
+	return c.fileReader.FileHashes()
+}
+
+// Symbols returns a copy of the declarations of the rule files, with their position
+// in the source files
+func (c *Compiler) Symbols() []*Symbol {
+	symbols := make([]*Symbol, 0, len(c.listener.symbols))
+	for _, sym := range c.listener.symbols {
+		s := *sym
+		s.SourceFileName, s.Line = c.localPosition(sym.SourceFileName, sym.Line)
+		symbols = append(symbols, &s)
+	}
+	return symbols
+}
+
+// Diagnostics returns a copy of the syntax and compilation errors, with their position
+// in the source files
+func (c *Compiler) Diagnostics() []Diagnostic {
+	diagnostics := make([]Diagnostic, 0, len(c.syntaxErrors)+len(c.listener.diagnostics))
+	for _, diags := range [][]Diagnostic{c.syntaxErrors, c.listener.diagnostics} {
+		for _, d := range diags {
+			d.SourceFileName, d.Line = c.localPosition(d.SourceFileName, d.Line)
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	return diagnostics
+}
+
+// localPosition maps a line of the combined content to the source file and its local line
+func (c *Compiler) localPosition(sourceFileName string, globalLine int) (string, int) {
+	if sourceFileName == "" {
+		sourceFileName = c.listener.mainRuleFileName
+	}
+	if c.fileReader == nil || globalLine == 0 {
+		return sourceFileName, globalLine
+	}
+	fileName, line, err := c.fileReader.GetLocalFileAndLine(globalLine)
+	if err != nil {
+		return sourceFileName, globalLine
+	}
+	return fileName, line
+}
+
 // All in one function to compile the rules
 func CompileJetRuleFiles(basePath string, mainRuleFileName string, saveJson, trace, autoAddResources bool) (*Compiler, error) {
 	log.Println("Compiling JetRule file:", mainRuleFileName, "autoAddResources:", autoAddResources)
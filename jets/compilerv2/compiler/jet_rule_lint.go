@@ -0,0 +1,340 @@
+package compiler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rete"
+)
+
+// This file contains the static checks of the compiled model (compilerv2 -lint mode).
+// The checks report warnings, they do not prevent the model from being used:
+//   - rules that never fire: an antecedent property is not produced by the classes,
+//     the triples, the lookup tables nor by the consequents of the rules that can fire,
+//   - declared resources and lookup tables that are not used,
+//   - literals compared to a data property of a different type, e.g. text vs date,
+//     in the antecedents and in the filters of the rules,
+//   - consequents writing a property that is not declared as a data property of a class.
+// The properties of the system namespaces (rdf, rdfs, owl and jets) are asserted by
+// the rule engine and the loader, they are not reported.
+
+// Namespaces of the system properties, not subject to the lint checks
+var lintSystemPrefixes = []string{"rdf:", "rdfs:", "owl:", "jets:"}
+
+// Comparison operators of the filters
+var lintComparisonOps = map[string]bool{
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+}
+
+// lintChecker holds the state of the static checks
+type lintChecker struct {
+	c             *Compiler
+	model         *rete.JetruleModel
+	resourceByKey map[int]*rete.ResourceNode
+	// data property name -> type, empty type when the classes declare different types
+	propertyTypes map[string]string
+	symbols       map[string]*Symbol
+	warnings      []Diagnostic
+}
+
+// Lint performs the static checks of the compiled model and returns the warnings,
+// with their position in the source files
+func (c *Compiler) Lint() []Diagnostic {
+	model := c.JetRuleModel()
+	lc := &lintChecker{
+		c:             c,
+		model:         model,
+		resourceByKey: make(map[int]*rete.ResourceNode),
+		propertyTypes: make(map[string]string),
+		symbols:       make(map[string]*Symbol),
+	}
+	for _, r := range model.Resources {
+		lc.resourceByKey[r.Key] = r
+	}
+	for _, class := range model.Classes {
+		for _, dp := range class.DataProperties {
+			if t, ok := lc.propertyTypes[dp.Name]; ok && t != dp.Type {
+				lc.propertyTypes[dp.Name] = ""
+				continue
+			}
+			lc.propertyTypes[dp.Name] = dp.Type
+		}
+	}
+	for _, sym := range c.listener.symbols {
+		switch sym.Kind {
+		case SymbolRule, SymbolResource, SymbolVolatileResource, SymbolLookupTable:
+			lc.symbols[sym.Kind+"|"+sym.Name] = sym
+		}
+	}
+	lc.checkUnreachableRules()
+	lc.checkUnusedDeclarations()
+	lc.checkTypeMismatches()
+	lc.checkUndeclaredProperties()
+	return lc.warnings
+}
+
+// warn adds a warning at the position of the symbol of kind and name, if any
+func (lc *lintChecker) warn(kind, name, format string, args ...any) {
+	d := Diagnostic{Message: "warning: " + fmt.Sprintf(format, args...)}
+	if sym := lc.symbols[kind+"|"+name]; sym != nil {
+		d.SourceFileName, d.Line = lc.c.localPosition(sym.SourceFileName, sym.Line)
+		d.Column = sym.Column
+	} else {
+		d.SourceFileName, d.Line = lc.c.localPosition("", 0)
+	}
+	lc.warnings = append(lc.warnings, d)
+}
+
+// warnRule adds a warning at the position of rule, the rules generated by the
+// expansion of the or blocks are at the position of the authored rule
+func (lc *lintChecker) warnRule(rule *rete.JetruleNode, format string, args ...any) {
+	name := rule.Name
+	if rule.BranchOf != "" {
+		name = rule.BranchOf
+	}
+	lc.warn(SymbolRule, name, "rule %s: %s", rule.Name, fmt.Sprintf(format, args...))
+}
+
+// predicate returns the id of the named resource at key, empty when it's not a
+// named resource or when it's a system property
+func (lc *lintChecker) predicate(key int) string {
+	r := lc.resourceByKey[key]
+	if r == nil || (r.Type != "resource" && r.Type != "volatile_resource") || isSystemId(r.Id) {
+		return ""
+	}
+	return r.Id
+}
+
+// isSystemId returns true when id is in a system namespace
+func isSystemId(id string) bool {
+	return slices.ContainsFunc(lintSystemPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(id, prefix)
+	})
+}
+
+// checkUnreachableRules reports the rules that cannot fire: starting from the properties
+// of the classes, the triples and the lookup tables, the rules having all their antecedent
+// properties produced can fire and produce the properties of their consequents.
+func (lc *lintChecker) checkUnreachableRules() {
+	produced := make(map[string]bool)
+	for _, class := range lc.model.Classes {
+		for _, dp := range class.DataProperties {
+			produced[dp.Name] = true
+		}
+	}
+	for _, t3 := range lc.model.Triples {
+		produced[lc.predicate(t3.PredicateKey)] = true
+	}
+	for _, tbl := range lc.model.LookupTables {
+		for _, col := range tbl.Columns {
+			produced[col.Name] = true
+		}
+	}
+	// missing returns the first antecedent property of rule that is not produced
+	missing := func(rule *rete.JetruleNode) string {
+		for _, term := range rule.Antecedents {
+			p := lc.predicate(term.PredicateKey)
+			// A negated antecedent is satisfied when the property is not produced
+			if p != "" && !term.IsNot && !produced[p] {
+				return p
+			}
+		}
+		return ""
+	}
+	reached := make(map[*rete.JetruleNode]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, rule := range lc.model.Jetrules {
+			if !rule.IsValid || reached[rule] || missing(rule) != "" {
+				continue
+			}
+			reached[rule] = true
+			for _, term := range rule.Consequents {
+				if p := lc.predicate(term.PredicateKey); p != "" && !produced[p] {
+					produced[p] = true
+					changed = true
+				}
+			}
+		}
+	}
+	for _, rule := range lc.model.Jetrules {
+		if rule.IsValid && !reached[rule] {
+			lc.warnRule(rule, "never fires, antecedent property %s is not produced by a class, a triple, a lookup table or a rule",
+				missing(rule))
+		}
+	}
+}
+
+// checkUnusedDeclarations reports the declared resources and lookup tables that are
+// not used by the rules and the triples
+func (lc *lintChecker) checkUnusedDeclarations() {
+	used := make(map[int]bool)
+	var useExpr func(expr *rete.ExpressionNode)
+	useExpr = func(expr *rete.ExpressionNode) {
+		if expr == nil {
+			return
+		}
+		if expr.Type == "identifier" {
+			used[expr.Value] = true
+		}
+		useExpr(expr.Lhs)
+		useExpr(expr.Rhs)
+		useExpr(expr.Arg)
+	}
+	for _, rule := range lc.model.Jetrules {
+		for _, term := range slices.Concat(rule.Antecedents, rule.Consequents) {
+			used[term.SubjectKey] = true
+			used[term.PredicateKey] = true
+			used[term.ObjectKey] = true
+			useExpr(term.Filter)
+			useExpr(term.ObjectExpr)
+			if term.Aggregate != nil {
+				used[term.Aggregate.SubjectKey] = true
+				used[term.Aggregate.PredicateKey] = true
+				used[term.Aggregate.ObjectKey] = true
+			}
+		}
+	}
+	for _, t3 := range lc.model.Triples {
+		used[t3.SubjectKey] = true
+		used[t3.PredicateKey] = true
+		used[t3.ObjectKey] = true
+	}
+	usedIds := make(map[string]bool)
+	for key := range used {
+		if r := lc.resourceByKey[key]; r != nil && r.Id != "" {
+			usedIds[r.Id] = true
+		}
+	}
+	// The classes and their data properties are resources used by the class definitions
+	for _, class := range lc.model.Classes {
+		usedIds[class.Name] = true
+		for _, dp := range class.DataProperties {
+			usedIds[dp.Name] = true
+		}
+	}
+	for _, sym := range lc.c.listener.symbols {
+		switch sym.Kind {
+		case SymbolResource, SymbolVolatileResource:
+			if usedIds[sym.Name] || isSystemId(sym.Name) {
+				continue
+			}
+			lc.warn(sym.Kind, sym.Name, "%s %s is declared but not used", strings.ReplaceAll(sym.Kind, "_", " "), sym.Name)
+		case SymbolLookupTable:
+			if !usedIds[sym.Name] {
+				lc.warn(sym.Kind, sym.Name, "lookup table %s is declared but not used", sym.Name)
+			}
+		}
+	}
+}
+
+// typeFamily returns the family of the data property or literal type, types of the
+// same family can be compared. Returns empty for the types that are not checked.
+func typeFamily(t string) string {
+	switch t {
+	case "int", "uint", "long", "ulong", "double", "bool":
+		return "numeric"
+	case "text":
+		return "text"
+	case "date":
+		return "date"
+	case "datetime":
+		return "datetime"
+	case "resource":
+		return "resource"
+	}
+	return ""
+}
+
+// checkTypeMismatches reports the literals of a type not compatible with the type
+// of the data property they are compared to: in the antecedents, e.g. (?c hc:dob "2000-01-01")
+// with hc:dob a date, and in the filters, e.g. [?dob < "2000-01-01"] with ?dob the object
+// of (?c hc:dob ?dob)
+func (lc *lintChecker) checkTypeMismatches() {
+	literalType := func(key int) string {
+		r := lc.resourceByKey[key]
+		if r == nil || r.Type == "resource" {
+			return ""
+		}
+		return r.Type
+	}
+	for _, rule := range lc.model.Jetrules {
+		if !rule.IsValid {
+			continue
+		}
+		// Type of the variables that are the object of a data property
+		varTypes := make(map[string]string)
+		varProperty := make(map[string]string)
+		for _, term := range rule.Antecedents {
+			p := lc.predicate(term.PredicateKey)
+			propertyType := lc.propertyTypes[p]
+			o := lc.resourceByKey[term.ObjectKey]
+			if p == "" || propertyType == "" || o == nil {
+				continue
+			}
+			if o.Type == "var" {
+				varTypes[o.Id] = propertyType
+				varProperty[o.Id] = p
+				continue
+			}
+			if lt := literalType(term.ObjectKey); lt != "" && !sameTypeFamily(lt, propertyType) {
+				lc.warnRule(rule, "literal %s of type %s matched against property %s of type %s",
+					o.Value, lt, p, propertyType)
+			}
+		}
+		var checkExpr func(expr *rete.ExpressionNode)
+		checkExpr = func(expr *rete.ExpressionNode) {
+			if expr == nil {
+				return
+			}
+			if expr.Type == "binary" && lintComparisonOps[expr.Op] && expr.Lhs != nil && expr.Rhs != nil &&
+				expr.Lhs.Type == "identifier" && expr.Rhs.Type == "identifier" {
+				lhs, rhs := lc.resourceByKey[expr.Lhs.Value], lc.resourceByKey[expr.Rhs.Value]
+				if lhs != nil && rhs != nil && rhs.Type == "var" {
+					lhs, rhs = rhs, lhs
+				}
+				if lhs != nil && rhs != nil && lhs.Type == "var" && varTypes[lhs.Id] != "" {
+					if lt := literalType(rhs.Key); lt != "" && !sameTypeFamily(lt, varTypes[lhs.Id]) {
+						lc.warnRule(rule, "literal %s of type %s compared to property %s of type %s",
+							rhs.Value, lt, varProperty[lhs.Id], varTypes[lhs.Id])
+					}
+				}
+			}
+			checkExpr(expr.Lhs)
+			checkExpr(expr.Rhs)
+			checkExpr(expr.Arg)
+		}
+		for _, term := range rule.Antecedents {
+			checkExpr(term.Filter)
+		}
+	}
+}
+
+// sameTypeFamily returns false when the literal type and the property type are known
+// and not compatible
+func sameTypeFamily(literalType, propertyType string) bool {
+	lf, pf := typeFamily(literalType), typeFamily(propertyType)
+	return lf == "" || pf == "" || lf == pf
+}
+
+// checkUndeclaredProperties reports the consequents writing a property that is not a
+// data property of a class, volatile resources are not reported
+func (lc *lintChecker) checkUndeclaredProperties() {
+	for _, rule := range lc.model.Jetrules {
+		if !rule.IsValid {
+			continue
+		}
+		reported := make(map[string]bool)
+		for _, term := range rule.Consequents {
+			p := lc.predicate(term.PredicateKey)
+			if p == "" || reported[p] || lc.resourceByKey[term.PredicateKey].Type != "resource" {
+				continue
+			}
+			if _, ok := lc.propertyTypes[p]; !ok {
+				reported[p] = true
+				lc.warnRule(rule, "consequent property %s is not declared by a class", p)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+)
+
+// This file contains test cases for the static checks of the compiled model
+
+var lintTestRules = `@JetCompilerDirective source_file = "lint.jr";
+class hc:Claim {
+  $base_classes = [owl:Thing],
+  $data_properties = [
+    hc:code      as text,
+    hc:dob       as date,
+    hc:amount    as double,
+    hc:status    as resource
+  ],
+  $as_table = true
+};
+resource hc:Open = "hc:Open";
+resource hc:Unused = "hc:Unused";
+resource hc:flagged = "hc:flagged";
+resource hc:orphan = "hc:orphan";
+lookup_table hc:UnusedLookup {
+  $csv_file = "lookups/unused.csv",
+  $key = ["code"],
+  $columns = ["label" as text]
+};
+
+[R01]:
+  (?c rdf:type hc:Claim).
+  (?c hc:code "A")
+->
+  (?c hc:status hc:Open).
+  (?c hc:flagged true)
+;
+
+[R02]:
+  (?c rdf:type hc:Claim).
+  (?c hc:orphan ?x)
+->
+  (?c hc:status hc:Open)
+;
+
+[R03]:
+  (?c rdf:type hc:Claim).
+  (?c hc:dob ?dob).[?dob < 100]
+->
+  (?c hc:status hc:Open)
+;
+
+[R04]:
+  (?c rdf:type hc:Claim).
+  (?c hc:amount "high")
+->
+  (?c hc:status hc:Open)
+;
+`
+
+func TestCompilerLint(t *testing.T) {
+	jrCompiler := NewCompiler("", "lint.jr", false, false, false)
+	if err := jrCompiler.CompileBuffer(lintTestRules); err != nil {
+		t.Fatal(err)
+	}
+	if jrCompiler.ErrorLog().Len() > 0 {
+		t.Fatal(jrCompiler.ErrorLog().String())
+	}
+	warnings := jrCompiler.Lint()
+	var messages []string
+	for _, w := range warnings {
+		if w.SourceFileName != "lint.jr" || w.Line == 0 {
+			t.Errorf("unexpected position %s:%d for %s", w.SourceFileName, w.Line, w.Message)
+		}
+		messages = append(messages, w.Message)
+	}
+	expected := []string{
+		"warning: rule R02: never fires, antecedent property hc:orphan is not produced",
+		"warning: resource hc:Unused is declared but not used",
+		"warning: lookup table hc:UnusedLookup is declared but not used",
+		"warning: rule R03: literal 100 of type int compared to property hc:dob of type date",
+		"warning: rule R04: literal high of type text matched against property hc:amount of type double",
+		"warning: rule R01: consequent property hc:flagged is not declared by a class",
+	}
+	all := strings.Join(messages, "\n")
+	for _, e := range expected {
+		if !strings.Contains(all, e) {
+			t.Errorf("expecting warning %q, got:\n%s", e, all)
+		}
+	}
+	if len(warnings) != len(expected) {
+		t.Errorf("expecting %d warnings, got:\n%s", len(expected), all)
+	}
+}
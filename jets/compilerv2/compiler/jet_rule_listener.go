@@ -52,5 +52,7 @@ func (l *JetRuleListener) ExitJetrule(ctx *parser.JetruleContext) {
 		fmt.Fprintf(l.parseLog, "** ExitJetrule\n")
 	}
 	// Compiler post processing and validation
+	errorLogPos := l.errorLog.Len()
 	l.PostProcessJetruleModel()
+	l.addDiagnostics(nil, errorLogPos)
 }
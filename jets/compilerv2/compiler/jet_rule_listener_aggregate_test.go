@@ -0,0 +1,229 @@
+package compiler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+	"github.com/artisoft-io/jetstore/jets/jetrules/rete"
+)
+
+// This file contains test cases for the aggregation antecedents and the aggregate terms
+// of the consequents
+
+// compileAggregateRule compiles the rule:
+//
+//	[name]: (?c rdf:type hc:Claim) -> (?c hc:nbr_lines op(aggVar where (?c hc:has_line ?line)));
+func compileAggregateRule(t *testing.T, name, op, aggVar string) *Compiler {
+	jrCompiler := NewCompiler("", "aggregate.jr", false, false, false)
+	err := jrCompiler.CompileBuffer(fmt.Sprintf(`@JetCompilerDirective source_file = "aggregate.jr";
+		resource hc:Claim = "hc:Claim";
+		resource hc:has_line = "hc:has_line";
+		resource hc:nbr_lines = "hc:nbr_lines";
+
+		[%s]:
+		(?c rdf:type hc:Claim)
+		->
+		(?c hc:nbr_lines %s(%s where (?c hc:has_line ?line)));`, name, op, aggVar))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return jrCompiler
+}
+
+func TestJetRuleListener_AggregateTerm(t *testing.T) {
+	jrCompiler := compileAggregateRule(t, "R01", "count", "?line")
+	if jrCompiler.ErrorLog().Len() > 0 {
+		t.Fatal(jrCompiler.ErrorLog().String())
+	}
+	model := jrCompiler.JetRuleModel()
+	expectedLabel := "[R01]:(?x01 rdf:type hc:Claim) -> (?x01 hc:nbr_lines count(?x02 where (?x01 hc:has_line ?x02)));"
+	if model.Jetrules[0].NormalizedLabel != expectedLabel {
+		t.Errorf("Unexpected normalized label: %s", model.Jetrules[0].NormalizedLabel)
+	}
+	// Expecting the head node, the antecedent, the aggregate antecedent and the consequent
+	if len(model.ReteNodes) != 4 {
+		t.Fatalf("Expecting 4 rete nodes, got %d", len(model.ReteNodes))
+	}
+	aggNode := model.ReteNodes[2]
+	switch {
+	case aggNode.Aggregate == nil || aggNode.Aggregate.Operator != "count" || aggNode.Aggregate.VarPos != 2:
+		t.Errorf("Unexpected aggregate node: %+v", aggNode.Aggregate)
+	case aggNode.ParentVertex != 1:
+		t.Errorf("Expecting aggregate node parent vertex 1, got %d", aggNode.ParentVertex)
+	case strings.Join(aggNode.BetaRelationVars, ",") != "?agg02,?x01":
+		t.Errorf("Unexpected aggregate node beta relation vars: %v", aggNode.BetaRelationVars)
+	case model.ReteNodes[3].Vertex != 2 || model.ReteNodes[3].Aggregate != nil:
+		t.Errorf("Expecting the consequent at the aggregate node vertex, got %d", model.ReteNodes[3].Vertex)
+	}
+
+	// Execute the rule
+	rdfSession, reteSession := newAggregateReteSession(t, jrCompiler)
+	defer reteSession.Done()
+	rm := rdfSession.ResourceMgr
+	c1 := rm.NewResource("c1")
+	rdfSession.Insert(c1, rm.NewResource("rdf:type"), rm.NewResource("hc:Claim"))
+	rdfSession.Insert(c1, rm.NewResource("hc:has_line"), rm.NewResource("l1"))
+	rdfSession.Insert(c1, rm.NewResource("hc:has_line"), rm.NewResource("l2"))
+	if err := reteSession.ExecuteRules(); err != nil {
+		t.Fatal(err)
+	}
+	if !rdfSession.Contains(c1, rm.NewResource("hc:nbr_lines"), rm.NewIntLiteral(2)) {
+		t.Error("Expecting (c1 hc:nbr_lines 2)")
+	}
+}
+
+// newAggregateReteSession returns the rdf session and the rete session of the compiled
+// rules, the rules are executed by the caller after inserting the test triples
+func newAggregateReteSession(t *testing.T, jrCompiler *Compiler) (*rdf.RdfSession, *rete.ReteSession) {
+	data, err := json.Marshal(jrCompiler.JetRuleModel())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reteModel := &rete.JetruleModel{}
+	if err = json.Unmarshal(data, reteModel); err != nil {
+		t.Fatal(err)
+	}
+	factory, err := rete.NewReteMetaStoreFactoryFromModels(nil, "", map[string]*rete.JetruleModel{"aggregate.jr": reteModel})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdfSession := rdf.NewRdfSession(factory.ResourceMgr, factory.MetaGraph)
+	reteSession := rete.NewReteSession(rdfSession)
+	reteSession.Initialize(factory.MetaStoreLookup["aggregate.jr"])
+	return rdfSession, reteSession
+}
+
+// compileAggregationAntecedent compiles the rule having the antecedents and the consequents
+func compileAggregationAntecedent(t *testing.T, antecedents, consequents string) *Compiler {
+	jrCompiler := NewCompiler("", "aggregate.jr", false, false, false)
+	err := jrCompiler.CompileBuffer(fmt.Sprintf(`@JetCompilerDirective source_file = "aggregate.jr";
+		resource hc:Claim = "hc:Claim";
+		resource hc:has_line = "hc:has_line";
+		resource hc:nbr_lines = "hc:nbr_lines";
+		resource hc:amount = "hc:amount";
+		resource hc:total_amount = "hc:total_amount";
+
+		[R01]:
+		%s
+		->
+		%s;`, antecedents, consequents))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return jrCompiler
+}
+
+func TestJetRuleListener_AggregationAntecedent(t *testing.T) {
+	jrCompiler := compileAggregationAntecedent(t,
+		`(?c rdf:type hc:Claim).
+		(?n = count(?line where (?c hc:has_line ?line))).[?n > 1].
+		(?total = sum(?amt where (?c hc:amount ?amt)))`,
+		`(?c hc:nbr_lines ?n).(?c hc:total_amount ?total)`)
+	if jrCompiler.ErrorLog().Len() > 0 {
+		t.Fatal(jrCompiler.ErrorLog().String())
+	}
+	model := jrCompiler.JetRuleModel()
+	expectedLabel := "[R01]:(?x01 rdf:type hc:Claim).(?x02 = count(?x03 where (?x01 hc:has_line ?x03))).[(?x02 > int(1))]" +
+		".(?x04 = sum(?x05 where (?x01 hc:amount ?x05))) -> (?x01 hc:nbr_lines ?x02).(?x01 hc:total_amount ?x04);"
+	if model.Jetrules[0].NormalizedLabel != expectedLabel {
+		t.Errorf("Unexpected normalized label: %s", model.Jetrules[0].NormalizedLabel)
+	}
+	// Expecting the head node, the antecedent, the 2 aggregate antecedents and the 2 consequents
+	if len(model.ReteNodes) != 6 {
+		t.Fatalf("Expecting 6 rete nodes, got %d", len(model.ReteNodes))
+	}
+	countNode := model.ReteNodes[2]
+	switch {
+	case countNode.Aggregate == nil || countNode.Aggregate.Operator != "count" || countNode.Aggregate.VarPos != 2:
+		t.Errorf("Unexpected aggregate node: %+v", countNode.Aggregate)
+	case countNode.ParentVertex != 1 || countNode.Filter == nil:
+		t.Errorf("Expecting aggregate node with parent vertex 1 and a filter, got %+v", countNode)
+	case strings.Join(countNode.BetaRelationVars, ",") != "?x01,?x02":
+		t.Errorf("Unexpected aggregate node beta relation vars: %v", countNode.BetaRelationVars)
+	case model.ReteNodes[3].ParentVertex != 2 || model.ReteNodes[4].Vertex != 3:
+		t.Errorf("Expecting the sum aggregate node as child of the count aggregate node")
+	}
+
+	// Execute the rule
+	rdfSession, reteSession := newAggregateReteSession(t, jrCompiler)
+	defer reteSession.Done()
+	rm := rdfSession.ResourceMgr
+	claimType := rm.NewResource("hc:Claim")
+	hasLine := rm.NewResource("hc:has_line")
+	amount := rm.NewResource("hc:amount")
+	c1 := rm.NewResource("c1")
+	rdfSession.Insert(c1, rm.NewResource("rdf:type"), claimType)
+	rdfSession.Insert(c1, hasLine, rm.NewResource("l1"))
+	rdfSession.Insert(c1, hasLine, rm.NewResource("l2"))
+	rdfSession.Insert(c1, amount, rm.NewIntLiteral(10))
+	rdfSession.Insert(c1, amount, rm.NewIntLiteral(5))
+	c2 := rm.NewResource("c2")
+	rdfSession.Insert(c2, rm.NewResource("rdf:type"), claimType)
+	rdfSession.Insert(c2, hasLine, rm.NewResource("l3"))
+	if err := reteSession.ExecuteRules(); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case !rdfSession.Contains(c1, rm.NewResource("hc:nbr_lines"), rm.NewIntLiteral(2)):
+		t.Error("Expecting (c1 hc:nbr_lines 2)")
+	case !rdfSession.Contains(c1, rm.NewResource("hc:total_amount"), rm.NewIntLiteral(15)):
+		t.Error("Expecting (c1 hc:total_amount 15)")
+	case rdfSession.ContainsSP(c2, rm.NewResource("hc:nbr_lines")):
+		t.Error("Not expecting hc:nbr_lines for c2, it has a single line")
+	}
+}
+
+func TestJetRuleListener_AggregationAntecedent_err1(t *testing.T) {
+	jrCompiler := compileAggregationAntecedent(t,
+		`(?c rdf:type hc:Claim).not(?n = count(?line where (?c hc:has_line ?line)))`,
+		`(?c hc:nbr_lines 0)`)
+	if !strings.Contains(jrCompiler.ErrorLog().String(), "aggregation antecedent with aggregate count cannot be negated") {
+		t.Errorf("Expecting negated aggregation antecedent error, got: %s", jrCompiler.ErrorLog().String())
+	}
+}
+
+func TestJetRuleListener_AggregationAntecedent_err2(t *testing.T) {
+	jrCompiler := compileAggregationAntecedent(t,
+		`(?c rdf:type hc:Claim).(?n = count(?line where (?c hc:has_line ?line))).(?c hc:amount ?n)`,
+		`(?c hc:nbr_lines ?n)`)
+	if !strings.Contains(jrCompiler.ErrorLog().String(), "must follow the other antecedents of the rule") ||
+		!strings.Contains(jrCompiler.ErrorLog().String(), "can only be used in filters and consequents") {
+		t.Errorf("Expecting aggregation antecedent errors, got: %s", jrCompiler.ErrorLog().String())
+	}
+}
+
+func TestJetRuleListener_AggregateTerm_err1(t *testing.T) {
+	jrCompiler := compileAggregateRule(t, "R01", "avg", "?line")
+	if !strings.Contains(jrCompiler.ErrorLog().String(), "unknown aggregate operator 'avg'") {
+		t.Errorf("Expecting unknown aggregate operator error, got: %s", jrCompiler.ErrorLog().String())
+	}
+}
+
+func TestJetRuleListener_AggregateTerm_err2(t *testing.T) {
+	jrCompiler := compileAggregateRule(t, "R01", "count", "?c")
+	if !strings.Contains(jrCompiler.ErrorLog().String(), "aggregated variable var|?c of aggregate count must not appear in the antecedents") {
+		t.Errorf("Expecting aggregated variable error, got: %s", jrCompiler.ErrorLog().String())
+	}
+}
+
+func TestJetRuleListener_AggregateTerm_err3(t *testing.T) {
+	jrCompiler := compileAggregationAntecedent(t,
+		`(?c rdf:type hc:Claim).(?n = count(?line wher (?c hc:has_line ?line)))`,
+		`(?c hc:nbr_lines ?n)`)
+	if !strings.Contains(jrCompiler.ErrorLog().String(), "expecting 'where' in aggregate term") {
+		t.Errorf("Expecting where keyword error, got: %s", jrCompiler.ErrorLog().String())
+	}
+}
+
+// where is a contextual keyword, it remains a valid identifier
+func TestJetRuleListener_AggregateTerm_WhereIdentifier(t *testing.T) {
+	jrCompiler := compileAggregationAntecedent(t,
+		`(?c rdf:type hc:Claim).(?c hc:has_line ?where)`,
+		`(?c hc:nbr_lines count(?line where (?where hc:has_line ?line)))`)
+	if jrCompiler.ErrorLog().Len() > 0 {
+		t.Fatal(jrCompiler.ErrorLog().String())
+	}
+}
@@ -0,0 +1,169 @@
+package compiler
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/artisoft-io/jetstore/jets/compilerv2/parser"
+	"github.com/artisoft-io/jetstore/jets/jetrules/rete"
+)
+
+// This file contains the compile-time expansion of the or blocks of the rule antecedents:
+//
+//	[R01]: (?c rdf:type hc:Claim).
+//	       or { (?c hc:code "A") } { (?c hc:alt_code "A").(?c hc:status "open") }
+//	       -> (?c hc:is_a true);
+//
+// is expanded into the rules R01.1 and R01.2, one rule per branch, having the antecedents
+// of the branch in place of the or block. When a rule has several or blocks, there is one
+// rule per combination of the branches. The expanded rules have their own variables and
+// are validated and optimized as authored rules, they share the consequents of the rule.
+
+// Max number of rules generated by the expansion of the or blocks of a rule
+const maxOrExpansion = 64
+
+// orBlock is an or block of the current rule, pos is the position of the block in
+// the antecedents of the rule
+type orBlock struct {
+	pos         int
+	branches    [][]*rete.RuleTerm
+	branchStart int
+}
+
+// enterOrAntecedent is called when production orAntecedent is entered.
+func (s *JetRuleListener) EnterOrAntecedent(ctx *parser.OrAntecedentContext) {
+	s.currentRuleOrBlocks = append(s.currentRuleOrBlocks, &orBlock{
+		pos: len(s.currentRuleAntecedents),
+	})
+}
+
+// enterOrBranch is called when production orBranch is entered, the antecedents
+// of the branch are appended to the rule antecedents by ExitAntecedent
+func (s *JetRuleListener) EnterOrBranch(ctx *parser.OrBranchContext) {
+	if len(s.currentRuleOrBlocks) == 0 {
+		return
+	}
+	block := s.currentRuleOrBlocks[len(s.currentRuleOrBlocks)-1]
+	block.branchStart = len(s.currentRuleAntecedents)
+}
+
+// exitOrBranch is called when production orBranch is exited, moves the antecedents
+// of the branch from the rule antecedents to the branch
+func (s *JetRuleListener) ExitOrBranch(ctx *parser.OrBranchContext) {
+	if len(s.currentRuleOrBlocks) == 0 {
+		return
+	}
+	block := s.currentRuleOrBlocks[len(s.currentRuleOrBlocks)-1]
+	if block.branchStart > len(s.currentRuleAntecedents) {
+		return
+	}
+	branch := slices.Clone(s.currentRuleAntecedents[block.branchStart:])
+	s.currentRuleAntecedents = s.currentRuleAntecedents[:block.branchStart]
+	block.branches = append(block.branches, branch)
+}
+
+// expandOrBlocks returns the rules of the expansion of the or blocks of rule,
+// returns nil when the expansion has too many rules
+func (s *JetRuleListener) expandOrBlocks(rule *rete.JetruleNode, blocks []*orBlock) []*rete.JetruleNode {
+	// The combinations of the branches, one branch index per or block
+	combinations := [][]int{{}}
+	for _, block := range blocks {
+		next := make([][]int, 0, len(combinations)*len(block.branches))
+		for _, c := range combinations {
+			for b := range block.branches {
+				next = append(next, append(slices.Clone(c), b))
+			}
+		}
+		combinations = next
+		if len(combinations) > maxOrExpansion {
+			fmt.Fprintf(s.errorLog, "** error: rule %s: the or blocks expand into more than %d rules\n",
+				rule.Name, maxOrExpansion)
+			return nil
+		}
+	}
+	rules := make([]*rete.JetruleNode, 0, len(combinations))
+	for i, c := range combinations {
+		// Each rule has its own variables since the optimization renames them
+		newVars := make(map[int]*rete.ResourceNode)
+		antecedents := make([]*rete.RuleTerm, 0, len(rule.Antecedents))
+		pos := 0
+		for j, block := range blocks {
+			for ; pos < block.pos; pos++ {
+				antecedents = append(antecedents, s.cloneRuleTerm(rule.Antecedents[pos], newVars))
+			}
+			for _, term := range block.branches[c[j]] {
+				antecedents = append(antecedents, s.cloneRuleTerm(term, newVars))
+			}
+		}
+		for ; pos < len(rule.Antecedents); pos++ {
+			antecedents = append(antecedents, s.cloneRuleTerm(rule.Antecedents[pos], newVars))
+		}
+		consequents := make([]*rete.RuleTerm, 0, len(rule.Consequents))
+		for _, term := range rule.Consequents {
+			consequents = append(consequents, s.cloneRuleTerm(term, newVars))
+		}
+		rules = append(rules, &rete.JetruleNode{
+			Name:           fmt.Sprintf("%s.%d", rule.Name, i+1),
+			BranchOf:       rule.Name,
+			Properties:     maps.Clone(rule.Properties),
+			Antecedents:    antecedents,
+			Consequents:    consequents,
+			SourceFileName: rule.SourceFileName,
+		})
+	}
+	return rules
+}
+
+// cloneRuleTerm returns a copy of term using the variables of newVars, the variables
+// are created on first encounter
+func (s *JetRuleListener) cloneRuleTerm(term *rete.RuleTerm, newVars map[int]*rete.ResourceNode) *rete.RuleTerm {
+	clone := *term
+	clone.SubjectKey = s.cloneVar(term.SubjectKey, newVars)
+	clone.PredicateKey = s.cloneVar(term.PredicateKey, newVars)
+	clone.ObjectKey = s.cloneVar(term.ObjectKey, newVars)
+	clone.Filter = s.cloneExpr(term.Filter, newVars)
+	clone.ObjectExpr = s.cloneExpr(term.ObjectExpr, newVars)
+	if term.Aggregate != nil {
+		aggregate := *term.Aggregate
+		aggregate.VarKey = s.cloneVar(aggregate.VarKey, newVars)
+		aggregate.ResultKey = s.cloneVar(aggregate.ResultKey, newVars)
+		aggregate.SubjectKey = s.cloneVar(aggregate.SubjectKey, newVars)
+		aggregate.PredicateKey = s.cloneVar(aggregate.PredicateKey, newVars)
+		aggregate.ObjectKey = s.cloneVar(aggregate.ObjectKey, newVars)
+		clone.Aggregate = &aggregate
+	}
+	return &clone
+}
+
+func (s *JetRuleListener) cloneExpr(expr *rete.ExpressionNode, newVars map[int]*rete.ResourceNode) *rete.ExpressionNode {
+	if expr == nil {
+		return nil
+	}
+	clone := *expr
+	clone.Lhs = s.cloneExpr(expr.Lhs, newVars)
+	clone.Rhs = s.cloneExpr(expr.Rhs, newVars)
+	clone.Arg = s.cloneExpr(expr.Arg, newVars)
+	clone.Value = s.cloneVar(expr.Value, newVars)
+	return &clone
+}
+
+// cloneVar returns the key of the variable replacing the variable at key,
+// returns key when it's not a variable
+func (s *JetRuleListener) cloneVar(key int, newVars map[int]*rete.ResourceNode) int {
+	r := s.Resource(key)
+	if r == nil || r.Type != "var" {
+		return key
+	}
+	v := newVars[key]
+	if v == nil {
+		v = &rete.ResourceNode{
+			Type:  "var",
+			Id:    r.Id,
+			Value: r.Value,
+		}
+		s.newResource(v)
+		newVars[key] = v
+	}
+	return v.Key
+}
@@ -0,0 +1,181 @@
+package compiler
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+	"github.com/artisoft-io/jetstore/jets/jetrules/rete"
+)
+
+// This file contains test cases for the expansion of the or blocks of the rule antecedents
+
+// compileOrRule compiles the resources and the rules
+func compileOrRule(t *testing.T, rules string) *Compiler {
+	jrCompiler := NewCompiler("", "disjunction.jr", false, false, false)
+	err := jrCompiler.CompileBuffer(`@JetCompilerDirective source_file = "disjunction.jr";
+		resource hc:Claim = "hc:Claim";
+		resource hc:code = "hc:code";
+		resource hc:alt_code = "hc:alt_code";
+		resource hc:status = "hc:status";
+		resource hc:is_a = "hc:is_a";
+		resource hc:amount = "hc:amount";
+		resource hc:is_large = "hc:is_large";
+		` + rules)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return jrCompiler
+}
+
+// newOrReteSession returns the rdf session and the rete session of the compiled rules
+func newOrReteSession(t *testing.T, jrCompiler *Compiler) (*rdf.RdfSession, *rete.ReteSession) {
+	data, err := json.Marshal(jrCompiler.JetRuleModel())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reteModel := &rete.JetruleModel{}
+	if err = json.Unmarshal(data, reteModel); err != nil {
+		t.Fatal(err)
+	}
+	factory, err := rete.NewReteMetaStoreFactoryFromModels(nil, "", map[string]*rete.JetruleModel{"disjunction.jr": reteModel})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdfSession := rdf.NewRdfSession(factory.ResourceMgr, factory.MetaGraph)
+	reteSession := rete.NewReteSession(rdfSession)
+	reteSession.Initialize(factory.MetaStoreLookup["disjunction.jr"])
+	return rdfSession, reteSession
+}
+
+func TestJetRuleListener_OrBlock(t *testing.T) {
+	jrCompiler := compileOrRule(t, `
+		[R01]:
+		(?c rdf:type hc:Claim).
+		or {
+			(?c hc:code "A")
+		} {
+			# second branch
+			(?c hc:alt_code "A").(?c hc:status "open")
+		}
+		->
+		(?c hc:is_a "yes");`)
+	if jrCompiler.ErrorLog().Len() > 0 {
+		t.Fatal(jrCompiler.ErrorLog().String())
+	}
+	model := jrCompiler.JetRuleModel()
+	if len(model.Jetrules) != 2 {
+		t.Fatalf("Expecting 2 rules, got %d", len(model.Jetrules))
+	}
+	expectedLabels := []string{
+		`[R01.1]:(?x01 rdf:type hc:Claim).(?x01 hc:code text(A)) -> (?x01 hc:is_a text(yes));`,
+		`[R01.2]:(?x01 rdf:type hc:Claim).(?x01 hc:alt_code text(A)).(?x01 hc:status text(open)) -> (?x01 hc:is_a text(yes));`,
+	}
+	for i, rule := range model.Jetrules {
+		switch {
+		case rule.BranchOf != "R01":
+			t.Errorf("Expecting rule %s to be a branch of R01, got %s", rule.Name, rule.BranchOf)
+		case !rule.IsValid:
+			t.Errorf("Expecting rule %s to be valid", rule.Name)
+		case rule.NormalizedLabel != expectedLabels[i]:
+			t.Errorf("Unexpected normalized label: %s", rule.NormalizedLabel)
+		}
+	}
+	// The branches share the first antecedent in the rete network
+	if len(model.ReteNodes[1].ChildrenVertexes) != 2 {
+		t.Errorf("Expecting 2 branches under the first antecedent, got %v", model.ReteNodes[1].ChildrenVertexes)
+	}
+
+	// Execute the rules
+	rdfSession, reteSession := newOrReteSession(t, jrCompiler)
+	defer reteSession.Done()
+	rm := rdfSession.ResourceMgr
+	rdfType := rm.NewResource("rdf:type")
+	claim := rm.NewResource("hc:Claim")
+	c1 := rm.NewResource("c1")
+	c2 := rm.NewResource("c2")
+	c3 := rm.NewResource("c3")
+	rdfSession.Insert(c1, rdfType, claim)
+	rdfSession.Insert(c1, rm.NewResource("hc:code"), rm.NewTextLiteral("A"))
+	rdfSession.Insert(c2, rdfType, claim)
+	rdfSession.Insert(c2, rm.NewResource("hc:alt_code"), rm.NewTextLiteral("A"))
+	rdfSession.Insert(c2, rm.NewResource("hc:status"), rm.NewTextLiteral("open"))
+	rdfSession.Insert(c3, rdfType, claim)
+	rdfSession.Insert(c3, rm.NewResource("hc:alt_code"), rm.NewTextLiteral("A"))
+	if err := reteSession.ExecuteRules(); err != nil {
+		t.Fatal(err)
+	}
+	isA := rm.NewResource("hc:is_a")
+	yes := rm.NewTextLiteral("yes")
+	if !rdfSession.Contains(c1, isA, yes) {
+		t.Error("Expecting (c1 hc:is_a \"yes\") from the first branch")
+	}
+	if !rdfSession.Contains(c2, isA, yes) {
+		t.Error("Expecting (c2 hc:is_a \"yes\") from the second branch")
+	}
+	if rdfSession.Contains(c3, isA, yes) {
+		t.Error("Not expecting (c3 hc:is_a \"yes\")")
+	}
+}
+
+// Two or blocks expand into one rule per combination of their branches, the filters
+// of the branch antecedents are kept
+func TestJetRuleListener_OrBlocks(t *testing.T) {
+	jrCompiler := compileOrRule(t, `
+		[R02]:
+		(?c rdf:type hc:Claim).
+		or { (?c hc:code ?code) } { (?c hc:alt_code ?code) }.
+		or { (?c hc:amount ?amt).[?amt > 100] } { (?c hc:status "large") }
+		->
+		(?c hc:is_large true);`)
+	if jrCompiler.ErrorLog().Len() > 0 {
+		t.Fatal(jrCompiler.ErrorLog().String())
+	}
+	model := jrCompiler.JetRuleModel()
+	var names []string
+	for _, rule := range model.Jetrules {
+		names = append(names, rule.Name)
+		if rule.BranchOf != "R02" || !rule.IsValid {
+			t.Errorf("Expecting valid rule %s to be a branch of R02, got %s", rule.Name, rule.BranchOf)
+		}
+	}
+	if strings.Join(names, ",") != "R02.1,R02.2,R02.3,R02.4" {
+		t.Fatalf("Unexpected expanded rules: %v", names)
+	}
+	expectedLabel := `[R02.1]:(?x01 rdf:type hc:Claim).(?x01 hc:code ?x02).(?x01 hc:amount ?x03).[(?x03 > int(100))] -> (?x01 hc:is_large keyword(true));`
+	if model.Jetrules[0].NormalizedLabel != expectedLabel {
+		t.Errorf("Unexpected normalized label: %s", model.Jetrules[0].NormalizedLabel)
+	}
+
+	// Execute the rules
+	rdfSession, reteSession := newOrReteSession(t, jrCompiler)
+	defer reteSession.Done()
+	rm := rdfSession.ResourceMgr
+	rdfType := rm.NewResource("rdf:type")
+	claim := rm.NewResource("hc:Claim")
+	c1 := rm.NewResource("c1")
+	c2 := rm.NewResource("c2")
+	c3 := rm.NewResource("c3")
+	rdfSession.Insert(c1, rdfType, claim)
+	rdfSession.Insert(c1, rm.NewResource("hc:code"), rm.NewTextLiteral("A"))
+	rdfSession.Insert(c1, rm.NewResource("hc:amount"), rm.NewIntLiteral(150))
+	rdfSession.Insert(c2, rdfType, claim)
+	rdfSession.Insert(c2, rm.NewResource("hc:alt_code"), rm.NewTextLiteral("B"))
+	rdfSession.Insert(c2, rm.NewResource("hc:status"), rm.NewTextLiteral("large"))
+	rdfSession.Insert(c3, rdfType, claim)
+	rdfSession.Insert(c3, rm.NewResource("hc:code"), rm.NewTextLiteral("C"))
+	rdfSession.Insert(c3, rm.NewResource("hc:amount"), rm.NewIntLiteral(50))
+	if err := reteSession.ExecuteRules(); err != nil {
+		t.Fatal(err)
+	}
+	isLarge := rm.NewResource("hc:is_large")
+	switch {
+	case !rdfSession.Contains(c1, isLarge, rm.NewBoolLiteral(true)):
+		t.Error("Expecting (c1 hc:is_large true) from R02.1")
+	case !rdfSession.Contains(c2, isLarge, rm.NewBoolLiteral(true)):
+		t.Error("Expecting (c2 hc:is_large true) from R02.4")
+	case rdfSession.ContainsSP(c3, isLarge):
+		t.Error("Not expecting hc:is_large for c3")
+	}
+}
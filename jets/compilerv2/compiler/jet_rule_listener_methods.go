@@ -59,6 +59,7 @@ func (s *JetRuleListener) EnterDefineClassStmt(ctx *parser.DefineClassStmtContex
 	if ctx.GetClassName() != nil {
 		name := EscR(ctx.GetClassName().GetText())
 		s.AddR(name)
+		s.addSymbol(ctx.GetClassName().GetStart(), &Symbol{Name: name, Kind: SymbolClass})
 		s.currentClass = &rete.ClassNode{
 			Type:           "class",
 			Name:           name,
@@ -106,6 +107,13 @@ func (s *JetRuleListener) ExitDataPropertyDefinitions(ctx *parser.DataPropertyDe
 			AsArray:   ctx.GetArray() != nil,
 		}
 		s.currentClass.DataProperties = append(s.currentClass.DataProperties, dp)
+		s.addSymbol(ctx.GetDataPName().GetStart(), &Symbol{
+			Name:     name,
+			Kind:     SymbolDataProperty,
+			DataType: dp.Type,
+			IsArray:  dp.AsArray,
+			Owner:    dp.ClassName,
+		})
 	}
 }
 
@@ -130,6 +138,7 @@ func (s *JetRuleListener) ExitDefineRuleSeqStmt(ctx *parser.DefineRuleSeqStmtCon
 	if s.currentRuleSequence != nil {
 		if ctx.GetRuleseqName() != nil {
 			s.currentRuleSequence.Name = ctx.GetRuleseqName().GetText()
+			s.addSymbol(ctx.GetRuleseqName(), &Symbol{Name: s.currentRuleSequence.Name, Kind: SymbolRuleSequence})
 			s.jetRuleModel.RuleSequences = append(s.jetRuleModel.RuleSequences, s.currentRuleSequence)
 		}
 		s.currentRuleSequence = nil
@@ -139,102 +148,81 @@ func (s *JetRuleListener) ExitDefineRuleSeqStmt(ctx *parser.DefineRuleSeqStmtCon
 // =====================================================================================
 // Literals
 // -------------------------------------------------------------------------------------
+// addLiteral adds the literal resource varName of type dataType and records its symbol
+func (s *JetRuleListener) addLiteral(varName parser.IDeclIdentifierContext, dataType, value string) {
+	r := s.AddResource(&rete.ResourceNode{
+		Type:  dataType,
+		Id:    varName.GetText(),
+		Value: value,
+	})
+	s.addSymbol(varName.GetStart(), &Symbol{
+		Name:     r.Id,
+		Kind:     SymbolLiteral,
+		DataType: r.Type,
+		Value:    r.Value,
+	})
+}
+
 // ExitInt32LiteralStmt is called when production int32Literal is exited.
 func (s *JetRuleListener) ExitInt32LiteralStmt(ctx *parser.Int32LiteralStmtContext) {
 	if ctx.GetVarType() != nil && ctx.GetVarName() != nil && ctx.GetDeclValue() != nil {
-		s.AddResource(&rete.ResourceNode{
-			Type:  ctx.GetVarType().GetText(),
-			Id:    ctx.GetVarName().GetText(),
-			Value: ctx.GetDeclValue().GetText(),
-		})
+		s.addLiteral(ctx.GetVarName(), ctx.GetVarType().GetText(), ctx.GetDeclValue().GetText())
 	}
 }
 
 // exitUInt32LiteralStmt is called when production uint32Literal is exited.
 func (s *JetRuleListener) ExitUInt32LiteralStmt(ctx *parser.UInt32LiteralStmtContext) {
 	if ctx.GetVarType() != nil && ctx.GetVarName() != nil && ctx.GetDeclValue() != nil {
-		s.AddResource(&rete.ResourceNode{
-			Type:  ctx.GetVarType().GetText(),
-			Id:    ctx.GetVarName().GetText(),
-			Value: ctx.GetDeclValue().GetText(),
-		})
+		s.addLiteral(ctx.GetVarName(), ctx.GetVarType().GetText(), ctx.GetDeclValue().GetText())
 	}
 }
 
 // ExitInt64LiteralStmt is called when production int64Literal is exited.
 func (s *JetRuleListener) ExitInt64LiteralStmt(ctx *parser.Int64LiteralStmtContext) {
 	if ctx.GetVarType() != nil && ctx.GetVarName() != nil && ctx.GetDeclValue() != nil {
-		s.AddResource(&rete.ResourceNode{
-			Type:  ctx.GetVarType().GetText(),
-			Id:    ctx.GetVarName().GetText(),
-			Value: ctx.GetDeclValue().GetText(),
-		})
+		s.addLiteral(ctx.GetVarName(), ctx.GetVarType().GetText(), ctx.GetDeclValue().GetText())
 	}
 }
 
 // ExitUInt64LiteralStmt is called when production uint64Literal is exited.
 func (s *JetRuleListener) ExitUInt64LiteralStmt(ctx *parser.UInt64LiteralStmtContext) {
 	if ctx.GetVarType() != nil && ctx.GetVarName() != nil && ctx.GetDeclValue() != nil {
-		s.AddResource(&rete.ResourceNode{
-			Type:  ctx.GetVarType().GetText(),
-			Id:    ctx.GetVarName().GetText(),
-			Value: ctx.GetDeclValue().GetText(),
-		})
+		s.addLiteral(ctx.GetVarName(), ctx.GetVarType().GetText(), ctx.GetDeclValue().GetText())
 	}
 }
 
 // exitDoubleLiteralStmt is called when production doubleLiteral is exited.
 func (s *JetRuleListener) ExitDoubleLiteralStmt(ctx *parser.DoubleLiteralStmtContext) {
 	if ctx.GetVarType() != nil && ctx.GetVarName() != nil && ctx.GetDeclValue() != nil {
-		s.AddResource(&rete.ResourceNode{
-			Type:  ctx.GetVarType().GetText(),
-			Id:    ctx.GetVarName().GetText(),
-			Value: ctx.GetDeclValue().GetText(),
-		})
+		s.addLiteral(ctx.GetVarName(), ctx.GetVarType().GetText(), ctx.GetDeclValue().GetText())
 	}
 }
 
 // exitStringLiteralStmt is called when production stringLiteral is exited.
 func (s *JetRuleListener) ExitStringLiteralStmt(ctx *parser.StringLiteralStmtContext) {
 	if ctx.GetVarType() != nil && ctx.GetVarName() != nil && ctx.GetDeclValue() != nil {
-		s.AddResource(&rete.ResourceNode{
-			Type:  ctx.GetVarType().GetText(),
-			Id:    ctx.GetVarName().GetText(),
-			Value: StripQuotes(ctx.GetDeclValue().GetText()),
-		})
+		s.addLiteral(ctx.GetVarName(), ctx.GetVarType().GetText(), StripQuotes(ctx.GetDeclValue().GetText()))
 	}
 }
 
 // exitDateLiteralStmt is called when production dateLiteral is exited.
 func (s *JetRuleListener) ExitDateLiteralStmt(ctx *parser.DateLiteralStmtContext) {
 	if ctx.GetVarType() != nil && ctx.GetVarName() != nil && ctx.GetDeclValue() != nil {
-		s.AddResource(&rete.ResourceNode{
-			Type:  ctx.GetVarType().GetText(),
-			Id:    ctx.GetVarName().GetText(),
-			Value: StripQuotes(ctx.GetDeclValue().GetText()),
-		})
+		s.addLiteral(ctx.GetVarName(), ctx.GetVarType().GetText(), StripQuotes(ctx.GetDeclValue().GetText()))
 	}
 }
 
 // exitDatetimeLiteralStmt is called when production datetimeLiteral is exited.
 func (s *JetRuleListener) ExitDatetimeLiteralStmt(ctx *parser.DatetimeLiteralStmtContext) {
 	if ctx.GetVarType() != nil && ctx.GetVarName() != nil && ctx.GetDeclValue() != nil {
-		s.AddResource(&rete.ResourceNode{
-			Type:  ctx.GetVarType().GetText(),
-			Id:    ctx.GetVarName().GetText(),
-			Value: StripQuotes(ctx.GetDeclValue().GetText()),
-		})
+		s.addLiteral(ctx.GetVarName(), ctx.GetVarType().GetText(), StripQuotes(ctx.GetDeclValue().GetText()))
 	}
 }
 
 // exitBooleanLiteralStmt is called when production booleanLiteral is exited.
 func (s *JetRuleListener) ExitBooleanLiteralStmt(ctx *parser.BooleanLiteralStmtContext) {
 	if ctx.GetVarType() != nil && ctx.GetVarName() != nil && ctx.GetDeclValue() != nil {
-		s.AddResource(&rete.ResourceNode{
-			Type:  ctx.GetVarType().GetText(),
-			Id:    ctx.GetVarName().GetText(),
-			Value: ctx.GetDeclValue().GetText(),
-		})
+		s.addLiteral(ctx.GetVarName(), ctx.GetVarType().GetText(), ctx.GetDeclValue().GetText())
 	}
 }
 
@@ -270,6 +258,11 @@ func (s *JetRuleListener) ExitNamedResourceStmt(ctx *parser.NamedResourceStmtCon
 		Value:          value,
 		SourceFileName: s.currentRuleFileName,
 	})
+	kind := SymbolResource
+	if typ == "volatile_resource" {
+		kind = SymbolVolatileResource
+	}
+	s.addSymbol(ctx.GetResName().GetStart(), &Symbol{Name: id, Kind: kind, Value: value})
 }
 
 // exitVolatileResourceStmt is called when production volatileResourceStmt is exited.
@@ -287,6 +280,9 @@ func (s *JetRuleListener) ExitVolatileResourceStmt(ctx *parser.VolatileResourceS
 		Value:          value,
 		SourceFileName: s.currentRuleFileName,
 	})
+	if ctx.GetResName() != nil {
+		s.addSymbol(ctx.GetResName().GetStart(), &Symbol{Name: id, Kind: SymbolVolatileResource, Value: value})
+	}
 }
 
 // =====================================================================================
@@ -313,6 +309,14 @@ func (s *JetRuleListener) ExitColumnDefinitions(ctx *parser.ColumnDefinitionsCon
 			IsArray: ctx.GetArray() != nil,
 		}
 		s.currentLookupTableColumns = append(s.currentLookupTableColumns, col)
+		// Column name is a quoted string, the symbol starts after the quote
+		s.addSymbol(ctx.GetColumnName(), &Symbol{
+			Name:     name,
+			Kind:     SymbolLookupColumn,
+			DataType: col.Type,
+			IsArray:  col.IsArray,
+		})
+		s.symbols[len(s.symbols)-1].Column++
 	}
 }
 
@@ -340,6 +344,11 @@ func (s *JetRuleListener) ExitLookupTableStmt(ctx *parser.LookupTableStmtContext
 		SourceFileName: s.currentRuleFileName,
 	}
 	s.jetRuleModel.LookupTables = append(s.jetRuleModel.LookupTables, lookupTbl)
+	// The columns are declared before the table, set their owner
+	for i := len(s.symbols) - 1; i >= 0 && s.symbols[i].Kind == SymbolLookupColumn && s.symbols[i].Owner == ""; i-- {
+		s.symbols[i].Owner = name
+	}
+	s.addSymbol(ctx.GetLookupName().GetStart(), &Symbol{Name: name, Kind: SymbolLookupTable})
 	s.currentLookupTableColumns = nil
 }
 
@@ -350,6 +359,7 @@ func (s *JetRuleListener) ExitLookupTableStmt(ctx *parser.LookupTableStmtContext
 func (s *JetRuleListener) EnterJetRuleStmt(ctx *parser.JetRuleStmtContext) {
 	s.currentRuleProperties = make(map[string]string)
 	s.currentRuleVarByValue = make(map[string]*rete.ResourceNode)
+	s.currentRuleOrBlocks = nil
 	s.currentJetruleNode = &rete.JetruleNode{}
 }
 
@@ -361,32 +371,41 @@ func (s *JetRuleListener) ExitJetRuleStmt(ctx *parser.JetRuleStmtContext) {
 		return
 	}
 	s.currentJetruleNode.Name = ctx.GetRuleName().GetText()
+	s.addSymbol(ctx.GetRuleName(), &Symbol{Name: s.currentJetruleNode.Name, Kind: SymbolRule})
 	s.currentJetruleNode.Properties = s.currentRuleProperties
 	s.currentJetruleNode.Antecedents = s.currentRuleAntecedents
 	s.currentJetruleNode.Consequents = s.currentRuleConsequents
 	s.currentJetruleNode.SourceFileName = s.currentRuleFileName
 
-	// Post process the rule properties
-	s.PostProcessJetruleProperties(s.currentJetruleNode)
+	// Expand the or blocks into one rule per branch
+	rules := []*rete.JetruleNode{s.currentJetruleNode}
+	if len(s.currentRuleOrBlocks) > 0 {
+		rules = s.expandOrBlocks(s.currentJetruleNode, s.currentRuleOrBlocks)
+	}
+	for _, rule := range rules {
+		// Post process the rule properties
+		s.PostProcessJetruleProperties(rule)
+
+		// Validate the rule and optimize it if valid
+		rule.IsValid = s.ValidateJetruleNode(rule)
 
-	// Validate the rule and optimize it if valid
-	s.currentJetruleNode.IsValid = s.ValidateJetruleNode(s.currentJetruleNode)
+		// Add rule AuthoredLabel, Label, and NormalizedLabel
+		s.PostProcessJetruleNode(rule)
 
-	// Add rule AuthoredLabel, Label, and NormalizedLabel
-	s.PostProcessJetruleNode(s.currentJetruleNode)
+		// Optimize the rule only if valid and optimization is enabled
+		// will update Label and NormalizedLabel accordingly
+		s.OptimizeJetruleNode(rule)
 
-	// Optimize the rule only if valid and optimization is enabled
-	// will update Label and NormalizedLabel accordingly
-	s.OptimizeJetruleNode(s.currentJetruleNode)
+		// Append to the model
+		s.jetRuleModel.Jetrules = append(s.jetRuleModel.Jetrules, rule)
+	}
 
 	// Reset current rule state
 	s.currentRuleProperties = nil
 	s.currentRuleAntecedents = nil
 	s.currentRuleConsequents = nil
+	s.currentRuleOrBlocks = nil
 	s.currentRuleVarByValue = nil
-
-	// Append to the model
-	s.jetRuleModel.Jetrules = append(s.jetRuleModel.Jetrules, s.currentJetruleNode)
 	s.currentJetruleNode = nil
 }
 
@@ -541,22 +560,30 @@ func (s *JetRuleListener) EnterAntecedent(ctx *parser.AntecedentContext) {
 
 // exitAntecedent is called when production antecedent is exited.
 func (s *JetRuleListener) ExitAntecedent(ctx *parser.AntecedentContext) {
-	if ctx.GetS() == nil || ctx.GetP() == nil || ctx.GetO() == nil {
-		return
-	}
-	kws := ""
-	if ctx.GetO().GetKws() != nil {
-		kws = ctx.GetO().GetKws().GetText()
+	var ruleTerm *rete.RuleTerm
+	if ctx.GetAgg() != nil {
+		ruleTerm = s.aggregationAntecedent(ctx)
+	} else {
+		if ctx.GetS() == nil || ctx.GetP() == nil || ctx.GetO() == nil {
+			return
+		}
+		kws := ""
+		if ctx.GetO().GetKws() != nil {
+			kws = ctx.GetO().GetKws().GetText()
+		}
+		S := s.ParseObjectAtom(EscR(ctx.GetS().GetText()), "")
+		P := s.ParseObjectAtom(EscR(ctx.GetP().GetText()), "")
+		O := s.ParseObjectAtom(EscR(ctx.GetO().GetText()), kws)
+		ruleTerm = &rete.RuleTerm{
+			Type:         "antecedent",
+			IsNot:        ctx.GetN() != nil,
+			SubjectKey:   S.Key,
+			PredicateKey: P.Key,
+			ObjectKey:    O.Key,
+		}
 	}
-	S := s.ParseObjectAtom(EscR(ctx.GetS().GetText()), "")
-	P := s.ParseObjectAtom(EscR(ctx.GetP().GetText()), "")
-	O := s.ParseObjectAtom(EscR(ctx.GetO().GetText()), kws)
-	ruleTerm := &rete.RuleTerm{
-		Type:         "antecedent",
-		IsNot:        ctx.GetN() != nil,
-		SubjectKey:   S.Key,
-		PredicateKey: P.Key,
-		ObjectKey:    O.Key,
+	if ruleTerm == nil {
+		return
 	}
 	// Add filter
 	if s.inProgressExpr.Len() > 0 {
@@ -575,6 +602,54 @@ func (s *JetRuleListener) ExitAntecedent(ctx *parser.AntecedentContext) {
 	s.currentRuleAntecedents = append(s.currentRuleAntecedents, ruleTerm)
 }
 
+// aggregationAntecedent returns the rule term of the aggregation antecedent, e.g.
+// (?n = count(?line where (?c hc:has_line ?line))), the triple of the rule term is the
+// where triple of the aggregate term. Returns nil if the aggregate term is not valid.
+func (s *JetRuleListener) aggregationAntecedent(ctx *parser.AntecedentContext) *rete.RuleTerm {
+	if ctx.GetV() == nil {
+		return nil
+	}
+	V := s.ParseObjectAtom(EscR(ctx.GetV().GetText()), "")
+	if V == nil || V.Type != "var" {
+		fmt.Fprintf(s.errorLog, "** error: aggregate value must be assigned to a variable, got '%s'\n", ctx.GetV().GetText())
+		return nil
+	}
+	aggregate := s.aggregateTerm(ctx.GetAgg())
+	if aggregate == nil {
+		return nil
+	}
+	aggregate.ResultKey = V.Key
+	return &rete.RuleTerm{
+		Type:         "antecedent",
+		IsNot:        ctx.GetN() != nil,
+		SubjectKey:   aggregate.SubjectKey,
+		PredicateKey: aggregate.PredicateKey,
+		ObjectKey:    aggregate.ObjectKey,
+		Aggregate:    aggregate,
+	}
+}
+
+// aggregateTerm returns the aggregate node of the aggregate term, e.g. count(?line where (?c hc:has_line ?line)).
+// The where keyword is contextual, it is parsed as an identifier and checked here.
+// Returns nil if the aggregate term is not valid.
+func (s *JetRuleListener) aggregateTerm(aggCtx parser.IAggregateTermContext) *rete.AggregateNode {
+	if aggCtx.GetOp() == nil || aggCtx.GetAggVar() == nil || aggCtx.GetKw() == nil || aggCtx.GetS() == nil ||
+		aggCtx.GetP() == nil || aggCtx.GetO() == nil {
+		return nil
+	}
+	if aggCtx.GetKw().GetText() != "where" {
+		fmt.Fprintf(s.errorLog, "** error: expecting 'where' in aggregate term '%s', got '%s'\n",
+			aggCtx.GetText(), aggCtx.GetKw().GetText())
+		return nil
+	}
+	kws := ""
+	if aggCtx.GetO().GetKws() != nil {
+		kws = aggCtx.GetO().GetKws().GetText()
+	}
+	return s.ParseAggregateTerm(aggCtx.GetOp().GetText(), aggCtx.GetAggVar().GetText(),
+		aggCtx.GetS().GetText(), aggCtx.GetP().GetText(), aggCtx.GetO().GetText(), kws)
+}
+
 // Consequent Definition
 // -------------------------------------------------------------------------------------
 // enterConsequent is called when production consequent is entered.
@@ -585,7 +660,8 @@ func (s *JetRuleListener) EnterConsequent(ctx *parser.ConsequentContext) {
 
 // exitConsequent is called when production consequent is exited.
 func (s *JetRuleListener) ExitConsequent(ctx *parser.ConsequentContext) {
-	if ctx.GetS() == nil || ctx.GetP() == nil || ctx.GetO() == nil {
+	aggCtx := ctx.GetAgg()
+	if ctx.GetS() == nil || ctx.GetP() == nil || (ctx.GetO() == nil && aggCtx == nil) {
 		return
 	}
 	S := s.ParseObjectAtom(EscR(ctx.GetS().GetText()), "")
@@ -595,6 +671,13 @@ func (s *JetRuleListener) ExitConsequent(ctx *parser.ConsequentContext) {
 		SubjectKey:   S.Key,
 		PredicateKey: P.Key,
 	}
+	// Add the aggregate term
+	if aggCtx != nil {
+		ruleTerm.Aggregate = s.aggregateTerm(aggCtx)
+		if ruleTerm.Aggregate == nil {
+			return
+		}
+	}
 	// Add object expression
 	if s.inProgressExpr.Len() > 0 {
 		expr, ok := s.inProgressExpr.Pop()
@@ -33,7 +33,11 @@ func (s *JetRuleListener) OptimizeJetruleNode(rule *rete.JetruleNode) {
 	}
 
 	// Simple greedy algorithm to reorder antecedents
+	// The aggregation antecedents are not reordered, they are kept after the other
+	// antecedents so that the variables of their where triple that are binded by the
+	// other antecedents are binded by their parent beta row
 	ordered := make([]*rete.RuleTerm, 0, n)
+	aggregations := make([]*rete.RuleTerm, 0)
 	filters := make([]*rete.ExpressionNode, 0)
 	// Collect filters from original rule's antecedent so to reallocated them after
 	// the antecedent have been reordered
@@ -43,6 +47,13 @@ func (s *JetRuleListener) OptimizeJetruleNode(rule *rete.JetruleNode) {
 			antecedents[i].Filter = nil
 		}
 	}
+	antecedents = slices.DeleteFunc(slices.Clone(antecedents), func(a *rete.RuleTerm) bool {
+		if a.Aggregate != nil {
+			aggregations = append(aggregations, a)
+			return true
+		}
+		return false
+	})
 
 	// Keep track of the binded variables
 	bindedVars := make(map[string]bool)
@@ -86,6 +97,7 @@ func (s *JetRuleListener) OptimizeJetruleNode(rule *rete.JetruleNode) {
 		// remove it from the antecedents list
 		antecedents = slices.Delete(antecedents, best.index, best.index+1)
 	}
+	ordered = append(ordered, aggregations...)
 
 	if len(filters) == 0 {
 		// No filters to reallocate
@@ -167,8 +179,14 @@ post_process:
 
 	// Re-normalize the rule's variable names
 	varIdByKey := make(map[int]string)
-	// visit the rule antecedents in order and collect the variable keys
+	// visit the rule antecedents in order and collect the variable keys,
+	// the variable holding the value of an aggregation antecedent comes first
 	for _, a := range rule.Antecedents {
+		if a.Aggregate != nil && varIdByKey[a.Aggregate.ResultKey] == "" {
+			r := s.resourceManager.ResourceByKey[a.Aggregate.ResultKey]
+			r.Id = fmt.Sprintf("?x%02d", len(varIdByKey)+1)
+			varIdByKey[a.Aggregate.ResultKey] = r.Id
+		}
 		if a.SubjectKey > 0 && varIdByKey[a.SubjectKey] == "" {
 			r := s.resourceManager.ResourceByKey[a.SubjectKey]
 			if r.Type == "var" {
@@ -192,13 +210,35 @@ post_process:
 		}
 	}
 
+	// The variables local to the aggregate terms of the consequents are numbered after
+	// the antecedent variables
+	for _, c := range rule.Consequents {
+		if c.Aggregate == nil {
+			continue
+		}
+		for _, key := range []int{c.Aggregate.SubjectKey, c.Aggregate.PredicateKey, c.Aggregate.ObjectKey} {
+			if key > 0 && varIdByKey[key] == "" {
+				r := s.resourceManager.ResourceByKey[key]
+				if r.Type == "var" {
+					r.Id = fmt.Sprintf("?x%02d", len(varIdByKey)+1)
+					varIdByKey[key] = r.Id
+				}
+			}
+		}
+	}
+
 	// Update rule's Label and NormalizedLabel
 	rule.Label = s.makeRuleLabel(rule, false)
 	rule.NormalizedLabel = s.makeRuleLabel(rule, true)
 }
 
 // collectVars updates the collectedVars map with the variables in the antecedent.
+// The aggregation antecedent binds only the variable holding the aggregate value.
 func (s *JetRuleListener) collectVars(collectedVars map[string]bool, antecedent *rete.RuleTerm) {
+	if antecedent.Aggregate != nil {
+		collectedVars[s.resourceManager.ResourceByKey[antecedent.Aggregate.ResultKey].Id] = true
+		return
+	}
 	if antecedent.SubjectKey > 0 {
 		r := s.resourceManager.ResourceByKey[antecedent.SubjectKey]
 		if r.Type == "var" {
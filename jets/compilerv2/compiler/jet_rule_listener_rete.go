@@ -71,6 +71,11 @@ func (l *JetRuleListener) BuildReteNetwork() {
 					fmt.Fprintf(l.parseLog, "***   No matching Rete node found, using antecedent: %s\n", antecedent.NormalizedLabel)
 				}
 				reteNode = antecedent
+				if reteNode.Aggregate != nil {
+					// Aggregation antecedent, keep the operator, the position of the aggregated
+					// var and the var holding the aggregate value
+					reteNode.Aggregate = newAggregateOperatorNode(reteNode.Aggregate, reteNode.Aggregate.ResultKey)
+				}
 				l.jetRuleModel.ReteNodes = append(l.jetRuleModel.ReteNodes, reteNode)
 				// Set the vertex and parent vertex
 				reteNode.Vertex = len(l.jetRuleModel.ReteNodes) - 1 // index in the slice
@@ -96,17 +101,25 @@ func (l *JetRuleListener) BuildReteNetwork() {
 		//   - associated with the consequent term of the rule
 		// Set the vertex for each consequent to be the last antecedent's vertex (current value of
 		// parentVertex)
+		// Consequents with an aggregate term are associated with the aggregate antecedent
+		// added as a child of the last antecedent, see addAggregateNode
 		lastAntecedent := l.jetRuleModel.ReteNodes[parentVertex]
 		lastAntecedent.Rules = append(lastAntecedent.Rules, rule.Name)
 		lastAntecedent.Salience = append(lastAntecedent.Salience, rule.Salience)
-		consequentOffset := len(consequentsByVertex[parentVertex])
-		for i := range rule.Consequents {
-			rule.Consequents[i].Vertex = parentVertex
-			rule.Consequents[i].ConsequentForRule = rule.Name
-			rule.Consequents[i].ConsequentSalience = rule.Salience
-			rule.Consequents[i].ConsequentSeq = consequentOffset + i + 1
+		for _, consequent := range rule.Consequents {
+			vertex := parentVertex
+			if consequent.Aggregate != nil {
+				aggregateNode := l.addAggregateNode(parentVertex, consequent)
+				aggregateNode.Rules = append(aggregateNode.Rules, rule.Name)
+				aggregateNode.Salience = append(aggregateNode.Salience, rule.Salience)
+				vertex = aggregateNode.Vertex
+			}
+			consequent.Vertex = vertex
+			consequent.ConsequentForRule = rule.Name
+			consequent.ConsequentSalience = rule.Salience
+			consequent.ConsequentSeq = len(consequentsByVertex[vertex]) + 1
+			consequentsByVertex[vertex] = append(consequentsByVertex[vertex], consequent)
 		}
-		consequentsByVertex[parentVertex] = append(consequentsByVertex[parentVertex], rule.Consequents...)
 	}
 
 	// Add the consequent nodes at the end of the antecedent nodes
@@ -144,6 +157,73 @@ func (l *JetRuleListener) reteNodeByNormalizedLabel(parentVertex int, normalized
 	return nil
 }
 
+// addAggregateNode adds the aggregate antecedent of the consequent's aggregate term as a
+// child of parentVertex, or use the existing one having the same normalized label.
+// The aggregate antecedent is the where triple of the aggregate term, its beta rows have the
+// aggregate value as the object of a virtual triple, see rete.AggregateOperator.
+// The consequent's object is replaced with the var holding the aggregate value, ?aggNN
+// where NN is the vertex of the aggregate antecedent.
+func (l *JetRuleListener) addAggregateNode(parentVertex int, consequent *rete.RuleTerm) *rete.RuleTerm {
+	aggregate := consequent.Aggregate
+	normalizedLabel := l.makeAggregateLabel(aggregate, true)
+	reteNode := l.reteNodeByNormalizedLabel(parentVertex, normalizedLabel)
+	if reteNode == nil {
+		reteNode = &rete.RuleTerm{
+			Type:             "antecedent",
+			NormalizedLabel:  normalizedLabel,
+			ParentVertex:     parentVertex,
+			ChildrenVertexes: []int{},
+			Rules:            []string{},
+			Salience:         []int{},
+			SubjectKey:       aggregate.SubjectKey,
+			PredicateKey:     aggregate.PredicateKey,
+			ObjectKey:        aggregate.ObjectKey,
+		}
+		l.jetRuleModel.ReteNodes = append(l.jetRuleModel.ReteNodes, reteNode)
+		reteNode.Vertex = len(l.jetRuleModel.ReteNodes) - 1
+		parentNode := l.jetRuleModel.ReteNodes[parentVertex]
+		parentNode.ChildrenVertexes = append(parentNode.ChildrenVertexes, reteNode.Vertex)
+		// The var holding the aggregate value, a temp var replaced in BuildBetaNodesRecursively
+		aggregateVar := &rete.ResourceNode{
+			Type:  "var",
+			Id:    aggregateVarId(reteNode.Vertex),
+			Value: aggregateVarId(reteNode.Vertex),
+		}
+		l.newResource(aggregateVar)
+		reteNode.Aggregate = newAggregateOperatorNode(aggregate, aggregateVar.Key)
+		if l.trace {
+			fmt.Fprintf(l.parseLog, "***   Adding aggregate Rete node: %s (vertex %d)\n", normalizedLabel, reteNode.Vertex)
+		}
+	}
+	consequent.ObjectKey = reteNode.Aggregate.ResultKey
+	consequent.Aggregate = nil
+	return reteNode
+}
+
+// newAggregateOperatorNode returns the Aggregate of the aggregate antecedent of the rete
+// network for the aggregate term, it has the operator, the position of the aggregated
+// var in the where triple and the key of the var holding the aggregate value.
+func newAggregateOperatorNode(aggregate *rete.AggregateNode, resultKey int) *rete.AggregateNode {
+	varPos := 2
+	switch aggregate.VarKey {
+	case aggregate.SubjectKey:
+		varPos = 0
+	case aggregate.PredicateKey:
+		varPos = 1
+	}
+	return &rete.AggregateNode{
+		Operator:  aggregate.Operator,
+		VarPos:    varPos,
+		ResultKey: resultKey,
+	}
+}
+
+// aggregateVarId returns the id of the var holding the aggregate value of the
+// aggregate antecedent at vertex
+func aggregateVarId(vertex int) string {
+	return fmt.Sprintf("?agg%02d", vertex)
+}
+
 // Collect all the var resources used by the argument r and it's descendents.
 // The argument doNodeTriples is used to control if the triple associated with the
 // antecedent node is processed for var collection.
@@ -215,7 +295,13 @@ func (l *JetRuleListener) BuildBetaNodesRecursively(node *rete.RuleTerm,
 	l.CollectDescendentsReqVars(descendentsReqVars, node, false, consequentsByVertex)
 
 	// Get the var position in the triple of current antecedent
+	// The aggregate antecedent binds only the var holding the aggregate value, which is
+	// the object of the virtual triple of its beta rows
 	varPos := l.getVarPosition(node)
+	if node.Aggregate != nil {
+		pos := 2
+		varPos = map[string]*int{l.resourceManager.ResourceByKey[node.Aggregate.ResultKey].Id: &pos}
+	}
 
 	// //***
 	// fmt.Fprintf(l.parseLog, ">>> Got bindedVars: %v, descendentsReqVars: %v, varPos: %v for node vertex %d, parent %d, %s\n",
@@ -341,7 +427,11 @@ func (l *JetRuleListener) BuildBetaNodesRecursively(node *rete.RuleTerm,
 
 	// Replace the var in node's term, excluding filters
 	// with the actual ResourceNode created above
-	l.replaceVarInTerm(node, newVars)
+	if node.Aggregate != nil {
+		l.replaceVarInAggregateTerm(node)
+	} else {
+		l.replaceVarInTerm(node, newVars)
+	}
 
 	// Replace the var in node's filter & obj_expr with the binded version
 	if node.Filter != nil {
@@ -416,6 +506,33 @@ func (l *JetRuleListener) replaceVarInTerm(term *rete.RuleTerm, newVars map[stri
 	}
 }
 
+// Replace the var in the where triple of the aggregate antecedent. The where triple is
+// matched for each parent beta row: the vars binded by the parent beta row are at their
+// position in the parent BetaRelationVars, the other vars are local to the aggregate term.
+func (l *JetRuleListener) replaceVarInAggregateTerm(term *rete.RuleTerm) {
+	parentNode := l.jetRuleModel.ReteNodes[term.ParentVertex]
+	replaceVar := func(key, triplePos int) int {
+		r := l.resourceManager.ResourceByKey[key]
+		if r == nil || r.Type != "var" {
+			return key
+		}
+		newR := &rete.ResourceNode{
+			Type:   "var",
+			Id:     r.Id,
+			Vertex: term.Vertex,
+			VarPos: triplePos,
+		}
+		if pos := getPosInBetaRelationVars(r.Id, parentNode.BetaRelationVars); pos >= 0 {
+			newR.IsBinded = true
+			newR.VarPos = pos
+		}
+		return l.addVarResourceByDomainKey(newR).Key
+	}
+	term.SubjectKey = replaceVar(term.SubjectKey, 0)
+	term.PredicateKey = replaceVar(term.PredicateKey, 1)
+	term.ObjectKey = replaceVar(term.ObjectKey, 2)
+}
+
 func (s *JetRuleListener) replaceVarInExpr(expr *rete.ExpressionNode, newVars map[string]*rete.ResourceNode) {
 	if expr == nil {
 		return
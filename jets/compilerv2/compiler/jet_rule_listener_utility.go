@@ -71,6 +71,38 @@ func (s *JetRuleListener) ParseObjectAtom(txt string, keywordsContextValue strin
 	return r
 }
 
+// Parse the aggregate term of a consequent, e.g. count(?line where (?c hc:has_line ?line))
+// where op is count, aggVar is ?line and subject, predicate, object are the atoms of the
+// where triple. The aggregate operators are count, sum, min, max.
+// returns the AggregateNode or nil if the aggregate term is not valid.
+// Note: s.currentRuleVarByValue must be initialized (at the start of a rule) before this function is called
+func (s *JetRuleListener) ParseAggregateTerm(op, aggVar, subject, predicate, object, kws string) *rete.AggregateNode {
+	switch op {
+	case "count", "sum", "min", "max":
+	default:
+		fmt.Fprintf(s.errorLog, "** error: unknown aggregate operator '%s', expecting count, sum, min or max\n", op)
+		return nil
+	}
+	V := s.ParseObjectAtom(EscR(aggVar), "")
+	if V == nil || V.Type != "var" {
+		fmt.Fprintf(s.errorLog, "** error: aggregate %s must be over a variable, got '%s'\n", op, aggVar)
+		return nil
+	}
+	S := s.ParseObjectAtom(EscR(subject), "")
+	P := s.ParseObjectAtom(EscR(predicate), "")
+	O := s.ParseObjectAtom(EscR(object), kws)
+	if S == nil || P == nil || O == nil {
+		return nil
+	}
+	return &rete.AggregateNode{
+		Operator:     op,
+		VarKey:       V.Key,
+		SubjectKey:   S.Key,
+		PredicateKey: P.Key,
+		ObjectKey:    O.Key,
+	}
+}
+
 // Variable - Id is the normalized variable name
 // Variables are unique in the context of a rule.
 func (s *JetRuleListener) AddVariable(name string) *rete.ResourceNode {
@@ -61,7 +61,9 @@ func (s *JetRuleListener) ValidateRuleTerm(term *rete.RuleTerm) {
 //   - All ResourceNode of Type "?var" in a filter expression must appear in the antecedent having
 //     the filter or in previous antecedents.
 //   - All ResourceNode of Type "?var" in a negated antecedent must appear in the previous antecedents.
-//   - All ResourceNode of Type "?var" in the Consequents must appear in the Antecedents
+//   - The aggregation antecedents are valid, see validateAggregationAntecedent
+//   - All ResourceNode of Type "?var" in the Consequents must appear in the Antecedents,
+//     except for the variables local to an aggregate term, see validateAggregateTerm
 //
 // Returns true if the rule is valid, false otherwise
 func (s *JetRuleListener) ValidateJetruleNode(rule *rete.JetruleNode) bool {
@@ -69,6 +71,15 @@ func (s *JetRuleListener) ValidateJetruleNode(rule *rete.JetruleNode) bool {
 	// Build a set of visited variables (aka binded variable)
 	visitedVarSet := make(map[string]bool)
 	for i := range rule.Antecedents {
+		if rule.Antecedents[i].Aggregate != nil {
+			if !s.validateAggregationAntecedent(rule, i, visitedVarSet) {
+				isValid = false
+			}
+			if !s.validateFilterVars(rule.Antecedents[i].Filter, visitedVarSet) {
+				isValid = false
+			}
+			continue
+		}
 		//** check for IsNot first before adding current var to varSet (rename to bindedVars)
 		// - All RuleTerm in Antecedents must have at least one of subject, predicate, object as variable
 		hasVar := false
@@ -120,20 +131,8 @@ func (s *JetRuleListener) ValidateJetruleNode(rule *rete.JetruleNode) bool {
 			isValid = false
 		}
 		// Check filter expression for variables
-		expr := rule.Antecedents[i].Filter
-		if expr != nil {
-			// Build a set of variable resource keys from the expression
-			exprVarSet := make(map[int]bool)
-			s.collectVarResourcesFromExpr(expr, exprVarSet)
-			// Check that all variables in the expression are in visitedVarSet
-			for vKey := range exprVarSet {
-				r := s.Resource(vKey)
-				if !visitedVarSet[r.Id] {
-					fmt.Fprintf(s.errorLog,
-						"** error: antecedent filter expression variable %s not found in previous antecedents\n", r.SKey())
-					isValid = false
-				}
-			}
+		if !s.validateFilterVars(rule.Antecedents[i].Filter, visitedVarSet) {
+			isValid = false
 		}
 	}
 	// All ResourceNode of Type "?var" in the Consequents must appear in the visitedVarSet
@@ -178,10 +177,111 @@ func (s *JetRuleListener) ValidateJetruleNode(rule *rete.JetruleNode) bool {
 				}
 			}
 		}
+		if rule.Consequents[i].Aggregate != nil {
+			if !s.validateAggregateTerm(rule.Consequents[i].Aggregate, visitedVarSet) {
+				isValid = false
+			}
+		}
 	}
 	return isValid
 }
 
+// validateFilterVars validates that all the variables of the antecedent filter
+// expression are binded by the antecedent having the filter or by previous antecedents.
+func (s *JetRuleListener) validateFilterVars(expr *rete.ExpressionNode, bindedVars map[string]bool) bool {
+	if expr == nil {
+		return true
+	}
+	isValid := true
+	// Build a set of variable resource keys from the expression
+	exprVarSet := make(map[int]bool)
+	s.collectVarResourcesFromExpr(expr, exprVarSet)
+	// Check that all variables in the expression are in bindedVars
+	for vKey := range exprVarSet {
+		r := s.Resource(vKey)
+		if !bindedVars[r.Id] {
+			fmt.Fprintf(s.errorLog,
+				"** error: antecedent filter expression variable %s not found in previous antecedents\n", r.SKey())
+			isValid = false
+		}
+	}
+	return isValid
+}
+
+// validateAggregationAntecedent validates the aggregation antecedent at position pos
+// of the rule antecedents, e.g. (?n = count(?line where (?c hc:has_line ?line))):
+//   - The aggregation antecedent cannot be negated
+//   - The aggregation antecedents must follow the other antecedents of the rule, the
+//     variables of the where triple are either binded by the other antecedents or local
+//     to the aggregate term
+//   - The aggregate term is valid, see validateAggregateTerm
+//   - The variable holding the aggregate value is not used in the antecedents triples,
+//     it can be used in filters and consequents
+//
+// The variable holding the aggregate value is added to bindedVars.
+func (s *JetRuleListener) validateAggregationAntecedent(rule *rete.JetruleNode, pos int, bindedVars map[string]bool) bool {
+	isValid := true
+	term := rule.Antecedents[pos]
+	agg := term.Aggregate
+	if term.IsNot {
+		fmt.Fprintf(s.errorLog, "** error: aggregation antecedent with aggregate %s cannot be negated\n", agg.Operator)
+		isValid = false
+	}
+	for _, a := range rule.Antecedents[pos+1:] {
+		if a.Aggregate == nil {
+			fmt.Fprintf(s.errorLog,
+				"** error: aggregation antecedent with aggregate %s must follow the other antecedents of the rule\n", agg.Operator)
+			isValid = false
+			break
+		}
+	}
+	if !s.validateAggregateTerm(agg, bindedVars) {
+		isValid = false
+	}
+	v := s.Resource(agg.ResultKey)
+	if bindedVars[v.Id] {
+		fmt.Fprintf(s.errorLog,
+			"** error: variable %s holding the value of aggregate %s is already binded\n", v.SKey(), agg.Operator)
+		isValid = false
+	}
+	for _, a := range rule.Antecedents {
+		if a.SubjectKey == agg.ResultKey || a.PredicateKey == agg.ResultKey || a.ObjectKey == agg.ResultKey {
+			fmt.Fprintf(s.errorLog,
+				"** error: variable %s holding the value of aggregate %s can only be used in filters and consequents\n",
+				v.SKey(), agg.Operator)
+			isValid = false
+			break
+		}
+	}
+	bindedVars[v.Id] = true
+	return isValid
+}
+
+// validateAggregateTerm validates the aggregate term of an aggregation antecedent or of a consequent:
+//   - The aggregated variable must be a variable of the where triple
+//   - The aggregated variable must not be binded by the antecedents
+//
+// The other variables of the where triple are either binded by the antecedents
+// or local to the aggregate term.
+func (s *JetRuleListener) validateAggregateTerm(agg *rete.AggregateNode, bindedVars map[string]bool) bool {
+	v := s.Resource(agg.VarKey)
+	if v == nil || v.Type != "var" {
+		fmt.Fprintf(s.errorLog, "** error: aggregate %s must be over a variable\n", agg.Operator)
+		return false
+	}
+	if agg.VarKey != agg.SubjectKey && agg.VarKey != agg.PredicateKey && agg.VarKey != agg.ObjectKey {
+		fmt.Fprintf(s.errorLog,
+			"** error: aggregated variable %s not found in the where triple of aggregate %s\n", v.SKey(), agg.Operator)
+		return false
+	}
+	if bindedVars[v.Id] {
+		fmt.Fprintf(s.errorLog,
+			"** error: aggregated variable %s of aggregate %s must not appear in the antecedents\n", v.SKey(), agg.Operator)
+		return false
+	}
+	return true
+}
+
 // PostProcessJetruleNode performs post-processing on a JetruleNode:
 //   - Add rule AuthoredLabel, Label and NormalizedLabel
 //
@@ -222,6 +322,7 @@ func (s *JetRuleListener) PostProcessJetruleProperties(rule *rete.JetruleNode) {
 // If normalize is true, variable resources are represented by their Id instead of their Value
 // Example where the Id ?x01 is used:
 // [MyRule, o=true, s=10]: (?x01 rdf:type ex:Person).not(?x01 ex:hasAge ?age) -> (?x01 ex:isAdult true);
+// The aggregation antecedents are labeled as (?x02 = count(?x03 where (?x01 ex:hasChild ?x03))).
 func (l *JetRuleListener) makeRuleLabel(rule *rete.JetruleNode, normalize bool) string {
 	label := &strings.Builder{}
 	fmt.Fprintf(label, "[%s", rule.Name)
@@ -252,10 +353,16 @@ func (l *JetRuleListener) makeRuleLabel(rule *rete.JetruleNode, normalize bool)
 		if a.IsNot {
 			ruleTermLabel.WriteString("not")
 		}
-		fmt.Fprintf(ruleTermLabel, "(%s %s %s)",
-			l.makeResourceLabel(l.Resource(a.SubjectKey), normalize),
-			l.makeResourceLabel(l.Resource(a.PredicateKey), normalize),
-			l.makeResourceLabel(l.Resource(a.ObjectKey), normalize))
+		if a.Aggregate != nil {
+			fmt.Fprintf(ruleTermLabel, "(%s = %s)",
+				l.makeResourceLabel(l.Resource(a.Aggregate.ResultKey), normalize),
+				l.makeAggregateLabel(a.Aggregate, normalize))
+		} else {
+			fmt.Fprintf(ruleTermLabel, "(%s %s %s)",
+				l.makeResourceLabel(l.Resource(a.SubjectKey), normalize),
+				l.makeResourceLabel(l.Resource(a.PredicateKey), normalize),
+				l.makeResourceLabel(l.Resource(a.ObjectKey), normalize))
+		}
 		if a.Filter != nil {
 			ruleTermLabel.WriteString(".[")
 			l.makeExpressionLabel(a.Filter, ruleTermLabel, normalize)
@@ -277,9 +384,12 @@ func (l *JetRuleListener) makeRuleLabel(rule *rete.JetruleNode, normalize bool)
 		fmt.Fprintf(ruleTermLabel, "(%s %s ",
 			l.makeResourceLabel(l.Resource(c.SubjectKey), normalize),
 			l.makeResourceLabel(l.Resource(c.PredicateKey), normalize))
-		if c.ObjectExpr != nil {
+		switch {
+		case c.Aggregate != nil:
+			ruleTermLabel.WriteString(l.makeAggregateLabel(c.Aggregate, normalize))
+		case c.ObjectExpr != nil:
 			l.makeExpressionLabel(c.ObjectExpr, ruleTermLabel, normalize)
-		} else {
+		default:
 			ruleTermLabel.WriteString(l.makeResourceLabel(l.Resource(c.ObjectKey), normalize))
 		}
 		ruleTermLabel.WriteString(")")
@@ -308,6 +418,15 @@ func (l *JetRuleListener) makeResourceLabel(rule *rete.ResourceNode, normalize b
 	}
 }
 
+// Make the aggregate term label: op(var where (subj pred obj))
+func (l *JetRuleListener) makeAggregateLabel(agg *rete.AggregateNode, normalize bool) string {
+	return fmt.Sprintf("%s(%s where (%s %s %s))", agg.Operator,
+		l.makeResourceLabel(l.Resource(agg.VarKey), normalize),
+		l.makeResourceLabel(l.Resource(agg.SubjectKey), normalize),
+		l.makeResourceLabel(l.Resource(agg.PredicateKey), normalize),
+		l.makeResourceLabel(l.Resource(agg.ObjectKey), normalize))
+}
+
 func (l *JetRuleListener) makeExpressionLabel(expr *rete.ExpressionNode, buf *strings.Builder, normalize bool) {
 	if expr == nil {
 		return
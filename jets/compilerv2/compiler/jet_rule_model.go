@@ -40,6 +40,7 @@ type JetRuleListener struct {
 	currentRuleProperties     map[string]string
 	currentRuleAntecedents    []*rete.RuleTerm
 	currentRuleConsequents    []*rete.RuleTerm
+	currentRuleOrBlocks       []*orBlock
 	currentJetruleNode        *rete.JetruleNode
 	currentRuleVarByValue     map[string]*rete.ResourceNode
 	// stack to build expressions in Antecedents and Consequents
@@ -47,18 +48,27 @@ type JetRuleListener struct {
 	// Collected temp var nodes, collected from currentRuleVarByValue
 	collectedTempVarNodes []*rete.ResourceNode
 
+	// Declarations and errors with their position, see symbol_table.go
+	symbols     []*Symbol
+	diagnostics []Diagnostic
+	errorLogPos int
+
 	// Logs
 	parseLog *strings.Builder
 	errorLog *strings.Builder
 	trace    bool
 }
 
+// outJsonFileName returns the name of the json file of the compiled model, relative to basePath
+func outJsonFileName(mainRuleFileName string) string {
+	return strings.TrimSuffix(mainRuleFileName, ".jetrule") + ".json"
+}
+
 func NewJetRuleListener(basePath string, mainRuleFileName string) *JetRuleListener {
-	outJsonFileName := strings.TrimSuffix(mainRuleFileName, ".jetrule") + ".json"
 	l := &JetRuleListener{
 		mainRuleFileName:      mainRuleFileName,
 		basePath:              basePath,
-		outJsonFileName:       outJsonFileName,
+		outJsonFileName:       outJsonFileName(mainRuleFileName),
 		jetRuleModel:          rete.NewJetruleModel(mainRuleFileName),
 		resourceManager:       NewResourceManager(),
 		classesByName:         make(map[string]*rete.ClassNode),
@@ -26,7 +26,9 @@ type RuleFileReader struct {
 	combinedContent   strings.Builder
 	importedFileNames map[string]bool
 	importedFileInfo  []*ImportedFileInfo
-	readFile          readFileFunc
+	// fileHashes is the content hash of the files read, by file name
+	fileHashes map[string]string
+	readFile   readFileFunc
 }
 
 func NewRuleFileReader(basePath string, mainFileName string, readFile readFileFunc) *RuleFileReader {
@@ -36,6 +38,7 @@ func NewRuleFileReader(basePath string, mainFileName string, readFile readFileFu
 		globalLineNum:     1,
 		importedFileNames: make(map[string]bool),
 		importedFileInfo:  make([]*ImportedFileInfo, 0),
+		fileHashes:        make(map[string]string),
 		readFile:          readFile,
 	}
 }
@@ -88,6 +91,11 @@ func (r *RuleFileReader) GetLocalFileAndLine(globalLineNum int) (string, int, er
 	return "", 0, fmt.Errorf("global line number %d not found in any imported files", globalLineNum)
 }
 
+// FileHashes returns the content hash of the main file and of all its imports, by file name
+func (r *RuleFileReader) FileHashes() map[string]string {
+	return r.fileHashes
+}
+
 func (r *RuleFileReader) PrintImportedFiles() {
 	fmt.Println("Imported Files:")
 	for _, fileInfo := range r.importedFileInfo {
@@ -114,6 +122,7 @@ func (r *RuleFileReader) readFileRecursive(fileName string) error {
 	if err != nil {
 		return err
 	}
+	r.fileHashes[fileName] = ContentHash(content)
 
 	lines := splitLines(content)
 	nbrLines := len(lines)
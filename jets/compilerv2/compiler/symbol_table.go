@@ -0,0 +1,101 @@
+package compiler
+
+import (
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/artisoft-io/jetstore/jets/compilerv2/parser"
+)
+
+// This file contains the symbol table and the diagnostics of the compiler,
+// with the position of the declarations and errors in the source files.
+// These are used by editor tooling (jetrule-lsp).
+// Note: the listener works on the combined content of the rule files (see rule_file_reader.go),
+// the line numbers are mapped back to the source files by the Compiler.
+
+// Kind of symbols
+const (
+	SymbolResource         = "resource"
+	SymbolVolatileResource = "volatile_resource"
+	SymbolLiteral          = "literal"
+	SymbolClass            = "class"
+	SymbolDataProperty     = "data_property"
+	SymbolLookupTable      = "lookup_table"
+	SymbolLookupColumn     = "lookup_column"
+	SymbolRule             = "rule"
+	SymbolRuleSequence     = "rule_sequence"
+)
+
+// Symbol is a declaration in the rule files.
+// DataType is the type of data properties, lookup columns and literals.
+// Owner is the class of a data property or the lookup table of a column.
+// Value is the value of resources and literals.
+// Line is 1-based and Column is 0-based, as in antlr.
+// Note: the Column is relative to the line with leading white spaces removed.
+type Symbol struct {
+	Name           string
+	Kind           string
+	DataType       string
+	IsArray        bool
+	Owner          string
+	Value          string
+	SourceFileName string
+	Line           int
+	Column         int
+}
+
+// Diagnostic is a compilation error, Line is 0 when the error has no position
+type Diagnostic struct {
+	SourceFileName string
+	Line           int
+	Column         int
+	Message        string
+}
+
+// addSymbol records the declaration of sym at the position of token
+func (s *JetRuleListener) addSymbol(token antlr.Token, sym *Symbol) {
+	if token != nil {
+		sym.Line = token.GetLine()
+		sym.Column = token.GetColumn()
+	}
+	sym.SourceFileName = s.currentRuleFileName
+	s.symbols = append(s.symbols, sym)
+}
+
+// addDiagnostics records the errors logged since errorLogPos at the position of token
+func (s *JetRuleListener) addDiagnostics(token antlr.Token, errorLogPos int) {
+	content := s.errorLog.String()
+	if errorLogPos >= len(content) {
+		return
+	}
+	var line, column int
+	sourceFileName := s.mainRuleFileName
+	if token != nil {
+		sourceFileName = s.currentRuleFileName
+		line = token.GetLine()
+		column = token.GetColumn()
+	}
+	for msg := range strings.SplitSeq(content[errorLogPos:], "\n") {
+		msg = strings.TrimSpace(msg)
+		if len(msg) > 0 {
+			s.diagnostics = append(s.diagnostics, Diagnostic{
+				SourceFileName: sourceFileName,
+				Line:           line,
+				Column:         column,
+				Message:        msg,
+			})
+		}
+	}
+}
+
+// EnterStatement is called when production statement is entered.
+// Keep track of the position in the error log to attribute the errors to the statement.
+func (s *JetRuleListener) EnterStatement(ctx *parser.StatementContext) {
+	s.errorLogPos = s.errorLog.Len()
+}
+
+// ExitStatement is called when production statement is exited.
+func (s *JetRuleListener) ExitStatement(ctx *parser.StatementContext) {
+	s.addDiagnostics(ctx.GetStart(), s.errorLogPos)
+	s.errorLogPos = s.errorLog.Len()
+}
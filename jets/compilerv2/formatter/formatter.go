@@ -0,0 +1,390 @@
+package formatter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/artisoft-io/jetstore/jets/compilerv2/compiler"
+	"github.com/artisoft-io/jetstore/jets/compilerv2/parser"
+)
+
+// This file contains the JetRule source formatter used by jrfmt.
+// The rule file is parsed with the compilerv2 grammar and printed in a canonical layout:
+//   - one statement per line, statement bodies indented by 2 spaces,
+//   - at most one blank line between statements, no blank lines within statements,
+//   - rule antecedents and consequents one per line with their subject and predicate aligned,
+//   - class data properties and lookup table columns one per line with their type aligned,
+//   - single spaces around the operators of filter and object expressions.
+// The comments are kept at their position, the comments at the end of a line stay at
+// the end of the corresponding line.
+// Note: the import statements are not part of the grammar (see rule_file_reader.go),
+// they are turned into comments while parsing and printed back as import statements.
+
+type Options struct {
+	// Sort the class data properties by name
+	SortDataProperties bool
+}
+
+const indentUnit = "  "
+
+// prefix of the comments holding an import statement
+const importMarker = "#\x00"
+
+var reImportStmt = regexp.MustCompile(`^import\s*("[^"]*")\s*(.*)$`)
+
+// Format returns the formatted source of a rule file, it returns an error
+// when the rule file has syntax errors
+func Format(source string, options *Options) (string, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	stream, tree, err := parse(markImports(source))
+	if err != nil {
+		return "", err
+	}
+	f := &formatter{
+		options:  options,
+		stream:   stream,
+		done:     make(map[int]bool),
+		lastLine: -1,
+	}
+	for _, token := range stream.GetAllTokens() {
+		if token.GetTokenType() == parser.JetRuleParserCOMMENT {
+			f.comments = append(f.comments, token)
+		}
+	}
+	f.jetrule(tree)
+	formatted := strings.Join(f.lines, "\n") + "\n"
+
+	// Make sure the formatted source has the same content as the source, only the
+	// layout and the optional separators may differ, as well as the order of the
+	// data properties when they are sorted
+	err = sameContent(source, formatted, options.SortDataProperties)
+	if err != nil {
+		return "", fmt.Errorf("error: formatted rule file differs from the source: %v", err)
+	}
+	return formatted, nil
+}
+
+// parse returns the token stream and the parse tree of source
+func parse(source string) (*antlr.CommonTokenStream, *parser.JetruleContext, error) {
+	errorLog := &strings.Builder{}
+	errorListener := compiler.NewCustomErrorListener(&strings.Builder{}, errorLog, false)
+	lexer := parser.NewJetRuleLexer(antlr.NewInputStream(source))
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(errorListener)
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	p := parser.NewJetRuleParser(stream)
+	p.BuildParseTrees = true
+	p.RemoveErrorListeners()
+	p.AddErrorListener(errorListener)
+	tree := p.Jetrule()
+	if errorLog.Len() > 0 {
+		return nil, nil, fmt.Errorf("%s", strings.TrimSpace(errorLog.String()))
+	}
+	return stream, tree.(*parser.JetruleContext), nil
+}
+
+// markImports turns the import statements into comments, as the RuleFileReader
+// the import statements are recognized at the start of the line
+func markImports(source string) string {
+	lines := strings.Split(source, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "import ") {
+			lines[i] = importMarker + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sameContent compares the tokens of source and formatted, ignoring the separators.
+// When sortDataProperties is true, the tokens of the data property lists are compared
+// regardless of their order.
+func sameContent(source, formatted string, sortDataProperties bool) error {
+	sourceTokens := contentTokens(markImports(source), sortDataProperties)
+	formattedTokens := contentTokens(markImports(formatted), sortDataProperties)
+	for i := range min(len(sourceTokens), len(formattedTokens)) {
+		if sourceTokens[i] != formattedTokens[i] {
+			return fmt.Errorf("token %q replaced by %q", sourceTokens[i], formattedTokens[i])
+		}
+	}
+	if len(sourceTokens) != len(formattedTokens) {
+		return fmt.Errorf("got %d tokens, expecting %d", len(formattedTokens), len(sourceTokens))
+	}
+	return nil
+}
+
+// contentTokens returns the tokens of source without the separators, the data property
+// lists are sorted by property when sortDataProperties is true
+func contentTokens(source string, sortDataProperties bool) []string {
+	lexer := parser.NewJetRuleLexer(antlr.NewInputStream(source))
+	lexer.RemoveErrorListeners()
+	var tokens []string
+	// start of the data property list being read, -1 when not in a list
+	listStart := -1
+	isDataProperties := false
+	for _, token := range lexer.GetAllTokens() {
+		text := token.GetText()
+		switch {
+		case text == "." || text == ",":
+		case token.GetTokenType() == parser.JetRuleParserCOMMENT:
+			tokens = append(tokens, normalizeComment(text))
+		default:
+			tokens = append(tokens, text)
+		}
+		switch {
+		case !sortDataProperties:
+		case token.GetTokenType() == parser.JetRuleParserDataProperties:
+			isDataProperties = true
+		case isDataProperties && text == "[":
+			listStart, isDataProperties = len(tokens), false
+		case listStart >= 0 && text == "]":
+			items := dataPropertyItems(tokens[listStart : len(tokens)-1])
+			slices.Sort(items)
+			tokens = append(append(tokens[:listStart], items...), text)
+			listStart = -1
+		}
+	}
+	return tokens
+}
+
+// dataPropertyItems groups the tokens of a data property list by property,
+// i.e. 'name as [array of] type', the comments are items of their own
+func dataPropertyItems(tokens []string) []string {
+	var items, item []string
+	afterAs := false
+	for _, text := range tokens {
+		switch {
+		case strings.HasPrefix(text, "#"):
+			items = append(items, text)
+			continue
+		case text == "as":
+			afterAs = true
+		case afterAs && text != "array" && text != "of":
+			items = append(items, strings.Join(append(item, text), " "))
+			item, afterAs = nil, false
+			continue
+		}
+		item = append(item, text)
+	}
+	if len(item) > 0 {
+		items = append(items, strings.Join(item, " "))
+	}
+	return items
+}
+
+type formatter struct {
+	options  *Options
+	stream   *antlr.CommonTokenStream
+	comments []antlr.Token
+	// comments already written, by token index
+	done map[int]bool
+	// formatted lines
+	lines []string
+	// source line of the last token written
+	lastLine int
+}
+
+// element is an item of a list, written on its own line(s)
+// text is the formatted item or write is called to write the item
+type element struct {
+	start, stop antlr.Token
+	text        string
+	write       func(indent, sep string)
+	sortKey     string
+	// comments attached to the element when sorting the list
+	leading, trailing []antlr.Token
+}
+
+func newElement(ctx antlr.ParserRuleContext, text string) *element {
+	return &element{start: ctx.GetStart(), stop: ctx.GetStop(), text: text}
+}
+
+// emit writes a line, last is the last source token of the line
+func (f *formatter) emit(text string, last antlr.Token) {
+	f.lines = append(f.lines, strings.TrimRight(text, " \t"))
+	if last != nil {
+		f.lastLine = last.GetLine()
+	}
+}
+
+// blankLine writes a blank line when token is separated from the last token by blank lines
+func (f *formatter) blankLine(token antlr.Token) {
+	if len(f.lines) > 0 && f.lines[len(f.lines)-1] != "" && token.GetLine() > f.lastLine+1 {
+		f.lines = append(f.lines, "")
+	}
+}
+
+func commentText(c antlr.Token) string {
+	return normalizeComment(c.GetText())
+}
+
+// normalizeComment returns the comment text without trailing spaces, or the import statement
+func normalizeComment(text string) string {
+	text = strings.TrimRight(text, " \t\r")
+	if strings.HasPrefix(text, importMarker) {
+		text = text[len(importMarker):]
+		if m := reImportStmt.FindStringSubmatch(text); m != nil {
+			text = strings.TrimSpace("import " + m[1] + " " + m[2])
+		}
+	}
+	return text
+}
+
+// comment writes comment c, at the end of the last line when it was on the same source line
+func (f *formatter) comment(indent string, c antlr.Token, topLevel bool) {
+	f.done[c.GetTokenIndex()] = true
+	text := commentText(c)
+	if len(f.lines) > 0 && c.GetLine() == f.lastLine && !strings.HasPrefix(c.GetText(), importMarker) {
+		f.lines[len(f.lines)-1] += "  " + text
+		return
+	}
+	if topLevel {
+		f.blankLine(c)
+	}
+	f.emit(indent+text, c)
+}
+
+// flush writes the comments located before token
+func (f *formatter) flush(indent string, token antlr.Token, topLevel bool) {
+	for _, c := range f.comments {
+		if c.GetTokenIndex() >= token.GetTokenIndex() {
+			break
+		}
+		if !f.done[c.GetTokenIndex()] {
+			f.comment(indent, c, topLevel)
+		}
+	}
+}
+
+// hasComments returns true when there are comments between tokens from and to
+func (f *formatter) hasComments(from, to antlr.Token) bool {
+	for _, c := range f.comments {
+		if c.GetTokenIndex() > from.GetTokenIndex() && c.GetTokenIndex() < to.GetTokenIndex() {
+			return true
+		}
+	}
+	return false
+}
+
+// next returns the token following token, skipping the comments
+func (f *formatter) next(token antlr.Token) antlr.Token {
+	for i := token.GetTokenIndex() + 1; i < f.stream.Size(); i++ {
+		t := f.stream.Get(i)
+		if t.GetTokenType() != parser.JetRuleParserCOMMENT {
+			return t
+		}
+	}
+	return token
+}
+
+// prev returns the token preceding token, skipping the comments
+func (f *formatter) prev(token antlr.Token) antlr.Token {
+	for i := token.GetTokenIndex() - 1; i >= 0; i-- {
+		t := f.stream.Get(i)
+		if t.GetTokenType() != parser.JetRuleParserCOMMENT {
+			return t
+		}
+	}
+	return token
+}
+
+// items writes the elements of a list, one per line, end is the token closing the list
+func (f *formatter) items(indent string, elements []*element, sep string, end antlr.Token) {
+	for i, e := range elements {
+		f.flush(indent, e.start, false)
+		s := sep
+		if i == len(elements)-1 {
+			s = ""
+		}
+		if e.write != nil {
+			e.write(indent, s)
+		} else {
+			f.emit(indent+e.text+s, e.stop)
+		}
+	}
+	f.flush(indent, end, false)
+}
+
+// sortedItems writes the elements sorted by sortKey, the comments on their own line are
+// moved with the following element and the comments at the end of a line are moved with
+// the element of that line
+func (f *formatter) sortedItems(indent string, elements []*element, sep string, open, end antlr.Token) {
+	var tail []antlr.Token
+	for _, c := range f.comments {
+		if f.done[c.GetTokenIndex()] || c.GetTokenIndex() <= open.GetTokenIndex() {
+			continue
+		}
+		if c.GetTokenIndex() >= end.GetTokenIndex() {
+			break
+		}
+		if c.GetLine() == open.GetLine() {
+			// stays at the end of the line opening the list
+			f.comment(indent, c, false)
+			continue
+		}
+		f.done[c.GetTokenIndex()] = true
+		i := slices.IndexFunc(elements, func(e *element) bool {
+			return e.start.GetTokenIndex() > c.GetTokenIndex()
+		})
+		if i < 0 {
+			i = len(elements)
+		}
+		switch {
+		case i > 0 && elements[i-1].stop.GetLine() == c.GetLine():
+			elements[i-1].trailing = append(elements[i-1].trailing, c)
+		case i < len(elements):
+			elements[i].leading = append(elements[i].leading, c)
+		default:
+			tail = append(tail, c)
+		}
+	}
+	slices.SortStableFunc(elements, func(a, b *element) int {
+		return strings.Compare(a.sortKey, b.sortKey)
+	})
+	for i, e := range elements {
+		for _, c := range e.leading {
+			f.emit(indent+commentText(c), nil)
+		}
+		line := indent + e.text
+		if i < len(elements)-1 {
+			line += sep
+		}
+		for _, c := range e.trailing {
+			line += "  " + commentText(c)
+		}
+		f.emit(line, e.stop)
+	}
+	for _, c := range tail {
+		f.emit(indent+commentText(c), nil)
+	}
+	f.lastLine = end.GetLine()
+}
+
+// list writes a list of identifiers on a single line, or one per line when
+// the list contains comments. open and end are the brackets of the list.
+func (f *formatter) list(indent, head string, elements []*element, sep string, open, end antlr.Token) {
+	if !f.hasComments(open, end) {
+		texts := make([]string, 0, len(elements))
+		for _, e := range elements {
+			texts = append(texts, e.text)
+		}
+		f.emit(fmt.Sprintf("%s%s[%s]%s", indent, head, strings.Join(texts, ", "), sep), end)
+		return
+	}
+	f.emit(indent+head+"[", open)
+	f.items(indent+indentUnit, elements, ",", end)
+	f.emit(indent+"]"+sep, end)
+}
+
+// pad returns text left aligned in width
+func pad(text string, width int) string {
+	if len(text) >= width {
+		return text
+	}
+	return text + strings.Repeat(" ", width-len(text))
+}
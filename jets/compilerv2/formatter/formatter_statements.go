@@ -0,0 +1,373 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/artisoft-io/jetstore/jets/compilerv2/parser"
+)
+
+// This file contains the formatting of the JetRule statements
+
+func (f *formatter) jetrule(ctx *parser.JetruleContext) {
+	for _, s := range ctx.AllStatement() {
+		stmt := s.(*parser.StatementContext)
+		if stmt.COMMENT() != nil {
+			// written with the following statement
+			continue
+		}
+		f.flush("", stmt.GetStart(), true)
+		f.blankLine(stmt.GetStart())
+		switch {
+		case stmt.JetCompilerDirectiveStmt() != nil:
+			c := stmt.JetCompilerDirectiveStmt().(*parser.JetCompilerDirectiveStmtContext)
+			f.emit(fmt.Sprintf("%s %s = %s;", c.JetCompilerDirective().GetText(),
+				c.GetVarName().GetText(), c.GetDeclValue().GetText()), c.GetStop())
+		case stmt.DefineJetStoreConfigStmt() != nil:
+			f.jetstoreConfig(stmt.DefineJetStoreConfigStmt().(*parser.DefineJetStoreConfigStmtContext))
+		case stmt.DefineLiteralStmt() != nil:
+			f.literal(stmt.DefineLiteralStmt().(*parser.DefineLiteralStmtContext))
+		case stmt.DefineClassStmt() != nil:
+			f.class(stmt.DefineClassStmt().(*parser.DefineClassStmtContext))
+		case stmt.DefineRuleSeqStmt() != nil:
+			f.ruleSequence(stmt.DefineRuleSeqStmt().(*parser.DefineRuleSeqStmtContext))
+		case stmt.DefineResourceStmt() != nil:
+			f.resource(stmt.DefineResourceStmt().(*parser.DefineResourceStmtContext))
+		case stmt.LookupTableStmt() != nil:
+			f.lookupTable(stmt.LookupTableStmt().(*parser.LookupTableStmtContext))
+		case stmt.JetRuleStmt() != nil:
+			f.rule(stmt.JetRuleStmt().(*parser.JetRuleStmtContext))
+		case stmt.TripleStmt() != nil:
+			c := stmt.TripleStmt().(*parser.TripleStmtContext)
+			f.emit(fmt.Sprintf("triple(%s, %s, %s);", c.GetS().GetText(), c.GetP().GetText(),
+				c.GetO().GetText()), c.GetStop())
+		}
+	}
+	f.flush("", ctx.EOF().GetSymbol(), true)
+}
+
+func (f *formatter) jetstoreConfig(ctx *parser.DefineJetStoreConfigStmtContext) {
+	open := f.next(ctx.JetstoreConfig().GetStop())
+	f.emit(ctx.JetstoreConfig().GetText()+" {", open)
+	var elements []*element
+	for _, item := range ctx.JetstoreConfigSeq().AllJetstoreConfigItem() {
+		c := item.(*parser.JetstoreConfigItemContext)
+		e := newElement(c, "")
+		key := c.GetConfigKey().GetText()
+		if c.InputType() != nil {
+			e.write = func(indent, sep string) {
+				var types []*element
+				for _, decl := range c.AllDeclIdentifier() {
+					types = append(types, newElement(decl, decl.GetText()))
+				}
+				f.list(indent, key+" = ", types, sep, f.next(c.ASSIGN().GetSymbol()), c.GetStop())
+			}
+		} else {
+			e.text = fmt.Sprintf("%s = %s", key, c.GetConfigValue().GetText())
+		}
+		elements = append(elements, e)
+	}
+	end := f.prev(ctx.SEMICOLON().GetSymbol())
+	f.items(indentUnit, elements, ",", end)
+	f.emit("};", ctx.GetStop())
+}
+
+func (f *formatter) literal(ctx *parser.DefineLiteralStmtContext) {
+	// All literal statements have the same structure: varType varName = declValue;
+	stmt := ctx.GetChild(0).(antlr.ParserRuleContext)
+	var parts []string
+	for _, child := range stmt.GetChildren() {
+		if tree, ok := child.(antlr.ParseTree); ok {
+			parts = append(parts, tree.GetText())
+		}
+	}
+	// parts: varType, varName, =, declValue, ;
+	text := strings.Join(parts[:len(parts)-1], " ") + ";"
+	f.emit(text, stmt.GetStop())
+}
+
+func (f *formatter) resource(ctx *parser.DefineResourceStmtContext) {
+	if c, ok := ctx.NamedResourceStmt().(*parser.NamedResourceStmtContext); ok && c != nil {
+		f.emit(fmt.Sprintf("resource %s = %s;", c.GetResName().GetText(), c.GetResCtx().GetText()), c.GetStop())
+		return
+	}
+	c := ctx.VolatileResourceStmt().(*parser.VolatileResourceStmtContext)
+	f.emit(fmt.Sprintf("volatile_resource %s = %s;", c.GetResName().GetText(), c.GetResVal().GetText()), c.GetStop())
+}
+
+func (f *formatter) class(ctx *parser.DefineClassStmtContext) {
+	f.emit(fmt.Sprintf("class %s {", ctx.GetClassName().GetText()), f.next(ctx.GetClassName().GetStop()))
+	var elements []*element
+	for _, item := range ctx.AllClassStmt() {
+		c := item.(*parser.ClassStmtContext)
+		e := newElement(c, "")
+		switch {
+		case c.BaseClasses() != nil:
+			e.write = func(indent, sep string) {
+				var classes []*element
+				for _, sc := range c.AllSubClassOfStmt() {
+					classes = append(classes, newElement(sc, sc.GetText()))
+				}
+				f.list(indent, "$base_classes = ", classes, sep, f.next(c.ASSIGN().GetSymbol()), c.GetStop())
+			}
+		case c.GroupingProperties() != nil:
+			e.write = func(indent, sep string) {
+				var properties []*element
+				for _, gp := range c.AllGroupingPropertyStmt() {
+					properties = append(properties, newElement(gp, gp.GetText()))
+				}
+				f.list(indent, "$grouping_properties = ", properties, sep, f.next(c.ASSIGN().GetSymbol()), c.GetStop())
+			}
+		case c.DataProperties() != nil:
+			e.write = func(indent, sep string) {
+				f.dataProperties(indent, c, sep)
+			}
+		default:
+			e.text = fmt.Sprintf("$as_table = %s", c.AsTableStmt().(*parser.AsTableStmtContext).GetAsTable().GetText())
+		}
+		elements = append(elements, e)
+	}
+	end := f.prev(ctx.SEMICOLON().GetSymbol())
+	f.items(indentUnit, elements, ",", end)
+	f.emit("};", ctx.GetStop())
+}
+
+func (f *formatter) dataProperties(indent string, ctx *parser.ClassStmtContext, sep string) {
+	open := f.next(ctx.ASSIGN().GetSymbol())
+	f.emit(indent+"$data_properties = [", open)
+	width := 0
+	for _, item := range ctx.AllDataPropertyDefinitions() {
+		width = max(width, len(item.(*parser.DataPropertyDefinitionsContext).GetDataPName().GetText()))
+	}
+	var elements []*element
+	for _, item := range ctx.AllDataPropertyDefinitions() {
+		c := item.(*parser.DataPropertyDefinitionsContext)
+		name := c.GetDataPName().GetText()
+		dataType := c.GetDataPType().GetText()
+		if c.ARRAY() != nil {
+			dataType = "array of " + dataType
+		}
+		e := newElement(c, fmt.Sprintf("%s as %s", pad(name, width), dataType))
+		e.sortKey = name
+		elements = append(elements, e)
+	}
+	if f.options.SortDataProperties {
+		f.sortedItems(indent+indentUnit, elements, ",", open, ctx.GetStop())
+	} else {
+		f.items(indent+indentUnit, elements, ",", ctx.GetStop())
+	}
+	f.emit(indent+"]"+sep, ctx.GetStop())
+}
+
+func (f *formatter) ruleSequence(ctx *parser.DefineRuleSeqStmtContext) {
+	f.emit(fmt.Sprintf("rule_sequence %s {", ctx.GetRuleseqName().GetText()), f.next(ctx.GetRuleseqName()))
+	f.flush(indentUnit, ctx.MainRuleSets().GetSymbol(), false)
+	f.emit(indentUnit+"$main_rule_sets = [", f.next(ctx.ASSIGN().GetSymbol()))
+	var elements []*element
+	for _, item := range ctx.RuleSetSeq().AllRuleSetDefinitions() {
+		elements = append(elements, newElement(item, item.GetText()))
+	}
+	close := f.next(ctx.RuleSetSeq().GetStop())
+	f.items(indentUnit+indentUnit, elements, ",", close)
+	f.emit(indentUnit+"]", close)
+	f.flush(indentUnit, f.prev(ctx.SEMICOLON().GetSymbol()), false)
+	f.emit("};", ctx.GetStop())
+}
+
+func (f *formatter) lookupTable(ctx *parser.LookupTableStmtContext) {
+	f.emit(fmt.Sprintf("lookup_table %s {", ctx.GetLookupName().GetText()), f.next(ctx.GetLookupName().GetStop()))
+
+	// Storage location
+	location := ctx.CsvLocation().(*parser.CsvLocationContext)
+	f.flush(indentUnit, location.GetStart(), false)
+	if location.TableName() != nil {
+		f.emit(fmt.Sprintf("%s$table_name = %s,", indentUnit, location.GetTblStorageName().GetText()), location.GetStop())
+	} else {
+		f.emit(fmt.Sprintf("%s$csv_file = %s,", indentUnit, location.GetCsvFileName().GetText()), location.GetStop())
+	}
+
+	// Key columns
+	f.flush(indentUnit, ctx.Key().GetSymbol(), false)
+	var keys []string
+	if seq := ctx.GetTblKeys().GetSeqCtx(); seq != nil {
+		for _, key := range seq.AllSTRING() {
+			keys = append(keys, key.GetText())
+		}
+	}
+	f.emit(fmt.Sprintf("%s$key = [%s],", indentUnit, strings.Join(keys, ", ")), f.next(ctx.GetTblKeys().GetStop()))
+
+	// Value columns
+	f.flush(indentUnit, ctx.Columns().GetSymbol(), false)
+	f.emit(indentUnit+"$columns = [", f.next(f.next(ctx.Columns().GetSymbol())))
+	columns := ctx.ColumnDefSeq().AllColumnDefinitions()
+	width := 0
+	for _, item := range columns {
+		width = max(width, len(item.(*parser.ColumnDefinitionsContext).GetColumnName().GetText()))
+	}
+	var elements []*element
+	for _, item := range columns {
+		c := item.(*parser.ColumnDefinitionsContext)
+		dataType := c.GetColumnType().GetText()
+		if c.ARRAY() != nil {
+			dataType = "array of " + dataType
+		}
+		elements = append(elements, newElement(c, fmt.Sprintf("%s as %s", pad(c.GetColumnName().GetText(), width), dataType)))
+	}
+	close := f.next(ctx.ColumnDefSeq().GetStop())
+	f.items(indentUnit+indentUnit, elements, ",", close)
+	f.emit(indentUnit+"]", close)
+	f.flush(indentUnit, f.prev(ctx.SEMICOLON().GetSymbol()), false)
+	f.emit("};", ctx.GetStop())
+}
+
+func (f *formatter) rule(ctx *parser.JetRuleStmtContext) {
+	// Rule name and properties
+	header := "[" + ctx.GetRuleName().GetText()
+	for _, item := range ctx.AllRuleProperties() {
+		c := item.(*parser.RulePropertiesContext)
+		header += fmt.Sprintf(", %s=%s", c.GetKey().GetText(), c.GetValCtx().GetText())
+	}
+	// The antecedents and the or blocks in rule order
+	var terms []antlr.ParserRuleContext
+	var allAntecedents []*parser.AntecedentContext
+	for _, child := range ctx.GetChildren() {
+		switch c := child.(type) {
+		case *parser.AntecedentContext:
+			terms = append(terms, c)
+			allAntecedents = append(allAntecedents, c)
+		case *parser.OrAntecedentContext:
+			terms = append(terms, c)
+			for _, branch := range c.AllOrBranch() {
+				for _, a := range branch.AllAntecedent() {
+					allAntecedents = append(allAntecedents, a.(*parser.AntecedentContext))
+				}
+			}
+		}
+	}
+	f.emit(header+"]:", f.prev(terms[0].GetStart()))
+
+	// Align the subject and predicate of the antecedents and consequents,
+	// the opening parenthesis are aligned when there are negated antecedents
+	prefixWidth, subjectWidth, predicateWidth := 1, 0, 0
+	for _, c := range allAntecedents {
+		prefixWidth = max(prefixWidth, len(antecedentPrefix(c)))
+		if c.GetAgg() != nil {
+			continue
+		}
+		subjectWidth = max(subjectWidth, len(c.GetS().GetText()))
+		predicateWidth = max(predicateWidth, len(c.GetP().GetText()))
+	}
+	for _, item := range ctx.AllConsequent() {
+		c := item.(*parser.ConsequentContext)
+		subjectWidth = max(subjectWidth, len(c.GetS().GetText()))
+		predicateWidth = max(predicateWidth, len(c.GetP().GetText()))
+	}
+	triple := func(prefix, s, p, o string) string {
+		return fmt.Sprintf("%*s%s %s %s)", prefixWidth, prefix, pad(s, subjectWidth), pad(p, predicateWidth), o)
+	}
+
+	// Antecedents, the filters are on their own line
+	antecedent := func(c *parser.AntecedentContext) *element {
+		var e *element
+		if c.GetAgg() != nil {
+			e = newElement(c, fmt.Sprintf("%*s%s = %s)", prefixWidth, antecedentPrefix(c), c.GetV().GetText(), aggregateTerm(c.GetAgg())))
+		} else {
+			e = newElement(c, triple(antecedentPrefix(c), c.GetS().GetText(), c.GetP().GetText(), c.GetO().GetText()))
+		}
+		if c.GetF() != nil {
+			text := e.text
+			filter := strings.Repeat(" ", prefixWidth-1) + "[" + exprTerm(c.GetF()) + "]"
+			e.write = func(indent, sep string) {
+				f.emit(indent+text+".", e.stop)
+				f.emit(indent+filter+sep, e.stop)
+			}
+		}
+		return e
+	}
+	// Or blocks, the branches are written as:
+	//   or {
+	//     (antecedent).
+	//     (antecedent)
+	//   } {
+	//     (antecedent)
+	//   }
+	orBlock := func(c *parser.OrAntecedentContext) *element {
+		e := newElement(c, "")
+		e.write = func(indent, sep string) {
+			open := "or {"
+			for _, branch := range c.AllOrBranch() {
+				f.emit(indent+open, branch.GetStart())
+				var elements []*element
+				for _, a := range branch.AllAntecedent() {
+					elements = append(elements, antecedent(a.(*parser.AntecedentContext)))
+				}
+				f.items(indent+indentUnit, elements, ".", branch.GetStop())
+				open = "} {"
+			}
+			f.emit(indent+"}"+sep, e.stop)
+		}
+		return e
+	}
+	var antecedents []*element
+	for _, term := range terms {
+		switch c := term.(type) {
+		case *parser.AntecedentContext:
+			antecedents = append(antecedents, antecedent(c))
+		case *parser.OrAntecedentContext:
+			antecedents = append(antecedents, orBlock(c))
+		}
+	}
+	arrow := f.next(terms[len(terms)-1].GetStop())
+	f.items(indentUnit, antecedents, ".", arrow)
+	f.emit(indentUnit+"->", arrow)
+
+	// Consequents
+	var consequents []*element
+	for _, item := range ctx.AllConsequent() {
+		c := item.(*parser.ConsequentContext)
+		consequents = append(consequents, newElement(c, triple("(", c.GetS().GetText(), c.GetP().GetText(), consequentObject(c))))
+	}
+	f.items(indentUnit, consequents, ".", ctx.SEMICOLON().GetSymbol())
+	f.emit(";", ctx.GetStop())
+}
+
+// consequentObject returns the object expression or the aggregate term of the consequent
+func consequentObject(ctx *parser.ConsequentContext) string {
+	if agg := ctx.GetAgg(); agg != nil {
+		return aggregateTerm(agg)
+	}
+	return exprTerm(ctx.GetO())
+}
+
+// aggregateTerm returns the aggregate term, e.g. count(?line where (?c hc:has_line ?line))
+func aggregateTerm(agg parser.IAggregateTermContext) string {
+	return fmt.Sprintf("%s(%s where (%s %s %s))", agg.GetOp().GetText(), agg.GetAggVar().GetText(),
+		agg.GetS().GetText(), agg.GetP().GetText(), agg.GetO().GetText())
+}
+
+func antecedentPrefix(ctx *parser.AntecedentContext) string {
+	if ctx.GetN() != nil {
+		return "not("
+	}
+	return "("
+}
+
+// exprTerm returns the expression with single spaces around the operators
+func exprTerm(ctx parser.IExprTermContext) string {
+	switch c := ctx.(type) {
+	case *parser.BinaryExprTermContext:
+		return fmt.Sprintf("%s %s %s", exprTerm(c.GetLhs()), c.GetOp().GetText(), exprTerm(c.GetRhs()))
+	case *parser.BinaryExprTerm2Context:
+		return fmt.Sprintf("(%s %s %s)", exprTerm(c.GetLhs()), c.GetOp().GetText(), exprTerm(c.GetRhs()))
+	case *parser.UnaryExprTermContext:
+		return fmt.Sprintf("%s(%s)", c.GetOp().GetText(), exprTerm(c.GetArg()))
+	case *parser.UnaryExprTerm2Context:
+		return fmt.Sprintf("(%s %s)", c.GetOp().GetText(), exprTerm(c.GetArg()))
+	case *parser.SelfExprTermContext:
+		return fmt.Sprintf("(%s)", exprTerm(c.GetSelfExpr()))
+	case *parser.UnaryExprTerm3Context:
+		return fmt.Sprintf("%s %s", c.GetOp().GetText(), exprTerm(c.GetArg()))
+	}
+	return ctx.GetText()
+}
@@ -0,0 +1,120 @@
+package formatter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// This file contains test cases for the JetRule formatter
+
+func readTestFile(t *testing.T, fileName string) string {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestFormat(t *testing.T) {
+	formatted, err := Format(readTestFile(t, "testdata/unformatted.jr"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := readTestFile(t, "testdata/formatted.jr")
+	if formatted != expected {
+		t.Errorf("unexpected formatted rule file:\n%s\nexpecting:\n%s", formatted, expected)
+	}
+	// Formatting is idempotent
+	again, err := Format(formatted, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != formatted {
+		t.Errorf("formatting a formatted rule file changed it:\n%s", again)
+	}
+}
+
+// Format the rule files of the compiler test data, formatting must preserve their content
+func TestFormatCompilerTestFiles(t *testing.T) {
+	fileNames, err := filepath.Glob("../compiler/testdata/*.jr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fileName := range fileNames {
+		if strings.Contains(fileName, "_err") {
+			continue
+		}
+		formatted, err := Format(readTestFile(t, fileName), nil)
+		if err != nil {
+			t.Errorf("while formatting %s: %v", fileName, err)
+			continue
+		}
+		again, err := Format(formatted, nil)
+		if err != nil || again != formatted {
+			t.Errorf("formatting %s is not idempotent: %v", fileName, err)
+		}
+	}
+}
+
+func TestFormatSortDataProperties(t *testing.T) {
+	source := `class A {
+  $data_properties = [  # properties
+    zeta as int,   # last
+    # the alpha
+    alpha as text,
+    beta as date
+  ]
+};
+`
+	expected := `class A {
+  $data_properties = [  # properties
+    # the alpha
+    alpha as text,
+    beta  as date,
+    zeta  as int  # last
+  ]
+};
+`
+	formatted, err := Format(source, &Options{SortDataProperties: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if formatted != expected {
+		t.Errorf("unexpected formatted rule file:\n%s", formatted)
+	}
+}
+
+func TestSameContent(t *testing.T) {
+	source := `class A {
+  $data_properties = [zeta as int, alpha as text],
+  $base_classes = [B, C]
+};
+`
+	tests := []struct {
+		formatted          string
+		sortDataProperties bool
+		ok                 bool
+	}{
+		{`class A { $data_properties = [zeta as int, alpha as text], $base_classes = [B, C] };`, false, true},
+		{`class A { $data_properties = [alpha as text, zeta as int], $base_classes = [B, C] };`, false, false},
+		{`class A { $data_properties = [alpha as text, zeta as int], $base_classes = [B, C] };`, true, true},
+		{`class A { $data_properties = [alpha as text], $base_classes = [B, C] };`, true, false},
+		{`class A { $data_properties = [alpha as int, zeta as text], $base_classes = [B, C] };`, true, false},
+		{`class A { $data_properties = [alpha as text, zeta as int], $base_classes = [C, B] };`, true, false},
+	}
+	for _, tt := range tests {
+		err := sameContent(source, tt.formatted, tt.sortDataProperties)
+		if (err == nil) != tt.ok {
+			t.Errorf("sameContent(%q, sort=%v): expecting ok=%v, got %v", tt.formatted, tt.sortDataProperties, tt.ok, err)
+		}
+	}
+}
+
+func TestFormatSyntaxError(t *testing.T) {
+	_, err := Format("resource a = ;\n", nil)
+	if err == nil {
+		t.Fatal("expecting syntax error")
+	}
+}
@@ -0,0 +1,231 @@
+package lsp
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/artisoft-io/jetstore/jets/compilerv2/compiler"
+)
+
+// Analysis of a rule file: the rule file is compiled as a main rule file, with its
+// imports, to collect the declarations (symbols) and the compilation errors.
+
+type Analysis struct {
+	BasePath     string
+	MainRuleFile string
+	Symbols      []*compiler.Symbol
+	Diagnostics  []compiler.Diagnostic
+	byName       map[string][]*compiler.Symbol
+}
+
+// analyze compiles mainRuleFile, relative to basePath, reading the files with readFile
+func analyze(basePath, mainRuleFile string, autoAddResources bool,
+	readFile func(filePath string) (string, error)) (a *Analysis) {
+
+	a = &Analysis{
+		BasePath:     basePath,
+		MainRuleFile: mainRuleFile,
+		byName:       make(map[string][]*compiler.Symbol),
+	}
+	// The compiler is not expected to panic, but the rule file may be incomplete while editing
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("while compiling %s: recovered error: %v", mainRuleFile, r)
+			a.Diagnostics = append(a.Diagnostics, compiler.Diagnostic{
+				SourceFileName: mainRuleFile,
+				Message:        fmt.Sprintf("compiler error: %v", r),
+			})
+		}
+	}()
+	jrCompiler := compiler.NewCompiler(basePath, mainRuleFile, false, false, autoAddResources)
+	jrCompiler.SetReadFile(readFile)
+	err := jrCompiler.Compile()
+	if err != nil {
+		a.Diagnostics = append(a.Diagnostics, compiler.Diagnostic{
+			SourceFileName: mainRuleFile,
+			Message:        err.Error(),
+		})
+		return
+	}
+	a.Symbols = jrCompiler.Symbols()
+	a.Diagnostics = append(a.Diagnostics, jrCompiler.Diagnostics()...)
+	for _, sym := range a.Symbols {
+		a.byName[sym.Name] = append(a.byName[sym.Name], sym)
+	}
+	return
+}
+
+// Lookup returns the declarations of name
+func (a *Analysis) Lookup(name string) []*compiler.Symbol {
+	return a.byName[name]
+}
+
+// Completions returns the declared identifiers starting with prefix
+func (a *Analysis) Completions(prefix string) []CompletionItem {
+	items := make([]CompletionItem, 0)
+	for name, symbols := range a.byName {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		sym := symbols[0]
+		var kind int
+		switch sym.Kind {
+		case compiler.SymbolClass:
+			kind = CompletionKindClass
+		case compiler.SymbolDataProperty, compiler.SymbolLookupColumn:
+			kind = CompletionKindProperty
+		case compiler.SymbolLiteral:
+			kind = CompletionKindConstant
+		case compiler.SymbolLookupTable:
+			kind = CompletionKindStruct
+		case compiler.SymbolResource, compiler.SymbolVolatileResource:
+			kind = CompletionKindValue
+		default:
+			// rules and rule sequences are not referenced in rules
+			continue
+		}
+		items = append(items, CompletionItem{
+			Label:  name,
+			Kind:   kind,
+			Detail: symbolSignature(sym),
+		})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Label < items[j].Label })
+	return items
+}
+
+// HoverText returns the markdown description of the declarations of sym.Name
+func (a *Analysis) HoverText(symbols []*compiler.Symbol) string {
+	var buf strings.Builder
+	for i, sym := range symbols {
+		if i > 0 {
+			buf.WriteString("\n---\n")
+		}
+		fmt.Fprintf(&buf, "```\n%s\n```\n", symbolSignature(sym))
+		switch sym.Kind {
+		case compiler.SymbolDataProperty:
+			fmt.Fprintf(&buf, "Data property of class `%s`\n\n", sym.Owner)
+		case compiler.SymbolLookupColumn:
+			fmt.Fprintf(&buf, "Column of lookup table `%s`\n\n", sym.Owner)
+		case compiler.SymbolClass, compiler.SymbolLookupTable:
+			// List the data properties or the columns
+			for _, s := range a.Symbols {
+				if s.Owner == sym.Name {
+					fmt.Fprintf(&buf, "- `%s`\n", symbolSignature(s))
+				}
+			}
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "Defined in `%s` line %d\n", sym.SourceFileName, sym.Line)
+	}
+	return buf.String()
+}
+
+// symbolSignature returns the declaration of sym as in the rule files
+func symbolSignature(sym *compiler.Symbol) string {
+	dataType := sym.DataType
+	if sym.IsArray {
+		dataType = "array of " + dataType
+	}
+	switch sym.Kind {
+	case compiler.SymbolResource, compiler.SymbolVolatileResource:
+		return fmt.Sprintf("%s %s = \"%s\"", sym.Kind, sym.Name, sym.Value)
+	case compiler.SymbolLiteral:
+		return fmt.Sprintf("%s %s = %s", sym.DataType, sym.Name, sym.Value)
+	case compiler.SymbolDataProperty:
+		return fmt.Sprintf("%s as %s", sym.Name, dataType)
+	case compiler.SymbolLookupColumn:
+		return fmt.Sprintf("\"%s\" as %s", sym.Name, dataType)
+	case compiler.SymbolClass:
+		return fmt.Sprintf("class %s", sym.Name)
+	case compiler.SymbolLookupTable:
+		return fmt.Sprintf("lookup_table %s", sym.Name)
+	case compiler.SymbolRule:
+		return fmt.Sprintf("[%s]", sym.Name)
+	case compiler.SymbolRuleSequence:
+		return fmt.Sprintf("ruleseq %s", sym.Name)
+	}
+	return sym.Name
+}
+
+// isIdentifierChar returns true for the characters of identifiers, incl. the namespace separator
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == ':' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// wordAt returns the identifier at pos in text, and the prefix of the identifier before pos
+func wordAt(text string, pos Position) (word, prefix string) {
+	line := lineAt(text, pos.Line)
+	character := byteOffset(line, pos.Character)
+	if character < 0 {
+		return "", ""
+	}
+	start := character
+	for start > 0 && isIdentifierChar(line[start-1]) {
+		start--
+	}
+	end := character
+	for end < len(line) && isIdentifierChar(line[end]) {
+		end++
+	}
+	// Variables are not declarations
+	if start > 0 && line[start-1] == '?' {
+		return "", ""
+	}
+	return line[start:end], line[start:character]
+}
+
+// lineAt returns the line of text at 0-based index
+func lineAt(text string, index int) string {
+	for i := 0; i < index; i++ {
+		pos := strings.IndexByte(text, '\n')
+		if pos < 0 {
+			return ""
+		}
+		text = text[pos+1:]
+	}
+	if pos := strings.IndexByte(text, '\n'); pos >= 0 {
+		text = text[:pos]
+	}
+	return strings.TrimRight(text, "\r")
+}
+
+// sourceColumn returns the column in the source line of the compiler column,
+// the compiler removes the leading white spaces of the lines.
+// Note: the compiler columns are in runes, as the antlr input stream.
+func sourceColumn(line string, column int) int {
+	return column + len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// utf16Column returns the lsp column, in UTF-16 code units, of the rune column of line,
+// the columns past the end of line are one code unit each
+func utf16Column(line string, column int) int {
+	n := 0
+	for _, r := range line {
+		if column == 0 {
+			return n
+		}
+		n += utf16.RuneLen(r)
+		column--
+	}
+	return n + column
+}
+
+// byteOffset returns the byte offset in line of the lsp column, in UTF-16 code units,
+// returns -1 when the column is past the end of line
+func byteOffset(line string, character int) int {
+	n := 0
+	for i, r := range line {
+		if n >= character {
+			return i
+		}
+		n += utf16.RuneLen(r)
+	}
+	if n >= character {
+		return len(line)
+	}
+	return -1
+}
@@ -0,0 +1,288 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/artisoft-io/jetstore/jets/compilerv2/compiler"
+)
+
+// This file contains test cases for the jetrule language server
+
+var testRuleFiles = map[string]string{
+	"/ws/main.jr": `import "classes.jr"
+
+resource rdf:type = "rdf:type";
+int MAX_COUNT = 10;
+
+[Rule1]: (?x rdf:type ClassA).(?x someProperty ?v) -> (?x someValue MAX_COUNT);
+`,
+	"/ws/classes.jr": `class ClassA {
+  $base_classes = [owl:Thing],
+  $data_properties = [
+    someProperty       as int,
+    someValue          as array of resource
+  ],
+  $as_table = true
+};
+`,
+}
+
+func testReadFile(filePath string) (string, error) {
+	content, ok := testRuleFiles[strings.ReplaceAll(filePath, "//", "/")]
+	if !ok {
+		return "", fmt.Errorf("file not found: %s", filePath)
+	}
+	return content, nil
+}
+
+func TestMessageFraming(t *testing.T) {
+	var buf bytes.Buffer
+	id := json.RawMessage(`1`)
+	err := writeMessage(&buf, &Message{Id: &id, Method: "initialize", Params: json.RawMessage(`{"rootUri":"file:///ws"}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := readMessage(bufio.NewReader(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Method != "initialize" || string(*msg.Id) != "1" || msg.JsonRpc != "2.0" {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+	_, err = readMessage(bufio.NewReader(strings.NewReader("Content-Type: x\r\n\r\n{}")))
+	if err == nil {
+		t.Error("expecting error for message without Content-Length")
+	}
+}
+
+func TestWordAt(t *testing.T) {
+	text := "resource a = \"a\";\n  (?x rdf:type ClassA)"
+	word, prefix := wordAt(text, Position{Line: 1, Character: 10})
+	if word != "rdf:type" || prefix != "rdf:" {
+		t.Errorf("got word %q prefix %q", word, prefix)
+	}
+	word, _ = wordAt(text, Position{Line: 1, Character: 4})
+	if word != "" {
+		t.Errorf("expecting no word for variable, got %q", word)
+	}
+	if got := sourceColumn(lineAt(text, 1), 4); got != 6 {
+		t.Errorf("expecting column 6, got %d", got)
+	}
+}
+
+func TestAnalysis(t *testing.T) {
+	a := analyze("/ws", "main.jr", false, testReadFile)
+	if len(a.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", a.Diagnostics)
+	}
+	symbols := a.Lookup("someValue")
+	if len(symbols) != 1 {
+		t.Fatalf("expecting 1 declaration of someValue, got %d", len(symbols))
+	}
+	sym := symbols[0]
+	if sym.SourceFileName != "classes.jr" || sym.Line != 5 || sym.Owner != "ClassA" || !sym.IsArray {
+		t.Errorf("unexpected symbol: %+v", sym)
+	}
+	symbols = a.Lookup("MAX_COUNT")
+	if len(symbols) != 1 || symbols[0].SourceFileName != "main.jr" || symbols[0].Line != 4 {
+		t.Errorf("unexpected MAX_COUNT declaration: %v", symbols)
+	}
+	hover := a.HoverText(a.Lookup("ClassA"))
+	if !strings.Contains(hover, "someValue as array of resource") {
+		t.Errorf("unexpected hover text: %s", hover)
+	}
+	items := a.Completions("some")
+	if len(items) != 2 || items[0].Label != "someProperty" {
+		t.Errorf("unexpected completions: %v", items)
+	}
+}
+
+func TestAnalysisErrors(t *testing.T) {
+	testRuleFiles["/ws/err.jr"] = "int MAX_COUNT = 10;\n[Rule1]: (?x undefinedProperty ?v) -> (?x rdf:type ?v);\n"
+	defer delete(testRuleFiles, "/ws/err.jr")
+	a := analyze("/ws", "err.jr", false, testReadFile)
+	if len(a.Diagnostics) == 0 {
+		t.Fatal("expecting diagnostics")
+	}
+	for _, d := range a.Diagnostics {
+		if d.SourceFileName != "err.jr" || d.Line != 2 {
+			t.Errorf("unexpected diagnostic: %+v", d)
+		}
+	}
+}
+
+// Symbols and Diagnostics return the source positions without changing the compiler state
+func TestCompilerPositionsStable(t *testing.T) {
+	testRuleFiles["/ws/err.jr"] = "import \"classes.jr\"\nint MAX_COUNT = 10;\n[Rule1]: (?x undefinedProperty ?v) -> (?x rdf:type ?v);\n"
+	defer delete(testRuleFiles, "/ws/err.jr")
+	jrCompiler := compiler.NewCompiler("/ws", "err.jr", false, false, false)
+	jrCompiler.SetReadFile(testReadFile)
+	if err := jrCompiler.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	var symbols []compiler.Symbol
+	for _, sym := range jrCompiler.Symbols() {
+		symbols = append(symbols, *sym)
+	}
+	diagnostics := jrCompiler.Diagnostics()
+	if len(symbols) == 0 || len(diagnostics) == 0 {
+		t.Fatalf("expecting symbols and diagnostics, got %d and %d", len(symbols), len(diagnostics))
+	}
+	for range 2 {
+		for i, sym := range jrCompiler.Symbols() {
+			if *sym != symbols[i] {
+				t.Errorf("expecting symbol %+v, got %+v", symbols[i], *sym)
+			}
+			if sym.Name == "someProperty" && (sym.SourceFileName != "classes.jr" || sym.Line != 4) {
+				t.Errorf("expecting someProperty in classes.jr line 4, got %+v", *sym)
+			}
+		}
+		for i, d := range jrCompiler.Diagnostics() {
+			if d != diagnostics[i] || d.SourceFileName != "err.jr" || d.Line != 3 {
+				t.Errorf("expecting diagnostic %+v in err.jr line 3, got %+v", diagnostics[i], d)
+			}
+		}
+	}
+}
+
+func TestUtf16Columns(t *testing.T) {
+	line := `  a = "é😀"; b`
+	// runes: a at 2, é at 7, 😀 at 8, b at 12
+	tests := []struct{ column, utf16 int }{{0, 0}, {2, 2}, {8, 8}, {9, 10}, {12, 13}, {13, 14}, {15, 16}}
+	for _, tt := range tests {
+		if got := utf16Column(line, tt.column); got != tt.utf16 {
+			t.Errorf("utf16Column(%d): expecting %d, got %d", tt.column, tt.utf16, got)
+		}
+	}
+	if got := byteOffset(line, 13); got != len(line)-1 || line[got:] != "b" {
+		t.Errorf("byteOffset(13): expecting %d, got %d", len(line)-1, got)
+	}
+	if got := byteOffset(line, 14); got != len(line) {
+		t.Errorf("byteOffset(14): expecting %d, got %d", len(line), got)
+	}
+	if got := byteOffset(line, 15); got != -1 {
+		t.Errorf("byteOffset(15): expecting -1, got %d", got)
+	}
+	word, prefix := wordAt(`x "😀" abc:Name`, Position{Line: 0, Character: 11})
+	if word != "abc:Name" || prefix != "abc:" {
+		t.Errorf("got word %q prefix %q", word, prefix)
+	}
+}
+
+// serverSession sends the messages to a new server and returns the messages written by the server
+func serverSession(t *testing.T, messages ...string) []*Message {
+	var in bytes.Buffer
+	for i, m := range messages {
+		msg := &Message{}
+		if err := json.Unmarshal([]byte(m), msg); err != nil {
+			t.Fatalf("message %d: %v", i, err)
+		}
+		if err := writeMessage(&in, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var out bytes.Buffer
+	if err := NewServer(&in, &out, false).Run(); err != nil {
+		t.Fatal(err)
+	}
+	var result []*Message
+	reader := bufio.NewReader(&out)
+	for {
+		msg, err := readMessage(reader)
+		if err != nil {
+			break
+		}
+		result = append(result, msg)
+	}
+	return result
+}
+
+// response returns the response with id
+func response(t *testing.T, messages []*Message, id string) *Message {
+	for _, msg := range messages {
+		if msg.Id != nil && string(*msg.Id) == id {
+			return msg
+		}
+	}
+	t.Fatalf("response %s not found", id)
+	return nil
+}
+
+func TestServer(t *testing.T) {
+	text := "resource name = \"é😀\"; int MAX_COUNT = 10;\n[Rule1]: (?x name ?y) -> (?x name MAX_COUNT);\n"
+	textJson, _ := json.Marshal(text)
+	messages := serverSession(t,
+		`{"id": 1, "method": "initialize", "params": {"rootUri": "file:///ws"}}`,
+		`{"method": "textDocument/didOpen", "params": {"textDocument": {"uri": "file:///ws/utf16.jr", "text": `+string(textJson)+`}}}`,
+		`{"id": 2, "method": "textDocument/definition", "params": {"textDocument": {"uri": "file:///ws/utf16.jr"}, "position": {"line": 1, "character": 36}}}`,
+		`{"method": "textDocument/didClose", "params": {"textDocument": {"uri": "file:///ws/utf16.jr"}}}`,
+		`{"id": 3, "method": "textDocument/definition", "params": {"textDocument": {"uri": "file:///ws/utf16.jr"}, "position": {"line": 1, "character": 36}}}`,
+		`{"id": 4, "method": "shutdown"}`,
+		`{"id": 5, "method": "textDocument/hover", "params": {"textDocument": {"uri": "file:///ws/utf16.jr"}, "position": {"line": 1, "character": 36}}}`,
+		`{"method": "exit"}`,
+	)
+
+	// MAX_COUNT is declared after the surrogate pair of 😀, at rune 26 and UTF-16 column 27
+	var locations []Location
+	if err := json.Unmarshal(*response(t, messages, "2").Result, &locations); err != nil {
+		t.Fatal(err)
+	}
+	expected := Range{Start: Position{Line: 0, Character: 27}, End: Position{Line: 0, Character: 36}}
+	if len(locations) != 1 || locations[0].Uri != "file:///ws/utf16.jr" || locations[0].Range != expected {
+		t.Errorf("expecting definition at %v, got %v", expected, locations)
+	}
+
+	// didClose clears the diagnostics and the analysis of the document
+	var published []PublishDiagnosticsParams
+	for _, msg := range messages {
+		if msg.Method == "textDocument/publishDiagnostics" {
+			var params PublishDiagnosticsParams
+			json.Unmarshal(msg.Params, &params)
+			published = append(published, params)
+		}
+	}
+	if len(published) != 2 || published[1].Uri != "file:///ws/utf16.jr" || len(published[1].Diagnostics) != 0 {
+		t.Errorf("expecting the diagnostics of the document cleared on close, got %v", published)
+	}
+	locations = nil
+	json.Unmarshal(*response(t, messages, "3").Result, &locations)
+	if len(locations) != 0 {
+		t.Errorf("expecting no definition after close, got %v", locations)
+	}
+
+	// Requests after shutdown are invalid
+	rerr := response(t, messages, "5").Error
+	if response(t, messages, "4").Error != nil || rerr == nil || rerr.Code != InvalidRequest {
+		t.Errorf("expecting InvalidRequest after shutdown, got %v", rerr)
+	}
+}
+
+func TestServerDiagnosticsUtf16(t *testing.T) {
+	text := "resource name = \"😀😀\"; [Rule1]: (?x undefinedProperty ?v) -> (?x name ?v);\n"
+	textJson, _ := json.Marshal(text)
+	messages := serverSession(t,
+		`{"method": "textDocument/didOpen", "params": {"textDocument": {"uri": "file:///tmp/diag.jr", "text": `+string(textJson)+`}}}`,
+		`{"method": "exit"}`,
+	)
+	if len(messages) != 1 {
+		t.Fatalf("expecting the diagnostics, got %d messages", len(messages))
+	}
+	var params PublishDiagnosticsParams
+	json.Unmarshal(messages[0].Params, &params)
+	if len(params.Diagnostics) == 0 {
+		t.Fatal("expecting diagnostics")
+	}
+	// The range ends at the end of line, in UTF-16 code units
+	lineLength := len(utf16.Encode([]rune(strings.TrimSuffix(text, "\n"))))
+	for _, d := range params.Diagnostics {
+		if d.Range.Start.Line != 0 || d.Range.End.Character != lineLength || d.Range.Start.Character > lineLength {
+			t.Errorf("unexpected diagnostic range %v, line length %d", d.Range, lineLength)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/artisoft-io/jetstore/jets/compilerv2/compiler"
+)
+
+// Language Server for the JetRule files, using compilerv2:
+//   - diagnostics: syntax and compilation errors, published when a rule file is opened or changed
+//   - go-to-definition: resources, literals, classes, data properties and lookup tables,
+//     across the imported files
+//   - hover: the declaration, incl. the data property types
+//   - completion: the declared identifiers
+//
+// Each opened rule file is compiled as a main rule file with its imports, the
+// import paths are relative to the workspace root (rootUri).
+
+type Server struct {
+	in               *bufio.Reader
+	out              io.Writer
+	basePath         string
+	autoAddResources bool
+	// open documents by uri
+	documents map[string]string
+	// analysis of the open documents by uri
+	analyses map[string]*Analysis
+	shutdown bool
+}
+
+func NewServer(in io.Reader, out io.Writer, autoAddResources bool) *Server {
+	return &Server{
+		in:               bufio.NewReader(in),
+		out:              out,
+		autoAddResources: autoAddResources,
+		documents:        make(map[string]string),
+		analyses:         make(map[string]*Analysis),
+	}
+}
+
+// Run processes the messages until the exit notification or the end of input
+func (s *Server) Run() error {
+	for {
+		msg, err := readMessage(s.in)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		result, rerr := s.handle(msg)
+		if msg.Id == nil {
+			// notification, no response
+			if rerr != nil {
+				log.Printf("while handling %s: %s", msg.Method, rerr.Message)
+			}
+			continue
+		}
+		response := &Message{Id: msg.Id, Error: rerr}
+		if rerr == nil {
+			b, err := json.Marshal(result)
+			if err != nil {
+				response.Error = &ResponseError{Code: InternalError, Message: err.Error()}
+			} else {
+				raw := json.RawMessage(b)
+				response.Result = &raw
+			}
+		}
+		err = writeMessage(s.out, response)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *Message) (any, *ResponseError) {
+	if s.shutdown && msg.Method != "exit" {
+		return nil, &ResponseError{Code: InvalidRequest, Message: "server is shut down"}
+	}
+	switch msg.Method {
+	case "initialize":
+		var params InitializeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &ResponseError{Code: InvalidParams, Message: err.Error()}
+		}
+		if params.RootUri != "" {
+			s.basePath = uriToPath(params.RootUri)
+		}
+		return map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync":   map[string]any{"openClose": true, "change": 1, "save": map[string]any{"includeText": true}},
+				"definitionProvider": true,
+				"hoverProvider":      true,
+				"completionProvider": map[string]any{"triggerCharacters": []string{":"}},
+			},
+			"serverInfo": map[string]any{"name": "jetrule-lsp"},
+		}, nil
+
+	case "initialized", "$/cancelRequest", "$/setTrace":
+		return nil, nil
+
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &ResponseError{Code: InvalidParams, Message: err.Error()}
+		}
+		s.documents[params.TextDocument.Uri] = params.TextDocument.Text
+		return nil, s.update(params.TextDocument.Uri)
+
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &ResponseError{Code: InvalidParams, Message: err.Error()}
+		}
+		// Full document sync, the last change is the document
+		if n := len(params.ContentChanges); n > 0 {
+			s.documents[params.TextDocument.Uri] = params.ContentChanges[n-1].Text
+		}
+		return nil, s.update(params.TextDocument.Uri)
+
+	case "textDocument/didSave":
+		var params DidSaveTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &ResponseError{Code: InvalidParams, Message: err.Error()}
+		}
+		if params.Text != nil {
+			s.documents[params.TextDocument.Uri] = *params.Text
+		}
+		return nil, s.update(params.TextDocument.Uri)
+
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &ResponseError{Code: InvalidParams, Message: err.Error()}
+		}
+		delete(s.documents, params.TextDocument.Uri)
+		delete(s.analyses, params.TextDocument.Uri)
+		return nil, s.publish(&PublishDiagnosticsParams{Uri: params.TextDocument.Uri, Diagnostics: []Diagnostic{}})
+
+	case "textDocument/definition", "textDocument/hover", "textDocument/completion":
+		var params TextDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &ResponseError{Code: InvalidParams, Message: err.Error()}
+		}
+		word, prefix := wordAt(s.documents[params.TextDocument.Uri], params.Position)
+		switch msg.Method {
+		case "textDocument/definition":
+			return s.definition(params.TextDocument.Uri, word), nil
+		case "textDocument/hover":
+			return s.hover(params.TextDocument.Uri, word), nil
+		default:
+			a := s.analyses[params.TextDocument.Uri]
+			if a == nil {
+				return []CompletionItem{}, nil
+			}
+			return a.Completions(prefix), nil
+		}
+	}
+	if strings.HasPrefix(msg.Method, "$/") {
+		return nil, nil
+	}
+	return nil, &ResponseError{Code: MethodNotFound, Message: fmt.Sprintf("method not supported: %s", msg.Method)}
+}
+
+// update compiles the document and publishes its diagnostics
+func (s *Server) update(uri string) *ResponseError {
+	path := uriToPath(uri)
+	basePath := s.basePath
+	mainRuleFile, err := filepath.Rel(basePath, path)
+	if basePath == "" || err != nil || strings.HasPrefix(mainRuleFile, "..") {
+		// Not in the workspace, imports are relative to the rule file
+		basePath = filepath.Dir(path)
+		mainRuleFile = filepath.Base(path)
+	}
+	a := analyze(basePath, mainRuleFile, s.autoAddResources, s.readFile)
+	s.analyses[uri] = a
+
+	params := &PublishDiagnosticsParams{Uri: uri, Diagnostics: []Diagnostic{}}
+	text := s.documents[uri]
+	for _, d := range a.Diagnostics {
+		if d.SourceFileName != mainRuleFile && d.SourceFileName != "" {
+			// Error in an imported file, reported at the top of the document
+			params.Diagnostics = append(params.Diagnostics, Diagnostic{
+				Severity: SeverityError,
+				Source:   "jetrule",
+				Message:  fmt.Sprintf("in imported file %s line %d: %s", d.SourceFileName, d.Line, d.Message),
+			})
+			continue
+		}
+		var r Range
+		if d.Line > 0 {
+			line := lineAt(text, d.Line-1)
+			lineLength := utf8.RuneCountInString(line)
+			column := min(sourceColumn(line, d.Column), lineLength)
+			r = Range{
+				Start: Position{Line: d.Line - 1, Character: utf16Column(line, column)},
+				End:   Position{Line: d.Line - 1, Character: utf16Column(line, max(lineLength, column+1))},
+			}
+		}
+		params.Diagnostics = append(params.Diagnostics, Diagnostic{
+			Range:    r,
+			Severity: SeverityError,
+			Source:   "jetrule",
+			Message:  d.Message,
+		})
+	}
+	return s.publish(params)
+}
+
+func (s *Server) publish(params *PublishDiagnosticsParams) *ResponseError {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return &ResponseError{Code: InternalError, Message: err.Error()}
+	}
+	err = writeMessage(s.out, &Message{Method: "textDocument/publishDiagnostics", Params: b})
+	if err != nil {
+		return &ResponseError{Code: InternalError, Message: err.Error()}
+	}
+	return nil
+}
+
+// lookup returns the declarations of name, from the analysis of the document
+// or else from the analysis of the other open documents
+func (s *Server) lookup(uri, name string) (*Analysis, []*compiler.Symbol) {
+	if name == "" {
+		return nil, nil
+	}
+	if a := s.analyses[uri]; a != nil {
+		if symbols := a.Lookup(name); len(symbols) > 0 {
+			return a, symbols
+		}
+	}
+	for _, a := range s.analyses {
+		if symbols := a.Lookup(name); len(symbols) > 0 {
+			return a, symbols
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) definition(uri, name string) []Location {
+	locations := make([]Location, 0)
+	a, symbols := s.lookup(uri, name)
+	for _, sym := range symbols {
+		path := filepath.Join(a.BasePath, sym.SourceFileName)
+		var line string
+		column := sym.Column
+		if text, err := s.readFile(path); err == nil {
+			line = lineAt(text, sym.Line-1)
+			column = sourceColumn(line, sym.Column)
+		}
+		locations = append(locations, Location{
+			Uri: pathToUri(path),
+			Range: Range{
+				Start: Position{Line: sym.Line - 1, Character: utf16Column(line, column)},
+				End:   Position{Line: sym.Line - 1, Character: utf16Column(line, column+utf8.RuneCountInString(sym.Name))},
+			},
+		})
+	}
+	return locations
+}
+
+func (s *Server) hover(uri, name string) *Hover {
+	a, symbols := s.lookup(uri, name)
+	if len(symbols) == 0 {
+		return nil
+	}
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: a.HoverText(symbols)}}
+}
+
+// readFile returns the content of the open document or else reads the file
+func (s *Server) readFile(filePath string) (string, error) {
+	uri := pathToUri(filepath.Clean(filePath))
+	if text, ok := s.documents[uri]; ok {
+		return text, nil
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.Clean(u.Path)
+}
+
+func pathToUri(path string) string {
+	u := url.URL{Scheme: "file", Path: path}
+	return u.String()
+}
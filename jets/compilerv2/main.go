@@ -19,6 +19,7 @@ var dependencyPropertyName = flag.String("dependency_property_name", "", "The pr
 var saveJson = flag.Bool("save_json", false, "Save JetRule json output file")
 var trace = flag.Bool("trace", false, "Enable trace logging")
 var autoAddResources = flag.Bool("a", false, "Enable automatic resource addition when an identifier is not defined")
+var lint = flag.Bool("lint", false, "Run the static checks of the rules and report the warnings")
 
 func main() {
 	inputFileNameSP := flag.String("f", "", "JetRule file (required) short name")
@@ -69,6 +70,13 @@ func main() {
 		log.Println(jrCompiler.ErrorLog().String())
 	}
 	log.Println("** Compilation successful")
+	if *lint {
+		warnings := jrCompiler.Lint()
+		for _, w := range warnings {
+			log.Printf("** %s:%d: %s\n", w.SourceFileName, w.Line, w.Message)
+		}
+		log.Printf("** Lint completed with %d warning(s)\n", len(warnings))
+	}
 	if len(*runOptions) > 0 {
 		analyzer := analyzer.NewAnalyzer(*basePath, *inputFileName, *runOptions, *dependencyPropertyName, *saveJson, jrCompiler)
 		err = analyzer.Analyze()
@@ -297,12 +297,30 @@ func (s *BaseJetRuleListener) EnterAntecedent(ctx *AntecedentContext) {}
 // ExitAntecedent is called when production antecedent is exited.
 func (s *BaseJetRuleListener) ExitAntecedent(ctx *AntecedentContext) {}
 
+// EnterOrAntecedent is called when production orAntecedent is entered.
+func (s *BaseJetRuleListener) EnterOrAntecedent(ctx *OrAntecedentContext) {}
+
+// ExitOrAntecedent is called when production orAntecedent is exited.
+func (s *BaseJetRuleListener) ExitOrAntecedent(ctx *OrAntecedentContext) {}
+
+// EnterOrBranch is called when production orBranch is entered.
+func (s *BaseJetRuleListener) EnterOrBranch(ctx *OrBranchContext) {}
+
+// ExitOrBranch is called when production orBranch is exited.
+func (s *BaseJetRuleListener) ExitOrBranch(ctx *OrBranchContext) {}
+
 // EnterConsequent is called when production consequent is entered.
 func (s *BaseJetRuleListener) EnterConsequent(ctx *ConsequentContext) {}
 
 // ExitConsequent is called when production consequent is exited.
 func (s *BaseJetRuleListener) ExitConsequent(ctx *ConsequentContext) {}
 
+// EnterAggregateTerm is called when production aggregateTerm is entered.
+func (s *BaseJetRuleListener) EnterAggregateTerm(ctx *AggregateTermContext) {}
+
+// ExitAggregateTerm is called when production aggregateTerm is exited.
+func (s *BaseJetRuleListener) ExitAggregateTerm(ctx *AggregateTermContext) {}
+
 // EnterAtom is called when production atom is entered.
 func (s *BaseJetRuleListener) EnterAtom(ctx *AtomContext) {}
 
@@ -146,9 +146,18 @@ type JetRuleListener interface {
 	// EnterAntecedent is called when entering the antecedent production.
 	EnterAntecedent(c *AntecedentContext)
 
+	// EnterOrAntecedent is called when entering the orAntecedent production.
+	EnterOrAntecedent(c *OrAntecedentContext)
+
+	// EnterOrBranch is called when entering the orBranch production.
+	EnterOrBranch(c *OrBranchContext)
+
 	// EnterConsequent is called when entering the consequent production.
 	EnterConsequent(c *ConsequentContext)
 
+	// EnterAggregateTerm is called when entering the aggregateTerm production.
+	EnterAggregateTerm(c *AggregateTermContext)
+
 	// EnterAtom is called when entering the atom production.
 	EnterAtom(c *AtomContext)
 
@@ -326,9 +335,18 @@ type JetRuleListener interface {
 	// ExitAntecedent is called when exiting the antecedent production.
 	ExitAntecedent(c *AntecedentContext)
 
+	// ExitOrAntecedent is called when exiting the orAntecedent production.
+	ExitOrAntecedent(c *OrAntecedentContext)
+
+	// ExitOrBranch is called when exiting the orBranch production.
+	ExitOrBranch(c *OrBranchContext)
+
 	// ExitConsequent is called when exiting the consequent production.
 	ExitConsequent(c *ConsequentContext)
 
+	// ExitAggregateTerm is called when exiting the aggregateTerm production.
+	ExitAggregateTerm(c *AggregateTermContext)
+
 	// ExitAtom is called when exiting the atom production.
 	ExitAtom(c *AtomContext)
 
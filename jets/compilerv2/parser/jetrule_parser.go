@@ -68,13 +68,13 @@ func jetruleParserInit() {
 		"intExpr", "uintExpr", "doubleExpr", "declIdentifier", "defineResourceStmt",
 		"namedResourceStmt", "volatileResourceStmt", "resourceValue", "lookupTableStmt",
 		"csvLocation", "stringList", "stringSeq", "columnDefSeq", "columnDefinitions",
-		"jetRuleStmt", "ruleProperties", "propertyValue", "antecedent", "consequent",
-		"atom", "objectAtom", "keywords", "exprTerm", "binaryOp", "unaryOp",
-		"tripleStmt",
+		"jetRuleStmt", "ruleProperties", "propertyValue", "antecedent", "orAntecedent",
+		"orBranch", "consequent", "aggregateTerm", "atom", "objectAtom", "keywords",
+		"exprTerm", "binaryOp", "unaryOp", "tripleStmt",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 69, 817, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 69, 877, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
 		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7, 15,
 		2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7, 20, 2,
@@ -84,362 +84,390 @@ func jetruleParserInit() {
 		2, 37, 7, 37, 2, 38, 7, 38, 2, 39, 7, 39, 2, 40, 7, 40, 2, 41, 7, 41, 2,
 		42, 7, 42, 2, 43, 7, 43, 2, 44, 7, 44, 2, 45, 7, 45, 2, 46, 7, 46, 2, 47,
 		7, 47, 2, 48, 7, 48, 2, 49, 7, 49, 2, 50, 7, 50, 2, 51, 7, 51, 2, 52, 7,
-		52, 2, 53, 7, 53, 1, 0, 5, 0, 110, 8, 0, 10, 0, 12, 0, 113, 9, 0, 1, 0,
-		1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1,
-		127, 8, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 5, 3,
-		138, 8, 3, 10, 3, 12, 3, 141, 9, 3, 1, 3, 1, 3, 5, 3, 145, 8, 3, 10, 3,
-		12, 3, 148, 9, 3, 1, 3, 1, 3, 1, 3, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 5, 5,
-		158, 8, 5, 10, 5, 12, 5, 161, 9, 5, 1, 5, 5, 5, 164, 8, 5, 10, 5, 12, 5,
-		167, 9, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6,
-		5, 6, 179, 8, 6, 10, 6, 12, 6, 182, 9, 6, 1, 6, 1, 6, 1, 6, 5, 6, 187,
-		8, 6, 10, 6, 12, 6, 190, 9, 6, 1, 6, 5, 6, 193, 8, 6, 10, 6, 12, 6, 196,
-		9, 6, 1, 6, 5, 6, 199, 8, 6, 10, 6, 12, 6, 202, 9, 6, 1, 6, 1, 6, 3, 6,
-		206, 8, 6, 1, 7, 1, 7, 1, 7, 1, 7, 5, 7, 212, 8, 7, 10, 7, 12, 7, 215,
-		9, 7, 1, 7, 1, 7, 1, 7, 5, 7, 220, 8, 7, 10, 7, 12, 7, 223, 9, 7, 1, 7,
-		5, 7, 226, 8, 7, 10, 7, 12, 7, 229, 9, 7, 1, 7, 5, 7, 232, 8, 7, 10, 7,
-		12, 7, 235, 9, 7, 1, 7, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 1, 8, 5, 8, 244,
-		8, 8, 10, 8, 12, 8, 247, 9, 8, 1, 8, 1, 8, 1, 8, 5, 8, 252, 8, 8, 10, 8,
-		12, 8, 255, 9, 8, 1, 8, 5, 8, 258, 8, 8, 10, 8, 12, 8, 261, 9, 8, 1, 8,
-		5, 8, 264, 8, 8, 10, 8, 12, 8, 267, 9, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8,
-		1, 8, 5, 8, 275, 8, 8, 10, 8, 12, 8, 278, 9, 8, 1, 8, 1, 8, 1, 8, 5, 8,
-		283, 8, 8, 10, 8, 12, 8, 286, 9, 8, 1, 8, 5, 8, 289, 8, 8, 10, 8, 12, 8,
-		292, 9, 8, 1, 8, 5, 8, 295, 8, 8, 10, 8, 12, 8, 298, 9, 8, 1, 8, 1, 8,
-		1, 8, 1, 8, 1, 8, 1, 8, 5, 8, 306, 8, 8, 10, 8, 12, 8, 309, 9, 8, 1, 8,
-		1, 8, 1, 8, 5, 8, 314, 8, 8, 10, 8, 12, 8, 317, 9, 8, 1, 8, 5, 8, 320,
-		8, 8, 10, 8, 12, 8, 323, 9, 8, 1, 8, 5, 8, 326, 8, 8, 10, 8, 12, 8, 329,
-		9, 8, 1, 8, 1, 8, 1, 8, 3, 8, 334, 8, 8, 1, 9, 1, 9, 1, 10, 1, 10, 1, 10,
-		3, 10, 341, 8, 10, 1, 10, 1, 10, 1, 11, 1, 11, 1, 12, 1, 12, 1, 13, 1,
-		13, 1, 13, 1, 13, 1, 14, 1, 14, 1, 15, 1, 15, 1, 15, 1, 15, 5, 15, 359,
-		8, 15, 10, 15, 12, 15, 362, 9, 15, 1, 15, 1, 15, 1, 15, 1, 15, 5, 15, 368,
-		8, 15, 10, 15, 12, 15, 371, 9, 15, 1, 15, 1, 15, 5, 15, 375, 8, 15, 10,
-		15, 12, 15, 378, 9, 15, 1, 15, 1, 15, 3, 15, 382, 8, 15, 1, 15, 5, 15,
-		385, 8, 15, 10, 15, 12, 15, 388, 9, 15, 1, 15, 1, 15, 1, 15, 1, 16, 1,
-		16, 1, 16, 5, 16, 396, 8, 16, 10, 16, 12, 16, 399, 9, 16, 1, 16, 5, 16,
-		402, 8, 16, 10, 16, 12, 16, 405, 9, 16, 1, 17, 1, 17, 1, 18, 1, 18, 1,
-		18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 3, 18, 418, 8, 18, 1, 19,
-		1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1,
-		20, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 22, 1, 22, 1, 22, 1, 22,
-		1, 22, 1, 22, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 24, 1, 24, 1,
-		24, 1, 24, 1, 24, 1, 24, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 26,
-		1, 26, 1, 26, 1, 26, 1, 26, 1, 26, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1,
-		27, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 3, 28, 479, 8, 28, 1, 29, 1, 29,
-		1, 29, 3, 29, 484, 8, 29, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1,
-		30, 3, 30, 493, 8, 30, 3, 30, 495, 8, 30, 1, 31, 1, 31, 1, 31, 1, 31, 1,
-		31, 1, 31, 1, 31, 3, 31, 504, 8, 31, 1, 32, 1, 32, 3, 32, 508, 8, 32, 1,
-		33, 1, 33, 1, 33, 1, 33, 1, 33, 1, 33, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34,
-		1, 34, 1, 35, 1, 35, 1, 35, 3, 35, 525, 8, 35, 1, 36, 1, 36, 1, 36, 1,
-		36, 5, 36, 531, 8, 36, 10, 36, 12, 36, 534, 9, 36, 1, 36, 1, 36, 5, 36,
-		538, 8, 36, 10, 36, 12, 36, 541, 9, 36, 1, 36, 1, 36, 1, 36, 1, 36, 1,
-		36, 5, 36, 548, 8, 36, 10, 36, 12, 36, 551, 9, 36, 1, 36, 1, 36, 1, 36,
-		1, 36, 5, 36, 557, 8, 36, 10, 36, 12, 36, 560, 9, 36, 1, 36, 1, 36, 5,
-		36, 564, 8, 36, 10, 36, 12, 36, 567, 9, 36, 1, 36, 1, 36, 3, 36, 571, 8,
-		36, 1, 36, 5, 36, 574, 8, 36, 10, 36, 12, 36, 577, 9, 36, 1, 36, 1, 36,
-		1, 36, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 3,
-		37, 591, 8, 37, 1, 38, 1, 38, 3, 38, 595, 8, 38, 1, 38, 1, 38, 1, 39, 1,
-		39, 1, 39, 5, 39, 602, 8, 39, 10, 39, 12, 39, 605, 9, 39, 1, 40, 1, 40,
-		1, 40, 5, 40, 610, 8, 40, 10, 40, 12, 40, 613, 9, 40, 1, 40, 5, 40, 616,
-		8, 40, 10, 40, 12, 40, 619, 9, 40, 1, 41, 1, 41, 1, 41, 3, 41, 624, 8,
-		41, 1, 41, 1, 41, 1, 42, 1, 42, 1, 42, 5, 42, 631, 8, 42, 10, 42, 12, 42,
-		634, 9, 42, 1, 42, 1, 42, 1, 42, 5, 42, 639, 8, 42, 10, 42, 12, 42, 642,
-		9, 42, 1, 42, 1, 42, 5, 42, 646, 8, 42, 10, 42, 12, 42, 649, 9, 42, 4,
-		42, 651, 8, 42, 11, 42, 12, 42, 652, 1, 42, 1, 42, 5, 42, 657, 8, 42, 10,
-		42, 12, 42, 660, 9, 42, 1, 42, 1, 42, 5, 42, 664, 8, 42, 10, 42, 12, 42,
-		667, 9, 42, 4, 42, 669, 8, 42, 11, 42, 12, 42, 670, 1, 42, 1, 42, 1, 43,
-		1, 43, 1, 43, 1, 43, 1, 43, 1, 44, 1, 44, 1, 44, 1, 44, 3, 44, 684, 8,
-		44, 1, 45, 3, 45, 687, 8, 45, 1, 45, 1, 45, 1, 45, 1, 45, 1, 45, 1, 45,
-		3, 45, 695, 8, 45, 1, 45, 1, 45, 1, 45, 1, 45, 3, 45, 701, 8, 45, 3, 45,
-		703, 8, 45, 1, 46, 1, 46, 1, 46, 1, 46, 1, 46, 1, 46, 3, 46, 711, 8, 46,
-		1, 47, 1, 47, 1, 47, 3, 47, 716, 8, 47, 1, 48, 1, 48, 1, 48, 1, 48, 1,
-		48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48,
-		1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1,
-		48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48,
-		1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 3, 48, 763,
-		8, 48, 1, 49, 1, 49, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1,
-		50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50,
-		1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 3, 50, 792, 8, 50, 1,
-		50, 1, 50, 1, 50, 1, 50, 5, 50, 798, 8, 50, 10, 50, 12, 50, 801, 9, 50,
-		1, 51, 1, 51, 1, 52, 1, 52, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1,
-		53, 1, 53, 1, 53, 1, 53, 1, 53, 0, 1, 100, 54, 0, 2, 4, 6, 8, 10, 12, 14,
-		16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50,
-		52, 54, 56, 58, 60, 62, 64, 66, 68, 70, 72, 74, 76, 78, 80, 82, 84, 86,
-		88, 90, 92, 94, 96, 98, 100, 102, 104, 106, 0, 6, 1, 0, 20, 21, 1, 0, 28,
-		37, 1, 0, 45, 46, 1, 0, 45, 47, 2, 0, 50, 62, 65, 65, 2, 0, 48, 49, 65,
-		65, 875, 0, 111, 1, 0, 0, 0, 2, 126, 1, 0, 0, 0, 4, 128, 1, 0, 0, 0, 6,
-		134, 1, 0, 0, 0, 8, 152, 1, 0, 0, 0, 10, 154, 1, 0, 0, 0, 12, 205, 1, 0,
-		0, 0, 14, 207, 1, 0, 0, 0, 16, 333, 1, 0, 0, 0, 18, 335, 1, 0, 0, 0, 20,
-		337, 1, 0, 0, 0, 22, 344, 1, 0, 0, 0, 24, 346, 1, 0, 0, 0, 26, 348, 1,
-		0, 0, 0, 28, 352, 1, 0, 0, 0, 30, 354, 1, 0, 0, 0, 32, 392, 1, 0, 0, 0,
-		34, 406, 1, 0, 0, 0, 36, 417, 1, 0, 0, 0, 38, 419, 1, 0, 0, 0, 40, 425,
-		1, 0, 0, 0, 42, 431, 1, 0, 0, 0, 44, 437, 1, 0, 0, 0, 46, 443, 1, 0, 0,
-		0, 48, 449, 1, 0, 0, 0, 50, 455, 1, 0, 0, 0, 52, 461, 1, 0, 0, 0, 54, 467,
-		1, 0, 0, 0, 56, 478, 1, 0, 0, 0, 58, 483, 1, 0, 0, 0, 60, 494, 1, 0, 0,
-		0, 62, 503, 1, 0, 0, 0, 64, 507, 1, 0, 0, 0, 66, 509, 1, 0, 0, 0, 68, 515,
-		1, 0, 0, 0, 70, 524, 1, 0, 0, 0, 72, 526, 1, 0, 0, 0, 74, 590, 1, 0, 0,
-		0, 76, 592, 1, 0, 0, 0, 78, 598, 1, 0, 0, 0, 80, 606, 1, 0, 0, 0, 82, 620,
-		1, 0, 0, 0, 84, 627, 1, 0, 0, 0, 86, 674, 1, 0, 0, 0, 88, 683, 1, 0, 0,
-		0, 90, 686, 1, 0, 0, 0, 92, 704, 1, 0, 0, 0, 94, 715, 1, 0, 0, 0, 96, 762,
-		1, 0, 0, 0, 98, 764, 1, 0, 0, 0, 100, 791, 1, 0, 0, 0, 102, 802, 1, 0,
-		0, 0, 104, 804, 1, 0, 0, 0, 106, 806, 1, 0, 0, 0, 108, 110, 3, 2, 1, 0,
-		109, 108, 1, 0, 0, 0, 110, 113, 1, 0, 0, 0, 111, 109, 1, 0, 0, 0, 111,
-		112, 1, 0, 0, 0, 112, 114, 1, 0, 0, 0, 113, 111, 1, 0, 0, 0, 114, 115,
-		5, 0, 0, 1, 115, 1, 1, 0, 0, 0, 116, 127, 3, 4, 2, 0, 117, 127, 3, 6, 3,
-		0, 118, 127, 3, 36, 18, 0, 119, 127, 3, 14, 7, 0, 120, 127, 3, 30, 15,
-		0, 121, 127, 3, 64, 32, 0, 122, 127, 3, 72, 36, 0, 123, 127, 3, 84, 42,
-		0, 124, 127, 3, 106, 53, 0, 125, 127, 5, 68, 0, 0, 126, 116, 1, 0, 0, 0,
-		126, 117, 1, 0, 0, 0, 126, 118, 1, 0, 0, 0, 126, 119, 1, 0, 0, 0, 126,
-		120, 1, 0, 0, 0, 126, 121, 1, 0, 0, 0, 126, 122, 1, 0, 0, 0, 126, 123,
-		1, 0, 0, 0, 126, 124, 1, 0, 0, 0, 126, 125, 1, 0, 0, 0, 127, 3, 1, 0, 0,
-		0, 128, 129, 5, 13, 0, 0, 129, 130, 3, 62, 31, 0, 130, 131, 5, 64, 0, 0,
-		131, 132, 5, 67, 0, 0, 132, 133, 5, 63, 0, 0, 133, 5, 1, 0, 0, 0, 134,
-		135, 3, 8, 4, 0, 135, 139, 5, 1, 0, 0, 136, 138, 5, 68, 0, 0, 137, 136,
-		1, 0, 0, 0, 138, 141, 1, 0, 0, 0, 139, 137, 1, 0, 0, 0, 139, 140, 1, 0,
-		0, 0, 140, 142, 1, 0, 0, 0, 141, 139, 1, 0, 0, 0, 142, 146, 3, 10, 5, 0,
-		143, 145, 5, 68, 0, 0, 144, 143, 1, 0, 0, 0, 145, 148, 1, 0, 0, 0, 146,
-		144, 1, 0, 0, 0, 146, 147, 1, 0, 0, 0, 147, 149, 1, 0, 0, 0, 148, 146,
-		1, 0, 0, 0, 149, 150, 5, 2, 0, 0, 150, 151, 5, 63, 0, 0, 151, 7, 1, 0,
-		0, 0, 152, 153, 7, 0, 0, 0, 153, 9, 1, 0, 0, 0, 154, 165, 3, 12, 6, 0,
-		155, 159, 5, 3, 0, 0, 156, 158, 5, 68, 0, 0, 157, 156, 1, 0, 0, 0, 158,
-		161, 1, 0, 0, 0, 159, 157, 1, 0, 0, 0, 159, 160, 1, 0, 0, 0, 160, 162,
-		1, 0, 0, 0, 161, 159, 1, 0, 0, 0, 162, 164, 3, 12, 6, 0, 163, 155, 1, 0,
-		0, 0, 164, 167, 1, 0, 0, 0, 165, 163, 1, 0, 0, 0, 165, 166, 1, 0, 0, 0,
-		166, 11, 1, 0, 0, 0, 167, 165, 1, 0, 0, 0, 168, 169, 5, 22, 0, 0, 169,
-		170, 5, 64, 0, 0, 170, 206, 3, 58, 29, 0, 171, 172, 5, 23, 0, 0, 172, 173,
-		5, 64, 0, 0, 173, 206, 3, 58, 29, 0, 174, 175, 5, 24, 0, 0, 175, 176, 5,
-		64, 0, 0, 176, 180, 5, 4, 0, 0, 177, 179, 5, 68, 0, 0, 178, 177, 1, 0,
-		0, 0, 179, 182, 1, 0, 0, 0, 180, 178, 1, 0, 0, 0, 180, 181, 1, 0, 0, 0,
-		181, 183, 1, 0, 0, 0, 182, 180, 1, 0, 0, 0, 183, 194, 3, 62, 31, 0, 184,
-		188, 5, 3, 0, 0, 185, 187, 5, 68, 0, 0, 186, 185, 1, 0, 0, 0, 187, 190,
-		1, 0, 0, 0, 188, 186, 1, 0, 0, 0, 188, 189, 1, 0, 0, 0, 189, 191, 1, 0,
-		0, 0, 190, 188, 1, 0, 0, 0, 191, 193, 3, 62, 31, 0, 192, 184, 1, 0, 0,
-		0, 193, 196, 1, 0, 0, 0, 194, 192, 1, 0, 0, 0, 194, 195, 1, 0, 0, 0, 195,
-		200, 1, 0, 0, 0, 196, 194, 1, 0, 0, 0, 197, 199, 5, 68, 0, 0, 198, 197,
-		1, 0, 0, 0, 199, 202, 1, 0, 0, 0, 200, 198, 1, 0, 0, 0, 200, 201, 1, 0,
-		0, 0, 201, 203, 1, 0, 0, 0, 202, 200, 1, 0, 0, 0, 203, 204, 5, 5, 0, 0,
-		204, 206, 1, 0, 0, 0, 205, 168, 1, 0, 0, 0, 205, 171, 1, 0, 0, 0, 205,
-		174, 1, 0, 0, 0, 206, 13, 1, 0, 0, 0, 207, 208, 5, 14, 0, 0, 208, 209,
-		3, 62, 31, 0, 209, 213, 5, 1, 0, 0, 210, 212, 5, 68, 0, 0, 211, 210, 1,
-		0, 0, 0, 212, 215, 1, 0, 0, 0, 213, 211, 1, 0, 0, 0, 213, 214, 1, 0, 0,
-		0, 214, 216, 1, 0, 0, 0, 215, 213, 1, 0, 0, 0, 216, 227, 3, 16, 8, 0, 217,
-		221, 5, 3, 0, 0, 218, 220, 5, 68, 0, 0, 219, 218, 1, 0, 0, 0, 220, 223,
-		1, 0, 0, 0, 221, 219, 1, 0, 0, 0, 221, 222, 1, 0, 0, 0, 222, 224, 1, 0,
-		0, 0, 223, 221, 1, 0, 0, 0, 224, 226, 3, 16, 8, 0, 225, 217, 1, 0, 0, 0,
-		226, 229, 1, 0, 0, 0, 227, 225, 1, 0, 0, 0, 227, 228, 1, 0, 0, 0, 228,
-		233, 1, 0, 0, 0, 229, 227, 1, 0, 0, 0, 230, 232, 5, 68, 0, 0, 231, 230,
-		1, 0, 0, 0, 232, 235, 1, 0, 0, 0, 233, 231, 1, 0, 0, 0, 233, 234, 1, 0,
-		0, 0, 234, 236, 1, 0, 0, 0, 235, 233, 1, 0, 0, 0, 236, 237, 5, 2, 0, 0,
-		237, 238, 5, 63, 0, 0, 238, 15, 1, 0, 0, 0, 239, 240, 5, 15, 0, 0, 240,
-		241, 5, 64, 0, 0, 241, 245, 5, 4, 0, 0, 242, 244, 5, 68, 0, 0, 243, 242,
-		1, 0, 0, 0, 244, 247, 1, 0, 0, 0, 245, 243, 1, 0, 0, 0, 245, 246, 1, 0,
-		0, 0, 246, 248, 1, 0, 0, 0, 247, 245, 1, 0, 0, 0, 248, 259, 3, 18, 9, 0,
-		249, 253, 5, 3, 0, 0, 250, 252, 5, 68, 0, 0, 251, 250, 1, 0, 0, 0, 252,
-		255, 1, 0, 0, 0, 253, 251, 1, 0, 0, 0, 253, 254, 1, 0, 0, 0, 254, 256,
-		1, 0, 0, 0, 255, 253, 1, 0, 0, 0, 256, 258, 3, 18, 9, 0, 257, 249, 1, 0,
-		0, 0, 258, 261, 1, 0, 0, 0, 259, 257, 1, 0, 0, 0, 259, 260, 1, 0, 0, 0,
-		260, 265, 1, 0, 0, 0, 261, 259, 1, 0, 0, 0, 262, 264, 5, 68, 0, 0, 263,
-		262, 1, 0, 0, 0, 264, 267, 1, 0, 0, 0, 265, 263, 1, 0, 0, 0, 265, 266,
-		1, 0, 0, 0, 266, 268, 1, 0, 0, 0, 267, 265, 1, 0, 0, 0, 268, 269, 5, 5,
-		0, 0, 269, 334, 1, 0, 0, 0, 270, 271, 5, 17, 0, 0, 271, 272, 5, 64, 0,
-		0, 272, 276, 5, 4, 0, 0, 273, 275, 5, 68, 0, 0, 274, 273, 1, 0, 0, 0, 275,
-		278, 1, 0, 0, 0, 276, 274, 1, 0, 0, 0, 276, 277, 1, 0, 0, 0, 277, 279,
-		1, 0, 0, 0, 278, 276, 1, 0, 0, 0, 279, 290, 3, 20, 10, 0, 280, 284, 5,
-		3, 0, 0, 281, 283, 5, 68, 0, 0, 282, 281, 1, 0, 0, 0, 283, 286, 1, 0, 0,
-		0, 284, 282, 1, 0, 0, 0, 284, 285, 1, 0, 0, 0, 285, 287, 1, 0, 0, 0, 286,
-		284, 1, 0, 0, 0, 287, 289, 3, 20, 10, 0, 288, 280, 1, 0, 0, 0, 289, 292,
-		1, 0, 0, 0, 290, 288, 1, 0, 0, 0, 290, 291, 1, 0, 0, 0, 291, 296, 1, 0,
-		0, 0, 292, 290, 1, 0, 0, 0, 293, 295, 5, 68, 0, 0, 294, 293, 1, 0, 0, 0,
-		295, 298, 1, 0, 0, 0, 296, 294, 1, 0, 0, 0, 296, 297, 1, 0, 0, 0, 297,
-		299, 1, 0, 0, 0, 298, 296, 1, 0, 0, 0, 299, 300, 5, 5, 0, 0, 300, 334,
-		1, 0, 0, 0, 301, 302, 5, 19, 0, 0, 302, 303, 5, 64, 0, 0, 303, 307, 5,
-		4, 0, 0, 304, 306, 5, 68, 0, 0, 305, 304, 1, 0, 0, 0, 306, 309, 1, 0, 0,
-		0, 307, 305, 1, 0, 0, 0, 307, 308, 1, 0, 0, 0, 308, 310, 1, 0, 0, 0, 309,
-		307, 1, 0, 0, 0, 310, 321, 3, 24, 12, 0, 311, 315, 5, 3, 0, 0, 312, 314,
-		5, 68, 0, 0, 313, 312, 1, 0, 0, 0, 314, 317, 1, 0, 0, 0, 315, 313, 1, 0,
-		0, 0, 315, 316, 1, 0, 0, 0, 316, 318, 1, 0, 0, 0, 317, 315, 1, 0, 0, 0,
-		318, 320, 3, 24, 12, 0, 319, 311, 1, 0, 0, 0, 320, 323, 1, 0, 0, 0, 321,
-		319, 1, 0, 0, 0, 321, 322, 1, 0, 0, 0, 322, 327, 1, 0, 0, 0, 323, 321,
-		1, 0, 0, 0, 324, 326, 5, 68, 0, 0, 325, 324, 1, 0, 0, 0, 326, 329, 1, 0,
-		0, 0, 327, 325, 1, 0, 0, 0, 327, 328, 1, 0, 0, 0, 328, 330, 1, 0, 0, 0,
-		329, 327, 1, 0, 0, 0, 330, 331, 5, 5, 0, 0, 331, 334, 1, 0, 0, 0, 332,
-		334, 3, 26, 13, 0, 333, 239, 1, 0, 0, 0, 333, 270, 1, 0, 0, 0, 333, 301,
-		1, 0, 0, 0, 333, 332, 1, 0, 0, 0, 334, 17, 1, 0, 0, 0, 335, 336, 3, 62,
-		31, 0, 336, 19, 1, 0, 0, 0, 337, 338, 3, 62, 31, 0, 338, 340, 5, 6, 0,
-		0, 339, 341, 5, 18, 0, 0, 340, 339, 1, 0, 0, 0, 340, 341, 1, 0, 0, 0, 341,
-		342, 1, 0, 0, 0, 342, 343, 3, 22, 11, 0, 343, 21, 1, 0, 0, 0, 344, 345,
-		7, 1, 0, 0, 345, 23, 1, 0, 0, 0, 346, 347, 3, 62, 31, 0, 347, 25, 1, 0,
-		0, 0, 348, 349, 5, 16, 0, 0, 349, 350, 5, 64, 0, 0, 350, 351, 3, 28, 14,
-		0, 351, 27, 1, 0, 0, 0, 352, 353, 7, 2, 0, 0, 353, 29, 1, 0, 0, 0, 354,
-		355, 5, 25, 0, 0, 355, 356, 5, 65, 0, 0, 356, 360, 5, 1, 0, 0, 357, 359,
-		5, 68, 0, 0, 358, 357, 1, 0, 0, 0, 359, 362, 1, 0, 0, 0, 360, 358, 1, 0,
-		0, 0, 360, 361, 1, 0, 0, 0, 361, 363, 1, 0, 0, 0, 362, 360, 1, 0, 0, 0,
-		363, 364, 5, 26, 0, 0, 364, 365, 5, 64, 0, 0, 365, 369, 5, 4, 0, 0, 366,
-		368, 5, 68, 0, 0, 367, 366, 1, 0, 0, 0, 368, 371, 1, 0, 0, 0, 369, 367,
-		1, 0, 0, 0, 369, 370, 1, 0, 0, 0, 370, 372, 1, 0, 0, 0, 371, 369, 1, 0,
-		0, 0, 372, 376, 3, 32, 16, 0, 373, 375, 5, 68, 0, 0, 374, 373, 1, 0, 0,
-		0, 375, 378, 1, 0, 0, 0, 376, 374, 1, 0, 0, 0, 376, 377, 1, 0, 0, 0, 377,
-		379, 1, 0, 0, 0, 378, 376, 1, 0, 0, 0, 379, 381, 5, 5, 0, 0, 380, 382,
-		5, 3, 0, 0, 381, 380, 1, 0, 0, 0, 381, 382, 1, 0, 0, 0, 382, 386, 1, 0,
-		0, 0, 383, 385, 5, 68, 0, 0, 384, 383, 1, 0, 0, 0, 385, 388, 1, 0, 0, 0,
-		386, 384, 1, 0, 0, 0, 386, 387, 1, 0, 0, 0, 387, 389, 1, 0, 0, 0, 388,
-		386, 1, 0, 0, 0, 389, 390, 5, 2, 0, 0, 390, 391, 5, 63, 0, 0, 391, 31,
-		1, 0, 0, 0, 392, 403, 3, 34, 17, 0, 393, 397, 5, 3, 0, 0, 394, 396, 5,
-		68, 0, 0, 395, 394, 1, 0, 0, 0, 396, 399, 1, 0, 0, 0, 397, 395, 1, 0, 0,
-		0, 397, 398, 1, 0, 0, 0, 398, 400, 1, 0, 0, 0, 399, 397, 1, 0, 0, 0, 400,
-		402, 3, 34, 17, 0, 401, 393, 1, 0, 0, 0, 402, 405, 1, 0, 0, 0, 403, 401,
-		1, 0, 0, 0, 403, 404, 1, 0, 0, 0, 404, 33, 1, 0, 0, 0, 405, 403, 1, 0,
-		0, 0, 406, 407, 5, 67, 0, 0, 407, 35, 1, 0, 0, 0, 408, 418, 3, 38, 19,
-		0, 409, 418, 3, 40, 20, 0, 410, 418, 3, 42, 21, 0, 411, 418, 3, 44, 22,
-		0, 412, 418, 3, 46, 23, 0, 413, 418, 3, 48, 24, 0, 414, 418, 3, 50, 25,
-		0, 415, 418, 3, 52, 26, 0, 416, 418, 3, 54, 27, 0, 417, 408, 1, 0, 0, 0,
-		417, 409, 1, 0, 0, 0, 417, 410, 1, 0, 0, 0, 417, 411, 1, 0, 0, 0, 417,
-		412, 1, 0, 0, 0, 417, 413, 1, 0, 0, 0, 417, 414, 1, 0, 0, 0, 417, 415,
-		1, 0, 0, 0, 417, 416, 1, 0, 0, 0, 418, 37, 1, 0, 0, 0, 419, 420, 5, 28,
-		0, 0, 420, 421, 3, 62, 31, 0, 421, 422, 5, 64, 0, 0, 422, 423, 3, 56, 28,
-		0, 423, 424, 5, 63, 0, 0, 424, 39, 1, 0, 0, 0, 425, 426, 5, 29, 0, 0, 426,
-		427, 3, 62, 31, 0, 427, 428, 5, 64, 0, 0, 428, 429, 3, 58, 29, 0, 429,
-		430, 5, 63, 0, 0, 430, 41, 1, 0, 0, 0, 431, 432, 5, 30, 0, 0, 432, 433,
-		3, 62, 31, 0, 433, 434, 5, 64, 0, 0, 434, 435, 3, 56, 28, 0, 435, 436,
-		5, 63, 0, 0, 436, 43, 1, 0, 0, 0, 437, 438, 5, 31, 0, 0, 438, 439, 3, 62,
-		31, 0, 439, 440, 5, 64, 0, 0, 440, 441, 3, 58, 29, 0, 441, 442, 5, 63,
-		0, 0, 442, 45, 1, 0, 0, 0, 443, 444, 5, 32, 0, 0, 444, 445, 3, 62, 31,
-		0, 445, 446, 5, 64, 0, 0, 446, 447, 3, 60, 30, 0, 447, 448, 5, 63, 0, 0,
-		448, 47, 1, 0, 0, 0, 449, 450, 5, 33, 0, 0, 450, 451, 3, 62, 31, 0, 451,
-		452, 5, 64, 0, 0, 452, 453, 5, 67, 0, 0, 453, 454, 5, 63, 0, 0, 454, 49,
-		1, 0, 0, 0, 455, 456, 5, 34, 0, 0, 456, 457, 3, 62, 31, 0, 457, 458, 5,
-		64, 0, 0, 458, 459, 5, 67, 0, 0, 459, 460, 5, 63, 0, 0, 460, 51, 1, 0,
-		0, 0, 461, 462, 5, 35, 0, 0, 462, 463, 3, 62, 31, 0, 463, 464, 5, 64, 0,
-		0, 464, 465, 5, 67, 0, 0, 465, 466, 5, 63, 0, 0, 466, 53, 1, 0, 0, 0, 467,
-		468, 5, 36, 0, 0, 468, 469, 3, 62, 31, 0, 469, 470, 5, 64, 0, 0, 470, 471,
-		5, 67, 0, 0, 471, 472, 5, 63, 0, 0, 472, 55, 1, 0, 0, 0, 473, 474, 5, 57,
-		0, 0, 474, 479, 3, 56, 28, 0, 475, 476, 5, 58, 0, 0, 476, 479, 3, 56, 28,
-		0, 477, 479, 5, 66, 0, 0, 478, 473, 1, 0, 0, 0, 478, 475, 1, 0, 0, 0, 478,
-		477, 1, 0, 0, 0, 479, 57, 1, 0, 0, 0, 480, 481, 5, 57, 0, 0, 481, 484,
-		3, 58, 29, 0, 482, 484, 5, 66, 0, 0, 483, 480, 1, 0, 0, 0, 483, 482, 1,
-		0, 0, 0, 484, 59, 1, 0, 0, 0, 485, 486, 5, 57, 0, 0, 486, 495, 3, 60, 30,
-		0, 487, 488, 5, 58, 0, 0, 488, 495, 3, 60, 30, 0, 489, 492, 5, 66, 0, 0,
-		490, 491, 5, 7, 0, 0, 491, 493, 5, 66, 0, 0, 492, 490, 1, 0, 0, 0, 492,
-		493, 1, 0, 0, 0, 493, 495, 1, 0, 0, 0, 494, 485, 1, 0, 0, 0, 494, 487,
-		1, 0, 0, 0, 494, 489, 1, 0, 0, 0, 495, 61, 1, 0, 0, 0, 496, 497, 5, 65,
-		0, 0, 497, 498, 5, 8, 0, 0, 498, 504, 5, 65, 0, 0, 499, 500, 5, 65, 0,
-		0, 500, 501, 5, 8, 0, 0, 501, 504, 5, 67, 0, 0, 502, 504, 5, 65, 0, 0,
-		503, 496, 1, 0, 0, 0, 503, 499, 1, 0, 0, 0, 503, 502, 1, 0, 0, 0, 504,
-		63, 1, 0, 0, 0, 505, 508, 3, 66, 33, 0, 506, 508, 3, 68, 34, 0, 507, 505,
-		1, 0, 0, 0, 507, 506, 1, 0, 0, 0, 508, 65, 1, 0, 0, 0, 509, 510, 5, 37,
-		0, 0, 510, 511, 3, 62, 31, 0, 511, 512, 5, 64, 0, 0, 512, 513, 3, 70, 35,
-		0, 513, 514, 5, 63, 0, 0, 514, 67, 1, 0, 0, 0, 515, 516, 5, 38, 0, 0, 516,
-		517, 3, 62, 31, 0, 517, 518, 5, 64, 0, 0, 518, 519, 5, 67, 0, 0, 519, 520,
-		5, 63, 0, 0, 520, 69, 1, 0, 0, 0, 521, 525, 3, 98, 49, 0, 522, 525, 5,
-		39, 0, 0, 523, 525, 5, 67, 0, 0, 524, 521, 1, 0, 0, 0, 524, 522, 1, 0,
-		0, 0, 524, 523, 1, 0, 0, 0, 525, 71, 1, 0, 0, 0, 526, 527, 5, 40, 0, 0,
-		527, 528, 3, 62, 31, 0, 528, 532, 5, 1, 0, 0, 529, 531, 5, 68, 0, 0, 530,
-		529, 1, 0, 0, 0, 531, 534, 1, 0, 0, 0, 532, 530, 1, 0, 0, 0, 532, 533,
-		1, 0, 0, 0, 533, 535, 1, 0, 0, 0, 534, 532, 1, 0, 0, 0, 535, 539, 3, 74,
-		37, 0, 536, 538, 5, 68, 0, 0, 537, 536, 1, 0, 0, 0, 538, 541, 1, 0, 0,
-		0, 539, 537, 1, 0, 0, 0, 539, 540, 1, 0, 0, 0, 540, 542, 1, 0, 0, 0, 541,
-		539, 1, 0, 0, 0, 542, 543, 5, 43, 0, 0, 543, 544, 5, 64, 0, 0, 544, 545,
-		3, 76, 38, 0, 545, 549, 5, 3, 0, 0, 546, 548, 5, 68, 0, 0, 547, 546, 1,
-		0, 0, 0, 548, 551, 1, 0, 0, 0, 549, 547, 1, 0, 0, 0, 549, 550, 1, 0, 0,
-		0, 550, 552, 1, 0, 0, 0, 551, 549, 1, 0, 0, 0, 552, 553, 5, 44, 0, 0, 553,
-		554, 5, 64, 0, 0, 554, 558, 5, 4, 0, 0, 555, 557, 5, 68, 0, 0, 556, 555,
-		1, 0, 0, 0, 557, 560, 1, 0, 0, 0, 558, 556, 1, 0, 0, 0, 558, 559, 1, 0,
-		0, 0, 559, 561, 1, 0, 0, 0, 560, 558, 1, 0, 0, 0, 561, 565, 3, 80, 40,
-		0, 562, 564, 5, 68, 0, 0, 563, 562, 1, 0, 0, 0, 564, 567, 1, 0, 0, 0, 565,
-		563, 1, 0, 0, 0, 565, 566, 1, 0, 0, 0, 566, 568, 1, 0, 0, 0, 567, 565,
-		1, 0, 0, 0, 568, 570, 5, 5, 0, 0, 569, 571, 5, 3, 0, 0, 570, 569, 1, 0,
-		0, 0, 570, 571, 1, 0, 0, 0, 571, 575, 1, 0, 0, 0, 572, 574, 5, 68, 0, 0,
-		573, 572, 1, 0, 0, 0, 574, 577, 1, 0, 0, 0, 575, 573, 1, 0, 0, 0, 575,
-		576, 1, 0, 0, 0, 576, 578, 1, 0, 0, 0, 577, 575, 1, 0, 0, 0, 578, 579,
-		5, 2, 0, 0, 579, 580, 5, 63, 0, 0, 580, 73, 1, 0, 0, 0, 581, 582, 5, 41,
-		0, 0, 582, 583, 5, 64, 0, 0, 583, 584, 3, 62, 31, 0, 584, 585, 5, 3, 0,
-		0, 585, 591, 1, 0, 0, 0, 586, 587, 5, 42, 0, 0, 587, 588, 5, 64, 0, 0,
-		588, 589, 5, 67, 0, 0, 589, 591, 5, 3, 0, 0, 590, 581, 1, 0, 0, 0, 590,
-		586, 1, 0, 0, 0, 591, 75, 1, 0, 0, 0, 592, 594, 5, 4, 0, 0, 593, 595, 3,
-		78, 39, 0, 594, 593, 1, 0, 0, 0, 594, 595, 1, 0, 0, 0, 595, 596, 1, 0,
-		0, 0, 596, 597, 5, 5, 0, 0, 597, 77, 1, 0, 0, 0, 598, 603, 5, 67, 0, 0,
-		599, 600, 5, 3, 0, 0, 600, 602, 5, 67, 0, 0, 601, 599, 1, 0, 0, 0, 602,
-		605, 1, 0, 0, 0, 603, 601, 1, 0, 0, 0, 603, 604, 1, 0, 0, 0, 604, 79, 1,
-		0, 0, 0, 605, 603, 1, 0, 0, 0, 606, 617, 3, 82, 41, 0, 607, 611, 5, 3,
-		0, 0, 608, 610, 5, 68, 0, 0, 609, 608, 1, 0, 0, 0, 610, 613, 1, 0, 0, 0,
-		611, 609, 1, 0, 0, 0, 611, 612, 1, 0, 0, 0, 612, 614, 1, 0, 0, 0, 613,
-		611, 1, 0, 0, 0, 614, 616, 3, 82, 41, 0, 615, 607, 1, 0, 0, 0, 616, 619,
-		1, 0, 0, 0, 617, 615, 1, 0, 0, 0, 617, 618, 1, 0, 0, 0, 618, 81, 1, 0,
-		0, 0, 619, 617, 1, 0, 0, 0, 620, 621, 5, 67, 0, 0, 621, 623, 5, 6, 0, 0,
-		622, 624, 5, 18, 0, 0, 623, 622, 1, 0, 0, 0, 623, 624, 1, 0, 0, 0, 624,
-		625, 1, 0, 0, 0, 625, 626, 3, 22, 11, 0, 626, 83, 1, 0, 0, 0, 627, 628,
-		5, 4, 0, 0, 628, 632, 5, 65, 0, 0, 629, 631, 3, 86, 43, 0, 630, 629, 1,
-		0, 0, 0, 631, 634, 1, 0, 0, 0, 632, 630, 1, 0, 0, 0, 632, 633, 1, 0, 0,
-		0, 633, 635, 1, 0, 0, 0, 634, 632, 1, 0, 0, 0, 635, 636, 5, 5, 0, 0, 636,
-		640, 5, 8, 0, 0, 637, 639, 5, 68, 0, 0, 638, 637, 1, 0, 0, 0, 639, 642,
-		1, 0, 0, 0, 640, 638, 1, 0, 0, 0, 640, 641, 1, 0, 0, 0, 641, 650, 1, 0,
-		0, 0, 642, 640, 1, 0, 0, 0, 643, 647, 3, 90, 45, 0, 644, 646, 5, 68, 0,
-		0, 645, 644, 1, 0, 0, 0, 646, 649, 1, 0, 0, 0, 647, 645, 1, 0, 0, 0, 647,
-		648, 1, 0, 0, 0, 648, 651, 1, 0, 0, 0, 649, 647, 1, 0, 0, 0, 650, 643,
-		1, 0, 0, 0, 651, 652, 1, 0, 0, 0, 652, 650, 1, 0, 0, 0, 652, 653, 1, 0,
-		0, 0, 653, 654, 1, 0, 0, 0, 654, 658, 5, 9, 0, 0, 655, 657, 5, 68, 0, 0,
-		656, 655, 1, 0, 0, 0, 657, 660, 1, 0, 0, 0, 658, 656, 1, 0, 0, 0, 658,
-		659, 1, 0, 0, 0, 659, 668, 1, 0, 0, 0, 660, 658, 1, 0, 0, 0, 661, 665,
-		3, 92, 46, 0, 662, 664, 5, 68, 0, 0, 663, 662, 1, 0, 0, 0, 664, 667, 1,
-		0, 0, 0, 665, 663, 1, 0, 0, 0, 665, 666, 1, 0, 0, 0, 666, 669, 1, 0, 0,
-		0, 667, 665, 1, 0, 0, 0, 668, 661, 1, 0, 0, 0, 669, 670, 1, 0, 0, 0, 670,
-		668, 1, 0, 0, 0, 670, 671, 1, 0, 0, 0, 671, 672, 1, 0, 0, 0, 672, 673,
-		5, 63, 0, 0, 673, 85, 1, 0, 0, 0, 674, 675, 5, 3, 0, 0, 675, 676, 5, 65,
-		0, 0, 676, 677, 5, 64, 0, 0, 677, 678, 3, 88, 44, 0, 678, 87, 1, 0, 0,
-		0, 679, 684, 5, 67, 0, 0, 680, 684, 5, 45, 0, 0, 681, 684, 5, 46, 0, 0,
-		682, 684, 3, 56, 28, 0, 683, 679, 1, 0, 0, 0, 683, 680, 1, 0, 0, 0, 683,
-		681, 1, 0, 0, 0, 683, 682, 1, 0, 0, 0, 684, 89, 1, 0, 0, 0, 685, 687, 5,
-		48, 0, 0, 686, 685, 1, 0, 0, 0, 686, 687, 1, 0, 0, 0, 687, 688, 1, 0, 0,
-		0, 688, 689, 5, 10, 0, 0, 689, 690, 3, 94, 47, 0, 690, 691, 3, 94, 47,
-		0, 691, 692, 3, 96, 48, 0, 692, 694, 5, 11, 0, 0, 693, 695, 5, 7, 0, 0,
-		694, 693, 1, 0, 0, 0, 694, 695, 1, 0, 0, 0, 695, 702, 1, 0, 0, 0, 696,
-		697, 5, 4, 0, 0, 697, 698, 3, 100, 50, 0, 698, 700, 5, 5, 0, 0, 699, 701,
-		5, 7, 0, 0, 700, 699, 1, 0, 0, 0, 700, 701, 1, 0, 0, 0, 701, 703, 1, 0,
-		0, 0, 702, 696, 1, 0, 0, 0, 702, 703, 1, 0, 0, 0, 703, 91, 1, 0, 0, 0,
-		704, 705, 5, 10, 0, 0, 705, 706, 3, 94, 47, 0, 706, 707, 3, 94, 47, 0,
-		707, 708, 3, 100, 50, 0, 708, 710, 5, 11, 0, 0, 709, 711, 5, 7, 0, 0, 710,
-		709, 1, 0, 0, 0, 710, 711, 1, 0, 0, 0, 711, 93, 1, 0, 0, 0, 712, 713, 5,
-		12, 0, 0, 713, 716, 5, 65, 0, 0, 714, 716, 3, 62, 31, 0, 715, 712, 1, 0,
-		0, 0, 715, 714, 1, 0, 0, 0, 716, 95, 1, 0, 0, 0, 717, 763, 3, 94, 47, 0,
-		718, 719, 5, 28, 0, 0, 719, 720, 5, 10, 0, 0, 720, 721, 3, 56, 28, 0, 721,
-		722, 5, 11, 0, 0, 722, 763, 1, 0, 0, 0, 723, 724, 5, 29, 0, 0, 724, 725,
-		5, 10, 0, 0, 725, 726, 3, 58, 29, 0, 726, 727, 5, 11, 0, 0, 727, 763, 1,
-		0, 0, 0, 728, 729, 5, 30, 0, 0, 729, 730, 5, 10, 0, 0, 730, 731, 3, 56,
-		28, 0, 731, 732, 5, 11, 0, 0, 732, 763, 1, 0, 0, 0, 733, 734, 5, 31, 0,
-		0, 734, 735, 5, 10, 0, 0, 735, 736, 3, 58, 29, 0, 736, 737, 5, 11, 0, 0,
-		737, 763, 1, 0, 0, 0, 738, 739, 5, 32, 0, 0, 739, 740, 5, 10, 0, 0, 740,
-		741, 3, 60, 30, 0, 741, 742, 5, 11, 0, 0, 742, 763, 1, 0, 0, 0, 743, 744,
-		5, 33, 0, 0, 744, 745, 5, 10, 0, 0, 745, 746, 5, 67, 0, 0, 746, 763, 5,
-		11, 0, 0, 747, 748, 5, 34, 0, 0, 748, 749, 5, 10, 0, 0, 749, 750, 5, 67,
-		0, 0, 750, 763, 5, 11, 0, 0, 751, 752, 5, 35, 0, 0, 752, 753, 5, 10, 0,
-		0, 753, 754, 5, 67, 0, 0, 754, 763, 5, 11, 0, 0, 755, 756, 5, 36, 0, 0,
-		756, 757, 5, 10, 0, 0, 757, 758, 5, 67, 0, 0, 758, 763, 5, 11, 0, 0, 759,
-		763, 5, 67, 0, 0, 760, 763, 3, 98, 49, 0, 761, 763, 3, 60, 30, 0, 762,
-		717, 1, 0, 0, 0, 762, 718, 1, 0, 0, 0, 762, 723, 1, 0, 0, 0, 762, 728,
-		1, 0, 0, 0, 762, 733, 1, 0, 0, 0, 762, 738, 1, 0, 0, 0, 762, 743, 1, 0,
-		0, 0, 762, 747, 1, 0, 0, 0, 762, 751, 1, 0, 0, 0, 762, 755, 1, 0, 0, 0,
-		762, 759, 1, 0, 0, 0, 762, 760, 1, 0, 0, 0, 762, 761, 1, 0, 0, 0, 763,
-		97, 1, 0, 0, 0, 764, 765, 7, 3, 0, 0, 765, 99, 1, 0, 0, 0, 766, 767, 6,
-		50, -1, 0, 767, 768, 5, 10, 0, 0, 768, 769, 3, 100, 50, 0, 769, 770, 3,
-		102, 51, 0, 770, 771, 3, 100, 50, 0, 771, 772, 5, 11, 0, 0, 772, 792, 1,
-		0, 0, 0, 773, 774, 3, 104, 52, 0, 774, 775, 5, 10, 0, 0, 775, 776, 3, 100,
-		50, 0, 776, 777, 5, 11, 0, 0, 777, 792, 1, 0, 0, 0, 778, 779, 5, 10, 0,
-		0, 779, 780, 3, 104, 52, 0, 780, 781, 3, 100, 50, 0, 781, 782, 5, 11, 0,
-		0, 782, 792, 1, 0, 0, 0, 783, 784, 5, 10, 0, 0, 784, 785, 3, 100, 50, 0,
-		785, 786, 5, 11, 0, 0, 786, 792, 1, 0, 0, 0, 787, 788, 3, 104, 52, 0, 788,
-		789, 3, 100, 50, 2, 789, 792, 1, 0, 0, 0, 790, 792, 3, 96, 48, 0, 791,
-		766, 1, 0, 0, 0, 791, 773, 1, 0, 0, 0, 791, 778, 1, 0, 0, 0, 791, 783,
-		1, 0, 0, 0, 791, 787, 1, 0, 0, 0, 791, 790, 1, 0, 0, 0, 792, 799, 1, 0,
-		0, 0, 793, 794, 10, 7, 0, 0, 794, 795, 3, 102, 51, 0, 795, 796, 3, 100,
-		50, 8, 796, 798, 1, 0, 0, 0, 797, 793, 1, 0, 0, 0, 798, 801, 1, 0, 0, 0,
-		799, 797, 1, 0, 0, 0, 799, 800, 1, 0, 0, 0, 800, 101, 1, 0, 0, 0, 801,
-		799, 1, 0, 0, 0, 802, 803, 7, 4, 0, 0, 803, 103, 1, 0, 0, 0, 804, 805,
-		7, 5, 0, 0, 805, 105, 1, 0, 0, 0, 806, 807, 5, 27, 0, 0, 807, 808, 5, 10,
-		0, 0, 808, 809, 3, 94, 47, 0, 809, 810, 5, 3, 0, 0, 810, 811, 3, 94, 47,
-		0, 811, 812, 5, 3, 0, 0, 812, 813, 3, 96, 48, 0, 813, 814, 5, 11, 0, 0,
-		814, 815, 5, 63, 0, 0, 815, 107, 1, 0, 0, 0, 74, 111, 126, 139, 146, 159,
-		165, 180, 188, 194, 200, 205, 213, 221, 227, 233, 245, 253, 259, 265, 276,
-		284, 290, 296, 307, 315, 321, 327, 333, 340, 360, 369, 376, 381, 386, 397,
-		403, 417, 478, 483, 492, 494, 503, 507, 524, 532, 539, 549, 558, 565, 570,
-		575, 590, 594, 603, 611, 617, 623, 632, 640, 647, 652, 658, 665, 670, 683,
-		686, 694, 700, 702, 710, 715, 762, 791, 799,
+		52, 2, 53, 7, 53, 2, 54, 7, 54, 2, 55, 7, 55, 2, 56, 7, 56, 1, 0, 5, 0,
+		116, 8, 0, 10, 0, 12, 0, 119, 9, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 133, 8, 1, 1, 2, 1, 2, 1, 2,
+		1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 5, 3, 144, 8, 3, 10, 3, 12, 3, 147,
+		9, 3, 1, 3, 1, 3, 5, 3, 151, 8, 3, 10, 3, 12, 3, 154, 9, 3, 1, 3, 1, 3,
+		1, 3, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 5, 5, 164, 8, 5, 10, 5, 12, 5, 167,
+		9, 5, 1, 5, 5, 5, 170, 8, 5, 10, 5, 12, 5, 173, 9, 5, 1, 6, 1, 6, 1, 6,
+		1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 5, 6, 185, 8, 6, 10, 6, 12, 6,
+		188, 9, 6, 1, 6, 1, 6, 1, 6, 5, 6, 193, 8, 6, 10, 6, 12, 6, 196, 9, 6,
+		1, 6, 5, 6, 199, 8, 6, 10, 6, 12, 6, 202, 9, 6, 1, 6, 5, 6, 205, 8, 6,
+		10, 6, 12, 6, 208, 9, 6, 1, 6, 1, 6, 3, 6, 212, 8, 6, 1, 7, 1, 7, 1, 7,
+		1, 7, 5, 7, 218, 8, 7, 10, 7, 12, 7, 221, 9, 7, 1, 7, 1, 7, 1, 7, 5, 7,
+		226, 8, 7, 10, 7, 12, 7, 229, 9, 7, 1, 7, 5, 7, 232, 8, 7, 10, 7, 12, 7,
+		235, 9, 7, 1, 7, 5, 7, 238, 8, 7, 10, 7, 12, 7, 241, 9, 7, 1, 7, 1, 7,
+		1, 7, 1, 8, 1, 8, 1, 8, 1, 8, 5, 8, 250, 8, 8, 10, 8, 12, 8, 253, 9, 8,
+		1, 8, 1, 8, 1, 8, 5, 8, 258, 8, 8, 10, 8, 12, 8, 261, 9, 8, 1, 8, 5, 8,
+		264, 8, 8, 10, 8, 12, 8, 267, 9, 8, 1, 8, 5, 8, 270, 8, 8, 10, 8, 12, 8,
+		273, 9, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 5, 8, 281, 8, 8, 10, 8,
+		12, 8, 284, 9, 8, 1, 8, 1, 8, 1, 8, 5, 8, 289, 8, 8, 10, 8, 12, 8, 292,
+		9, 8, 1, 8, 5, 8, 295, 8, 8, 10, 8, 12, 8, 298, 9, 8, 1, 8, 5, 8, 301,
+		8, 8, 10, 8, 12, 8, 304, 9, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 5, 8,
+		312, 8, 8, 10, 8, 12, 8, 315, 9, 8, 1, 8, 1, 8, 1, 8, 5, 8, 320, 8, 8,
+		10, 8, 12, 8, 323, 9, 8, 1, 8, 5, 8, 326, 8, 8, 10, 8, 12, 8, 329, 9, 8,
+		1, 8, 5, 8, 332, 8, 8, 10, 8, 12, 8, 335, 9, 8, 1, 8, 1, 8, 1, 8, 3, 8,
+		340, 8, 8, 1, 9, 1, 9, 1, 10, 1, 10, 1, 10, 3, 10, 347, 8, 10, 1, 10, 1,
+		10, 1, 11, 1, 11, 1, 12, 1, 12, 1, 13, 1, 13, 1, 13, 1, 13, 1, 14, 1, 14,
+		1, 15, 1, 15, 1, 15, 1, 15, 5, 15, 365, 8, 15, 10, 15, 12, 15, 368, 9,
+		15, 1, 15, 1, 15, 1, 15, 1, 15, 5, 15, 374, 8, 15, 10, 15, 12, 15, 377,
+		9, 15, 1, 15, 1, 15, 5, 15, 381, 8, 15, 10, 15, 12, 15, 384, 9, 15, 1,
+		15, 1, 15, 3, 15, 388, 8, 15, 1, 15, 5, 15, 391, 8, 15, 10, 15, 12, 15,
+		394, 9, 15, 1, 15, 1, 15, 1, 15, 1, 16, 1, 16, 1, 16, 5, 16, 402, 8, 16,
+		10, 16, 12, 16, 405, 9, 16, 1, 16, 5, 16, 408, 8, 16, 10, 16, 12, 16, 411,
+		9, 16, 1, 17, 1, 17, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1,
+		18, 1, 18, 3, 18, 424, 8, 18, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19,
+		1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 21, 1, 21, 1, 21, 1, 21, 1,
+		21, 1, 21, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 23, 1, 23, 1, 23,
+		1, 23, 1, 23, 1, 23, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 25, 1,
+		25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26,
+		1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 28, 1, 28, 1, 28, 1, 28, 1,
+		28, 3, 28, 485, 8, 28, 1, 29, 1, 29, 1, 29, 3, 29, 490, 8, 29, 1, 30, 1,
+		30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 3, 30, 499, 8, 30, 3, 30, 501, 8,
+		30, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 3, 31, 510, 8, 31,
+		1, 32, 1, 32, 3, 32, 514, 8, 32, 1, 33, 1, 33, 1, 33, 1, 33, 1, 33, 1,
+		33, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 35, 1, 35, 1, 35, 3, 35,
+		531, 8, 35, 1, 36, 1, 36, 1, 36, 1, 36, 5, 36, 537, 8, 36, 10, 36, 12,
+		36, 540, 9, 36, 1, 36, 1, 36, 5, 36, 544, 8, 36, 10, 36, 12, 36, 547, 9,
+		36, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 5, 36, 554, 8, 36, 10, 36, 12, 36,
+		557, 9, 36, 1, 36, 1, 36, 1, 36, 1, 36, 5, 36, 563, 8, 36, 10, 36, 12,
+		36, 566, 9, 36, 1, 36, 1, 36, 5, 36, 570, 8, 36, 10, 36, 12, 36, 573, 9,
+		36, 1, 36, 1, 36, 3, 36, 577, 8, 36, 1, 36, 5, 36, 580, 8, 36, 10, 36,
+		12, 36, 583, 9, 36, 1, 36, 1, 36, 1, 36, 1, 37, 1, 37, 1, 37, 1, 37, 1,
+		37, 1, 37, 1, 37, 1, 37, 1, 37, 3, 37, 597, 8, 37, 1, 38, 1, 38, 3, 38,
+		601, 8, 38, 1, 38, 1, 38, 1, 39, 1, 39, 1, 39, 5, 39, 608, 8, 39, 10, 39,
+		12, 39, 611, 9, 39, 1, 40, 1, 40, 1, 40, 5, 40, 616, 8, 40, 10, 40, 12,
+		40, 619, 9, 40, 1, 40, 5, 40, 622, 8, 40, 10, 40, 12, 40, 625, 9, 40, 1,
+		41, 1, 41, 1, 41, 3, 41, 630, 8, 41, 1, 41, 1, 41, 1, 42, 1, 42, 1, 42,
+		5, 42, 637, 8, 42, 10, 42, 12, 42, 640, 9, 42, 1, 42, 1, 42, 1, 42, 5,
+		42, 645, 8, 42, 10, 42, 12, 42, 648, 9, 42, 1, 42, 1, 42, 3, 42, 652, 8,
+		42, 1, 42, 5, 42, 655, 8, 42, 10, 42, 12, 42, 658, 9, 42, 4, 42, 660, 8,
+		42, 11, 42, 12, 42, 661, 1, 42, 1, 42, 5, 42, 666, 8, 42, 10, 42, 12, 42,
+		669, 9, 42, 1, 42, 1, 42, 5, 42, 673, 8, 42, 10, 42, 12, 42, 676, 9, 42,
+		4, 42, 678, 8, 42, 11, 42, 12, 42, 679, 1, 42, 1, 42, 1, 43, 1, 43, 1,
+		43, 1, 43, 1, 43, 1, 44, 1, 44, 1, 44, 1, 44, 3, 44, 693, 8, 44, 1, 45,
+		3, 45, 696, 8, 45, 1, 45, 1, 45, 1, 45, 1, 45, 1, 45, 1, 45, 1, 45, 1,
+		45, 1, 45, 3, 45, 707, 8, 45, 1, 45, 1, 45, 3, 45, 711, 8, 45, 1, 45, 1,
+		45, 1, 45, 1, 45, 3, 45, 717, 8, 45, 3, 45, 719, 8, 45, 1, 46, 1, 46, 1,
+		46, 4, 46, 724, 8, 46, 11, 46, 12, 46, 725, 1, 46, 3, 46, 729, 8, 46, 1,
+		47, 1, 47, 5, 47, 733, 8, 47, 10, 47, 12, 47, 736, 9, 47, 1, 47, 1, 47,
+		5, 47, 740, 8, 47, 10, 47, 12, 47, 743, 9, 47, 4, 47, 745, 8, 47, 11, 47,
+		12, 47, 746, 1, 47, 1, 47, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 3, 48, 756,
+		8, 48, 1, 48, 1, 48, 3, 48, 760, 8, 48, 1, 49, 1, 49, 1, 49, 1, 49, 1,
+		49, 1, 49, 1, 49, 1, 49, 1, 49, 1, 49, 1, 49, 1, 50, 1, 50, 1, 50, 3, 50,
+		776, 8, 50, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1,
+		51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51,
+		1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1,
+		51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51,
+		1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 3, 51, 823, 8, 51, 1, 52, 1, 52, 1,
+		53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53,
+		1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1, 53, 1,
+		53, 1, 53, 1, 53, 1, 53, 3, 53, 852, 8, 53, 1, 53, 1, 53, 1, 53, 1, 53,
+		5, 53, 858, 8, 53, 10, 53, 12, 53, 861, 9, 53, 1, 54, 1, 54, 1, 55, 1,
+		55, 1, 56, 1, 56, 1, 56, 1, 56, 1, 56, 1, 56, 1, 56, 1, 56, 1, 56, 1, 56,
+		1, 56, 0, 1, 106, 57, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26,
+		28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 58, 60, 62,
+		64, 66, 68, 70, 72, 74, 76, 78, 80, 82, 84, 86, 88, 90, 92, 94, 96, 98,
+		100, 102, 104, 106, 108, 110, 112, 0, 6, 1, 0, 20, 21, 1, 0, 28, 37, 1,
+		0, 45, 46, 1, 0, 45, 47, 2, 0, 50, 62, 65, 65, 2, 0, 48, 49, 65, 65, 940,
+		0, 117, 1, 0, 0, 0, 2, 132, 1, 0, 0, 0, 4, 134, 1, 0, 0, 0, 6, 140, 1,
+		0, 0, 0, 8, 158, 1, 0, 0, 0, 10, 160, 1, 0, 0, 0, 12, 211, 1, 0, 0, 0,
+		14, 213, 1, 0, 0, 0, 16, 339, 1, 0, 0, 0, 18, 341, 1, 0, 0, 0, 20, 343,
+		1, 0, 0, 0, 22, 350, 1, 0, 0, 0, 24, 352, 1, 0, 0, 0, 26, 354, 1, 0, 0,
+		0, 28, 358, 1, 0, 0, 0, 30, 360, 1, 0, 0, 0, 32, 398, 1, 0, 0, 0, 34, 412,
+		1, 0, 0, 0, 36, 423, 1, 0, 0, 0, 38, 425, 1, 0, 0, 0, 40, 431, 1, 0, 0,
+		0, 42, 437, 1, 0, 0, 0, 44, 443, 1, 0, 0, 0, 46, 449, 1, 0, 0, 0, 48, 455,
+		1, 0, 0, 0, 50, 461, 1, 0, 0, 0, 52, 467, 1, 0, 0, 0, 54, 473, 1, 0, 0,
+		0, 56, 484, 1, 0, 0, 0, 58, 489, 1, 0, 0, 0, 60, 500, 1, 0, 0, 0, 62, 509,
+		1, 0, 0, 0, 64, 513, 1, 0, 0, 0, 66, 515, 1, 0, 0, 0, 68, 521, 1, 0, 0,
+		0, 70, 530, 1, 0, 0, 0, 72, 532, 1, 0, 0, 0, 74, 596, 1, 0, 0, 0, 76, 598,
+		1, 0, 0, 0, 78, 604, 1, 0, 0, 0, 80, 612, 1, 0, 0, 0, 82, 626, 1, 0, 0,
+		0, 84, 633, 1, 0, 0, 0, 86, 683, 1, 0, 0, 0, 88, 692, 1, 0, 0, 0, 90, 695,
+		1, 0, 0, 0, 92, 720, 1, 0, 0, 0, 94, 730, 1, 0, 0, 0, 96, 750, 1, 0, 0,
+		0, 98, 761, 1, 0, 0, 0, 100, 775, 1, 0, 0, 0, 102, 822, 1, 0, 0, 0, 104,
+		824, 1, 0, 0, 0, 106, 851, 1, 0, 0, 0, 108, 862, 1, 0, 0, 0, 110, 864,
+		1, 0, 0, 0, 112, 866, 1, 0, 0, 0, 114, 116, 3, 2, 1, 0, 115, 114, 1, 0,
+		0, 0, 116, 119, 1, 0, 0, 0, 117, 115, 1, 0, 0, 0, 117, 118, 1, 0, 0, 0,
+		118, 120, 1, 0, 0, 0, 119, 117, 1, 0, 0, 0, 120, 121, 5, 0, 0, 1, 121,
+		1, 1, 0, 0, 0, 122, 133, 3, 4, 2, 0, 123, 133, 3, 6, 3, 0, 124, 133, 3,
+		36, 18, 0, 125, 133, 3, 14, 7, 0, 126, 133, 3, 30, 15, 0, 127, 133, 3,
+		64, 32, 0, 128, 133, 3, 72, 36, 0, 129, 133, 3, 84, 42, 0, 130, 133, 3,
+		112, 56, 0, 131, 133, 5, 68, 0, 0, 132, 122, 1, 0, 0, 0, 132, 123, 1, 0,
+		0, 0, 132, 124, 1, 0, 0, 0, 132, 125, 1, 0, 0, 0, 132, 126, 1, 0, 0, 0,
+		132, 127, 1, 0, 0, 0, 132, 128, 1, 0, 0, 0, 132, 129, 1, 0, 0, 0, 132,
+		130, 1, 0, 0, 0, 132, 131, 1, 0, 0, 0, 133, 3, 1, 0, 0, 0, 134, 135, 5,
+		13, 0, 0, 135, 136, 3, 62, 31, 0, 136, 137, 5, 64, 0, 0, 137, 138, 5, 67,
+		0, 0, 138, 139, 5, 63, 0, 0, 139, 5, 1, 0, 0, 0, 140, 141, 3, 8, 4, 0,
+		141, 145, 5, 1, 0, 0, 142, 144, 5, 68, 0, 0, 143, 142, 1, 0, 0, 0, 144,
+		147, 1, 0, 0, 0, 145, 143, 1, 0, 0, 0, 145, 146, 1, 0, 0, 0, 146, 148,
+		1, 0, 0, 0, 147, 145, 1, 0, 0, 0, 148, 152, 3, 10, 5, 0, 149, 151, 5, 68,
+		0, 0, 150, 149, 1, 0, 0, 0, 151, 154, 1, 0, 0, 0, 152, 150, 1, 0, 0, 0,
+		152, 153, 1, 0, 0, 0, 153, 155, 1, 0, 0, 0, 154, 152, 1, 0, 0, 0, 155,
+		156, 5, 2, 0, 0, 156, 157, 5, 63, 0, 0, 157, 7, 1, 0, 0, 0, 158, 159, 7,
+		0, 0, 0, 159, 9, 1, 0, 0, 0, 160, 171, 3, 12, 6, 0, 161, 165, 5, 3, 0,
+		0, 162, 164, 5, 68, 0, 0, 163, 162, 1, 0, 0, 0, 164, 167, 1, 0, 0, 0, 165,
+		163, 1, 0, 0, 0, 165, 166, 1, 0, 0, 0, 166, 168, 1, 0, 0, 0, 167, 165,
+		1, 0, 0, 0, 168, 170, 3, 12, 6, 0, 169, 161, 1, 0, 0, 0, 170, 173, 1, 0,
+		0, 0, 171, 169, 1, 0, 0, 0, 171, 172, 1, 0, 0, 0, 172, 11, 1, 0, 0, 0,
+		173, 171, 1, 0, 0, 0, 174, 175, 5, 22, 0, 0, 175, 176, 5, 64, 0, 0, 176,
+		212, 3, 58, 29, 0, 177, 178, 5, 23, 0, 0, 178, 179, 5, 64, 0, 0, 179, 212,
+		3, 58, 29, 0, 180, 181, 5, 24, 0, 0, 181, 182, 5, 64, 0, 0, 182, 186, 5,
+		4, 0, 0, 183, 185, 5, 68, 0, 0, 184, 183, 1, 0, 0, 0, 185, 188, 1, 0, 0,
+		0, 186, 184, 1, 0, 0, 0, 186, 187, 1, 0, 0, 0, 187, 189, 1, 0, 0, 0, 188,
+		186, 1, 0, 0, 0, 189, 200, 3, 62, 31, 0, 190, 194, 5, 3, 0, 0, 191, 193,
+		5, 68, 0, 0, 192, 191, 1, 0, 0, 0, 193, 196, 1, 0, 0, 0, 194, 192, 1, 0,
+		0, 0, 194, 195, 1, 0, 0, 0, 195, 197, 1, 0, 0, 0, 196, 194, 1, 0, 0, 0,
+		197, 199, 3, 62, 31, 0, 198, 190, 1, 0, 0, 0, 199, 202, 1, 0, 0, 0, 200,
+		198, 1, 0, 0, 0, 200, 201, 1, 0, 0, 0, 201, 206, 1, 0, 0, 0, 202, 200,
+		1, 0, 0, 0, 203, 205, 5, 68, 0, 0, 204, 203, 1, 0, 0, 0, 205, 208, 1, 0,
+		0, 0, 206, 204, 1, 0, 0, 0, 206, 207, 1, 0, 0, 0, 207, 209, 1, 0, 0, 0,
+		208, 206, 1, 0, 0, 0, 209, 210, 5, 5, 0, 0, 210, 212, 1, 0, 0, 0, 211,
+		174, 1, 0, 0, 0, 211, 177, 1, 0, 0, 0, 211, 180, 1, 0, 0, 0, 212, 13, 1,
+		0, 0, 0, 213, 214, 5, 14, 0, 0, 214, 215, 3, 62, 31, 0, 215, 219, 5, 1,
+		0, 0, 216, 218, 5, 68, 0, 0, 217, 216, 1, 0, 0, 0, 218, 221, 1, 0, 0, 0,
+		219, 217, 1, 0, 0, 0, 219, 220, 1, 0, 0, 0, 220, 222, 1, 0, 0, 0, 221,
+		219, 1, 0, 0, 0, 222, 233, 3, 16, 8, 0, 223, 227, 5, 3, 0, 0, 224, 226,
+		5, 68, 0, 0, 225, 224, 1, 0, 0, 0, 226, 229, 1, 0, 0, 0, 227, 225, 1, 0,
+		0, 0, 227, 228, 1, 0, 0, 0, 228, 230, 1, 0, 0, 0, 229, 227, 1, 0, 0, 0,
+		230, 232, 3, 16, 8, 0, 231, 223, 1, 0, 0, 0, 232, 235, 1, 0, 0, 0, 233,
+		231, 1, 0, 0, 0, 233, 234, 1, 0, 0, 0, 234, 239, 1, 0, 0, 0, 235, 233,
+		1, 0, 0, 0, 236, 238, 5, 68, 0, 0, 237, 236, 1, 0, 0, 0, 238, 241, 1, 0,
+		0, 0, 239, 237, 1, 0, 0, 0, 239, 240, 1, 0, 0, 0, 240, 242, 1, 0, 0, 0,
+		241, 239, 1, 0, 0, 0, 242, 243, 5, 2, 0, 0, 243, 244, 5, 63, 0, 0, 244,
+		15, 1, 0, 0, 0, 245, 246, 5, 15, 0, 0, 246, 247, 5, 64, 0, 0, 247, 251,
+		5, 4, 0, 0, 248, 250, 5, 68, 0, 0, 249, 248, 1, 0, 0, 0, 250, 253, 1, 0,
+		0, 0, 251, 249, 1, 0, 0, 0, 251, 252, 1, 0, 0, 0, 252, 254, 1, 0, 0, 0,
+		253, 251, 1, 0, 0, 0, 254, 265, 3, 18, 9, 0, 255, 259, 5, 3, 0, 0, 256,
+		258, 5, 68, 0, 0, 257, 256, 1, 0, 0, 0, 258, 261, 1, 0, 0, 0, 259, 257,
+		1, 0, 0, 0, 259, 260, 1, 0, 0, 0, 260, 262, 1, 0, 0, 0, 261, 259, 1, 0,
+		0, 0, 262, 264, 3, 18, 9, 0, 263, 255, 1, 0, 0, 0, 264, 267, 1, 0, 0, 0,
+		265, 263, 1, 0, 0, 0, 265, 266, 1, 0, 0, 0, 266, 271, 1, 0, 0, 0, 267,
+		265, 1, 0, 0, 0, 268, 270, 5, 68, 0, 0, 269, 268, 1, 0, 0, 0, 270, 273,
+		1, 0, 0, 0, 271, 269, 1, 0, 0, 0, 271, 272, 1, 0, 0, 0, 272, 274, 1, 0,
+		0, 0, 273, 271, 1, 0, 0, 0, 274, 275, 5, 5, 0, 0, 275, 340, 1, 0, 0, 0,
+		276, 277, 5, 17, 0, 0, 277, 278, 5, 64, 0, 0, 278, 282, 5, 4, 0, 0, 279,
+		281, 5, 68, 0, 0, 280, 279, 1, 0, 0, 0, 281, 284, 1, 0, 0, 0, 282, 280,
+		1, 0, 0, 0, 282, 283, 1, 0, 0, 0, 283, 285, 1, 0, 0, 0, 284, 282, 1, 0,
+		0, 0, 285, 296, 3, 20, 10, 0, 286, 290, 5, 3, 0, 0, 287, 289, 5, 68, 0,
+		0, 288, 287, 1, 0, 0, 0, 289, 292, 1, 0, 0, 0, 290, 288, 1, 0, 0, 0, 290,
+		291, 1, 0, 0, 0, 291, 293, 1, 0, 0, 0, 292, 290, 1, 0, 0, 0, 293, 295,
+		3, 20, 10, 0, 294, 286, 1, 0, 0, 0, 295, 298, 1, 0, 0, 0, 296, 294, 1,
+		0, 0, 0, 296, 297, 1, 0, 0, 0, 297, 302, 1, 0, 0, 0, 298, 296, 1, 0, 0,
+		0, 299, 301, 5, 68, 0, 0, 300, 299, 1, 0, 0, 0, 301, 304, 1, 0, 0, 0, 302,
+		300, 1, 0, 0, 0, 302, 303, 1, 0, 0, 0, 303, 305, 1, 0, 0, 0, 304, 302,
+		1, 0, 0, 0, 305, 306, 5, 5, 0, 0, 306, 340, 1, 0, 0, 0, 307, 308, 5, 19,
+		0, 0, 308, 309, 5, 64, 0, 0, 309, 313, 5, 4, 0, 0, 310, 312, 5, 68, 0,
+		0, 311, 310, 1, 0, 0, 0, 312, 315, 1, 0, 0, 0, 313, 311, 1, 0, 0, 0, 313,
+		314, 1, 0, 0, 0, 314, 316, 1, 0, 0, 0, 315, 313, 1, 0, 0, 0, 316, 327,
+		3, 24, 12, 0, 317, 321, 5, 3, 0, 0, 318, 320, 5, 68, 0, 0, 319, 318, 1,
+		0, 0, 0, 320, 323, 1, 0, 0, 0, 321, 319, 1, 0, 0, 0, 321, 322, 1, 0, 0,
+		0, 322, 324, 1, 0, 0, 0, 323, 321, 1, 0, 0, 0, 324, 326, 3, 24, 12, 0,
+		325, 317, 1, 0, 0, 0, 326, 329, 1, 0, 0, 0, 327, 325, 1, 0, 0, 0, 327,
+		328, 1, 0, 0, 0, 328, 333, 1, 0, 0, 0, 329, 327, 1, 0, 0, 0, 330, 332,
+		5, 68, 0, 0, 331, 330, 1, 0, 0, 0, 332, 335, 1, 0, 0, 0, 333, 331, 1, 0,
+		0, 0, 333, 334, 1, 0, 0, 0, 334, 336, 1, 0, 0, 0, 335, 333, 1, 0, 0, 0,
+		336, 337, 5, 5, 0, 0, 337, 340, 1, 0, 0, 0, 338, 340, 3, 26, 13, 0, 339,
+		245, 1, 0, 0, 0, 339, 276, 1, 0, 0, 0, 339, 307, 1, 0, 0, 0, 339, 338,
+		1, 0, 0, 0, 340, 17, 1, 0, 0, 0, 341, 342, 3, 62, 31, 0, 342, 19, 1, 0,
+		0, 0, 343, 344, 3, 62, 31, 0, 344, 346, 5, 6, 0, 0, 345, 347, 5, 18, 0,
+		0, 346, 345, 1, 0, 0, 0, 346, 347, 1, 0, 0, 0, 347, 348, 1, 0, 0, 0, 348,
+		349, 3, 22, 11, 0, 349, 21, 1, 0, 0, 0, 350, 351, 7, 1, 0, 0, 351, 23,
+		1, 0, 0, 0, 352, 353, 3, 62, 31, 0, 353, 25, 1, 0, 0, 0, 354, 355, 5, 16,
+		0, 0, 355, 356, 5, 64, 0, 0, 356, 357, 3, 28, 14, 0, 357, 27, 1, 0, 0,
+		0, 358, 359, 7, 2, 0, 0, 359, 29, 1, 0, 0, 0, 360, 361, 5, 25, 0, 0, 361,
+		362, 5, 65, 0, 0, 362, 366, 5, 1, 0, 0, 363, 365, 5, 68, 0, 0, 364, 363,
+		1, 0, 0, 0, 365, 368, 1, 0, 0, 0, 366, 364, 1, 0, 0, 0, 366, 367, 1, 0,
+		0, 0, 367, 369, 1, 0, 0, 0, 368, 366, 1, 0, 0, 0, 369, 370, 5, 26, 0, 0,
+		370, 371, 5, 64, 0, 0, 371, 375, 5, 4, 0, 0, 372, 374, 5, 68, 0, 0, 373,
+		372, 1, 0, 0, 0, 374, 377, 1, 0, 0, 0, 375, 373, 1, 0, 0, 0, 375, 376,
+		1, 0, 0, 0, 376, 378, 1, 0, 0, 0, 377, 375, 1, 0, 0, 0, 378, 382, 3, 32,
+		16, 0, 379, 381, 5, 68, 0, 0, 380, 379, 1, 0, 0, 0, 381, 384, 1, 0, 0,
+		0, 382, 380, 1, 0, 0, 0, 382, 383, 1, 0, 0, 0, 383, 385, 1, 0, 0, 0, 384,
+		382, 1, 0, 0, 0, 385, 387, 5, 5, 0, 0, 386, 388, 5, 3, 0, 0, 387, 386,
+		1, 0, 0, 0, 387, 388, 1, 0, 0, 0, 388, 392, 1, 0, 0, 0, 389, 391, 5, 68,
+		0, 0, 390, 389, 1, 0, 0, 0, 391, 394, 1, 0, 0, 0, 392, 390, 1, 0, 0, 0,
+		392, 393, 1, 0, 0, 0, 393, 395, 1, 0, 0, 0, 394, 392, 1, 0, 0, 0, 395,
+		396, 5, 2, 0, 0, 396, 397, 5, 63, 0, 0, 397, 31, 1, 0, 0, 0, 398, 409,
+		3, 34, 17, 0, 399, 403, 5, 3, 0, 0, 400, 402, 5, 68, 0, 0, 401, 400, 1,
+		0, 0, 0, 402, 405, 1, 0, 0, 0, 403, 401, 1, 0, 0, 0, 403, 404, 1, 0, 0,
+		0, 404, 406, 1, 0, 0, 0, 405, 403, 1, 0, 0, 0, 406, 408, 3, 34, 17, 0,
+		407, 399, 1, 0, 0, 0, 408, 411, 1, 0, 0, 0, 409, 407, 1, 0, 0, 0, 409,
+		410, 1, 0, 0, 0, 410, 33, 1, 0, 0, 0, 411, 409, 1, 0, 0, 0, 412, 413, 5,
+		67, 0, 0, 413, 35, 1, 0, 0, 0, 414, 424, 3, 38, 19, 0, 415, 424, 3, 40,
+		20, 0, 416, 424, 3, 42, 21, 0, 417, 424, 3, 44, 22, 0, 418, 424, 3, 46,
+		23, 0, 419, 424, 3, 48, 24, 0, 420, 424, 3, 50, 25, 0, 421, 424, 3, 52,
+		26, 0, 422, 424, 3, 54, 27, 0, 423, 414, 1, 0, 0, 0, 423, 415, 1, 0, 0,
+		0, 423, 416, 1, 0, 0, 0, 423, 417, 1, 0, 0, 0, 423, 418, 1, 0, 0, 0, 423,
+		419, 1, 0, 0, 0, 423, 420, 1, 0, 0, 0, 423, 421, 1, 0, 0, 0, 423, 422,
+		1, 0, 0, 0, 424, 37, 1, 0, 0, 0, 425, 426, 5, 28, 0, 0, 426, 427, 3, 62,
+		31, 0, 427, 428, 5, 64, 0, 0, 428, 429, 3, 56, 28, 0, 429, 430, 5, 63,
+		0, 0, 430, 39, 1, 0, 0, 0, 431, 432, 5, 29, 0, 0, 432, 433, 3, 62, 31,
+		0, 433, 434, 5, 64, 0, 0, 434, 435, 3, 58, 29, 0, 435, 436, 5, 63, 0, 0,
+		436, 41, 1, 0, 0, 0, 437, 438, 5, 30, 0, 0, 438, 439, 3, 62, 31, 0, 439,
+		440, 5, 64, 0, 0, 440, 441, 3, 56, 28, 0, 441, 442, 5, 63, 0, 0, 442, 43,
+		1, 0, 0, 0, 443, 444, 5, 31, 0, 0, 444, 445, 3, 62, 31, 0, 445, 446, 5,
+		64, 0, 0, 446, 447, 3, 58, 29, 0, 447, 448, 5, 63, 0, 0, 448, 45, 1, 0,
+		0, 0, 449, 450, 5, 32, 0, 0, 450, 451, 3, 62, 31, 0, 451, 452, 5, 64, 0,
+		0, 452, 453, 3, 60, 30, 0, 453, 454, 5, 63, 0, 0, 454, 47, 1, 0, 0, 0,
+		455, 456, 5, 33, 0, 0, 456, 457, 3, 62, 31, 0, 457, 458, 5, 64, 0, 0, 458,
+		459, 5, 67, 0, 0, 459, 460, 5, 63, 0, 0, 460, 49, 1, 0, 0, 0, 461, 462,
+		5, 34, 0, 0, 462, 463, 3, 62, 31, 0, 463, 464, 5, 64, 0, 0, 464, 465, 5,
+		67, 0, 0, 465, 466, 5, 63, 0, 0, 466, 51, 1, 0, 0, 0, 467, 468, 5, 35,
+		0, 0, 468, 469, 3, 62, 31, 0, 469, 470, 5, 64, 0, 0, 470, 471, 5, 67, 0,
+		0, 471, 472, 5, 63, 0, 0, 472, 53, 1, 0, 0, 0, 473, 474, 5, 36, 0, 0, 474,
+		475, 3, 62, 31, 0, 475, 476, 5, 64, 0, 0, 476, 477, 5, 67, 0, 0, 477, 478,
+		5, 63, 0, 0, 478, 55, 1, 0, 0, 0, 479, 480, 5, 57, 0, 0, 480, 485, 3, 56,
+		28, 0, 481, 482, 5, 58, 0, 0, 482, 485, 3, 56, 28, 0, 483, 485, 5, 66,
+		0, 0, 484, 479, 1, 0, 0, 0, 484, 481, 1, 0, 0, 0, 484, 483, 1, 0, 0, 0,
+		485, 57, 1, 0, 0, 0, 486, 487, 5, 57, 0, 0, 487, 490, 3, 58, 29, 0, 488,
+		490, 5, 66, 0, 0, 489, 486, 1, 0, 0, 0, 489, 488, 1, 0, 0, 0, 490, 59,
+		1, 0, 0, 0, 491, 492, 5, 57, 0, 0, 492, 501, 3, 60, 30, 0, 493, 494, 5,
+		58, 0, 0, 494, 501, 3, 60, 30, 0, 495, 498, 5, 66, 0, 0, 496, 497, 5, 7,
+		0, 0, 497, 499, 5, 66, 0, 0, 498, 496, 1, 0, 0, 0, 498, 499, 1, 0, 0, 0,
+		499, 501, 1, 0, 0, 0, 500, 491, 1, 0, 0, 0, 500, 493, 1, 0, 0, 0, 500,
+		495, 1, 0, 0, 0, 501, 61, 1, 0, 0, 0, 502, 503, 5, 65, 0, 0, 503, 504,
+		5, 8, 0, 0, 504, 510, 5, 65, 0, 0, 505, 506, 5, 65, 0, 0, 506, 507, 5,
+		8, 0, 0, 507, 510, 5, 67, 0, 0, 508, 510, 5, 65, 0, 0, 509, 502, 1, 0,
+		0, 0, 509, 505, 1, 0, 0, 0, 509, 508, 1, 0, 0, 0, 510, 63, 1, 0, 0, 0,
+		511, 514, 3, 66, 33, 0, 512, 514, 3, 68, 34, 0, 513, 511, 1, 0, 0, 0, 513,
+		512, 1, 0, 0, 0, 514, 65, 1, 0, 0, 0, 515, 516, 5, 37, 0, 0, 516, 517,
+		3, 62, 31, 0, 517, 518, 5, 64, 0, 0, 518, 519, 3, 70, 35, 0, 519, 520,
+		5, 63, 0, 0, 520, 67, 1, 0, 0, 0, 521, 522, 5, 38, 0, 0, 522, 523, 3, 62,
+		31, 0, 523, 524, 5, 64, 0, 0, 524, 525, 5, 67, 0, 0, 525, 526, 5, 63, 0,
+		0, 526, 69, 1, 0, 0, 0, 527, 531, 3, 104, 52, 0, 528, 531, 5, 39, 0, 0,
+		529, 531, 5, 67, 0, 0, 530, 527, 1, 0, 0, 0, 530, 528, 1, 0, 0, 0, 530,
+		529, 1, 0, 0, 0, 531, 71, 1, 0, 0, 0, 532, 533, 5, 40, 0, 0, 533, 534,
+		3, 62, 31, 0, 534, 538, 5, 1, 0, 0, 535, 537, 5, 68, 0, 0, 536, 535, 1,
+		0, 0, 0, 537, 540, 1, 0, 0, 0, 538, 536, 1, 0, 0, 0, 538, 539, 1, 0, 0,
+		0, 539, 541, 1, 0, 0, 0, 540, 538, 1, 0, 0, 0, 541, 545, 3, 74, 37, 0,
+		542, 544, 5, 68, 0, 0, 543, 542, 1, 0, 0, 0, 544, 547, 1, 0, 0, 0, 545,
+		543, 1, 0, 0, 0, 545, 546, 1, 0, 0, 0, 546, 548, 1, 0, 0, 0, 547, 545,
+		1, 0, 0, 0, 548, 549, 5, 43, 0, 0, 549, 550, 5, 64, 0, 0, 550, 551, 3,
+		76, 38, 0, 551, 555, 5, 3, 0, 0, 552, 554, 5, 68, 0, 0, 553, 552, 1, 0,
+		0, 0, 554, 557, 1, 0, 0, 0, 555, 553, 1, 0, 0, 0, 555, 556, 1, 0, 0, 0,
+		556, 558, 1, 0, 0, 0, 557, 555, 1, 0, 0, 0, 558, 559, 5, 44, 0, 0, 559,
+		560, 5, 64, 0, 0, 560, 564, 5, 4, 0, 0, 561, 563, 5, 68, 0, 0, 562, 561,
+		1, 0, 0, 0, 563, 566, 1, 0, 0, 0, 564, 562, 1, 0, 0, 0, 564, 565, 1, 0,
+		0, 0, 565, 567, 1, 0, 0, 0, 566, 564, 1, 0, 0, 0, 567, 571, 3, 80, 40,
+		0, 568, 570, 5, 68, 0, 0, 569, 568, 1, 0, 0, 0, 570, 573, 1, 0, 0, 0, 571,
+		569, 1, 0, 0, 0, 571, 572, 1, 0, 0, 0, 572, 574, 1, 0, 0, 0, 573, 571,
+		1, 0, 0, 0, 574, 576, 5, 5, 0, 0, 575, 577, 5, 3, 0, 0, 576, 575, 1, 0,
+		0, 0, 576, 577, 1, 0, 0, 0, 577, 581, 1, 0, 0, 0, 578, 580, 5, 68, 0, 0,
+		579, 578, 1, 0, 0, 0, 580, 583, 1, 0, 0, 0, 581, 579, 1, 0, 0, 0, 581,
+		582, 1, 0, 0, 0, 582, 584, 1, 0, 0, 0, 583, 581, 1, 0, 0, 0, 584, 585,
+		5, 2, 0, 0, 585, 586, 5, 63, 0, 0, 586, 73, 1, 0, 0, 0, 587, 588, 5, 41,
+		0, 0, 588, 589, 5, 64, 0, 0, 589, 590, 3, 62, 31, 0, 590, 591, 5, 3, 0,
+		0, 591, 597, 1, 0, 0, 0, 592, 593, 5, 42, 0, 0, 593, 594, 5, 64, 0, 0,
+		594, 595, 5, 67, 0, 0, 595, 597, 5, 3, 0, 0, 596, 587, 1, 0, 0, 0, 596,
+		592, 1, 0, 0, 0, 597, 75, 1, 0, 0, 0, 598, 600, 5, 4, 0, 0, 599, 601, 3,
+		78, 39, 0, 600, 599, 1, 0, 0, 0, 600, 601, 1, 0, 0, 0, 601, 602, 1, 0,
+		0, 0, 602, 603, 5, 5, 0, 0, 603, 77, 1, 0, 0, 0, 604, 609, 5, 67, 0, 0,
+		605, 606, 5, 3, 0, 0, 606, 608, 5, 67, 0, 0, 607, 605, 1, 0, 0, 0, 608,
+		611, 1, 0, 0, 0, 609, 607, 1, 0, 0, 0, 609, 610, 1, 0, 0, 0, 610, 79, 1,
+		0, 0, 0, 611, 609, 1, 0, 0, 0, 612, 623, 3, 82, 41, 0, 613, 617, 5, 3,
+		0, 0, 614, 616, 5, 68, 0, 0, 615, 614, 1, 0, 0, 0, 616, 619, 1, 0, 0, 0,
+		617, 615, 1, 0, 0, 0, 617, 618, 1, 0, 0, 0, 618, 620, 1, 0, 0, 0, 619,
+		617, 1, 0, 0, 0, 620, 622, 3, 82, 41, 0, 621, 613, 1, 0, 0, 0, 622, 625,
+		1, 0, 0, 0, 623, 621, 1, 0, 0, 0, 623, 624, 1, 0, 0, 0, 624, 81, 1, 0,
+		0, 0, 625, 623, 1, 0, 0, 0, 626, 627, 5, 67, 0, 0, 627, 629, 5, 6, 0, 0,
+		628, 630, 5, 18, 0, 0, 629, 628, 1, 0, 0, 0, 629, 630, 1, 0, 0, 0, 630,
+		631, 1, 0, 0, 0, 631, 632, 3, 22, 11, 0, 632, 83, 1, 0, 0, 0, 633, 634,
+		5, 4, 0, 0, 634, 638, 5, 65, 0, 0, 635, 637, 3, 86, 43, 0, 636, 635, 1,
+		0, 0, 0, 637, 640, 1, 0, 0, 0, 638, 636, 1, 0, 0, 0, 638, 639, 1, 0, 0,
+		0, 639, 641, 1, 0, 0, 0, 640, 638, 1, 0, 0, 0, 641, 642, 5, 5, 0, 0, 642,
+		646, 5, 8, 0, 0, 643, 645, 5, 68, 0, 0, 644, 643, 1, 0, 0, 0, 645, 648,
+		1, 0, 0, 0, 646, 644, 1, 0, 0, 0, 646, 647, 1, 0, 0, 0, 647, 659, 1, 0,
+		0, 0, 648, 646, 1, 0, 0, 0, 649, 652, 3, 90, 45, 0, 650, 652, 3, 92, 46,
+		0, 651, 649, 1, 0, 0, 0, 651, 650, 1, 0, 0, 0, 652, 656, 1, 0, 0, 0, 653,
+		655, 5, 68, 0, 0, 654, 653, 1, 0, 0, 0, 655, 658, 1, 0, 0, 0, 656, 654,
+		1, 0, 0, 0, 656, 657, 1, 0, 0, 0, 657, 660, 1, 0, 0, 0, 658, 656, 1, 0,
+		0, 0, 659, 651, 1, 0, 0, 0, 660, 661, 1, 0, 0, 0, 661, 659, 1, 0, 0, 0,
+		661, 662, 1, 0, 0, 0, 662, 663, 1, 0, 0, 0, 663, 667, 5, 9, 0, 0, 664,
+		666, 5, 68, 0, 0, 665, 664, 1, 0, 0, 0, 666, 669, 1, 0, 0, 0, 667, 665,
+		1, 0, 0, 0, 667, 668, 1, 0, 0, 0, 668, 677, 1, 0, 0, 0, 669, 667, 1, 0,
+		0, 0, 670, 674, 3, 96, 48, 0, 671, 673, 5, 68, 0, 0, 672, 671, 1, 0, 0,
+		0, 673, 676, 1, 0, 0, 0, 674, 672, 1, 0, 0, 0, 674, 675, 1, 0, 0, 0, 675,
+		678, 1, 0, 0, 0, 676, 674, 1, 0, 0, 0, 677, 670, 1, 0, 0, 0, 678, 679,
+		1, 0, 0, 0, 679, 677, 1, 0, 0, 0, 679, 680, 1, 0, 0, 0, 680, 681, 1, 0,
+		0, 0, 681, 682, 5, 63, 0, 0, 682, 85, 1, 0, 0, 0, 683, 684, 5, 3, 0, 0,
+		684, 685, 5, 65, 0, 0, 685, 686, 5, 64, 0, 0, 686, 687, 3, 88, 44, 0, 687,
+		87, 1, 0, 0, 0, 688, 693, 5, 67, 0, 0, 689, 693, 5, 45, 0, 0, 690, 693,
+		5, 46, 0, 0, 691, 693, 3, 56, 28, 0, 692, 688, 1, 0, 0, 0, 692, 689, 1,
+		0, 0, 0, 692, 690, 1, 0, 0, 0, 692, 691, 1, 0, 0, 0, 693, 89, 1, 0, 0,
+		0, 694, 696, 5, 48, 0, 0, 695, 694, 1, 0, 0, 0, 695, 696, 1, 0, 0, 0, 696,
+		697, 1, 0, 0, 0, 697, 706, 5, 10, 0, 0, 698, 699, 3, 100, 50, 0, 699, 700,
+		3, 100, 50, 0, 700, 701, 3, 102, 51, 0, 701, 707, 1, 0, 0, 0, 702, 703,
+		3, 100, 50, 0, 703, 704, 5, 64, 0, 0, 704, 705, 3, 98, 49, 0, 705, 707,
+		1, 0, 0, 0, 706, 698, 1, 0, 0, 0, 706, 702, 1, 0, 0, 0, 707, 708, 1, 0,
+		0, 0, 708, 710, 5, 11, 0, 0, 709, 711, 5, 7, 0, 0, 710, 709, 1, 0, 0, 0,
+		710, 711, 1, 0, 0, 0, 711, 718, 1, 0, 0, 0, 712, 713, 5, 4, 0, 0, 713,
+		714, 3, 106, 53, 0, 714, 716, 5, 5, 0, 0, 715, 717, 5, 7, 0, 0, 716, 715,
+		1, 0, 0, 0, 716, 717, 1, 0, 0, 0, 717, 719, 1, 0, 0, 0, 718, 712, 1, 0,
+		0, 0, 718, 719, 1, 0, 0, 0, 719, 91, 1, 0, 0, 0, 720, 721, 5, 61, 0, 0,
+		721, 723, 3, 94, 47, 0, 722, 724, 3, 94, 47, 0, 723, 722, 1, 0, 0, 0, 724,
+		725, 1, 0, 0, 0, 725, 723, 1, 0, 0, 0, 725, 726, 1, 0, 0, 0, 726, 728,
+		1, 0, 0, 0, 727, 729, 5, 7, 0, 0, 728, 727, 1, 0, 0, 0, 728, 729, 1, 0,
+		0, 0, 729, 93, 1, 0, 0, 0, 730, 734, 5, 1, 0, 0, 731, 733, 5, 68, 0, 0,
+		732, 731, 1, 0, 0, 0, 733, 736, 1, 0, 0, 0, 734, 732, 1, 0, 0, 0, 734,
+		735, 1, 0, 0, 0, 735, 744, 1, 0, 0, 0, 736, 734, 1, 0, 0, 0, 737, 741,
+		3, 90, 45, 0, 738, 740, 5, 68, 0, 0, 739, 738, 1, 0, 0, 0, 740, 743, 1,
+		0, 0, 0, 741, 739, 1, 0, 0, 0, 741, 742, 1, 0, 0, 0, 742, 745, 1, 0, 0,
+		0, 743, 741, 1, 0, 0, 0, 744, 737, 1, 0, 0, 0, 745, 746, 1, 0, 0, 0, 746,
+		744, 1, 0, 0, 0, 746, 747, 1, 0, 0, 0, 747, 748, 1, 0, 0, 0, 748, 749,
+		5, 2, 0, 0, 749, 95, 1, 0, 0, 0, 750, 751, 5, 10, 0, 0, 751, 752, 3, 100,
+		50, 0, 752, 755, 3, 100, 50, 0, 753, 756, 3, 106, 53, 0, 754, 756, 3, 98,
+		49, 0, 755, 753, 1, 0, 0, 0, 755, 754, 1, 0, 0, 0, 756, 757, 1, 0, 0, 0,
+		757, 759, 5, 11, 0, 0, 758, 760, 5, 7, 0, 0, 759, 758, 1, 0, 0, 0, 759,
+		760, 1, 0, 0, 0, 760, 97, 1, 0, 0, 0, 761, 762, 5, 65, 0, 0, 762, 763,
+		5, 10, 0, 0, 763, 764, 3, 100, 50, 0, 764, 765, 5, 65, 0, 0, 765, 766,
+		5, 10, 0, 0, 766, 767, 3, 100, 50, 0, 767, 768, 3, 100, 50, 0, 768, 769,
+		3, 102, 51, 0, 769, 770, 5, 11, 0, 0, 770, 771, 5, 11, 0, 0, 771, 99, 1,
+		0, 0, 0, 772, 773, 5, 12, 0, 0, 773, 776, 5, 65, 0, 0, 774, 776, 3, 62,
+		31, 0, 775, 772, 1, 0, 0, 0, 775, 774, 1, 0, 0, 0, 776, 101, 1, 0, 0, 0,
+		777, 823, 3, 100, 50, 0, 778, 779, 5, 28, 0, 0, 779, 780, 5, 10, 0, 0,
+		780, 781, 3, 56, 28, 0, 781, 782, 5, 11, 0, 0, 782, 823, 1, 0, 0, 0, 783,
+		784, 5, 29, 0, 0, 784, 785, 5, 10, 0, 0, 785, 786, 3, 58, 29, 0, 786, 787,
+		5, 11, 0, 0, 787, 823, 1, 0, 0, 0, 788, 789, 5, 30, 0, 0, 789, 790, 5,
+		10, 0, 0, 790, 791, 3, 56, 28, 0, 791, 792, 5, 11, 0, 0, 792, 823, 1, 0,
+		0, 0, 793, 794, 5, 31, 0, 0, 794, 795, 5, 10, 0, 0, 795, 796, 3, 58, 29,
+		0, 796, 797, 5, 11, 0, 0, 797, 823, 1, 0, 0, 0, 798, 799, 5, 32, 0, 0,
+		799, 800, 5, 10, 0, 0, 800, 801, 3, 60, 30, 0, 801, 802, 5, 11, 0, 0, 802,
+		823, 1, 0, 0, 0, 803, 804, 5, 33, 0, 0, 804, 805, 5, 10, 0, 0, 805, 806,
+		5, 67, 0, 0, 806, 823, 5, 11, 0, 0, 807, 808, 5, 34, 0, 0, 808, 809, 5,
+		10, 0, 0, 809, 810, 5, 67, 0, 0, 810, 823, 5, 11, 0, 0, 811, 812, 5, 35,
+		0, 0, 812, 813, 5, 10, 0, 0, 813, 814, 5, 67, 0, 0, 814, 823, 5, 11, 0,
+		0, 815, 816, 5, 36, 0, 0, 816, 817, 5, 10, 0, 0, 817, 818, 5, 67, 0, 0,
+		818, 823, 5, 11, 0, 0, 819, 823, 5, 67, 0, 0, 820, 823, 3, 104, 52, 0,
+		821, 823, 3, 60, 30, 0, 822, 777, 1, 0, 0, 0, 822, 778, 1, 0, 0, 0, 822,
+		783, 1, 0, 0, 0, 822, 788, 1, 0, 0, 0, 822, 793, 1, 0, 0, 0, 822, 798,
+		1, 0, 0, 0, 822, 803, 1, 0, 0, 0, 822, 807, 1, 0, 0, 0, 822, 811, 1, 0,
+		0, 0, 822, 815, 1, 0, 0, 0, 822, 819, 1, 0, 0, 0, 822, 820, 1, 0, 0, 0,
+		822, 821, 1, 0, 0, 0, 823, 103, 1, 0, 0, 0, 824, 825, 7, 3, 0, 0, 825,
+		105, 1, 0, 0, 0, 826, 827, 6, 53, -1, 0, 827, 828, 5, 10, 0, 0, 828, 829,
+		3, 106, 53, 0, 829, 830, 3, 108, 54, 0, 830, 831, 3, 106, 53, 0, 831, 832,
+		5, 11, 0, 0, 832, 852, 1, 0, 0, 0, 833, 834, 3, 110, 55, 0, 834, 835, 5,
+		10, 0, 0, 835, 836, 3, 106, 53, 0, 836, 837, 5, 11, 0, 0, 837, 852, 1,
+		0, 0, 0, 838, 839, 5, 10, 0, 0, 839, 840, 3, 110, 55, 0, 840, 841, 3, 106,
+		53, 0, 841, 842, 5, 11, 0, 0, 842, 852, 1, 0, 0, 0, 843, 844, 5, 10, 0,
+		0, 844, 845, 3, 106, 53, 0, 845, 846, 5, 11, 0, 0, 846, 852, 1, 0, 0, 0,
+		847, 848, 3, 110, 55, 0, 848, 849, 3, 106, 53, 2, 849, 852, 1, 0, 0, 0,
+		850, 852, 3, 102, 51, 0, 851, 826, 1, 0, 0, 0, 851, 833, 1, 0, 0, 0, 851,
+		838, 1, 0, 0, 0, 851, 843, 1, 0, 0, 0, 851, 847, 1, 0, 0, 0, 851, 850,
+		1, 0, 0, 0, 852, 859, 1, 0, 0, 0, 853, 854, 10, 7, 0, 0, 854, 855, 3, 108,
+		54, 0, 855, 856, 3, 106, 53, 8, 856, 858, 1, 0, 0, 0, 857, 853, 1, 0, 0,
+		0, 858, 861, 1, 0, 0, 0, 859, 857, 1, 0, 0, 0, 859, 860, 1, 0, 0, 0, 860,
+		107, 1, 0, 0, 0, 861, 859, 1, 0, 0, 0, 862, 863, 7, 4, 0, 0, 863, 109,
+		1, 0, 0, 0, 864, 865, 7, 5, 0, 0, 865, 111, 1, 0, 0, 0, 866, 867, 5, 27,
+		0, 0, 867, 868, 5, 10, 0, 0, 868, 869, 3, 100, 50, 0, 869, 870, 5, 3, 0,
+		0, 870, 871, 3, 100, 50, 0, 871, 872, 5, 3, 0, 0, 872, 873, 3, 102, 51,
+		0, 873, 874, 5, 11, 0, 0, 874, 875, 5, 63, 0, 0, 875, 113, 1, 0, 0, 0,
+		82, 117, 132, 145, 152, 165, 171, 186, 194, 200, 206, 211, 219, 227, 233,
+		239, 251, 259, 265, 271, 282, 290, 296, 302, 313, 321, 327, 333, 339, 346,
+		366, 375, 382, 387, 392, 403, 409, 423, 484, 489, 498, 500, 509, 513, 530,
+		538, 545, 555, 564, 571, 576, 581, 596, 600, 609, 617, 623, 629, 638, 646,
+		651, 656, 661, 667, 674, 679, 692, 695, 706, 710, 716, 718, 725, 728, 734,
+		741, 746, 755, 759, 775, 822, 851, 859,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -597,14 +625,17 @@ const (
 	JetRuleParserRULE_ruleProperties           = 43
 	JetRuleParserRULE_propertyValue            = 44
 	JetRuleParserRULE_antecedent               = 45
-	JetRuleParserRULE_consequent               = 46
-	JetRuleParserRULE_atom                     = 47
-	JetRuleParserRULE_objectAtom               = 48
-	JetRuleParserRULE_keywords                 = 49
-	JetRuleParserRULE_exprTerm                 = 50
-	JetRuleParserRULE_binaryOp                 = 51
-	JetRuleParserRULE_unaryOp                  = 52
-	JetRuleParserRULE_tripleStmt               = 53
+	JetRuleParserRULE_orAntecedent             = 46
+	JetRuleParserRULE_orBranch                 = 47
+	JetRuleParserRULE_consequent               = 48
+	JetRuleParserRULE_aggregateTerm            = 49
+	JetRuleParserRULE_atom                     = 50
+	JetRuleParserRULE_objectAtom               = 51
+	JetRuleParserRULE_keywords                 = 52
+	JetRuleParserRULE_exprTerm                 = 53
+	JetRuleParserRULE_binaryOp                 = 54
+	JetRuleParserRULE_unaryOp                  = 55
+	JetRuleParserRULE_tripleStmt               = 56
 )
 
 // IJetruleContext is an interface to support dynamic dispatch.
@@ -726,7 +757,7 @@ func (p *JetRuleParser) Jetrule() (localctx IJetruleContext) {
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(111)
+	p.SetState(117)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -735,11 +766,11 @@ func (p *JetRuleParser) Jetrule() (localctx IJetruleContext) {
 
 	for ((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&1649169948688) != 0) || _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(108)
+			p.SetState(114)
 			p.Statement()
 		}
 
-		p.SetState(113)
+		p.SetState(119)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -747,7 +778,7 @@ func (p *JetRuleParser) Jetrule() (localctx IJetruleContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(114)
+		p.SetState(120)
 		p.Match(JetRuleParserEOF)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -994,7 +1025,7 @@ func (s *StatementContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *JetRuleParser) Statement() (localctx IStatementContext) {
 	localctx = NewStatementContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 2, JetRuleParserRULE_statement)
-	p.SetState(126)
+	p.SetState(132)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1004,70 +1035,70 @@ func (p *JetRuleParser) Statement() (localctx IStatementContext) {
 	case JetRuleParserJetCompilerDirective:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(116)
+			p.SetState(122)
 			p.JetCompilerDirectiveStmt()
 		}
 
 	case JetRuleParserMAIN, JetRuleParserJETSCONFIG:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(117)
+			p.SetState(123)
 			p.DefineJetStoreConfigStmt()
 		}
 
 	case JetRuleParserInt32Type, JetRuleParserUInt32Type, JetRuleParserInt64Type, JetRuleParserUInt64Type, JetRuleParserDoubleType, JetRuleParserStringType, JetRuleParserDateType, JetRuleParserDatetimeType, JetRuleParserBoolType:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(118)
+			p.SetState(124)
 			p.DefineLiteralStmt()
 		}
 
 	case JetRuleParserCLASS:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(119)
+			p.SetState(125)
 			p.DefineClassStmt()
 		}
 
 	case JetRuleParserRULESEQ:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(120)
+			p.SetState(126)
 			p.DefineRuleSeqStmt()
 		}
 
 	case JetRuleParserResourceType, JetRuleParserVolatileResourceType:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(121)
+			p.SetState(127)
 			p.DefineResourceStmt()
 		}
 
 	case JetRuleParserLookupTable:
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(122)
+			p.SetState(128)
 			p.LookupTableStmt()
 		}
 
 	case JetRuleParserT__3:
 		p.EnterOuterAlt(localctx, 8)
 		{
-			p.SetState(123)
+			p.SetState(129)
 			p.JetRuleStmt()
 		}
 
 	case JetRuleParserTRIPLE:
 		p.EnterOuterAlt(localctx, 9)
 		{
-			p.SetState(124)
+			p.SetState(130)
 			p.TripleStmt()
 		}
 
 	case JetRuleParserCOMMENT:
 		p.EnterOuterAlt(localctx, 10)
 		{
-			p.SetState(125)
+			p.SetState(131)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1222,7 +1253,7 @@ func (p *JetRuleParser) JetCompilerDirectiveStmt() (localctx IJetCompilerDirecti
 	p.EnterRule(localctx, 4, JetRuleParserRULE_jetCompilerDirectiveStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(128)
+		p.SetState(134)
 		p.Match(JetRuleParserJetCompilerDirective)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1230,14 +1261,14 @@ func (p *JetRuleParser) JetCompilerDirectiveStmt() (localctx IJetCompilerDirecti
 		}
 	}
 	{
-		p.SetState(129)
+		p.SetState(135)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*JetCompilerDirectiveStmtContext).varName = _x
 	}
 	{
-		p.SetState(130)
+		p.SetState(136)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1245,7 +1276,7 @@ func (p *JetRuleParser) JetCompilerDirectiveStmt() (localctx IJetCompilerDirecti
 		}
 	}
 	{
-		p.SetState(131)
+		p.SetState(137)
 
 		var _m = p.Match(JetRuleParserSTRING)
 
@@ -1256,7 +1287,7 @@ func (p *JetRuleParser) JetCompilerDirectiveStmt() (localctx IJetCompilerDirecti
 		}
 	}
 	{
-		p.SetState(132)
+		p.SetState(138)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1398,18 +1429,18 @@ func (p *JetRuleParser) DefineJetStoreConfigStmt() (localctx IDefineJetStoreConf
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(134)
+		p.SetState(140)
 		p.JetstoreConfig()
 	}
 	{
-		p.SetState(135)
+		p.SetState(141)
 		p.Match(JetRuleParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(139)
+	p.SetState(145)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1418,7 +1449,7 @@ func (p *JetRuleParser) DefineJetStoreConfigStmt() (localctx IDefineJetStoreConf
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(136)
+			p.SetState(142)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1426,7 +1457,7 @@ func (p *JetRuleParser) DefineJetStoreConfigStmt() (localctx IDefineJetStoreConf
 			}
 		}
 
-		p.SetState(141)
+		p.SetState(147)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -1434,10 +1465,10 @@ func (p *JetRuleParser) DefineJetStoreConfigStmt() (localctx IDefineJetStoreConf
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(142)
+		p.SetState(148)
 		p.JetstoreConfigSeq()
 	}
-	p.SetState(146)
+	p.SetState(152)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1446,7 +1477,7 @@ func (p *JetRuleParser) DefineJetStoreConfigStmt() (localctx IDefineJetStoreConf
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(143)
+			p.SetState(149)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1454,7 +1485,7 @@ func (p *JetRuleParser) DefineJetStoreConfigStmt() (localctx IDefineJetStoreConf
 			}
 		}
 
-		p.SetState(148)
+		p.SetState(154)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -1462,7 +1493,7 @@ func (p *JetRuleParser) DefineJetStoreConfigStmt() (localctx IDefineJetStoreConf
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(149)
+		p.SetState(155)
 		p.Match(JetRuleParserT__1)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1470,7 +1501,7 @@ func (p *JetRuleParser) DefineJetStoreConfigStmt() (localctx IDefineJetStoreConf
 		}
 	}
 	{
-		p.SetState(150)
+		p.SetState(156)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1573,7 +1604,7 @@ func (p *JetRuleParser) JetstoreConfig() (localctx IJetstoreConfigContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(152)
+		p.SetState(158)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == JetRuleParserMAIN || _la == JetRuleParserJETSCONFIG) {
@@ -1722,10 +1753,10 @@ func (p *JetRuleParser) JetstoreConfigSeq() (localctx IJetstoreConfigSeqContext)
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(154)
+		p.SetState(160)
 		p.JetstoreConfigItem()
 	}
-	p.SetState(165)
+	p.SetState(171)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1734,14 +1765,14 @@ func (p *JetRuleParser) JetstoreConfigSeq() (localctx IJetstoreConfigSeqContext)
 
 	for _la == JetRuleParserT__2 {
 		{
-			p.SetState(155)
+			p.SetState(161)
 			p.Match(JetRuleParserT__2)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(159)
+		p.SetState(165)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -1750,7 +1781,7 @@ func (p *JetRuleParser) JetstoreConfigSeq() (localctx IJetstoreConfigSeqContext)
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(156)
+				p.SetState(162)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -1758,7 +1789,7 @@ func (p *JetRuleParser) JetstoreConfigSeq() (localctx IJetstoreConfigSeqContext)
 				}
 			}
 
-			p.SetState(161)
+			p.SetState(167)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -1766,11 +1797,11 @@ func (p *JetRuleParser) JetstoreConfigSeq() (localctx IJetstoreConfigSeqContext)
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(162)
+			p.SetState(168)
 			p.JetstoreConfigItem()
 		}
 
-		p.SetState(167)
+		p.SetState(173)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -1999,7 +2030,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 	p.EnterRule(localctx, 12, JetRuleParserRULE_jetstoreConfigItem)
 	var _la int
 
-	p.SetState(205)
+	p.SetState(211)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2009,7 +2040,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 	case JetRuleParserMaxLooping:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(168)
+			p.SetState(174)
 
 			var _m = p.Match(JetRuleParserMaxLooping)
 
@@ -2020,7 +2051,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 			}
 		}
 		{
-			p.SetState(169)
+			p.SetState(175)
 			p.Match(JetRuleParserASSIGN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2028,7 +2059,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 			}
 		}
 		{
-			p.SetState(170)
+			p.SetState(176)
 
 			var _x = p.UintExpr()
 
@@ -2038,7 +2069,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 	case JetRuleParserMaxRuleExec:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(171)
+			p.SetState(177)
 
 			var _m = p.Match(JetRuleParserMaxRuleExec)
 
@@ -2049,7 +2080,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 			}
 		}
 		{
-			p.SetState(172)
+			p.SetState(178)
 			p.Match(JetRuleParserASSIGN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2057,7 +2088,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 			}
 		}
 		{
-			p.SetState(173)
+			p.SetState(179)
 
 			var _x = p.UintExpr()
 
@@ -2067,7 +2098,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 	case JetRuleParserInputType:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(174)
+			p.SetState(180)
 
 			var _m = p.Match(JetRuleParserInputType)
 
@@ -2078,7 +2109,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 			}
 		}
 		{
-			p.SetState(175)
+			p.SetState(181)
 			p.Match(JetRuleParserASSIGN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2086,14 +2117,14 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 			}
 		}
 		{
-			p.SetState(176)
+			p.SetState(182)
 			p.Match(JetRuleParserT__3)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(180)
+		p.SetState(186)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2102,7 +2133,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(177)
+				p.SetState(183)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -2110,7 +2141,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 				}
 			}
 
-			p.SetState(182)
+			p.SetState(188)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -2118,14 +2149,14 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(183)
+			p.SetState(189)
 
 			var _x = p.DeclIdentifier()
 
 			localctx.(*JetstoreConfigItemContext)._declIdentifier = _x
 		}
 		localctx.(*JetstoreConfigItemContext).rdfTypeList = append(localctx.(*JetstoreConfigItemContext).rdfTypeList, localctx.(*JetstoreConfigItemContext)._declIdentifier)
-		p.SetState(194)
+		p.SetState(200)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2134,14 +2165,14 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 
 		for _la == JetRuleParserT__2 {
 			{
-				p.SetState(184)
+				p.SetState(190)
 				p.Match(JetRuleParserT__2)
 				if p.HasError() {
 					// Recognition error - abort rule
 					goto errorExit
 				}
 			}
-			p.SetState(188)
+			p.SetState(194)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -2150,7 +2181,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 
 			for _la == JetRuleParserCOMMENT {
 				{
-					p.SetState(185)
+					p.SetState(191)
 					p.Match(JetRuleParserCOMMENT)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -2158,7 +2189,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 					}
 				}
 
-				p.SetState(190)
+				p.SetState(196)
 				p.GetErrorHandler().Sync(p)
 				if p.HasError() {
 					goto errorExit
@@ -2166,7 +2197,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 				_la = p.GetTokenStream().LA(1)
 			}
 			{
-				p.SetState(191)
+				p.SetState(197)
 
 				var _x = p.DeclIdentifier()
 
@@ -2174,14 +2205,14 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 			}
 			localctx.(*JetstoreConfigItemContext).rdfTypeList = append(localctx.(*JetstoreConfigItemContext).rdfTypeList, localctx.(*JetstoreConfigItemContext)._declIdentifier)
 
-			p.SetState(196)
+			p.SetState(202)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
 			}
 			_la = p.GetTokenStream().LA(1)
 		}
-		p.SetState(200)
+		p.SetState(206)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2190,7 +2221,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(197)
+				p.SetState(203)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -2198,7 +2229,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 				}
 			}
 
-			p.SetState(202)
+			p.SetState(208)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -2206,7 +2237,7 @@ func (p *JetRuleParser) JetstoreConfigItem() (localctx IJetstoreConfigItemContex
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(203)
+			p.SetState(209)
 			p.Match(JetRuleParserT__4)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2395,7 +2426,7 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(207)
+		p.SetState(213)
 		p.Match(JetRuleParserCLASS)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -2403,21 +2434,21 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 		}
 	}
 	{
-		p.SetState(208)
+		p.SetState(214)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*DefineClassStmtContext).className = _x
 	}
 	{
-		p.SetState(209)
+		p.SetState(215)
 		p.Match(JetRuleParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(213)
+	p.SetState(219)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2426,7 +2457,7 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(210)
+			p.SetState(216)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2434,7 +2465,7 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 			}
 		}
 
-		p.SetState(215)
+		p.SetState(221)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2442,10 +2473,10 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(216)
+		p.SetState(222)
 		p.ClassStmt()
 	}
-	p.SetState(227)
+	p.SetState(233)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2454,14 +2485,14 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 
 	for _la == JetRuleParserT__2 {
 		{
-			p.SetState(217)
+			p.SetState(223)
 			p.Match(JetRuleParserT__2)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(221)
+		p.SetState(227)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2470,7 +2501,7 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(218)
+				p.SetState(224)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -2478,7 +2509,7 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 				}
 			}
 
-			p.SetState(223)
+			p.SetState(229)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -2486,18 +2517,18 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(224)
+			p.SetState(230)
 			p.ClassStmt()
 		}
 
-		p.SetState(229)
+		p.SetState(235)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 	}
-	p.SetState(233)
+	p.SetState(239)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2506,7 +2537,7 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(230)
+			p.SetState(236)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2514,7 +2545,7 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 			}
 		}
 
-		p.SetState(235)
+		p.SetState(241)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2522,7 +2553,7 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(236)
+		p.SetState(242)
 		p.Match(JetRuleParserT__1)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -2530,7 +2561,7 @@ func (p *JetRuleParser) DefineClassStmt() (localctx IDefineClassStmtContext) {
 		}
 	}
 	{
-		p.SetState(237)
+		p.SetState(243)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -2797,7 +2828,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 	p.EnterRule(localctx, 16, JetRuleParserRULE_classStmt)
 	var _la int
 
-	p.SetState(333)
+	p.SetState(339)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2807,7 +2838,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 	case JetRuleParserBaseClasses:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(239)
+			p.SetState(245)
 			p.Match(JetRuleParserBaseClasses)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2815,7 +2846,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 			}
 		}
 		{
-			p.SetState(240)
+			p.SetState(246)
 			p.Match(JetRuleParserASSIGN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2823,14 +2854,14 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 			}
 		}
 		{
-			p.SetState(241)
+			p.SetState(247)
 			p.Match(JetRuleParserT__3)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(245)
+		p.SetState(251)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2839,7 +2870,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(242)
+				p.SetState(248)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -2847,7 +2878,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 				}
 			}
 
-			p.SetState(247)
+			p.SetState(253)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -2855,10 +2886,10 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(248)
+			p.SetState(254)
 			p.SubClassOfStmt()
 		}
-		p.SetState(259)
+		p.SetState(265)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2867,14 +2898,14 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 
 		for _la == JetRuleParserT__2 {
 			{
-				p.SetState(249)
+				p.SetState(255)
 				p.Match(JetRuleParserT__2)
 				if p.HasError() {
 					// Recognition error - abort rule
 					goto errorExit
 				}
 			}
-			p.SetState(253)
+			p.SetState(259)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -2883,7 +2914,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 
 			for _la == JetRuleParserCOMMENT {
 				{
-					p.SetState(250)
+					p.SetState(256)
 					p.Match(JetRuleParserCOMMENT)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -2891,7 +2922,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 					}
 				}
 
-				p.SetState(255)
+				p.SetState(261)
 				p.GetErrorHandler().Sync(p)
 				if p.HasError() {
 					goto errorExit
@@ -2899,18 +2930,18 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 				_la = p.GetTokenStream().LA(1)
 			}
 			{
-				p.SetState(256)
+				p.SetState(262)
 				p.SubClassOfStmt()
 			}
 
-			p.SetState(261)
+			p.SetState(267)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
 			}
 			_la = p.GetTokenStream().LA(1)
 		}
-		p.SetState(265)
+		p.SetState(271)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2919,7 +2950,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(262)
+				p.SetState(268)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -2927,7 +2958,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 				}
 			}
 
-			p.SetState(267)
+			p.SetState(273)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -2935,7 +2966,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(268)
+			p.SetState(274)
 			p.Match(JetRuleParserT__4)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2946,7 +2977,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 	case JetRuleParserDataProperties:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(270)
+			p.SetState(276)
 			p.Match(JetRuleParserDataProperties)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2954,7 +2985,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 			}
 		}
 		{
-			p.SetState(271)
+			p.SetState(277)
 			p.Match(JetRuleParserASSIGN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2962,14 +2993,14 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 			}
 		}
 		{
-			p.SetState(272)
+			p.SetState(278)
 			p.Match(JetRuleParserT__3)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(276)
+		p.SetState(282)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2978,7 +3009,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(273)
+				p.SetState(279)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -2986,7 +3017,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 				}
 			}
 
-			p.SetState(278)
+			p.SetState(284)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -2994,10 +3025,10 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(279)
+			p.SetState(285)
 			p.DataPropertyDefinitions()
 		}
-		p.SetState(290)
+		p.SetState(296)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -3006,14 +3037,14 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 
 		for _la == JetRuleParserT__2 {
 			{
-				p.SetState(280)
+				p.SetState(286)
 				p.Match(JetRuleParserT__2)
 				if p.HasError() {
 					// Recognition error - abort rule
 					goto errorExit
 				}
 			}
-			p.SetState(284)
+			p.SetState(290)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -3022,7 +3053,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 
 			for _la == JetRuleParserCOMMENT {
 				{
-					p.SetState(281)
+					p.SetState(287)
 					p.Match(JetRuleParserCOMMENT)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -3030,7 +3061,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 					}
 				}
 
-				p.SetState(286)
+				p.SetState(292)
 				p.GetErrorHandler().Sync(p)
 				if p.HasError() {
 					goto errorExit
@@ -3038,18 +3069,18 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 				_la = p.GetTokenStream().LA(1)
 			}
 			{
-				p.SetState(287)
+				p.SetState(293)
 				p.DataPropertyDefinitions()
 			}
 
-			p.SetState(292)
+			p.SetState(298)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
 			}
 			_la = p.GetTokenStream().LA(1)
 		}
-		p.SetState(296)
+		p.SetState(302)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -3058,7 +3089,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(293)
+				p.SetState(299)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -3066,7 +3097,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 				}
 			}
 
-			p.SetState(298)
+			p.SetState(304)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -3074,7 +3105,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(299)
+			p.SetState(305)
 			p.Match(JetRuleParserT__4)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3085,7 +3116,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 	case JetRuleParserGroupingProperties:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(301)
+			p.SetState(307)
 			p.Match(JetRuleParserGroupingProperties)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3093,7 +3124,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 			}
 		}
 		{
-			p.SetState(302)
+			p.SetState(308)
 			p.Match(JetRuleParserASSIGN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3101,14 +3132,14 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 			}
 		}
 		{
-			p.SetState(303)
+			p.SetState(309)
 			p.Match(JetRuleParserT__3)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(307)
+		p.SetState(313)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -3117,7 +3148,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(304)
+				p.SetState(310)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -3125,7 +3156,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 				}
 			}
 
-			p.SetState(309)
+			p.SetState(315)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -3133,10 +3164,10 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(310)
+			p.SetState(316)
 			p.GroupingPropertyStmt()
 		}
-		p.SetState(321)
+		p.SetState(327)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -3145,14 +3176,14 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 
 		for _la == JetRuleParserT__2 {
 			{
-				p.SetState(311)
+				p.SetState(317)
 				p.Match(JetRuleParserT__2)
 				if p.HasError() {
 					// Recognition error - abort rule
 					goto errorExit
 				}
 			}
-			p.SetState(315)
+			p.SetState(321)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -3161,7 +3192,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 
 			for _la == JetRuleParserCOMMENT {
 				{
-					p.SetState(312)
+					p.SetState(318)
 					p.Match(JetRuleParserCOMMENT)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -3169,7 +3200,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 					}
 				}
 
-				p.SetState(317)
+				p.SetState(323)
 				p.GetErrorHandler().Sync(p)
 				if p.HasError() {
 					goto errorExit
@@ -3177,18 +3208,18 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 				_la = p.GetTokenStream().LA(1)
 			}
 			{
-				p.SetState(318)
+				p.SetState(324)
 				p.GroupingPropertyStmt()
 			}
 
-			p.SetState(323)
+			p.SetState(329)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
 			}
 			_la = p.GetTokenStream().LA(1)
 		}
-		p.SetState(327)
+		p.SetState(333)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -3197,7 +3228,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(324)
+				p.SetState(330)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -3205,7 +3236,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 				}
 			}
 
-			p.SetState(329)
+			p.SetState(335)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -3213,7 +3244,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(330)
+			p.SetState(336)
 			p.Match(JetRuleParserT__4)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3224,7 +3255,7 @@ func (p *JetRuleParser) ClassStmt() (localctx IClassStmtContext) {
 	case JetRuleParserAsTable:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(332)
+			p.SetState(338)
 			p.AsTableStmt()
 		}
 
@@ -3344,7 +3375,7 @@ func (p *JetRuleParser) SubClassOfStmt() (localctx ISubClassOfStmtContext) {
 	p.EnterRule(localctx, 18, JetRuleParserRULE_subClassOfStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(335)
+		p.SetState(341)
 
 		var _x = p.DeclIdentifier()
 
@@ -3508,21 +3539,21 @@ func (p *JetRuleParser) DataPropertyDefinitions() (localctx IDataPropertyDefinit
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(337)
+		p.SetState(343)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*DataPropertyDefinitionsContext).dataPName = _x
 	}
 	{
-		p.SetState(338)
+		p.SetState(344)
 		p.Match(JetRuleParserT__5)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(340)
+	p.SetState(346)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -3531,7 +3562,7 @@ func (p *JetRuleParser) DataPropertyDefinitions() (localctx IDataPropertyDefinit
 
 	if _la == JetRuleParserARRAY {
 		{
-			p.SetState(339)
+			p.SetState(345)
 
 			var _m = p.Match(JetRuleParserARRAY)
 
@@ -3544,7 +3575,7 @@ func (p *JetRuleParser) DataPropertyDefinitions() (localctx IDataPropertyDefinit
 
 	}
 	{
-		p.SetState(342)
+		p.SetState(348)
 
 		var _x = p.DataPropertyType()
 
@@ -3686,7 +3717,7 @@ func (p *JetRuleParser) DataPropertyType() (localctx IDataPropertyTypeContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(344)
+		p.SetState(350)
 		_la = p.GetTokenStream().LA(1)
 
 		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&274609471488) != 0) {
@@ -3812,7 +3843,7 @@ func (p *JetRuleParser) GroupingPropertyStmt() (localctx IGroupingPropertyStmtCo
 	p.EnterRule(localctx, 24, JetRuleParserRULE_groupingPropertyStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(346)
+		p.SetState(352)
 
 		var _x = p.DeclIdentifier()
 
@@ -3940,7 +3971,7 @@ func (p *JetRuleParser) AsTableStmt() (localctx IAsTableStmtContext) {
 	p.EnterRule(localctx, 26, JetRuleParserRULE_asTableStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(348)
+		p.SetState(354)
 		p.Match(JetRuleParserAsTable)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -3948,7 +3979,7 @@ func (p *JetRuleParser) AsTableStmt() (localctx IAsTableStmtContext) {
 		}
 	}
 	{
-		p.SetState(349)
+		p.SetState(355)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -3956,7 +3987,7 @@ func (p *JetRuleParser) AsTableStmt() (localctx IAsTableStmtContext) {
 		}
 	}
 	{
-		p.SetState(350)
+		p.SetState(356)
 
 		var _x = p.AsTableFlag()
 
@@ -4058,7 +4089,7 @@ func (p *JetRuleParser) AsTableFlag() (localctx IAsTableFlagContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(352)
+		p.SetState(358)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == JetRuleParserTRUE || _la == JetRuleParserFALSE) {
@@ -4217,7 +4248,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(354)
+		p.SetState(360)
 		p.Match(JetRuleParserRULESEQ)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -4225,7 +4256,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 		}
 	}
 	{
-		p.SetState(355)
+		p.SetState(361)
 
 		var _m = p.Match(JetRuleParserIdentifier)
 
@@ -4236,14 +4267,14 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 		}
 	}
 	{
-		p.SetState(356)
+		p.SetState(362)
 		p.Match(JetRuleParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(360)
+	p.SetState(366)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -4252,7 +4283,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(357)
+			p.SetState(363)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4260,7 +4291,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 			}
 		}
 
-		p.SetState(362)
+		p.SetState(368)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -4268,7 +4299,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(363)
+		p.SetState(369)
 		p.Match(JetRuleParserMainRuleSets)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -4276,7 +4307,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 		}
 	}
 	{
-		p.SetState(364)
+		p.SetState(370)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -4284,14 +4315,14 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 		}
 	}
 	{
-		p.SetState(365)
+		p.SetState(371)
 		p.Match(JetRuleParserT__3)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(369)
+	p.SetState(375)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -4300,7 +4331,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(366)
+			p.SetState(372)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4308,7 +4339,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 			}
 		}
 
-		p.SetState(371)
+		p.SetState(377)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -4316,10 +4347,10 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(372)
+		p.SetState(378)
 		p.RuleSetSeq()
 	}
-	p.SetState(376)
+	p.SetState(382)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -4328,7 +4359,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(373)
+			p.SetState(379)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4336,7 +4367,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 			}
 		}
 
-		p.SetState(378)
+		p.SetState(384)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -4344,14 +4375,14 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(379)
+		p.SetState(385)
 		p.Match(JetRuleParserT__4)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(381)
+	p.SetState(387)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -4360,7 +4391,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 
 	if _la == JetRuleParserT__2 {
 		{
-			p.SetState(380)
+			p.SetState(386)
 			p.Match(JetRuleParserT__2)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4369,7 +4400,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 		}
 
 	}
-	p.SetState(386)
+	p.SetState(392)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -4378,7 +4409,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(383)
+			p.SetState(389)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4386,7 +4417,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 			}
 		}
 
-		p.SetState(388)
+		p.SetState(394)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -4394,7 +4425,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(389)
+		p.SetState(395)
 		p.Match(JetRuleParserT__1)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -4402,7 +4433,7 @@ func (p *JetRuleParser) DefineRuleSeqStmt() (localctx IDefineRuleSeqStmtContext)
 		}
 	}
 	{
-		p.SetState(390)
+		p.SetState(396)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -4548,10 +4579,10 @@ func (p *JetRuleParser) RuleSetSeq() (localctx IRuleSetSeqContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(392)
+		p.SetState(398)
 		p.RuleSetDefinitions()
 	}
-	p.SetState(403)
+	p.SetState(409)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -4560,14 +4591,14 @@ func (p *JetRuleParser) RuleSetSeq() (localctx IRuleSetSeqContext) {
 
 	for _la == JetRuleParserT__2 {
 		{
-			p.SetState(393)
+			p.SetState(399)
 			p.Match(JetRuleParserT__2)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(397)
+		p.SetState(403)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -4576,7 +4607,7 @@ func (p *JetRuleParser) RuleSetSeq() (localctx IRuleSetSeqContext) {
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(394)
+				p.SetState(400)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -4584,7 +4615,7 @@ func (p *JetRuleParser) RuleSetSeq() (localctx IRuleSetSeqContext) {
 				}
 			}
 
-			p.SetState(399)
+			p.SetState(405)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -4592,11 +4623,11 @@ func (p *JetRuleParser) RuleSetSeq() (localctx IRuleSetSeqContext) {
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(400)
+			p.SetState(406)
 			p.RuleSetDefinitions()
 		}
 
-		p.SetState(405)
+		p.SetState(411)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -4703,7 +4734,7 @@ func (p *JetRuleParser) RuleSetDefinitions() (localctx IRuleSetDefinitionsContex
 	p.EnterRule(localctx, 34, JetRuleParserRULE_ruleSetDefinitions)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(406)
+		p.SetState(412)
 
 		var _m = p.Match(JetRuleParserSTRING)
 
@@ -4948,7 +4979,7 @@ func (s *DefineLiteralStmtContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *JetRuleParser) DefineLiteralStmt() (localctx IDefineLiteralStmtContext) {
 	localctx = NewDefineLiteralStmtContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 36, JetRuleParserRULE_defineLiteralStmt)
-	p.SetState(417)
+	p.SetState(423)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -4958,63 +4989,63 @@ func (p *JetRuleParser) DefineLiteralStmt() (localctx IDefineLiteralStmtContext)
 	case JetRuleParserInt32Type:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(408)
+			p.SetState(414)
 			p.Int32LiteralStmt()
 		}
 
 	case JetRuleParserUInt32Type:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(409)
+			p.SetState(415)
 			p.UInt32LiteralStmt()
 		}
 
 	case JetRuleParserInt64Type:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(410)
+			p.SetState(416)
 			p.Int64LiteralStmt()
 		}
 
 	case JetRuleParserUInt64Type:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(411)
+			p.SetState(417)
 			p.UInt64LiteralStmt()
 		}
 
 	case JetRuleParserDoubleType:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(412)
+			p.SetState(418)
 			p.DoubleLiteralStmt()
 		}
 
 	case JetRuleParserStringType:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(413)
+			p.SetState(419)
 			p.StringLiteralStmt()
 		}
 
 	case JetRuleParserDateType:
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(414)
+			p.SetState(420)
 			p.DateLiteralStmt()
 		}
 
 	case JetRuleParserDatetimeType:
 		p.EnterOuterAlt(localctx, 8)
 		{
-			p.SetState(415)
+			p.SetState(421)
 			p.DatetimeLiteralStmt()
 		}
 
 	case JetRuleParserBoolType:
 		p.EnterOuterAlt(localctx, 9)
 		{
-			p.SetState(416)
+			p.SetState(422)
 			p.BooleanLiteralStmt()
 		}
 
@@ -5188,7 +5219,7 @@ func (p *JetRuleParser) Int32LiteralStmt() (localctx IInt32LiteralStmtContext) {
 	p.EnterRule(localctx, 38, JetRuleParserRULE_int32LiteralStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(419)
+		p.SetState(425)
 
 		var _m = p.Match(JetRuleParserInt32Type)
 
@@ -5199,14 +5230,14 @@ func (p *JetRuleParser) Int32LiteralStmt() (localctx IInt32LiteralStmtContext) {
 		}
 	}
 	{
-		p.SetState(420)
+		p.SetState(426)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*Int32LiteralStmtContext).varName = _x
 	}
 	{
-		p.SetState(421)
+		p.SetState(427)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -5214,14 +5245,14 @@ func (p *JetRuleParser) Int32LiteralStmt() (localctx IInt32LiteralStmtContext) {
 		}
 	}
 	{
-		p.SetState(422)
+		p.SetState(428)
 
 		var _x = p.IntExpr()
 
 		localctx.(*Int32LiteralStmtContext).declValue = _x
 	}
 	{
-		p.SetState(423)
+		p.SetState(429)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -5394,7 +5425,7 @@ func (p *JetRuleParser) UInt32LiteralStmt() (localctx IUInt32LiteralStmtContext)
 	p.EnterRule(localctx, 40, JetRuleParserRULE_uInt32LiteralStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(425)
+		p.SetState(431)
 
 		var _m = p.Match(JetRuleParserUInt32Type)
 
@@ -5405,14 +5436,14 @@ func (p *JetRuleParser) UInt32LiteralStmt() (localctx IUInt32LiteralStmtContext)
 		}
 	}
 	{
-		p.SetState(426)
+		p.SetState(432)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*UInt32LiteralStmtContext).varName = _x
 	}
 	{
-		p.SetState(427)
+		p.SetState(433)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -5420,14 +5451,14 @@ func (p *JetRuleParser) UInt32LiteralStmt() (localctx IUInt32LiteralStmtContext)
 		}
 	}
 	{
-		p.SetState(428)
+		p.SetState(434)
 
 		var _x = p.UintExpr()
 
 		localctx.(*UInt32LiteralStmtContext).declValue = _x
 	}
 	{
-		p.SetState(429)
+		p.SetState(435)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -5600,7 +5631,7 @@ func (p *JetRuleParser) Int64LiteralStmt() (localctx IInt64LiteralStmtContext) {
 	p.EnterRule(localctx, 42, JetRuleParserRULE_int64LiteralStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(431)
+		p.SetState(437)
 
 		var _m = p.Match(JetRuleParserInt64Type)
 
@@ -5611,14 +5642,14 @@ func (p *JetRuleParser) Int64LiteralStmt() (localctx IInt64LiteralStmtContext) {
 		}
 	}
 	{
-		p.SetState(432)
+		p.SetState(438)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*Int64LiteralStmtContext).varName = _x
 	}
 	{
-		p.SetState(433)
+		p.SetState(439)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -5626,14 +5657,14 @@ func (p *JetRuleParser) Int64LiteralStmt() (localctx IInt64LiteralStmtContext) {
 		}
 	}
 	{
-		p.SetState(434)
+		p.SetState(440)
 
 		var _x = p.IntExpr()
 
 		localctx.(*Int64LiteralStmtContext).declValue = _x
 	}
 	{
-		p.SetState(435)
+		p.SetState(441)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -5806,7 +5837,7 @@ func (p *JetRuleParser) UInt64LiteralStmt() (localctx IUInt64LiteralStmtContext)
 	p.EnterRule(localctx, 44, JetRuleParserRULE_uInt64LiteralStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(437)
+		p.SetState(443)
 
 		var _m = p.Match(JetRuleParserUInt64Type)
 
@@ -5817,14 +5848,14 @@ func (p *JetRuleParser) UInt64LiteralStmt() (localctx IUInt64LiteralStmtContext)
 		}
 	}
 	{
-		p.SetState(438)
+		p.SetState(444)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*UInt64LiteralStmtContext).varName = _x
 	}
 	{
-		p.SetState(439)
+		p.SetState(445)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -5832,14 +5863,14 @@ func (p *JetRuleParser) UInt64LiteralStmt() (localctx IUInt64LiteralStmtContext)
 		}
 	}
 	{
-		p.SetState(440)
+		p.SetState(446)
 
 		var _x = p.UintExpr()
 
 		localctx.(*UInt64LiteralStmtContext).declValue = _x
 	}
 	{
-		p.SetState(441)
+		p.SetState(447)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -6012,7 +6043,7 @@ func (p *JetRuleParser) DoubleLiteralStmt() (localctx IDoubleLiteralStmtContext)
 	p.EnterRule(localctx, 46, JetRuleParserRULE_doubleLiteralStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(443)
+		p.SetState(449)
 
 		var _m = p.Match(JetRuleParserDoubleType)
 
@@ -6023,14 +6054,14 @@ func (p *JetRuleParser) DoubleLiteralStmt() (localctx IDoubleLiteralStmtContext)
 		}
 	}
 	{
-		p.SetState(444)
+		p.SetState(450)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*DoubleLiteralStmtContext).varName = _x
 	}
 	{
-		p.SetState(445)
+		p.SetState(451)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -6038,14 +6069,14 @@ func (p *JetRuleParser) DoubleLiteralStmt() (localctx IDoubleLiteralStmtContext)
 		}
 	}
 	{
-		p.SetState(446)
+		p.SetState(452)
 
 		var _x = p.DoubleExpr()
 
 		localctx.(*DoubleLiteralStmtContext).declValue = _x
 	}
 	{
-		p.SetState(447)
+		p.SetState(453)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -6206,7 +6237,7 @@ func (p *JetRuleParser) StringLiteralStmt() (localctx IStringLiteralStmtContext)
 	p.EnterRule(localctx, 48, JetRuleParserRULE_stringLiteralStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(449)
+		p.SetState(455)
 
 		var _m = p.Match(JetRuleParserStringType)
 
@@ -6217,14 +6248,14 @@ func (p *JetRuleParser) StringLiteralStmt() (localctx IStringLiteralStmtContext)
 		}
 	}
 	{
-		p.SetState(450)
+		p.SetState(456)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*StringLiteralStmtContext).varName = _x
 	}
 	{
-		p.SetState(451)
+		p.SetState(457)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -6232,7 +6263,7 @@ func (p *JetRuleParser) StringLiteralStmt() (localctx IStringLiteralStmtContext)
 		}
 	}
 	{
-		p.SetState(452)
+		p.SetState(458)
 
 		var _m = p.Match(JetRuleParserSTRING)
 
@@ -6243,7 +6274,7 @@ func (p *JetRuleParser) StringLiteralStmt() (localctx IStringLiteralStmtContext)
 		}
 	}
 	{
-		p.SetState(453)
+		p.SetState(459)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -6404,7 +6435,7 @@ func (p *JetRuleParser) DateLiteralStmt() (localctx IDateLiteralStmtContext) {
 	p.EnterRule(localctx, 50, JetRuleParserRULE_dateLiteralStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(455)
+		p.SetState(461)
 
 		var _m = p.Match(JetRuleParserDateType)
 
@@ -6415,14 +6446,14 @@ func (p *JetRuleParser) DateLiteralStmt() (localctx IDateLiteralStmtContext) {
 		}
 	}
 	{
-		p.SetState(456)
+		p.SetState(462)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*DateLiteralStmtContext).varName = _x
 	}
 	{
-		p.SetState(457)
+		p.SetState(463)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -6430,7 +6461,7 @@ func (p *JetRuleParser) DateLiteralStmt() (localctx IDateLiteralStmtContext) {
 		}
 	}
 	{
-		p.SetState(458)
+		p.SetState(464)
 
 		var _m = p.Match(JetRuleParserSTRING)
 
@@ -6441,7 +6472,7 @@ func (p *JetRuleParser) DateLiteralStmt() (localctx IDateLiteralStmtContext) {
 		}
 	}
 	{
-		p.SetState(459)
+		p.SetState(465)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -6602,7 +6633,7 @@ func (p *JetRuleParser) DatetimeLiteralStmt() (localctx IDatetimeLiteralStmtCont
 	p.EnterRule(localctx, 52, JetRuleParserRULE_datetimeLiteralStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(461)
+		p.SetState(467)
 
 		var _m = p.Match(JetRuleParserDatetimeType)
 
@@ -6613,14 +6644,14 @@ func (p *JetRuleParser) DatetimeLiteralStmt() (localctx IDatetimeLiteralStmtCont
 		}
 	}
 	{
-		p.SetState(462)
+		p.SetState(468)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*DatetimeLiteralStmtContext).varName = _x
 	}
 	{
-		p.SetState(463)
+		p.SetState(469)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -6628,7 +6659,7 @@ func (p *JetRuleParser) DatetimeLiteralStmt() (localctx IDatetimeLiteralStmtCont
 		}
 	}
 	{
-		p.SetState(464)
+		p.SetState(470)
 
 		var _m = p.Match(JetRuleParserSTRING)
 
@@ -6639,7 +6670,7 @@ func (p *JetRuleParser) DatetimeLiteralStmt() (localctx IDatetimeLiteralStmtCont
 		}
 	}
 	{
-		p.SetState(465)
+		p.SetState(471)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -6800,7 +6831,7 @@ func (p *JetRuleParser) BooleanLiteralStmt() (localctx IBooleanLiteralStmtContex
 	p.EnterRule(localctx, 54, JetRuleParserRULE_booleanLiteralStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(467)
+		p.SetState(473)
 
 		var _m = p.Match(JetRuleParserBoolType)
 
@@ -6811,14 +6842,14 @@ func (p *JetRuleParser) BooleanLiteralStmt() (localctx IBooleanLiteralStmtContex
 		}
 	}
 	{
-		p.SetState(468)
+		p.SetState(474)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*BooleanLiteralStmtContext).varName = _x
 	}
 	{
-		p.SetState(469)
+		p.SetState(475)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -6826,7 +6857,7 @@ func (p *JetRuleParser) BooleanLiteralStmt() (localctx IBooleanLiteralStmtContex
 		}
 	}
 	{
-		p.SetState(470)
+		p.SetState(476)
 
 		var _m = p.Match(JetRuleParserSTRING)
 
@@ -6837,7 +6868,7 @@ func (p *JetRuleParser) BooleanLiteralStmt() (localctx IBooleanLiteralStmtContex
 		}
 	}
 	{
-		p.SetState(471)
+		p.SetState(477)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -6958,7 +6989,7 @@ func (s *IntExprContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *JetRuleParser) IntExpr() (localctx IIntExprContext) {
 	localctx = NewIntExprContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 56, JetRuleParserRULE_intExpr)
-	p.SetState(478)
+	p.SetState(484)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -6968,7 +6999,7 @@ func (p *JetRuleParser) IntExpr() (localctx IIntExprContext) {
 	case JetRuleParserPLUS:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(473)
+			p.SetState(479)
 			p.Match(JetRuleParserPLUS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -6976,14 +7007,14 @@ func (p *JetRuleParser) IntExpr() (localctx IIntExprContext) {
 			}
 		}
 		{
-			p.SetState(474)
+			p.SetState(480)
 			p.IntExpr()
 		}
 
 	case JetRuleParserMINUS:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(475)
+			p.SetState(481)
 			p.Match(JetRuleParserMINUS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -6991,14 +7022,14 @@ func (p *JetRuleParser) IntExpr() (localctx IIntExprContext) {
 			}
 		}
 		{
-			p.SetState(476)
+			p.SetState(482)
 			p.IntExpr()
 		}
 
 	case JetRuleParserDIGITS:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(477)
+			p.SetState(483)
 			p.Match(JetRuleParserDIGITS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -7119,7 +7150,7 @@ func (s *UintExprContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *JetRuleParser) UintExpr() (localctx IUintExprContext) {
 	localctx = NewUintExprContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 58, JetRuleParserRULE_uintExpr)
-	p.SetState(483)
+	p.SetState(489)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -7129,7 +7160,7 @@ func (p *JetRuleParser) UintExpr() (localctx IUintExprContext) {
 	case JetRuleParserPLUS:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(480)
+			p.SetState(486)
 			p.Match(JetRuleParserPLUS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -7137,14 +7168,14 @@ func (p *JetRuleParser) UintExpr() (localctx IUintExprContext) {
 			}
 		}
 		{
-			p.SetState(481)
+			p.SetState(487)
 			p.UintExpr()
 		}
 
 	case JetRuleParserDIGITS:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(482)
+			p.SetState(488)
 			p.Match(JetRuleParserDIGITS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -7275,7 +7306,7 @@ func (s *DoubleExprContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *JetRuleParser) DoubleExpr() (localctx IDoubleExprContext) {
 	localctx = NewDoubleExprContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 60, JetRuleParserRULE_doubleExpr)
-	p.SetState(494)
+	p.SetState(500)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -7285,7 +7316,7 @@ func (p *JetRuleParser) DoubleExpr() (localctx IDoubleExprContext) {
 	case JetRuleParserPLUS:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(485)
+			p.SetState(491)
 			p.Match(JetRuleParserPLUS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -7293,14 +7324,14 @@ func (p *JetRuleParser) DoubleExpr() (localctx IDoubleExprContext) {
 			}
 		}
 		{
-			p.SetState(486)
+			p.SetState(492)
 			p.DoubleExpr()
 		}
 
 	case JetRuleParserMINUS:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(487)
+			p.SetState(493)
 			p.Match(JetRuleParserMINUS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -7308,26 +7339,26 @@ func (p *JetRuleParser) DoubleExpr() (localctx IDoubleExprContext) {
 			}
 		}
 		{
-			p.SetState(488)
+			p.SetState(494)
 			p.DoubleExpr()
 		}
 
 	case JetRuleParserDIGITS:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(489)
+			p.SetState(495)
 			p.Match(JetRuleParserDIGITS)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(492)
+		p.SetState(498)
 		p.GetErrorHandler().Sync(p)
 
 		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 39, p.GetParserRuleContext()) == 1 {
 			{
-				p.SetState(490)
+				p.SetState(496)
 				p.Match(JetRuleParserT__6)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -7335,7 +7366,7 @@ func (p *JetRuleParser) DoubleExpr() (localctx IDoubleExprContext) {
 				}
 			}
 			{
-				p.SetState(491)
+				p.SetState(497)
 				p.Match(JetRuleParserDIGITS)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -7448,7 +7479,7 @@ func (s *DeclIdentifierContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *JetRuleParser) DeclIdentifier() (localctx IDeclIdentifierContext) {
 	localctx = NewDeclIdentifierContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 62, JetRuleParserRULE_declIdentifier)
-	p.SetState(503)
+	p.SetState(509)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -7458,7 +7489,7 @@ func (p *JetRuleParser) DeclIdentifier() (localctx IDeclIdentifierContext) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(496)
+			p.SetState(502)
 			p.Match(JetRuleParserIdentifier)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -7466,7 +7497,7 @@ func (p *JetRuleParser) DeclIdentifier() (localctx IDeclIdentifierContext) {
 			}
 		}
 		{
-			p.SetState(497)
+			p.SetState(503)
 			p.Match(JetRuleParserT__7)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -7474,7 +7505,7 @@ func (p *JetRuleParser) DeclIdentifier() (localctx IDeclIdentifierContext) {
 			}
 		}
 		{
-			p.SetState(498)
+			p.SetState(504)
 			p.Match(JetRuleParserIdentifier)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -7485,7 +7516,7 @@ func (p *JetRuleParser) DeclIdentifier() (localctx IDeclIdentifierContext) {
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(499)
+			p.SetState(505)
 			p.Match(JetRuleParserIdentifier)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -7493,7 +7524,7 @@ func (p *JetRuleParser) DeclIdentifier() (localctx IDeclIdentifierContext) {
 			}
 		}
 		{
-			p.SetState(500)
+			p.SetState(506)
 			p.Match(JetRuleParserT__7)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -7501,7 +7532,7 @@ func (p *JetRuleParser) DeclIdentifier() (localctx IDeclIdentifierContext) {
 			}
 		}
 		{
-			p.SetState(501)
+			p.SetState(507)
 			p.Match(JetRuleParserSTRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -7512,7 +7543,7 @@ func (p *JetRuleParser) DeclIdentifier() (localctx IDeclIdentifierContext) {
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(502)
+			p.SetState(508)
 			p.Match(JetRuleParserIdentifier)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -7639,7 +7670,7 @@ func (s *DefineResourceStmtContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *JetRuleParser) DefineResourceStmt() (localctx IDefineResourceStmtContext) {
 	localctx = NewDefineResourceStmtContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 64, JetRuleParserRULE_defineResourceStmt)
-	p.SetState(507)
+	p.SetState(513)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -7649,14 +7680,14 @@ func (p *JetRuleParser) DefineResourceStmt() (localctx IDefineResourceStmtContex
 	case JetRuleParserResourceType:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(505)
+			p.SetState(511)
 			p.NamedResourceStmt()
 		}
 
 	case JetRuleParserVolatileResourceType:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(506)
+			p.SetState(512)
 			p.VolatileResourceStmt()
 		}
 
@@ -7819,7 +7850,7 @@ func (p *JetRuleParser) NamedResourceStmt() (localctx INamedResourceStmtContext)
 	p.EnterRule(localctx, 66, JetRuleParserRULE_namedResourceStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(509)
+		p.SetState(515)
 		p.Match(JetRuleParserResourceType)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -7827,14 +7858,14 @@ func (p *JetRuleParser) NamedResourceStmt() (localctx INamedResourceStmtContext)
 		}
 	}
 	{
-		p.SetState(510)
+		p.SetState(516)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*NamedResourceStmtContext).resName = _x
 	}
 	{
-		p.SetState(511)
+		p.SetState(517)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -7842,14 +7873,14 @@ func (p *JetRuleParser) NamedResourceStmt() (localctx INamedResourceStmtContext)
 		}
 	}
 	{
-		p.SetState(512)
+		p.SetState(518)
 
 		var _x = p.ResourceValue()
 
 		localctx.(*NamedResourceStmtContext).resCtx = _x
 	}
 	{
-		p.SetState(513)
+		p.SetState(519)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -8010,7 +8041,7 @@ func (p *JetRuleParser) VolatileResourceStmt() (localctx IVolatileResourceStmtCo
 	p.EnterRule(localctx, 68, JetRuleParserRULE_volatileResourceStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(515)
+		p.SetState(521)
 
 		var _m = p.Match(JetRuleParserVolatileResourceType)
 
@@ -8021,14 +8052,14 @@ func (p *JetRuleParser) VolatileResourceStmt() (localctx IVolatileResourceStmtCo
 		}
 	}
 	{
-		p.SetState(516)
+		p.SetState(522)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*VolatileResourceStmtContext).resName = _x
 	}
 	{
-		p.SetState(517)
+		p.SetState(523)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -8036,7 +8067,7 @@ func (p *JetRuleParser) VolatileResourceStmt() (localctx IVolatileResourceStmtCo
 		}
 	}
 	{
-		p.SetState(518)
+		p.SetState(524)
 
 		var _m = p.Match(JetRuleParserSTRING)
 
@@ -8047,7 +8078,7 @@ func (p *JetRuleParser) VolatileResourceStmt() (localctx IVolatileResourceStmtCo
 		}
 	}
 	{
-		p.SetState(519)
+		p.SetState(525)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -8185,7 +8216,7 @@ func (s *ResourceValueContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *JetRuleParser) ResourceValue() (localctx IResourceValueContext) {
 	localctx = NewResourceValueContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 70, JetRuleParserRULE_resourceValue)
-	p.SetState(524)
+	p.SetState(530)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -8195,7 +8226,7 @@ func (p *JetRuleParser) ResourceValue() (localctx IResourceValueContext) {
 	case JetRuleParserTRUE, JetRuleParserFALSE, JetRuleParserNULL:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(521)
+			p.SetState(527)
 
 			var _x = p.Keywords()
 
@@ -8205,7 +8236,7 @@ func (p *JetRuleParser) ResourceValue() (localctx IResourceValueContext) {
 	case JetRuleParserCreateUUIDResource:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(522)
+			p.SetState(528)
 
 			var _m = p.Match(JetRuleParserCreateUUIDResource)
 
@@ -8219,7 +8250,7 @@ func (p *JetRuleParser) ResourceValue() (localctx IResourceValueContext) {
 	case JetRuleParserSTRING:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(523)
+			p.SetState(529)
 
 			var _m = p.Match(JetRuleParserSTRING)
 
@@ -8450,7 +8481,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(526)
+		p.SetState(532)
 		p.Match(JetRuleParserLookupTable)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -8458,21 +8489,21 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		}
 	}
 	{
-		p.SetState(527)
+		p.SetState(533)
 
 		var _x = p.DeclIdentifier()
 
 		localctx.(*LookupTableStmtContext).lookupName = _x
 	}
 	{
-		p.SetState(528)
+		p.SetState(534)
 		p.Match(JetRuleParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(532)
+	p.SetState(538)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -8481,7 +8512,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(529)
+			p.SetState(535)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -8489,7 +8520,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 			}
 		}
 
-		p.SetState(534)
+		p.SetState(540)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -8497,10 +8528,10 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(535)
+		p.SetState(541)
 		p.CsvLocation()
 	}
-	p.SetState(539)
+	p.SetState(545)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -8509,7 +8540,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(536)
+			p.SetState(542)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -8517,7 +8548,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 			}
 		}
 
-		p.SetState(541)
+		p.SetState(547)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -8525,7 +8556,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(542)
+		p.SetState(548)
 		p.Match(JetRuleParserKey)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -8533,7 +8564,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		}
 	}
 	{
-		p.SetState(543)
+		p.SetState(549)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -8541,21 +8572,21 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		}
 	}
 	{
-		p.SetState(544)
+		p.SetState(550)
 
 		var _x = p.StringList()
 
 		localctx.(*LookupTableStmtContext).tblKeys = _x
 	}
 	{
-		p.SetState(545)
+		p.SetState(551)
 		p.Match(JetRuleParserT__2)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(549)
+	p.SetState(555)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -8564,7 +8595,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(546)
+			p.SetState(552)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -8572,7 +8603,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 			}
 		}
 
-		p.SetState(551)
+		p.SetState(557)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -8580,7 +8611,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(552)
+		p.SetState(558)
 		p.Match(JetRuleParserColumns)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -8588,7 +8619,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		}
 	}
 	{
-		p.SetState(553)
+		p.SetState(559)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -8596,14 +8627,14 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		}
 	}
 	{
-		p.SetState(554)
+		p.SetState(560)
 		p.Match(JetRuleParserT__3)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(558)
+	p.SetState(564)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -8612,7 +8643,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(555)
+			p.SetState(561)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -8620,7 +8651,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 			}
 		}
 
-		p.SetState(560)
+		p.SetState(566)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -8628,10 +8659,10 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(561)
+		p.SetState(567)
 		p.ColumnDefSeq()
 	}
-	p.SetState(565)
+	p.SetState(571)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -8640,7 +8671,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(562)
+			p.SetState(568)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -8648,7 +8679,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 			}
 		}
 
-		p.SetState(567)
+		p.SetState(573)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -8656,14 +8687,14 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(568)
+		p.SetState(574)
 		p.Match(JetRuleParserT__4)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(570)
+	p.SetState(576)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -8672,7 +8703,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 
 	if _la == JetRuleParserT__2 {
 		{
-			p.SetState(569)
+			p.SetState(575)
 			p.Match(JetRuleParserT__2)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -8681,7 +8712,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		}
 
 	}
-	p.SetState(575)
+	p.SetState(581)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -8690,7 +8721,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(572)
+			p.SetState(578)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -8698,7 +8729,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 			}
 		}
 
-		p.SetState(577)
+		p.SetState(583)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -8706,7 +8737,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(578)
+		p.SetState(584)
 		p.Match(JetRuleParserT__1)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -8714,7 +8745,7 @@ func (p *JetRuleParser) LookupTableStmt() (localctx ILookupTableStmtContext) {
 		}
 	}
 	{
-		p.SetState(579)
+		p.SetState(585)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -8862,7 +8893,7 @@ func (s *CsvLocationContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *JetRuleParser) CsvLocation() (localctx ICsvLocationContext) {
 	localctx = NewCsvLocationContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 74, JetRuleParserRULE_csvLocation)
-	p.SetState(590)
+	p.SetState(596)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -8872,7 +8903,7 @@ func (p *JetRuleParser) CsvLocation() (localctx ICsvLocationContext) {
 	case JetRuleParserTableName:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(581)
+			p.SetState(587)
 			p.Match(JetRuleParserTableName)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -8880,7 +8911,7 @@ func (p *JetRuleParser) CsvLocation() (localctx ICsvLocationContext) {
 			}
 		}
 		{
-			p.SetState(582)
+			p.SetState(588)
 			p.Match(JetRuleParserASSIGN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -8888,14 +8919,14 @@ func (p *JetRuleParser) CsvLocation() (localctx ICsvLocationContext) {
 			}
 		}
 		{
-			p.SetState(583)
+			p.SetState(589)
 
 			var _x = p.DeclIdentifier()
 
 			localctx.(*CsvLocationContext).tblStorageName = _x
 		}
 		{
-			p.SetState(584)
+			p.SetState(590)
 			p.Match(JetRuleParserT__2)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -8906,7 +8937,7 @@ func (p *JetRuleParser) CsvLocation() (localctx ICsvLocationContext) {
 	case JetRuleParserCSVFileName:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(586)
+			p.SetState(592)
 			p.Match(JetRuleParserCSVFileName)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -8914,7 +8945,7 @@ func (p *JetRuleParser) CsvLocation() (localctx ICsvLocationContext) {
 			}
 		}
 		{
-			p.SetState(587)
+			p.SetState(593)
 			p.Match(JetRuleParserASSIGN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -8922,7 +8953,7 @@ func (p *JetRuleParser) CsvLocation() (localctx ICsvLocationContext) {
 			}
 		}
 		{
-			p.SetState(588)
+			p.SetState(594)
 
 			var _m = p.Match(JetRuleParserSTRING)
 
@@ -8933,7 +8964,7 @@ func (p *JetRuleParser) CsvLocation() (localctx ICsvLocationContext) {
 			}
 		}
 		{
-			p.SetState(589)
+			p.SetState(595)
 			p.Match(JetRuleParserT__2)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -9059,14 +9090,14 @@ func (p *JetRuleParser) StringList() (localctx IStringListContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(592)
+		p.SetState(598)
 		p.Match(JetRuleParserT__3)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(594)
+	p.SetState(600)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -9075,7 +9106,7 @@ func (p *JetRuleParser) StringList() (localctx IStringListContext) {
 
 	if _la == JetRuleParserSTRING {
 		{
-			p.SetState(593)
+			p.SetState(599)
 
 			var _x = p.StringSeq()
 
@@ -9084,7 +9115,7 @@ func (p *JetRuleParser) StringList() (localctx IStringListContext) {
 
 	}
 	{
-		p.SetState(596)
+		p.SetState(602)
 		p.Match(JetRuleParserT__4)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -9209,7 +9240,7 @@ func (p *JetRuleParser) StringSeq() (localctx IStringSeqContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(598)
+		p.SetState(604)
 
 		var _m = p.Match(JetRuleParserSTRING)
 
@@ -9220,7 +9251,7 @@ func (p *JetRuleParser) StringSeq() (localctx IStringSeqContext) {
 		}
 	}
 	localctx.(*StringSeqContext).slist = append(localctx.(*StringSeqContext).slist, localctx.(*StringSeqContext)._STRING)
-	p.SetState(603)
+	p.SetState(609)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -9229,7 +9260,7 @@ func (p *JetRuleParser) StringSeq() (localctx IStringSeqContext) {
 
 	for _la == JetRuleParserT__2 {
 		{
-			p.SetState(599)
+			p.SetState(605)
 			p.Match(JetRuleParserT__2)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -9237,7 +9268,7 @@ func (p *JetRuleParser) StringSeq() (localctx IStringSeqContext) {
 			}
 		}
 		{
-			p.SetState(600)
+			p.SetState(606)
 
 			var _m = p.Match(JetRuleParserSTRING)
 
@@ -9249,7 +9280,7 @@ func (p *JetRuleParser) StringSeq() (localctx IStringSeqContext) {
 		}
 		localctx.(*StringSeqContext).slist = append(localctx.(*StringSeqContext).slist, localctx.(*StringSeqContext)._STRING)
 
-		p.SetState(605)
+		p.SetState(611)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -9395,10 +9426,10 @@ func (p *JetRuleParser) ColumnDefSeq() (localctx IColumnDefSeqContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(606)
+		p.SetState(612)
 		p.ColumnDefinitions()
 	}
-	p.SetState(617)
+	p.SetState(623)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -9407,14 +9438,14 @@ func (p *JetRuleParser) ColumnDefSeq() (localctx IColumnDefSeqContext) {
 
 	for _la == JetRuleParserT__2 {
 		{
-			p.SetState(607)
+			p.SetState(613)
 			p.Match(JetRuleParserT__2)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(611)
+		p.SetState(617)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -9423,7 +9454,7 @@ func (p *JetRuleParser) ColumnDefSeq() (localctx IColumnDefSeqContext) {
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(608)
+				p.SetState(614)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -9431,7 +9462,7 @@ func (p *JetRuleParser) ColumnDefSeq() (localctx IColumnDefSeqContext) {
 				}
 			}
 
-			p.SetState(613)
+			p.SetState(619)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -9439,11 +9470,11 @@ func (p *JetRuleParser) ColumnDefSeq() (localctx IColumnDefSeqContext) {
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(614)
+			p.SetState(620)
 			p.ColumnDefinitions()
 		}
 
-		p.SetState(619)
+		p.SetState(625)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -9596,7 +9627,7 @@ func (p *JetRuleParser) ColumnDefinitions() (localctx IColumnDefinitionsContext)
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(620)
+		p.SetState(626)
 
 		var _m = p.Match(JetRuleParserSTRING)
 
@@ -9607,14 +9638,14 @@ func (p *JetRuleParser) ColumnDefinitions() (localctx IColumnDefinitionsContext)
 		}
 	}
 	{
-		p.SetState(621)
+		p.SetState(627)
 		p.Match(JetRuleParserT__5)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(623)
+	p.SetState(629)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -9623,7 +9654,7 @@ func (p *JetRuleParser) ColumnDefinitions() (localctx IColumnDefinitionsContext)
 
 	if _la == JetRuleParserARRAY {
 		{
-			p.SetState(622)
+			p.SetState(628)
 
 			var _m = p.Match(JetRuleParserARRAY)
 
@@ -9636,7 +9667,7 @@ func (p *JetRuleParser) ColumnDefinitions() (localctx IColumnDefinitionsContext)
 
 	}
 	{
-		p.SetState(625)
+		p.SetState(631)
 
 		var _x = p.DataPropertyType()
 
@@ -9676,10 +9707,12 @@ type IJetRuleStmtContext interface {
 	RuleProperties(i int) IRulePropertiesContext
 	AllCOMMENT() []antlr.TerminalNode
 	COMMENT(i int) antlr.TerminalNode
-	AllAntecedent() []IAntecedentContext
-	Antecedent(i int) IAntecedentContext
 	AllConsequent() []IConsequentContext
 	Consequent(i int) IConsequentContext
+	AllAntecedent() []IAntecedentContext
+	Antecedent(i int) IAntecedentContext
+	AllOrAntecedent() []IOrAntecedentContext
+	OrAntecedent(i int) IOrAntecedentContext
 
 	// IsJetRuleStmtContext differentiates from other interfaces.
 	IsJetRuleStmtContext()
@@ -9779,6 +9812,47 @@ func (s *JetRuleStmtContext) COMMENT(i int) antlr.TerminalNode {
 	return s.GetToken(JetRuleParserCOMMENT, i)
 }
 
+func (s *JetRuleStmtContext) AllConsequent() []IConsequentContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IConsequentContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IConsequentContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IConsequentContext); ok {
+			tst[i] = t.(IConsequentContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *JetRuleStmtContext) Consequent(i int) IConsequentContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IConsequentContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IConsequentContext)
+}
+
 func (s *JetRuleStmtContext) AllAntecedent() []IAntecedentContext {
 	children := s.GetChildren()
 	len := 0
@@ -9820,20 +9894,20 @@ func (s *JetRuleStmtContext) Antecedent(i int) IAntecedentContext {
 	return t.(IAntecedentContext)
 }
 
-func (s *JetRuleStmtContext) AllConsequent() []IConsequentContext {
+func (s *JetRuleStmtContext) AllOrAntecedent() []IOrAntecedentContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
-		if _, ok := ctx.(IConsequentContext); ok {
+		if _, ok := ctx.(IOrAntecedentContext); ok {
 			len++
 		}
 	}
 
-	tst := make([]IConsequentContext, len)
+	tst := make([]IOrAntecedentContext, len)
 	i := 0
 	for _, ctx := range children {
-		if t, ok := ctx.(IConsequentContext); ok {
-			tst[i] = t.(IConsequentContext)
+		if t, ok := ctx.(IOrAntecedentContext); ok {
+			tst[i] = t.(IOrAntecedentContext)
 			i++
 		}
 	}
@@ -9841,11 +9915,11 @@ func (s *JetRuleStmtContext) AllConsequent() []IConsequentContext {
 	return tst
 }
 
-func (s *JetRuleStmtContext) Consequent(i int) IConsequentContext {
+func (s *JetRuleStmtContext) OrAntecedent(i int) IOrAntecedentContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IConsequentContext); ok {
+		if _, ok := ctx.(IOrAntecedentContext); ok {
 			if j == i {
 				t = ctx.(antlr.RuleContext)
 				break
@@ -9858,7 +9932,7 @@ func (s *JetRuleStmtContext) Consequent(i int) IConsequentContext {
 		return nil
 	}
 
-	return t.(IConsequentContext)
+	return t.(IOrAntecedentContext)
 }
 
 func (s *JetRuleStmtContext) GetRuleContext() antlr.RuleContext {
@@ -9888,7 +9962,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(627)
+		p.SetState(633)
 		p.Match(JetRuleParserT__3)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -9896,7 +9970,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 		}
 	}
 	{
-		p.SetState(628)
+		p.SetState(634)
 
 		var _m = p.Match(JetRuleParserIdentifier)
 
@@ -9906,7 +9980,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 			goto errorExit
 		}
 	}
-	p.SetState(632)
+	p.SetState(638)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -9915,11 +9989,11 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 
 	for _la == JetRuleParserT__2 {
 		{
-			p.SetState(629)
+			p.SetState(635)
 			p.RuleProperties()
 		}
 
-		p.SetState(634)
+		p.SetState(640)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -9927,7 +10001,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(635)
+		p.SetState(641)
 		p.Match(JetRuleParserT__4)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -9935,14 +10009,14 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 		}
 	}
 	{
-		p.SetState(636)
+		p.SetState(642)
 		p.Match(JetRuleParserT__7)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(640)
+	p.SetState(646)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -9951,7 +10025,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(637)
+			p.SetState(643)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -9959,26 +10033,45 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 			}
 		}
 
-		p.SetState(642)
+		p.SetState(648)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 	}
-	p.SetState(650)
+	p.SetState(659)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	for ok := true; ok; ok = _la == JetRuleParserT__9 || _la == JetRuleParserNOT {
-		{
-			p.SetState(643)
-			p.Antecedent()
+	for ok := true; ok; ok = (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&2306124484190405632) != 0 {
+		p.SetState(651)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+
+		switch p.GetTokenStream().LA(1) {
+		case JetRuleParserT__9, JetRuleParserNOT:
+			{
+				p.SetState(649)
+				p.Antecedent()
+			}
+
+		case JetRuleParserOR:
+			{
+				p.SetState(650)
+				p.OrAntecedent()
+			}
+
+		default:
+			p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+			goto errorExit
 		}
-		p.SetState(647)
+		p.SetState(656)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -9987,7 +10080,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(644)
+				p.SetState(653)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -9995,7 +10088,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 				}
 			}
 
-			p.SetState(649)
+			p.SetState(658)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -10003,7 +10096,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 			_la = p.GetTokenStream().LA(1)
 		}
 
-		p.SetState(652)
+		p.SetState(661)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -10011,14 +10104,14 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(654)
+		p.SetState(663)
 		p.Match(JetRuleParserT__8)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(658)
+	p.SetState(667)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -10027,7 +10120,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 
 	for _la == JetRuleParserCOMMENT {
 		{
-			p.SetState(655)
+			p.SetState(664)
 			p.Match(JetRuleParserCOMMENT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -10035,14 +10128,14 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 			}
 		}
 
-		p.SetState(660)
+		p.SetState(669)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 	}
-	p.SetState(668)
+	p.SetState(677)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -10051,10 +10144,10 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 
 	for ok := true; ok; ok = _la == JetRuleParserT__9 {
 		{
-			p.SetState(661)
+			p.SetState(670)
 			p.Consequent()
 		}
-		p.SetState(665)
+		p.SetState(674)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -10063,7 +10156,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 
 		for _la == JetRuleParserCOMMENT {
 			{
-				p.SetState(662)
+				p.SetState(671)
 				p.Match(JetRuleParserCOMMENT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -10071,7 +10164,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 				}
 			}
 
-			p.SetState(667)
+			p.SetState(676)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -10079,7 +10172,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 			_la = p.GetTokenStream().LA(1)
 		}
 
-		p.SetState(670)
+		p.SetState(679)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -10087,7 +10180,7 @@ func (p *JetRuleParser) JetRuleStmt() (localctx IJetRuleStmtContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(672)
+		p.SetState(681)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -10227,7 +10320,7 @@ func (p *JetRuleParser) RuleProperties() (localctx IRulePropertiesContext) {
 	p.EnterRule(localctx, 86, JetRuleParserRULE_ruleProperties)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(674)
+		p.SetState(683)
 		p.Match(JetRuleParserT__2)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -10235,7 +10328,7 @@ func (p *JetRuleParser) RuleProperties() (localctx IRulePropertiesContext) {
 		}
 	}
 	{
-		p.SetState(675)
+		p.SetState(684)
 
 		var _m = p.Match(JetRuleParserIdentifier)
 
@@ -10246,7 +10339,7 @@ func (p *JetRuleParser) RuleProperties() (localctx IRulePropertiesContext) {
 		}
 	}
 	{
-		p.SetState(676)
+		p.SetState(685)
 		p.Match(JetRuleParserASSIGN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -10254,7 +10347,7 @@ func (p *JetRuleParser) RuleProperties() (localctx IRulePropertiesContext) {
 		}
 	}
 	{
-		p.SetState(677)
+		p.SetState(686)
 
 		var _x = p.PropertyValue()
 
@@ -10397,7 +10490,7 @@ func (p *JetRuleParser) PropertyValue() (localctx IPropertyValueContext) {
 	localctx = NewPropertyValueContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 88, JetRuleParserRULE_propertyValue)
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(683)
+	p.SetState(692)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -10406,7 +10499,7 @@ func (p *JetRuleParser) PropertyValue() (localctx IPropertyValueContext) {
 	switch p.GetTokenStream().LA(1) {
 	case JetRuleParserSTRING:
 		{
-			p.SetState(679)
+			p.SetState(688)
 
 			var _m = p.Match(JetRuleParserSTRING)
 
@@ -10419,7 +10512,7 @@ func (p *JetRuleParser) PropertyValue() (localctx IPropertyValueContext) {
 
 	case JetRuleParserTRUE:
 		{
-			p.SetState(680)
+			p.SetState(689)
 
 			var _m = p.Match(JetRuleParserTRUE)
 
@@ -10432,7 +10525,7 @@ func (p *JetRuleParser) PropertyValue() (localctx IPropertyValueContext) {
 
 	case JetRuleParserFALSE:
 		{
-			p.SetState(681)
+			p.SetState(690)
 
 			var _m = p.Match(JetRuleParserFALSE)
 
@@ -10445,7 +10538,7 @@ func (p *JetRuleParser) PropertyValue() (localctx IPropertyValueContext) {
 
 	case JetRuleParserPLUS, JetRuleParserMINUS, JetRuleParserDIGITS:
 		{
-			p.SetState(682)
+			p.SetState(691)
 
 			var _x = p.IntExpr()
 
@@ -10492,6 +10585,12 @@ type IAntecedentContext interface {
 	// GetO returns the o rule contexts.
 	GetO() IObjectAtomContext
 
+	// GetV returns the v rule contexts.
+	GetV() IAtomContext
+
+	// GetAgg returns the agg rule contexts.
+	GetAgg() IAggregateTermContext
+
 	// GetF returns the f rule contexts.
 	GetF() IExprTermContext
 
@@ -10504,13 +10603,21 @@ type IAntecedentContext interface {
 	// SetO sets the o rule contexts.
 	SetO(IObjectAtomContext)
 
+	// SetV sets the v rule contexts.
+	SetV(IAtomContext)
+
+	// SetAgg sets the agg rule contexts.
+	SetAgg(IAggregateTermContext)
+
 	// SetF sets the f rule contexts.
 	SetF(IExprTermContext)
 
 	// Getter signatures
+	ASSIGN() antlr.TerminalNode
 	AllAtom() []IAtomContext
 	Atom(i int) IAtomContext
 	ObjectAtom() IObjectAtomContext
+	AggregateTerm() IAggregateTermContext
 	NOT() antlr.TerminalNode
 	ExprTerm() IExprTermContext
 
@@ -10525,6 +10632,8 @@ type AntecedentContext struct {
 	s      IAtomContext
 	p      IAtomContext
 	o      IObjectAtomContext
+	v      IAtomContext
+	agg    IAggregateTermContext
 	f      IExprTermContext
 }
 
@@ -10565,6 +10674,10 @@ func (s *AntecedentContext) GetP() IAtomContext { return s.p }
 
 func (s *AntecedentContext) GetO() IObjectAtomContext { return s.o }
 
+func (s *AntecedentContext) GetV() IAtomContext { return s.v }
+
+func (s *AntecedentContext) GetAgg() IAggregateTermContext { return s.agg }
+
 func (s *AntecedentContext) GetF() IExprTermContext { return s.f }
 
 func (s *AntecedentContext) SetS(v IAtomContext) { s.s = v }
@@ -10573,8 +10686,16 @@ func (s *AntecedentContext) SetP(v IAtomContext) { s.p = v }
 
 func (s *AntecedentContext) SetO(v IObjectAtomContext) { s.o = v }
 
+func (s *AntecedentContext) SetV(v IAtomContext) { s.v = v }
+
+func (s *AntecedentContext) SetAgg(v IAggregateTermContext) { s.agg = v }
+
 func (s *AntecedentContext) SetF(v IExprTermContext) { s.f = v }
 
+func (s *AntecedentContext) ASSIGN() antlr.TerminalNode {
+	return s.GetToken(JetRuleParserASSIGN, 0)
+}
+
 func (s *AntecedentContext) AllAtom() []IAtomContext {
 	children := s.GetChildren()
 	len := 0
@@ -10632,6 +10753,22 @@ func (s *AntecedentContext) ObjectAtom() IObjectAtomContext {
 	return t.(IObjectAtomContext)
 }
 
+func (s *AntecedentContext) AggregateTerm() IAggregateTermContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAggregateTermContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAggregateTermContext)
+}
+
 func (s *AntecedentContext) NOT() antlr.TerminalNode {
 	return s.GetToken(JetRuleParserNOT, 0)
 }
@@ -10662,45 +10799,798 @@ func (s *AntecedentContext) ToStringTree(ruleNames []string, recog antlr.Recogni
 
 func (s *AntecedentContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(JetRuleListener); ok {
-		listenerT.EnterAntecedent(s)
+		listenerT.EnterAntecedent(s)
+	}
+}
+
+func (s *AntecedentContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(JetRuleListener); ok {
+		listenerT.ExitAntecedent(s)
+	}
+}
+
+func (p *JetRuleParser) Antecedent() (localctx IAntecedentContext) {
+	localctx = NewAntecedentContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 90, JetRuleParserRULE_antecedent)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(695)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == JetRuleParserNOT {
+		{
+			p.SetState(694)
+
+			var _m = p.Match(JetRuleParserNOT)
+
+			localctx.(*AntecedentContext).n = _m
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	}
+	{
+		p.SetState(697)
+		p.Match(JetRuleParserT__9)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	p.SetState(706)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 67, p.GetParserRuleContext()) {
+	case 1:
+		{
+			p.SetState(698)
+
+			var _x = p.Atom()
+
+			localctx.(*AntecedentContext).s = _x
+		}
+		{
+			p.SetState(699)
+
+			var _x = p.Atom()
+
+			localctx.(*AntecedentContext).p = _x
+		}
+		{
+			p.SetState(700)
+
+			var _x = p.ObjectAtom()
+
+			localctx.(*AntecedentContext).o = _x
+		}
+
+	case 2:
+		{
+			p.SetState(702)
+
+			var _x = p.Atom()
+
+			localctx.(*AntecedentContext).v = _x
+		}
+		{
+			p.SetState(703)
+			p.Match(JetRuleParserASSIGN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(704)
+
+			var _x = p.AggregateTerm()
+
+			localctx.(*AntecedentContext).agg = _x
+		}
+
+	case antlr.ATNInvalidAltNumber:
+		goto errorExit
+	}
+	{
+		p.SetState(708)
+		p.Match(JetRuleParserT__10)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	p.SetState(710)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == JetRuleParserT__6 {
+		{
+			p.SetState(709)
+			p.Match(JetRuleParserT__6)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	}
+	p.SetState(718)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == JetRuleParserT__3 {
+		{
+			p.SetState(712)
+			p.Match(JetRuleParserT__3)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(713)
+
+			var _x = p.exprTerm(0)
+
+			localctx.(*AntecedentContext).f = _x
+		}
+		{
+			p.SetState(714)
+			p.Match(JetRuleParserT__4)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(716)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JetRuleParserT__6 {
+			{
+				p.SetState(715)
+				p.Match(JetRuleParserT__6)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IOrAntecedentContext is an interface to support dynamic dispatch.
+type IOrAntecedentContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	OR() antlr.TerminalNode
+	AllOrBranch() []IOrBranchContext
+	OrBranch(i int) IOrBranchContext
+
+	// IsOrAntecedentContext differentiates from other interfaces.
+	IsOrAntecedentContext()
+}
+
+type OrAntecedentContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyOrAntecedentContext() *OrAntecedentContext {
+	var p = new(OrAntecedentContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = JetRuleParserRULE_orAntecedent
+	return p
+}
+
+func InitEmptyOrAntecedentContext(p *OrAntecedentContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = JetRuleParserRULE_orAntecedent
+}
+
+func (*OrAntecedentContext) IsOrAntecedentContext() {}
+
+func NewOrAntecedentContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *OrAntecedentContext {
+	var p = new(OrAntecedentContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = JetRuleParserRULE_orAntecedent
+
+	return p
+}
+
+func (s *OrAntecedentContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *OrAntecedentContext) OR() antlr.TerminalNode {
+	return s.GetToken(JetRuleParserOR, 0)
+}
+
+func (s *OrAntecedentContext) AllOrBranch() []IOrBranchContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IOrBranchContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IOrBranchContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IOrBranchContext); ok {
+			tst[i] = t.(IOrBranchContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *OrAntecedentContext) OrBranch(i int) IOrBranchContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IOrBranchContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IOrBranchContext)
+}
+
+func (s *OrAntecedentContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *OrAntecedentContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *OrAntecedentContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(JetRuleListener); ok {
+		listenerT.EnterOrAntecedent(s)
+	}
+}
+
+func (s *OrAntecedentContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(JetRuleListener); ok {
+		listenerT.ExitOrAntecedent(s)
+	}
+}
+
+func (p *JetRuleParser) OrAntecedent() (localctx IOrAntecedentContext) {
+	localctx = NewOrAntecedentContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 92, JetRuleParserRULE_orAntecedent)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(720)
+		p.Match(JetRuleParserOR)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(721)
+		p.OrBranch()
+	}
+	p.SetState(723)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for ok := true; ok; ok = _la == JetRuleParserT__0 {
+		{
+			p.SetState(722)
+			p.OrBranch()
+		}
+
+		p.SetState(725)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+	p.SetState(728)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == JetRuleParserT__6 {
+		{
+			p.SetState(727)
+			p.Match(JetRuleParserT__6)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IOrBranchContext is an interface to support dynamic dispatch.
+type IOrBranchContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AllCOMMENT() []antlr.TerminalNode
+	COMMENT(i int) antlr.TerminalNode
+	AllAntecedent() []IAntecedentContext
+	Antecedent(i int) IAntecedentContext
+
+	// IsOrBranchContext differentiates from other interfaces.
+	IsOrBranchContext()
+}
+
+type OrBranchContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyOrBranchContext() *OrBranchContext {
+	var p = new(OrBranchContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = JetRuleParserRULE_orBranch
+	return p
+}
+
+func InitEmptyOrBranchContext(p *OrBranchContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = JetRuleParserRULE_orBranch
+}
+
+func (*OrBranchContext) IsOrBranchContext() {}
+
+func NewOrBranchContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *OrBranchContext {
+	var p = new(OrBranchContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = JetRuleParserRULE_orBranch
+
+	return p
+}
+
+func (s *OrBranchContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *OrBranchContext) AllCOMMENT() []antlr.TerminalNode {
+	return s.GetTokens(JetRuleParserCOMMENT)
+}
+
+func (s *OrBranchContext) COMMENT(i int) antlr.TerminalNode {
+	return s.GetToken(JetRuleParserCOMMENT, i)
+}
+
+func (s *OrBranchContext) AllAntecedent() []IAntecedentContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IAntecedentContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IAntecedentContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IAntecedentContext); ok {
+			tst[i] = t.(IAntecedentContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *OrBranchContext) Antecedent(i int) IAntecedentContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAntecedentContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAntecedentContext)
+}
+
+func (s *OrBranchContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *OrBranchContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *OrBranchContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(JetRuleListener); ok {
+		listenerT.EnterOrBranch(s)
+	}
+}
+
+func (s *OrBranchContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(JetRuleListener); ok {
+		listenerT.ExitOrBranch(s)
+	}
+}
+
+func (p *JetRuleParser) OrBranch() (localctx IOrBranchContext) {
+	localctx = NewOrBranchContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 94, JetRuleParserRULE_orBranch)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(730)
+		p.Match(JetRuleParserT__0)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	p.SetState(734)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == JetRuleParserCOMMENT {
+		{
+			p.SetState(731)
+			p.Match(JetRuleParserCOMMENT)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+		p.SetState(736)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+	p.SetState(744)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for ok := true; ok; ok = _la == JetRuleParserT__9 || _la == JetRuleParserNOT {
+		{
+			p.SetState(737)
+			p.Antecedent()
+		}
+		p.SetState(741)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		for _la == JetRuleParserCOMMENT {
+			{
+				p.SetState(738)
+				p.Match(JetRuleParserCOMMENT)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+			p.SetState(743)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
+				goto errorExit
+			}
+			_la = p.GetTokenStream().LA(1)
+		}
+
+		p.SetState(746)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(748)
+		p.Match(JetRuleParserT__1)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IConsequentContext is an interface to support dynamic dispatch.
+type IConsequentContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// GetS returns the s rule contexts.
+	GetS() IAtomContext
+
+	// GetP returns the p rule contexts.
+	GetP() IAtomContext
+
+	// GetO returns the o rule contexts.
+	GetO() IExprTermContext
+
+	// GetAgg returns the agg rule contexts.
+	GetAgg() IAggregateTermContext
+
+	// SetS sets the s rule contexts.
+	SetS(IAtomContext)
+
+	// SetP sets the p rule contexts.
+	SetP(IAtomContext)
+
+	// SetO sets the o rule contexts.
+	SetO(IExprTermContext)
+
+	// SetAgg sets the agg rule contexts.
+	SetAgg(IAggregateTermContext)
+
+	// Getter signatures
+	AllAtom() []IAtomContext
+	Atom(i int) IAtomContext
+	ExprTerm() IExprTermContext
+	AggregateTerm() IAggregateTermContext
+
+	// IsConsequentContext differentiates from other interfaces.
+	IsConsequentContext()
+}
+
+type ConsequentContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+	s      IAtomContext
+	p      IAtomContext
+	o      IExprTermContext
+	agg    IAggregateTermContext
+}
+
+func NewEmptyConsequentContext() *ConsequentContext {
+	var p = new(ConsequentContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = JetRuleParserRULE_consequent
+	return p
+}
+
+func InitEmptyConsequentContext(p *ConsequentContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = JetRuleParserRULE_consequent
+}
+
+func (*ConsequentContext) IsConsequentContext() {}
+
+func NewConsequentContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ConsequentContext {
+	var p = new(ConsequentContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = JetRuleParserRULE_consequent
+
+	return p
+}
+
+func (s *ConsequentContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ConsequentContext) GetS() IAtomContext { return s.s }
+
+func (s *ConsequentContext) GetP() IAtomContext { return s.p }
+
+func (s *ConsequentContext) GetO() IExprTermContext { return s.o }
+
+func (s *ConsequentContext) GetAgg() IAggregateTermContext { return s.agg }
+
+func (s *ConsequentContext) SetS(v IAtomContext) { s.s = v }
+
+func (s *ConsequentContext) SetP(v IAtomContext) { s.p = v }
+
+func (s *ConsequentContext) SetO(v IExprTermContext) { s.o = v }
+
+func (s *ConsequentContext) SetAgg(v IAggregateTermContext) { s.agg = v }
+
+func (s *ConsequentContext) AllAtom() []IAtomContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IAtomContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IAtomContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IAtomContext); ok {
+			tst[i] = t.(IAtomContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ConsequentContext) Atom(i int) IAtomContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAtomContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *ConsequentContext) ExprTerm() IExprTermContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExprTermContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExprTermContext)
+}
+
+func (s *ConsequentContext) AggregateTerm() IAggregateTermContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAggregateTermContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAggregateTermContext)
+}
+
+func (s *ConsequentContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ConsequentContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ConsequentContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(JetRuleListener); ok {
+		listenerT.EnterConsequent(s)
 	}
 }
 
-func (s *AntecedentContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ConsequentContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(JetRuleListener); ok {
-		listenerT.ExitAntecedent(s)
+		listenerT.ExitConsequent(s)
 	}
 }
 
-func (p *JetRuleParser) Antecedent() (localctx IAntecedentContext) {
-	localctx = NewAntecedentContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 90, JetRuleParserRULE_antecedent)
+func (p *JetRuleParser) Consequent() (localctx IConsequentContext) {
+	localctx = NewConsequentContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 96, JetRuleParserRULE_consequent)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(686)
-	p.GetErrorHandler().Sync(p)
-	if p.HasError() {
-		goto errorExit
-	}
-	_la = p.GetTokenStream().LA(1)
-
-	if _la == JetRuleParserNOT {
-		{
-			p.SetState(685)
-
-			var _m = p.Match(JetRuleParserNOT)
-
-			localctx.(*AntecedentContext).n = _m
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
-		}
-
-	}
 	{
-		p.SetState(688)
+		p.SetState(750)
 		p.Match(JetRuleParserT__9)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -10708,35 +11598,56 @@ func (p *JetRuleParser) Antecedent() (localctx IAntecedentContext) {
 		}
 	}
 	{
-		p.SetState(689)
+		p.SetState(751)
 
 		var _x = p.Atom()
 
-		localctx.(*AntecedentContext).s = _x
+		localctx.(*ConsequentContext).s = _x
 	}
 	{
-		p.SetState(690)
+		p.SetState(752)
 
 		var _x = p.Atom()
 
-		localctx.(*AntecedentContext).p = _x
+		localctx.(*ConsequentContext).p = _x
+	}
+	p.SetState(755)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
 	}
-	{
-		p.SetState(691)
 
-		var _x = p.ObjectAtom()
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 76, p.GetParserRuleContext()) {
+	case 1:
+		{
+			p.SetState(753)
+
+			var _x = p.exprTerm(0)
+
+			localctx.(*ConsequentContext).o = _x
+		}
+
+	case 2:
+		{
+			p.SetState(754)
+
+			var _x = p.AggregateTerm()
+
+			localctx.(*ConsequentContext).agg = _x
+		}
 
-		localctx.(*AntecedentContext).o = _x
+	case antlr.ATNInvalidAltNumber:
+		goto errorExit
 	}
 	{
-		p.SetState(692)
+		p.SetState(757)
 		p.Match(JetRuleParserT__10)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(694)
+	p.SetState(759)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -10745,7 +11656,7 @@ func (p *JetRuleParser) Antecedent() (localctx IAntecedentContext) {
 
 	if _la == JetRuleParserT__6 {
 		{
-			p.SetState(693)
+			p.SetState(758)
 			p.Match(JetRuleParserT__6)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -10754,57 +11665,6 @@ func (p *JetRuleParser) Antecedent() (localctx IAntecedentContext) {
 		}
 
 	}
-	p.SetState(702)
-	p.GetErrorHandler().Sync(p)
-	if p.HasError() {
-		goto errorExit
-	}
-	_la = p.GetTokenStream().LA(1)
-
-	if _la == JetRuleParserT__3 {
-		{
-			p.SetState(696)
-			p.Match(JetRuleParserT__3)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
-		}
-		{
-			p.SetState(697)
-
-			var _x = p.exprTerm(0)
-
-			localctx.(*AntecedentContext).f = _x
-		}
-		{
-			p.SetState(698)
-			p.Match(JetRuleParserT__4)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
-		}
-		p.SetState(700)
-		p.GetErrorHandler().Sync(p)
-		if p.HasError() {
-			goto errorExit
-		}
-		_la = p.GetTokenStream().LA(1)
-
-		if _la == JetRuleParserT__6 {
-			{
-				p.SetState(699)
-				p.Match(JetRuleParserT__6)
-				if p.HasError() {
-					// Recognition error - abort rule
-					goto errorExit
-				}
-			}
-
-		}
-
-	}
 
 errorExit:
 	if p.HasError() {
@@ -10819,13 +11679,28 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
-// IConsequentContext is an interface to support dynamic dispatch.
-type IConsequentContext interface {
+// IAggregateTermContext is an interface to support dynamic dispatch.
+type IAggregateTermContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
+	// GetOp returns the op token.
+	GetOp() antlr.Token
+
+	// GetKw returns the kw token.
+	GetKw() antlr.Token
+
+	// SetOp sets the op token.
+	SetOp(antlr.Token)
+
+	// SetKw sets the kw token.
+	SetKw(antlr.Token)
+
+	// GetAggVar returns the aggVar rule contexts.
+	GetAggVar() IAtomContext
+
 	// GetS returns the s rule contexts.
 	GetS() IAtomContext
 
@@ -10833,7 +11708,10 @@ type IConsequentContext interface {
 	GetP() IAtomContext
 
 	// GetO returns the o rule contexts.
-	GetO() IExprTermContext
+	GetO() IObjectAtomContext
+
+	// SetAggVar sets the aggVar rule contexts.
+	SetAggVar(IAtomContext)
 
 	// SetS sets the s rule contexts.
 	SetS(IAtomContext)
@@ -10842,65 +11720,90 @@ type IConsequentContext interface {
 	SetP(IAtomContext)
 
 	// SetO sets the o rule contexts.
-	SetO(IExprTermContext)
+	SetO(IObjectAtomContext)
 
 	// Getter signatures
+	AllIdentifier() []antlr.TerminalNode
+	Identifier(i int) antlr.TerminalNode
 	AllAtom() []IAtomContext
 	Atom(i int) IAtomContext
-	ExprTerm() IExprTermContext
+	ObjectAtom() IObjectAtomContext
 
-	// IsConsequentContext differentiates from other interfaces.
-	IsConsequentContext()
+	// IsAggregateTermContext differentiates from other interfaces.
+	IsAggregateTermContext()
 }
 
-type ConsequentContext struct {
+type AggregateTermContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
+	op     antlr.Token
+	aggVar IAtomContext
+	kw     antlr.Token
 	s      IAtomContext
 	p      IAtomContext
-	o      IExprTermContext
+	o      IObjectAtomContext
 }
 
-func NewEmptyConsequentContext() *ConsequentContext {
-	var p = new(ConsequentContext)
+func NewEmptyAggregateTermContext() *AggregateTermContext {
+	var p = new(AggregateTermContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = JetRuleParserRULE_consequent
+	p.RuleIndex = JetRuleParserRULE_aggregateTerm
 	return p
 }
 
-func InitEmptyConsequentContext(p *ConsequentContext) {
+func InitEmptyAggregateTermContext(p *AggregateTermContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = JetRuleParserRULE_consequent
+	p.RuleIndex = JetRuleParserRULE_aggregateTerm
 }
 
-func (*ConsequentContext) IsConsequentContext() {}
+func (*AggregateTermContext) IsAggregateTermContext() {}
 
-func NewConsequentContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ConsequentContext {
-	var p = new(ConsequentContext)
+func NewAggregateTermContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AggregateTermContext {
+	var p = new(AggregateTermContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = JetRuleParserRULE_consequent
+	p.RuleIndex = JetRuleParserRULE_aggregateTerm
 
 	return p
 }
 
-func (s *ConsequentContext) GetParser() antlr.Parser { return s.parser }
+func (s *AggregateTermContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ConsequentContext) GetS() IAtomContext { return s.s }
+func (s *AggregateTermContext) GetOp() antlr.Token { return s.op }
 
-func (s *ConsequentContext) GetP() IAtomContext { return s.p }
+func (s *AggregateTermContext) GetKw() antlr.Token { return s.kw }
 
-func (s *ConsequentContext) GetO() IExprTermContext { return s.o }
+func (s *AggregateTermContext) SetOp(v antlr.Token) { s.op = v }
 
-func (s *ConsequentContext) SetS(v IAtomContext) { s.s = v }
+func (s *AggregateTermContext) SetKw(v antlr.Token) { s.kw = v }
 
-func (s *ConsequentContext) SetP(v IAtomContext) { s.p = v }
+func (s *AggregateTermContext) GetAggVar() IAtomContext { return s.aggVar }
 
-func (s *ConsequentContext) SetO(v IExprTermContext) { s.o = v }
+func (s *AggregateTermContext) GetS() IAtomContext { return s.s }
 
-func (s *ConsequentContext) AllAtom() []IAtomContext {
+func (s *AggregateTermContext) GetP() IAtomContext { return s.p }
+
+func (s *AggregateTermContext) GetO() IObjectAtomContext { return s.o }
+
+func (s *AggregateTermContext) SetAggVar(v IAtomContext) { s.aggVar = v }
+
+func (s *AggregateTermContext) SetS(v IAtomContext) { s.s = v }
+
+func (s *AggregateTermContext) SetP(v IAtomContext) { s.p = v }
+
+func (s *AggregateTermContext) SetO(v IObjectAtomContext) { s.o = v }
+
+func (s *AggregateTermContext) AllIdentifier() []antlr.TerminalNode {
+	return s.GetTokens(JetRuleParserIdentifier)
+}
+
+func (s *AggregateTermContext) Identifier(i int) antlr.TerminalNode {
+	return s.GetToken(JetRuleParserIdentifier, i)
+}
+
+func (s *AggregateTermContext) AllAtom() []IAtomContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -10921,7 +11824,7 @@ func (s *ConsequentContext) AllAtom() []IAtomContext {
 	return tst
 }
 
-func (s *ConsequentContext) Atom(i int) IAtomContext {
+func (s *AggregateTermContext) Atom(i int) IAtomContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -10941,10 +11844,10 @@ func (s *ConsequentContext) Atom(i int) IAtomContext {
 	return t.(IAtomContext)
 }
 
-func (s *ConsequentContext) ExprTerm() IExprTermContext {
+func (s *AggregateTermContext) ObjectAtom() IObjectAtomContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExprTermContext); ok {
+		if _, ok := ctx.(IObjectAtomContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -10954,37 +11857,46 @@ func (s *ConsequentContext) ExprTerm() IExprTermContext {
 		return nil
 	}
 
-	return t.(IExprTermContext)
+	return t.(IObjectAtomContext)
 }
 
-func (s *ConsequentContext) GetRuleContext() antlr.RuleContext {
+func (s *AggregateTermContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ConsequentContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *AggregateTermContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ConsequentContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *AggregateTermContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(JetRuleListener); ok {
-		listenerT.EnterConsequent(s)
+		listenerT.EnterAggregateTerm(s)
 	}
 }
 
-func (s *ConsequentContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *AggregateTermContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(JetRuleListener); ok {
-		listenerT.ExitConsequent(s)
+		listenerT.ExitAggregateTerm(s)
 	}
 }
 
-func (p *JetRuleParser) Consequent() (localctx IConsequentContext) {
-	localctx = NewConsequentContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 92, JetRuleParserRULE_consequent)
-	var _la int
-
+func (p *JetRuleParser) AggregateTerm() (localctx IAggregateTermContext) {
+	localctx = NewAggregateTermContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 98, JetRuleParserRULE_aggregateTerm)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(704)
+		p.SetState(761)
+
+		var _m = p.Match(JetRuleParserIdentifier)
+
+		localctx.(*AggregateTermContext).op = _m
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(762)
 		p.Match(JetRuleParserT__9)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -10992,51 +11904,67 @@ func (p *JetRuleParser) Consequent() (localctx IConsequentContext) {
 		}
 	}
 	{
-		p.SetState(705)
+		p.SetState(763)
 
 		var _x = p.Atom()
 
-		localctx.(*ConsequentContext).s = _x
+		localctx.(*AggregateTermContext).aggVar = _x
+	}
+	{
+		p.SetState(764)
+
+		var _m = p.Match(JetRuleParserIdentifier)
+
+		localctx.(*AggregateTermContext).kw = _m
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(765)
+		p.Match(JetRuleParserT__9)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
 	}
 	{
-		p.SetState(706)
+		p.SetState(766)
 
 		var _x = p.Atom()
 
-		localctx.(*ConsequentContext).p = _x
+		localctx.(*AggregateTermContext).s = _x
 	}
 	{
-		p.SetState(707)
+		p.SetState(767)
 
-		var _x = p.exprTerm(0)
+		var _x = p.Atom()
 
-		localctx.(*ConsequentContext).o = _x
+		localctx.(*AggregateTermContext).p = _x
 	}
 	{
-		p.SetState(708)
+		p.SetState(768)
+
+		var _x = p.ObjectAtom()
+
+		localctx.(*AggregateTermContext).o = _x
+	}
+	{
+		p.SetState(769)
 		p.Match(JetRuleParserT__10)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(710)
-	p.GetErrorHandler().Sync(p)
-	if p.HasError() {
-		goto errorExit
-	}
-	_la = p.GetTokenStream().LA(1)
-
-	if _la == JetRuleParserT__6 {
-		{
-			p.SetState(709)
-			p.Match(JetRuleParserT__6)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
+	{
+		p.SetState(770)
+		p.Match(JetRuleParserT__10)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
 		}
-
 	}
 
 errorExit:
@@ -11141,8 +12069,8 @@ func (s *AtomContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *JetRuleParser) Atom() (localctx IAtomContext) {
 	localctx = NewAtomContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 94, JetRuleParserRULE_atom)
-	p.SetState(715)
+	p.EnterRule(localctx, 100, JetRuleParserRULE_atom)
+	p.SetState(775)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -11152,7 +12080,7 @@ func (p *JetRuleParser) Atom() (localctx IAtomContext) {
 	case JetRuleParserT__11:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(712)
+			p.SetState(772)
 			p.Match(JetRuleParserT__11)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11160,7 +12088,7 @@ func (p *JetRuleParser) Atom() (localctx IAtomContext) {
 			}
 		}
 		{
-			p.SetState(713)
+			p.SetState(773)
 			p.Match(JetRuleParserIdentifier)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11171,7 +12099,7 @@ func (p *JetRuleParser) Atom() (localctx IAtomContext) {
 	case JetRuleParserIdentifier:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(714)
+			p.SetState(774)
 			p.DeclIdentifier()
 		}
 
@@ -11406,8 +12334,8 @@ func (s *ObjectAtomContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	localctx = NewObjectAtomContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 96, JetRuleParserRULE_objectAtom)
-	p.SetState(762)
+	p.EnterRule(localctx, 102, JetRuleParserRULE_objectAtom)
+	p.SetState(822)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -11417,14 +12345,14 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	case JetRuleParserT__11, JetRuleParserIdentifier:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(717)
+			p.SetState(777)
 			p.Atom()
 		}
 
 	case JetRuleParserInt32Type:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(718)
+			p.SetState(778)
 			p.Match(JetRuleParserInt32Type)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11432,7 +12360,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(719)
+			p.SetState(779)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11440,11 +12368,11 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(720)
+			p.SetState(780)
 			p.IntExpr()
 		}
 		{
-			p.SetState(721)
+			p.SetState(781)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11455,7 +12383,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	case JetRuleParserUInt32Type:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(723)
+			p.SetState(783)
 			p.Match(JetRuleParserUInt32Type)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11463,7 +12391,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(724)
+			p.SetState(784)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11471,11 +12399,11 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(725)
+			p.SetState(785)
 			p.UintExpr()
 		}
 		{
-			p.SetState(726)
+			p.SetState(786)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11486,7 +12414,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	case JetRuleParserInt64Type:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(728)
+			p.SetState(788)
 			p.Match(JetRuleParserInt64Type)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11494,7 +12422,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(729)
+			p.SetState(789)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11502,11 +12430,11 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(730)
+			p.SetState(790)
 			p.IntExpr()
 		}
 		{
-			p.SetState(731)
+			p.SetState(791)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11517,7 +12445,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	case JetRuleParserUInt64Type:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(733)
+			p.SetState(793)
 			p.Match(JetRuleParserUInt64Type)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11525,7 +12453,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(734)
+			p.SetState(794)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11533,11 +12461,11 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(735)
+			p.SetState(795)
 			p.UintExpr()
 		}
 		{
-			p.SetState(736)
+			p.SetState(796)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11548,7 +12476,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	case JetRuleParserDoubleType:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(738)
+			p.SetState(798)
 			p.Match(JetRuleParserDoubleType)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11556,7 +12484,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(739)
+			p.SetState(799)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11564,11 +12492,11 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(740)
+			p.SetState(800)
 			p.DoubleExpr()
 		}
 		{
-			p.SetState(741)
+			p.SetState(801)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11579,7 +12507,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	case JetRuleParserStringType:
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(743)
+			p.SetState(803)
 			p.Match(JetRuleParserStringType)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11587,7 +12515,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(744)
+			p.SetState(804)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11595,7 +12523,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(745)
+			p.SetState(805)
 			p.Match(JetRuleParserSTRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11603,7 +12531,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(746)
+			p.SetState(806)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11614,7 +12542,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	case JetRuleParserDateType:
 		p.EnterOuterAlt(localctx, 8)
 		{
-			p.SetState(747)
+			p.SetState(807)
 			p.Match(JetRuleParserDateType)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11622,7 +12550,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(748)
+			p.SetState(808)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11630,7 +12558,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(749)
+			p.SetState(809)
 			p.Match(JetRuleParserSTRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11638,7 +12566,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(750)
+			p.SetState(810)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11649,7 +12577,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	case JetRuleParserDatetimeType:
 		p.EnterOuterAlt(localctx, 9)
 		{
-			p.SetState(751)
+			p.SetState(811)
 			p.Match(JetRuleParserDatetimeType)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11657,7 +12585,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(752)
+			p.SetState(812)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11665,7 +12593,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(753)
+			p.SetState(813)
 			p.Match(JetRuleParserSTRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11673,7 +12601,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(754)
+			p.SetState(814)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11684,7 +12612,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	case JetRuleParserBoolType:
 		p.EnterOuterAlt(localctx, 10)
 		{
-			p.SetState(755)
+			p.SetState(815)
 			p.Match(JetRuleParserBoolType)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11692,7 +12620,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(756)
+			p.SetState(816)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11700,7 +12628,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(757)
+			p.SetState(817)
 			p.Match(JetRuleParserSTRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11708,7 +12636,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 			}
 		}
 		{
-			p.SetState(758)
+			p.SetState(818)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11719,7 +12647,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	case JetRuleParserSTRING:
 		p.EnterOuterAlt(localctx, 11)
 		{
-			p.SetState(759)
+			p.SetState(819)
 			p.Match(JetRuleParserSTRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -11730,7 +12658,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	case JetRuleParserTRUE, JetRuleParserFALSE, JetRuleParserNULL:
 		p.EnterOuterAlt(localctx, 12)
 		{
-			p.SetState(760)
+			p.SetState(820)
 
 			var _x = p.Keywords()
 
@@ -11740,7 +12668,7 @@ func (p *JetRuleParser) ObjectAtom() (localctx IObjectAtomContext) {
 	case JetRuleParserPLUS, JetRuleParserMINUS, JetRuleParserDIGITS:
 		p.EnterOuterAlt(localctx, 13)
 		{
-			p.SetState(761)
+			p.SetState(821)
 			p.DoubleExpr()
 		}
 
@@ -11844,12 +12772,12 @@ func (s *KeywordsContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *JetRuleParser) Keywords() (localctx IKeywordsContext) {
 	localctx = NewKeywordsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 98, JetRuleParserRULE_keywords)
+	p.EnterRule(localctx, 104, JetRuleParserRULE_keywords)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(764)
+		p.SetState(824)
 		_la = p.GetTokenStream().LA(1)
 
 		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&246290604621824) != 0) {
@@ -12460,25 +13388,25 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 	localctx = NewExprTermContext(p, p.GetParserRuleContext(), _parentState)
 	var _prevctx IExprTermContext = localctx
 	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 100
-	p.EnterRecursionRule(localctx, 100, JetRuleParserRULE_exprTerm, _p)
+	_startState := 106
+	p.EnterRecursionRule(localctx, 106, JetRuleParserRULE_exprTerm, _p)
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(791)
+	p.SetState(851)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 72, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 80, p.GetParserRuleContext()) {
 	case 1:
 		localctx = NewBinaryExprTerm2Context(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 
 		{
-			p.SetState(767)
+			p.SetState(827)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -12486,28 +13414,28 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 			}
 		}
 		{
-			p.SetState(768)
+			p.SetState(828)
 
 			var _x = p.exprTerm(0)
 
 			localctx.(*BinaryExprTerm2Context).lhs = _x
 		}
 		{
-			p.SetState(769)
+			p.SetState(829)
 
 			var _x = p.BinaryOp()
 
 			localctx.(*BinaryExprTerm2Context).op = _x
 		}
 		{
-			p.SetState(770)
+			p.SetState(830)
 
 			var _x = p.exprTerm(0)
 
 			localctx.(*BinaryExprTerm2Context).rhs = _x
 		}
 		{
-			p.SetState(771)
+			p.SetState(831)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -12520,14 +13448,14 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(773)
+			p.SetState(833)
 
 			var _x = p.UnaryOp()
 
 			localctx.(*UnaryExprTermContext).op = _x
 		}
 		{
-			p.SetState(774)
+			p.SetState(834)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -12535,14 +13463,14 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 			}
 		}
 		{
-			p.SetState(775)
+			p.SetState(835)
 
 			var _x = p.exprTerm(0)
 
 			localctx.(*UnaryExprTermContext).arg = _x
 		}
 		{
-			p.SetState(776)
+			p.SetState(836)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -12555,7 +13483,7 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(778)
+			p.SetState(838)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -12563,21 +13491,21 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 			}
 		}
 		{
-			p.SetState(779)
+			p.SetState(839)
 
 			var _x = p.UnaryOp()
 
 			localctx.(*UnaryExprTerm2Context).op = _x
 		}
 		{
-			p.SetState(780)
+			p.SetState(840)
 
 			var _x = p.exprTerm(0)
 
 			localctx.(*UnaryExprTerm2Context).arg = _x
 		}
 		{
-			p.SetState(781)
+			p.SetState(841)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -12590,7 +13518,7 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(783)
+			p.SetState(843)
 			p.Match(JetRuleParserT__9)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -12598,14 +13526,14 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 			}
 		}
 		{
-			p.SetState(784)
+			p.SetState(844)
 
 			var _x = p.exprTerm(0)
 
 			localctx.(*SelfExprTermContext).selfExpr = _x
 		}
 		{
-			p.SetState(785)
+			p.SetState(845)
 			p.Match(JetRuleParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -12618,14 +13546,14 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(787)
+			p.SetState(847)
 
 			var _x = p.UnaryOp()
 
 			localctx.(*UnaryExprTerm3Context).op = _x
 		}
 		{
-			p.SetState(788)
+			p.SetState(848)
 
 			var _x = p.exprTerm(2)
 
@@ -12637,7 +13565,7 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(790)
+			p.SetState(850)
 
 			var _x = p.ObjectAtom()
 
@@ -12648,12 +13576,12 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 		goto errorExit
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(799)
+	p.SetState(859)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
-	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 73, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 81, p.GetParserRuleContext())
 	if p.HasError() {
 		goto errorExit
 	}
@@ -12667,21 +13595,21 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 			localctx.(*BinaryExprTermContext).lhs = _prevctx
 
 			p.PushNewRecursionContext(localctx, _startState, JetRuleParserRULE_exprTerm)
-			p.SetState(793)
+			p.SetState(853)
 
 			if !(p.Precpred(p.GetParserRuleContext(), 7)) {
 				p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
 				goto errorExit
 			}
 			{
-				p.SetState(794)
+				p.SetState(854)
 
 				var _x = p.BinaryOp()
 
 				localctx.(*BinaryExprTermContext).op = _x
 			}
 			{
-				p.SetState(795)
+				p.SetState(855)
 
 				var _x = p.exprTerm(8)
 
@@ -12689,12 +13617,12 @@ func (p *JetRuleParser) exprTerm(_p int) (localctx IExprTermContext) {
 			}
 
 		}
-		p.SetState(801)
+		p.SetState(861)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
-		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 73, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 81, p.GetParserRuleContext())
 		if p.HasError() {
 			goto errorExit
 		}
@@ -12850,12 +13778,12 @@ func (s *BinaryOpContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *JetRuleParser) BinaryOp() (localctx IBinaryOpContext) {
 	localctx = NewBinaryOpContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 102, JetRuleParserRULE_binaryOp)
+	p.EnterRule(localctx, 108, JetRuleParserRULE_binaryOp)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(802)
+		p.SetState(862)
 		_la = p.GetTokenStream().LA(1)
 
 		if !((int64((_la-50)) & ^0x3f) == 0 && ((int64(1)<<(_la-50))&40959) != 0) {
@@ -12961,12 +13889,12 @@ func (s *UnaryOpContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *JetRuleParser) UnaryOp() (localctx IUnaryOpContext) {
 	localctx = NewUnaryOpContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 104, JetRuleParserRULE_unaryOp)
+	p.EnterRule(localctx, 110, JetRuleParserRULE_unaryOp)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(804)
+		p.SetState(864)
 		_la = p.GetTokenStream().LA(1)
 
 		if !((int64((_la-48)) & ^0x3f) == 0 && ((int64(1)<<(_la-48))&131075) != 0) {
@@ -13160,10 +14088,10 @@ func (s *TripleStmtContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *JetRuleParser) TripleStmt() (localctx ITripleStmtContext) {
 	localctx = NewTripleStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 106, JetRuleParserRULE_tripleStmt)
+	p.EnterRule(localctx, 112, JetRuleParserRULE_tripleStmt)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(806)
+		p.SetState(866)
 		p.Match(JetRuleParserTRIPLE)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -13171,7 +14099,7 @@ func (p *JetRuleParser) TripleStmt() (localctx ITripleStmtContext) {
 		}
 	}
 	{
-		p.SetState(807)
+		p.SetState(867)
 		p.Match(JetRuleParserT__9)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -13179,14 +14107,14 @@ func (p *JetRuleParser) TripleStmt() (localctx ITripleStmtContext) {
 		}
 	}
 	{
-		p.SetState(808)
+		p.SetState(868)
 
 		var _x = p.Atom()
 
 		localctx.(*TripleStmtContext).s = _x
 	}
 	{
-		p.SetState(809)
+		p.SetState(869)
 		p.Match(JetRuleParserT__2)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -13194,14 +14122,14 @@ func (p *JetRuleParser) TripleStmt() (localctx ITripleStmtContext) {
 		}
 	}
 	{
-		p.SetState(810)
+		p.SetState(870)
 
 		var _x = p.Atom()
 
 		localctx.(*TripleStmtContext).p = _x
 	}
 	{
-		p.SetState(811)
+		p.SetState(871)
 		p.Match(JetRuleParserT__2)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -13209,14 +14137,14 @@ func (p *JetRuleParser) TripleStmt() (localctx ITripleStmtContext) {
 		}
 	}
 	{
-		p.SetState(812)
+		p.SetState(872)
 
 		var _x = p.ObjectAtom()
 
 		localctx.(*TripleStmtContext).o = _x
 	}
 	{
-		p.SetState(813)
+		p.SetState(873)
 		p.Match(JetRuleParserT__10)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -13224,7 +14152,7 @@ func (p *JetRuleParser) TripleStmt() (localctx ITripleStmtContext) {
 		}
 	}
 	{
-		p.SetState(814)
+		p.SetState(874)
 		p.Match(JetRuleParserSEMICOLON)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -13247,7 +14175,7 @@ errorExit:
 
 func (p *JetRuleParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
-	case 50:
+	case 53:
 		var t *ExprTermContext = nil
 		if localctx != nil {
 			t = localctx.(*ExprTermContext)
@@ -6,8 +6,6 @@ import (
 	"log"
 	"os"
 	"regexp"
-
-	"github.com/artisoft-io/jetstore/jets/awsi"
 )
 
 // Common functions and types for cp lambda version
@@ -153,7 +151,7 @@ func WriteCpipesArgsToS3(cpa []ComputePipesNodeArgs, s3Location string) error {
 	if err != nil {
 		return err
 	}
-	err = awsi.UploadToS3(os.Getenv("JETS_BUCKET"), os.Getenv("JETS_REGION"), s3Location, f)
+	err = objectStore.PutObject("", s3Location, f)
 	if err != nil {
 		return fmt.Errorf("while copying cpipes arg to s3: %v", err)
 	}
@@ -172,7 +170,7 @@ func ReadCpipesArgsFromS3(s3Location string) ([]ComputePipesNodeArgs, error) {
 	defer os.Remove(f.Name()) // clean up
 
 	// Download the object
-	_, err = awsi.DownloadFromS3(os.Getenv("JETS_BUCKET"), os.Getenv("JETS_REGION"), s3Location, f)
+	_, err = objectStore.GetObject("", s3Location, nil, f)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download cpipes arg from s3: %v", err)
 	}
@@ -258,6 +256,7 @@ type ComputePipesContext struct {
 	DownloadS3ResultCh    chan DownloadS3Result // avoid to modify ChannelResult for now...
 	S3DeviceMgr           *S3DeviceManager
 	SchemaManager         *SchemaManager
+	LookupTableManager    *LookupTableManager
 }
 
 func (cpCtx *ComputePipesContext) DoneAll(err error) {
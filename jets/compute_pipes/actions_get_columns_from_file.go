@@ -10,7 +10,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/artisoft-io/jetstore/jets/awsi"
 	"github.com/artisoft-io/jetstore/jets/csv"
 	"github.com/artisoft-io/jetstore/jets/datatable/jcsv"
 )
@@ -68,7 +67,7 @@ func FetchHeadersAndDelimiterFromFile(externalBucket, fileKey, fileFormat, compr
 	retry := 0
 do_retry:
 	// Download the object
-	fileSize, err := awsi.DownloadFromS3v2(downloader, externalBucket, fileKey, byteRange, fileHd)
+	fileSize, err := objectStore.GetObject(externalBucket, fileKey, byteRange, fileHd)
 	if err != nil {
 		if retry < 6 {
 			time.Sleep(500 * time.Millisecond)
@@ -123,6 +122,17 @@ do_retry:
 					fileKey)
 		}
 
+	case fileFormat == "avro" || fileFormat == "orc":
+		if fetchHeaders {
+			// Get the file headers from the avro or orc schema
+			fileInfo.Headers, err = GetRawHeadersTyped(fileHd, fileKey, fileFormat)
+			return fileInfo, err
+		} else {
+			return nil,
+				fmt.Errorf("error: in FetchHeadersAndDelimiterFromFile for %s file called, but fetchHeaders is false (bug), filekey: %s",
+					fileFormat, fileKey)
+		}
+
 	case fileFormat == "fixed_width":
 		if fetchEncoding {
 			fileInfo.Encoding, err = DetectFileEncoding(fileHd, 0)
@@ -80,9 +80,11 @@ func (cpCtx *ComputePipesContext) loadMainInput(computePipesInputCh chan []any,
 	var fwEncodingInfo *FixedWidthEncodingInfo
 	var xlsxSheetInfo map[string]any
 	var reorderColumnsOnRead []int
+	var jsonPaths map[string]string
 	sp := cpCtx.SchemaManager.GetSchemaProvider(inputChannelConfig.SchemaProvider)
 	if sp != nil {
 		fwEncodingInfo = sp.FixedWidthEncodingInfo()
+		jsonPaths = jsonPathsOf(sp)
 		sheetInfoJson := sp.InputFormatDataJson()
 		if cpCtx.CpConfig.CommonRuntimeArgs.CpipesMode == "sharding" {
 			reorderColumnsOnRead = sp.ReorderColumnsOnRead()
@@ -174,6 +176,15 @@ func (cpCtx *ComputePipesContext) loadMainInput(computePipesInputCh chan []any,
 						count, badRowCount, err = cpCtx.ReadFixedWidthFile(
 							&localInFile, fileHd, fwEncodingInfo, castToRdfTxtTypeFncs, reorderColumnsOnRead, computePipesInputCh, badRowChannel)
 
+					case "jsonl":
+						count, badRowCount, err = cpCtx.ReadJsonlFile(
+							&localInFile, fileHd, jsonPaths, castToRdfTxtTypeFncs, reorderColumnsOnRead, computePipesInputCh, badRowChannel)
+
+					case "avro", "orc":
+						count, err = cpCtx.ReadTypedFile(
+							&localInFile, fileHd, inputFormat, castToRdfTxtTypeFncs, reorderColumnsOnRead, computePipesInputCh)
+						badRowCount = 0
+
 					default:
 						err = fmt.Errorf("%s node %d, error: unsupported file format: %s", cpCtx.SessionId, cpCtx.NodeId, inputFormat)
 						log.Println(err)
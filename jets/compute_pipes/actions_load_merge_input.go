@@ -24,8 +24,8 @@ func (cpCtx *ComputePipesContext) loadMergeInput(computePipesInputCh chan []any,
 	}()
 
 	// Load the merge input files
-	if inputChannelConfig == nil || inputChannelConfig.Type != "stage" {
-		err = fmt.Errorf("unexpected error: invalid input channel config for loadMergeInput, must be type 'stage', got: %+v", inputChannelConfig)
+	if inputChannelConfig == nil || (inputChannelConfig.Type != "stage" && inputChannelConfig.Type != "input") {
+		err = fmt.Errorf("unexpected error: invalid input channel config for loadMergeInput, must be type 'stage' or 'input', got: %+v", inputChannelConfig)
 		log.Println(err)
 		cpCtx.ChResults.LoadFromS3FilesResultCh <- LoadFromS3FilesResult{LoadRowCount: 0, BadRowCount: 0, Err: err}
 		return
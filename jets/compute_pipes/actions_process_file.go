@@ -187,6 +187,11 @@ func (cpCtx *ComputePipesContext) ProcessFilesAndReportStatus(ctx context.Contex
 	}
 	// log.Println("**@= CP RESULT = WritePartitionsResultCh: DONE")
 
+	// The compute pipes are done, release the lookup tables (e.g. the on-disk stores of kv_lookup)
+	if cpCtx.LookupTableManager != nil {
+		cpCtx.LookupTableManager.ReleaseLookupTables()
+	}
+
 	// Get the result from S3DeviceManager
 	// cpCtx.S3DeviceMgr == nil when cpCtx.ComputePipesArgs.MergeFiles == true
 	if cpCtx.S3DeviceMgr != nil {
@@ -0,0 +1,166 @@
+package compute_pipes
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/golang/snappy"
+)
+
+// ReadJsonlFile reads a jsonl (JSON Lines) file, each line is a json object that is
+// mapped to one or more rows using jsonPaths (json_path of the columns by column name).
+// Lines that are not valid json objects are sent to the bad rows channel.
+// Note: jsonl files are not split when sharding, a file is read in full by a single node.
+func (cpCtx *ComputePipesContext) ReadJsonlFile(
+	filePath *FileName, fileReader ReaderAtSeeker, jsonPaths map[string]string,
+	castToRdfTxtTypeFncs []*CastToRdfTxtFnc, reorderColumnsOnRead []int,
+	computePipesInputCh chan<- []any, badRowChannel *BadRowsChannel) (int64, int64, error) {
+
+	var err error
+	inputChannelConfig := cpCtx.CpConfig.PipesConfig[0].InputChannel
+	samplingRate := inputChannelConfig.SamplingRate
+	samplingMaxCount := int64(inputChannelConfig.SamplingMaxCount)
+	encoding := inputChannelConfig.Encoding
+	compression := inputChannelConfig.Compression
+	nbrColumns := len(cpCtx.CpConfig.CommonRuntimeArgs.SourcesConfig.MainInput.InputColumns)
+	var inputColumns []string
+	var extColumns []string
+	switch cpCtx.CpConfig.CommonRuntimeArgs.CpipesMode {
+	case "sharding":
+		// SourcesConfig.MainInput.InputColumns include the partfile_key_component and the add'l ones from input channel
+		inputColumns =
+			cpCtx.CpConfig.CommonRuntimeArgs.SourcesConfig.MainInput.InputColumns[:nbrColumns-len(cpCtx.PartFileKeyComponents)-
+				len(cpCtx.AddionalInputHeaders)]
+		// Prepare the extended columns from partfile_key_component
+		if len(cpCtx.PartFileKeyComponents) > 0 {
+			extColumns = make([]string, len(cpCtx.PartFileKeyComponents))
+			for i := range cpCtx.PartFileKeyComponents {
+				result := cpCtx.PartFileKeyComponents[i].Regex.FindStringSubmatch(filePath.InFileKeyInfo.key)
+				if len(result) > 1 {
+					extColumns[i] = result[1]
+				}
+			}
+		}
+	case "reducing":
+		inputColumns = cpCtx.CpConfig.CommonRuntimeArgs.SourcesConfig.MainInput.InputColumns
+	default:
+		return 0, 0, fmt.Errorf("error: unknown cpipes mode in ReadJsonlFile: %s", cpCtx.CpConfig.CommonRuntimeArgs.CpipesMode)
+	}
+
+	rowMapper, err := newJsonlRowMapper(inputColumns, jsonPaths)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Setup the jsonl reader
+	var utfReader io.Reader
+	switch compression {
+	case "", "none":
+		utfReader, err = WrapReaderWithDecoder(fileReader, encoding)
+	case "snappy":
+		utfReader, err = WrapReaderWithDecoder(snappy.NewReader(fileReader), encoding)
+	default:
+		return 0, 0, fmt.Errorf("error: unknown compression in ReadJsonlFile: %s", compression)
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("while WrapReaderWithDecoder for encoding '%s' (ReadJsonlFile): %v", encoding, err)
+	}
+	// Using a bufio.Reader rather than a Scanner since json lines can be very long
+	jsonlReader := bufio.NewReader(utfReader)
+
+	var inputRowCount, badRowCount int64
+	var line []byte
+	for {
+		// Kill Switch - prevent lambda timeout
+		if cpCtx.CpConfig.ClusterConfig.KillSwitchMin > 0 &&
+			time.Since(ComputePipesStart).Minutes() >= float64(cpCtx.CpConfig.ClusterConfig.KillSwitchMin) {
+			return inputRowCount, badRowCount, ErrKillSwitch
+		}
+		line, err = jsonlReader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return inputRowCount, badRowCount, fmt.Errorf("error while reading input jsonl records: %v", err)
+		}
+		isEOF := err == io.EOF
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			if isEOF {
+				return inputRowCount, badRowCount, nil
+			}
+			continue
+		}
+		cpCtx.SamplingCount += 1
+		switch {
+		case inputRowCount > 0 && samplingRate > 0 && cpCtx.SamplingCount < samplingRate:
+		case samplingMaxCount > 0 && inputRowCount >= samplingMaxCount:
+		default:
+			cpCtx.SamplingCount = 0
+			doc, errLine := parseJsonlLine(line)
+			if errLine != nil {
+				// Got a bad row
+				if badRowChannel == nil {
+					return inputRowCount, badRowCount + 1,
+						fmt.Errorf("while reading input records (ReadJsonlFile): invalid json line: %v", errLine)
+				}
+				if cpCtx.CpConfig.ClusterConfig.IsDebugMode {
+					log.Printf("%s node %d Invalid jsonl line sent to bad rows: %v", cpCtx.SessionId, cpCtx.NodeId, errLine)
+				}
+				select {
+				case badRowChannel.OutputCh <- append(line, '\n'):
+				case <-cpCtx.Done:
+					log.Println("Sending bad input row interrupted (ReadJsonlFile)")
+					return inputRowCount, badRowCount, nil
+				}
+				badRowCount += 1
+				break
+			}
+			for _, row := range rowMapper.Rows(doc) {
+				record := make([]any, nbrColumns)
+				for i := range row {
+					switch {
+					case row[i] == nil:
+					case i < len(castToRdfTxtTypeFncs) && castToRdfTxtTypeFncs[i] != nil:
+						record[i], err = castToRdfTxtTypeFncs[i].Cast(row[i].(string))
+						if err != nil {
+							return inputRowCount, badRowCount,
+								fmt.Errorf("error while applying castToRdfTxtTypeFncs (ReadJsonlFile): %v", err)
+						}
+					default:
+						record[i] = row[i]
+					}
+				}
+				// Add the columns from the partfile_key_component
+				if len(extColumns) > 0 {
+					offset := len(inputColumns)
+					for i := range extColumns {
+						record[offset+i] = extColumns[i]
+					}
+				}
+				if len(reorderColumnsOnRead) > 0 {
+					m := min(len(reorderColumnsOnRead), len(record))
+					reordered := make([]any, len(record))
+					for i := range m {
+						reordered[i] = record[reorderColumnsOnRead[i]]
+					}
+					for i := m; i < len(record); i++ {
+						reordered[i] = record[i]
+					}
+					record = reordered
+				}
+				select {
+				case computePipesInputCh <- record:
+				case <-cpCtx.Done:
+					log.Println("loading input jsonl row from file interrupted")
+					return inputRowCount, badRowCount, nil
+				}
+				inputRowCount += 1
+			}
+		}
+		if isEOF {
+			return inputRowCount, badRowCount, nil
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package compute_pipes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+// ReadTypedFile reads avro and orc files. The columns are mapped to the same
+// types as with ReadParquetFileV2.
+// When sharding, the file is split by byte range: the avro blocks and orc stripes
+// are read by the shard where they start.
+func (cpCtx *ComputePipesContext) ReadTypedFile(filePath *FileName, fileReader ReaderAtSeeker,
+	inputFormat string, castToRdfTxtTypeFncs []*CastToRdfTxtFnc, reorderColumnsOnRead []int,
+	computePipesInputCh chan<- []any) (int64, error) {
+
+	var inputColumns []string
+	var err error
+	samplingRate := int64(cpCtx.CpConfig.PipesConfig[0].InputChannel.SamplingRate)
+	samplingMaxCount := int64(cpCtx.CpConfig.PipesConfig[0].InputChannel.SamplingMaxCount)
+
+	// Here nbrColumns is the nbr of columns in the file (excluding the extra columns added by the process)
+	nbrColumns := len(cpCtx.CpConfig.CommonRuntimeArgs.SourcesConfig.MainInput.InputColumns) -
+		len(cpCtx.PartFileKeyComponents) - len(cpCtx.AddionalInputHeaders)
+	if nbrColumns > 0 {
+		// Read specified columns
+		inputColumns = cpCtx.CpConfig.CommonRuntimeArgs.SourcesConfig.MainInput.InputColumns[:nbrColumns]
+	}
+	inputChannelConfig := &cpCtx.CpConfig.PipesConfig[0].InputChannel
+
+	fileSize, err := fileReader.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("while getting the size of '%s' (ReadTypedFile): %v", filePath.LocalFileName, err)
+	}
+	reader, err := newTypedFileReader(inputFormat, fileReader, fileSize)
+	if err != nil {
+		return 0, fmt.Errorf("while opening the %s file reader for '%s' (ReadTypedFile): %v", inputFormat, filePath.LocalFileName, err)
+	}
+	schemaInfo := reader.SchemaInfo()
+
+	// Check if we read only a portion of the file
+	start, end := shardByteRange(fileSize, filePath.InFileKeyInfo.start, filePath.InFileKeyInfo.end)
+	if cpCtx.CpConfig.ClusterConfig.IsDebugMode {
+		log.Println("*** The", inputFormat, "file has", fileSize, "bytes, reading from byte", start, "to", end,
+			"start", filePath.InFileKeyInfo.start, "end", filePath.InFileKeyInfo.end)
+	}
+
+	// Make the list of column idx to read
+	var columnIndices []int
+	if nbrColumns > 0 {
+		columnIndices = make([]int, 0, nbrColumns)
+		for _, c := range inputColumns {
+			idx := -1
+			for i, fi := range schemaInfo.Fields {
+				if fi.Name == c {
+					idx = i
+					break
+				}
+			}
+			if idx < 0 {
+				si, _ := json.Marshal(schemaInfo)
+				log.Printf("error: column %s is not found in %s schema of part file (ReadTypedFile), schema of part file:\n %s", c, inputFormat, string(si))
+				return 0, fmt.Errorf("error: column %s is not found in the %s schema of '%s' (ReadTypedFile)", c, inputFormat, cpCtx.FileKey)
+			}
+			columnIndices = append(columnIndices, idx)
+		}
+	} else {
+		// Get the columns from the schema
+		for i, fi := range schemaInfo.Fields {
+			inputColumns = append(inputColumns, fi.Name)
+			columnIndices = append(columnIndices, i)
+		}
+		nbrColumns = len(inputColumns)
+	}
+
+	// Prepare the extended columns from partfile_key_component
+	var extColumns []string
+	if len(cpCtx.PartFileKeyComponents) > 0 {
+		extColumns = make([]string, len(cpCtx.PartFileKeyComponents))
+		for i := range cpCtx.PartFileKeyComponents {
+			result := cpCtx.PartFileKeyComponents[i].Regex.FindStringSubmatch(filePath.InFileKeyInfo.key)
+			if len(result) > 1 {
+				extColumns[i] = result[1]
+			}
+		}
+	}
+
+	// Determine if trim the columns
+	trimColumns := false
+	if cpCtx.CpConfig.CommonRuntimeArgs.CpipesMode == "sharding" {
+		trimColumns = inputChannelConfig.TrimColumns
+	}
+
+	var inputRowCount int64
+	var castFnc *CastToRdfTxtFnc
+	nbrPartFileKeyColumns := len(cpCtx.PartFileKeyComponents)
+	err = reader.ReadRows(start, end, columnIndices, func(values []any) error {
+		cpCtx.SamplingCount += 1
+		if samplingRate > 0 && int64(cpCtx.SamplingCount) < samplingRate {
+			return nil
+		}
+		if samplingMaxCount > 0 && inputRowCount >= samplingMaxCount {
+			return errStopReading
+		}
+		cpCtx.SamplingCount = 0
+		record := make([]any, nbrColumns+nbrPartFileKeyColumns, nbrColumns+nbrPartFileKeyColumns+len(cpCtx.AddionalInputHeaders))
+		for jcol, v := range values {
+			if castToRdfTxtTypeFncs != nil {
+				castFnc = castToRdfTxtTypeFncs[jcol]
+			}
+			fieldInfo := schemaInfo.Fields[columnIndices[jcol]]
+			value, errCol := typedValueToTxt(v, fieldInfo, trimColumns, castFnc)
+			if errCol != nil {
+				return fmt.Errorf("while reading input records (ReadTypedFile) for column %d (%s) with value %v: %v",
+					jcol, fieldInfo.Name, v, errCol)
+			}
+			record[jcol] = value
+		}
+		// Add the columns from the partfile_key_component
+		for i := range extColumns {
+			record[nbrColumns+i] = extColumns[i]
+		}
+		// Add placeholders for the additional input headers/columns
+		for range cpCtx.AddionalInputHeaders {
+			record = append(record, nil)
+		}
+
+		// Kill Switch - prevent lambda timeout
+		if cpCtx.CpConfig.ClusterConfig.KillSwitchMin > 0 &&
+			time.Since(ComputePipesStart).Minutes() >= float64(cpCtx.CpConfig.ClusterConfig.KillSwitchMin) {
+			return ErrKillSwitch
+		}
+
+		if len(reorderColumnsOnRead) > 0 {
+			m := min(len(reorderColumnsOnRead), len(record))
+			row := make([]any, len(record))
+			for i := range m {
+				row[i] = record[reorderColumnsOnRead[i]]
+			}
+			for i := m; i < len(record); i++ {
+				row[i] = record[i]
+			}
+			record = row
+		}
+
+		// Send out the record
+		select {
+		case computePipesInputCh <- record:
+		case <-cpCtx.Done:
+			log.Println(cpCtx.SessionId, "node", cpCtx.NodeId, "loading input row from file interrupted")
+			return errStopReading
+		}
+		inputRowCount += 1
+		return nil
+	})
+	return inputRowCount, err
+}
+
+// newTypedFileReader returns the reader for the avro or orc file
+func newTypedFileReader(inputFormat string, fileReader ReaderAtSeeker, fileSize int64) (typedFileReader, error) {
+	switch inputFormat {
+	case "avro":
+		return newAvroFileReader(fileReader)
+	case "orc":
+		return newOrcFileReader(fileReader, fileSize)
+	}
+	return nil, fmt.Errorf("error: unsupported typed file format: %s", inputFormat)
+}
+
+// GetRawHeadersTyped returns the column names from the schema of an avro or orc file
+func GetRawHeadersTyped(fileHd *os.File, fileName, inputFormat string) ([]string, error) {
+	info, err := fileHd.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("while getting the size of %s file %s (GetRawHeadersTyped): %v", inputFormat, fileName, err)
+	}
+	reader, err := newTypedFileReader(inputFormat, fileHd, info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("while opening the %s file reader for %s (GetRawHeadersTyped): %v", inputFormat, fileName, err)
+	}
+	rawHeaders := reader.SchemaInfo().Columns()
+
+	// Make sure we don't have empty names in rawHeaders
+	AdjustFillers(&rawHeaders)
+	fmt.Printf("Got input columns (rawHeaders) from %s file: %v\n", inputFormat, rawHeaders)
+	return rawHeaders, nil
+}
@@ -14,7 +14,6 @@ import (
 
 	"github.com/artisoft-io/jetstore/jets/awsi"
 	"github.com/artisoft-io/jetstore/jets/utils"
-	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -22,16 +21,16 @@ import (
 // Common functions and types for s3, rewite of loader's version
 
 var bucketName, regionName, kmsKeyArn string
-var downloader *manager.Downloader
+var objectStore ObjectStore
 
 func init() {
 	bucketName = os.Getenv("JETS_BUCKET")
 	regionName = os.Getenv("JETS_REGION")
 	kmsKeyArn = os.Getenv("JETS_S3_KMS_KEY_ARN")
 	var err error
-	downloader, err = awsi.NewDownloader(regionName)
+	objectStore, err = NewObjectStore()
 	if err != nil {
-		log.Fatalf("while init s3 downloader for region %s: %v", regionName, err)
+		log.Fatalf("while init object store for region %s: %v", regionName, err)
 	}
 }
 
@@ -106,7 +105,7 @@ func GetS3Objects4LookbackPeriod(bucket, fileKey, lookbackPeriod string, env map
 		fileKeyPrefix := utils.ReplaceEnvVars(fileKey, lookbackEnv)
 		log.Printf("  Lookback period_id: %d, downloading file keys from s3 stage folder: %s",
 			lookbackEnv["${PERIOD_ID}"], fileKeyPrefix)
-		periodS3Objects, err := awsi.ListS3Objects(bucket, &fileKeyPrefix)
+		periodS3Objects, err := objectStore.ListObjects(bucket, fileKeyPrefix)
 		if err != nil {
 			return nil, fmt.Errorf("failed to download list of files from s3 for lookback period_id %d: %v",
 				lookbackEnv["${PERIOD_ID}"], err)
@@ -136,7 +135,7 @@ func GetPartitionSize4LookbackPeriod(bucket, fileKey, lookbackPeriod string, env
 		fileKeyPrefix := utils.ReplaceEnvVars(fileKey, lookbackEnv)
 		log.Printf("  Lookback period_id: %d, downloading file keys from s3 stage folder: %s",
 			lookbackEnv["${PERIOD_ID}"], fileKeyPrefix)
-		periodS3Objects, err := awsi.ListS3Objects(bucket, &fileKeyPrefix)
+		periodS3Objects, err := objectStore.ListObjects(bucket, fileKeyPrefix)
 		if err != nil {
 			return fmt.Errorf("failed to download list of files from s3 for lookback period_id %d: %v",
 				lookbackEnv["${PERIOD_ID}"], err)
@@ -154,6 +153,15 @@ func GetPartitionSize4LookbackPeriod(bucket, fileKey, lookbackPeriod string, env
 	return nil
 }
 
+// mergeChannelPrefix returns the s3 prefix of the merge channel file key,
+// the input folder for type input, the stage folder otherwise.
+func mergeChannelPrefix(config *InputChannelConfig) string {
+	if config.Type == "input" {
+		return awsi.JetStoreInputPrefix()
+	}
+	return awsi.JetStoreStagePrefix()
+}
+
 // Get the file_key(s) from s3 for the given process/session/step/partition.
 // This is used during the reducing mode.
 func GetS3FileKeys(processName, sessionId, mainInputStepId, jetsPartitionLabel string,
@@ -170,7 +178,7 @@ func GetS3FileKeys(processName, sessionId, mainInputStepId, jetsPartitionLabel s
 	if len(inputChannelConfig.FileKey) == 0 {
 		s3BaseFolder = fmt.Sprintf("%s/process_name=%s/session_id=%s/step_id=%s/jets_partition=%s",
 			awsi.JetStoreStagePrefix(), processName, sessionId, mainInputStepId, jetsPartitionLabel)
-		s3Objects, err = awsi.ListS3Objects("", &s3BaseFolder)
+		s3Objects, err = objectStore.ListObjects("", s3BaseFolder)
 		if err != nil || s3Objects == nil {
 			return nil, fmt.Errorf("failed to download list of files from s3: %v", err)
 		}
@@ -194,14 +202,17 @@ func GetS3FileKeys(processName, sessionId, mainInputStepId, jetsPartitionLabel s
 			sid = utils.ReplaceEnvVars(mergeChannelConfig.ReadSessionId, envSettings)
 		}
 		if len(mergeChannelConfig.FileKey) == 0 {
+			if mergeChannelConfig.Type == "input" {
+				return nil, fmt.Errorf("error: merge channel %d of type input must have file_key", i)
+			}
 			mergeS3BaseFolder = fmt.Sprintf("%s/process_name=%s/session_id=%s/step_id=%s/jets_partition=%s",
 				awsi.JetStoreStagePrefix(), processName, sid, mergeChannelConfig.ReadStepId, jetsPartitionLabel)
-			s3Objects, err = awsi.ListS3Objects("", &mergeS3BaseFolder)
+			s3Objects, err = objectStore.ListObjects("", mergeS3BaseFolder)
 			if err != nil || s3Objects == nil {
 				return nil, fmt.Errorf("failed to download list of files from s3 for merge channel %d: %v", i, err)
 			}
 		} else {
-			fileKey := fmt.Sprintf("%s/%s", awsi.JetStoreStagePrefix(), mergeChannelConfig.FileKey)
+			fileKey := fmt.Sprintf("%s/%s", mergeChannelPrefix(mergeChannelConfig), mergeChannelConfig.FileKey)
 			s3Objects, err = GetS3Objects4LookbackPeriod("", fileKey,
 				mergeChannelConfig.LookbackPeriods, envSettings)
 			if err != nil {
@@ -285,7 +296,7 @@ func (cpCtx *ComputePipesContext) downloadS3Files(inFolderPath, externalBucket s
 
 	// Regular flow for non generator input channel, need to download the file(s) from s3 and send the file name(s) to the channel
 	inputFormat := inputChannelConfig.Format
-	if strings.HasPrefix(inputFormat, "parquet") {
+	if strings.HasPrefix(inputFormat, "parquet") || inputFormat == "avro" || inputFormat == "orc" {
 		fullDownload = true
 	}
 	if cpCtx.CpConfig.ClusterConfig.IsDebugMode {
@@ -360,7 +371,7 @@ func DownloadS3Object(externalBucket string, s3Key *FileKeyInfo, localDir string
 	}
 
 	// Download the object
-	nsz, err := awsi.DownloadFromS3v2(downloader, externalBucket, s3Key.key, byteRange, fileHd)
+	nsz, err := objectStore.GetObject(externalBucket, s3Key.key, byteRange, fileHd)
 	if err != nil {
 		return "", 0, fmt.Errorf("failed to download input file from bucket %s: %v", externalBucket, err)
 	}
@@ -49,7 +49,7 @@ func ShardFileKeys(exeCtx context.Context, dbpool *pgxpool.Pool, baseFileKey str
 		// Get all the file keys having baseFileKey as prefix
 		baseFileKey = utils.ReplaceEnvVars(baseFileKey, envSettings)
 		log.Printf("Downloading file keys from s3 folder: %s", baseFileKey)
-		s3Objects, err = awsi.ListS3Objects(schemaProviderConfig.Bucket, &baseFileKey)
+		s3Objects, err = objectStore.ListObjects(schemaProviderConfig.Bucket, baseFileKey)
 		if err != nil {
 			cpErr = fmt.Errorf("failed to download list of files from s3: %v", err)
 			return
@@ -69,7 +69,7 @@ func ShardFileKeys(exeCtx context.Context, dbpool *pgxpool.Pool, baseFileKey str
 		} else {
 			fileKeyPrefix := utils.ReplaceEnvVars(fileKey, envSettings)
 			log.Printf("Downloading file keys from s3 stage folder: %s", fileKeyPrefix)
-			s3Objects, err = awsi.ListS3Objects(schemaProviderConfig.Bucket, &fileKeyPrefix)
+			s3Objects, err = objectStore.ListObjects(schemaProviderConfig.Bucket, fileKeyPrefix)
 			if err != nil {
 				cpErr = fmt.Errorf("failed to download list of files from s3: %v", err)
 				return
@@ -86,7 +86,7 @@ func ShardFileKeys(exeCtx context.Context, dbpool *pgxpool.Pool, baseFileKey str
 	mergeS3Objects = make([][]*awsi.S3Object, len(inputChannelConfig.MergeChannels))
 	for i := range inputChannelConfig.MergeChannels {
 		mergeConfig := inputChannelConfig.MergeChannels[i]
-		fileKey := fmt.Sprintf("%s/%s", awsi.JetStoreStagePrefix(), mergeConfig.FileKey)
+		fileKey := fmt.Sprintf("%s/%s", mergeChannelPrefix(&mergeConfig), mergeConfig.FileKey)
 		mergeObjects, err := GetS3Objects4LookbackPeriod(mergeConfig.Bucket, fileKey,
 			mergeConfig.LookbackPeriods, envSettings)
 		if err != nil {
@@ -136,7 +136,9 @@ func ShardFileKeys(exeCtx context.Context, dbpool *pgxpool.Pool, baseFileKey str
 		switch schemaProviderConfig.Format {
 		case "csv", "headerless_csv", "fixed_width":
 			doSplitFiles = true
-		case "parquet", "parquet_select":
+		case "parquet", "parquet_select", "avro", "orc":
+			// avro and orc files are split in shards as parquet files,
+			// the shard of a row group (avro block, orc stripe) is determined by the reader
 			doSplitFiles = true
 			isParquet = true
 		}
@@ -745,12 +745,18 @@ func MergeTransformationSpec(host, override *TransformationSpec) error {
 	if override.SortConfig != nil {
 		host.SortConfig = override.SortConfig
 	}
+	if override.WindowConfig != nil {
+		host.WindowConfig = override.WindowConfig
+	}
 	if override.JetrulesConfig != nil {
 		host.JetrulesConfig = override.JetrulesConfig
 	}
 	if override.ClusteringConfig != nil {
 		host.ClusteringConfig = override.ClusteringConfig
 	}
+	if override.JoinConfig != nil {
+		host.JoinConfig = override.JoinConfig
+	}
 	if override.OutputChannel.Name != "" {
 		host.OutputChannel = override.OutputChannel
 	}
@@ -876,7 +882,7 @@ func (args *CpipesStartup) ValidatePipeSpecConfig(cpConfig *ComputePipesConfig,
 				}
 				config := transformationConfig.PartitionWriterConfig
 				switch config.DeviceWriterType {
-				case "csv_writer", "parquet_writer", "fixed_width_writer":
+				case "csv_writer", "parquet_writer", "fixed_width_writer", "jsonl_writer":
 				default:
 					if config.DeviceWriterType == "" && sp == nil {
 						return fmt.Errorf(
@@ -892,6 +898,8 @@ func (args *CpipesStartup) ValidatePipeSpecConfig(cpConfig *ComputePipesConfig,
 							deviceWriterType = "parquet_writer"
 						case "fixed_width":
 							deviceWriterType = "fixed_width_writer"
+						case "jsonl":
+							deviceWriterType = "jsonl_writer"
 						default:
 							err := fmt.Errorf("configuration error: unsupported output file format: %s (in NewPartitionWriterTransformationPipe)", sp.Format)
 							log.Println(err)
@@ -901,7 +909,7 @@ func (args *CpipesStartup) ValidatePipeSpecConfig(cpConfig *ComputePipesConfig,
 						outputChConfig.Format = sp.Format
 					} else {
 						return fmt.Errorf(
-							"configuration error: unknown/invalid device_writer_type '%s' for partition_writer (valid type: csv_writer, parquet_writer, fixed_width_writer)",
+							"configuration error: unknown/invalid device_writer_type '%s' for partition_writer (valid type: csv_writer, parquet_writer, fixed_width_writer, jsonl_writer)",
 							config.DeviceWriterType)
 					}
 				}
@@ -137,7 +137,7 @@ func (args *StartComputePipesArgs) StartShardingComputePipes(ctx context.Context
 		detectEncoding = true
 	}
 	format := inputChannelConfig.Format
-	if (format == "csv" || format == "xlsx") && len(cpipesStartup.InputColumns) == 0 {
+	if (format == "csv" || format == "xlsx" || format == "avro" || format == "orc") && len(cpipesStartup.InputColumns) == 0 {
 		fetchHeaders = true
 	}
 	if strings.HasSuffix(format, "csv") {
@@ -0,0 +1,643 @@
+package compute_pipes
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/golang/snappy"
+)
+
+// Reader for avro object container files.
+// Supported codecs: null, deflate, snappy.
+// The top-level schema must be a record, the fields of the record are the columns.
+// Nullable fields (union of null and a type) have the type of the non-null branch.
+// Logical types date, timestamp-millis, timestamp-micros (and local-*), time-millis,
+// time-micros and decimal are supported.
+
+var avroMagic = []byte{'O', 'b', 'j', 1}
+
+type avroSchema struct {
+	kind        string
+	name        string
+	logicalType string
+	precision   int32
+	scale       int32
+	size        int
+	symbols     []string
+	fields      []avroField
+	items       *avroSchema
+	branches    []*avroSchema
+}
+
+type avroField struct {
+	name   string
+	schema *avroSchema
+}
+
+// parseAvroSchema parses the json avro schema
+func parseAvroSchema(schemaJson []byte) (*avroSchema, error) {
+	var raw any
+	err := json.Unmarshal(schemaJson, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing avro schema json: %v", err)
+	}
+	return compileAvroSchema(raw, "", make(map[string]*avroSchema))
+}
+
+func compileAvroSchema(raw any, namespace string, named map[string]*avroSchema) (*avroSchema, error) {
+	switch vv := raw.(type) {
+	case string:
+		switch vv {
+		case "null", "boolean", "int", "long", "float", "double", "bytes", "string":
+			return &avroSchema{kind: vv}, nil
+		}
+		// Reference to a named type
+		if s, ok := named[vv]; ok {
+			return s, nil
+		}
+		if s, ok := named[namespace+"."+vv]; ok {
+			return s, nil
+		}
+		return nil, fmt.Errorf("error: unknown avro type '%s'", vv)
+
+	case []any:
+		s := &avroSchema{kind: "union"}
+		for _, b := range vv {
+			branch, err := compileAvroSchema(b, namespace, named)
+			if err != nil {
+				return nil, err
+			}
+			s.branches = append(s.branches, branch)
+		}
+		return s, nil
+
+	case map[string]any:
+		kind, _ := vv["type"].(string)
+		if kind == "" {
+			// type is itself a schema, e.g. {"type": {"type": "array", ...}}
+			if t, ok := vv["type"]; ok {
+				return compileAvroSchema(t, namespace, named)
+			}
+			return nil, fmt.Errorf("error: avro schema is missing type")
+		}
+		var s *avroSchema
+		switch kind {
+		case "record", "error", "enum", "fixed":
+			s = &avroSchema{kind: kind}
+			name, _ := vv["name"].(string)
+			if ns, ok := vv["namespace"].(string); ok && ns != "" {
+				namespace = ns
+			}
+			s.name = name
+			named[name] = s
+			if namespace != "" {
+				named[namespace+"."+name] = s
+			}
+			switch kind {
+			case "record", "error":
+				s.kind = "record"
+				fields, _ := vv["fields"].([]any)
+				for _, f := range fields {
+					fm, ok := f.(map[string]any)
+					if !ok {
+						return nil, fmt.Errorf("error: invalid field in avro record '%s'", name)
+					}
+					fieldName, _ := fm["name"].(string)
+					fieldSchema, err := compileAvroSchema(fm["type"], namespace, named)
+					if err != nil {
+						return nil, fmt.Errorf("while compiling field '%s' of avro record '%s': %v", fieldName, name, err)
+					}
+					s.fields = append(s.fields, avroField{name: fieldName, schema: fieldSchema})
+				}
+			case "enum":
+				symbols, _ := vv["symbols"].([]any)
+				for _, sym := range symbols {
+					str, _ := sym.(string)
+					s.symbols = append(s.symbols, str)
+				}
+			case "fixed":
+				size, _ := vv["size"].(float64)
+				s.size = int(size)
+			}
+		case "array":
+			items, err := compileAvroSchema(vv["items"], namespace, named)
+			if err != nil {
+				return nil, err
+			}
+			s = &avroSchema{kind: kind, items: items}
+		case "map":
+			values, err := compileAvroSchema(vv["values"], namespace, named)
+			if err != nil {
+				return nil, err
+			}
+			s = &avroSchema{kind: kind, items: values}
+		default:
+			base, err := compileAvroSchema(kind, namespace, named)
+			if err != nil {
+				return nil, err
+			}
+			// Make a copy so the logical type does not alter a named type
+			cp := *base
+			s = &cp
+		}
+		if lt, ok := vv["logicalType"].(string); ok {
+			s.logicalType = lt
+			p, _ := vv["precision"].(float64)
+			sc, _ := vv["scale"].(float64)
+			s.precision = int32(p)
+			s.scale = int32(sc)
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("error: invalid avro schema element: %v", raw)
+}
+
+// nonNullBranch returns the non null branch of a nullable union, nil if s is not a nullable union
+func (s *avroSchema) nonNullBranch() *avroSchema {
+	if s.kind != "union" || len(s.branches) != 2 {
+		return nil
+	}
+	switch {
+	case s.branches[0].kind == "null":
+		return s.branches[1]
+	case s.branches[1].kind == "null":
+		return s.branches[0]
+	}
+	return nil
+}
+
+// fieldInfo returns the schema field info of the avro type, using the arrow type names
+func (s *avroSchema) fieldInfo(name string) *FieldInfo {
+	fi := &FieldInfo{Name: name}
+	if b := s.nonNullBranch(); b != nil {
+		s = b
+		fi.Nullable = true
+	}
+	switch s.logicalType {
+	case "date":
+		fi.Type = "date32"
+		return fi
+	case "timestamp-millis", "timestamp-micros", "local-timestamp-millis", "local-timestamp-micros":
+		fi.Type = "timestamp"
+		return fi
+	case "decimal":
+		fi.Type = "decimal"
+		fi.DecimalPrecision = s.precision
+		fi.DecimalScale = s.scale
+		return fi
+	}
+	switch s.kind {
+	case "boolean":
+		fi.Type = "bool"
+	case "int":
+		fi.Type = "int32"
+	case "long":
+		fi.Type = "int64"
+	case "float":
+		fi.Type = "float32"
+	case "double":
+		fi.Type = "float64"
+	case "bytes", "fixed":
+		fi.Type = "binary"
+	default:
+		// string, enum and nested types (as json)
+		fi.Type = "utf8"
+	}
+	return fi
+}
+
+// avroDecoder decodes avro binary encoded values from buf
+type avroDecoder struct {
+	buf []byte
+	pos int
+}
+
+var errAvroShortBuffer = errors.New("error: avro data is truncated")
+
+func (d *avroDecoder) readLong() (int64, error) {
+	v, n := binary.Varint(d.buf[d.pos:])
+	if n <= 0 {
+		return 0, errAvroShortBuffer
+	}
+	d.pos += n
+	return v, nil
+}
+
+func (d *avroDecoder) readBytes() ([]byte, error) {
+	l, err := d.readLong()
+	if err != nil {
+		return nil, err
+	}
+	return d.readFixed(int(l))
+}
+
+func (d *avroDecoder) readFixed(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.buf) {
+		return nil, errAvroShortBuffer
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// decode the value of schema s
+func (d *avroDecoder) decode(s *avroSchema) (any, error) {
+	switch s.kind {
+	case "null":
+		return nil, nil
+	case "boolean":
+		b, err := d.readFixed(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case "int", "long":
+		v, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		switch s.logicalType {
+		case "date":
+			return time.Unix(v*86400, 0).UTC(), nil
+		case "timestamp-millis", "local-timestamp-millis":
+			return time.UnixMilli(v).UTC(), nil
+		case "timestamp-micros", "local-timestamp-micros":
+			return time.UnixMicro(v).UTC(), nil
+		}
+		if s.kind == "int" {
+			return int32(v), nil
+		}
+		return v, nil
+	case "float":
+		b, err := d.readFixed(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+	case "double":
+		b, err := d.readFixed(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case "bytes", "string", "fixed":
+		var b []byte
+		var err error
+		if s.kind == "fixed" {
+			b, err = d.readFixed(s.size)
+		} else {
+			b, err = d.readBytes()
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case s.logicalType == "decimal":
+			return typedDecimal{unscaled: twosComplementToBigInt(b), scale: s.scale}, nil
+		case s.kind == "string":
+			return string(b), nil
+		}
+		// copy since buf is reused
+		return bytes.Clone(b), nil
+	case "enum":
+		v, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if v < 0 || int(v) >= len(s.symbols) {
+			return nil, fmt.Errorf("error: invalid avro enum index %d", v)
+		}
+		return s.symbols[v], nil
+	case "union":
+		v, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if v < 0 || int(v) >= len(s.branches) {
+			return nil, fmt.Errorf("error: invalid avro union index %d", v)
+		}
+		return d.decode(s.branches[v])
+	case "record":
+		record := make(map[string]any, len(s.fields))
+		for _, f := range s.fields {
+			v, err := d.decode(f.schema)
+			if err != nil {
+				return nil, err
+			}
+			record[f.name] = v
+		}
+		return record, nil
+	case "array", "map":
+		var arr []any
+		var m map[string]any
+		if s.kind == "array" {
+			arr = make([]any, 0)
+		} else {
+			m = make(map[string]any)
+		}
+		for {
+			count, err := d.readLong()
+			if err != nil {
+				return nil, err
+			}
+			if count == 0 {
+				break
+			}
+			if count < 0 {
+				// block size follows
+				count = -count
+				if _, err = d.readLong(); err != nil {
+					return nil, err
+				}
+			}
+			for range count {
+				var key []byte
+				if m != nil {
+					if key, err = d.readBytes(); err != nil {
+						return nil, err
+					}
+				}
+				v, err := d.decode(s.items)
+				if err != nil {
+					return nil, err
+				}
+				if m != nil {
+					m[string(key)] = v
+				} else {
+					arr = append(arr, v)
+				}
+			}
+		}
+		if m != nil {
+			return m, nil
+		}
+		return arr, nil
+	}
+	return nil, fmt.Errorf("error: unsupported avro type '%s'", s.kind)
+}
+
+// twosComplementToBigInt returns the big-endian two's-complement integer in b
+func twosComplementToBigInt(b []byte) *big.Int {
+	v := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	return v
+}
+
+// avroFileReader reads an avro object container file
+type avroFileReader struct {
+	fileReader io.ReadSeeker
+	schema     *avroSchema
+	schemaInfo *ParquetSchemaInfo
+	codec      string
+	sync       []byte
+	dataStart  int64
+}
+
+// countingReader keeps track of the position in the file
+type countingReader struct {
+	r   *bufio.Reader
+	pos int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.pos += int64(n)
+	return n, err
+}
+
+func (c *countingReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.pos++
+	}
+	return b, err
+}
+
+func newAvroFileReader(fileReader io.ReadSeeker) (*avroFileReader, error) {
+	_, err := fileReader.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	cr := &countingReader{r: bufio.NewReader(fileReader)}
+	magic := make([]byte, 4)
+	if _, err = io.ReadFull(cr, magic); err != nil || !bytes.Equal(magic, avroMagic) {
+		return nil, fmt.Errorf("error: not an avro object container file")
+	}
+	// File metadata, map of bytes
+	metadata := make(map[string][]byte)
+	for {
+		count, err := binary.ReadVarint(cr)
+		if err != nil {
+			return nil, fmt.Errorf("while reading avro file metadata: %v", err)
+		}
+		if count == 0 {
+			break
+		}
+		if count < 0 {
+			count = -count
+			if _, err = binary.ReadVarint(cr); err != nil {
+				return nil, fmt.Errorf("while reading avro file metadata: %v", err)
+			}
+		}
+		for range count {
+			key, err := readAvroBytes(cr)
+			if err != nil {
+				return nil, fmt.Errorf("while reading avro file metadata key: %v", err)
+			}
+			value, err := readAvroBytes(cr)
+			if err != nil {
+				return nil, fmt.Errorf("while reading avro file metadata value: %v", err)
+			}
+			metadata[string(key)] = value
+		}
+	}
+	sync := make([]byte, 16)
+	if _, err = io.ReadFull(cr, sync); err != nil {
+		return nil, fmt.Errorf("while reading avro sync marker: %v", err)
+	}
+	schema, err := parseAvroSchema(metadata["avro.schema"])
+	if err != nil {
+		return nil, err
+	}
+	if schema.kind != "record" {
+		return nil, fmt.Errorf("error: the avro schema must be a record, got %s", schema.kind)
+	}
+	codec := string(metadata["avro.codec"])
+	switch codec {
+	case "":
+		codec = "null"
+	case "null", "deflate", "snappy":
+	default:
+		return nil, fmt.Errorf("error: unsupported avro codec '%s', supported codecs: null, deflate, snappy", codec)
+	}
+	schemaInfo := &ParquetSchemaInfo{Fields: make([]*FieldInfo, 0, len(schema.fields))}
+	for _, f := range schema.fields {
+		schemaInfo.Fields = append(schemaInfo.Fields, f.schema.fieldInfo(f.name))
+	}
+	return &avroFileReader{
+		fileReader: fileReader,
+		schema:     schema,
+		schemaInfo: schemaInfo,
+		codec:      codec,
+		sync:       sync,
+		dataStart:  cr.pos,
+	}, nil
+}
+
+func readAvroBytes(r io.ByteReader) ([]byte, error) {
+	l, err := binary.ReadVarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if l < 0 {
+		return nil, fmt.Errorf("error: invalid avro bytes length %d", l)
+	}
+	b := make([]byte, l)
+	for i := range b {
+		if b[i], err = r.ReadByte(); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func (r *avroFileReader) SchemaInfo() *ParquetSchemaInfo {
+	return r.schemaInfo
+}
+
+// ReadRows reads the blocks starting in the byte range [start, end)
+func (r *avroFileReader) ReadRows(start, end int64, columns []int, fnc func(row []any) error) error {
+	var cr *countingReader
+	if start <= r.dataStart {
+		_, err := r.fileReader.Seek(r.dataStart, io.SeekStart)
+		if err != nil {
+			return fmt.Errorf("while seeking to avro data: %v", err)
+		}
+		cr = &countingReader{r: bufio.NewReader(r.fileReader), pos: r.dataStart}
+	} else {
+		// Position after the first sync marker that ends at or after start
+		seekPos := start - int64(len(r.sync))
+		_, err := r.fileReader.Seek(seekPos, io.SeekStart)
+		if err != nil {
+			return fmt.Errorf("while seeking to avro shard start: %v", err)
+		}
+		cr = &countingReader{r: bufio.NewReader(r.fileReader), pos: seekPos}
+		for cr.pos < start {
+			found, err := r.skipToSync(cr)
+			if err != nil {
+				return err
+			}
+			if !found {
+				return nil
+			}
+		}
+	}
+	var block []byte
+	for end < 0 || cr.pos < end {
+		count, err := binary.ReadVarint(cr)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("while reading avro block count: %v", err)
+		}
+		size, err := binary.ReadVarint(cr)
+		if err != nil || size < 0 {
+			return fmt.Errorf("while reading avro block size: %v", err)
+		}
+		if cap(block) < int(size) {
+			block = make([]byte, size)
+		}
+		block = block[:size]
+		if _, err = io.ReadFull(cr, block); err != nil {
+			return fmt.Errorf("while reading avro block: %v", err)
+		}
+		data, err := r.decompress(block)
+		if err != nil {
+			return err
+		}
+		decoder := &avroDecoder{buf: data}
+		for range count {
+			row := make([]any, len(columns))
+			values := make([]any, len(r.schema.fields))
+			for i := range r.schema.fields {
+				values[i], err = decoder.decode(r.schema.fields[i].schema)
+				if err != nil {
+					return fmt.Errorf("while decoding avro record field '%s': %v", r.schema.fields[i].name, err)
+				}
+			}
+			for i, icol := range columns {
+				row[i] = values[icol]
+			}
+			if err = fnc(row); err != nil {
+				if err == errStopReading {
+					return nil
+				}
+				return err
+			}
+		}
+		sync := make([]byte, len(r.sync))
+		if _, err = io.ReadFull(cr, sync); err != nil || !bytes.Equal(sync, r.sync) {
+			return fmt.Errorf("error: invalid avro sync marker at end of block")
+		}
+	}
+	return nil
+}
+
+// skipToSync reads until after the next sync marker, returns false if no sync marker found
+func (r *avroFileReader) skipToSync(cr *countingReader) (bool, error) {
+	n := len(r.sync)
+	window := make([]byte, 0, 2*n)
+	for {
+		b, err := cr.ReadByte()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("while looking for avro sync marker: %v", err)
+		}
+		window = append(window, b)
+		if len(window) >= n && bytes.Equal(window[len(window)-n:], r.sync) {
+			return true, nil
+		}
+		if len(window) == cap(window) {
+			window = append(window[:0], window[len(window)-n+1:]...)
+		}
+	}
+}
+
+func (r *avroFileReader) decompress(block []byte) ([]byte, error) {
+	switch r.codec {
+	case "deflate":
+		data, err := io.ReadAll(flate.NewReader(bytes.NewReader(block)))
+		if err != nil {
+			return nil, fmt.Errorf("while inflating avro block: %v", err)
+		}
+		return data, nil
+	case "snappy":
+		if len(block) < 4 {
+			return nil, fmt.Errorf("error: invalid avro snappy block")
+		}
+		data, err := snappy.Decode(nil, block[:len(block)-4])
+		if err != nil {
+			return nil, fmt.Errorf("while decompressing avro snappy block: %v", err)
+		}
+		if crc32.ChecksumIEEE(data) != binary.BigEndian.Uint32(block[len(block)-4:]) {
+			return nil, fmt.Errorf("error: avro snappy block checksum mismatch")
+		}
+		return data, nil
+	}
+	return block, nil
+}
@@ -0,0 +1,234 @@
+package compute_pipes
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"math/big"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// This file contains test cases for the avro reader
+
+const testAvroSchema = `{
+  "type": "record", "name": "claim", "namespace": "test",
+  "fields": [
+    {"name": "id", "type": "long"},
+    {"name": "name", "type": ["null", "string"]},
+    {"name": "dos", "type": {"type": "int", "logicalType": "date"}},
+    {"name": "ts", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+    {"name": "amt", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+    {"name": "codes", "type": {"type": "array", "items": "string"}}
+  ]
+}`
+
+func avroLong(buf *bytes.Buffer, v int64) {
+	b := binary.AppendVarint(nil, v)
+	buf.Write(b)
+}
+
+func avroString(buf *bytes.Buffer, s string) {
+	avroLong(buf, int64(len(s)))
+	buf.WriteString(s)
+}
+
+// writeTestAvroFile writes an avro object container file with nbrBlocks blocks of 2 records each
+func writeTestAvroFile(t *testing.T, codec string, nbrBlocks int) []byte {
+	t.Helper()
+	sync := []byte("0123456789abcdef")
+	var buf bytes.Buffer
+	buf.Write(avroMagic)
+	avroLong(&buf, 2)
+	avroString(&buf, "avro.schema")
+	avroString(&buf, testAvroSchema)
+	avroString(&buf, "avro.codec")
+	avroString(&buf, codec)
+	avroLong(&buf, 0)
+	buf.Write(sync)
+	id := int64(0)
+	for range nbrBlocks {
+		var block bytes.Buffer
+		for j := range 2 {
+			id++
+			avroLong(&block, id)
+			if j == 0 {
+				avroLong(&block, 1)
+				avroString(&block, "name")
+			} else {
+				avroLong(&block, 0)
+			}
+			avroLong(&block, 19000)
+			avroLong(&block, 1641600000123)
+			// -12.34 as two's complement big endian
+			avroLong(&block, 2)
+			block.Write([]byte{0xfb, 0x2e})
+			// array of 1 block with 2 items
+			avroLong(&block, 2)
+			avroString(&block, "A1")
+			avroString(&block, "B2")
+			avroLong(&block, 0)
+		}
+		data := block.Bytes()
+		if codec == "deflate" {
+			var compressed bytes.Buffer
+			w, err := flate.NewWriter(&compressed, flate.DefaultCompression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.Write(data)
+			w.Close()
+			data = compressed.Bytes()
+		}
+		avroLong(&buf, 2)
+		avroLong(&buf, int64(len(data)))
+		buf.Write(data)
+		buf.Write(sync)
+	}
+	return buf.Bytes()
+}
+
+func readTestTypedFile(t *testing.T, reader typedFileReader, start, end int64, columns []int) [][]any {
+	t.Helper()
+	var rows [][]any
+	err := reader.ReadRows(start, end, columns, func(row []any) error {
+		txtRow := make([]any, len(row))
+		for i := range row {
+			var err error
+			txtRow[i], err = typedValueToTxt(row[i], reader.SchemaInfo().Fields[columns[i]], false, nil)
+			if err != nil {
+				return err
+			}
+		}
+		rows = append(rows, txtRow)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestAvroFileReader(t *testing.T) {
+	for _, codec := range []string{"null", "deflate"} {
+		data := writeTestAvroFile(t, codec, 1)
+		reader, err := newAvroFileReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("codec %s: %v", codec, err)
+		}
+		schemaInfo := reader.SchemaInfo()
+		if !reflect.DeepEqual(schemaInfo.Columns(), []string{"id", "name", "dos", "ts", "amt", "codes"}) {
+			t.Errorf("codec %s: unexpected columns %v", codec, schemaInfo.Columns())
+		}
+		types := make([]string, 0)
+		for _, fi := range schemaInfo.Fields {
+			types = append(types, fi.Type)
+		}
+		if !reflect.DeepEqual(types, []string{"int64", "utf8", "date32", "timestamp", "decimal", "utf8"}) {
+			t.Errorf("codec %s: unexpected types %v", codec, types)
+		}
+		rows := readTestTypedFile(t, reader, 0, -1, []int{0, 1, 2, 3, 4, 5})
+		expected := [][]any{
+			{"1", "name", "2022-01-08", "2022-01-08T00:00:00.123", "-12.34", `["A1","B2"]`},
+			{"2", nil, "2022-01-08", "2022-01-08T00:00:00.123", "-12.34", `["A1","B2"]`},
+		}
+		if !reflect.DeepEqual(rows, expected) {
+			t.Errorf("codec %s: expecting %v, got %v", codec, expected, rows)
+		}
+	}
+}
+
+func TestAvroFileReaderShards(t *testing.T) {
+	data := writeTestAvroFile(t, "null", 10)
+	reader, err := newAvroFileReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, nbrShards := range []int{1, 2, 3, 7, 50} {
+		var ids []any
+		for ishard := range nbrShards {
+			start, end := shardByteRange(int64(len(data)), ishard, nbrShards)
+			for _, row := range readTestTypedFile(t, reader, start, end, []int{0}) {
+				ids = append(ids, row[0])
+			}
+		}
+		if len(ids) != 20 {
+			t.Fatalf("%d shards: expecting 20 rows, got %d", nbrShards, len(ids))
+		}
+		for i := range ids {
+			if ids[i] != strconv.Itoa(i+1) {
+				t.Errorf("%d shards: unexpected row %d: %v", nbrShards, i, ids[i])
+			}
+		}
+	}
+}
+
+// The files testdata/reference_<codec>.avro are written by github.com/linkedin/goavro/v2
+// (v2.12.0) with 3 blocks of 4 records, see the schema in testdata/reference.avsc
+func TestAvroFileReaderReference(t *testing.T) {
+	for _, codec := range []string{"null", "deflate", "snappy"} {
+		data, err := os.ReadFile("testdata/reference_" + codec + ".avro")
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader, err := newAvroFileReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("codec %s: %v", codec, err)
+		}
+		types := make([]string, 0)
+		for _, fi := range reader.SchemaInfo().Fields {
+			types = append(types, fi.Type)
+		}
+		expectedTypes := []string{"int64", "utf8", "bool", "float64", "float32", "date32", "timestamp", "decimal", "utf8", "utf8"}
+		if !reflect.DeepEqual(types, expectedTypes) {
+			t.Errorf("codec %s: unexpected types %v", codec, types)
+		}
+		rows := readTestTypedFile(t, reader, 0, -1, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+		if len(rows) != 12 {
+			t.Fatalf("codec %s: expecting 12 rows, got %d", codec, len(rows))
+		}
+		expected := [][]any{
+			{"1", "nameA", "0", "1.5", "0.25", "1980-01-01", "2024-03-15T10:30:01.25", "-10.05", "M", `["C1","X"]`},
+			{"3", nil, "0", "3.5", "0.75", "1980-01-03", "2024-03-15T10:30:03.25", "-30.05", "F", `["C3","X"]`},
+			{"12", nil, "1", "12.5", "3", "1980-01-12", "2024-03-15T10:30:12.25", "-120.05", "F", `["C2","X"]`},
+		}
+		for i, irow := range []int{0, 2, 11} {
+			if !reflect.DeepEqual(rows[irow], expected[i]) {
+				t.Errorf("codec %s: row %d: expecting %v, got %v", codec, irow, expected[i], rows[irow])
+			}
+		}
+		// Sharding on the block boundaries
+		var ids []any
+		for ishard := range 4 {
+			start, end := shardByteRange(int64(len(data)), ishard, 4)
+			for _, row := range readTestTypedFile(t, reader, start, end, []int{0}) {
+				ids = append(ids, row[0])
+			}
+		}
+		if len(ids) != 12 {
+			t.Errorf("codec %s: expecting 12 rows across shards, got %d", codec, len(ids))
+		}
+	}
+}
+
+func TestFormatDecimalTxt(t *testing.T) {
+	cases := []struct {
+		unscaled int64
+		scale    int32
+		expected string
+	}{
+		{-1234, 2, "-12.34"},
+		{5, 3, "0.005"},
+		{-5, 1, "-0.5"},
+		{120, 0, "120"},
+		{12, -2, "1200"},
+	}
+	for _, c := range cases {
+		txt := formatDecimalTxt(big.NewInt(c.unscaled), c.scale)
+		if txt != c.expected {
+			t.Errorf("expecting %s, got %s", c.expected, txt)
+		}
+	}
+}
@@ -67,6 +67,7 @@ func (cpCtx *ComputePipesContext) StartComputePipes(dbpool *pgxpool.Pool,
 	// Create the LookupTableManager and prepare the lookups async
 	lookupManager := NewLookupTableManager(cpCtx.CpConfig.LookupTables, cpCtx.EnvSettings,
 		cpCtx.CpConfig.ClusterConfig.IsDebugMode)
+	cpCtx.LookupTableManager = lookupManager
 	managersWg.Add(1)
 	go func() {
 		defer managersWg.Done()
@@ -367,6 +368,7 @@ func (cpCtx *ComputePipesContext) StartComputePipes(dbpool *pgxpool.Pool,
 		channelRegistry:    channelRegistry,
 		lookupTableManager: lookupManager,
 		s3DeviceManager:    cpCtx.S3DeviceMgr,
+		inputFileKeys:      cpCtx.InputFileKeys,
 		schemaManager:      cpCtx.SchemaManager,
 		inputParquetSchema: inputParquetSchema,
 		jetRules:           cpCtx.JetRules,
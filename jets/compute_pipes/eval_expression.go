@@ -59,6 +59,8 @@ type expressionFunctionEvaluator struct {
 	Args    []evalExpression
 	Fnc     evalFunction
 	Default evalExpression
+	// returnType is the static type of the function result, empty when unknown
+	returnType string
 }
 
 func (node *expressionFunctionEvaluator) Eval(input any) (any, error) {
@@ -351,7 +353,7 @@ func (ctx ExprBuilderContext) BuildExprNodeEvaluator(sourceName string, columns
 		case "EXPR_PROXY":
 			// special case of expression proxy, the actual expression is specified by one of:
 			// - ExprEnvVarProxy: the expression is specified by an env var, the value of the
-			//   env var is the actual expression as a json string to evaluate.
+			//   env var is the actual expression as a json string or using the text syntax.
 			if spec.ExprEnvVarProxy == "" {
 				return nil, fmt.Errorf("error: Type expr_proxy must have ExprEnvVarProxy not nil")
 			}
@@ -363,9 +365,18 @@ func (ctx ExprBuilderContext) BuildExprNodeEvaluator(sourceName string, columns
 			if !ok {
 				return nil, fmt.Errorf("error: env var %s does not contain a valid string for expr_proxy", spec.ExprEnvVarProxy)
 			}
-			// parse the exprStr as an ExpressionNode
+			// parse the exprStr as an ExpressionNode, either json or text syntax
 			var exprNode ExpressionNode
-			err := json.Unmarshal([]byte(exprStr), &exprNode)
+			var err error
+			if strings.HasPrefix(strings.TrimSpace(exprStr), "{") {
+				err = json.Unmarshal([]byte(exprStr), &exprNode)
+			} else {
+				var node *ExpressionNode
+				node, err = ParseExpression(exprStr)
+				if node != nil {
+					exprNode = *node
+				}
+			}
 			if err != nil {
 				return nil, fmt.Errorf("error: failed to parse expr_proxy env var %s value as ExpressionNode: %v", spec.ExprEnvVarProxy, err)
 			}
@@ -376,22 +387,25 @@ func (ctx ExprBuilderContext) BuildExprNodeEvaluator(sourceName string, columns
 			if spec.Expr == "" {
 				return nil, fmt.Errorf("error: Type function must have Expr not nil")
 			}
-			fnc, err := BuildFncEvaluator(spec.Expr)
-			if err != nil {
-				return nil, fmt.Errorf("error: failed to build operator for function type expression with expr %s: %v", spec.Expr, err)
-			}
 			args := make([]evalExpression, len(spec.Farg))
+			argsInfo := make([]fncArg, len(spec.Farg))
 			for i, argSpec := range spec.Farg {
 				argEval, err := ctx.BuildExprNodeEvaluator(sourceName, columns, &argSpec)
 				if err != nil {
 					return nil, fmt.Errorf("error: failed to build evaluator for argument %d of function type expression with expr %s: %v", i, spec.Expr, err)
 				}
 				args[i] = argEval
+				argsInfo[i] = fncArgOf(argEval)
+			}
+			fnc, returnType, err := BuildFncEvaluator(spec.Expr, argsInfo)
+			if err != nil {
+				return nil, fmt.Errorf("error: failed to build operator for function type expression with expr %s: %v", spec.Expr, err)
 			}
 			return &expressionFunctionEvaluator{
-				Fnc:     fnc,
-				Args:    args,
-				Default: defaultExpr,
+				Fnc:        fnc,
+				Args:       args,
+				Default:    defaultExpr,
+				returnType: returnType,
 			}, nil
 		default:
 			return nil, fmt.Errorf("error: unknown expression leaf node type: %s", spec.Type)
@@ -0,0 +1,535 @@
+package compute_pipes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parser for the text syntax of expressions, compiles a SQL-like expression
+// into an ExpressionNode tree. In the pipeline config, an expression can be specified
+// as a json string instead of an ExpressionNode object, for example:
+//
+//	"when": "total_file_size_gb > 10 AND format IN ('csv','parquet')"
+//
+// Grammar, by increasing precedence:
+//
+//	expr    := and_expr (OR and_expr)*
+//	and_expr:= not_expr (AND not_expr)*
+//	not_expr:= NOT not_expr | cmp_expr
+//	cmp_expr:= add_expr [ cmp_op add_expr | IS [NOT] add_expr | [NOT] IN list | [NOT] IN_NO_CASE list ]
+//	cmp_op  := == | = | != | <> | < | <= | > | >=
+//	add_expr:= mul_expr ((+ | -) mul_expr)*
+//	mul_expr:= unary ((* | /) unary)*
+//	unary   := - unary | primary
+//	primary := number | 'string' | NULL | NAN | $ENV_VAR | column | "column" | `column`
+//	           | name '(' [expr (, expr)*] ')' | '(' expr ')'
+//	list    := '(' literal (, literal)* ')'
+//
+// Keywords are case insensitive, strings use '' to escape a single quote.
+// A call to a name of the function library (see eval_functions.go) is a function node,
+// otherwise the name must be an operator taking 1 or 2 arguments, e.g. abs(x), distance_months(d1, d2).
+
+// UnmarshalJSON accepts an ExpressionNode object or a string using the text syntax
+func (n *ExpressionNode) UnmarshalJSON(data []byte) error {
+	var text string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		node, err := ParseExpression(text)
+		if err != nil {
+			return err
+		}
+		*n = *node
+		return nil
+	}
+	type expressionNodeAlias ExpressionNode
+	return json.Unmarshal(data, (*expressionNodeAlias)(n))
+}
+
+// ParseExpression parses the text expression into an ExpressionNode,
+// parse errors report the 1-based position in text.
+func ParseExpression(text string) (*ExpressionNode, error) {
+	tokens, err := tokenizeExpression(text)
+	if err != nil {
+		return nil, fmt.Errorf("error: invalid expression '%s': %v", text, err)
+	}
+	p := &exprParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err == nil && p.peek().kind != tokEOF {
+		err = fmt.Errorf("unexpected %s", p.peek())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error: invalid expression '%s': %v", text, err)
+	}
+	return node, nil
+}
+
+type exprTokenKind int
+
+const (
+	tokEOF exprTokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokQuotedIdent
+	tokEnvVar
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+	// pos is the 1-based position of the token in the expression
+	pos int
+}
+
+func (t exprToken) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return fmt.Sprintf("string '%s' at position %d", t.text, t.pos)
+	}
+	return fmt.Sprintf("'%s' at position %d", t.text, t.pos)
+}
+
+// isKeyword returns true when the token is the keyword kw (in upper case)
+func (t exprToken) isKeyword(kw string) bool {
+	return t.kind == tokIdent && strings.ToUpper(t.text) == kw
+}
+
+func isIdentRune(r rune, first bool) bool {
+	if r == '_' || unicode.IsLetter(r) {
+		return true
+	}
+	return !first && (unicode.IsDigit(r) || r == '.')
+}
+
+func tokenizeExpression(text string) ([]exprToken, error) {
+	var tokens []exprToken
+	i := 0
+	for i < len(text) {
+		r, sz := utf8.DecodeRuneInString(text[i:])
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i += sz
+
+		case r == '(' || r == ')' || r == ',':
+			kind := tokComma
+			switch r {
+			case '(':
+				kind = tokLParen
+			case ')':
+				kind = tokRParen
+			}
+			tokens = append(tokens, exprToken{kind: kind, text: string(r), pos: pos})
+			i++
+
+		case strings.ContainsRune("=!<>+-*/", r):
+			op := string(r)
+			if i+1 < len(text) {
+				switch two := text[i : i+2]; two {
+				case "==", "!=", "<=", ">=", "<>":
+					op = two
+				}
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected '!' at position %d", pos)
+			}
+			tokens = append(tokens, exprToken{kind: tokOp, text: op, pos: pos})
+			i += len(op)
+
+		case r == '\'':
+			// string literal, '' is an escaped quote
+			var buf strings.Builder
+			j := i + 1
+			for {
+				if j >= len(text) {
+					return nil, fmt.Errorf("unterminated string starting at position %d", pos)
+				}
+				if text[j] == '\'' {
+					if j+1 < len(text) && text[j+1] == '\'' {
+						buf.WriteByte('\'')
+						j += 2
+						continue
+					}
+					break
+				}
+				buf.WriteByte(text[j])
+				j++
+			}
+			tokens = append(tokens, exprToken{kind: tokString, text: buf.String(), pos: pos})
+			i = j + 1
+
+		case r == '"' || r == '`':
+			end := strings.IndexRune(text[i+1:], r)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted column name starting at position %d", pos)
+			}
+			tokens = append(tokens, exprToken{kind: tokQuotedIdent, text: text[i+1 : i+1+end], pos: pos})
+			i += end + 2
+
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(text) && (text[j] >= '0' && text[j] <= '9' || text[j] == '.') {
+				j++
+			}
+			if j < len(text) {
+				if next, _ := utf8.DecodeRuneInString(text[j:]); isIdentRune(next, true) {
+					return nil, fmt.Errorf("invalid number at position %d", pos)
+				}
+			}
+			if strings.Count(text[i:j], ".") > 1 {
+				return nil, fmt.Errorf("invalid number '%s' at position %d", text[i:j], pos)
+			}
+			tokens = append(tokens, exprToken{kind: tokNumber, text: text[i:j], pos: pos})
+			i = j
+
+		case r == '$' || isIdentRune(r, true):
+			j := i + sz
+			for j < len(text) {
+				next, nsz := utf8.DecodeRuneInString(text[j:])
+				if !isIdentRune(next, false) {
+					break
+				}
+				j += nsz
+			}
+			kind := tokIdent
+			if r == '$' {
+				if j == i+1 {
+					return nil, fmt.Errorf("expecting an env var name after '$' at position %d", pos)
+				}
+				kind = tokEnvVar
+			}
+			tokens = append(tokens, exprToken{kind: kind, text: text[i:j], pos: pos})
+			i = j
+
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' at position %d", r, pos)
+		}
+	}
+	tokens = append(tokens, exprToken{kind: tokEOF, pos: len(text) + 1})
+	return tokens, nil
+}
+
+type exprParser struct {
+	tokens []exprToken
+	next   int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.next]
+}
+
+func (p *exprParser) advance() exprToken {
+	t := p.tokens[p.next]
+	if t.kind != tokEOF {
+		p.next++
+	}
+	return t
+}
+
+func (p *exprParser) expect(kind exprTokenKind, what string) (exprToken, error) {
+	t := p.advance()
+	if t.kind != kind {
+		return t, fmt.Errorf("expecting %s, got %s", what, t)
+	}
+	return t, nil
+}
+
+func binaryNode(lhs *ExpressionNode, op string, rhs *ExpressionNode) *ExpressionNode {
+	return &ExpressionNode{Lhs: lhs, Op: op, Rhs: rhs}
+}
+
+func (p *exprParser) parseOr() (*ExpressionNode, error) {
+	lhs, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("OR") {
+		p.advance()
+		rhs, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		lhs = binaryNode(lhs, "or", rhs)
+	}
+	return lhs, nil
+}
+
+func (p *exprParser) parseAnd() (*ExpressionNode, error) {
+	lhs, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("AND") {
+		p.advance()
+		rhs, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		lhs = binaryNode(lhs, "and", rhs)
+	}
+	return lhs, nil
+}
+
+func (p *exprParser) parseNot() (*ExpressionNode, error) {
+	if p.peek().isKeyword("NOT") {
+		p.advance()
+		arg, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &ExpressionNode{Op: "not", Arg: arg}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (*ExpressionNode, error) {
+	lhs, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	switch {
+	case t.kind == tokOp && isComparisonOp(t.text):
+		p.advance()
+		op := t.text
+		switch op {
+		case "=":
+			op = "=="
+		case "<>":
+			op = "!="
+		}
+		rhs, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return binaryNode(lhs, op, rhs), nil
+
+	case t.isKeyword("IS"):
+		p.advance()
+		op := "is"
+		if p.peek().isKeyword("NOT") {
+			p.advance()
+			op = "is not"
+		}
+		rhs, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return binaryNode(lhs, op, rhs), nil
+
+	case t.isKeyword("NOT") || t.isKeyword("IN") || t.isKeyword("IN_NO_CASE"):
+		p.advance()
+		negate := false
+		if t.isKeyword("NOT") {
+			negate = true
+			t = p.advance()
+			if !t.isKeyword("IN") && !t.isKeyword("IN_NO_CASE") {
+				return nil, fmt.Errorf("expecting IN or IN_NO_CASE after NOT, got %s", t)
+			}
+		}
+		list, err := p.parseStaticList()
+		if err != nil {
+			return nil, err
+		}
+		node := binaryNode(lhs, strings.ToLower(t.text), list)
+		if negate {
+			node = &ExpressionNode{Op: "not", Arg: node}
+		}
+		return node, nil
+	}
+	return lhs, nil
+}
+
+func isComparisonOp(op string) bool {
+	switch op {
+	case "==", "=", "!=", "<>", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) parseStaticList() (*ExpressionNode, error) {
+	if _, err := p.expect(tokLParen, "'(' to start the list of values"); err != nil {
+		return nil, err
+	}
+	list := &ExpressionNode{Type: "static_list"}
+	for {
+		t := p.advance()
+		negative := false
+		if t.kind == tokOp && t.text == "-" && p.peek().kind == tokNumber {
+			negative = true
+			t = p.advance()
+		}
+		value, ok := literalExpr(t)
+		if !ok {
+			return nil, fmt.Errorf("expecting a literal value in list, got %s", t)
+		}
+		if negative {
+			value = "-" + value
+		}
+		list.ExprList = append(list.ExprList, value)
+		t = p.advance()
+		if t.kind == tokRParen {
+			return list, nil
+		}
+		if t.kind != tokComma {
+			return nil, fmt.Errorf("expecting ',' or ')' in list of values, got %s", t)
+		}
+	}
+}
+
+// literalExpr returns the Expr of value node for the literal token
+func literalExpr(t exprToken) (string, bool) {
+	switch {
+	case t.kind == tokNumber, t.kind == tokEnvVar:
+		return t.text, true
+	case t.kind == tokString:
+		return "'" + t.text + "'", true
+	case t.isKeyword("NULL"):
+		return "null", true
+	case t.isKeyword("NAN"):
+		return "NaN", true
+	}
+	return "", false
+}
+
+func (p *exprParser) parseAdditive() (*ExpressionNode, error) {
+	lhs, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.kind == tokOp && (t.text == "+" || t.text == "-"); t = p.peek() {
+		p.advance()
+		rhs, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		lhs = binaryNode(lhs, t.text, rhs)
+	}
+	return lhs, nil
+}
+
+func (p *exprParser) parseMultiplicative() (*ExpressionNode, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.kind == tokOp && (t.text == "*" || t.text == "/"); t = p.peek() {
+		p.advance()
+		rhs, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		lhs = binaryNode(lhs, t.text, rhs)
+	}
+	return lhs, nil
+}
+
+func (p *exprParser) parseUnary() (*ExpressionNode, error) {
+	t := p.peek()
+	if t.kind == tokOp && t.text == "-" {
+		p.advance()
+		if p.peek().kind == tokNumber {
+			return &ExpressionNode{Type: "value", Expr: "-" + p.advance().text}, nil
+		}
+		arg, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return binaryNode(&ExpressionNode{Type: "value", Expr: "0"}, "-", arg), nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (*ExpressionNode, error) {
+	t := p.advance()
+	switch t.kind {
+	case tokLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err = p.expect(tokRParen, "')'"); err != nil {
+			return nil, err
+		}
+		return node, nil
+
+	case tokQuotedIdent:
+		if len(t.text) == 0 {
+			return nil, fmt.Errorf("empty column name at position %d", t.pos)
+		}
+		return &ExpressionNode{Type: "select", Expr: t.text}, nil
+
+	case tokIdent:
+		if p.peek().kind == tokLParen {
+			return p.parseCall(t)
+		}
+		switch strings.ToUpper(t.text) {
+		case "AND", "OR", "NOT", "IN", "IN_NO_CASE", "IS":
+			return nil, fmt.Errorf("unexpected keyword %s", t)
+		}
+	}
+	if value, ok := literalExpr(t); ok {
+		return &ExpressionNode{Type: "value", Expr: value}, nil
+	}
+	if t.kind == tokIdent {
+		return &ExpressionNode{Type: "select", Expr: t.text}, nil
+	}
+	return nil, fmt.Errorf("expecting a value, column or function, got %s", t)
+}
+
+// parseCall parses the arguments of the call to name, the current token is '('
+func (p *exprParser) parseCall(name exprToken) (*ExpressionNode, error) {
+	p.advance()
+	var args []*ExpressionNode
+	if p.peek().kind == tokRParen {
+		p.advance()
+	} else {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			t := p.advance()
+			if t.kind == tokRParen {
+				break
+			}
+			if t.kind != tokComma {
+				return nil, fmt.Errorf("expecting ',' or ')' in arguments of %s, got %s", name.text, t)
+			}
+		}
+	}
+	fname := strings.ToLower(name.text)
+	if fncLibrary[strings.ToUpper(fname)] != nil {
+		node := &ExpressionNode{Type: "function", Expr: fname}
+		for _, arg := range args {
+			node.Farg = append(node.Farg, *arg)
+		}
+		return node, nil
+	}
+	// Operator used with function call syntax
+	if _, err := BuildEvalOperator(fname); err != nil {
+		return nil, fmt.Errorf("unknown function %s", name)
+	}
+	switch len(args) {
+	case 0:
+		return &ExpressionNode{Op: fname, Arg: &ExpressionNode{Type: "value", Expr: "null"}}, nil
+	case 1:
+		return &ExpressionNode{Op: fname, Arg: args[0]}, nil
+	case 2:
+		return binaryNode(args[0], fname, args[1]), nil
+	}
+	return nil, fmt.Errorf("operator %s takes at most 2 arguments, got %d", name, len(args))
+}
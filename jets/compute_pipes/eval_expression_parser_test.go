@@ -0,0 +1,141 @@
+package compute_pipes
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// This file contains test cases for the text syntax of expressions
+
+func TestParseExpressionTree(t *testing.T) {
+	node, err := ParseExpression("total_file_size_gb > 10 AND format IN ('csv','parquet')")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &ExpressionNode{
+		Lhs: &ExpressionNode{
+			Lhs: &ExpressionNode{Type: "select", Expr: "total_file_size_gb"},
+			Op:  ">",
+			Rhs: &ExpressionNode{Type: "value", Expr: "10"},
+		},
+		Op: "and",
+		Rhs: &ExpressionNode{
+			Lhs: &ExpressionNode{Type: "select", Expr: "format"},
+			Op:  "in",
+			Rhs: &ExpressionNode{Type: "static_list", ExprList: []string{"'csv'", "'parquet'"}},
+		},
+	}
+	if !reflect.DeepEqual(node, expected) {
+		b1, _ := json.Marshal(expected)
+		b2, _ := json.Marshal(node)
+		t.Errorf("expecting %s, got %s", string(b1), string(b2))
+	}
+}
+
+func TestParseExpressionEval(t *testing.T) {
+	ctx := ExprBuilderContext(map[string]any{"$MAX_SIZE": 100})
+	columns := map[string]int{"size": 0, "format": 1, "name": 2, "first name": 3}
+	row := []any{20, "csv", "O'Brien", nil}
+
+	tests := []struct {
+		text     string
+		expected any
+	}{
+		{"size > 10 AND format IN ('csv','parquet')", true},
+		{"size > 10 and not format in ('csv')", false},
+		{"format NOT IN ('json', 'xml')", true},
+		{"format in_no_case ('CSV')", true},
+		{"size = 20 OR size = 30", true},
+		{"size <> 20", false},
+		{"1 + 2 * 3 == 7", true},
+		{"(1 + 2) * 3 == 9", true},
+		{"-size + 25 == 5", true},
+		{"size - -5 == 25", true},
+		{"name == 'O''Brien'", true},
+		{"\"first name\" IS NULL", true},
+		{"`first name` is not null", false},
+		{"size < $MAX_SIZE", true},
+		{"not (size > 10 or size < 0)", false},
+		{"upper(format) == 'CSV'", true},
+		{"concat(format, '-', size)", "csv-20"},
+		{"abs(-3) == 3", true},
+		{"length(name) == 7", true},
+	}
+	for _, tc := range tests {
+		spec, err := ParseExpression(tc.text)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error: %v", tc.text, err)
+		}
+		eval, err := ctx.BuildExprNodeEvaluator("source1", columns, spec)
+		if err != nil {
+			t.Fatalf("%s: unexpected build error: %v", tc.text, err)
+		}
+		value, err := eval.Eval(row)
+		if err != nil {
+			t.Fatalf("%s: unexpected eval error: %v", tc.text, err)
+		}
+		if b, ok := tc.expected.(bool); ok {
+			if ToBool(value) != b {
+				t.Errorf("%s: expecting %v, got %v", tc.text, b, value)
+			}
+			continue
+		}
+		if value != tc.expected {
+			t.Errorf("%s: expecting %v (%T), got %v (%T)", tc.text, tc.expected, tc.expected, value, value)
+		}
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	tests := []struct {
+		text     string
+		position string
+	}{
+		{"size > ", "end of expression"},
+		{"size > 10 AND", "end of expression"},
+		{"size >> 10", "position 7"},
+		{"(size > 10", "end of expression"},
+		{"size > 10)", "position 10"},
+		{"format IN 'csv'", "position 11"},
+		{"format IN ('csv' 'json')", "position 18"},
+		{"format NOT 'csv'", "position 12"},
+		{"name == 'abc", "position 9"},
+		{"size # 10", "position 6"},
+		{"no_such_fnc(size)", "position 1"},
+		{"size > 10abc", "position 8"},
+		{"AND size", "position 1"},
+	}
+	for _, tc := range tests {
+		_, err := ParseExpression(tc.text)
+		if err == nil {
+			t.Errorf("%s: expecting a parse error", tc.text)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.position) {
+			t.Errorf("%s: expecting error with %s, got %v", tc.text, tc.position, err)
+		}
+	}
+}
+
+func TestExpressionNodeUnmarshalText(t *testing.T) {
+	var spec struct {
+		When  *ExpressionNode  `json:"when"`
+		Cases []ExpressionNode `json:"cases"`
+	}
+	data := `{"when": "size > 10", "cases": ["a == 1", {"type": "select", "expr": "b"}]}`
+	if err := json.Unmarshal([]byte(data), &spec); err != nil {
+		t.Fatal(err)
+	}
+	if spec.When == nil || spec.When.Op != ">" || spec.When.Lhs.Expr != "size" {
+		t.Errorf("unexpected when expression: %+v", spec.When)
+	}
+	if len(spec.Cases) != 2 || spec.Cases[0].Op != "==" || spec.Cases[1].Type != "select" {
+		t.Errorf("unexpected cases: %+v", spec.Cases)
+	}
+	err := json.Unmarshal([]byte(`{"when": "size > "}`), &spec)
+	if err == nil || !strings.Contains(err.Error(), "end of expression") {
+		t.Errorf("expecting a parse error, got %v", err)
+	}
+}
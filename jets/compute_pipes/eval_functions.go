@@ -0,0 +1,621 @@
+package compute_pipes
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+	"github.com/artisoft-io/jetstore/jets/utils"
+)
+
+// Function library of the expression evaluator, used by expression of type function.
+// The functions are checked for arity and argument types when the expression is built.
+// The argument types are checked statically when known:
+//   - constant arguments (expression of type value) are converted to the parameter type,
+//   - selected columns are checked using their as_rdf_type,
+//   - nested functions are checked using their return type.
+// Unless specified otherwise, functions return null when a required argument is null.
+//
+// Functions:
+//	CURRENT_YEAR()                         current year as int
+//	SUBSTRING(str, start [, length])       1-based start, length in characters
+//	UPPER(str), LOWER(str), TRIM(str)
+//	CONCAT(arg1, arg2, ...)                null arguments are skipped
+//	COALESCE(arg1, arg2, ...)              first non null argument
+//	NULLIF(arg1, arg2)                     null if arg1 == arg2, arg1 otherwise
+//	ROUND(x [, digits])                    round half away from zero, returns a double
+//	FLOOR(x), CEIL(x)                      returns an int
+//	MOD(x, y)                              int when both are int, double otherwise
+//	DATE_ADD(date, n [, unit])             unit: 'day' (default), 'week', 'month', 'year'
+//	DATE_DIFF(from, to [, unit])           nbr of units from from to to, unit: 'day' (default), 'week', 'month', 'year'
+//	DATE_TRUNC(unit, date)                 unit: 'day', 'week' (monday), 'month', 'quarter', 'year'
+//	AGE_AS_OF(dob, as_of_date)             age in years
+//	PARSE_DOUBLE(str)                      null for empty string, error if not a number
+//	REGEX_EXTRACT(str, pattern [, group])  pattern must be a constant, null when no match
+
+// Parameter and static types of the function arguments
+const (
+	fncTypeAny    = ""
+	fncTypeString = "string"
+	fncTypeInt    = "int"
+	fncTypeNumber = "double"
+	fncTypeDate   = "date"
+	fncTypeBool   = "bool"
+)
+
+// fncArg is the static information about a function argument known when building the expression
+type fncArg struct {
+	isConst    bool
+	value      any
+	staticType string
+}
+
+type fncSpec struct {
+	// maxArgs = -1 for variadic, the type of the variadic arguments is the last param type
+	minArgs    int
+	maxArgs    int
+	params     []string
+	returnType string
+	// build returns the function, args are the checked arguments
+	build func(args []fncArg) (evalFunction, error)
+}
+
+var fncLibrary map[string]*fncSpec
+
+func init() {
+	fncLibrary = map[string]*fncSpec{
+		"CURRENT_YEAR": {0, 0, nil, fncTypeInt, func(_ []fncArg) (evalFunction, error) {
+			return func(args []any) (any, error) {
+				return time.Now().Year(), nil
+			}, nil
+		}},
+		"SUBSTRING":    {2, 3, []string{fncTypeString, fncTypeInt, fncTypeInt}, fncTypeString, buildFncSubstring},
+		"UPPER":        {1, 1, []string{fncTypeString}, fncTypeString, buildFncString(strings.ToUpper)},
+		"LOWER":        {1, 1, []string{fncTypeString}, fncTypeString, buildFncString(strings.ToLower)},
+		"TRIM":         {1, 1, []string{fncTypeString}, fncTypeString, buildFncString(strings.TrimSpace)},
+		"CONCAT":       {1, -1, []string{fncTypeString}, fncTypeString, buildFncConcat},
+		"COALESCE":     {1, -1, []string{fncTypeAny}, fncTypeAny, buildFncCoalesce},
+		"NULLIF":       {2, 2, []string{fncTypeAny, fncTypeAny}, fncTypeAny, buildFncNullIf},
+		"ROUND":        {1, 2, []string{fncTypeNumber, fncTypeInt}, fncTypeNumber, buildFncRound},
+		"FLOOR":        {1, 1, []string{fncTypeNumber}, fncTypeInt, buildFncNumber(math.Floor)},
+		"CEIL":         {1, 1, []string{fncTypeNumber}, fncTypeInt, buildFncNumber(math.Ceil)},
+		"MOD":          {2, 2, []string{fncTypeNumber, fncTypeNumber}, fncTypeNumber, buildFncMod},
+		"DATE_ADD":     {2, 3, []string{fncTypeDate, fncTypeInt, fncTypeString}, fncTypeDate, buildFncDateAdd},
+		"DATE_DIFF":    {2, 3, []string{fncTypeDate, fncTypeDate, fncTypeString}, fncTypeInt, buildFncDateDiff},
+		"DATE_TRUNC":   {2, 2, []string{fncTypeString, fncTypeDate}, fncTypeDate, buildFncDateTrunc},
+		"AGE_AS_OF":    {2, 2, []string{fncTypeDate, fncTypeDate}, fncTypeInt, buildFncAgeAsOf},
+		"PARSE_DOUBLE": {1, 1, []string{fncTypeString}, fncTypeNumber, buildFncParseDouble},
+		"REGEX_EXTRACT": {2, 3, []string{fncTypeString, fncTypeString, fncTypeInt},
+			fncTypeString, buildFncRegexExtract},
+	}
+}
+
+// fncArgOf returns the static information of the function argument
+func fncArgOf(arg evalExpression) fncArg {
+	switch a := arg.(type) {
+	case *expressionValueLeaf:
+		return fncArg{isConst: true, value: a.value, staticType: staticTypeOfValue(a.value)}
+	case *expressionSelectLeaf:
+		return fncArg{staticType: staticTypeOfRdfType(a.rdfType)}
+	case *expressionFunctionEvaluator:
+		return fncArg{staticType: a.returnType}
+	}
+	return fncArg{}
+}
+
+func staticTypeOfValue(v any) string {
+	switch v.(type) {
+	case string:
+		return fncTypeString
+	case int, int64, uint, uint64:
+		return fncTypeInt
+	case float64, float32:
+		return fncTypeNumber
+	case time.Time:
+		return fncTypeDate
+	case bool:
+		return fncTypeBool
+	}
+	return fncTypeAny
+}
+
+func staticTypeOfRdfType(rdfType string) string {
+	switch rdfType {
+	case "text", "string", "resource":
+		return fncTypeString
+	case "int", "integer", "int64", "long", "uint", "uint64", "ulong":
+		return fncTypeInt
+	case "double", "float64":
+		return fncTypeNumber
+	case "date", "datetime":
+		return fncTypeDate
+	case "bool":
+		return fncTypeBool
+	}
+	return fncTypeAny
+}
+
+// checkFncArg checks that the argument is compatible with the param type
+func checkFncArg(param string, arg fncArg) error {
+	if arg.isConst {
+		if arg.value == nil {
+			return nil
+		}
+		var err error
+		switch param {
+		case fncTypeNumber:
+			_, err = ToDouble(arg.value)
+		case fncTypeInt:
+			_, err = toFncInt(arg.value)
+		case fncTypeDate:
+			_, err = toFncDate(arg.value)
+		}
+		return err
+	}
+	switch param {
+	case fncTypeNumber, fncTypeInt:
+		if arg.staticType == fncTypeDate || arg.staticType == fncTypeBool {
+			return fmt.Errorf("expecting a number, got a %s", arg.staticType)
+		}
+	case fncTypeDate:
+		if arg.staticType == fncTypeNumber || arg.staticType == fncTypeBool {
+			return fmt.Errorf("expecting a date, got a %s", arg.staticType)
+		}
+	}
+	return nil
+}
+
+// buildFncFromLibrary checks the arguments and builds the function
+func buildFncFromLibrary(name string, args []fncArg) (evalFunction, string, error) {
+	spec := fncLibrary[name]
+	if spec == nil {
+		return nil, "", fmt.Errorf("error: unknown function: %v", name)
+	}
+	if len(args) < spec.minArgs || (spec.maxArgs >= 0 && len(args) > spec.maxArgs) {
+		switch {
+		case spec.maxArgs < 0:
+			return nil, "", fmt.Errorf("error: function %s expects at least %d arguments, got %d", name, spec.minArgs, len(args))
+		case spec.minArgs == spec.maxArgs:
+			return nil, "", fmt.Errorf("error: function %s expects %d arguments, got %d", name, spec.minArgs, len(args))
+		default:
+			return nil, "", fmt.Errorf("error: function %s expects %d to %d arguments, got %d", name, spec.minArgs, spec.maxArgs, len(args))
+		}
+	}
+	for i := range args {
+		param := spec.params[min(i, len(spec.params)-1)]
+		if err := checkFncArg(param, args[i]); err != nil {
+			return nil, "", fmt.Errorf("error: invalid argument %d of function %s: %v", i+1, name, err)
+		}
+	}
+	fnc, err := spec.build(args)
+	if err != nil {
+		return nil, "", fmt.Errorf("error: function %s: %v", name, err)
+	}
+	return fnc, spec.returnType, nil
+}
+
+func toFncInt(v any) (int, error) {
+	switch vv := v.(type) {
+	case int:
+		return vv, nil
+	case int64:
+		return int(vv), nil
+	case uint:
+		return int(vv), nil
+	case uint64:
+		return int(vv), nil
+	case float64:
+		if vv != math.Trunc(vv) {
+			return 0, fmt.Errorf("expecting an int, got %v", vv)
+		}
+		return int(vv), nil
+	case string:
+		return utils.String2Int(strings.TrimSpace(vv))
+	}
+	return 0, fmt.Errorf("expecting an int, got %v of type %T", v, v)
+}
+
+func toFncDate(v any) (time.Time, error) {
+	switch vv := v.(type) {
+	case time.Time:
+		return vv, nil
+	case string:
+		vv = strings.TrimSpace(vv)
+		d, err := rdf.ParseDate(vv)
+		if err == nil {
+			return *d, nil
+		}
+		d, err2 := rdf.ParseDatetime(vv)
+		if err2 != nil {
+			return time.Time{}, fmt.Errorf("expecting a date, got '%s': %v", vv, err)
+		}
+		return *d, nil
+	case int:
+		return time.Unix(int64(vv), 0), nil
+	case int64:
+		return time.Unix(vv, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("expecting a date, got %v of type %T", v, v)
+}
+
+func toFncString(v any) string {
+	switch vv := v.(type) {
+	case string:
+		return vv
+	case time.Time:
+		return vv.Format("2006-01-02")
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// constFncString returns the lower case value of a constant string argument
+func constFncString(arg fncArg, param string) (string, bool, error) {
+	if !arg.isConst {
+		return "", false, nil
+	}
+	s, ok := arg.value.(string)
+	if !ok {
+		return "", false, fmt.Errorf("%s must be a string", param)
+	}
+	return strings.ToLower(s), true, nil
+}
+
+func buildFncString(f func(string) string) func([]fncArg) (evalFunction, error) {
+	return func(_ []fncArg) (evalFunction, error) {
+		return func(args []any) (any, error) {
+			if args[0] == nil {
+				return nil, nil
+			}
+			return f(toFncString(args[0])), nil
+		}, nil
+	}
+}
+
+func buildFncNumber(f func(float64) float64) func([]fncArg) (evalFunction, error) {
+	return func(_ []fncArg) (evalFunction, error) {
+		return func(args []any) (any, error) {
+			if args[0] == nil {
+				return nil, nil
+			}
+			x, err := ToDouble(args[0])
+			if err != nil {
+				return nil, err
+			}
+			return int(f(x)), nil
+		}, nil
+	}
+}
+
+func buildFncSubstring(_ []fncArg) (evalFunction, error) {
+	return func(args []any) (any, error) {
+		if args[0] == nil || args[1] == nil {
+			return nil, nil
+		}
+		str := toFncString(args[0])
+		start, err := toFncInt(args[1])
+		if err != nil {
+			return nil, err
+		}
+		length := -1
+		if len(args) > 2 {
+			if args[2] == nil {
+				return nil, nil
+			}
+			if length, err = toFncInt(args[2]); err != nil {
+				return nil, err
+			}
+			if length < 0 {
+				return nil, fmt.Errorf("substring length must be positive, got %d", length)
+			}
+		}
+		// Work with characters, not bytes
+		if utf8.RuneCountInString(str) != len(str) {
+			runes := []rune(str)
+			from := min(max(start-1, 0), len(runes))
+			to := len(runes)
+			if length >= 0 {
+				to = min(max(start-1+length, from), len(runes))
+			}
+			return string(runes[from:to]), nil
+		}
+		from := min(max(start-1, 0), len(str))
+		to := len(str)
+		if length >= 0 {
+			to = min(max(start-1+length, from), len(str))
+		}
+		return str[from:to], nil
+	}, nil
+}
+
+func buildFncConcat(_ []fncArg) (evalFunction, error) {
+	return func(args []any) (any, error) {
+		var buf strings.Builder
+		for _, arg := range args {
+			if arg != nil {
+				buf.WriteString(toFncString(arg))
+			}
+		}
+		return buf.String(), nil
+	}, nil
+}
+
+func buildFncCoalesce(_ []fncArg) (evalFunction, error) {
+	return func(args []any) (any, error) {
+		for _, arg := range args {
+			if arg != nil {
+				return arg, nil
+			}
+		}
+		return nil, nil
+	}, nil
+}
+
+func buildFncNullIf(_ []fncArg) (evalFunction, error) {
+	eq := &opEqual{}
+	return func(args []any) (any, error) {
+		if args[0] == nil || args[1] == nil {
+			return args[0], nil
+		}
+		v, err := eq.Eval(args[0], args[1])
+		if err != nil {
+			return nil, err
+		}
+		if ToBool(v) {
+			return nil, nil
+		}
+		return args[0], nil
+	}, nil
+}
+
+func buildFncRound(_ []fncArg) (evalFunction, error) {
+	return func(args []any) (any, error) {
+		if args[0] == nil {
+			return nil, nil
+		}
+		x, err := ToDouble(args[0])
+		if err != nil {
+			return nil, err
+		}
+		digits := 0
+		if len(args) > 1 && args[1] != nil {
+			if digits, err = toFncInt(args[1]); err != nil {
+				return nil, err
+			}
+		}
+		p := math.Pow10(digits)
+		return math.Round(x*p) / p, nil
+	}, nil
+}
+
+func buildFncMod(_ []fncArg) (evalFunction, error) {
+	return func(args []any) (any, error) {
+		if args[0] == nil || args[1] == nil {
+			return nil, nil
+		}
+		if staticTypeOfValue(args[0]) == fncTypeInt && staticTypeOfValue(args[1]) == fncTypeInt {
+			x, _ := toFncInt(args[0])
+			y, _ := toFncInt(args[1])
+			if y == 0 {
+				return nil, fmt.Errorf("mod by zero")
+			}
+			return x % y, nil
+		}
+		x, err := ToDouble(args[0])
+		if err != nil {
+			return nil, err
+		}
+		y, err := ToDouble(args[1])
+		if err != nil {
+			return nil, err
+		}
+		if y == 0 {
+			return nil, fmt.Errorf("mod by zero")
+		}
+		return math.Mod(x, y), nil
+	}, nil
+}
+
+// checkDateUnit validates the date unit when constant
+func checkDateUnit(args []fncArg, ipos int, units ...string) error {
+	if len(args) <= ipos {
+		return nil
+	}
+	unit, ok, err := constFncString(args[ipos], "date unit")
+	if err != nil || !ok {
+		return err
+	}
+	for _, u := range units {
+		if unit == u {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid date unit '%s', expecting one of %v", unit, units)
+}
+
+func fncDateUnit(args []any, ipos int) string {
+	if len(args) <= ipos || args[ipos] == nil {
+		return "day"
+	}
+	return strings.ToLower(toFncString(args[ipos]))
+}
+
+func buildFncDateAdd(args []fncArg) (evalFunction, error) {
+	if err := checkDateUnit(args, 2, "day", "week", "month", "year"); err != nil {
+		return nil, err
+	}
+	return func(args []any) (any, error) {
+		if args[0] == nil || args[1] == nil {
+			return nil, nil
+		}
+		d, err := toFncDate(args[0])
+		if err != nil {
+			return nil, err
+		}
+		n, err := toFncInt(args[1])
+		if err != nil {
+			return nil, err
+		}
+		switch unit := fncDateUnit(args, 2); unit {
+		case "day":
+			return d.AddDate(0, 0, n), nil
+		case "week":
+			return d.AddDate(0, 0, 7*n), nil
+		case "month":
+			return d.AddDate(0, n, 0), nil
+		case "year":
+			return d.AddDate(n, 0, 0), nil
+		default:
+			return nil, fmt.Errorf("invalid date unit '%s'", unit)
+		}
+	}, nil
+}
+
+func buildFncDateDiff(args []fncArg) (evalFunction, error) {
+	if err := checkDateUnit(args, 2, "day", "week", "month", "year"); err != nil {
+		return nil, err
+	}
+	return func(args []any) (any, error) {
+		if args[0] == nil || args[1] == nil {
+			return nil, nil
+		}
+		from, err := toFncDate(args[0])
+		if err != nil {
+			return nil, err
+		}
+		to, err := toFncDate(args[1])
+		if err != nil {
+			return nil, err
+		}
+		// nbr of days between the dates, ignoring the time of day
+		days := int(truncateDate(to, "day").Sub(truncateDate(from, "day")).Hours() / 24)
+		switch unit := fncDateUnit(args, 2); unit {
+		case "day":
+			return days, nil
+		case "week":
+			return days / 7, nil
+		case "month":
+			return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month()), nil
+		case "year":
+			return to.Year() - from.Year(), nil
+		default:
+			return nil, fmt.Errorf("invalid date unit '%s'", unit)
+		}
+	}, nil
+}
+
+// truncateDate truncates d to the start of the unit, in the location of d
+func truncateDate(d time.Time, unit string) time.Time {
+	switch unit {
+	case "week":
+		// weeks start on monday
+		offset := (int(d.Weekday()) + 6) % 7
+		return time.Date(d.Year(), d.Month(), d.Day()-offset, 0, 0, 0, 0, d.Location())
+	case "month":
+		return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, d.Location())
+	case "quarter":
+		return time.Date(d.Year(), d.Month()-(d.Month()-1)%3, 1, 0, 0, 0, 0, d.Location())
+	case "year":
+		return time.Date(d.Year(), 1, 1, 0, 0, 0, 0, d.Location())
+	}
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, d.Location())
+}
+
+func buildFncDateTrunc(args []fncArg) (evalFunction, error) {
+	if err := checkDateUnit(args, 0, "day", "week", "month", "quarter", "year"); err != nil {
+		return nil, err
+	}
+	return func(args []any) (any, error) {
+		if args[0] == nil || args[1] == nil {
+			return nil, nil
+		}
+		d, err := toFncDate(args[1])
+		if err != nil {
+			return nil, err
+		}
+		switch unit := fncDateUnit(args, 0); unit {
+		case "day", "week", "month", "quarter", "year":
+			return truncateDate(d, unit), nil
+		default:
+			return nil, fmt.Errorf("invalid date unit '%s'", unit)
+		}
+	}, nil
+}
+
+func buildFncAgeAsOf(_ []fncArg) (evalFunction, error) {
+	return func(args []any) (any, error) {
+		if args[0] == nil || args[1] == nil {
+			return nil, nil
+		}
+		dob, err := toFncDate(args[0])
+		if err != nil {
+			return nil, err
+		}
+		asOf, err := toFncDate(args[1])
+		if err != nil {
+			return nil, err
+		}
+		age := asOf.Year() - dob.Year()
+		if asOf.Month() < dob.Month() || (asOf.Month() == dob.Month() && asOf.Day() < dob.Day()) {
+			age -= 1
+		}
+		return age, nil
+	}, nil
+}
+
+func buildFncParseDouble(_ []fncArg) (evalFunction, error) {
+	return func(args []any) (any, error) {
+		if args[0] == nil {
+			return nil, nil
+		}
+		str := strings.TrimSpace(toFncString(args[0]))
+		if len(str) == 0 {
+			return nil, nil
+		}
+		return utils.String2Double(str)
+	}, nil
+}
+
+func buildFncRegexExtract(args []fncArg) (evalFunction, error) {
+	if !args[1].isConst {
+		return nil, fmt.Errorf("the regex pattern must be a constant")
+	}
+	pattern, ok := args[1].value.(string)
+	if !ok {
+		return nil, fmt.Errorf("the regex pattern must be a string")
+	}
+	re, err := getCompiledRegex(pattern)
+	if err != nil {
+		return nil, err
+	}
+	group := 0
+	if len(args) > 2 {
+		if !args[2].isConst {
+			return nil, fmt.Errorf("the regex group must be a constant")
+		}
+		group, err = toFncInt(args[2].value)
+		if err != nil {
+			return nil, err
+		}
+		if group < 0 || group > re.NumSubexp() {
+			return nil, fmt.Errorf("invalid regex group %d, the pattern has %d groups", group, re.NumSubexp())
+		}
+	}
+	return func(args []any) (any, error) {
+		if args[0] == nil {
+			return nil, nil
+		}
+		return regexExtract(re, toFncString(args[0]), group), nil
+	}, nil
+}
+
+func regexExtract(re *regexp.Regexp, str string, group int) any {
+	match := re.FindStringSubmatch(str)
+	if len(match) <= group || len(match[group]) == 0 {
+		return nil
+	}
+	return match[group]
+}
@@ -0,0 +1,140 @@
+package compute_pipes
+
+import (
+	"testing"
+	"time"
+)
+
+// This file contains test cases for the function library of the expression evaluator
+
+func fncNode(name string, args ...ExpressionNode) ExpressionNode {
+	return ExpressionNode{Type: "function", Expr: name, Farg: args}
+}
+
+func selectNode(colName, rdfType string) ExpressionNode {
+	return ExpressionNode{Type: "select", Expr: colName, AsRdfType: rdfType}
+}
+
+func valueNode(value string) ExpressionNode {
+	return ExpressionNode{Type: "value", Expr: value}
+}
+
+func TestFunctionLibrary(t *testing.T) {
+	ctx := ExprBuilderContext(make(map[string]any))
+	columns := map[string]int{"name": 0, "dob": 1, "amount": 2, "code": 3}
+	row := []any{"  Jean-Paul  ", "1980-06-15", 10.456, "ABC-1234-X"}
+	date := func(y, m, d int) time.Time { return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		spec     ExpressionNode
+		expected any
+	}{
+		{fncNode("trim", selectNode("name", "")), "Jean-Paul"},
+		{fncNode("upper", fncNode("trim", selectNode("name", "text"))), "JEAN-PAUL"},
+		{fncNode("lower", valueNode("'ABC'")), "abc"},
+		{fncNode("substring", valueNode("'abcdef'"), valueNode("2"), valueNode("3")), "bcd"},
+		{fncNode("substring", valueNode("'abcdef'"), valueNode("4")), "def"},
+		{fncNode("substring", valueNode("'abc'"), valueNode("5"), valueNode("3")), ""},
+		{fncNode("substring", valueNode("'héllo'"), valueNode("2"), valueNode("2")), "él"},
+		{fncNode("concat", valueNode("'a'"), valueNode("null"), valueNode("1")), "a1"},
+		{fncNode("coalesce", valueNode("null"), valueNode("'x'")), "x"},
+		{fncNode("nullif", valueNode("'x'"), valueNode("'x'")), nil},
+		{fncNode("nullif", valueNode("'x'"), valueNode("'y'")), "x"},
+		{fncNode("round", selectNode("amount", "double"), valueNode("2")), 10.46},
+		{fncNode("round", valueNode("2.5")), 3.0},
+		{fncNode("floor", selectNode("amount", "")), 10},
+		{fncNode("ceil", valueNode("-1.5")), -1},
+		{fncNode("mod", valueNode("7"), valueNode("3")), 1},
+		{fncNode("mod", valueNode("7.5"), valueNode("2")), 1.5},
+		{fncNode("date_add", selectNode("dob", "date"), valueNode("10")), date(1980, 6, 25)},
+		{fncNode("date_add", valueNode("'2024-01-31'"), valueNode("1"), valueNode("'month'")), date(2024, 3, 2)},
+		{fncNode("date_add", valueNode("'2024-02-29'"), valueNode("-1"), valueNode("'year'")), date(2023, 3, 1)},
+		{fncNode("date_diff", valueNode("'2024-01-01'"), valueNode("'2024-03-01'")), 60},
+		{fncNode("date_diff", valueNode("'2024-01-01'"), valueNode("'2024-03-01'"), valueNode("'week'")), 8},
+		{fncNode("date_diff", valueNode("'2023-11-30'"), valueNode("'2024-02-01'"), valueNode("'month'")), 3},
+		{fncNode("date_trunc", valueNode("'month'"), valueNode("'2024-05-17'")), date(2024, 5, 1)},
+		{fncNode("date_trunc", valueNode("'quarter'"), valueNode("'2024-05-17'")), date(2024, 4, 1)},
+		{fncNode("date_trunc", valueNode("'week'"), valueNode("'2024-05-19'")), date(2024, 5, 13)},
+		{fncNode("date_trunc", valueNode("'year'"), valueNode("'2024-05-17'")), date(2024, 1, 1)},
+		{fncNode("age_as_of", selectNode("dob", "date"), valueNode("'2020-06-14'")), 39},
+		{fncNode("age_as_of", selectNode("dob", "date"), valueNode("'2020-06-15'")), 40},
+		{fncNode("age_as_of", selectNode("dob", "date"), valueNode("'2021-01-01'")), 40},
+		{fncNode("parse_double", valueNode("' 12.5 '")), 12.5},
+		{fncNode("parse_double", valueNode("''")), nil},
+		{fncNode("regex_extract", selectNode("code", "text"), valueNode("'[0-9]+'")), "1234"},
+		{fncNode("regex_extract", selectNode("code", "text"), valueNode("'^([A-Z]+)-'"), valueNode("1")), "ABC"},
+		{fncNode("regex_extract", selectNode("code", "text"), valueNode("'Z+'")), nil},
+		{fncNode("upper", valueNode("null")), nil},
+	}
+	for i, tc := range tests {
+		eval, err := ctx.BuildExprNodeEvaluator("source1", columns, &tc.spec)
+		if err != nil {
+			t.Fatalf("test %d (%s): unexpected build error: %v", i, tc.spec.Expr, err)
+		}
+		value, err := eval.Eval(row)
+		if err != nil {
+			t.Fatalf("test %d (%s): unexpected eval error: %v", i, tc.spec.Expr, err)
+		}
+		if expectedDate, ok := tc.expected.(time.Time); ok {
+			d, ok := value.(time.Time)
+			if !ok || !d.Equal(expectedDate) {
+				t.Errorf("test %d (%s): expecting %v, got %v", i, tc.spec.Expr, expectedDate, value)
+			}
+			continue
+		}
+		if value != tc.expected {
+			t.Errorf("test %d (%s): expecting %v (%T), got %v (%T)", i, tc.spec.Expr, tc.expected, tc.expected, value, value)
+		}
+	}
+}
+
+func TestFunctionLibraryBuildErrors(t *testing.T) {
+	ctx := ExprBuilderContext(make(map[string]any))
+	columns := map[string]int{"name": 0, "dob": 1, "amount": 2}
+
+	tests := []ExpressionNode{
+		fncNode("no_such_function"),
+		fncNode("current_year", valueNode("1")),
+		fncNode("upper"),
+		fncNode("substring", valueNode("'abc'")),
+		fncNode("substring", valueNode("'abc'"), valueNode("'x'")),
+		fncNode("round", selectNode("dob", "date")),
+		fncNode("date_add", selectNode("amount", "double"), valueNode("1")),
+		fncNode("date_add", valueNode("'2024-01-01'"), valueNode("1"), valueNode("'hour'")),
+		fncNode("date_trunc", valueNode("'decade'"), selectNode("dob", "date")),
+		fncNode("age_as_of", valueNode("'not a date'"), selectNode("dob", "date")),
+		fncNode("regex_extract", selectNode("name", "text"), selectNode("name", "text")),
+		fncNode("regex_extract", selectNode("name", "text"), valueNode("'[a-z'")),
+		fncNode("regex_extract", selectNode("name", "text"), valueNode("'([a-z]+)'"), valueNode("2")),
+		fncNode("floor", fncNode("date_trunc", valueNode("'day'"), selectNode("dob", "date"))),
+	}
+	for i, spec := range tests {
+		_, err := ctx.BuildExprNodeEvaluator("source1", columns, &spec)
+		if err == nil {
+			t.Errorf("test %d (%s): expecting a build error", i, spec.Expr)
+		}
+	}
+}
+
+func TestFunctionLibraryEvalErrors(t *testing.T) {
+	ctx := ExprBuilderContext(make(map[string]any))
+	columns := map[string]int{"x": 0, "y": 1}
+
+	tests := []struct {
+		spec ExpressionNode
+		row  []any
+	}{
+		{fncNode("mod", selectNode("x", ""), selectNode("y", "")), []any{7, 0}},
+		{fncNode("parse_double", selectNode("x", "")), []any{"abc", nil}},
+		{fncNode("date_add", selectNode("x", ""), valueNode("1")), []any{"not a date", nil}},
+	}
+	for i, tc := range tests {
+		eval, err := ctx.BuildExprNodeEvaluator("source1", columns, &tc.spec)
+		if err != nil {
+			t.Fatalf("test %d (%s): unexpected build error: %v", i, tc.spec.Expr, err)
+		}
+		if _, err = eval.Eval(tc.row); err == nil {
+			t.Errorf("test %d (%s): expecting an eval error", i, tc.spec.Expr)
+		}
+	}
+}
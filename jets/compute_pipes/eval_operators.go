@@ -77,15 +77,10 @@ func BuildEvalOperator(op string) (evalOperator, error) {
 }
 
 // Build the function evaluators
-func BuildFncEvaluator(fnc string) (evalFunction, error) {
-
-	switch strings.ToUpper(fnc) {
-	case "CURRENT_YEAR":
-		return func(args []any) (any, error) {
-			return time.Now().Year(), nil
-		}, nil
-	}
-	return nil, fmt.Errorf("error: unknown function: %v", fnc)
+// BuildFncEvaluator returns the function of the function library (see eval_functions.go)
+// along with its return type, args are the static information about the arguments.
+func BuildFncEvaluator(fnc string, args []fncArg) (evalFunction, string, error) {
+	return buildFncFromLibrary(strings.ToUpper(fnc), args)
 }
 
 func ToBool(b any) bool {
@@ -3,6 +3,7 @@ package jetrules_go_adaptor
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
 	"time"
 
@@ -320,6 +321,13 @@ func (ses *JetRdfSessionGo) EncodeRdfSession() string {
 	return string(r)
 }
 
+func (ses *JetRdfSessionGo) Snapshot(w io.Writer) error {
+	if ses.rdfSession == nil {
+		return fmt.Errorf("error: Snapshot called with nil rdfSession")
+	}
+	return ses.rdfSession.Snapshot(w)
+}
+
 // Returns map[string]any which is
 //
 //	   {
@@ -452,6 +460,32 @@ func (ses *JetReteSessionGo) ExecuteRules() error {
 	return ses.reteSession.ExecuteRules()
 }
 
+func (ses *JetReteSessionGo) EnableProvenance() error {
+	ses.reteSession.EnableProvenance()
+	return nil
+}
+
+func (ses *JetReteSessionGo) Explain(s, p, o compute_pipes.RdfNode) ([]string, error) {
+	u, v, w, err := toSPO(s, p, o)
+	if err != nil {
+		return nil, err
+	}
+	explanations, err := ses.reteSession.Explain(u, v, w)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(explanations))
+	for _, e := range explanations {
+		result = append(result, e.String())
+	}
+	return result, nil
+}
+
+func (ses *JetReteSessionGo) EnableProfiler(profiler *rete.ReteProfiler, ruleset string) error {
+	ses.reteSession.EnableProfiler(profiler, ruleset)
+	return nil
+}
+
 func (ses *JetReteSessionGo) Release() error {
 	if ses.reteSession != nil {
 		ses.reteSession.Done()
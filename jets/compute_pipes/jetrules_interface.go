@@ -2,10 +2,12 @@ package compute_pipes
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 
 	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+	"github.com/artisoft-io/jetstore/jets/jetrules/rete"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -67,11 +69,22 @@ type JetRdfSession interface {
 
 	NewReteSession(ruleset string) (JetReteSession, error)
 	EncodeRdfSession() string
+	// Snapshot writes the binary snapshot of the asserted and inferred graphs to w,
+	// see rdf.RestoreSession to reload it.
+	Snapshot(w io.Writer) error
 	Release() error
 }
 
 type JetReteSession interface {
 	ExecuteRules() error
+	// EnableProvenance turns on the rule firing provenance, call before ExecuteRules.
+	EnableProvenance() error
+	// Explain returns the derivation tree, as text, of the triples matching (s, p, o),
+	// nil s, p or o match any node.
+	Explain(s, p, o RdfNode) ([]string, error)
+	// EnableProfiler turns on the rule execution profiler, the profile of the session is
+	// added to profiler under ruleset when the session is released. Call before ExecuteRules.
+	EnableProfiler(profiler *rete.ReteProfiler, ruleset string) error
 	Release() error
 }
 
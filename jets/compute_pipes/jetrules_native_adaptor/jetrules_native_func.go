@@ -3,6 +3,7 @@ package jetrules_native_adaptor
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"sort"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/artisoft-io/jetstore/jets/bridge"
 	"github.com/artisoft-io/jetstore/jets/compute_pipes"
 	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+	"github.com/artisoft-io/jetstore/jets/jetrules/rete"
 	"github.com/artisoft-io/jetstore/jets/workspace"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -426,6 +428,10 @@ func (ses *JetRdfSessionNative) EncodeRdfSession() string {
 	return string(r)
 }
 
+func (ses *JetRdfSessionNative) Snapshot(w io.Writer) error {
+	return fmt.Errorf("error: rdf session snapshot is not supported by the jetrules native engine")
+}
+
 // Returns map[string]any which is
 //
 //	   {
@@ -578,6 +584,18 @@ func (ses *JetReteSessionNative) ExecuteRules() error {
 	return nil
 }
 
+func (ses *JetReteSessionNative) EnableProvenance() error {
+	return fmt.Errorf("error: rule firing provenance is not supported by the jetrules native engine")
+}
+
+func (ses *JetReteSessionNative) Explain(s, p, o compute_pipes.RdfNode) ([]string, error) {
+	return nil, fmt.Errorf("error: rule firing provenance is not supported by the jetrules native engine")
+}
+
+func (ses *JetReteSessionNative) EnableProfiler(profiler *rete.ReteProfiler, ruleset string) error {
+	return fmt.Errorf("error: rule execution profiler is not supported by the jetrules native engine")
+}
+
 func (ses *JetReteSessionNative) Release() error {
 	if ses.reteSession != nil {
 		ses.reteSession.ReleaseReteSession()
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"sync"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rete"
 )
 
 // JrPoolManager manages a pool of JrPoolWorkers for jetrules execution
@@ -11,12 +13,15 @@ import (
 // JrPoolManager manage a pool of workers to execute rules in parallel
 // jrPoolWg is a wait group of the workers.
 // The WorkersTaskCh is closed in jetrules operator
+// profiler is set when the rule execution profiler is enabled, it collects the
+// profile of the rete sessions of all the workers.
 type JrPoolManager struct {
 	config        *JetrulesSpec
 	WorkersTaskCh chan []any
 	ErrorOutputCh	*OutputChannel
 	jrPoolWg      *sync.WaitGroup
 	WaitForDone   *sync.WaitGroup
+	profiler      *rete.ReteProfiler
 }
 
 // Create the JrPoolManager, it will be set to the receiving BuilderContext
@@ -39,6 +44,9 @@ func (ctx *BuilderContext) NewJrPoolManager(
 		jrPoolWg:      new(sync.WaitGroup),
 		WaitForDone:   new(sync.WaitGroup),
 	}
+	if config.Profile {
+		jrpm.profiler = rete.NewReteProfiler()
+	}
 	jrpm.WaitForDone.Add(1)
 
 	// Create a channel for the workers to report results
@@ -91,9 +99,26 @@ func (ctx *BuilderContext) NewJrPoolManager(
 			}()
 		}
 		jrpm.jrPoolWg.Wait()
+		jrpm.writeProfile(ctx)
 		jrpm.WaitForDone.Done()
 		close(workersResultCh)
 		log.Println("Jetrules Worker Pool Completed")
 	}()
 	return
 }
+
+// writeProfile writes the rule execution profile to the log and to the error channel
+func (jrpm *JrPoolManager) writeProfile(ctx *BuilderContext) {
+	if jrpm.profiler == nil {
+		return
+	}
+	log.Println("Jetrules Execution Profile:")
+	for _, line := range jrpm.profiler.Table() {
+		log.Println(line)
+		if jrpm.ErrorOutputCh != nil {
+			peRow := ctx.NewProcessError()
+			peRow.ErrorMessage = fmt.Sprintf("profile: %s", line)
+			peRow.write2Chan(jrpm.ErrorOutputCh, ctx.done)
+		}
+	}
+}
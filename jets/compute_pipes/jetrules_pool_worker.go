@@ -1,6 +1,7 @@
 package compute_pipes
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -13,8 +14,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/artisoft-io/jetstore/jets/awsi"
 	"github.com/artisoft-io/jetstore/jets/jetrules/rete"
 	"github.com/artisoft-io/jetstore/jets/utils"
+	"github.com/google/uuid"
 )
 
 // Worker to perform jetrules execute rules function
@@ -28,6 +31,8 @@ type JrPoolWorker struct {
 	ruleEngine           JetRuleEngine
 	errorCount           int
 	nbrReteSessionsSaved int
+	nbrProvenanceSaved   int
+	profiler             *rete.ReteProfiler
 	errorOutputCh        *OutputChannel
 	outputChannels       []*JetrulesOutputChan
 	done                 chan struct{}
@@ -85,6 +90,7 @@ func (ctx *BuilderContext) NewJrPoolWorker(config *JetrulesSpec, source *InputCh
 func (ctx *JrPoolWorker) DoWork(mgr *JrPoolManager, resultCh chan JetrulesWorkerResult) {
 	var count int64
 	var err error
+	ctx.profiler = mgr.profiler
 	for task := range mgr.WorkersTaskCh {
 		err = ctx.executeRules(&task, resultCh)
 		if err != nil {
@@ -104,7 +110,8 @@ func (ctx *JrPoolWorker) DoWork(mgr *JrPoolManager, resultCh chan JetrulesWorker
 
 // Perform jetrules execute rules
 // errorOutputCh to collect rule errors / exception to write to process_errors table:
-//   - rete session triples saved
+//   - rete session triples saved, with the session snapshot saved in the stage area
+//     when config.SessionSnapshot is true
 //   - BAD ROW via ExecuteRules() returned error
 //   - error: max loop reached
 //   - Rete Session Has Rule Exception
@@ -181,6 +188,20 @@ func (ctx *JrPoolWorker) executeRules(inputRecords *[]any,
 			cpErr = fmt.Errorf("error: while creating rete session for ruleset %s: %v", ruleset, err)
 			goto gotError
 		}
+		if ctx.profiler != nil {
+			err = reteSession.EnableProfiler(ctx.profiler, ruleset)
+			if err != nil {
+				cpErr = fmt.Errorf("while enabling profiler for ruleset %s: %v", ruleset, err)
+				goto gotError
+			}
+		}
+		if ctx.config.Provenance != nil {
+			err = reteSession.EnableProvenance()
+			if err != nil {
+				cpErr = fmt.Errorf("while enabling provenance for ruleset %s: %v", ruleset, err)
+				goto gotError
+			}
+		}
 		if !inputAsserted {
 			// Assert the input records to rdf session
 			err = assertInputRecords(ctx.config, ctx.source, ctx.rdfType2Columns, rdfSession, inputRecords)
@@ -231,6 +252,7 @@ func (ctx *JrPoolWorker) executeRules(inputRecords *[]any,
 					// report the rule error
 					peRow := ctx.builderContext.NewProcessError()
 					peRow.ErrorMessage = fmt.Sprintf("ExecuteRules returned error: %v", err2)
+					ctx.saveReteSession(&peRow, rdfSession)
 					peRow.write2Chan(ctx.errorOutputCh, ctx.done)
 					log.Printf("jetrules: ExecuteRules returned error: %v", err2)
 				} else {
@@ -270,11 +292,7 @@ func (ctx *JrPoolWorker) executeRules(inputRecords *[]any,
 				if ctx.errorOutputCh != nil && ctx.errorCount < 25 {
 					peRow := ctx.builderContext.NewProcessError()
 					peRow.ErrorMessage = fmt.Sprintf("jets:exception caught: %s", hasException)
-					if ctx.config.MaxReteSessionsSaved > 0 && ctx.nbrReteSessionsSaved < ctx.config.MaxReteSessionsSaved {
-						ctx.nbrReteSessionsSaved++
-						peRow.ReteSessionSaved = "Y"
-						peRow.ReteSessionTriples = sql.NullString{String: rdfSession.EncodeRdfSession(), Valid: true}
-					}
+					ctx.saveReteSession(&peRow, rdfSession)
 					peRow.write2Chan(ctx.errorOutputCh, ctx.done)
 					log.Printf("jetrule: jets:exception caught: %s", hasException)
 				} else {
@@ -287,6 +305,13 @@ func (ctx *JrPoolWorker) executeRules(inputRecords *[]any,
 			ctor.Next()
 		}
 		ctor.Release()
+		if ctx.config.Provenance != nil {
+			err = ctx.exportProvenance(reteSession, rm)
+			if err != nil {
+				cpErr = fmt.Errorf("while exporting provenance for ruleset %s: %v", ruleset, err)
+				goto gotError
+			}
+		}
 		reteSession.Release()
 	}
 
@@ -334,6 +359,36 @@ gotError:
 	return cpErr
 }
 
+// saveReteSession saves the rdf session in peRow, up to config.MaxReteSessionsSaved sessions.
+// When config.SessionSnapshot is true, the snapshot of the session is also saved in the
+// stage area and its file key is added to the error message.
+func (ctx *JrPoolWorker) saveReteSession(peRow *ProcessError, rdfSession JetRdfSession) {
+	if ctx.config.MaxReteSessionsSaved == 0 || ctx.nbrReteSessionsSaved >= ctx.config.MaxReteSessionsSaved {
+		return
+	}
+	ctx.nbrReteSessionsSaved++
+	peRow.ReteSessionSaved = "Y"
+	peRow.ReteSessionTriples = sql.NullString{String: rdfSession.EncodeRdfSession(), Valid: true}
+	if !ctx.config.SessionSnapshot {
+		return
+	}
+	var buf bytes.Buffer
+	err := rdfSession.Snapshot(&buf)
+	if err != nil {
+		log.Printf("jetrules: while taking the rdf session snapshot: %v", err)
+		return
+	}
+	bc := ctx.builderContext
+	fileKey := fmt.Sprintf("%s/process_name=%s/session_id=%s/jetrules_snapshots/jets_partition=%s/%s.jrss",
+		awsi.JetStoreStagePrefix(), bc.processName, bc.sessionId, bc.jetsPartition, uuid.NewString())
+	err = objectStore.PutObject("", fileKey, &buf)
+	if err != nil {
+		log.Printf("jetrules: while saving the rdf session snapshot to %s: %v", fileKey, err)
+		return
+	}
+	peRow.ErrorMessage = fmt.Sprintf("%s (session snapshot: %s)", peRow.ErrorMessage, fileKey)
+}
+
 func (ctx *JrPoolWorker) extractSessionData(rdfSession JetRdfSession,
 	outChannel *JetrulesOutputChan) error {
 
@@ -718,3 +773,44 @@ func NewRdfNode(inValue any, re JetResourceManager) (RdfNode, error) {
 		return nil, fmt.Errorf("error: unknown type %T for NewRdfNode", vv)
 	}
 }
+
+// exportProvenance writes the derivation tree of the inferred triples matching the
+// provenance spec to the error channel
+func (ctx *JrPoolWorker) exportProvenance(reteSession JetReteSession, rm JetResourceManager) error {
+	spec := ctx.config.Provenance
+	maxExported := spec.MaxExported
+	if maxExported == 0 {
+		maxExported = 10
+	}
+	if ctx.errorOutputCh == nil || ctx.nbrProvenanceSaved >= maxExported {
+		return nil
+	}
+	predicate := rm.NewResource(spec.Predicate)
+	var object RdfNode
+	var err error
+	if spec.Object != "" {
+		rdfType := spec.ObjectRdfType
+		if rdfType == "" {
+			rdfType = "resource"
+		}
+		object, err = ParseRdfNodeValue(rm, spec.Object, rdfType)
+		if err != nil {
+			return fmt.Errorf("while parsing provenance object value: %v", err)
+		}
+	}
+	explanations, err := reteSession.Explain(nil, predicate, object)
+	if err != nil {
+		return err
+	}
+	for _, explanation := range explanations {
+		if ctx.nbrProvenanceSaved >= maxExported {
+			break
+		}
+		ctx.nbrProvenanceSaved++
+		peRow := ctx.builderContext.NewProcessError()
+		peRow.InputColumn = sql.NullString{String: spec.Predicate, Valid: true}
+		peRow.ErrorMessage = fmt.Sprintf("provenance: %s", explanation)
+		peRow.write2Chan(ctx.errorOutputCh, ctx.done)
+	}
+	return nil
+}
@@ -24,12 +24,15 @@ import (
 // Env variables:
 // JETS_BUCKET
 // JETS_DSN_SECRET
+// JETS_DSN_JSON_VALUE Alternate to JETS_DSN_SECRET when using the local object store
 // JETS_REGION
 // JETS_s3_INPUT_PREFIX
 // JETS_s3_OUTPUT_PREFIX
 // JETS_s3_STAGE_PREFIX
 // JETS_s3_SCHEMA_TRIGGERS
 // JETS_S3_KMS_KEY_ARN
+// JETS_LOCAL_OBJECT_STORE Use the local directory rather than s3, files are located at
+// $JETS_LOCAL_OBJECT_STORE/$JETS_BUCKET/$JETS_s3_INPUT_PREFIX/... (same for stage and output)
 // USING_SSH_TUNNEL Connect  to DB using ssh tunnel (expecting the ssh open)
 // DEPLOY_CPIPES_NATIVE Use the native jetrules engine
 var pipelineExecKey = flag.Int("pipeline_execution_key", -1, "Pipeline execution key (required)")
@@ -44,6 +47,7 @@ var awsBucket string
 var dsn string
 var dbpool *pgxpool.Pool
 var usingJetRuleEngineNative bool
+var localObjectStore string
 
 // var nbrNodes int
 
@@ -72,13 +76,14 @@ func main() {
 	var errMsg []string
 	var err error
 	dbPoolSize = 3
+	localObjectStore = os.Getenv("JETS_LOCAL_OBJECT_STORE")
 	awsDsnSecret = os.Getenv("JETS_DSN_SECRET")
-	if awsDsnSecret == "" {
+	if awsDsnSecret == "" && (localObjectStore == "" || os.Getenv("JETS_DSN_JSON_VALUE") == "") {
 		hasErr = true
-		errMsg = append(errMsg, "Connection string must be provided using env JETS_DSN_SECRET")
+		errMsg = append(errMsg, "Connection string must be provided using env JETS_DSN_SECRET (or JETS_DSN_JSON_VALUE with JETS_LOCAL_OBJECT_STORE)")
 	}
 	awsRegion = os.Getenv("JETS_REGION")
-	if awsRegion == "" {
+	if awsRegion == "" && localObjectStore == "" {
 		hasErr = true
 		errMsg = append(errMsg, "aws region must be provided using env JETS_REGION")
 	}
@@ -96,17 +101,30 @@ func main() {
 		errMsg = append(errMsg, "env var JETS_s3_OUTPUT_PREFIX must be provided")
 	}
 	_, usingSshTunnel = os.LookupEnv("USING_SSH_TUNNEL")
-	if !usingSshTunnel {
+	switch {
+	case usingSshTunnel:
+		log.Println("Using SSH Tunnel to connect to DB")
+	case localObjectStore != "":
+		log.Println("Using local object store, connecting to DB without ssh tunnel")
+	default:
 		hasErr = true
 		errMsg = append(errMsg, "env USING_SSH_TUNNEL must be set for local testing")
-	} else {
-		log.Println("Using SSH Tunnel to connect to DB")
 	}
 
-	// Get the dsn from the aws secret
-	dsn, err = awsi.GetDsnFromSecret(awsDsnSecret, usingSshTunnel, dbPoolSize)
+	if awsDsnSecret != "" {
+		// Get the dsn from the aws secret
+		dsn, err = awsi.GetDsnFromSecret(awsDsnSecret, usingSshTunnel, dbPoolSize)
+		if err != nil {
+			err = fmt.Errorf("while getting dsn from aws secret: %v", err)
+		}
+	} else {
+		// Get the dsn from the json value, no aws needed
+		dsn, err = awsi.GetDsnFromJson(os.Getenv("JETS_DSN_JSON_VALUE"), usingSshTunnel, dbPoolSize)
+		if err != nil {
+			err = fmt.Errorf("while getting dsn from json value: %v", err)
+		}
+	}
 	if err != nil {
-		err = fmt.Errorf("while getting dsn from aws secret: %v", err)
 		log.Println(err)
 		hasErr = true
 		errMsg = append(errMsg, err.Error())
@@ -118,6 +136,7 @@ func main() {
 	log.Println("Got argument: awsDsnSecret", awsDsnSecret)
 	log.Println("Got argument: dbPoolSize", dbPoolSize)
 	log.Println("Got argument: awsRegion", awsRegion)
+	log.Println("Got env: JETS_LOCAL_OBJECT_STORE", localObjectStore)
 	log.Println("Got env: JETS_S3_KMS_KEY_ARN", os.Getenv("JETS_S3_KMS_KEY_ARN"))
 	log.Println("Got env: DEPLOY_CPIPES_NATIVE", os.Getenv("DEPLOY_CPIPES_NATIVE"))
 
@@ -0,0 +1,355 @@
+package compute_pipes
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lookup table from s3 files, loaded into an on-disk key-value store.
+// Lookup table type kv_lookup is for tables too large to be loaded into memory,
+// the csv_source format can be csv, headerless_csv or parquet.
+// Lookup table type s3_parquet_lookup is a kv_lookup with csv_source format parquet.
+// The key-value store is built once per node: the lookup tables of the same key
+// and s3 file share the same store, it is removed when the last of them is released.
+//
+// The store is a single data file of hash partitions, each partition is a sorted
+// sequence of blocks of kvBlockSize rows. Only the first key of each block is kept in memory.
+
+const kvBlockSize = 64
+
+func init() {
+	gob.Register([]any{})
+}
+
+type LookupTableKv struct {
+	spec         *LookupSpec
+	isEmptyTable bool
+	store        *kvStore
+	columnsMap   map[string]int
+}
+
+// kvLookupStores is the registry of the key-value stores built on this node,
+// the stores are reference counted by the lookup tables using them
+var kvLookupStores = struct {
+	sync.Mutex
+	stores map[string]*kvStore
+}{stores: make(map[string]*kvStore)}
+
+func NewLookupTableKv(_ *pgxpool.Pool, spec *LookupSpec, env map[string]any, isVerbose bool) (LookupTable, error) {
+	if spec == nil || spec.CsvSource == nil {
+		return nil, fmt.Errorf("error: lookup table of type kv_lookup or s3_parquet_lookup must have csv_source configured")
+	}
+	if spec.Type == "s3_parquet_lookup" {
+		spec.CsvSource.Format = "parquet"
+	}
+	tbl := &LookupTableKv{
+		spec:       spec,
+		columnsMap: make(map[string]int),
+	}
+	// Keep a mapping of the returned column names to their position in the returned row
+	for i, valueColumn := range tbl.spec.LookupValues {
+		tbl.columnsMap[valueColumn] = i
+	}
+
+	csvSource, err := NewCsvSourceS3(spec.CsvSource, env)
+	if err != nil {
+		return nil, fmt.Errorf("while calling NewCsvSourceS3 (NewLookupTableKv): %v", err)
+	}
+	// Check if this is an empty source (no file found and spec indicates not to error out)
+	if csvSource.fileKey == nil {
+		tbl.isEmptyTable = true
+		return tbl, nil
+	}
+
+	// Build the store once per node
+	kvLookupStores.Lock()
+	defer kvLookupStores.Unlock()
+	storeKey := fmt.Sprintf("%s|%s", spec.Key, csvSource.fileKey.key)
+	tbl.store = kvLookupStores.stores[storeKey]
+	if tbl.store != nil {
+		tbl.store.refCount++
+		if isVerbose {
+			log.Printf("Lookup table of type %s with key %s is reusing the store of %d rows", spec.Type, spec.Key, tbl.store.size)
+		}
+		return tbl, nil
+	}
+	tbl.store, err = buildKvLookupStore(spec, csvSource.fileKey)
+	if err != nil {
+		return nil, fmt.Errorf("while building %s with key %s: %v", spec.Type, spec.Key, err)
+	}
+	tbl.store.storeKey = storeKey
+	tbl.store.refCount = 1
+	kvLookupStores.stores[storeKey] = tbl.store
+	log.Printf("Lookup table of type %s with key %s is built with %d rows", spec.Type, spec.Key, tbl.store.size)
+	return tbl, nil
+}
+
+func buildKvLookupStore(spec *LookupSpec, fileKey *FileKeyInfo) (*kvStore, error) {
+	// Create a local temp directory to hold the file
+	inFolderPath, err := os.MkdirTemp("", "jetstore")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create local temp directory: %v", err)
+	}
+	defer func() {
+		err := os.RemoveAll(inFolderPath)
+		if err != nil {
+			log.Printf("WARNING while calling RemoveAll in lookup temp folder:%v", err)
+		}
+	}()
+
+	// Fetch the file from s3, save it locally
+	retry := 0
+do_retry:
+	inFilePath, fileSize, err := DownloadS3Object("", fileKey, inFolderPath, 1)
+	if err != nil {
+		if retry < 6 {
+			time.Sleep(500 * time.Millisecond)
+			retry++
+			goto do_retry
+		}
+		return nil, fmt.Errorf("failed to download file from s3 for %s of type cpipes: %v", spec.Type, err)
+	}
+	defer os.Remove(inFilePath)
+
+	// Partition the rows so that each partition can be sorted in memory,
+	// using about 8 MB of input file per partition
+	nbrPartitions := min(max(int(fileSize/(8*1024*1024))+1, 16), 4096)
+	builder, err := newKvStoreBuilder("", nbrPartitions)
+	if err != nil {
+		return nil, err
+	}
+	defer builder.Remove()
+	_, err = readLookupFile(spec, inFilePath, builder.Put)
+	if err != nil {
+		return nil, err
+	}
+	return builder.Build()
+}
+
+func (tbl *LookupTableKv) Lookup(key *string) (*[]any, error) {
+	if key == nil {
+		return nil, fmt.Errorf("error: cannot do a lookup with a null key for lookup table %s", tbl.spec.Key)
+	}
+	if tbl.isEmptyTable {
+		return nil, nil
+	}
+	values, err := tbl.store.Get(*key)
+	if err != nil {
+		return nil, fmt.Errorf("while looking up key in lookup table %s: %v", tbl.spec.Key, err)
+	}
+	if values == nil {
+		return nil, nil
+	}
+	return &values, nil
+}
+
+func (tbl *LookupTableKv) LookupValue(row *[]any, columnName string) (any, error) {
+	if tbl.isEmptyTable {
+		return nil, nil
+	}
+	pos, ok := tbl.columnsMap[columnName]
+	if !ok {
+		return nil, fmt.Errorf("error: column named %s is not a column returned by the lookup table %s",
+			columnName, tbl.spec.Key)
+	}
+	return (*row)[pos], nil
+}
+
+func (tbl *LookupTableKv) ColumnMap() map[string]int {
+	return tbl.columnsMap
+}
+
+// Return true only if there was no files found on s3
+func (tbl *LookupTableKv) IsEmptyTable() bool {
+	return tbl.isEmptyTable
+}
+
+// Return size of the lookup table
+func (tbl *LookupTableKv) Size() int64 {
+	if tbl.isEmptyTable {
+		return 0
+	}
+	return tbl.store.size
+}
+
+// Release the store of the lookup table, the store is closed and removed when
+// it is no longer used by any lookup table of the node
+func (tbl *LookupTableKv) Release() {
+	if tbl.store == nil {
+		return
+	}
+	kvLookupStores.Lock()
+	defer kvLookupStores.Unlock()
+	tbl.store.refCount--
+	if tbl.store.refCount <= 0 {
+		delete(kvLookupStores.stores, tbl.store.storeKey)
+		tbl.store.Close()
+	}
+	tbl.store = nil
+}
+
+// kvStore is a read-only on-disk key-value store, safe for concurrent use.
+// storeKey and refCount are guarded by kvLookupStores.
+type kvStore struct {
+	fileHd     *os.File
+	partitions [][]kvBlockIndex
+	size       int64
+	storeKey   string
+	refCount   int
+}
+
+type kvBlockIndex struct {
+	firstKey string
+	offset   int64
+	length   int64
+}
+
+// Get returns the values associated with key, nil if key is not in the store
+func (s *kvStore) Get(key string) ([]any, error) {
+	blocks := s.partitions[spillPartitionOf(key, len(s.partitions))]
+	// Find the last block with first key <= key
+	iblock := sort.Search(len(blocks), func(i int) bool { return blocks[i].firstKey > key }) - 1
+	if iblock < 0 {
+		return nil, nil
+	}
+	rows, err := s.readBlock(blocks[iblock])
+	if err != nil {
+		return nil, err
+	}
+	irow, found := slices.BinarySearchFunc(rows, key, func(row []any, key string) int {
+		return strings.Compare(row[0].(string), key)
+	})
+	if !found {
+		return nil, nil
+	}
+	return rows[irow][1:], nil
+}
+
+func (s *kvStore) readBlock(block kvBlockIndex) ([][]any, error) {
+	buf := make([]byte, block.length)
+	_, err := s.fileHd.ReadAt(buf, block.offset)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("while reading kv store block: %v", err)
+	}
+	data, err := snappy.Decode(nil, buf)
+	if err != nil {
+		return nil, fmt.Errorf("while decompressing kv store block: %v", err)
+	}
+	var rows [][]any
+	err = gob.NewDecoder(bytes.NewReader(data)).Decode(&rows)
+	if err != nil {
+		return nil, fmt.Errorf("while decoding kv store block: %v", err)
+	}
+	return rows, nil
+}
+
+// Close closes and removes the store data file
+func (s *kvStore) Close() {
+	s.fileHd.Close()
+	os.Remove(s.fileHd.Name())
+}
+
+// kvStoreBuilder builds a kvStore from rows put in any order.
+// When a key is put more than once, the last values are kept.
+type kvStoreBuilder struct {
+	dir        string
+	partitions *spillPartitions
+}
+
+// newKvStoreBuilder creates a builder with the store in tempFolder (default temp dir when empty)
+func newKvStoreBuilder(tempFolder string, nbrPartitions int) (*kvStoreBuilder, error) {
+	partitions, err := newSpillPartitions(tempFolder, "kv_lookup", nbrPartitions)
+	if err != nil {
+		return nil, err
+	}
+	return &kvStoreBuilder{
+		dir:        tempFolder,
+		partitions: partitions,
+	}, nil
+}
+
+func (b *kvStoreBuilder) Put(key string, values *[]any) error {
+	row := make([]any, 0, len(*values)+1)
+	row = append(row, key)
+	row = append(row, *values...)
+	return b.partitions.Write(key, row)
+}
+
+// Build sorts the partitions and writes the store data file
+func (b *kvStoreBuilder) Build() (*kvStore, error) {
+	fileHd, err := os.CreateTemp(b.dir, "kv_lookup_store")
+	if err != nil {
+		return nil, fmt.Errorf("while creating kv store file: %v", err)
+	}
+	store := &kvStore{
+		fileHd:     fileHd,
+		partitions: make([][]kvBlockIndex, len(b.partitions.files)),
+	}
+	var offset int64
+	var buf bytes.Buffer
+	err = b.partitions.ForEachPartition(func(ipartition int, reader *spillFileReader) error {
+		var rows [][]any
+		for {
+			row, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			rows = append(rows, row)
+		}
+		// Stable sort keeps the put order of duplicate keys, keep the last one
+		slices.SortStableFunc(rows, func(lhs, rhs []any) int {
+			return strings.Compare(lhs[0].(string), rhs[0].(string))
+		})
+		uniqueRows := rows[:0]
+		for i := range rows {
+			if i+1 < len(rows) && rows[i][0].(string) == rows[i+1][0].(string) {
+				continue
+			}
+			uniqueRows = append(uniqueRows, rows[i])
+		}
+		store.size += int64(len(uniqueRows))
+		for start := 0; start < len(uniqueRows); start += kvBlockSize {
+			block := uniqueRows[start:min(start+kvBlockSize, len(uniqueRows))]
+			buf.Reset()
+			if err := gob.NewEncoder(&buf).Encode(block); err != nil {
+				return fmt.Errorf("while encoding kv store block: %v", err)
+			}
+			data := snappy.Encode(nil, buf.Bytes())
+			if _, err := fileHd.Write(data); err != nil {
+				return fmt.Errorf("while writing kv store file: %v", err)
+			}
+			store.partitions[ipartition] = append(store.partitions[ipartition], kvBlockIndex{
+				firstKey: block[0][0].(string),
+				offset:   offset,
+				length:   int64(len(data)),
+			})
+			offset += int64(len(data))
+		}
+		return nil
+	})
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Remove the partition files of the builder, the built store is not removed
+func (b *kvStoreBuilder) Remove() {
+	b.partitions.Remove()
+}
@@ -0,0 +1,154 @@
+package compute_pipes
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// This file contains test cases for the on-disk key-value lookup store
+
+func TestKvStore(t *testing.T) {
+	builder, err := newKvStoreBuilder(t.TempDir(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer builder.Remove()
+	dt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	nbrRows := 1000
+	for i := range nbrRows {
+		values := []any{fmt.Sprintf("name%d", i), int64(i), nil}
+		if err = builder.Put(fmt.Sprintf("K%04d", i), &values); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Duplicate key, the last one is kept
+	values := []any{"updated", dt, []any{"a", "b"}}
+	if err = builder.Put("K0010", &values); err != nil {
+		t.Fatal(err)
+	}
+	store, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if store.size != int64(nbrRows) {
+		t.Errorf("expecting %d rows, got %d", nbrRows, store.size)
+	}
+	for _, i := range []int{0, 1, 63, 64, 500, 999} {
+		row, err := store.Get(fmt.Sprintf("K%04d", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []any{fmt.Sprintf("name%d", i), int64(i), nil}
+		if !reflect.DeepEqual(row, expected) {
+			t.Errorf("key %d: expecting %v, got %v", i, expected, row)
+		}
+	}
+	row, err := store.Get("K0010")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(row, values) {
+		t.Errorf("expecting %v, got %v", values, row)
+	}
+	for _, key := range []string{"", "A", "K0010x", "K1000", "Z"} {
+		row, err = store.Get(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if row != nil {
+			t.Errorf("expecting no row for key '%s', got %v", key, row)
+		}
+	}
+}
+
+func TestLookupTableKv(t *testing.T) {
+	builder, err := newKvStoreBuilder(t.TempDir(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer builder.Remove()
+	values := []any{"Jones", "Family Medicine"}
+	if err = builder.Put("1234567890", &values); err != nil {
+		t.Fatal(err)
+	}
+	store, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	tbl := &LookupTableKv{
+		spec:       &LookupSpec{Key: "npi", LookupValues: []string{"last_name", "specialty"}},
+		store:      store,
+		columnsMap: map[string]int{"last_name": 0, "specialty": 1},
+	}
+	key := "1234567890"
+	row, err := tbl.Lookup(&key)
+	if err != nil || row == nil {
+		t.Fatalf("expecting a row, got %v, %v", row, err)
+	}
+	v, err := tbl.LookupValue(row, "specialty")
+	if err != nil || v != "Family Medicine" {
+		t.Errorf("expecting Family Medicine, got %v, %v", v, err)
+	}
+	if _, err = tbl.LookupValue(row, "first_name"); err == nil {
+		t.Errorf("expecting error for unknown column")
+	}
+	key = "0000000000"
+	row, err = tbl.Lookup(&key)
+	if err != nil || row != nil {
+		t.Errorf("expecting no row, got %v, %v", row, err)
+	}
+	if _, err = tbl.Lookup(nil); err == nil {
+		t.Errorf("expecting error for null key")
+	}
+	if tbl.Size() != 1 {
+		t.Errorf("expecting size 1, got %d", tbl.Size())
+	}
+}
+
+func TestLookupTableKvRelease(t *testing.T) {
+	builder, err := newKvStoreBuilder(t.TempDir(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer builder.Remove()
+	store, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two lookup tables sharing the store
+	store.storeKey = "npi|npi_registry.csv"
+	store.refCount = 2
+	kvLookupStores.Lock()
+	kvLookupStores.stores[store.storeKey] = store
+	kvLookupStores.Unlock()
+	tbl1 := &LookupTableKv{spec: &LookupSpec{Key: "npi"}, store: store}
+	tbl2 := &LookupTableKv{spec: &LookupSpec{Key: "npi"}, store: store}
+
+	tbl1.Release()
+	tbl1.Release()
+	kvLookupStores.Lock()
+	_, ok := kvLookupStores.stores[store.storeKey]
+	kvLookupStores.Unlock()
+	if !ok {
+		t.Fatal("expecting the store to be kept while used by a lookup table")
+	}
+	if _, err = os.Stat(store.fileHd.Name()); err != nil {
+		t.Fatalf("expecting the store file to exist: %v", err)
+	}
+
+	tbl2.Release()
+	kvLookupStores.Lock()
+	_, ok = kvLookupStores.stores[store.storeKey]
+	kvLookupStores.Unlock()
+	if ok {
+		t.Error("expecting the store to be removed from the registry")
+	}
+	if _, err = os.Stat(store.fileHd.Name()); !os.IsNotExist(err) {
+		t.Errorf("expecting the store file to be removed, got %v", err)
+	}
+}
@@ -26,6 +26,8 @@ type LookupTable interface {
 	IsEmptyTable() bool
 	// Return the number of rows in the lookup table
 	Size() int64
+	// Release the resources held by the lookup table, it must not be used afterward
+	Release()
 }
 
 func NewLookupTableManager(spec []*LookupSpec, envSettings map[string]any, isVerbose bool) *LookupTableManager {
@@ -56,9 +58,24 @@ func (mgr *LookupTableManager) PrepareLookupTables(dbpool *pgxpool.Pool) error {
 			}
 			mgr.LookupTableMap[lookupTableConfig.Key] = tbl
 
+		case "kv_lookup", "s3_parquet_lookup":
+			tbl, err := NewLookupTableKv(dbpool, lookupTableConfig, mgr.envSettings, mgr.isVerbose)
+			if err != nil {
+				return fmt.Errorf("while calling NewLookupTableKv: %v", err)
+			}
+			mgr.LookupTableMap[lookupTableConfig.Key] = tbl
+
 		default:
 			return fmt.Errorf("error:unknown lookup table type: %s", lookupTableConfig.Type)
 		}
 	}
 	return nil
 }
+
+// ReleaseLookupTables releases the resources held by the lookup tables, called once the
+// compute pipes are done with them
+func (mgr *LookupTableManager) ReleaseLookupTables() {
+	for _, tbl := range mgr.LookupTableMap {
+		tbl.Release()
+	}
+}
@@ -0,0 +1,153 @@
+package compute_pipes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet/file"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+)
+
+// Reading lookup tables from parquet files.
+// Only the lookup key and lookup values columns are read from the file.
+// The rdf type of the columns are taken from the parquet schema unless
+// specified in the lookup spec columns.
+
+func readParquetLookup(spec *LookupSpec, localFileName string, fnc func(key string, values *[]any) error) (int64, error) {
+	fileHd, err := os.Open(localFileName)
+	if err != nil {
+		return 0, fmt.Errorf("while opening temp file '%s' (readParquetLookup): %v", localFileName, err)
+	}
+	defer fileHd.Close()
+
+	pqFileReader, err := file.NewParquetReader(fileHd)
+	if err != nil {
+		return 0, fmt.Errorf("while opening the parquet file reader (readParquetLookup): %v", err)
+	}
+	defer pqFileReader.Close()
+
+	reader, err := pqarrow.NewFileReader(pqFileReader, pqarrow.ArrowReadProperties{BatchSize: 1024}, memory.NewGoAllocator())
+	if err != nil {
+		return 0, fmt.Errorf("while opening the pqarrow file reader (readParquetLookup): %v", err)
+	}
+	schema, err := reader.Schema()
+	if err != nil || schema == nil {
+		return 0, fmt.Errorf("while getting the arrow schema (readParquetLookup): %v", err)
+	}
+	schemaInfo := NewParquetSchemaInfo(schema)
+
+	// Make a lookup of the current column spec
+	overrides := make(map[string]*TableColumnSpec)
+	for i := range spec.Columns {
+		overrides[spec.Columns[i].Name] = &spec.Columns[i]
+	}
+	// Set the column spec from the parquet schema
+	columns := make([]TableColumnSpec, 0, len(schemaInfo.Fields))
+	for _, fi := range schemaInfo.Fields {
+		column := TableColumnSpec{Name: fi.Name, RdfType: rdfTypeOfParquetField(fi)}
+		if override := overrides[fi.Name]; override != nil {
+			column.RdfType = override.RdfType
+			column.IsArray = override.IsArray
+		}
+		columns = append(columns, column)
+	}
+	spec.Columns = columns
+
+	// Make the list of columns to read: the key columns followed by the value columns
+	columnIndices := make([]int, 0, len(spec.LookupKey)+len(spec.LookupValues))
+	for _, name := range spec.LookupKey {
+		idx := schema.FieldIndices(name)
+		if len(idx) == 0 {
+			return 0, fmt.Errorf("error: key column '%s' is not in the parquet lookup table %s", name, spec.Key)
+		}
+		columnIndices = append(columnIndices, idx[0])
+	}
+	for _, name := range spec.LookupValues {
+		idx := schema.FieldIndices(name)
+		if len(idx) == 0 {
+			return 0, fmt.Errorf("error: lookup value column '%s' is not in the parquet lookup table %s", name, spec.Key)
+		}
+		columnIndices = append(columnIndices, idx[0])
+	}
+	recordReader, err := reader.GetRecordReader(context.TODO(), columnIndices, nil)
+	if err != nil {
+		return 0, fmt.Errorf("while creating parquet record reader (readParquetLookup): %v", err)
+	}
+	defer recordReader.Release()
+
+	nbrKeys := len(spec.LookupKey)
+	var inputRowCount int64
+	keys := make([]string, nbrKeys)
+	for recordReader.Next() {
+		record := recordReader.Record()
+		cols := record.Columns()
+		for irow := range int(record.NumRows()) {
+			// If a key component is null, the corresponding key component will be the empty string
+			for i := range nbrKeys {
+				keys[i] = ""
+				if cols[i].IsValid(irow) {
+					v, err := ConvertWithSchemaV1(irow, cols[i], false, schemaInfo.Fields[columnIndices[i]], nil)
+					if err != nil {
+						return 0, fmt.Errorf("while reading key column '%s' of parquet lookup table %s: %v",
+							spec.LookupKey[i], spec.Key, err)
+					}
+					if v != nil {
+						keys[i] = v.(string)
+					}
+				}
+			}
+			lookupKey := strings.Join(keys, "")
+
+			// the associated values
+			lookupValues := make([]any, len(spec.LookupValues))
+			for i := range spec.LookupValues {
+				col := cols[nbrKeys+i]
+				if !col.IsValid(irow) {
+					continue
+				}
+				icol := columnIndices[nbrKeys+i]
+				v, err := ConvertWithSchemaV1(irow, col, false, schemaInfo.Fields[icol], nil)
+				if err != nil {
+					return 0, fmt.Errorf("while reading value column '%s' of parquet lookup table %s: %v",
+						spec.LookupValues[i], spec.Key, err)
+				}
+				cspec := spec.Columns[icol]
+				lookupValues[i], err = CastToRdfType(v, cspec.RdfType, &cspec.IsArray)
+				if err != nil {
+					return 0, fmt.Errorf("while loading parquet lookup table, error in casting to rdf type: %v", err)
+				}
+			}
+			if err = fnc(lookupKey, &lookupValues); err != nil {
+				return 0, err
+			}
+			inputRowCount += 1
+		}
+	}
+	if err = recordReader.Err(); err != nil && err != io.EOF {
+		return 0, fmt.Errorf("error while reading parquet lookup table: %v", err)
+	}
+	return inputRowCount, nil
+}
+
+// rdfTypeOfParquetField returns the rdf type corresponding to the parquet field type
+func rdfTypeOfParquetField(fi *FieldInfo) string {
+	switch fi.Type {
+	case "int8", "int16", "int32", "int64":
+		return "int"
+	case "uint8", "uint16", "uint32", "uint64":
+		return "uint"
+	case "float16", "float32", "float64", "decimal", "decimal256":
+		return "double"
+	case "date32", "date64":
+		return "date"
+	case "timestamp":
+		return "datetime"
+	case "bool":
+		return "bool"
+	}
+	return "text"
+}
@@ -14,6 +14,7 @@ import (
 )
 
 // lookup table from s3 files, loaded into memory
+// Lookup table type s3_csv_lookup reads csv files
 
 // data is the mapping of the looup key -> values
 // columnsMap is the mapping of the return column name -> position in the returned row (values)
@@ -33,6 +34,10 @@ func NewLookupTableS3(_ *pgxpool.Pool, spec *LookupSpec, env map[string]any, isV
 		data:       make(map[string]*[]any),
 		columnsMap: make(map[string]int),
 	}
+	// Keep a mapping of the returned column names to their position in the returned row
+	for i, valueColumn := range tbl.spec.LookupValues {
+		tbl.columnsMap[valueColumn] = i
+	}
 
 	csvSource, err := NewCsvSourceS3(spec.CsvSource, env)
 	if err != nil {
@@ -66,17 +71,20 @@ do_retry:
 			retry++
 			goto do_retry
 		}
-		return nil, fmt.Errorf("failed to download file from s3 for s3_csv_lookup of type cpipes: %v", err)
+		return nil, fmt.Errorf("failed to download file from s3 for %s of type cpipes: %v", spec.Type, err)
 	}
 	defer os.Remove(inFilePath)
 
 	// Read the file and load the lookup table into memory
-	nrows, err := tbl.readCsvLookup(inFilePath)
+	nrows, err := readLookupFile(tbl.spec, inFilePath, func(key string, values *[]any) error {
+		tbl.data[key] = values
+		return nil
+	})
 	if err != nil {
-		err = fmt.Errorf("while loading s3_csv_lookup with key %s: %v", tbl.spec.Key, err)
+		err = fmt.Errorf("while loading %s with key %s: %v", spec.Type, tbl.spec.Key, err)
 		return nil, err
 	}
-	log.Printf("Lookup table of type s3_csv_lookup with key %s is loaded with %d rows", tbl.spec.Key, nrows)
+	log.Printf("Lookup table of type %s with key %s is loaded with %d rows", spec.Type, tbl.spec.Key, nrows)
 	return tbl, nil
 }
 
@@ -116,7 +124,23 @@ func (tbl *LookupTableS3) Size() int64 {
 	return int64(len(tbl.data))
 }
 
-func (tbl *LookupTableS3) readCsvLookup(localFileName string) (int64, error) {
+func (tbl *LookupTableS3) Release() {
+	tbl.data = nil
+}
+
+// readLookupFile reads the local lookup file according to the format of the csv_source
+// and calls fnc with the lookup key and values of each row.
+// Returns the number of rows read.
+func readLookupFile(spec *LookupSpec, localFileName string, fnc func(key string, values *[]any) error) (int64, error) {
+	switch spec.CsvSource.Format {
+	case "parquet":
+		return readParquetLookup(spec, localFileName, fnc)
+	default:
+		return readCsvLookup(spec, localFileName, fnc)
+	}
+}
+
+func readCsvLookup(spec *LookupSpec, localFileName string, fnc func(key string, values *[]any) error) (int64, error) {
 	var fileHd *os.File
 	var csvReader *csv.Reader
 	var err error
@@ -128,12 +152,7 @@ func (tbl *LookupTableS3) readCsvLookup(localFileName string) (int64, error) {
 		fileHd.Close()
 	}()
 
-	// Keep a mapping of the returned column names to their position in the returned row
-	for i, valueColumn := range tbl.spec.LookupValues {
-		tbl.columnsMap[valueColumn] = i
-	}
-
-	source := tbl.spec.CsvSource
+	source := spec.CsvSource
 	sepFlag := ','
 	if source.Delimiter != 0 {
 		sepFlag = source.Delimiter
@@ -160,8 +179,8 @@ func (tbl *LookupTableS3) readCsvLookup(localFileName string) (int64, error) {
 	if source.Format == "csv" {
 		// Make a lookup of the current column spec
 		overrides := make(map[string]*TableColumnSpec)
-		for i := range tbl.spec.Columns {
-			tblSpec := &tbl.spec.Columns[i]
+		for i := range spec.Columns {
+			tblSpec := &spec.Columns[i]
 			overrides[tblSpec.Name] = tblSpec
 		}
 		// get the header row (first row)
@@ -189,42 +208,42 @@ func (tbl *LookupTableS3) readCsvLookup(localFileName string) (int64, error) {
 			})
 		}
 		// set the column spec
-		tbl.spec.Columns = columns
+		spec.Columns = columns
 	}
 
 	// keep track of the column name and their pos in the returned csv row
 	csvColumnsPos := make(map[string]int)
-	for i := range tbl.spec.Columns {
-		csvColumnsPos[tbl.spec.Columns[i].Name] = i
+	for i := range spec.Columns {
+		csvColumnsPos[spec.Columns[i].Name] = i
 	}
 
 	// Read the file
 	var inputRowCount int64
 	var inRow []string
-	keys := make([]string, len(tbl.spec.LookupKey))
+	keys := make([]string, len(spec.LookupKey))
 	for {
 		// read and put the lookup rows into tbl method receiver
 		err = nil
 		inRow, err = csvReader.Read()
 		if err == nil {
 			// If a key component is null, the corresponding key component will be the empty string
-			for i, key := range tbl.spec.LookupKey {
+			for i, key := range spec.LookupKey {
 				pos, ok := csvColumnsPos[key]
 				if !ok {
-					return 0, fmt.Errorf("error: key column '%s' is not in the csv lookup table %s", key, tbl.spec.Key)
+					return 0, fmt.Errorf("error: key column '%s' is not in the csv lookup table %s", key, spec.Key)
 				}
 				keys[i] = inRow[pos]
 			}
 			lookupKey := strings.Join(keys, "")
 
 			// the associated values
-			lookupValues := make([]any, len(tbl.spec.LookupValues))
-			for i, name := range tbl.spec.LookupValues {
+			lookupValues := make([]any, len(spec.LookupValues))
+			for i, name := range spec.LookupValues {
 				pos, ok := csvColumnsPos[name]
 				if !ok {
-					return 0, fmt.Errorf("error: lookup value column '%s' is not in the csv lookup table %s", name, tbl.spec.Key)
+					return 0, fmt.Errorf("error: lookup value column '%s' is not in the csv lookup table %s", name, spec.Key)
 				}
-				cspec := tbl.spec.Columns[csvColumnsPos[name]]
+				cspec := spec.Columns[csvColumnsPos[name]]
 				lookupValues[i], err = CastToRdfType(inRow[pos], cspec.RdfType, &cspec.IsArray)
 				if err != nil {
 					return 0, fmt.Errorf("while loading csv lookup table, error in casting to rdf type: %v", err)
@@ -232,7 +251,9 @@ func (tbl *LookupTableS3) readCsvLookup(localFileName string) (int64, error) {
 			}
 
 			// save the lookup row
-			tbl.data[lookupKey] = &lookupValues
+			if err = fnc(lookupKey, &lookupValues); err != nil {
+				return 0, err
+			}
 		}
 
 		switch {
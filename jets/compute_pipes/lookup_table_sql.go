@@ -180,3 +180,7 @@ func (tbl *LookupTableSql) IsEmptyTable() bool {
 func (tbl *LookupTableSql) Size() int64 {
 	return int64(len(tbl.data))
 }
+
+func (tbl *LookupTableSql) Release() {
+	tbl.data = nil
+}
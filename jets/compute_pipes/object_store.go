@@ -0,0 +1,107 @@
+package compute_pipes
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+
+	"github.com/artisoft-io/jetstore/jets/awsi"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// ObjectStore is the abstraction used by compute pipes to read and write
+// the files of the jetstore bucket (input, stage and output prefixes).
+// The default implementation is backed by s3, a local directory
+// implementation is selected by setting env var JETS_LOCAL_OBJECT_STORE
+// to the root directory of the store. In that case each bucket is a
+// sub directory of the root directory and each key is a file path relative
+// to the bucket directory, e.g.:
+//
+//	<root>/<bucket>/jetstore/input/client=ACME/object_type=Claim/...
+//
+// For all functions, when bucket is empty the jetstore default bucket is used.
+type ObjectStore interface {
+	// ListObjects returns the objects whose key starts with prefix,
+	// skipping the directories, sorted by key.
+	ListObjects(bucket, prefix string) ([]*awsi.S3Object, error)
+	// GetObject writes the object (or the byteRange of the object when not nil)
+	// into w. The byteRange is using the http range format: "bytes=start-end",
+	// end being inclusive. Returns the number of bytes written.
+	GetObject(bucket, key string, byteRange *string, w io.WriterAt) (int64, error)
+	// PutObject writes the content of reader (until EOF) to the object.
+	PutObject(bucket, key string, reader io.Reader) error
+	// MultiPartCopy concatenates all the objects whose key starts with srcPrefix
+	// into destKey. The source objects are concatenated in key order.
+	MultiPartCopy(ctx context.Context, maxPoolSize int, srcBucket, srcPrefix, destBucket, destKey string, debug bool) error
+	// DeleteObject removes the object, deleting a key that does not exist is not an error.
+	DeleteObject(bucket, key string) error
+}
+
+// NewObjectStore returns the ObjectStore selected by env var JETS_LOCAL_OBJECT_STORE,
+// the local directory store when set, the s3 store otherwise.
+func NewObjectStore() (ObjectStore, error) {
+	rootDir := os.Getenv("JETS_LOCAL_OBJECT_STORE")
+	if len(rootDir) > 0 {
+		log.Printf("Using local object store with root directory %s", rootDir)
+		return NewLocalObjectStore(rootDir)
+	}
+	return NewS3ObjectStore()
+}
+
+// S3ObjectStore is the ObjectStore backed by s3, it keeps a shared client,
+// downloader and uploaders for all the operations.
+// uploader uses the default part size and is used for readers of known size
+// (local files), streamUploader uses large parts to accommodate large streamed objects.
+type S3ObjectStore struct {
+	s3Client       *s3.Client
+	downloader     *manager.Downloader
+	uploader       *manager.Uploader
+	streamUploader *manager.Uploader
+}
+
+func NewS3ObjectStore() (*S3ObjectStore, error) {
+	s3Client, err := awsi.NewS3Client()
+	if err != nil {
+		return nil, err
+	}
+	return &S3ObjectStore{
+		s3Client:       s3Client,
+		downloader:     manager.NewDownloader(s3Client),
+		uploader:       manager.NewUploader(s3Client),
+		streamUploader: awsi.NewUploaderWithClient(s3Client),
+	}, nil
+}
+
+func (store *S3ObjectStore) ListObjects(bucket, prefix string) ([]*awsi.S3Object, error) {
+	return awsi.ListS3ObjectsV2(store.s3Client, bucket, &prefix)
+}
+
+func (store *S3ObjectStore) GetObject(bucket, key string, byteRange *string, w io.WriterAt) (int64, error) {
+	if bucket == "" {
+		bucket = bucketName
+	}
+	return awsi.DownloadFromS3v2(store.downloader, bucket, key, byteRange, w)
+}
+
+func (store *S3ObjectStore) PutObject(bucket, key string, reader io.Reader) error {
+	uploader := store.streamUploader
+	if _, ok := reader.(io.Seeker); ok {
+		uploader = store.uploader
+	}
+	return awsi.UploadToS3WithUploader(uploader, bucket, key, reader)
+}
+
+func (store *S3ObjectStore) MultiPartCopy(ctx context.Context, maxPoolSize int,
+	srcBucket, srcPrefix, destBucket, destKey string, debug bool) error {
+	return awsi.MultiPartCopy(ctx, store.s3Client, maxPoolSize, srcBucket, srcPrefix, destBucket, destKey, debug)
+}
+
+func (store *S3ObjectStore) DeleteObject(bucket, key string) error {
+	return awsi.DeleteS3Object(store.s3Client, bucket, key)
+}
+
+// Make sure the implementations satisfy the interface
+var _ ObjectStore = (*S3ObjectStore)(nil)
+var _ ObjectStore = (*LocalObjectStore)(nil)
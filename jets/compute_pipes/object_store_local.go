@@ -0,0 +1,231 @@
+package compute_pipes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/artisoft-io/jetstore/jets/awsi"
+)
+
+// LocalObjectStore is the ObjectStore backed by a local directory.
+// This is used to run cpipes without aws, on a laptop or in CI.
+// Layout of the directory: <rootDir>/<bucket>/<key>
+
+type LocalObjectStore struct {
+	rootDir string
+}
+
+func NewLocalObjectStore(rootDir string) (*LocalObjectStore, error) {
+	rootDir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("while getting absolute path of local object store root %s: %v", rootDir, err)
+	}
+	err = os.MkdirAll(rootDir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("while creating local object store root %s: %v", rootDir, err)
+	}
+	return &LocalObjectStore{rootDir: rootDir}, nil
+}
+
+// bucketDir returns the directory of bucket, using the jetstore default bucket when empty
+func (store *LocalObjectStore) bucketDir(bucket string) string {
+	if bucket == "" {
+		bucket = bucketName
+	}
+	return filepath.Join(store.rootDir, bucket)
+}
+
+// filePath returns the path of the file for key
+func (store *LocalObjectStore) filePath(bucket, key string) (string, error) {
+	bucketDir := store.bucketDir(bucket)
+	p := filepath.Join(bucketDir, filepath.FromSlash(key))
+	if !strings.HasPrefix(p, bucketDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("error: invalid object key '%s' for local object store", key)
+	}
+	return p, nil
+}
+
+func (store *LocalObjectStore) ListObjects(bucket, prefix string) ([]*awsi.S3Object, error) {
+	bucketDir := store.bucketDir(bucket)
+	// The prefix is not necessarily a directory, walk the directory
+	// containing the prefix and keep the keys starting with prefix
+	walkDir := bucketDir
+	if i := strings.LastIndex(prefix, "/"); i > 0 {
+		walkDir = filepath.Join(bucketDir, filepath.FromSlash(prefix[:i]))
+	}
+	keys := make([]*awsi.S3Object, 0)
+	err := filepath.WalkDir(walkDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(bucketDir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		keys = append(keys, &awsi.S3Object{Key: key, Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		err = fmt.Errorf("while listing objects from local bucket '%s': %v", bucketDir, err)
+		log.Println(err)
+		return nil, err
+	}
+	slices.SortFunc(keys, func(lhs, rhs *awsi.S3Object) int {
+		return strings.Compare(lhs.Key, rhs.Key)
+	})
+	return keys, nil
+}
+
+func (store *LocalObjectStore) GetObject(bucket, key string, byteRange *string, w io.WriterAt) (int64, error) {
+	p, err := store.filePath(bucket, key)
+	if err != nil {
+		return 0, err
+	}
+	fileHd, err := os.Open(p)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open local object '%s': %v", p, err)
+	}
+	defer fileHd.Close()
+	info, err := fileHd.Stat()
+	if err != nil {
+		return 0, err
+	}
+	start, end := int64(0), info.Size()-1
+	if byteRange != nil {
+		start, end, err = parseByteRange(*byteRange, info.Size())
+		if err != nil {
+			return 0, fmt.Errorf("while getting local object '%s': %v", p, err)
+		}
+	}
+	if end < start {
+		return 0, nil
+	}
+	n, err := io.Copy(io.NewOffsetWriter(w, 0), io.NewSectionReader(fileHd, start, end-start+1))
+	if err != nil {
+		return n, fmt.Errorf("failed to read local object '%s': %v", p, err)
+	}
+	return n, nil
+}
+
+// parseByteRange parses the http range "bytes=start-end", the returned end is
+// capped to the last byte of the object
+func parseByteRange(byteRange string, size int64) (start, end int64, err error) {
+	_, err = fmt.Sscanf(byteRange, "bytes=%d-%d", &start, &end)
+	if err != nil || start < 0 || end < start {
+		return 0, 0, fmt.Errorf("error: invalid byte range '%s'", byteRange)
+	}
+	end = min(end, size-1)
+	return start, end, nil
+}
+
+func (store *LocalObjectStore) PutObject(bucket, key string, reader io.Reader) error {
+	p, err := store.filePath(bucket, key)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(p), 0755)
+	if err != nil {
+		return fmt.Errorf("while creating directory for local object '%s': %v", p, err)
+	}
+	// Write to a temp file and rename it so readers never see a partial object
+	fileHd, err := os.CreateTemp(filepath.Dir(p), ".jetstore_put")
+	if err != nil {
+		return fmt.Errorf("while creating temp file for local object '%s': %v", p, err)
+	}
+	tempPath := fileHd.Name()
+	_, err = io.Copy(fileHd, reader)
+	if err2 := fileHd.Close(); err == nil {
+		err = err2
+	}
+	if err == nil {
+		err = os.Rename(tempPath, p)
+	}
+	if err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("failed to write local object '%s': %v", p, err)
+	}
+	return nil
+}
+
+func (store *LocalObjectStore) MultiPartCopy(ctx context.Context, _ int,
+	srcBucket, srcPrefix, destBucket, destKey string, debug bool) error {
+	objects, err := store.ListObjects(srcBucket, srcPrefix)
+	if err != nil {
+		return fmt.Errorf("while getting the source files: %v", err)
+	}
+	if len(objects) == 0 {
+		log.Printf(
+			"warning: in MultiPartCopy, source file key %s does not exist, skipping file copy", path.Join(srcBucket, srcPrefix))
+		return nil
+	}
+	pin, pout := io.Pipe()
+	go func() {
+		var err error
+		for _, obj := range objects {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+				break
+			}
+			if debug {
+				log.Printf("MultiPartCopy: Copy file %s of size %d\n", obj.Key, obj.Size)
+			}
+			var p string
+			p, err = store.filePath(srcBucket, obj.Key)
+			if err != nil {
+				break
+			}
+			var fileHd *os.File
+			fileHd, err = os.Open(p)
+			if err != nil {
+				break
+			}
+			_, err = io.Copy(pout, fileHd)
+			fileHd.Close()
+			if err != nil {
+				break
+			}
+		}
+		pout.CloseWithError(err)
+	}()
+	err = store.PutObject(destBucket, destKey, pin)
+	pin.Close()
+	if err != nil {
+		return fmt.Errorf("while multipart copy file: %v", err)
+	}
+	return nil
+}
+
+func (store *LocalObjectStore) DeleteObject(bucket, key string) error {
+	p, err := store.filePath(bucket, key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(p)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete local object '%s': %v", p, err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package compute_pipes
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+// This file contains test cases for LocalObjectStore
+
+func TestLocalObjectStore1(t *testing.T) {
+	store, err := NewLocalObjectStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix := "jetstore/stage/process_name=P1/session_id=123/step_id=reducing01"
+	parts := map[string]string{
+		prefix + "/jets_partition=1p/part_002":                    "c,d\n",
+		prefix + "/jets_partition=1p/part_001":                    "a,b\n",
+		prefix + "/jets_partition=2p/part_001":                    "e,f\n",
+		"jetstore/stage/process_name=P1/session_id=1234/part_001": "x,y\n",
+	}
+	for k, v := range parts {
+		err = store.PutObject("bucket1", k, strings.NewReader(v))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Prefix is not a directory, must not get the objects of session 1234
+	objects, err := store.ListObjects("bucket1", "jetstore/stage/process_name=P1/session_id=123/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 3 {
+		t.Fatalf("expecting 3 objects, got %d", len(objects))
+	}
+	if objects[0].Key != prefix+"/jets_partition=1p/part_001" || objects[0].Size != 4 {
+		t.Errorf("unexpected first object: %v", *objects[0])
+	}
+	// Listing a prefix that does not exist is not an error
+	objects, err = store.ListObjects("bucket1", "jetstore/output/")
+	if err != nil || len(objects) != 0 {
+		t.Errorf("expecting no objects and no error, got %d objects, err: %v", len(objects), err)
+	}
+
+	// Multipart copy concatenates the parts in key order
+	err = store.MultiPartCopy(context.TODO(), 0, "bucket1", prefix, "bucket2", "jetstore/output/out.csv", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileHd, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fileHd.Close()
+	n, err := store.GetObject("bucket2", "jetstore/output/out.csv", nil, fileHd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, _ := os.ReadFile(fileHd.Name())
+	if n != 12 || string(buf) != "a,b\nc,d\ne,f\n" {
+		t.Errorf("unexpected merged content (%d bytes): %q", n, string(buf))
+	}
+
+	// Delete, deleting twice is not an error
+	for range 2 {
+		err = store.DeleteObject("bucket2", "jetstore/output/out.csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	objects, _ = store.ListObjects("bucket2", "jetstore/output/")
+	if len(objects) != 0 {
+		t.Errorf("expecting object to be deleted")
+	}
+}
+
+func TestLocalObjectStoreByteRange(t *testing.T) {
+	store, err := NewLocalObjectStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.PutObject("bucket1", "input/file.csv", strings.NewReader("0123456789"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		byteRange string
+		expected  string
+	}{
+		{"bytes=0-3", "0123"},
+		{"bytes=4-7", "4567"},
+		{"bytes=8-50000", "89"},
+	}
+	for _, c := range cases {
+		fileHd, err := os.CreateTemp(t.TempDir(), "part")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = store.GetObject("bucket1", "input/file.csv", &c.byteRange, fileHd)
+		fileHd.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf, _ := os.ReadFile(fileHd.Name())
+		if string(buf) != c.expected {
+			t.Errorf("range %s: expecting %q, got %q", c.byteRange, c.expected, string(buf))
+		}
+	}
+	// Invalid range and invalid keys
+	r := "bytes=5-2"
+	_, err = store.GetObject("bucket1", "input/file.csv", &r, nil)
+	if err == nil {
+		t.Errorf("expecting error for invalid byte range")
+	}
+	err = store.PutObject("bucket1", "../../escape.csv", strings.NewReader("x"))
+	if err == nil {
+		t.Errorf("expecting error for key outside of the bucket")
+	}
+}
@@ -0,0 +1,870 @@
+package compute_pipes
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+// Reader for orc files.
+// Supported compression: none, zlib, snappy, lz4 and zstd, lzo is not supported.
+// The top-level type must be a struct, the fields of the struct are the columns.
+// Supported types: boolean, tinyint, smallint, int, bigint, float, double, string,
+// varchar, char, binary, date, timestamp, timestamp with local time zone, decimal,
+// and the nested types struct, list and map (read as json).
+// Timestamps are read as UTC.
+
+// orc compression kinds
+const (
+	orcCompressionNone = iota
+	orcCompressionZlib
+	orcCompressionSnappy
+	orcCompressionLzo
+	orcCompressionLz4
+	orcCompressionZstd
+)
+
+// orcDefaultBlockSize is the compression block size when not in the postscript
+const orcDefaultBlockSize = 256 * 1024
+
+// orc type kinds
+const (
+	orcBoolean = iota
+	orcByte
+	orcShort
+	orcInt
+	orcLong
+	orcFloat
+	orcDouble
+	orcString
+	orcBinary
+	orcTimestamp
+	orcList
+	orcMap
+	orcStruct
+	orcUnion
+	orcDecimal
+	orcDate
+	orcVarchar
+	orcChar
+	orcTimestampInstant
+)
+
+// orc stream kinds
+const (
+	orcStreamPresent        = 0
+	orcStreamData           = 1
+	orcStreamLength         = 2
+	orcStreamDictionaryData = 3
+	orcStreamSecondary      = 5
+)
+
+// orc column encoding kinds
+const (
+	orcEncodingDirect       = 0
+	orcEncodingDictionary   = 1
+	orcEncodingDirectV2     = 2
+	orcEncodingDictionaryV2 = 3
+)
+
+// Seconds between unix epoch and orc epoch (2015-01-01 00:00:00 UTC)
+const orcEpochSeconds = 1420070400
+
+// protoMessage is a decoded protobuf message: field number -> values,
+// values are uint64 for varint and fixed types and []byte for length delimited.
+type protoMessage map[int][]any
+
+func parseProtoMessage(buf []byte) (protoMessage, error) {
+	msg := make(protoMessage)
+	pos := 0
+	for pos < len(buf) {
+		key, n := binary.Uvarint(buf[pos:])
+		if n <= 0 {
+			return nil, fmt.Errorf("error: invalid protobuf message")
+		}
+		pos += n
+		field := int(key >> 3)
+		switch key & 0x07 {
+		case 0:
+			v, n := binary.Uvarint(buf[pos:])
+			if n <= 0 {
+				return nil, fmt.Errorf("error: invalid protobuf varint")
+			}
+			pos += n
+			msg[field] = append(msg[field], v)
+		case 1:
+			if pos+8 > len(buf) {
+				return nil, fmt.Errorf("error: truncated protobuf message")
+			}
+			msg[field] = append(msg[field], binary.LittleEndian.Uint64(buf[pos:]))
+			pos += 8
+		case 2:
+			l, n := binary.Uvarint(buf[pos:])
+			if n <= 0 || pos+n+int(l) > len(buf) {
+				return nil, fmt.Errorf("error: truncated protobuf message")
+			}
+			pos += n
+			msg[field] = append(msg[field], buf[pos:pos+int(l)])
+			pos += int(l)
+		case 5:
+			if pos+4 > len(buf) {
+				return nil, fmt.Errorf("error: truncated protobuf message")
+			}
+			msg[field] = append(msg[field], uint64(binary.LittleEndian.Uint32(buf[pos:])))
+			pos += 4
+		default:
+			return nil, fmt.Errorf("error: unsupported protobuf wire type %d", key&0x07)
+		}
+	}
+	return msg, nil
+}
+
+func (m protoMessage) uint(field int) uint64 {
+	values := m[field]
+	if len(values) == 0 {
+		return 0
+	}
+	v, _ := values[len(values)-1].(uint64)
+	return v
+}
+
+func (m protoMessage) bytes(field int) []byte {
+	values := m[field]
+	if len(values) == 0 {
+		return nil
+	}
+	v, _ := values[len(values)-1].([]byte)
+	return v
+}
+
+// uints returns the repeated uint field, packed or not
+func (m protoMessage) uints(field int) []uint64 {
+	var result []uint64
+	for _, v := range m[field] {
+		switch vv := v.(type) {
+		case uint64:
+			result = append(result, vv)
+		case []byte:
+			for len(vv) > 0 {
+				u, n := binary.Uvarint(vv)
+				if n <= 0 {
+					break
+				}
+				result = append(result, u)
+				vv = vv[n:]
+			}
+		}
+	}
+	return result
+}
+
+// strings returns the repeated string field
+func (m protoMessage) strings(field int) []string {
+	result := make([]string, 0, len(m[field]))
+	for _, v := range m[field] {
+		b, _ := v.([]byte)
+		result = append(result, string(b))
+	}
+	return result
+}
+
+func (m protoMessage) messages(field int) ([]protoMessage, error) {
+	result := make([]protoMessage, 0, len(m[field]))
+	for _, v := range m[field] {
+		b, _ := v.([]byte)
+		msg, err := parseProtoMessage(b)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, msg)
+	}
+	return result, nil
+}
+
+type orcType struct {
+	kind       int
+	subtypes   []int
+	fieldNames []string
+	precision  int32
+	scale      int32
+}
+
+type orcStripeInfo struct {
+	offset       int64
+	indexLength  int64
+	dataLength   int64
+	footerLength int64
+	numberOfRows int64
+}
+
+// orcFileReader reads an orc file
+type orcFileReader struct {
+	fileReader  io.ReaderAt
+	compression uint64
+	blockSize   int
+	zstdDecoder *zstd.Decoder
+	types       []orcType
+	stripes     []orcStripeInfo
+	schemaInfo  *ParquetSchemaInfo
+}
+
+func newOrcFileReader(fileReader io.ReaderAt, fileSize int64) (*orcFileReader, error) {
+	if fileSize < 4 {
+		return nil, fmt.Errorf("error: not an orc file, file too small")
+	}
+	// Read the postscript
+	tailSize := min(fileSize, 16*1024)
+	tail := make([]byte, tailSize)
+	if _, err := fileReader.ReadAt(tail, fileSize-tailSize); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("while reading orc file tail: %v", err)
+	}
+	psLen := int64(tail[tailSize-1])
+	if psLen+1 > tailSize {
+		return nil, fmt.Errorf("error: not an orc file, invalid postscript length")
+	}
+	postscript, err := parseProtoMessage(tail[tailSize-1-psLen : tailSize-1])
+	if err != nil {
+		return nil, fmt.Errorf("while parsing orc postscript: %v", err)
+	}
+	if string(postscript.bytes(8000)) != "ORC" {
+		return nil, fmt.Errorf("error: not an orc file, invalid postscript magic")
+	}
+	r := &orcFileReader{
+		fileReader:  fileReader,
+		compression: postscript.uint(2),
+		blockSize:   int(postscript.uint(3)),
+	}
+	switch r.compression {
+	case orcCompressionNone, orcCompressionZlib, orcCompressionSnappy, orcCompressionLz4:
+	case orcCompressionZstd:
+		r.zstdDecoder, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("while creating the orc zstd decoder: %v", err)
+		}
+	case orcCompressionLzo:
+		return nil, fmt.Errorf("error: unsupported orc compression LZO, supported compression: NONE, ZLIB, SNAPPY, LZ4, ZSTD")
+	default:
+		return nil, fmt.Errorf("error: unknown orc compression kind %d", r.compression)
+	}
+	if r.blockSize == 0 {
+		r.blockSize = orcDefaultBlockSize
+	}
+	// Read the footer
+	footerLength := int64(postscript.uint(1))
+	footerStart := fileSize - 1 - psLen - footerLength
+	if footerStart < 0 {
+		return nil, fmt.Errorf("error: invalid orc footer length")
+	}
+	footerBuf, err := r.readSection(footerStart, footerLength)
+	if err != nil {
+		return nil, fmt.Errorf("while reading orc footer: %v", err)
+	}
+	footer, err := parseProtoMessage(footerBuf)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing orc footer: %v", err)
+	}
+	stripes, err := footer.messages(3)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing orc stripes info: %v", err)
+	}
+	for _, s := range stripes {
+		r.stripes = append(r.stripes, orcStripeInfo{
+			offset:       int64(s.uint(1)),
+			indexLength:  int64(s.uint(2)),
+			dataLength:   int64(s.uint(3)),
+			footerLength: int64(s.uint(4)),
+			numberOfRows: int64(s.uint(5)),
+		})
+	}
+	types, err := footer.messages(4)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing orc types: %v", err)
+	}
+	for _, t := range types {
+		ot := orcType{
+			kind:      int(t.uint(1)),
+			precision: int32(t.uint(5)),
+			scale:     int32(t.uint(6)),
+		}
+		for _, st := range t.uints(2) {
+			ot.subtypes = append(ot.subtypes, int(st))
+		}
+		ot.fieldNames = t.strings(3)
+		r.types = append(r.types, ot)
+	}
+	if len(r.types) == 0 || r.types[0].kind != orcStruct {
+		return nil, fmt.Errorf("error: the orc top-level type must be a struct")
+	}
+	root := r.types[0]
+	r.schemaInfo = &ParquetSchemaInfo{Fields: make([]*FieldInfo, 0, len(root.subtypes))}
+	for i, st := range root.subtypes {
+		if st >= len(r.types) || i >= len(root.fieldNames) {
+			return nil, fmt.Errorf("error: invalid orc struct type")
+		}
+		r.schemaInfo.Fields = append(r.schemaInfo.Fields, r.types[st].fieldInfo(root.fieldNames[i]))
+	}
+	return r, nil
+}
+
+// fieldInfo returns the schema field info of the orc type, using the arrow type names
+func (t orcType) fieldInfo(name string) *FieldInfo {
+	fi := &FieldInfo{Name: name, Nullable: true}
+	switch t.kind {
+	case orcBoolean:
+		fi.Type = "bool"
+	case orcByte, orcShort, orcInt:
+		fi.Type = "int32"
+	case orcLong:
+		fi.Type = "int64"
+	case orcFloat:
+		fi.Type = "float32"
+	case orcDouble:
+		fi.Type = "float64"
+	case orcBinary:
+		fi.Type = "binary"
+	case orcDate:
+		fi.Type = "date32"
+	case orcTimestamp, orcTimestampInstant:
+		fi.Type = "timestamp"
+	case orcDecimal:
+		fi.Type = "decimal"
+		fi.DecimalPrecision = t.precision
+		fi.DecimalScale = t.scale
+	default:
+		// string, varchar, char and nested types (as json)
+		fi.Type = "utf8"
+	}
+	return fi
+}
+
+func (r *orcFileReader) SchemaInfo() *ParquetSchemaInfo {
+	return r.schemaInfo
+}
+
+// readSection reads and decompresses a section of the file
+func (r *orcFileReader) readSection(offset, length int64) ([]byte, error) {
+	buf := make([]byte, length)
+	if _, err := r.fileReader.ReadAt(buf, offset); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return r.decompress(buf)
+}
+
+// decompress the chunks of buf, each chunk has a 3 bytes header
+func (r *orcFileReader) decompress(buf []byte) ([]byte, error) {
+	if r.compression == orcCompressionNone {
+		return buf, nil
+	}
+	var out bytes.Buffer
+	for len(buf) > 0 {
+		if len(buf) < 3 {
+			return nil, fmt.Errorf("error: invalid orc compression chunk header")
+		}
+		header := int(buf[0]) | int(buf[1])<<8 | int(buf[2])<<16
+		isOriginal := header&1 == 1
+		chunkLength := header >> 1
+		buf = buf[3:]
+		if chunkLength > len(buf) {
+			return nil, fmt.Errorf("error: invalid orc compression chunk length")
+		}
+		chunk := buf[:chunkLength]
+		buf = buf[chunkLength:]
+		if isOriginal {
+			out.Write(chunk)
+			continue
+		}
+		switch r.compression {
+		case orcCompressionZlib:
+			data, err := io.ReadAll(flate.NewReader(bytes.NewReader(chunk)))
+			if err != nil {
+				return nil, fmt.Errorf("while inflating orc chunk: %v", err)
+			}
+			out.Write(data)
+		case orcCompressionSnappy:
+			data, err := snappy.Decode(nil, chunk)
+			if err != nil {
+				return nil, fmt.Errorf("while decompressing orc snappy chunk: %v", err)
+			}
+			out.Write(data)
+		case orcCompressionLz4:
+			// lz4 block format, the chunk decompresses to at most the compression block size
+			data := make([]byte, r.blockSize)
+			n, err := lz4.UncompressBlock(chunk, data)
+			if err != nil {
+				return nil, fmt.Errorf("while decompressing orc lz4 chunk: %v", err)
+			}
+			out.Write(data[:n])
+		case orcCompressionZstd:
+			data, err := r.zstdDecoder.DecodeAll(chunk, nil)
+			if err != nil {
+				return nil, fmt.Errorf("while decompressing orc zstd chunk: %v", err)
+			}
+			out.Write(data)
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// orcStripe holds the decompressed streams of a stripe
+type orcStripe struct {
+	streams   map[[2]int][]byte
+	encodings []protoMessage
+}
+
+func (r *orcFileReader) readStripe(info orcStripeInfo) (*orcStripe, error) {
+	footerBuf, err := r.readSection(info.offset+info.indexLength+info.dataLength, info.footerLength)
+	if err != nil {
+		return nil, fmt.Errorf("while reading orc stripe footer: %v", err)
+	}
+	footer, err := parseProtoMessage(footerBuf)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing orc stripe footer: %v", err)
+	}
+	streams, err := footer.messages(1)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing orc streams: %v", err)
+	}
+	stripe := &orcStripe{streams: make(map[[2]int][]byte)}
+	stripe.encodings, err = footer.messages(2)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing orc column encodings: %v", err)
+	}
+	offset := info.offset
+	for _, s := range streams {
+		kind := int(s.uint(1))
+		column := int(s.uint(2))
+		length := int64(s.uint(3))
+		switch kind {
+		case orcStreamPresent, orcStreamData, orcStreamLength, orcStreamDictionaryData, orcStreamSecondary:
+			data, err := r.readSection(offset, length)
+			if err != nil {
+				return nil, fmt.Errorf("while reading orc stream %d of column %d: %v", kind, column, err)
+			}
+			stripe.streams[[2]int{column, kind}] = data
+		}
+		offset += length
+	}
+	return stripe, nil
+}
+
+// orcColumnReader reads the values of a column for n rows (n rows where the parent is present)
+type orcColumnReader interface {
+	next(n int) ([]any, error)
+}
+
+// orcPresent reads the present stream of a column, all values are present when there is no stream
+type orcPresent struct {
+	present *orcBoolReader
+}
+
+// isPresent returns the present flag of the n rows and the count of present rows
+func (p orcPresent) isPresent(n int) ([]bool, int, error) {
+	flags := make([]bool, n)
+	if p.present == nil {
+		for i := range flags {
+			flags[i] = true
+		}
+		return flags, n, nil
+	}
+	count := 0
+	for i := range flags {
+		v, err := p.present.next()
+		if err != nil {
+			return nil, 0, fmt.Errorf("while reading orc present stream: %v", err)
+		}
+		flags[i] = v
+		if v {
+			count++
+		}
+	}
+	return flags, count, nil
+}
+
+type orcValueReader struct {
+	orcPresent
+	readValue func() (any, error)
+}
+
+func (c *orcValueReader) next(n int) ([]any, error) {
+	flags, _, err := c.isPresent(n)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, n)
+	for i := range values {
+		if flags[i] {
+			if values[i], err = c.readValue(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return values, nil
+}
+
+type orcStructReader struct {
+	orcPresent
+	names    []string
+	children []orcColumnReader
+}
+
+func (c *orcStructReader) next(n int) ([]any, error) {
+	flags, count, err := c.isPresent(n)
+	if err != nil {
+		return nil, err
+	}
+	childValues := make([][]any, len(c.children))
+	for i, child := range c.children {
+		if childValues[i], err = child.next(count); err != nil {
+			return nil, err
+		}
+	}
+	values := make([]any, n)
+	ipos := 0
+	for i := range values {
+		if !flags[i] {
+			continue
+		}
+		record := make(map[string]any, len(c.names))
+		for j, name := range c.names {
+			record[name] = childValues[j][ipos]
+		}
+		values[i] = record
+		ipos++
+	}
+	return values, nil
+}
+
+// orcListReader reads list and map columns
+type orcListReader struct {
+	orcPresent
+	lengths *orcIntReader
+	keys    orcColumnReader
+	items   orcColumnReader
+}
+
+func (c *orcListReader) next(n int) ([]any, error) {
+	flags, count, err := c.isPresent(n)
+	if err != nil {
+		return nil, err
+	}
+	lengths := make([]int, count)
+	total := 0
+	for i := range lengths {
+		l, err := c.lengths.next()
+		if err != nil {
+			return nil, fmt.Errorf("while reading orc length stream: %v", err)
+		}
+		lengths[i] = int(l)
+		total += int(l)
+	}
+	var keys []any
+	if c.keys != nil {
+		if keys, err = c.keys.next(total); err != nil {
+			return nil, err
+		}
+	}
+	items, err := c.items.next(total)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, n)
+	ipos, itemPos := 0, 0
+	for i := range values {
+		if !flags[i] {
+			continue
+		}
+		l := lengths[ipos]
+		ipos++
+		if c.keys != nil {
+			m := make(map[string]any, l)
+			for j := itemPos; j < itemPos+l; j++ {
+				m[fmt.Sprintf("%v", keys[j])] = items[j]
+			}
+			values[i] = m
+		} else {
+			values[i] = items[itemPos : itemPos+l]
+		}
+		itemPos += l
+	}
+	return values, nil
+}
+
+// newColumnReader creates the reader of column (type id) for the stripe
+func (r *orcFileReader) newColumnReader(stripe *orcStripe, column int) (orcColumnReader, error) {
+	if column >= len(r.types) {
+		return nil, fmt.Errorf("error: invalid orc column %d", column)
+	}
+	t := r.types[column]
+	stream := func(kind int) *bytes.Reader {
+		return bytes.NewReader(stripe.streams[[2]int{column, kind}])
+	}
+	var present orcPresent
+	if _, ok := stripe.streams[[2]int{column, orcStreamPresent}]; ok {
+		present.present = newOrcBoolReader(stream(orcStreamPresent))
+	}
+	var encoding uint64
+	if column < len(stripe.encodings) {
+		encoding = stripe.encodings[column].uint(1)
+	}
+	isV2 := encoding == orcEncodingDirectV2 || encoding == orcEncodingDictionaryV2
+	reader := &orcValueReader{orcPresent: present}
+	switch t.kind {
+	case orcBoolean:
+		data := newOrcBoolReader(stream(orcStreamData))
+		reader.readValue = func() (any, error) { return data.next() }
+
+	case orcByte:
+		data := &orcByteRleReader{r: stream(orcStreamData)}
+		reader.readValue = func() (any, error) {
+			v, err := data.next()
+			return int32(int8(v)), err
+		}
+
+	case orcShort, orcInt, orcLong, orcDate:
+		data := newOrcIntReader(stream(orcStreamData), true, isV2)
+		reader.readValue = func() (any, error) {
+			v, err := data.next()
+			switch t.kind {
+			case orcLong:
+				return v, err
+			case orcDate:
+				return time.Unix(v*86400, 0).UTC(), err
+			}
+			return int32(v), err
+		}
+
+	case orcFloat:
+		data := stream(orcStreamData)
+		reader.readValue = func() (any, error) {
+			var b [4]byte
+			if _, err := io.ReadFull(data, b[:]); err != nil {
+				return nil, err
+			}
+			return math.Float32frombits(binary.LittleEndian.Uint32(b[:])), nil
+		}
+
+	case orcDouble:
+		data := stream(orcStreamData)
+		reader.readValue = func() (any, error) {
+			var b [8]byte
+			if _, err := io.ReadFull(data, b[:]); err != nil {
+				return nil, err
+			}
+			return math.Float64frombits(binary.LittleEndian.Uint64(b[:])), nil
+		}
+
+	case orcString, orcVarchar, orcChar, orcBinary:
+		isBinary := t.kind == orcBinary
+		toValue := func(b []byte) any {
+			if isBinary {
+				return bytes.Clone(b)
+			}
+			return string(b)
+		}
+		switch encoding {
+		case orcEncodingDictionary, orcEncodingDictionaryV2:
+			// Read the dictionary
+			dictSize := int(stripe.encodings[column].uint(2))
+			lengths := newOrcIntReader(stream(orcStreamLength), false, isV2)
+			dictData := stripe.streams[[2]int{column, orcStreamDictionaryData}]
+			dictionary := make([]any, dictSize)
+			pos := 0
+			for i := range dictionary {
+				l, err := lengths.next()
+				if err != nil {
+					return nil, fmt.Errorf("while reading orc dictionary lengths: %v", err)
+				}
+				if pos+int(l) > len(dictData) {
+					return nil, fmt.Errorf("error: invalid orc dictionary")
+				}
+				dictionary[i] = toValue(dictData[pos : pos+int(l)])
+				pos += int(l)
+			}
+			data := newOrcIntReader(stream(orcStreamData), false, isV2)
+			reader.readValue = func() (any, error) {
+				idx, err := data.next()
+				if err != nil {
+					return nil, err
+				}
+				if idx < 0 || int(idx) >= len(dictionary) {
+					return nil, fmt.Errorf("error: invalid orc dictionary index %d", idx)
+				}
+				return dictionary[idx], nil
+			}
+		default:
+			lengths := newOrcIntReader(stream(orcStreamLength), false, isV2)
+			data := stripe.streams[[2]int{column, orcStreamData}]
+			pos := 0
+			reader.readValue = func() (any, error) {
+				l, err := lengths.next()
+				if err != nil {
+					return nil, err
+				}
+				if pos+int(l) > len(data) {
+					return nil, fmt.Errorf("error: orc string data is truncated")
+				}
+				v := toValue(data[pos : pos+int(l)])
+				pos += int(l)
+				return v, nil
+			}
+		}
+
+	case orcTimestamp, orcTimestampInstant:
+		seconds := newOrcIntReader(stream(orcStreamData), true, isV2)
+		nanos := newOrcIntReader(stream(orcStreamSecondary), false, isV2)
+		reader.readValue = func() (any, error) {
+			s, err := seconds.next()
+			if err != nil {
+				return nil, err
+			}
+			ns, err := nanos.next()
+			if err != nil {
+				return nil, err
+			}
+			// The low 3 bits are the nbr of trailing zeros removed
+			zeros := ns & 0x07
+			ns >>= 3
+			if zeros != 0 {
+				for range zeros + 1 {
+					ns *= 10
+				}
+			}
+			s += orcEpochSeconds
+			if s < 0 && ns > 999999 {
+				s -= 1
+			}
+			return time.Unix(s, ns).UTC(), nil
+		}
+
+	case orcDecimal:
+		data := stream(orcStreamData)
+		scales := newOrcIntReader(stream(orcStreamSecondary), true, isV2)
+		reader.readValue = func() (any, error) {
+			unscaled, err := readOrcBigVarInt(data)
+			if err != nil {
+				return nil, err
+			}
+			scale, err := scales.next()
+			if err != nil {
+				return nil, err
+			}
+			return typedDecimal{unscaled: unscaled, scale: int32(scale)}, nil
+		}
+
+	case orcStruct:
+		structReader := &orcStructReader{orcPresent: present, names: t.fieldNames}
+		for _, st := range t.subtypes {
+			child, err := r.newColumnReader(stripe, st)
+			if err != nil {
+				return nil, err
+			}
+			structReader.children = append(structReader.children, child)
+		}
+		return structReader, nil
+
+	case orcList, orcMap:
+		listReader := &orcListReader{
+			orcPresent: present,
+			lengths:    newOrcIntReader(stream(orcStreamLength), false, isV2),
+		}
+		if len(t.subtypes) != 1 && len(t.subtypes) != 2 {
+			return nil, fmt.Errorf("error: invalid orc list or map type")
+		}
+		var err error
+		if t.kind == orcMap {
+			if len(t.subtypes) != 2 {
+				return nil, fmt.Errorf("error: invalid orc map type")
+			}
+			if listReader.keys, err = r.newColumnReader(stripe, t.subtypes[0]); err != nil {
+				return nil, err
+			}
+		}
+		if listReader.items, err = r.newColumnReader(stripe, t.subtypes[len(t.subtypes)-1]); err != nil {
+			return nil, err
+		}
+		return listReader, nil
+
+	default:
+		return nil, fmt.Errorf("error: unsupported orc type kind %d for column %d", t.kind, column)
+	}
+	return reader, nil
+}
+
+// readOrcBigVarInt reads an unbounded zigzag base 128 varint
+func readOrcBigVarInt(r io.ByteReader) (*big.Int, error) {
+	v := new(big.Int)
+	var shift uint
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		v.Or(v, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), shift))
+		if b < 0x80 {
+			break
+		}
+		shift += 7
+	}
+	// zigzag decoding
+	isNegative := v.Bit(0) == 1
+	v.Rsh(v, 1)
+	if isNegative {
+		v.Add(v, big.NewInt(1))
+		v.Neg(v)
+	}
+	return v, nil
+}
+
+// ReadRows reads the stripes starting in the byte range [start, end)
+func (r *orcFileReader) ReadRows(start, end int64, columns []int, fnc func(row []any) error) error {
+	const batchSize = 1024
+	root := r.types[0]
+	for _, info := range r.stripes {
+		if info.offset < start || (end >= 0 && info.offset >= end) {
+			continue
+		}
+		stripe, err := r.readStripe(info)
+		if err != nil {
+			return err
+		}
+		readers := make([]orcColumnReader, len(columns))
+		for i, icol := range columns {
+			readers[i], err = r.newColumnReader(stripe, root.subtypes[icol])
+			if err != nil {
+				return err
+			}
+		}
+		for remaining := info.numberOfRows; remaining > 0; {
+			n := int(min(remaining, batchSize))
+			remaining -= int64(n)
+			values := make([][]any, len(columns))
+			for i, reader := range readers {
+				if values[i], err = reader.next(n); err != nil {
+					return fmt.Errorf("while reading orc column %s: %v", r.schemaInfo.Fields[columns[i]].Name, err)
+				}
+			}
+			for irow := range n {
+				row := make([]any, len(columns))
+				for i := range columns {
+					row[i] = values[i][irow]
+				}
+				if err = fnc(row); err != nil {
+					if err == errStopReading {
+						return nil
+					}
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
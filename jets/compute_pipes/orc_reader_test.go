@@ -0,0 +1,258 @@
+package compute_pipes
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+// This file contains test cases for the orc reader
+
+// protoBuilder encodes protobuf messages for the test orc file
+type protoBuilder struct {
+	buf []byte
+}
+
+func (p *protoBuilder) varint(field int, v uint64) *protoBuilder {
+	p.buf = binary.AppendUvarint(p.buf, uint64(field<<3))
+	p.buf = binary.AppendUvarint(p.buf, v)
+	return p
+}
+
+func (p *protoBuilder) bytes(field int, b []byte) *protoBuilder {
+	p.buf = binary.AppendUvarint(p.buf, uint64(field<<3|2))
+	p.buf = binary.AppendUvarint(p.buf, uint64(len(b)))
+	p.buf = append(p.buf, b...)
+	return p
+}
+
+// testOrcStream is a stream of the test orc file
+type testOrcStream struct {
+	kind, column int
+	data         []byte
+}
+
+// compressTestOrcSection compresses data as one orc compression chunk, using the reference
+// implementation of each codec. The chunk is marked as original when isOriginal is true.
+func compressTestOrcSection(t *testing.T, compression int, data []byte, isOriginal bool) []byte {
+	t.Helper()
+	if compression == orcCompressionNone {
+		return data
+	}
+	chunk := data
+	if !isOriginal {
+		switch compression {
+		case orcCompressionZlib:
+			var buf bytes.Buffer
+			w, _ := flate.NewWriter(&buf, flate.BestCompression)
+			w.Write(data)
+			w.Close()
+			chunk = buf.Bytes()
+		case orcCompressionSnappy:
+			chunk = snappy.Encode(nil, data)
+		case orcCompressionLz4:
+			chunk = make([]byte, lz4.CompressBlockBound(len(data)))
+			n, err := lz4.CompressBlock(data, chunk, nil)
+			if err != nil || n == 0 {
+				t.Fatalf("while compressing lz4 block: %v", err)
+			}
+			chunk = chunk[:n]
+		case orcCompressionZstd:
+			encoder, err := zstd.NewWriter(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			chunk = encoder.EncodeAll(data, nil)
+			encoder.Close()
+		}
+	}
+	header := len(chunk) << 1
+	if isOriginal {
+		header |= 1
+	}
+	return append([]byte{byte(header), byte(header >> 8), byte(header >> 16)}, chunk...)
+}
+
+// writeTestOrcFile writes an orc file with one stripe, the streams and the stripe footer are
+// written as specified by the orc spec, the sections are compressed with compression
+// (the stripe footer is written as an original chunk)
+func writeTestOrcFile(t *testing.T, compression int, streams []testOrcStream, encodings, types []*protoBuilder, numberOfRows int) []byte {
+	var file bytes.Buffer
+	file.WriteString("ORC")
+	stripeOffset := file.Len()
+	stripeFooter := &protoBuilder{}
+	for _, s := range streams {
+		data := compressTestOrcSection(t, compression, s.data, false)
+		file.Write(data)
+		stream := &protoBuilder{}
+		stream.varint(1, uint64(s.kind)).varint(2, uint64(s.column)).varint(3, uint64(len(data)))
+		stripeFooter.bytes(1, stream.buf)
+	}
+	for _, encoding := range encodings {
+		stripeFooter.bytes(2, encoding.buf)
+	}
+	dataLength := file.Len() - stripeOffset
+	stripeFooterData := compressTestOrcSection(t, compression, stripeFooter.buf, true)
+	file.Write(stripeFooterData)
+
+	footer := &protoBuilder{}
+	stripe := &protoBuilder{}
+	stripe.varint(1, uint64(stripeOffset)).varint(2, 0).varint(3, uint64(dataLength)).
+		varint(4, uint64(len(stripeFooterData))).varint(5, uint64(numberOfRows))
+	footer.bytes(3, stripe.buf)
+	for _, tp := range types {
+		footer.bytes(4, tp.buf)
+	}
+	footer.varint(6, uint64(numberOfRows))
+	footerData := compressTestOrcSection(t, compression, footer.buf, false)
+	file.Write(footerData)
+
+	postscript := &protoBuilder{}
+	postscript.varint(1, uint64(len(footerData))).varint(2, uint64(compression)).bytes(8000, []byte("ORC"))
+	if compression != orcCompressionNone {
+		postscript.varint(3, 64*1024)
+	}
+	file.Write(postscript.buf)
+	file.WriteByte(byte(len(postscript.buf)))
+	return file.Bytes()
+}
+
+// writeTestOrcFileV1 writes an orc file with 3 rows and the columns id int, name string
+// (with a null), dos date, using rle v1
+func writeTestOrcFileV1(t *testing.T, compression int) []byte {
+	streams := []testOrcStream{
+		{orcStreamData, 1, []byte{0xfd, 0x02, 0x04, 0x06}},
+		{orcStreamPresent, 2, []byte{0xff, 0xa0}},
+		{orcStreamData, 2, []byte("abc")},
+		{orcStreamLength, 2, []byte{0xfe, 0x01, 0x02}},
+		{orcStreamData, 3, []byte{0x00, 0x00, 0xf0, 0xa8, 0x02}},
+	}
+	var encodings []*protoBuilder
+	for range 4 {
+		encodings = append(encodings, (&protoBuilder{}).varint(1, orcEncodingDirect))
+	}
+	types := []*protoBuilder{
+		(&protoBuilder{}).varint(1, orcStruct).bytes(2, []byte{1, 2, 3}).
+			bytes(3, []byte("id")).bytes(3, []byte("name")).bytes(3, []byte("dos")),
+		(&protoBuilder{}).varint(1, orcInt),
+		(&protoBuilder{}).varint(1, orcString),
+		(&protoBuilder{}).varint(1, orcDate),
+	}
+	return writeTestOrcFile(t, compression, streams, encodings, types, 3)
+}
+
+// writeTestOrcFileV2 writes an orc file with 3 rows and the columns code string (dictionary
+// encoded), amount decimal(10,2), ts timestamp, using rle v2. The streams are encoded as per
+// the orc spec, rle v2 direct runs:
+//   - code: dictionary ["AB", "C"], rows [1, 0, 1]
+//   - amount: unscaled values as zigzag varints [1234, -50, 10000], scales [2, 2, 2]
+//   - ts: seconds from 2015-01-01 [1, 221574600, -1], nanos with the trailing zeros
+//     removed [5 with 8 zeros, 12 with 7 zeros, 0]
+func writeTestOrcFileV2(t *testing.T, compression int) []byte {
+	streams := []testOrcStream{
+		{orcStreamData, 1, []byte{0x40, 0x02, 0xa0}},
+		{orcStreamLength, 1, []byte{0x42, 0x01, 0x90}},
+		{orcStreamDictionaryData, 1, []byte("ABC")},
+		{orcStreamData, 2, []byte{0xa4, 0x13, 0x63, 0xa0, 0x9c, 0x01}},
+		{orcStreamSecondary, 2, []byte{0x44, 0x02, 0x92, 0x00}},
+		{orcStreamData, 3, []byte{0x74, 0x02, 0x00, 0x00, 0x00, 0x09, 0xa6, 0x9e, 0xb9, 0x00, 0x00, 0x00, 0x00, 0x40}},
+		{orcStreamSecondary, 3, []byte{0x4c, 0x02, 0x5f, 0x98, 0x00}},
+	}
+	encodings := []*protoBuilder{
+		(&protoBuilder{}).varint(1, orcEncodingDirect),
+		(&protoBuilder{}).varint(1, orcEncodingDictionaryV2).varint(2, 2),
+		(&protoBuilder{}).varint(1, orcEncodingDirectV2),
+		(&protoBuilder{}).varint(1, orcEncodingDirectV2),
+	}
+	types := []*protoBuilder{
+		(&protoBuilder{}).varint(1, orcStruct).bytes(2, []byte{1, 2, 3}).
+			bytes(3, []byte("code")).bytes(3, []byte("amount")).bytes(3, []byte("ts")),
+		(&protoBuilder{}).varint(1, orcString),
+		(&protoBuilder{}).varint(1, orcDecimal).varint(5, 10).varint(6, 2),
+		(&protoBuilder{}).varint(1, orcTimestamp),
+	}
+	return writeTestOrcFile(t, compression, streams, encodings, types, 3)
+}
+
+var testOrcCompressions = []int{orcCompressionNone, orcCompressionZlib, orcCompressionSnappy, orcCompressionLz4, orcCompressionZstd}
+
+func TestOrcFileReader(t *testing.T) {
+	for _, compression := range testOrcCompressions {
+		data := writeTestOrcFileV1(t, compression)
+		reader, err := newOrcFileReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("compression %d: %v", compression, err)
+		}
+		schemaInfo := reader.SchemaInfo()
+		if !reflect.DeepEqual(schemaInfo.Columns(), []string{"id", "name", "dos"}) {
+			t.Errorf("compression %d: unexpected columns %v", compression, schemaInfo.Columns())
+		}
+		if schemaInfo.Fields[0].Type != "int32" || schemaInfo.Fields[2].Type != "date32" {
+			t.Errorf("compression %d: unexpected types %s, %s", compression, schemaInfo.Fields[0].Type, schemaInfo.Fields[2].Type)
+		}
+		rows := readTestTypedFile(t, reader, 0, -1, []int{2, 1, 0})
+		expected := [][]any{
+			{"2022-01-08", "a", "1"},
+			{"2022-01-08", nil, "2"},
+			{"2022-01-08", "bc", "3"},
+		}
+		if !reflect.DeepEqual(rows, expected) {
+			t.Errorf("compression %d: expecting %v, got %v", compression, expected, rows)
+		}
+
+		// The stripe belongs to the first shard only
+		rows = readTestTypedFile(t, reader, 10, -1, []int{0})
+		if len(rows) != 0 {
+			t.Errorf("compression %d: expecting no rows in second shard, got %v", compression, rows)
+		}
+	}
+}
+
+func TestOrcFileReaderV2(t *testing.T) {
+	for _, compression := range testOrcCompressions {
+		data := writeTestOrcFileV2(t, compression)
+		reader, err := newOrcFileReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("compression %d: %v", compression, err)
+		}
+		types := make([]string, 0)
+		for _, fi := range reader.SchemaInfo().Fields {
+			types = append(types, fi.Type)
+		}
+		if !reflect.DeepEqual(types, []string{"utf8", "decimal", "timestamp"}) {
+			t.Errorf("compression %d: unexpected types %v", compression, types)
+		}
+		rows := readTestTypedFile(t, reader, 0, -1, []int{0, 1, 2})
+		expected := [][]any{
+			{"C", "12.34", "2015-01-01T00:00:01.5"},
+			{"AB", "-0.50", "2022-01-08T12:30:00.12"},
+			{"C", "100.00", "2014-12-31T23:59:59"},
+		}
+		if !reflect.DeepEqual(rows, expected) {
+			t.Errorf("compression %d: expecting %v, got %v", compression, expected, rows)
+		}
+	}
+}
+
+func TestOrcFileReaderUnsupportedCompression(t *testing.T) {
+	data := writeTestOrcFileV1(t, orcCompressionNone)
+	// Patch the compression kind of the postscript: field 2 follows the footer length
+	psLen := int(data[len(data)-1])
+	postscript := data[len(data)-1-psLen : len(data)-1]
+	idx := bytes.IndexByte(postscript, 2<<3)
+	if idx < 0 {
+		t.Fatal("compression kind not found in postscript")
+	}
+	postscript[idx+1] = orcCompressionLzo
+	_, err := newOrcFileReader(bytes.NewReader(data), int64(len(data)))
+	if err == nil || !strings.Contains(err.Error(), "unsupported orc compression LZO") {
+		t.Errorf("expecting unsupported LZO compression error, got %v", err)
+	}
+}
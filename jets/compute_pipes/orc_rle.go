@@ -0,0 +1,391 @@
+package compute_pipes
+
+import (
+	"fmt"
+	"io"
+)
+
+// Run length decoders of the orc file format: byte rle, boolean rle,
+// integer rle v1 and integer rle v2.
+
+// orcByteRleReader decodes byte rle
+type orcByteRleReader struct {
+	r       io.ByteReader
+	literal bool
+	count   int
+	value   byte
+}
+
+func (d *orcByteRleReader) next() (byte, error) {
+	if d.count == 0 {
+		control, err := d.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if control < 0x80 {
+			// run
+			d.literal = false
+			d.count = int(control) + 3
+			if d.value, err = d.r.ReadByte(); err != nil {
+				return 0, err
+			}
+		} else {
+			d.literal = true
+			d.count = 0x100 - int(control)
+		}
+	}
+	d.count--
+	if d.literal {
+		return d.r.ReadByte()
+	}
+	return d.value, nil
+}
+
+// orcBoolReader decodes boolean rle, the bits are msb first
+type orcBoolReader struct {
+	bytes   orcByteRleReader
+	current byte
+	bitPos  int
+}
+
+func newOrcBoolReader(r io.ByteReader) *orcBoolReader {
+	return &orcBoolReader{bytes: orcByteRleReader{r: r}, bitPos: 8}
+}
+
+func (d *orcBoolReader) next() (bool, error) {
+	if d.bitPos == 8 {
+		b, err := d.bytes.next()
+		if err != nil {
+			return false, err
+		}
+		d.current = b
+		d.bitPos = 0
+	}
+	v := d.current&(0x80>>d.bitPos) != 0
+	d.bitPos++
+	return v, nil
+}
+
+// orcIntReader decodes integer rle v1 or v2, the values of a run are decoded
+// at once in values
+type orcIntReader struct {
+	r      io.ByteReader
+	signed bool
+	isV2   bool
+	values []int64
+	pos    int
+}
+
+func newOrcIntReader(r io.ByteReader, signed, isV2 bool) *orcIntReader {
+	return &orcIntReader{r: r, signed: signed, isV2: isV2}
+}
+
+func (d *orcIntReader) next() (int64, error) {
+	if d.pos >= len(d.values) {
+		d.values = d.values[:0]
+		d.pos = 0
+		var err error
+		if d.isV2 {
+			err = d.readRunV2()
+		} else {
+			err = d.readRunV1()
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	v := d.values[d.pos]
+	d.pos++
+	return v, nil
+}
+
+func zigzagDecode(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func readOrcVarUint(r io.ByteReader) (uint64, error) {
+	var v uint64
+	var shift uint
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if shift >= 64 {
+			return 0, fmt.Errorf("error: orc varint overflow")
+		}
+		v |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return v, nil
+		}
+		shift += 7
+	}
+}
+
+func (d *orcIntReader) readVarInt() (int64, error) {
+	v, err := readOrcVarUint(d.r)
+	if err != nil {
+		return 0, err
+	}
+	if d.signed {
+		return zigzagDecode(v), nil
+	}
+	return int64(v), nil
+}
+
+func (d *orcIntReader) readRunV1() error {
+	header, err := d.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if header < 0x80 {
+		// run of header+3 values, delta is a signed byte
+		count := int(header) + 3
+		delta, err := d.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		base, err := d.readVarInt()
+		if err != nil {
+			return err
+		}
+		for i := range count {
+			d.values = append(d.values, base+int64(i)*int64(int8(delta)))
+		}
+		return nil
+	}
+	count := 0x100 - int(header)
+	for range count {
+		v, err := d.readVarInt()
+		if err != nil {
+			return err
+		}
+		d.values = append(d.values, v)
+	}
+	return nil
+}
+
+// orcDecodeBitWidth decodes the 5 bits width of rle v2
+func orcDecodeBitWidth(encoded byte) int {
+	switch {
+	case encoded <= 23:
+		return int(encoded) + 1
+	case encoded == 24:
+		return 26
+	case encoded == 25:
+		return 28
+	case encoded == 26:
+		return 30
+	case encoded == 27:
+		return 32
+	case encoded == 28:
+		return 40
+	case encoded == 29:
+		return 48
+	case encoded == 30:
+		return 56
+	}
+	return 64
+}
+
+// orcClosestFixedBits returns the bit width used to pack the patch list entries
+func orcClosestFixedBits(n int) int {
+	switch {
+	case n == 0:
+		return 1
+	case n <= 24:
+		return n
+	case n <= 26:
+		return 26
+	case n <= 28:
+		return 28
+	case n <= 30:
+		return 30
+	case n <= 32:
+		return 32
+	case n <= 40:
+		return 40
+	case n <= 48:
+		return 48
+	case n <= 56:
+		return 56
+	}
+	return 64
+}
+
+// readBitPacked reads count values of width bits, msb first, the run ends on a byte boundary
+func (d *orcIntReader) readBitPacked(count, width int) ([]uint64, error) {
+	result := make([]uint64, count)
+	var current byte
+	bitsLeft := 0
+	for i := range count {
+		var v uint64
+		need := width
+		for need > 0 {
+			if bitsLeft == 0 {
+				b, err := d.r.ReadByte()
+				if err != nil {
+					return nil, err
+				}
+				current = b
+				bitsLeft = 8
+			}
+			take := min(need, bitsLeft)
+			shift := bitsLeft - take
+			v = v<<take | uint64((current>>shift)&byte(0xff>>(8-take)))
+			bitsLeft -= take
+			need -= take
+		}
+		result[i] = v
+	}
+	return result, nil
+}
+
+func (d *orcIntReader) readBigEndian(nbytes int) (uint64, error) {
+	var v uint64
+	for range nbytes {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		v = v<<8 | uint64(b)
+	}
+	return v, nil
+}
+
+func (d *orcIntReader) decodeValue(v uint64) int64 {
+	if d.signed {
+		return zigzagDecode(v)
+	}
+	return int64(v)
+}
+
+func (d *orcIntReader) readRunV2() error {
+	header, err := d.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch header >> 6 {
+	case 0:
+		// short repeat
+		width := int((header>>3)&0x07) + 1
+		count := int(header&0x07) + 3
+		v, err := d.readBigEndian(width)
+		if err != nil {
+			return err
+		}
+		value := d.decodeValue(v)
+		for range count {
+			d.values = append(d.values, value)
+		}
+		return nil
+
+	case 1:
+		// direct
+		width := orcDecodeBitWidth((header >> 1) & 0x1f)
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		count := (int(header&0x01)<<8 | int(b)) + 1
+		packed, err := d.readBitPacked(count, width)
+		if err != nil {
+			return err
+		}
+		for _, v := range packed {
+			d.values = append(d.values, d.decodeValue(v))
+		}
+		return nil
+
+	case 2:
+		// patched base
+		width := orcDecodeBitWidth((header >> 1) & 0x1f)
+		hdr := make([]byte, 3)
+		for i := range hdr {
+			if hdr[i], err = d.r.ReadByte(); err != nil {
+				return err
+			}
+		}
+		count := (int(header&0x01)<<8 | int(hdr[0])) + 1
+		baseWidth := int((hdr[1]>>5)&0x07) + 1
+		patchWidth := orcDecodeBitWidth(hdr[1] & 0x1f)
+		patchGapWidth := int((hdr[2]>>5)&0x07) + 1
+		patchListLength := int(hdr[2] & 0x1f)
+		// base value is sign-magnitude
+		b, err := d.readBigEndian(baseWidth)
+		if err != nil {
+			return err
+		}
+		signMask := uint64(1) << (baseWidth*8 - 1)
+		base := int64(b &^ signMask)
+		if b&signMask != 0 {
+			base = -base
+		}
+		packed, err := d.readBitPacked(count, width)
+		if err != nil {
+			return err
+		}
+		patches, err := d.readBitPacked(patchListLength, orcClosestFixedBits(patchGapWidth+patchWidth))
+		if err != nil {
+			return err
+		}
+		ipos := 0
+		patchMask := uint64(1)<<patchWidth - 1
+		for _, p := range patches {
+			gap := int(p >> patchWidth)
+			ipos += gap
+			if ipos >= count {
+				return fmt.Errorf("error: invalid orc patched base run, patch position out of range")
+			}
+			packed[ipos] |= (p & patchMask) << width
+		}
+		for _, v := range packed {
+			d.values = append(d.values, base+int64(v))
+		}
+		return nil
+
+	default:
+		// delta
+		encodedWidth := (header >> 1) & 0x1f
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		count := (int(header&0x01)<<8 | int(b)) + 1
+		base, err := d.readVarInt()
+		if err != nil {
+			return err
+		}
+		du, err := readOrcVarUint(d.r)
+		if err != nil {
+			return err
+		}
+		deltaBase := zigzagDecode(du)
+		d.values = append(d.values, base)
+		if count == 1 {
+			return nil
+		}
+		if encodedWidth == 0 {
+			// fixed delta
+			for i := 1; i < count; i++ {
+				d.values = append(d.values, base+int64(i)*deltaBase)
+			}
+			return nil
+		}
+		value := base + deltaBase
+		d.values = append(d.values, value)
+		deltas, err := d.readBitPacked(count-2, orcDecodeBitWidth(encodedWidth))
+		if err != nil {
+			return err
+		}
+		for _, delta := range deltas {
+			if deltaBase < 0 {
+				value -= int64(delta)
+			} else {
+				value += int64(delta)
+			}
+			d.values = append(d.values, value)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package compute_pipes
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// This file contains test cases for the orc rle decoders, using the examples of the orc specification
+
+func readOrcInts(t *testing.T, data []byte, signed, isV2 bool, n int) []int64 {
+	t.Helper()
+	d := newOrcIntReader(bytes.NewReader(data), signed, isV2)
+	result := make([]int64, 0, n)
+	for range n {
+		v, err := d.next()
+		if err != nil {
+			t.Fatalf("while decoding rle: %v", err)
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+func TestOrcIntRleV2(t *testing.T) {
+	// short repeat
+	v := readOrcInts(t, []byte{0x0a, 0x27, 0x10}, false, true, 5)
+	if !reflect.DeepEqual(v, []int64{10000, 10000, 10000, 10000, 10000}) {
+		t.Errorf("short repeat: got %v", v)
+	}
+	// direct
+	v = readOrcInts(t, []byte{0x5e, 0x03, 0x5c, 0xa1, 0xab, 0x1e, 0xde, 0xad, 0xbe, 0xef}, false, true, 4)
+	if !reflect.DeepEqual(v, []int64{23713, 43806, 57005, 48879}) {
+		t.Errorf("direct: got %v", v)
+	}
+	// patched base
+	v = readOrcInts(t, []byte{0x8e, 0x13, 0x2b, 0x21, 0x07, 0xd0, 0x1e, 0x00, 0x14, 0x70, 0x28, 0x32, 0x3c, 0x46,
+		0x50, 0x5a, 0x64, 0x6e, 0x78, 0x82, 0x8c, 0x96, 0xa0, 0xaa, 0xb4, 0xbe, 0xfc, 0xe8}, false, true, 20)
+	expected := []int64{2030, 2000, 2020, 1000000}
+	for i := 2040; i <= 2190; i += 10 {
+		expected = append(expected, int64(i))
+	}
+	if !reflect.DeepEqual(v, expected) {
+		t.Errorf("patched base: got %v", v)
+	}
+	// delta
+	v = readOrcInts(t, []byte{0xc6, 0x09, 0x02, 0x02, 0x22, 0x42, 0x42, 0x46}, false, true, 10)
+	if !reflect.DeepEqual(v, []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}) {
+		t.Errorf("delta: got %v", v)
+	}
+}
+
+func TestOrcIntRleV1(t *testing.T) {
+	// run of 100 values starting at 7 with delta -1, then a literal of 2 signed values
+	v := readOrcInts(t, []byte{0x61, 0xff, 0x0e, 0xfe, 0x03, 0x04}, true, false, 102)
+	if v[0] != 7 || v[99] != -92 || v[100] != -2 || v[101] != 2 {
+		t.Errorf("rle v1: got %v", v)
+	}
+}
+
+func TestOrcBoolRle(t *testing.T) {
+	d := newOrcBoolReader(bytes.NewReader([]byte{0xff, 0x80}))
+	for i := range 8 {
+		b, err := d.next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b != (i == 0) {
+			t.Errorf("bool rle: unexpected value at %d: %v", i, b)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package compute_pipes
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -86,7 +87,7 @@ func (cpCtx *ComputePipesContext) ReadParquetFileV2(filePath *FileName,
 			fileKey := fmt.Sprintf("%s/process_name=%s/session_id=%s/input_parquet_schema.json",
 				awsi.JetStoreStagePrefix(), cpCtx.ProcessName, cpCtx.SessionId)
 			log.Printf("Saving parquet schema to: %s", fileKey)
-			err = awsi.UploadBufToS3("", fileKey, schemaInfo)
+			err = objectStore.PutObject("", fileKey, bytes.NewReader(schemaInfo))
 			if err != nil {
 				return 0, fmt.Errorf("while uploading parquet schema info to s3: %v", err)
 			}
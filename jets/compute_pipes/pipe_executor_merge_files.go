@@ -240,16 +240,10 @@ func (cpCtx *ComputePipesContext) StartMergeFiles(dbpool *pgxpool.Pool) (cpErr e
 	default:
 		// Use s3 multipart file copy
 		log.Printf("*** MERGE %d files using s3 multipart file copy with format %s\n", nbrFiles, inputFormat)
-		s3Client, err := awsi.NewS3Client()
-		if err != nil {
-			cpErr = err
-			return
-		}
-
 		poolSize := cpCtx.CpConfig.ClusterConfig.S3WorkerPoolSize
 		sourceKey := fmt.Sprintf("%s/process_name=%s/session_id=%s/step_id=%s",
 			awsi.JetStoreStagePrefix(), cpCtx.ProcessName, cpCtx.SessionId, inputChannel.ReadStepId)
-		err = awsi.MultiPartCopy(context.TODO(), s3Client, poolSize, "", sourceKey, externalBucket, outputS3FileKey,
+		err = objectStore.MultiPartCopy(context.TODO(), poolSize, "", sourceKey, externalBucket, outputS3FileKey,
 			cpCtx.CpConfig.ClusterConfig.IsDebugMode)
 		if err != nil {
 			cpErr = fmt.Errorf("%s while merging files using s3 copy: %v", cpCtx.SessionId, err)
@@ -261,7 +255,7 @@ func (cpCtx *ComputePipesContext) StartMergeFiles(dbpool *pgxpool.Pool) (cpErr e
 	}
 
 	// put content of file to s3 using a local reader
-	if err := awsi.UploadToS3FromReader(externalBucket, outputS3FileKey, fileReader); err != nil {
+	if err := objectStore.PutObject(externalBucket, outputS3FileKey, fileReader); err != nil {
 		cpErr = fmt.Errorf("while copying to s3: %v", err)
 		return
 	}
@@ -11,7 +11,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/artisoft-io/jetstore/jets/awsi"
 	"github.com/artisoft-io/jetstore/jets/csv"
 	"github.com/artisoft-io/jetstore/jets/date_utils"
 	"github.com/artisoft-io/jetstore/jets/utils"
@@ -661,7 +660,7 @@ func (ctx *BuilderContext) NewAnonymizeTransformationPipe(source *InputChannel,
 			log.Println("***", ctx.sessionId, "Uploading anonymized columns file to s3 bucket:", bucket, "path:", path)
 			log.Println(data)
 		}
-		err = awsi.UploadToS3FromReader(bucket, path, strings.NewReader(data))
+		err = objectStore.PutObject(bucket, path, strings.NewReader(data))
 		if err != nil {
 			return nil, fmt.Errorf("while uploading anonymized columns file to s3 output location %s/%s: %v",
 				outputFileSpec.Bucket, outputFileSpec.OutputLocation, err)
@@ -2,23 +2,44 @@ package compute_pipes
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"strings"
 
 	"github.com/dolthub/swiss"
 )
 
+// Distinct operator. Send the first record of each distinct composite key.
+// In mode spill, the input records are hash partitioned on the composite key
+// into local spill files and the distinct records are sent at Done, partition
+// by partition. In that mode the records are not sent in input order, but
+// the first record of each key is the first one in input order.
 type DistinctTransformationPipe struct {
 	cpConfig     *ComputePipesConfig
 	source       *InputChannel
 	outputCh     *OutputChannel
 	compositeKey []int
 	distinctRows *swiss.Map[string, bool]
+	spill        *spillPartitions
 	spec         *TransformationSpec
 	env          map[string]interface{}
 	doneCh       chan struct{}
 }
 
+// make the composite key of the input record
+func (ctx *DistinctTransformationPipe) keyOf(input *[]interface{}) string {
+	ckeys := make([]string, 0, len(ctx.compositeKey))
+	for _, pos := range ctx.compositeKey {
+		switch vv := (*input)[pos].(type) {
+		case string:
+			ckeys = append(ckeys, vv)
+		default:
+			ckeys = append(ckeys, fmt.Sprintf("%v", vv))
+		}
+	}
+	return strings.Join(ckeys, "")
+}
+
 // Implementing interface PipeTransformationEvaluator
 func (ctx *DistinctTransformationPipe) Apply(input *[]interface{}) error {
 	if input == nil {
@@ -32,17 +53,10 @@ func (ctx *DistinctTransformationPipe) Apply(input *[]interface{}) error {
 		return nil
 	}
 
-	// make the key
-	ckeys := make([]string, 0, len(ctx.compositeKey))
-	for i := range ctx.compositeKey {
-		switch vv := (*input)[i].(type) {
-		case string:
-			ckeys = append(ckeys, vv)
-		default:
-			ckeys = append(ckeys, fmt.Sprintf("%v", vv))
-		}
+	key := ctx.keyOf(input)
+	if ctx.spill != nil {
+		return ctx.spill.Write(key, *input)
 	}
-	key := strings.Join(ckeys, "")
 	_, ok := ctx.distinctRows.Get(key)
 	if !ok {
 		ctx.distinctRows.Put(key, true)
@@ -56,10 +70,52 @@ func (ctx *DistinctTransformationPipe) Apply(input *[]interface{}) error {
 }
 
 func (ctx *DistinctTransformationPipe) Done() error {
+	if ctx.spill == nil {
+		return nil
+	}
+	// Send the distinct rows of each partition, only the keys of one partition
+	// are held in memory
+	var count int64
+	err := ctx.spill.ForEachPartition(func(_ int, reader *spillFileReader) error {
+		ctx.distinctRows = swiss.NewMap[string, bool](1000)
+		for {
+			record, err := reader.Read()
+			if err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+			key := ctx.keyOf(&record)
+			_, ok := ctx.distinctRows.Get(key)
+			if !ok {
+				ctx.distinctRows.Put(key, true)
+				count++
+				select {
+				case ctx.outputCh.Channel <- record:
+				case <-ctx.doneCh:
+					log.Println("Distinct Transform interrupted")
+					return nil
+				}
+			}
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("while sending distinct rows from spill partitions: %v", err)
+	}
+	if ctx.spec.DistinctConfig.IsDebug {
+		log.Printf("DistinctTransformationPipe: sent %d distinct rows from %d spill partitions",
+			count, len(ctx.spill.files))
+	}
 	return nil
 }
 
-func (ctx *DistinctTransformationPipe) Finally() {}
+func (ctx *DistinctTransformationPipe) Finally() {
+	if ctx.spill != nil {
+		ctx.spill.Remove()
+		ctx.spill = nil
+	}
+}
 
 func (ctx *BuilderContext) NewDistinctTransformationPipe(source *InputChannel, outputCh *OutputChannel, spec *TransformationSpec) (*DistinctTransformationPipe, error) {
 	if spec == nil || spec.DistinctConfig == nil {
@@ -74,12 +130,28 @@ func (ctx *BuilderContext) NewDistinctTransformationPipe(source *InputChannel, o
 		}
 		compositeKey = append(compositeKey, pos)
 	}
+	// Check if using spill partitions
+	var spill *spillPartitions
+	var err error
+	switch spec.DistinctConfig.Mode {
+	case "", "in_memory":
+	case "spill":
+		spill, err = newSpillPartitions(ctx.JetStoreTempFolder(), "distinct_spill",
+			spillPartitionsCount(spec.DistinctConfig.SpillPartitions))
+		if err != nil {
+			return nil, fmt.Errorf("while creating spill partitions (distinct operator): %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("error: unknown mode '%s' for distinct operator, expecting in_memory or spill",
+			spec.DistinctConfig.Mode)
+	}
 	return &DistinctTransformationPipe{
 		cpConfig:     ctx.cpConfig,
 		source:       source,
 		outputCh:     outputCh,
 		compositeKey: compositeKey,
 		distinctRows: swiss.NewMap[string, bool](1000),
+		spill:        spill,
 		spec:         spec,
 		env:          ctx.env,
 		doneCh:       ctx.done,
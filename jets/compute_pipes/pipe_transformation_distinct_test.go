@@ -0,0 +1,143 @@
+package compute_pipes
+
+import (
+	"sync"
+	"testing"
+)
+
+// This file contains test cases for DistinctTransformationPipe
+
+func TestDistinctRecords1(t *testing.T) {
+	inputRecords := []*[]any{
+		{"5", "3", "a"},
+		{"1", "2", "b"},
+		{"5", "3", "c"},
+		{"5", "1", "d"},
+		{"1", "2", "e"},
+	}
+	for _, mode := range []string{"", "spill"} {
+		outputRecords, err := doDistinctRecordsTest([]string{"key1", "key2"}, mode, inputRecords)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(outputRecords) != 3 {
+			t.Fatalf("mode '%s': expecting 3 distinct records, got %d", mode, len(outputRecords))
+		}
+		// The first record of each key is kept
+		values := make(map[string]bool)
+		for _, r := range outputRecords {
+			values[r[2].(string)] = true
+		}
+		if !values["a"] || !values["b"] || !values["d"] {
+			t.Errorf("mode '%s': unexpected distinct records: %v", mode, outputRecords)
+		}
+	}
+}
+
+func TestDistinctRecordsSpill(t *testing.T) {
+	inputRecords := make([]*[]any, 0, 1000)
+	for i := range 1000 {
+		inputRecords = append(inputRecords, &[]any{i % 97, "x", i})
+	}
+	outputRecords, err := doDistinctRecordsTest([]string{"key1"}, "spill", inputRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputRecords) != 97 {
+		t.Fatalf("expecting 97 distinct records, got %d", len(outputRecords))
+	}
+	for _, r := range outputRecords {
+		if r[0].(int) != r[2].(int) {
+			t.Errorf("expecting first record of key %v, got %v", r[0], r)
+		}
+	}
+	_, err = doDistinctRecordsTest([]string{"key1"}, "bad_mode", inputRecords)
+	if err == nil {
+		t.Error("expecting error with unknown mode")
+	}
+}
+
+// The composite key is made of the columns of distinct_on, not the first columns of the record
+func TestDistinctRecordsOnColumnPos(t *testing.T) {
+	inputRecords := []*[]any{
+		{"1", "x", "a"},
+		{"2", "x", "b"},
+		{"3", "y", "c"},
+		{"4", "y", "d"},
+		{"5", "z", "e"},
+	}
+	for _, mode := range []string{"", "spill"} {
+		outputRecords, err := doDistinctRecordsTest([]string{"key2"}, mode, inputRecords)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(outputRecords) != 3 {
+			t.Fatalf("mode '%s': expecting 3 distinct records, got %d", mode, len(outputRecords))
+		}
+		values := make(map[string]bool)
+		for _, r := range outputRecords {
+			values[r[2].(string)] = true
+		}
+		if !values["a"] || !values["c"] || !values["e"] {
+			t.Errorf("mode '%s': unexpected distinct records: %v", mode, outputRecords)
+		}
+	}
+}
+
+func doDistinctRecordsTest(distinctOn []string, mode string, inputRecords []*[]any) (outputRecords [][]any, err error) {
+	spec := &TransformationSpec{
+		Type: "distinct",
+		DistinctConfig: &DistinctSpec{
+			DistinctOn:      distinctOn,
+			Mode:            mode,
+			SpillPartitions: 8,
+		},
+	}
+	source := &InputChannel{
+		Name: "in",
+		Columns: &map[string]int{
+			"key1":  0,
+			"key2":  1,
+			"value": 2,
+		},
+		Config: &ChannelSpec{
+			Name:    "in",
+			Columns: []string{"key1", "key2", "value"},
+		},
+	}
+	outCh := make(chan []any)
+	outputCh := &OutputChannel{
+		Channel: outCh,
+	}
+	ctx := &BuilderContext{
+		done: make(chan struct{}),
+	}
+
+	var distinctTrsf *DistinctTransformationPipe
+	distinctTrsf, err = ctx.NewDistinctTransformationPipe(source, outputCh, spec)
+	if err != nil {
+		return
+	}
+	defer distinctTrsf.Finally()
+	outputRecords = make([][]any, 0, len(inputRecords))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for outRow := range outCh {
+			outputRecords = append(outputRecords, outRow)
+		}
+	}()
+
+	for _, r := range inputRecords {
+		err = distinctTrsf.Apply(r)
+		if err != nil {
+			return
+		}
+	}
+	err = distinctTrsf.Done()
+	close(outCh)
+	wg.Wait()
+	return
+}
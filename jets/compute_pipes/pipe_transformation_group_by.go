@@ -1,13 +1,18 @@
 package compute_pipes
 
 import (
+	"container/heap"
 	"fmt"
+	"io"
 	"log"
 	"strings"
 )
 
 // Group By operator. Group the input records into bundles, where each
 // record of the bundle is a rule session.
+// In mode spill, the input records are hash partitioned on the group by value
+// into local spill files and the bundles are sent at Done, in order of first
+// appearance of the group by value, the input does not need to be sorted on the group by value.
 type GroupByTransformationPipe struct {
 	cpConfig      *ComputePipesConfig
 	source        *InputChannel
@@ -16,6 +21,8 @@ type GroupByTransformationPipe struct {
 	currentBundle []any
 	groupByCount  int
 	groupByPos    []int
+	spill         *spillPartitions
+	spillSeq      int64
 	spec          *TransformationSpec
 	env           map[string]any
 	doneCh        chan struct{}
@@ -54,6 +61,14 @@ func (ctx *GroupByTransformationPipe) Apply(input *[]any) error {
 	}
 	// Group by value
 	groupByValue := ctx.groupValueOf(input)
+	if ctx.spill != nil {
+		// Spill the record prefixed with its sequence nbr to restore the input order of the groups
+		ctx.spillSeq++
+		record := make([]any, 0, len(*input)+1)
+		record = append(record, ctx.spillSeq)
+		record = append(record, (*input)...)
+		return ctx.spill.Write(fmt.Sprintf("%v", groupByValue), record)
+	}
 	if ctx.spec.GroupByConfig.IsDebug {
 		log.Printf("GroupByTransformationPipe input: groupByValue=%v, currentValue=%v", groupByValue, ctx.currentValue)
 	}
@@ -82,12 +97,184 @@ func (ctx *GroupByTransformationPipe) Apply(input *[]any) error {
 }
 
 func (ctx *GroupByTransformationPipe) Done() error {
+	if ctx.spill != nil {
+		return ctx.sendSpilledBundles()
+	}
 	// Send the last bundle
 	ctx.sendBundle()
 	return nil
 }
 
-func (ctx *GroupByTransformationPipe) Finally() {}
+// sendSpilledBundles sends the groups of the spill partitions in order of first appearance
+// of the group by value, only the records of a single partition are held in memory:
+//   - the records of each partition are grouped and written to a group file, in order of first
+//     appearance, each group is preceded by the sequence nbr of its first record and its size
+//   - the group files are merged on the sequence nbr of the first record of the groups
+func (ctx *GroupByTransformationPipe) sendSpilledBundles() error {
+	groupFiles := make([]string, 0, len(ctx.spill.files))
+	err := ctx.spill.ForEachPartition(func(ipartition int, reader *spillFileReader) error {
+		fileName, err := ctx.writeGroupFile(ipartition, reader)
+		if err != nil {
+			return err
+		}
+		groupFiles = append(groupFiles, fileName)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("while grouping the records of the spill partitions: %v", err)
+	}
+	count, err := ctx.mergeGroupFiles(groupFiles)
+	if err != nil {
+		return fmt.Errorf("while sending bundles from spill partitions: %v", err)
+	}
+	if ctx.spec.GroupByConfig.IsDebug {
+		log.Printf("GroupByTransformationPipe: sent %d bundles from %d spill partitions", count, len(ctx.spill.files))
+	}
+	return nil
+}
+
+// writeGroupFile groups the records of the partition and writes the groups to a group file
+// in the spill directory, returns the name of the group file
+func (ctx *GroupByTransformationPipe) writeGroupFile(ipartition int, reader *spillFileReader) (string, error) {
+	bundlePos := make(map[string]int)
+	bundles := make([][][]any, 0)
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return "", err
+		}
+		values := record[1:]
+		key := fmt.Sprintf("%v", ctx.groupValueOf(&values))
+		ipos, ok := bundlePos[key]
+		if !ok {
+			ipos = len(bundles)
+			bundlePos[key] = ipos
+			bundles = append(bundles, make([][]any, 0))
+		}
+		bundles[ipos] = append(bundles[ipos], record)
+	}
+	writer, err := newSpillFileWriter(ctx.spill.dir, fmt.Sprintf("groups_%d_", ipartition))
+	if err != nil {
+		return "", err
+	}
+	for _, bundle := range bundles {
+		// Group header: sequence nbr of the first record and nbr of records
+		err = writer.Write([]any{bundle[0][0], int64(len(bundle))})
+		for i := 0; err == nil && i < len(bundle); i++ {
+			err = writer.Write(bundle[i][1:])
+		}
+		if err != nil {
+			writer.Close()
+			return "", err
+		}
+	}
+	return writer.FileName(), writer.Close()
+}
+
+// mergeGroupFiles sends the groups of the group files in order of the sequence nbr
+// of their first record, returns the nbr of bundles sent
+func (ctx *GroupByTransformationPipe) mergeGroupFiles(groupFiles []string) (int64, error) {
+	groups := make(groupFileHeap, 0, len(groupFiles))
+	defer func() {
+		for _, g := range groups {
+			g.reader.Close()
+		}
+	}()
+	for _, fileName := range groupFiles {
+		reader, err := newSpillFileReader(fileName)
+		if err != nil {
+			return 0, err
+		}
+		g := &groupFileReader{reader: reader}
+		ok, err := g.nextGroup()
+		if err != nil || !ok {
+			reader.Close()
+			if err != nil {
+				return 0, err
+			}
+			continue
+		}
+		groups = append(groups, g)
+	}
+	heap.Init(&groups)
+	var count int64
+	for len(groups) > 0 {
+		g := groups[0]
+		bundle := make([]any, 0, g.size)
+		for range g.size {
+			record, err := g.reader.Read()
+			if err != nil {
+				return count, fmt.Errorf("while reading group from spill file: %v", err)
+			}
+			bundle = append(bundle, record)
+		}
+		ctx.currentBundle = bundle
+		ctx.sendBundle()
+		count++
+		ok, err := g.nextGroup()
+		if err != nil {
+			return count, err
+		}
+		if ok {
+			heap.Fix(&groups, 0)
+		} else {
+			g.reader.Close()
+			heap.Pop(&groups)
+		}
+	}
+	ctx.currentBundle = nil
+	return count, nil
+}
+
+// groupFileReader reads the groups of a group file, see writeGroupFile
+type groupFileReader struct {
+	reader   *spillFileReader
+	firstSeq int64
+	size     int64
+}
+
+// nextGroup reads the header of the next group, returns false when no more groups
+func (g *groupFileReader) nextGroup() (bool, error) {
+	header, err := g.reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, err
+	}
+	var ok1, ok2 bool
+	g.firstSeq, ok1 = header[0].(int64)
+	g.size, ok2 = header[1].(int64)
+	if !ok1 || !ok2 {
+		return false, fmt.Errorf("error: invalid group header in spill file: %v", header)
+	}
+	return true, nil
+}
+
+// groupFileHeap is a min heap of group files on the sequence nbr of their current group
+type groupFileHeap []*groupFileReader
+
+func (h groupFileHeap) Len() int           { return len(h) }
+func (h groupFileHeap) Less(i, j int) bool { return h[i].firstSeq < h[j].firstSeq }
+func (h groupFileHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *groupFileHeap) Push(x any)        { *h = append(*h, x.(*groupFileReader)) }
+func (h *groupFileHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+func (ctx *GroupByTransformationPipe) Finally() {
+	if ctx.spill != nil {
+		ctx.spill.Remove()
+		ctx.spill = nil
+	}
+}
 
 func (ctx *GroupByTransformationPipe) sendBundle() {
 	// Send the bundle out
@@ -143,7 +330,25 @@ func (ctx *BuilderContext) NewGroupByTransformationPipe(source *InputChannel, ou
 		return nil, fmt.Errorf("error: group_by operator must specify one of: domain_key, group_by_name, group_by_pos, group_by_count")
 	}
 	if config.IsDebug {
-		log.Printf("GroupByTransformationPipe config: group_by_count=%d, group_by_pos=%v (name=%v)", groupByCount, groupByPos, config.GroupByName)
+		log.Printf("GroupByTransformationPipe config: group_by_count=%d, group_by_pos=%v (name=%v), mode=%s", groupByCount, groupByPos, config.GroupByName, config.Mode)
+	}
+
+	// Check if using spill partitions
+	var spill *spillPartitions
+	var err error
+	switch config.Mode {
+	case "", "in_memory":
+	case "spill":
+		if groupByCount > 0 {
+			return nil, fmt.Errorf("error: group_by operator with mode spill does not support group_by_count")
+		}
+		spill, err = newSpillPartitions(ctx.JetStoreTempFolder(), "group_by_spill",
+			spillPartitionsCount(config.SpillPartitions))
+		if err != nil {
+			return nil, fmt.Errorf("while creating spill partitions (group_by operator): %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("error: unknown mode '%s' for group_by operator, expecting in_memory or spill", config.Mode)
 	}
 
 	return &GroupByTransformationPipe{
@@ -152,6 +357,7 @@ func (ctx *BuilderContext) NewGroupByTransformationPipe(source *InputChannel, ou
 		outputCh:      outputCh,
 		groupByCount:  groupByCount,
 		groupByPos:    groupByPos,
+		spill:         spill,
 		currentBundle: make([]any, 0),
 		spec:          spec,
 		env:           ctx.env,
@@ -1,6 +1,8 @@
 package compute_pipes
 
 import (
+	"fmt"
+	"strconv"
 	"sync"
 	"testing"
 )
@@ -95,13 +97,94 @@ func TestGroupByRecords3(t *testing.T) {
 	// t.Error()
 }
 
+func TestGroupByRecordsSpill(t *testing.T) {
+	// Input is not sorted on the group by column
+	inputRecords := []*[]any{
+		{"5", "3", "5", "5"},
+		{"1", "2", "1", "1"},
+		{"5", "3", "2", "2"},
+		{"7", "1", "3", "3"},
+		{"1", "1", "3", "3"},
+		{"5", "1", "4", "4"},
+	}
+	outputRecords, err := doGroupByRecordsSpillTest([]string{"key1"}, nil, 0, "spill", inputRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputRecords) != 3 {
+		t.Fatalf("expecting 3 bundles, got %d", len(outputRecords))
+	}
+	bundleSizes := map[string]int{"5": 3, "1": 2, "7": 1}
+	// Bundles are in order of first appearance of the group by value
+	keys := []string{"5", "1", "7"}
+	for i, bundle := range outputRecords {
+		if bundle[0].([]any)[0] != keys[i] {
+			t.Errorf("expecting bundle %d with key %s, got %v", i, keys[i], bundle)
+		}
+		key := bundle[0].([]any)[0].(string)
+		if len(bundle) != bundleSizes[key] {
+			t.Errorf("expecting %d records for key %s, got %d", bundleSizes[key], key, len(bundle))
+		}
+		// Records of the bundle must be in input order
+		if key == "5" && (bundle[0].([]any)[2] != "5" || bundle[1].([]any)[2] != "2" || bundle[2].([]any)[2] != "4") {
+			t.Errorf("expecting records in input order for key 5, got %v", bundle)
+		}
+	}
+	// Mode spill is not supported with group_by_count
+	_, err = doGroupByRecordsSpillTest(nil, nil, 3, "spill", inputRecords)
+	if err == nil {
+		t.Error("expecting error with mode spill and group_by_count")
+	}
+}
+
+func TestGroupByRecordsSpillOrder(t *testing.T) {
+	// Groups spread over the spill partitions, in order of first appearance: 0, 1, 2, ... 49
+	inputRecords := make([]*[]any, 0, 200)
+	for i := range 200 {
+		key := fmt.Sprintf("k%d", (i/2)%50)
+		inputRecords = append(inputRecords, &[]any{key, "x", strconv.Itoa(i), "y"})
+	}
+	outputRecords, err := doGroupByRecordsSpillTest([]string{"key1"}, nil, 0, "spill", inputRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputRecords) != 50 {
+		t.Fatalf("expecting 50 bundles, got %d", len(outputRecords))
+	}
+	for i, bundle := range outputRecords {
+		key := fmt.Sprintf("k%d", i)
+		if len(bundle) != 4 {
+			t.Errorf("expecting 4 records for key %s, got %d", key, len(bundle))
+		}
+		prevPos := -1
+		for _, r := range bundle {
+			record := r.([]any)
+			if record[0] != key {
+				t.Fatalf("expecting bundle %d with key %s, got %v", i, key, bundle)
+			}
+			pos, _ := strconv.Atoi(record[2].(string))
+			if pos <= prevPos {
+				t.Errorf("expecting records in input order for key %s, got %v", key, bundle)
+			}
+			prevPos = pos
+		}
+	}
+}
+
 func doGroupByRecordsTest( groupByColumn []string, groupByPos []int, groupByCount int, inputRecords []*[]any) (outputRecords [][]any, err error) {
+	return doGroupByRecordsSpillTest(groupByColumn, groupByPos, groupByCount, "", inputRecords)
+}
+
+func doGroupByRecordsSpillTest(groupByColumn []string, groupByPos []int, groupByCount int, mode string,
+	inputRecords []*[]any) (outputRecords [][]any, err error) {
 	spec := &TransformationSpec{
 		Type: "group_by",
 		GroupByConfig: &GroupBySpec{
 			GroupByName: groupByColumn,
 			GroupByPos: groupByPos,
 			GroupByCount: groupByCount,
+			Mode: mode,
+			SpillPartitions: 4,
 		},
 	}
 	columns := &map[string]int{
@@ -129,6 +212,7 @@ func doGroupByRecordsTest( groupByColumn []string, groupByPos []int, groupByCoun
 	if err != nil {
 		return
 	}
+	defer groupByTrsf.Finally()
 	outputRecords = make([][]any, 0, len(inputRecords))
 
 	// Prepare to read the sorted records
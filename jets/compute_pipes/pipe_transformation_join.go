@@ -0,0 +1,350 @@
+package compute_pipes
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Join operator. Equi-join of the main input records with the records of a merge channel.
+// The build side is held in memory in a hash table, it is the smaller side based on the
+// size of the input files unless build_side is specified:
+//   - build side join: the join channel is read in full at the first main
+//     record, each main record is then probed against the hash table.
+//   - build side main: the main records are kept in the hash table and the join channel
+//     is read at Done, the main records without a match (left, anti) and the records
+//     of semi join are sent at the end.
+type JoinTransformationPipe struct {
+	cpConfig        *ComputePipesConfig
+	source          *InputChannel
+	joinSource      *InputChannel
+	outputCh        *OutputChannel
+	joinType        string
+	buildMain       bool
+	mainKeys        []int
+	joinKeys        []int
+	mainColumns     [][2]int
+	joinColumns     [][2]int
+	joinTable       map[string][]*[]any
+	isBuilt         bool
+	mainTable       map[string][]int
+	mainRows        []*[]any
+	matched         []bool
+	outputCount     int64
+	spec            *TransformationSpec
+	env             map[string]any
+	doneCh          chan struct{}
+	mainMergeDoneCh *chan struct{}
+}
+
+// Implementing interface PipeTransformationEvaluator
+func (ctx *JoinTransformationPipe) Apply(input *[]any) error {
+	if input == nil {
+		return fmt.Errorf("error: unexpected null input arg in JoinTransformationPipe")
+	}
+	key, ok := joinKeyOf(ctx.mainKeys, input)
+	if ctx.buildMain {
+		ctx.mainRows = append(ctx.mainRows, input)
+		ctx.matched = append(ctx.matched, false)
+		if ok {
+			ctx.mainTable[key] = append(ctx.mainTable[key], len(ctx.mainRows)-1)
+		}
+		return nil
+	}
+	if !ctx.isBuilt {
+		ctx.buildJoinTable()
+	}
+	var matches []*[]any
+	if ok {
+		matches = ctx.joinTable[key]
+	}
+	switch ctx.joinType {
+	case "inner", "left":
+		if len(matches) == 0 && ctx.joinType == "left" {
+			ctx.sendRow(input, nil)
+		}
+		for _, joinRow := range matches {
+			ctx.sendRow(input, joinRow)
+		}
+	case "semi":
+		if len(matches) > 0 {
+			ctx.sendRow(input, nil)
+		}
+	case "anti":
+		if len(matches) == 0 {
+			ctx.sendRow(input, nil)
+		}
+	}
+	return nil
+}
+
+func (ctx *JoinTransformationPipe) Done() error {
+	// Let the join channel loader know we are done with the join channel
+	defer ctx.mainMergeDone()
+	if !ctx.buildMain {
+		if ctx.spec.JoinConfig.IsDebug {
+			log.Printf("JoinTransformationPipe: %s join sent %d rows, join table has %d keys",
+				ctx.joinType, ctx.outputCount, len(ctx.joinTable))
+		}
+		return nil
+	}
+	// Build side main: probe the main table with the join channel
+	for {
+		joinRow := ctx.readJoinRecord()
+		if joinRow == nil {
+			break
+		}
+		key, ok := joinKeyOf(ctx.joinKeys, joinRow)
+		if !ok {
+			continue
+		}
+		for _, ipos := range ctx.mainTable[key] {
+			ctx.matched[ipos] = true
+			if ctx.joinType == "inner" || ctx.joinType == "left" {
+				ctx.sendRow(ctx.mainRows[ipos], joinRow)
+			}
+		}
+	}
+	// Send the main rows according to their match status
+	for i, mainRow := range ctx.mainRows {
+		switch {
+		case ctx.joinType == "left" && !ctx.matched[i],
+			ctx.joinType == "semi" && ctx.matched[i],
+			ctx.joinType == "anti" && !ctx.matched[i]:
+			ctx.sendRow(mainRow, nil)
+		}
+	}
+	if ctx.spec.JoinConfig.IsDebug {
+		log.Printf("JoinTransformationPipe: %s join sent %d rows, main table has %d rows",
+			ctx.joinType, ctx.outputCount, len(ctx.mainRows))
+	}
+	ctx.mainRows = nil
+	ctx.mainTable = nil
+	return nil
+}
+
+func (ctx *JoinTransformationPipe) Finally() {}
+
+// buildJoinTable reads the join channel in full into the hash table
+func (ctx *JoinTransformationPipe) buildJoinTable() {
+	ctx.isBuilt = true
+	var count int
+	for {
+		joinRow := ctx.readJoinRecord()
+		if joinRow == nil {
+			break
+		}
+		key, ok := joinKeyOf(ctx.joinKeys, joinRow)
+		if ok {
+			ctx.joinTable[key] = append(ctx.joinTable[key], joinRow)
+		}
+		count++
+	}
+	if ctx.spec.JoinConfig.IsDebug {
+		log.Printf("JoinTransformationPipe: join table built with %d rows from channel %s", count, ctx.joinSource.Name)
+	}
+}
+
+func (ctx *JoinTransformationPipe) readJoinRecord() *[]any {
+	select {
+	case joinRow, ok := <-ctx.joinSource.Channel:
+		if !ok {
+			return nil
+		}
+		return &joinRow
+	case <-ctx.doneCh:
+		log.Println("JoinTransformationPipe interrupted while reading join channel")
+		return nil
+	}
+}
+
+// joinBuildMain returns true when the build side is the main channel: buildSide when
+// specified, otherwise the smaller side based on the size of the input files.
+// The build side is the join channel when the sizes are not known.
+func joinBuildMain(buildSide string, mainSize, joinSize int64, sizesKnown bool) (bool, error) {
+	switch buildSide {
+	case "":
+		return sizesKnown && mainSize < joinSize, nil
+	case "join":
+		return false, nil
+	case "main":
+		return true, nil
+	}
+	return false, fmt.Errorf("error: unknown join build_side '%s', expecting join or main", buildSide)
+}
+
+// joinKeyOf returns the join key of the record, returns false when a key column is nil
+func joinKeyOf(keys []int, record *[]any) (string, bool) {
+	var buf strings.Builder
+	for i, pos := range keys {
+		if pos >= len(*record) || (*record)[pos] == nil {
+			return "", false
+		}
+		if i > 0 {
+			buf.WriteByte('|')
+		}
+		fmt.Fprintf(&buf, "%v", (*record)[pos])
+	}
+	return buf.String(), true
+}
+
+// sendRow sends the output row made of the main row and the join row (may be nil)
+func (ctx *JoinTransformationPipe) sendRow(mainRow, joinRow *[]any) {
+	out := make([]any, len(ctx.outputCh.Config.Columns))
+	for _, p := range ctx.mainColumns {
+		if p[0] < len(*mainRow) {
+			out[p[1]] = (*mainRow)[p[0]]
+		}
+	}
+	if joinRow != nil {
+		for _, p := range ctx.joinColumns {
+			if p[0] < len(*joinRow) {
+				out[p[1]] = (*joinRow)[p[0]]
+			}
+		}
+	}
+	select {
+	case ctx.outputCh.Channel <- out:
+		ctx.outputCount++
+	case <-ctx.doneCh:
+		log.Println("JoinTransformationPipe interrupted")
+	}
+}
+
+func (ctx *JoinTransformationPipe) mainMergeDone() {
+	if ctx.mainMergeDoneCh == nil {
+		return
+	}
+	select {
+	case <-*ctx.mainMergeDoneCh:
+	default:
+		close(*ctx.mainMergeDoneCh)
+	}
+}
+
+// joinColumnsOf returns the (input pos, output pos) of the input columns that are in the output channel
+func joinColumnsOf(columns map[string]int, prefix string, outputCh *OutputChannel, assigned map[string]string,
+	sourceName string) ([][2]int, error) {
+	result := make([][2]int, 0, len(columns))
+	for name, ipos := range columns {
+		outName := prefix + name
+		opos, ok := (*outputCh.Columns)[outName]
+		if !ok {
+			continue
+		}
+		if other, ok := assigned[outName]; ok {
+			return nil, fmt.Errorf(
+				"error: output column '%s' is mapped from both %s and %s channels, use main_column_prefix or join_column_prefix (join operator)",
+				outName, other, sourceName)
+		}
+		assigned[outName] = sourceName
+		result = append(result, [2]int{ipos, opos})
+	}
+	return result, nil
+}
+
+// Builder function for JoinTransformationPipe
+func (ctx *BuilderContext) NewJoinTransformationPipe(source *InputChannel, outputCh *OutputChannel, spec *TransformationSpec) (*JoinTransformationPipe, error) {
+	if spec == nil || spec.JoinConfig == nil {
+		return nil, fmt.Errorf("error: Join Pipe Transformation spec is missing join_config element")
+	}
+	config := spec.JoinConfig
+	spec.NewRecord = true
+	joinType := config.Type
+	switch joinType {
+	case "":
+		joinType = "inner"
+	case "inner", "left", "semi", "anti":
+	default:
+		return nil, fmt.Errorf("error: unknown join type '%s', expecting inner, left, semi or anti", config.Type)
+	}
+	if len(config.MainKeys) == 0 || len(config.MainKeys) != len(config.JoinKeys) {
+		return nil, fmt.Errorf("error: join operator must have main_keys and join_keys of the same length")
+	}
+	if source.HasGroupedRows {
+		return nil, fmt.Errorf("error: join operator does not support input channel with grouped rows")
+	}
+
+	// The join channel must be a merge channel of the main input channel
+	var joinChannelConfig *InputChannelConfig
+	var joinChannelPos int
+	inputChannel := &ctx.cpConfig.PipesConfig[0].InputChannel
+	for i := range inputChannel.MergeChannels {
+		if inputChannel.MergeChannels[i].Name == config.JoinChannel {
+			joinChannelConfig = &inputChannel.MergeChannels[i]
+			joinChannelPos = i + 1
+			break
+		}
+	}
+	if joinChannelConfig == nil {
+		return nil, fmt.Errorf("error: join channel '%s' must be one of the merge_channels of input channel '%s'",
+			config.JoinChannel, inputChannel.Name)
+	}
+	if joinChannelConfig.HasGroupedRows {
+		return nil, fmt.Errorf("error: join operator does not support join channel with grouped rows")
+	}
+	joinSource, err := ctx.channelRegistry.GetInputChannel(config.JoinChannel, false)
+	if err != nil {
+		return nil, fmt.Errorf("while getting join channel %s: %v", config.JoinChannel, err)
+	}
+	mainSize, mainKnown := ctx.inputFilesSize(0)
+	joinSize, joinKnown := ctx.inputFilesSize(joinChannelPos)
+	buildMain, err := joinBuildMain(config.BuildSide, mainSize, joinSize, mainKnown && joinKnown)
+	if err != nil {
+		return nil, err
+	}
+
+	mainKeys := make([]int, 0, len(config.MainKeys))
+	for _, c := range config.MainKeys {
+		pos, ok := (*source.Columns)[c]
+		if !ok {
+			return nil, fmt.Errorf("error: join main key '%s' is not a column of %s", c, source.Name)
+		}
+		mainKeys = append(mainKeys, pos)
+	}
+	joinKeys := make([]int, 0, len(config.JoinKeys))
+	for _, c := range config.JoinKeys {
+		pos, ok := (*joinSource.Columns)[c]
+		if !ok {
+			return nil, fmt.Errorf("error: join key '%s' is not a column of join channel %s", c, joinSource.Name)
+		}
+		joinKeys = append(joinKeys, pos)
+	}
+
+	// Map the input columns to the output columns
+	assigned := make(map[string]string)
+	mainColumns, err := joinColumnsOf(*source.Columns, config.MainColumnPrefix, outputCh, assigned, "main")
+	if err != nil {
+		return nil, err
+	}
+	var joinColumns [][2]int
+	if joinType == "inner" || joinType == "left" {
+		joinColumns, err = joinColumnsOf(*joinSource.Columns, config.JoinColumnPrefix, outputCh, assigned, "join")
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.IsDebug {
+		log.Printf("JoinTransformationPipe: %s join of %s with %s on %v = %v, build side main: %v, %d main and %d join output columns",
+			joinType, source.Name, joinSource.Name, config.MainKeys, config.JoinKeys, buildMain, len(mainColumns), len(joinColumns))
+	}
+
+	return &JoinTransformationPipe{
+		cpConfig:        ctx.cpConfig,
+		source:          source,
+		joinSource:      joinSource,
+		outputCh:        outputCh,
+		joinType:        joinType,
+		buildMain:       buildMain,
+		mainKeys:        mainKeys,
+		joinKeys:        joinKeys,
+		mainColumns:     mainColumns,
+		joinColumns:     joinColumns,
+		joinTable:       make(map[string][]*[]any),
+		mainTable:       make(map[string][]int),
+		spec:            spec,
+		env:             ctx.env,
+		doneCh:          ctx.done,
+		mainMergeDoneCh: ctx.mainMergeDone,
+	}, nil
+}
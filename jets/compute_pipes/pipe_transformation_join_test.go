@@ -0,0 +1,203 @@
+package compute_pipes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// This file contains test cases for JoinTransformationPipe
+
+var joinTestMainRecords = []*[]any{
+	{"1", "alice"},
+	{"2", "bob"},
+	{"3", "carol"},
+	{nil, "dan"},
+}
+
+var joinTestJoinRecords = [][]any{
+	{"1", "NY"},
+	{"1", "LA"},
+	{"3", "SF"},
+	{"4", "TX"},
+	{nil, "NJ"},
+}
+
+func TestJoinRecords(t *testing.T) {
+	cases := []struct {
+		joinType string
+		expected []string
+	}{
+		{"inner", []string{"1,alice,LA", "1,alice,NY", "3,carol,SF"}},
+		{"left", []string{"1,alice,LA", "1,alice,NY", "2,bob,<nil>", "3,carol,SF", "<nil>,dan,<nil>"}},
+		{"semi", []string{"1,alice,<nil>", "3,carol,<nil>"}},
+		{"anti", []string{"2,bob,<nil>", "<nil>,dan,<nil>"}},
+	}
+	for _, buildSide := range []string{"", "join", "main"} {
+		for _, c := range cases {
+			config := &JoinSpec{
+				Type:             c.joinType,
+				JoinChannel:      "join_ch",
+				MainKeys:         []string{"id"},
+				JoinKeys:         []string{"id"},
+				BuildSide:        buildSide,
+				JoinColumnPrefix: "j_",
+			}
+			outputRecords, err := doJoinRecordsTest(config, []string{"id", "name", "j_city"}, joinTestMainRecords, joinTestJoinRecords)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(outputRecords, c.expected) {
+				t.Errorf("%s join with build side %s: expecting %v, got %v", c.joinType, buildSide, c.expected, outputRecords)
+			}
+		}
+	}
+}
+
+func TestJoinRecordsInvalidConfig(t *testing.T) {
+	configs := []*JoinSpec{
+		// Column city is in both main and join channels w/o prefix
+		{JoinChannel: "join_ch", MainKeys: []string{"id"}, JoinKeys: []string{"id"}},
+		{JoinChannel: "other_ch", MainKeys: []string{"id"}, JoinKeys: []string{"id"}, JoinColumnPrefix: "j_"},
+		{JoinChannel: "join_ch", MainKeys: []string{"id", "name"}, JoinKeys: []string{"id"}, JoinColumnPrefix: "j_"},
+		{Type: "outer", JoinChannel: "join_ch", MainKeys: []string{"id"}, JoinKeys: []string{"id"}, JoinColumnPrefix: "j_"},
+		{JoinChannel: "join_ch", MainKeys: []string{"id"}, JoinKeys: []string{"id"}, BuildSide: "left", JoinColumnPrefix: "j_"},
+	}
+	for i, config := range configs {
+		_, err := doJoinRecordsTest(config, []string{"id", "name", "j_city"}, nil, nil)
+		if err == nil {
+			t.Errorf("expecting error for config %d", i)
+		}
+	}
+}
+
+func TestJoinBuildMain(t *testing.T) {
+	cases := []struct {
+		buildSide  string
+		mainSize   int64
+		joinSize   int64
+		sizesKnown bool
+		expected   bool
+	}{
+		// The smaller side by default
+		{"", 100, 1000, true, true},
+		{"", 1000, 100, true, false},
+		{"", 100, 100, true, false},
+		// The join channel when the sizes are not known
+		{"", 0, 0, false, false},
+		// build_side overrides the input sizes
+		{"join", 100, 1000, true, false},
+		{"main", 1000, 100, true, true},
+	}
+	for _, c := range cases {
+		buildMain, err := joinBuildMain(c.buildSide, c.mainSize, c.joinSize, c.sizesKnown)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if buildMain != c.expected {
+			t.Errorf("build side '%s' with main size %d and join size %d (known: %v): expecting build main %v, got %v",
+				c.buildSide, c.mainSize, c.joinSize, c.sizesKnown, c.expected, buildMain)
+		}
+	}
+	if _, err := joinBuildMain("left", 0, 0, false); err == nil {
+		t.Error("expecting error for unknown build side")
+	}
+}
+
+// doJoinRecordsTest returns the output records as sorted text rows
+func doJoinRecordsTest(config *JoinSpec, outputColumns []string, mainRecords []*[]any,
+	joinRecords [][]any) (outputRecords []string, err error) {
+	spec := &TransformationSpec{
+		Type:       "join",
+		JoinConfig: config,
+	}
+	toColumnsMap := func(columns []string) *map[string]int {
+		m := make(map[string]int)
+		for i, c := range columns {
+			m[c] = i
+		}
+		return &m
+	}
+	source := &InputChannel{
+		Name:    "input_row",
+		Columns: toColumnsMap([]string{"id", "name"}),
+	}
+	joinCh := make(chan []any)
+	registry := &ChannelRegistry{
+		ComputeChannels: map[string]*Channel{
+			"join_ch": {
+				Name:    "join_ch",
+				Channel: joinCh,
+				Columns: toColumnsMap([]string{"id", "city"}),
+			},
+		},
+	}
+	outCh := make(chan []any)
+	outputCh := &OutputChannel{
+		Name:    "out",
+		Channel: outCh,
+		Columns: toColumnsMap(outputColumns),
+		Config: &ChannelSpec{
+			Name:    "out",
+			Columns: outputColumns,
+		},
+	}
+	mainMergeDone := make(chan struct{})
+	ctx := &BuilderContext{
+		cpConfig: &ComputePipesConfig{
+			PipesConfig: []PipeSpec{{
+				InputChannel: InputChannelConfig{
+					Name:          "input_row",
+					MergeChannels: []InputChannelConfig{{Type: "stage", Name: "join_ch"}},
+				},
+			}},
+		},
+		channelRegistry: registry,
+		mainMergeDone:   &mainMergeDone,
+		done:            make(chan struct{}),
+	}
+
+	var joinTrsf *JoinTransformationPipe
+	joinTrsf, err = ctx.NewJoinTransformationPipe(source, outputCh, spec)
+	if err != nil {
+		return
+	}
+
+	// The join channel loader
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		defer close(joinCh)
+		for _, r := range joinRecords {
+			select {
+			case joinCh <- r:
+			case <-mainMergeDone:
+				return
+			}
+		}
+	})
+	wg.Go(func() {
+		for outRow := range outCh {
+			row := make([]string, 0, len(outRow))
+			for _, v := range outRow {
+				row = append(row, fmt.Sprintf("%v", v))
+			}
+			outputRecords = append(outputRecords, strings.Join(row, ","))
+		}
+	})
+
+	for _, r := range mainRecords {
+		err = joinTrsf.Apply(r)
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = joinTrsf.Done()
+	}
+	close(outCh)
+	wg.Wait()
+	slices.Sort(outputRecords)
+	return
+}
@@ -153,6 +153,8 @@ func (ctx *PartitionWriterTransformationPipe) applyInternal(input *[]any) error
 				fileEx = "parquet"
 			case "fixed_width_writer":
 				fileEx = "fixed_width"
+			case "jsonl_writer":
+				fileEx = "jsonl"
 			}
 			partitionFileName = fmt.Sprintf("part%04d-%07d.%s", ctx.nodeId, ctx.filePartitionNumber, fileEx)
 		}
@@ -208,6 +210,8 @@ func (ctx *PartitionWriterTransformationPipe) applyInternal(input *[]any) error
 				fnc = s3DeviceWriter.WriteParquetPartitionV2
 			case "fixed_width_writer":
 				fnc = s3DeviceWriter.WriteFixedWidthPartition
+			case "jsonl_writer":
+				fnc = s3DeviceWriter.WriteJsonlPartition
 			}
 			s3DeviceWriter.WritePartition(fnc)
 		}()
@@ -377,6 +381,12 @@ func (ctx *BuilderContext) NewPartitionWriterTransformationPipe(source *InputCha
 		default:
 			return nil, fmt.Errorf("error: fixed_width_writer does not support file format '%s'", spec.OutputChannel.Format)
 		}
+	case "jsonl_writer":
+		switch spec.OutputChannel.Format {
+		case "jsonl":
+		default:
+			return nil, fmt.Errorf("error: jsonl_writer does not support file format '%s'", spec.OutputChannel.Format)
+		}
 	}
 
 	// Use the column specified from the output channel, if none are specified, look at the schema provider
@@ -1,18 +1,29 @@
 package compute_pipes
 
 import (
+	"container/heap"
 	"fmt"
+	"io"
 	"log"
+	"os"
 	"slices"
 )
 
 // sort operator.Sort the input records according to a composite key
+// When sort_config.memory_budget_mb is set, the input records are sorted in runs
+// that are spilled to the JetStore temp folder when the budget is reached.
+// The runs are k-way merged at Done().
 type SortTransformationPipe struct {
 	cpConfig     *ComputePipesConfig
 	source       *InputChannel
 	outputCh     *OutputChannel
 	sortBy       []int
 	inputRecords []*[]any
+	memoryBudget int64
+	memorySize   int64
+	tempFolder   string
+	spillDir     string
+	spillFiles   []string
 	spec         *TransformationSpec
 	env          map[string]any
 	doneCh       chan struct{}
@@ -24,34 +35,83 @@ func (ctx *SortTransformationPipe) Apply(input *[]any) error {
 		return fmt.Errorf("error: unexpected null input arg in SortTransformationPipe")
 	}
 	ctx.inputRecords = append(ctx.inputRecords, input)
+	if ctx.memoryBudget > 0 {
+		ctx.memorySize += estimateRecordSize(*input)
+		if ctx.memorySize >= ctx.memoryBudget {
+			return ctx.spillRun()
+		}
+	}
 	return nil
 }
 
-func (ctx *SortTransformationPipe) Done() error {
-	// Sort the input records and send them
-	slices.SortFunc(ctx.inputRecords, func(lhs, rhs *[]any) int {
-		if lhs == nil || rhs == nil {
+// cmpRecords compares the records on the sort key
+func (ctx *SortTransformationPipe) cmpRecords(lhs, rhs *[]any) int {
+	if lhs == nil || rhs == nil {
+		return 0
+	}
+	sz := len(*lhs)
+	r := len(*rhs)
+	if r < sz {
+		sz = r
+	}
+	for _, pos := range ctx.sortBy {
+		if pos >= sz {
 			return 0
 		}
-		sz := len(*lhs)
-		r := len(*rhs)
-		if r < sz {
-			sz = r
+		c := CmpRecord((*lhs)[pos], (*rhs)[pos])
+		switch c {
+		case 0:
+			continue
+		default:
+			return c
 		}
-		for _, pos := range ctx.sortBy {
-			if pos >= sz {
-				return 0
-			}
-			c := CmpRecord((*lhs)[pos], (*rhs)[pos])
-			switch c {
-			case 0:
-				continue
-			default:
-				return c
-			}
+	}
+	return 0
+}
+
+// spillRun sorts the records in memory and writes them to a spill file as a sorted run
+func (ctx *SortTransformationPipe) spillRun() error {
+	var err error
+	if len(ctx.spillDir) == 0 {
+		ctx.spillDir, err = os.MkdirTemp(ctx.tempFolder, "sort_spill")
+		if err != nil {
+			return fmt.Errorf("while creating sort spill directory: %v", err)
 		}
-		return 0
-	})
+	}
+	slices.SortFunc(ctx.inputRecords, ctx.cmpRecords)
+	w, err := newSpillFileWriter(ctx.spillDir, "run")
+	if err != nil {
+		return err
+	}
+	for _, inputRecord := range ctx.inputRecords {
+		err = w.Write(*inputRecord)
+		if err != nil {
+			w.Close()
+			return err
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+	if ctx.spec.SortConfig.IsDebug {
+		log.Printf("SortTransformationPipe: spilled run %d with %d records (~%d bytes) to %s",
+			len(ctx.spillFiles), len(ctx.inputRecords), ctx.memorySize, w.FileName())
+	}
+	ctx.spillFiles = append(ctx.spillFiles, w.FileName())
+	// Release the records
+	clear(ctx.inputRecords)
+	ctx.inputRecords = ctx.inputRecords[:0]
+	ctx.memorySize = 0
+	return nil
+}
+
+func (ctx *SortTransformationPipe) Done() error {
+	if len(ctx.spillFiles) > 0 {
+		return ctx.mergeRuns()
+	}
+	// Sort the input records and send them
+	slices.SortFunc(ctx.inputRecords, ctx.cmpRecords)
 
 	if ctx.spec.SortConfig != nil && ctx.spec.SortConfig.IsDebug {
 		log.Printf("SortTransformationPipe: sorted %d records", len(ctx.inputRecords))
@@ -80,7 +140,131 @@ func (ctx *SortTransformationPipe) Done() error {
 	return nil
 }
 
-func (ctx *SortTransformationPipe) Finally() {}
+// sortRun is a sorted run, either from a spill file or the in-memory records.
+// order is the position of the run in the input, used to break ties.
+type sortRun struct {
+	reader  *spillFileReader
+	records []*[]any
+	current *[]any
+	pos     int
+	order   int
+}
+
+// next advances the run to the next record, returns false when the run is exhausted
+func (run *sortRun) next() (bool, error) {
+	if run.reader == nil {
+		if run.pos >= len(run.records) {
+			return false, nil
+		}
+		run.current = run.records[run.pos]
+		run.pos++
+		return true, nil
+	}
+	record, err := run.reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, err
+	}
+	run.current = &record
+	return true, nil
+}
+
+// sortRunHeap is the priority queue of the k-way merge, ties are broken
+// using the run order to preserve the input order of the runs.
+type sortRunHeap struct {
+	runs []*sortRun
+	cmp  func(lhs, rhs *[]any) int
+}
+
+func (h *sortRunHeap) Len() int { return len(h.runs) }
+func (h *sortRunHeap) Less(i, j int) bool {
+	c := h.cmp(h.runs[i].current, h.runs[j].current)
+	if c == 0 {
+		return h.runs[i].order < h.runs[j].order
+	}
+	return c < 0
+}
+func (h *sortRunHeap) Swap(i, j int) { h.runs[i], h.runs[j] = h.runs[j], h.runs[i] }
+func (h *sortRunHeap) Push(x any)    { h.runs = append(h.runs, x.(*sortRun)) }
+func (h *sortRunHeap) Pop() any {
+	n := len(h.runs)
+	run := h.runs[n-1]
+	h.runs = h.runs[:n-1]
+	return run
+}
+
+// mergeRuns performs the k-way merge of the spilled runs and the remaining in-memory records
+func (ctx *SortTransformationPipe) mergeRuns() error {
+	slices.SortFunc(ctx.inputRecords, ctx.cmpRecords)
+	runs := make([]*sortRun, 0, len(ctx.spillFiles)+1)
+	defer func() {
+		for _, run := range runs {
+			if run.reader != nil {
+				run.reader.Close()
+			}
+		}
+	}()
+	for _, fileName := range ctx.spillFiles {
+		reader, err := newSpillFileReader(fileName)
+		if err != nil {
+			return err
+		}
+		runs = append(runs, &sortRun{reader: reader, order: len(runs)})
+	}
+	if len(ctx.inputRecords) > 0 {
+		runs = append(runs, &sortRun{records: ctx.inputRecords, order: len(runs)})
+	}
+	if ctx.spec.SortConfig.IsDebug {
+		log.Printf("SortTransformationPipe: merging %d sorted runs (%d spilled)", len(runs), len(ctx.spillFiles))
+	}
+	h := &sortRunHeap{runs: make([]*sortRun, 0, len(runs)), cmp: ctx.cmpRecords}
+	for _, run := range runs {
+		ok, err := run.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			h.runs = append(h.runs, run)
+		}
+	}
+	heap.Init(h)
+	var count int64
+	for h.Len() > 0 {
+		run := h.runs[0]
+		select {
+		case ctx.outputCh.Channel <- *run.current:
+		case <-ctx.doneCh:
+			log.Println("SortTransform interrupted")
+			return nil
+		}
+		count++
+		ok, err := run.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	if ctx.spec.SortConfig.IsDebug {
+		log.Printf("SortTransformationPipe: sorted %d records using %d runs", count, len(runs))
+	}
+	return nil
+}
+
+func (ctx *SortTransformationPipe) Finally() {
+	if len(ctx.spillDir) > 0 {
+		err := os.RemoveAll(ctx.spillDir)
+		if err != nil {
+			log.Printf("WARNING while removing sort spill directory: %v", err)
+		}
+		ctx.spillDir = ""
+	}
+}
 
 func (ctx *BuilderContext) NewSortTransformationPipe(source *InputChannel, outputCh *OutputChannel, spec *TransformationSpec) (*SortTransformationPipe, error) {
 	if spec == nil || spec.SortConfig == nil {
@@ -115,9 +299,15 @@ func (ctx *BuilderContext) NewSortTransformationPipe(source *InputChannel, outpu
 		log.Printf("SortTransformationPipe: sort by columns %v at positions %v", config.SortByColumn, sortBy)
 	}
 
+	if config.MemoryBudgetMb < 0 {
+		return nil, fmt.Errorf("error: sort operator memory_budget_mb must be positive")
+	}
+
 	return &SortTransformationPipe{
 		cpConfig:     ctx.cpConfig,
 		inputRecords: make([]*[]any, 0, 2048),
+		memoryBudget: int64(config.MemoryBudgetMb) * 1024 * 1024,
+		tempFolder:   ctx.JetStoreTempFolder(),
 		source:       source,
 		outputCh:     outputCh,
 		sortBy:       sortBy,
@@ -2,6 +2,7 @@ package compute_pipes
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -110,7 +111,43 @@ func TestSortRecords3(t *testing.T) {
 	// t.Error()
 }
 
+func TestSortRecordsSpill(t *testing.T) {
+	SortByColumn := []string{"key1", "key2", "key3"}
+	inputRecords := make([]*[]any, 0, 1000)
+	for i := range 1000 {
+		inputRecords = append(inputRecords, &[]any{
+			fmt.Sprintf("%d", (i*7919)%13),
+			(i * 104729) % 1000,
+			time.Date(2000, time.Month(1+i%12), 1, 0, 0, 0, 0, time.UTC),
+			nil,
+		})
+	}
+	expectedRecords, err := doSortRecordsTest(SortByColumn, inputRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Small memory budget to have several spilled runs
+	outputRecords, err := doSortRecordsSpillTest(SortByColumn, inputRecords, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputRecords) != len(expectedRecords) {
+		t.Fatalf("expecting %d records, got %d", len(expectedRecords), len(outputRecords))
+	}
+	for i := range expectedRecords {
+		if !reflect.DeepEqual(expectedRecords[i][:2], outputRecords[i][:2]) ||
+			!expectedRecords[i][2].(time.Time).Equal(outputRecords[i][2].(time.Time)) ||
+			outputRecords[i][3] != nil {
+			t.Fatalf("record %d: expecting %v, got %v", i, expectedRecords[i], outputRecords[i])
+		}
+	}
+}
+
 func doSortRecordsTest( SortByColumn []string, inputRecords []*[]any) (outputRecords [][]any, err error) {
+	return doSortRecordsSpillTest(SortByColumn, inputRecords, 0)
+}
+
+func doSortRecordsSpillTest(SortByColumn []string, inputRecords []*[]any, memoryBudget int64) (outputRecords [][]any, err error) {
 	spec := &TransformationSpec{
 		Type: "sort",
 		SortConfig: &SortSpec{
@@ -142,6 +179,8 @@ func doSortRecordsTest( SortByColumn []string, inputRecords []*[]any) (outputRec
 	if err != nil {
 		return
 	}
+	sortTrsf.memoryBudget = memoryBudget
+	defer sortTrsf.Finally()
 	outputRecords = make([][]any, 0, len(inputRecords))
 
 	// Prepare to read the sorted records
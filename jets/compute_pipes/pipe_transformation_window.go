@@ -0,0 +1,413 @@
+package compute_pipes
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+)
+
+// window operator. Compute per-row values over the ordered rows of a partition:
+// row_number, rank, dense_rank, lag, lead, first_value, last_value and
+// sum, count, min, max over a frame of rows.
+// When the input has grouped rows, each bundle is processed in Apply and sent as
+// a bundle, otherwise the input records are kept until Done.
+type WindowTransformationPipe struct {
+	cpConfig      *ComputePipesConfig
+	source        *InputChannel
+	outputCh      *OutputChannel
+	partitionBy   []int
+	orderBy       []int
+	descending    []bool
+	frame         WindowFrameSpec
+	functions     []*windowFunction
+	inputColumns  [][2]int
+	inputRecords  []*[]any
+	nbrPartitions int64
+	spec          *TransformationSpec
+	env           map[string]any
+	doneCh        chan struct{}
+}
+
+// windowFunction is a WindowFunctionSpec with the resolved column positions
+type windowFunction struct {
+	fncType      string
+	inputPos     int
+	outputPos    int
+	offset       int
+	defaultValue any
+	cast2RdfType *CastToRdfFnc
+}
+
+// Implementing interface PipeTransformationEvaluator
+func (ctx *WindowTransformationPipe) Apply(input *[]any) error {
+	if input == nil {
+		return fmt.Errorf("error: unexpected null input arg in WindowTransformationPipe")
+	}
+	if !ctx.source.HasGroupedRows {
+		ctx.inputRecords = append(ctx.inputRecords, input)
+		return nil
+	}
+	// input is a bundle of rows, process the bundle and send it as a bundle
+	rows := make([]*[]any, 0, len(*input))
+	for i := range *input {
+		row, ok := (*input)[i].([]any)
+		if !ok {
+			return fmt.Errorf("error: expecting input record of type []any in WindowTransformationPipe.Apply, got %T", (*input)[i])
+		}
+		rows = append(rows, &row)
+	}
+	outRows, err := ctx.processRows(rows)
+	if err != nil {
+		return err
+	}
+	bundle := make([]any, 0, len(outRows))
+	for _, row := range outRows {
+		bundle = append(bundle, row)
+	}
+	if len(bundle) > 0 {
+		select {
+		case ctx.outputCh.Channel <- bundle:
+		case <-ctx.doneCh:
+			log.Println("WindowTransform interrupted")
+		}
+	}
+	return nil
+}
+
+func (ctx *WindowTransformationPipe) Done() error {
+	if ctx.source.HasGroupedRows {
+		return nil
+	}
+	outRows, err := ctx.processRows(ctx.inputRecords)
+	ctx.inputRecords = nil
+	if err != nil {
+		return err
+	}
+	if ctx.spec.WindowConfig.IsDebug {
+		log.Printf("WindowTransformationPipe: sending %d rows from %d partitions", len(outRows), ctx.nbrPartitions)
+	}
+	for _, row := range outRows {
+		select {
+		case ctx.outputCh.Channel <- row:
+		case <-ctx.doneCh:
+			log.Println("WindowTransform interrupted")
+			return nil
+		}
+	}
+	return nil
+}
+
+func (ctx *WindowTransformationPipe) Finally() {}
+
+// processRows splits the rows into partitions, in order of first appearance of
+// the partition key, and returns the output rows partition by partition.
+func (ctx *WindowTransformationPipe) processRows(rows []*[]any) ([][]any, error) {
+	partitionPos := make(map[string]int)
+	partitions := make([][]*[]any, 0)
+	for _, row := range rows {
+		key := ctx.partitionKeyOf(row)
+		ipos, ok := partitionPos[key]
+		if !ok {
+			ipos = len(partitions)
+			partitionPos[key] = ipos
+			partitions = append(partitions, make([]*[]any, 0))
+		}
+		partitions[ipos] = append(partitions[ipos], row)
+	}
+	ctx.nbrPartitions += int64(len(partitions))
+	outRows := make([][]any, 0, len(rows))
+	for _, partition := range partitions {
+		var err error
+		outRows, err = ctx.processPartition(partition, outRows)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return outRows, nil
+}
+
+func (ctx *WindowTransformationPipe) partitionKeyOf(row *[]any) string {
+	if len(ctx.partitionBy) == 0 {
+		return ""
+	}
+	var buf strings.Builder
+	for _, pos := range ctx.partitionBy {
+		if (*row)[pos] != nil {
+			fmt.Fprintf(&buf, "%v", (*row)[pos])
+		}
+		buf.WriteByte('|')
+	}
+	return buf.String()
+}
+
+// cmpOrderBy compares the records on the order by columns
+func (ctx *WindowTransformationPipe) cmpOrderBy(lhs, rhs *[]any) int {
+	for i, pos := range ctx.orderBy {
+		c := CmpRecord((*lhs)[pos], (*rhs)[pos])
+		if c != 0 {
+			if ctx.descending[i] {
+				return -c
+			}
+			return c
+		}
+	}
+	return 0
+}
+
+// frameOf returns the first and last row of the frame of row i in a partition of n rows
+func (ctx *WindowTransformationPipe) frameOf(i, n int) (int, int) {
+	lo, hi := 0, n-1
+	if !ctx.frame.UnboundedStart {
+		lo = max(0, i-ctx.frame.StartOffset)
+	}
+	if !ctx.frame.UnboundedEnd {
+		hi = min(n-1, i+ctx.frame.EndOffset)
+	}
+	return lo, hi
+}
+
+// processPartition sorts the partition and appends the output rows to outRows
+func (ctx *WindowTransformationPipe) processPartition(partition []*[]any, outRows [][]any) ([][]any, error) {
+	if len(ctx.orderBy) > 0 {
+		slices.SortStableFunc(partition, ctx.cmpOrderBy)
+	}
+	n := len(partition)
+	start := len(outRows)
+	for _, row := range partition {
+		out := make([]any, len(ctx.outputCh.Config.Columns))
+		for _, p := range ctx.inputColumns {
+			if p[0] < len(*row) {
+				out[p[1]] = (*row)[p[0]]
+			}
+		}
+		outRows = append(outRows, out)
+	}
+	outPartition := outRows[start:]
+	for _, fnc := range ctx.functions {
+		switch fnc.fncType {
+		case "row_number":
+			for i := range n {
+				outPartition[i][fnc.outputPos] = int64(i + 1)
+			}
+
+		case "rank", "dense_rank":
+			var rank, denseRank int64
+			for i := range n {
+				if i == 0 || ctx.cmpOrderBy(partition[i-1], partition[i]) != 0 {
+					rank = int64(i + 1)
+					denseRank++
+				}
+				if fnc.fncType == "rank" {
+					outPartition[i][fnc.outputPos] = rank
+				} else {
+					outPartition[i][fnc.outputPos] = denseRank
+				}
+			}
+
+		case "lag", "lead":
+			for i := range n {
+				j := i - fnc.offset
+				if fnc.fncType == "lead" {
+					j = i + fnc.offset
+				}
+				if j >= 0 && j < n {
+					outPartition[i][fnc.outputPos] = (*partition[j])[fnc.inputPos]
+				} else {
+					outPartition[i][fnc.outputPos] = fnc.defaultValue
+				}
+			}
+
+		case "first_value", "last_value":
+			for i := range n {
+				lo, hi := ctx.frameOf(i, n)
+				switch {
+				case lo > hi:
+				case fnc.fncType == "first_value":
+					outPartition[i][fnc.outputPos] = (*partition[lo])[fnc.inputPos]
+				default:
+					outPartition[i][fnc.outputPos] = (*partition[hi])[fnc.inputPos]
+				}
+			}
+
+		case "sum", "count", "min", "max":
+			err := ctx.aggregateOverFrame(fnc, partition, outPartition)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return outRows, nil
+}
+
+// aggregateOverFrame computes the aggregate of each row over its frame. When the frame
+// starts at the first row of the partition, the aggregate is computed incrementally.
+func (ctx *WindowTransformationPipe) aggregateOverFrame(fnc *windowFunction, partition []*[]any, outPartition [][]any) error {
+	var err error
+	n := len(partition)
+	var acc any
+	accHi := -1
+	for i := range n {
+		lo, hi := ctx.frameOf(i, n)
+		if !ctx.frame.UnboundedStart {
+			// Recompute the frame
+			acc = nil
+			accHi = lo - 1
+		}
+		for j := accHi + 1; j <= hi; j++ {
+			acc, err = windowAggregate(fnc, acc, partition[j])
+			if err != nil {
+				return fmt.Errorf("while computing window function %s: %v", fnc.fncType, err)
+			}
+		}
+		accHi = max(accHi, hi)
+		if fnc.fncType == "count" && acc == nil {
+			outPartition[i][fnc.outputPos] = int64(0)
+		} else {
+			outPartition[i][fnc.outputPos] = acc
+		}
+	}
+	return nil
+}
+
+// windowAggregate adds the value of row to the aggregate acc
+func windowAggregate(fnc *windowFunction, acc any, row *[]any) (any, error) {
+	var value any
+	if fnc.inputPos >= 0 {
+		value = (*row)[fnc.inputPos]
+	}
+	switch fnc.fncType {
+	case "count":
+		if fnc.inputPos >= 0 && value == nil {
+			return acc, nil
+		}
+		var count int64
+		if acc != nil {
+			count = acc.(int64)
+		}
+		return count + 1, nil
+	case "sum":
+		return add(acc, value, fnc.cast2RdfType)
+	case "min":
+		return minMaxAgg(acc, value, fnc.cast2RdfType, true)
+	case "max":
+		return minMaxAgg(acc, value, fnc.cast2RdfType, false)
+	}
+	return nil, fmt.Errorf("error: unknown aggregate window function %s", fnc.fncType)
+}
+
+// Builder function for WindowTransformationPipe
+func (ctx *BuilderContext) NewWindowTransformationPipe(source *InputChannel, outputCh *OutputChannel, spec *TransformationSpec) (*WindowTransformationPipe, error) {
+	if spec == nil || spec.WindowConfig == nil {
+		return nil, fmt.Errorf("error: Window Pipe Transformation spec is missing window_config element")
+	}
+	config := spec.WindowConfig
+	if len(config.Functions) == 0 {
+		return nil, fmt.Errorf("error: window operator must have at least one function")
+	}
+	spec.NewRecord = true
+
+	partitionBy := make([]int, 0, len(config.PartitionBy))
+	for _, c := range config.PartitionBy {
+		pos, ok := (*source.Columns)[c]
+		if !ok {
+			return nil, fmt.Errorf("error: partition_by column '%s' is not an input column to %s (window operator)", c, source.Name)
+		}
+		partitionBy = append(partitionBy, pos)
+	}
+	orderBy := make([]int, 0, len(config.OrderBy))
+	descending := make([]bool, 0, len(config.OrderBy))
+	for _, o := range config.OrderBy {
+		pos, ok := (*source.Columns)[o.Column]
+		if !ok {
+			return nil, fmt.Errorf("error: order_by column '%s' is not an input column to %s (window operator)", o.Column, source.Name)
+		}
+		orderBy = append(orderBy, pos)
+		descending = append(descending, o.Descending)
+	}
+
+	// Default frame: from the start of the partition to the current row
+	frame := WindowFrameSpec{UnboundedStart: true}
+	if config.Frame != nil {
+		frame = *config.Frame
+		if frame.StartOffset < 0 || frame.EndOffset < 0 {
+			return nil, fmt.Errorf("error: window operator frame offsets must not be negative")
+		}
+	}
+
+	functions := make([]*windowFunction, 0, len(config.Functions))
+	fncColumns := make(map[string]bool)
+	for i := range config.Functions {
+		fspec := &config.Functions[i]
+		outputPos, ok := (*outputCh.Columns)[fspec.Name]
+		if !ok {
+			return nil, fmt.Errorf("error: window function column %s not found in output channel %s", fspec.Name, outputCh.Name)
+		}
+		fncColumns[fspec.Name] = true
+		fnc := &windowFunction{
+			fncType:      fspec.Type,
+			inputPos:     -1,
+			outputPos:    outputPos,
+			offset:       fspec.Offset,
+			defaultValue: fspec.DefaultValue,
+		}
+		switch fspec.Type {
+		case "row_number", "rank", "dense_rank":
+			if fspec.Type != "row_number" && len(orderBy) == 0 {
+				return nil, fmt.Errorf("error: window function %s requires order_by", fspec.Type)
+			}
+		case "lag", "lead", "first_value", "last_value", "sum", "min", "max", "count":
+			if len(fspec.Column) == 0 || fspec.Column == "*" {
+				if fspec.Type == "count" {
+					break
+				}
+				return nil, fmt.Errorf("error: window function %s requires a column", fspec.Type)
+			}
+			fnc.inputPos, ok = (*source.Columns)[fspec.Column]
+			if !ok {
+				return nil, fmt.Errorf("error: window function %s column '%s' is not an input column to %s", fspec.Type, fspec.Column, source.Name)
+			}
+			if fnc.offset < 0 {
+				return nil, fmt.Errorf("error: window function %s offset must not be negative", fspec.Type)
+			}
+			if fnc.offset == 0 {
+				fnc.offset = 1
+			}
+		default:
+			return nil, fmt.Errorf("error: unknown window function type '%s'", fspec.Type)
+		}
+		if fspec.AsRdfType != "" {
+			fnc.cast2RdfType = NewCastToRdfFnc("", fspec.AsRdfType, new(false))
+		}
+		functions = append(functions, fnc)
+	}
+
+	// The input columns that are in the output record
+	inputColumns := make([][2]int, 0, len(*source.Columns))
+	for name, ipos := range *source.Columns {
+		opos, ok := (*outputCh.Columns)[name]
+		if ok && !fncColumns[name] {
+			inputColumns = append(inputColumns, [2]int{ipos, opos})
+		}
+	}
+	if config.IsDebug {
+		log.Printf("WindowTransformationPipe: partition_by %v, order_by %v, frame %+v, %d functions, grouped input: %v",
+			config.PartitionBy, config.OrderBy, frame, len(functions), source.HasGroupedRows)
+	}
+
+	return &WindowTransformationPipe{
+		cpConfig:     ctx.cpConfig,
+		source:       source,
+		outputCh:     outputCh,
+		partitionBy:  partitionBy,
+		orderBy:      orderBy,
+		descending:   descending,
+		frame:        frame,
+		functions:    functions,
+		inputColumns: inputColumns,
+		inputRecords: make([]*[]any, 0, 2048),
+		spec:         spec,
+		env:          ctx.env,
+		doneCh:       ctx.done,
+	}, nil
+}
@@ -0,0 +1,218 @@
+package compute_pipes
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// This file contains test cases for WindowTransformationPipe
+
+var windowTestInputColumns = []string{"key", "seq", "amount"}
+
+func TestWindowRecords1(t *testing.T) {
+	inputRecords := []*[]any{
+		{"A", 3, 30},
+		{"B", 1, 5},
+		{"A", 1, 10},
+		{"A", 2, 10},
+		{"B", 2, 7},
+	}
+	config := &WindowSpec{
+		PartitionBy: []string{"key"},
+		OrderBy:     []WindowOrderBySpec{{Column: "seq"}},
+		Functions: []WindowFunctionSpec{
+			{Name: "row_nbr", Type: "row_number"},
+			{Name: "prev_amount", Type: "lag", Column: "amount", DefaultValue: 0},
+			{Name: "next_amount", Type: "lead", Column: "amount"},
+			{Name: "running_sum", Type: "sum", Column: "amount"},
+		},
+	}
+	outputColumns := []string{"key", "seq", "row_nbr", "prev_amount", "next_amount", "running_sum"}
+	outputRecords, err := doWindowRecordsTest(config, outputColumns, false, inputRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]any{
+		{"A", 1, int64(1), 0, 10, 10},
+		{"A", 2, int64(2), 10, 30, 20},
+		{"A", 3, int64(3), 10, nil, 50},
+		{"B", 1, int64(1), 0, 7, 5},
+		{"B", 2, int64(2), 5, nil, 12},
+	}
+	if !reflect.DeepEqual(outputRecords, expected) {
+		t.Errorf("expecting %v, got %v", expected, outputRecords)
+	}
+}
+
+func TestWindowRecordsRank(t *testing.T) {
+	inputRecords := []*[]any{
+		{"A", 1, 30},
+		{"A", 2, 10},
+		{"A", 3, 20},
+		{"A", 4, 10},
+		{"A", 5, 30},
+	}
+	config := &WindowSpec{
+		OrderBy: []WindowOrderBySpec{{Column: "amount", Descending: true}},
+		Functions: []WindowFunctionSpec{
+			{Name: "rank", Type: "rank"},
+			{Name: "dense_rank", Type: "dense_rank"},
+			{Name: "first", Type: "first_value", Column: "seq"},
+			{Name: "cnt", Type: "count"},
+		},
+	}
+	outputColumns := []string{"seq", "rank", "dense_rank", "first", "cnt"}
+	outputRecords, err := doWindowRecordsTest(config, outputColumns, false, inputRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Rows with the same amount are kept in input order
+	expected := [][]any{
+		{1, int64(1), int64(1), 1, int64(1)},
+		{5, int64(1), int64(1), 1, int64(2)},
+		{3, int64(3), int64(2), 1, int64(3)},
+		{2, int64(4), int64(3), 1, int64(4)},
+		{4, int64(4), int64(3), 1, int64(5)},
+	}
+	if !reflect.DeepEqual(outputRecords, expected) {
+		t.Errorf("expecting %v, got %v", expected, outputRecords)
+	}
+}
+
+func TestWindowRecordsFrame(t *testing.T) {
+	inputRecords := []*[]any{
+		{"A", 1, 1},
+		{"A", 2, 5},
+		{"A", 3, 2},
+		{"A", 4, 8},
+	}
+	// Moving window of the previous, current and next rows
+	config := &WindowSpec{
+		OrderBy: []WindowOrderBySpec{{Column: "seq"}},
+		Frame:   &WindowFrameSpec{StartOffset: 1, EndOffset: 1},
+		Functions: []WindowFunctionSpec{
+			{Name: "sum3", Type: "sum", Column: "amount"},
+			{Name: "max3", Type: "max", Column: "amount"},
+			{Name: "last", Type: "last_value", Column: "amount"},
+		},
+	}
+	outputColumns := []string{"seq", "sum3", "max3", "last"}
+	outputRecords, err := doWindowRecordsTest(config, outputColumns, false, inputRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]any{
+		{1, 6, 5, 5},
+		{2, 8, 5, 2},
+		{3, 15, 8, 8},
+		{4, 10, 8, 8},
+	}
+	if !reflect.DeepEqual(outputRecords, expected) {
+		t.Errorf("expecting %v, got %v", expected, outputRecords)
+	}
+}
+
+func TestWindowRecordsGroupedRows(t *testing.T) {
+	// Each bundle is a partition
+	inputRecords := []*[]any{
+		{[]any{"A", 2, 10}, []any{"A", 1, 20}},
+		{[]any{"B", 1, 5}},
+	}
+	config := &WindowSpec{
+		OrderBy: []WindowOrderBySpec{{Column: "seq"}},
+		Functions: []WindowFunctionSpec{
+			{Name: "row_nbr", Type: "row_number"},
+		},
+	}
+	outputColumns := []string{"key", "amount", "row_nbr"}
+	outputRecords, err := doWindowRecordsTest(config, outputColumns, true, inputRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]any{
+		{[]any{"A", 20, int64(1)}, []any{"A", 10, int64(2)}},
+		{[]any{"B", 5, int64(1)}},
+	}
+	if !reflect.DeepEqual(outputRecords, expected) {
+		t.Errorf("expecting %v, got %v", expected, outputRecords)
+	}
+}
+
+func TestWindowRecordsInvalidConfig(t *testing.T) {
+	configs := []*WindowSpec{
+		{Functions: []WindowFunctionSpec{{Name: "rank", Type: "rank"}}},
+		{Functions: []WindowFunctionSpec{{Name: "rank", Type: "median", Column: "amount"}}},
+		{Functions: []WindowFunctionSpec{{Name: "rank", Type: "lag"}}},
+		{PartitionBy: []string{"unknown"}, Functions: []WindowFunctionSpec{{Name: "rank", Type: "row_number"}}},
+	}
+	for i, config := range configs {
+		_, err := doWindowRecordsTest(config, []string{"rank"}, false, nil)
+		if err == nil {
+			t.Errorf("expecting error for config %d", i)
+		}
+	}
+}
+
+func doWindowRecordsTest(config *WindowSpec, outputColumns []string, hasGroupedRows bool,
+	inputRecords []*[]any) (outputRecords [][]any, err error) {
+	spec := &TransformationSpec{
+		Type:         "window",
+		WindowConfig: config,
+	}
+	inputColumnsMap := make(map[string]int)
+	for i, c := range windowTestInputColumns {
+		inputColumnsMap[c] = i
+	}
+	outputColumnsMap := make(map[string]int)
+	for i, c := range outputColumns {
+		outputColumnsMap[c] = i
+	}
+	source := &InputChannel{
+		Name:           "in",
+		Columns:        &inputColumnsMap,
+		HasGroupedRows: hasGroupedRows,
+	}
+	outCh := make(chan []any)
+	outputCh := &OutputChannel{
+		Name:    "out",
+		Channel: outCh,
+		Columns: &outputColumnsMap,
+		Config: &ChannelSpec{
+			Name:    "out",
+			Columns: outputColumns,
+		},
+	}
+	ctx := &BuilderContext{
+		done: make(chan struct{}),
+	}
+
+	var windowTrsf *WindowTransformationPipe
+	windowTrsf, err = ctx.NewWindowTransformationPipe(source, outputCh, spec)
+	if err != nil {
+		return
+	}
+	outputRecords = make([][]any, 0, len(inputRecords))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for outRow := range outCh {
+			outputRecords = append(outputRecords, outRow)
+		}
+	}()
+
+	for _, r := range inputRecords {
+		err = windowTrsf.Apply(r)
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = windowTrsf.Done()
+	}
+	close(outCh)
+	wg.Wait()
+	return
+}
@@ -187,11 +187,15 @@ type Metric struct {
 }
 
 type LookupSpec struct {
-	// type range: sql_lookup, s3_csv_lookup
+	// type range: sql_lookup, s3_csv_lookup, s3_parquet_lookup, kv_lookup
+	// kv_lookup: lookup table loaded into an on-disk key-value store built once per node,
+	// for tables too large to fit in memory. The csv_source format can be
+	// csv, headerless_csv or parquet.
+	// s3_parquet_lookup: kv_lookup of a parquet file.
 	Key          string            `json:"key"`
 	Type         string            `json:"type"`
 	Query        string            `json:"query,omitempty"`     // for sql_lookup
-	CsvSource    *CsvSourceSpec    `json:"csv_source,omitzero"` //for s3_csv_lookup
+	CsvSource    *CsvSourceSpec    `json:"csv_source,omitzero"` //for s3_csv_lookup, s3_parquet_lookup, kv_lookup
 	Columns      []TableColumnSpec `json:"columns,omitempty"`
 	LookupKey    []string          `json:"lookup_key,omitempty"`
 	LookupValues []string          `json:"lookup_values,omitempty"`
@@ -202,7 +206,7 @@ type CsvSourceSpec struct {
 	// This is a single file source, the first file found is taken.
 	// Type range: cpipes, csv_file (future)
 	// Default values are taken from current pipeline
-	// Format: csv, headerless_csv
+	// Format: csv, headerless_csv, parquet (parquet for lookup tables only)
 	// Compression: none, snappy
 	// MakeEmptyWhenNoFile: Do not make an error when no files
 	// are found, make empty source. Default: generate an error when no files
@@ -305,8 +309,10 @@ type SchemaProviderSpec struct {
 	// Type range: default
 	// Key is schema provider key for reference by compute pipes steps
 	// Format: csv, headerless_csv, fixed_width, parquet, parquet_select,
-	//              xlsx, headerless_xlsx
+	//              xlsx, headerless_xlsx, jsonl, avro, orc
 	// Compression: none, snappy (parquet is always snappy).
+	//   avro and orc files have their own compression (avro codec: null, deflate, snappy;
+	//   orc: none, zlib, snappy), logical types are mapped as for parquet.
 	// DetectEncoding: Detect file encoding (limited) for text file format.
 	// DetectCrAsEol: Detect if \r is used as eol (format: csv,headerless_csv).
 	// DiscardFileHeaders: when true, discard the headers from the input file (typically for csv format),
@@ -394,8 +400,9 @@ type S3CopyFileSpec struct {
 
 type SchemaColumnSpec struct {
 	Name      string `json:"name,omitempty"`
-	Length    int    `json:"length,omitzero"`    // for fixed_width
-	Precision *int   `json:"precision,omitzero"` // for fixed_width
+	Length    int    `json:"length,omitzero"`     // for fixed_width
+	Precision *int   `json:"precision,omitzero"`  // for fixed_width
+	JsonPath  string `json:"json_path,omitempty"` // for jsonl, e.g. claim.lines[*].code
 }
 
 // ChannelSpecName specify the channel spec.
@@ -515,7 +522,8 @@ type SplitterSpec struct {
 
 type TransformationSpec struct {
 	// Type range: map_record, aggregate, analyze, high_freq, partition_writer,
-	// anonymize, distinct, shuffling, group_by, filter, sort, merge, jetrules, clustering
+	// anonymize, distinct, shuffling, group_by, filter, sort, merge, jetrules, clustering,
+	// window, join
 	// Format takes precedence over SchemaProvider's Format (from OutputChannelConfig)
 	Type                  string                           `json:"type"`
 	NewRecord             bool                             `json:"new_record,omitzero"`
@@ -530,9 +538,11 @@ type TransformationSpec struct {
 	GroupByConfig         *GroupBySpec                     `json:"group_by_config,omitzero"`
 	FilterConfig          *FilterSpec                      `json:"filter_config,omitzero"`
 	SortConfig            *SortSpec                        `json:"sort_config,omitzero"`
+	WindowConfig          *WindowSpec                      `json:"window_config,omitzero"`
 	JetrulesConfig        *JetrulesSpec                    `json:"jetrules_config,omitzero"`
 	ClusteringConfig      *ClusteringSpec                  `json:"clustering_config,omitzero"`
 	MergeConfig           *MergeSpec                       `json:"merge_config,omitzero"`
+	JoinConfig            *JoinSpec                        `json:"join_config,omitzero"`
 	OutputChannel         OutputChannelConfig              `json:"output_channel"`
 	ConditionalConfig     []*ConditionalTransformationSpec `json:"conditional_config,omitzero"`
 	When                  *ExpressionNode                  `json:"when,omitzero"`
@@ -645,6 +655,8 @@ type InputChannelConfig struct {
 	// rdf type specified by the domain class of the main input source.
 	// NbrNodesAny and NbrRowsAny are used for Type = "generator" to specify the number
 	// of nodes and rows to generate, they can be int or string (with env var substitution).
+	// MergeChannels are the secondary inputs of the merge and join operators, they have
+	// Type stage or input, for Type input FileKey is relative to the s3 input folder.
 	FileConfig
 	Type                 string               `json:"type"`
 	Name                 string               `json:"name"`
@@ -846,7 +858,7 @@ type HighFreqSpec struct {
 	re            *regexp.Regexp
 }
 
-// DeviceWriterType range: csv_writer, parquet_writer, fixed_width_writer
+// DeviceWriterType range: csv_writer, parquet_writer, fixed_width_writer, jsonl_writer
 // JetsPartitionKey used by partition_writer as the default value for jet_partition
 // use $JETS_PARTITION_LABEL for current node input partition
 // When StreamDataOut is true, data is stream to s3 rather than written locally
@@ -918,8 +930,17 @@ type AnonymizeSpec struct {
 	KeysOutputChannel           *OutputChannelConfig `json:"keys_output_channel"`
 }
 
+// DistinctSpec configuration
+// Mode: in_memory (default) or spill.
+// in_memory: keep all the distinct keys in memory, the records are sent in input order.
+// spill: hash partition the records on the distinct keys into spill_partitions
+// local files (default 64), the distinct records are sent partition by partition
+// at the end, only the keys of a single partition are held in memory.
 type DistinctSpec struct {
-	DistinctOn []string `json:"distinct_on,omitempty"`
+	DistinctOn      []string `json:"distinct_on,omitempty"`
+	Mode            string   `json:"mode,omitempty"`
+	SpillPartitions int      `json:"spill_partitions,omitzero"`
+	IsDebug         bool     `json:"is_debug,omitzero"`
 }
 
 type ShufflingSpec struct {
@@ -946,12 +967,22 @@ type FilterColumnSpec struct {
 // group_by_name wins when both group_by_name and group_by_pos are specified.
 // domain_key use the domain key info to compute the composite key
 // At least one must be specified.
+// Mode: in_memory (default) or spill.
+// in_memory: group consecutive records having the same key, the input is expected
+// to be sorted (or clustered) on the group by key.
+// spill: hash partition the records on the group by key into spill_partitions
+// local files (default 64), the groups are sent at the end in order of first appearance
+// of the group by key and the input does not need to be sorted. The records of a group
+// are in input order.
+// Mode spill does not apply to group_by_count.
 type GroupBySpec struct {
-	GroupByName  []string `json:"group_by_name,omitempty"`
-	GroupByPos   []int    `json:"group_by_pos,omitempty"`
-	GroupByCount int      `json:"group_by_count,omitzero"`
-	DomainKey    string   `json:"domain_key,omitempty"`
-	IsDebug      bool     `json:"is_debug,omitzero"`
+	GroupByName     []string `json:"group_by_name,omitempty"`
+	GroupByPos      []int    `json:"group_by_pos,omitempty"`
+	GroupByCount    int      `json:"group_by_count,omitzero"`
+	DomainKey       string   `json:"domain_key,omitempty"`
+	Mode            string   `json:"mode,omitempty"`
+	SpillPartitions int      `json:"spill_partitions,omitzero"`
+	IsDebug         bool     `json:"is_debug,omitzero"`
 }
 
 type MergeSpec struct {
@@ -960,6 +991,33 @@ type MergeSpec struct {
 	MergeGroupBy []*GroupBySpec `json:"merge_group_by,omitempty"`
 }
 
+// JoinSpec configuration for join transformation
+// Equi-join of the main input channel with join_channel, which must be one of the
+// merge_channels of the main input channel (first pipe input channel).
+// Type range: inner (default), left, semi, anti.
+// left: main records without a match have nil join columns.
+// semi: main records having at least one match, anti: main records without a match,
+// semi and anti output the main columns only.
+// MainKeys and JoinKeys are the columns making the join key of the main and join
+// channel respectively, they must have the same length. Records with a nil key
+// column do not match.
+// BuildSide range: join, main. The records of the build side are held in memory in a
+// hash table. By default the build side is the smaller side based on the size of the
+// input files, the join channel when the sizes are not known. BuildSide overrides it.
+// MainColumnPrefix and JoinColumnPrefix: the input column c of the main (resp. join)
+// channel goes to the output column prefix+c, when the output channel has that column.
+// When the main and join channels have the same column names, use a prefix on one side.
+type JoinSpec struct {
+	Type             string   `json:"type,omitempty"`
+	JoinChannel      string   `json:"join_channel"`
+	MainKeys         []string `json:"main_keys"`
+	JoinKeys         []string `json:"join_keys"`
+	BuildSide        string   `json:"build_side,omitempty"`
+	MainColumnPrefix string   `json:"main_column_prefix,omitempty"`
+	JoinColumnPrefix string   `json:"join_column_prefix,omitempty"`
+	IsDebug          bool     `json:"is_debug,omitzero"`
+}
+
 // Filter row base on:
 //   - when criteria, if provided,
 //   - max output count, if provided.
@@ -975,10 +1033,68 @@ type FilterSpec struct {
 // Sort using composite key
 // sort_by column names making the composite key
 // domain_key use the domain key info to compute the composite key
+// memory_budget_mb when > 0, the input records are sorted in runs of
+// up to memory_budget_mb that are spilled to local disk and merged at the end,
+// otherwise all the records are sorted in memory.
 type SortSpec struct {
-	DomainKey    string   `json:"domain_key,omitempty"`
-	SortByColumn []string `json:"sort_by,omitempty"`
-	IsDebug      bool     `json:"is_debug,omitzero"`
+	DomainKey      string   `json:"domain_key,omitempty"`
+	SortByColumn   []string `json:"sort_by,omitempty"`
+	MemoryBudgetMb int      `json:"memory_budget_mb,omitzero"`
+	IsDebug        bool     `json:"is_debug,omitzero"`
+}
+
+// WindowSpec configuration for window transformation
+// Compute per-row values over the rows of a partition ordered by order_by,
+// the output record has the input columns (by name) and the function columns.
+// partition_by column names making the partition key, all rows in one partition when empty.
+// order_by columns ordering the rows within the partition, the input order is kept
+// for rows with equal order_by values.
+// frame applies to functions first_value, last_value, sum, count, min, max,
+// the default frame is from the first row of the partition to the current row.
+// When the input channel has grouped rows (from group_by), each bundle is processed
+// independently and is sent as a bundle, otherwise all the input records are held
+// in memory until the end of the input.
+type WindowSpec struct {
+	PartitionBy []string             `json:"partition_by,omitempty"`
+	OrderBy     []WindowOrderBySpec  `json:"order_by,omitempty"`
+	Frame       *WindowFrameSpec     `json:"frame,omitzero"`
+	Functions   []WindowFunctionSpec `json:"functions"`
+	IsDebug     bool                 `json:"is_debug,omitzero"`
+}
+
+type WindowOrderBySpec struct {
+	Column     string `json:"column"`
+	Descending bool   `json:"descending,omitzero"`
+}
+
+// WindowFrameSpec specifies the rows of the frame relative to the current row.
+// start_offset: nbr of rows preceding the current row, unbounded_start to start at
+// the first row of the partition.
+// end_offset: nbr of rows following the current row, unbounded_end to end at
+// the last row of the partition.
+type WindowFrameSpec struct {
+	StartOffset    int  `json:"start_offset,omitzero"`
+	UnboundedStart bool `json:"unbounded_start,omitzero"`
+	EndOffset      int  `json:"end_offset,omitzero"`
+	UnboundedEnd   bool `json:"unbounded_end,omitzero"`
+}
+
+// WindowFunctionSpec specifies a window function
+// Name is the output column name.
+// Type range: row_number, rank, dense_rank, lag, lead, first_value, last_value,
+// sum, count, min, max.
+// Column is the input column, required except for row_number, rank, dense_rank
+// and count (count all rows when column is empty).
+// Offset applies to lag and lead, default is 1.
+// DefaultValue applies to lag and lead when the offset row is outside the partition.
+// AsRdfType applies to sum, min, max to cast the input values (e.g. text input).
+type WindowFunctionSpec struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Column       string `json:"column,omitempty"`
+	Offset       int    `json:"offset,omitzero"`
+	DefaultValue any    `json:"default_value,omitempty"`
+	AsRdfType    string `json:"as_rdf_type,omitempty"`
 }
 
 // JetrulesSpec configuration
@@ -992,6 +1108,9 @@ type SortSpec struct {
 // MaxInputCount is the max nbr of input records to process.
 // PoolSize is the nbr of worker pool size.
 // MaxReteSessionsSaved is the max nbr of rete sessions to save in err table.
+// SessionSnapshot when true, the rete sessions saved in err table are also saved as
+// binary snapshots in the stage area, to replay them with rdf.RestoreSession
+// (jetrules go engine only).
 // CurrentSourcePeriod is the source period key to use for this process.
 // CurrentSourcePeriodDate is the source period date  (aka file period date)
 // to use this process.
@@ -1004,23 +1123,42 @@ type SortSpec struct {
 // MaxLooping overrides the value in the jetrules metastore.
 // OutputChannels specify the output channels to write the extracted entities from JetRules
 // ErrorChannel specify the channel to write the errors and exported triples from JetRules processing.
+// Provenance when specified enables the rule firing provenance and exports the derivation
+// tree of the matching inferred triples to the error channel (jetrules go engine only).
+// Profile when true enables the rule execution profiler, the timing and beta row counts
+// per rule and per rete vertex, aggregated over all the rete sessions of the pool, are
+// written to the error channel when the pool completes (jetrules go engine only).
 type JetrulesSpec struct {
-	ProcessName             string                `json:"process_name,omitempty"`
-	UseJetRulesNative       bool                  `json:"use_jet_rules_native,omitzero"`
-	UseJetRulesGo           bool                  `json:"use_jet_rules_go,omitzero"`
-	InputRdfType            string                `json:"input_rdf_type,omitempty"`
-	MaxInputCount           int                   `json:"max_input_count,omitzero"`
-	PoolSize                int                   `json:"pool_size,omitzero"`
-	MaxReteSessionsSaved    int                   `json:"max_rete_sessions_saved,omitzero"`
-	MaxLooping              int                   `json:"max_looping,omitzero"`
-	CurrentSourcePeriod     int                   `json:"current_source_period,omitzero"`
-	CurrentSourcePeriodDate string                `json:"current_source_period_date,omitempty"`
-	CurrentSourcePeriodType string                `json:"current_source_period_type,omitempty"`
-	RuleConfig              []map[string]any      `json:"rule_config,omitempty"`
-	MetadataInputSources    []CsvSourceSpec       `json:"metadata_input_sources,omitempty"`
-	IsDebug                 bool                  `json:"is_debug,omitzero"`
-	OutputChannels          []OutputChannelConfig `json:"output_channels,omitempty"`
-	ErrorChannel            *OutputChannelConfig  `json:"error_channel,omitzero"`
+	ProcessName             string                  `json:"process_name,omitempty"`
+	UseJetRulesNative       bool                    `json:"use_jet_rules_native,omitzero"`
+	UseJetRulesGo           bool                    `json:"use_jet_rules_go,omitzero"`
+	InputRdfType            string                  `json:"input_rdf_type,omitempty"`
+	MaxInputCount           int                     `json:"max_input_count,omitzero"`
+	PoolSize                int                     `json:"pool_size,omitzero"`
+	MaxReteSessionsSaved    int                     `json:"max_rete_sessions_saved,omitzero"`
+	SessionSnapshot         bool                    `json:"session_snapshot,omitzero"`
+	MaxLooping              int                     `json:"max_looping,omitzero"`
+	CurrentSourcePeriod     int                     `json:"current_source_period,omitzero"`
+	CurrentSourcePeriodDate string                  `json:"current_source_period_date,omitempty"`
+	CurrentSourcePeriodType string                  `json:"current_source_period_type,omitempty"`
+	RuleConfig              []map[string]any        `json:"rule_config,omitempty"`
+	MetadataInputSources    []CsvSourceSpec         `json:"metadata_input_sources,omitempty"`
+	IsDebug                 bool                    `json:"is_debug,omitzero"`
+	OutputChannels          []OutputChannelConfig   `json:"output_channels,omitempty"`
+	ErrorChannel            *OutputChannelConfig    `json:"error_channel,omitzero"`
+	Provenance              *JetrulesProvenanceSpec `json:"provenance,omitzero"`
+	Profile                 bool                    `json:"profile,omitzero"`
+}
+
+// JetrulesProvenanceSpec specify the inferred triples to explain, e.g. why does ?claim have hc:status X.
+// Predicate is the predicate of the triples to explain, e.g. hc:status.
+// Object is the optional object value to match, e.g. X, of rdf type ObjectRdfType (default resource).
+// MaxExported is the max nbr of derivation trees exported by each worker, default 10.
+type JetrulesProvenanceSpec struct {
+	Predicate     string `json:"predicate"`
+	Object        string `json:"object,omitempty"`
+	ObjectRdfType string `json:"object_rdf_type,omitempty"`
+	MaxExported   int    `json:"max_exported,omitzero"`
 }
 
 // If is_debug is true, correlation results are forwarded to s3 otherwise
@@ -1166,11 +1304,15 @@ type ExpressionNode struct {
 	// Special case for type: expr_proxy, it indicates that the expression is a proxy
 	// for another expression, the actual expression is specified by one of:
 	// - ExprEnvVarProxy: the expression is specified by an env var, the value of
-	//   the env var is the actual expression as a json string to evaluate.
+	//   the env var is the actual expression as a json string or using the text syntax.
 	// (more to come)
 	// Special case for type: function, it indicates that the expression is a function call,
 	// the actual function is specified by Expr, and the arguments are specified by Farg.
+	// The functions are checked for arity and argument types when the expression is built,
+	// see eval_functions.go for the list of functions.
 	// Default value to use when the evaluation returns error
+	// An ExpressionNode can also be specified as a json string using the text syntax,
+	// e.g. "total_file_size_gb > 10 AND format IN ('csv','parquet')", see eval_expression_parser.go.
 	Name                  string           `json:"name,omitempty"`
 	Type                  string           `json:"type,omitempty"`
 	Expr                  string           `json:"expr,omitempty"`
@@ -141,6 +141,7 @@ type BuilderContext struct {
 	chResults          *ChannelResults
 	env                map[string]any
 	s3DeviceManager    *S3DeviceManager
+	inputFileKeys      [][]*FileKeyInfo
 	nodeId             int
 }
 
@@ -148,6 +149,28 @@ func (ctx *BuilderContext) FileKey() string {
 	return ctx.cpConfig.CommonRuntimeArgs.FileKey
 }
 
+// inputFilesSize returns the total size of the input files of the main input channel (pos 0)
+// or of the merge channel pos-1, returns false when the input files are not known
+func (ctx *BuilderContext) inputFilesSize(pos int) (int64, bool) {
+	if pos >= len(ctx.inputFileKeys) {
+		return 0, false
+	}
+	var size int64
+	for _, fileKey := range ctx.inputFileKeys[pos] {
+		size += int64(fileKey.size)
+	}
+	return size, true
+}
+
+// JetStoreTempFolder returns the local temp folder of the node, it returns
+// an empty string (ie the os temp dir) when not available.
+func (ctx *BuilderContext) JetStoreTempFolder() string {
+	if ctx.s3DeviceManager == nil {
+		return ""
+	}
+	return ctx.s3DeviceManager.JetStoreTempFolder
+}
+
 // Delegate to ExprBuilderContext
 func (ctx *BuilderContext) BuildExprNodeEvaluator(sourceName string, columns map[string]int, spec *ExpressionNode) (evalExpression, error) {
 	if ctx == nil {
@@ -305,6 +328,9 @@ func (ctx *BuilderContext) BuildPipeTransformationEvaluator(source *InputChannel
 	case "merge":
 		return ctx.NewMergeTransformationPipe(source, outCh, spec)
 
+	case "join":
+		return ctx.NewJoinTransformationPipe(source, outCh, spec)
+
 	case "distinct":
 		return ctx.NewDistinctTransformationPipe(source, outCh, spec)
 
@@ -314,6 +340,9 @@ func (ctx *BuilderContext) BuildPipeTransformationEvaluator(source *InputChannel
 	case "sort":
 		return ctx.NewSortTransformationPipe(source, outCh, spec)
 
+	case "window":
+		return ctx.NewWindowTransformationPipe(source, outCh, spec)
+
 	case "jetrules":
 		return ctx.NewJetrulesTransformationPipe(source, outCh, spec)
 
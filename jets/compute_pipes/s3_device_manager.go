@@ -1,18 +1,13 @@
 package compute_pipes
 
 import (
-	"context"
 	"fmt"
 	"log"
 	"sync"
-
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
 // S3DeviceManager manages a pool of S3DeviceWorker to put local files
-// to s3
+// to s3 (or the configured ObjectStore)
 
 // S3DeviceManager manage a pool of workers to put file to s3.
 // ClientWg is a wait group of the partition writers created during
@@ -38,14 +33,6 @@ func (cpCtx *ComputePipesContext) NewS3DeviceManager() error {
 	if cpCtx.CpConfig.ClusterConfig.S3WorkerPoolSize < 1 {
 		return fmt.Errorf("error: S3DeviceManager cannot have s3_worker_pool_size < 1")
 	}
-	// Create the s3 uploader that will be used by all the workers
-	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(regionName))
-	if err != nil {
-		return fmt.Errorf("while loading aws configuration (in NewS3DeviceManager): %v", err)
-	}
-	// Create the uploader
-	s3Uploader := manager.NewUploader(s3.NewFromConfig(cfg))
-
 	// Create the s3 device manager
 	var clientsWg sync.WaitGroup
 	s3DeviceManager := &S3DeviceManager{
@@ -99,7 +86,7 @@ func (cpCtx *ComputePipesContext) NewS3DeviceManager() error {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				worker := NewS3DeviceWorker(s3Uploader, cpCtx.Done, cpCtx.ErrCh)
+				worker := NewS3DeviceWorker(objectStore, cpCtx.Done, cpCtx.ErrCh)
 				worker.DoWork(s3DeviceManager, s3WorkersResultCh)
 			}()
 		}
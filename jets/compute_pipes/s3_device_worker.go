@@ -1,32 +1,25 @@
 package compute_pipes
 
 import (
-	"bufio"
-	"context"
 	"fmt"
 	"log"
 	"os"
-	"time"
-
-	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
 // S3DeviceWorker is the component that actually put a local file to s3
-// The worker gets it's tasks from a channel managed by S3DeviceManager
+// (using the ObjectStore). The worker gets it's tasks from a channel managed by S3DeviceManager
 
 type S3DeviceWorker struct {
-	s3Uploader *manager.Uploader
-	done       chan struct{}
-	errCh      chan error
+	store ObjectStore
+	done  chan struct{}
+	errCh chan error
 }
 
-func NewS3DeviceWorker(s3Uploader *manager.Uploader, done chan struct{}, errCh chan error) *S3DeviceWorker {
+func NewS3DeviceWorker(store ObjectStore, done chan struct{}, errCh chan error) *S3DeviceWorker {
 	return &S3DeviceWorker{
-		s3Uploader: s3Uploader,
-		done:       done,
-		errCh:      errCh,
+		store: store,
+		done:  done,
+		errCh: errCh,
 	}
 }
 
@@ -45,8 +38,6 @@ func (ctx *S3DeviceWorker) DoWork(mgr *S3DeviceManager, resultCh chan ComputePip
 
 func (ctx *S3DeviceWorker) processTask(task *S3Object, _ *S3DeviceManager, resultCh chan ComputePipesResult) error {
 	var cpErr error
-	var putObjInput *s3.PutObjectInput
-	var retry int
 
 	// log.Printf("*** S3DeviceWorker: Put s3 key %s, from local file %s\n",task.FileKey, task.LocalFilePath)
 	// open the local temp file for the writer
@@ -63,24 +54,8 @@ func (ctx *S3DeviceWorker) processTask(task *S3Object, _ *S3DeviceManager, resul
 	if task.ExternalBucket == "" {
 		task.ExternalBucket = bucketName
 	}
-	putObjInput = &s3.PutObjectInput{
-		Bucket: &task.ExternalBucket,
-		Key:    &task.FileKey,
-		Body:   bufio.NewReader(fileHd),
-	}
-	if len(kmsKeyArn) > 0 {
-		putObjInput.ServerSideEncryption = types.ServerSideEncryptionAwsKms
-		putObjInput.SSEKMSKeyId = &kmsKeyArn
-	}
-	retry = 0
-do_retry:
-	_, err = ctx.s3Uploader.Upload(context.TODO(), putObjInput)
+	err = ctx.store.PutObject(task.ExternalBucket, task.FileKey, fileHd)
 	if err != nil {
-		if retry < 6 {
-			time.Sleep(500 * time.Millisecond)
-			retry++
-			goto do_retry
-		}
 		cpErr = fmt.Errorf("while copying file to s3: %v", err)
 		goto gotError
 	}
@@ -2,13 +2,13 @@ package compute_pipes
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"strings"
 
-	"github.com/artisoft-io/jetstore/jets/awsi"
 	"github.com/artisoft-io/jetstore/jets/csv"
 	"github.com/golang/snappy"
 )
@@ -48,7 +48,7 @@ func (ctx *S3DeviceWriter) WritePartition(writer func(w io.Writer)) {
 		}()
 
 		// Write to s3 from pin
-		awsi.UploadToS3FromReader(*ctx.externalBucket, s3FileName, pin)
+		objectStore.PutObject(*ctx.externalBucket, s3FileName, pin)
 
 	} else {
 		// Write the data to a local temp file and then copy it to s3
@@ -300,3 +300,74 @@ gotError:
 	ctx.errCh <- cpErr
 	close(ctx.doneCh)
 }
+
+func (ctx *S3DeviceWriter) WriteJsonlPartition(fout io.Writer) {
+	var err error
+	var cpErr error
+	var snWriter *snappy.Writer
+	var jsonlWriter *bufio.Writer
+	var rootNode *jsonlWriterNode
+	var buf bytes.Buffer
+	var interim io.Writer
+	var outputEncoding string
+	if ctx.schemaProvider != nil {
+		outputEncoding = ctx.schemaProvider.OutputEncoding()
+	}
+
+	// Nested objects are created from the json_path of the schema provider columns
+	rootNode, err = newJsonlWriterNode(ctx.outputCh.Config.Columns, jsonPathsOf(ctx.schemaProvider))
+	if err != nil {
+		cpErr = fmt.Errorf("while preparing jsonl writer: %v", err)
+		goto gotError
+	}
+
+	switch ctx.spec.OutputChannel.Compression {
+	case "none":
+		interim = fout
+	case "snappy":
+		// Open a snappy compressor
+		snWriter = snappy.NewBufferedWriter(fout)
+		interim = snWriter
+	default:
+		cpErr = fmt.Errorf("error: unknown compression %s in WriteJsonlPartition",
+			ctx.spec.OutputChannel.Compression)
+		goto gotError
+	}
+	if len(outputEncoding) != 0 {
+		log.Printf("WriteJsonlPartition: using output encoding from schema provider: %s", outputEncoding)
+	}
+	interim, err = WrapWriterWithEncoder(interim, outputEncoding)
+	if err != nil {
+		cpErr = fmt.Errorf("while wrapping writer with encoder: %v", err)
+		goto gotError
+	}
+	jsonlWriter = bufio.NewWriter(interim)
+
+	// Write the rows into the temp file, one json object per line
+	for inRow := range ctx.source.Channel {
+		buf.Reset()
+		err = rootNode.Write(&buf, inRow)
+		if err != nil {
+			cpErr = fmt.Errorf("while encoding row to jsonl: %v", err)
+			goto gotError
+		}
+		buf.WriteByte('\n')
+		_, err = jsonlWriter.Write(buf.Bytes())
+		if err != nil {
+			cpErr = fmt.Errorf("while writing to local jsonl file: %v", err)
+			goto gotError
+		}
+	}
+
+	jsonlWriter.Flush()
+	if snWriter != nil {
+		snWriter.Flush()
+	}
+
+	// All good!
+	return
+gotError:
+	log.Println(cpErr)
+	ctx.errCh <- cpErr
+	close(ctx.doneCh)
+}
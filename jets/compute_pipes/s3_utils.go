@@ -158,7 +158,7 @@ func (cpipesStartup *CpipesStartup) GetComputePipesPartitions(dbpool *pgxpool.Po
 			} else {
 				prefix = utils.ReplaceEnvVars(fileKey, cpipesStartup.EnvSettings)
 				log.Printf("Downloading file keys from s3 stage folder: %s", prefix)
-				s3Objects, err := awsi.ListS3Objects("", &prefix)
+				s3Objects, err := objectStore.ListObjects("", prefix)
 				if err != nil {
 					return nil, fmt.Errorf("failed to download list of files from s3: %v", err)
 				}
@@ -176,7 +176,7 @@ func (cpipesStartup *CpipesStartup) GetComputePipesPartitions(dbpool *pgxpool.Po
 		} else {
 			prefix = fmt.Sprintf("%s/process_name=%s/session_id=%s/step_id=%s/", awsi.JetStoreStagePrefix(), processName, sessionId, inputChannelConfig.ReadStepId)
 			log.Printf("Downloading file keys from s3 stage folder: %s", prefix)
-			s3Objects, err := awsi.ListS3Objects("", &prefix)
+			s3Objects, err := objectStore.ListObjects("", prefix)
 			if err != nil {
 				return nil, fmt.Errorf("failed to download list of files from s3: %v", err)
 			}
@@ -98,6 +98,8 @@ func (tbl *LookupTableTest) Size() int64 {
 	return int64(len(tbl.rows))
 }
 
+func (tbl *LookupTableTest) Release() {}
+
 func TestLookupTokensState1(t *testing.T) {
 	lookup := &LookupTableTest{
 		rows: map[string]*[]any{
@@ -0,0 +1,360 @@
+package compute_pipes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Utilities for the jsonl (JSON Lines) file format.
+// Each line of the file is a json object, the columns of the channel are mapped to
+// the json fields using the json_path of the schema provider columns:
+//	claim.member.id        nested object field
+//	claim.diagnosis[0]     array element
+//	claim.lines[*].code    explode the array: one row per element of claim.lines
+// The leading "$." of the json path is optional.
+// All the columns with [*] must explode the same array. When the exploded array is
+// empty or missing, a single row is produced with the exploded columns set to null.
+// When a column has no json_path, the column name is used as the field name.
+
+// jsonlPathStep is a step of a json path: a field name, an array index
+// or the array explode marker [*]
+type jsonlPathStep struct {
+	key     string
+	index   int
+	isIndex bool
+	explode bool
+}
+
+type jsonlPath []jsonlPathStep
+
+// parseJsonlPath parses a json path such as $.claim.lines[*].code
+func parseJsonlPath(path string) (jsonlPath, error) {
+	p := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	p = strings.TrimPrefix(p, ".")
+	if len(p) == 0 {
+		return nil, fmt.Errorf("error: empty json path '%s'", path)
+	}
+	result := make(jsonlPath, 0)
+	for segment := range strings.SplitSeq(p, ".") {
+		key, rest, _ := strings.Cut(segment, "[")
+		if len(key) > 0 {
+			result = append(result, jsonlPathStep{key: key})
+		} else if len(rest) == 0 {
+			return nil, fmt.Errorf("error: invalid json path '%s', empty field name", path)
+		}
+		// Array indexes, rest is of the form 0] or 0][*]
+		for len(rest) > 0 {
+			idx, after, ok := strings.Cut(rest, "]")
+			if !ok {
+				return nil, fmt.Errorf("error: invalid json path '%s', missing ']'", path)
+			}
+			if idx == "*" {
+				result = append(result, jsonlPathStep{explode: true})
+			} else {
+				i, err := strconv.Atoi(idx)
+				if err != nil || i < 0 {
+					return nil, fmt.Errorf("error: invalid json path '%s', invalid array index '%s'", path, idx)
+				}
+				result = append(result, jsonlPathStep{index: i, isIndex: true})
+			}
+			switch {
+			case len(after) == 0:
+				rest = ""
+			case after[0] == '[':
+				rest = after[1:]
+			default:
+				return nil, fmt.Errorf("error: invalid json path '%s', unexpected '%s'", path, after)
+			}
+		}
+	}
+	return result, nil
+}
+
+// explodePos returns the position of the [*] step, -1 if none
+func (p jsonlPath) explodePos() int {
+	for i := range p {
+		if p[i].explode {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p jsonlPath) String() string {
+	var buf strings.Builder
+	for i, step := range p {
+		switch {
+		case step.explode:
+			buf.WriteString("[*]")
+		case step.isIndex:
+			fmt.Fprintf(&buf, "[%d]", step.index)
+		default:
+			if i > 0 {
+				buf.WriteByte('.')
+			}
+			buf.WriteString(step.key)
+		}
+	}
+	return buf.String()
+}
+
+// lookup returns the value at path, nil if the path does not exist in doc.
+// The path must not contain the explode marker.
+func (p jsonlPath) lookup(doc any) any {
+	value := doc
+	for _, step := range p {
+		switch {
+		case step.isIndex:
+			arr, ok := value.([]any)
+			if !ok || step.index >= len(arr) {
+				return nil
+			}
+			value = arr[step.index]
+		default:
+			obj, ok := value.(map[string]any)
+			if !ok {
+				return nil
+			}
+			value = obj[step.key]
+		}
+	}
+	return value
+}
+
+// jsonlRowMapper maps a json document into the rows of a channel
+type jsonlRowMapper struct {
+	paths []jsonlPath
+	// explodePath is the path of the exploded array, nil when no column explode an array.
+	// isExploded[i] is true when column i is relative to the element of the exploded array,
+	// in which case paths[i] is the path within the array element.
+	explodePath jsonlPath
+	isExploded  []bool
+}
+
+// newJsonlRowMapper creates the mapper for the columns, jsonPaths is the json_path
+// of the columns (by column name) from the schema provider
+func newJsonlRowMapper(columns []string, jsonPaths map[string]string) (*jsonlRowMapper, error) {
+	mapper := &jsonlRowMapper{
+		paths:      make([]jsonlPath, len(columns)),
+		isExploded: make([]bool, len(columns)),
+	}
+	for i, column := range columns {
+		jsonPath := jsonPaths[column]
+		if len(jsonPath) == 0 {
+			// Use the column name as field name, no parsing so the column name may contain '.'
+			mapper.paths[i] = jsonlPath{{key: column}}
+			continue
+		}
+		path, err := parseJsonlPath(jsonPath)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing json_path of column '%s': %v", column, err)
+		}
+		ipos := path.explodePos()
+		if ipos < 0 {
+			mapper.paths[i] = path
+			continue
+		}
+		if path[ipos+1:].explodePos() >= 0 {
+			return nil, fmt.Errorf("error: json_path '%s' of column '%s' has more than one [*]", jsonPath, column)
+		}
+		if mapper.explodePath == nil {
+			mapper.explodePath = path[:ipos]
+		} else if mapper.explodePath.String() != path[:ipos].String() {
+			return nil, fmt.Errorf(
+				"error: json_path '%s' of column '%s' explodes a different array than '%s', only one array can be exploded",
+				jsonPath, column, mapper.explodePath.String())
+		}
+		mapper.paths[i] = path[ipos+1:]
+		mapper.isExploded[i] = true
+	}
+	return mapper, nil
+}
+
+// Rows returns the rows of the json document, the values are text (or nil).
+// Returns one row per element of the exploded array, at least one row.
+func (m *jsonlRowMapper) Rows(doc any) [][]any {
+	var elements []any
+	if m.explodePath != nil {
+		elements, _ = m.explodePath.lookup(doc).([]any)
+	}
+	nbrRows := max(1, len(elements))
+	rows := make([][]any, 0, nbrRows)
+	for irow := range nbrRows {
+		row := make([]any, len(m.paths))
+		for i, path := range m.paths {
+			if m.isExploded[i] {
+				if irow < len(elements) {
+					row[i] = jsonlValueToTxt(path.lookup(elements[irow]))
+				}
+				continue
+			}
+			row[i] = jsonlValueToTxt(path.lookup(doc))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// jsonlValueToTxt returns the text representation of a json value decoded with UseNumber,
+// objects and arrays are returned as compact json, empty string and null are returned as nil.
+func jsonlValueToTxt(value any) any {
+	switch vv := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if len(vv) == 0 {
+			return nil
+		}
+		return vv
+	case json.Number:
+		return vv.String()
+	case bool:
+		if vv {
+			return "true"
+		}
+		return "false"
+	default:
+		b, err := json.Marshal(vv)
+		if err != nil {
+			return fmt.Sprintf("%v", vv)
+		}
+		return string(b)
+	}
+}
+
+// parseJsonlLine decodes a line of a jsonl file, the line must be a json object
+func parseJsonlLine(line []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	var doc map[string]any
+	err := decoder.Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("error: expecting a json object")
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("error: unexpected data after the json object")
+	}
+	return doc, nil
+}
+
+// jsonlWriterNode is used to write the rows as json objects, the nested objects
+// are created from the json_path of the columns (only field names are supported).
+// The fields are written in the order of the columns.
+type jsonlWriterNode struct {
+	key      string
+	pos      int
+	children []*jsonlWriterNode
+}
+
+// newJsonlWriterNode creates the root node for the columns, jsonPaths is the
+// json_path of the columns (by column name) from the schema provider
+func newJsonlWriterNode(columns []string, jsonPaths map[string]string) (*jsonlWriterNode, error) {
+	root := &jsonlWriterNode{pos: -1}
+	for ipos, column := range columns {
+		path := jsonlPath{{key: column}}
+		if jsonPath := jsonPaths[column]; len(jsonPath) > 0 {
+			var err error
+			path, err = parseJsonlPath(jsonPath)
+			if err != nil {
+				return nil, fmt.Errorf("while parsing json_path of column '%s': %v", column, err)
+			}
+		}
+		node := root
+		for i, step := range path {
+			if step.isIndex || step.explode {
+				return nil, fmt.Errorf(
+					"error: json_path '%s' of column '%s' is not supported for writing jsonl, only field names are supported",
+					jsonPaths[column], column)
+			}
+			var child *jsonlWriterNode
+			for _, c := range node.children {
+				if c.key == step.key {
+					child = c
+					break
+				}
+			}
+			isLeaf := i == len(path)-1
+			switch {
+			case child == nil:
+				child = &jsonlWriterNode{key: step.key, pos: -1}
+				node.children = append(node.children, child)
+			case isLeaf || child.pos >= 0:
+				return nil, fmt.Errorf("error: json_path of column '%s' conflicts with another column for writing jsonl", column)
+			}
+			if isLeaf {
+				child.pos = ipos
+			}
+			node = child
+		}
+	}
+	return root, nil
+}
+
+// Write the row as a json object into buf
+func (n *jsonlWriterNode) Write(buf *bytes.Buffer, row []any) error {
+	if n.pos >= 0 {
+		return writeJsonlValue(buf, row[n.pos])
+	}
+	buf.WriteByte('{')
+	for i, child := range n.children {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(child.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		if err := child.Write(buf, row); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// writeJsonlValue writes the rdf type value as json, numbers and bool are written as
+// json numbers and bool, dates and other types as text.
+func writeJsonlValue(buf *bytes.Buffer, value any) error {
+	var b []byte
+	var err error
+	switch vv := value.(type) {
+	case nil:
+		buf.WriteString("null")
+		return nil
+	case string, bool, int, int32, int64, uint, uint32, uint64:
+		b, err = json.Marshal(vv)
+	case float64, float32:
+		b, err = json.Marshal(vv)
+		if err != nil {
+			// NaN and Inf are not valid json numbers
+			b, err = json.Marshal(encodeRdfTypeToTxt(vv))
+		}
+	default:
+		// time.Time and other types are written as text
+		b, err = json.Marshal(encodeRdfTypeToTxt(vv))
+	}
+	if err != nil {
+		return fmt.Errorf("while encoding value to json: %v", err)
+	}
+	buf.Write(b)
+	return nil
+}
+
+// jsonPathsOf returns the json_path of the schema provider columns, by column name
+func jsonPathsOf(sp SchemaProvider) map[string]string {
+	jsonPaths := make(map[string]string)
+	if sp == nil {
+		return jsonPaths
+	}
+	for _, column := range sp.Columns() {
+		if len(column.JsonPath) > 0 {
+			jsonPaths[column.Name] = column.JsonPath
+		}
+	}
+	return jsonPaths
+}
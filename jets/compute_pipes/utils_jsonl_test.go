@@ -0,0 +1,142 @@
+package compute_pipes
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// This file contains test cases for the jsonl utilities
+
+func TestParseJsonlPath(t *testing.T) {
+	cases := map[string]string{
+		"a":                 "a",
+		"$.a.b":             "a.b",
+		"claim.lines[*].cd": "claim.lines[*].cd",
+		"a[0][2].b":         "a[0][2].b",
+	}
+	for path, expected := range cases {
+		p, err := parseJsonlPath(path)
+		if err != nil {
+			t.Fatalf("parsing %s: %v", path, err)
+		}
+		if p.String() != expected {
+			t.Errorf("parsing %s: expecting %s, got %s", path, expected, p.String())
+		}
+	}
+	for _, path := range []string{"", "$", "a..b", "a[x]", "a[0", "a[0]b"} {
+		_, err := parseJsonlPath(path)
+		if err == nil {
+			t.Errorf("expecting error for path '%s'", path)
+		}
+	}
+}
+
+func TestJsonlRowMapper(t *testing.T) {
+	columns := []string{"id", "member_id", "dx1", "line_code", "line_amt", "flag"}
+	jsonPaths := map[string]string{
+		"member_id": "member.id",
+		"dx1":       "diagnosis[0]",
+		"line_code": "lines[*].code",
+		"line_amt":  "$.lines[*].amount",
+	}
+	mapper, err := newJsonlRowMapper(columns, jsonPaths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := parseJsonlLine([]byte(
+		`{"id":"C1","member":{"id":12},"diagnosis":["A01","B02"],"flag":true,"lines":[{"code":"X","amount":10.5},{"code":"Y"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]any{
+		{"C1", "12", "A01", "X", "10.5", "true"},
+		{"C1", "12", "A01", "Y", nil, "true"},
+	}
+	rows := mapper.Rows(doc)
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expecting %v, got %v", expected, rows)
+	}
+
+	// Missing array produces a single row
+	doc, err = parseJsonlLine([]byte(`{"id":"C2","member":{"id":"M2","name":"x"},"lines":[]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = [][]any{{"C2", "M2", nil, nil, nil, nil}}
+	rows = mapper.Rows(doc)
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expecting %v, got %v", expected, rows)
+	}
+
+	// Nested object as column value
+	mapper, err = newJsonlRowMapper([]string{"member"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows = mapper.Rows(doc)
+	if rows[0][0] != `{"id":"M2","name":"x"}` {
+		t.Errorf("expecting nested object as json, got %v", rows[0][0])
+	}
+
+	// Only one array can be exploded
+	_, err = newJsonlRowMapper([]string{"a", "b"}, map[string]string{"a": "x[*].a", "b": "y[*].b"})
+	if err == nil {
+		t.Errorf("expecting error when exploding two arrays")
+	}
+}
+
+func TestParseJsonlLineInvalid(t *testing.T) {
+	for _, line := range []string{`{"a":1`, `[1,2]`, `"a"`, `null`, `{"a":1} {"b":2}`} {
+		_, err := parseJsonlLine([]byte(line))
+		if err == nil {
+			t.Errorf("expecting error for line %s", line)
+		}
+	}
+}
+
+func TestJsonlWriterNode(t *testing.T) {
+	columns := []string{"id", "member_id", "member_name", "amount", "paid"}
+	jsonPaths := map[string]string{
+		"member_id":   "member.id",
+		"member_name": "member.name",
+	}
+	root, err := newJsonlWriterNode(columns, jsonPaths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	err = root.Write(&buf, []any{"C1", int64(12), nil, 10.5, true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"id":"C1","member":{"id":12,"name":null},"amount":10.5,"paid":true}`
+	if buf.String() != expected {
+		t.Errorf("expecting %s, got %s", expected, buf.String())
+	}
+
+	// Written json can be read back with the same json paths
+	mapper, err := newJsonlRowMapper(columns, jsonPaths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := parseJsonlLine(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := mapper.Rows(doc)
+	expectedRow := []any{"C1", "12", nil, "10.5", "true"}
+	if !reflect.DeepEqual(rows[0], expectedRow) {
+		t.Errorf("expecting %v, got %v", expectedRow, rows[0])
+	}
+
+	// Conflicting and unsupported json paths
+	_, err = newJsonlWriterNode([]string{"a", "b"}, map[string]string{"b": "a.x"})
+	if err == nil {
+		t.Errorf("expecting error for conflicting json paths")
+	}
+	_, err = newJsonlWriterNode([]string{"a"}, map[string]string{"a": "x[0]"})
+	if err == nil {
+		t.Errorf("expecting error for json path with array index")
+	}
+}
@@ -0,0 +1,224 @@
+package compute_pipes
+
+import (
+	"bufio"
+	"encoding/gob"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/golang/snappy"
+)
+
+// Utilities to spill records to local files, used by the operators
+// that need to hold more records than what fits in memory (sort, distinct, group_by).
+// The records are gob encoded and snappy compressed.
+// Supported value types are the basic types (string, int, int64, float64, bool, []byte...),
+// time.Time and nil.
+
+func init() {
+	gob.Register(time.Time{})
+}
+
+type spillFileWriter struct {
+	fileHd   *os.File
+	snWriter *snappy.Writer
+	encoder  *gob.Encoder
+	count    int64
+}
+
+// newSpillFileWriter creates a new spill file in dir, dir must exist.
+func newSpillFileWriter(dir, pattern string) (*spillFileWriter, error) {
+	fileHd, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("while creating spill file: %v", err)
+	}
+	snWriter := snappy.NewBufferedWriter(fileHd)
+	return &spillFileWriter{
+		fileHd:   fileHd,
+		snWriter: snWriter,
+		encoder:  gob.NewEncoder(snWriter),
+	}, nil
+}
+
+func (w *spillFileWriter) FileName() string {
+	return w.fileHd.Name()
+}
+
+func (w *spillFileWriter) Write(record []any) error {
+	err := w.encoder.Encode(record)
+	if err != nil {
+		return fmt.Errorf("while writing record to spill file %s: %v", w.fileHd.Name(), err)
+	}
+	w.count++
+	return nil
+}
+
+// Close flushes and closes the file, the file is not removed.
+func (w *spillFileWriter) Close() error {
+	err := w.snWriter.Close()
+	if err2 := w.fileHd.Close(); err == nil {
+		err = err2
+	}
+	if err != nil {
+		return fmt.Errorf("while closing spill file %s: %v", w.fileHd.Name(), err)
+	}
+	return nil
+}
+
+type spillFileReader struct {
+	fileHd  *os.File
+	decoder *gob.Decoder
+}
+
+func newSpillFileReader(fileName string) (*spillFileReader, error) {
+	fileHd, err := os.Open(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("while opening spill file: %v", err)
+	}
+	return &spillFileReader{
+		fileHd:  fileHd,
+		decoder: gob.NewDecoder(bufio.NewReader(snappy.NewReader(fileHd))),
+	}, nil
+}
+
+// Read returns the next record, returns io.EOF when no more records.
+func (r *spillFileReader) Read() ([]any, error) {
+	var record []any
+	err := r.decoder.Decode(&record)
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, fmt.Errorf("while reading record from spill file %s: %v", r.fileHd.Name(), err)
+	}
+	if record == nil {
+		record = make([]any, 0)
+	}
+	return record, nil
+}
+
+func (r *spillFileReader) Close() error {
+	return r.fileHd.Close()
+}
+
+// estimateRecordSize returns an approximation of the memory used by the record, in bytes.
+func estimateRecordSize(record []any) int64 {
+	// slice header + interface values
+	sz := int64(24 + 16*len(record))
+	for _, v := range record {
+		switch vv := v.(type) {
+		case string:
+			sz += int64(len(vv))
+		case []byte:
+			sz += int64(len(vv) + 24)
+		case time.Time:
+			sz += 24
+		case nil:
+		default:
+			sz += 8
+		}
+	}
+	return sz
+}
+
+// spillPartitionsCount returns the nbr of spill partitions, 64 when not configured
+func spillPartitionsCount(n int) int {
+	if n > 0 {
+		return n
+	}
+	return 64
+}
+
+// spillPartitions hash partitions records into nbrPartitions spill files
+// based on a record key. All the records with the same key are in the same
+// partition, in input order. Used for operators holding high-cardinality keys.
+type spillPartitions struct {
+	dir     string
+	writers []*spillFileWriter
+	files   []string
+}
+
+func newSpillPartitions(tempFolder, pattern string, nbrPartitions int) (*spillPartitions, error) {
+	if nbrPartitions < 1 {
+		return nil, fmt.Errorf("error: spill partitions must have at least 1 partition")
+	}
+	dir, err := os.MkdirTemp(tempFolder, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("while creating spill directory: %v", err)
+	}
+	sp := &spillPartitions{
+		dir:     dir,
+		writers: make([]*spillFileWriter, nbrPartitions),
+		files:   make([]string, nbrPartitions),
+	}
+	for i := range nbrPartitions {
+		sp.writers[i], err = newSpillFileWriter(dir, fmt.Sprintf("partition_%d_", i))
+		if err != nil {
+			sp.Remove()
+			return nil, err
+		}
+		sp.files[i] = sp.writers[i].FileName()
+	}
+	return sp, nil
+}
+
+// Write the record to the partition of key
+func (sp *spillPartitions) Write(key string, record []any) error {
+	return sp.writers[spillPartitionOf(key, len(sp.writers))].Write(record)
+}
+
+// spillPartitionOf returns the partition of key
+func spillPartitionOf(key string, nbrPartitions int) int {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return int(h.Sum64() % uint64(nbrPartitions))
+}
+
+// closeWriters closes the partition files, no more Write allowed
+func (sp *spillPartitions) closeWriters() error {
+	var err error
+	for i, w := range sp.writers {
+		if w != nil {
+			if err2 := w.Close(); err == nil {
+				err = err2
+			}
+			sp.writers[i] = nil
+		}
+	}
+	return err
+}
+
+// ForEachPartition closes the writers and calls fnc with a reader on each partition,
+// the partition file is removed once fnc returns.
+func (sp *spillPartitions) ForEachPartition(fnc func(ipartition int, reader *spillFileReader) error) error {
+	err := sp.closeWriters()
+	if err != nil {
+		return err
+	}
+	for i, fileName := range sp.files {
+		reader, err := newSpillFileReader(fileName)
+		if err != nil {
+			return err
+		}
+		err = fnc(i, reader)
+		reader.Close()
+		os.Remove(fileName)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove the spill files and directory
+func (sp *spillPartitions) Remove() {
+	sp.closeWriters()
+	err := os.RemoveAll(sp.dir)
+	if err != nil {
+		log.Printf("WARNING while removing spill directory: %v", err)
+	}
+}
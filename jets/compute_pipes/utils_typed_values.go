@@ -0,0 +1,135 @@
+package compute_pipes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Utilities for reading typed file formats (avro, orc).
+// The file schema is mapped onto ParquetSchemaInfo using the arrow type names
+// so the columns have the same types as when reading a parquet file:
+//	bool, int32, int64, float32, float64, utf8, binary, date32, timestamp, decimal.
+// The values are converted to text as in ConvertWithSchemaV1, the nested
+// values (record, struct, array, map) are converted to json text.
+
+// typedFileReader is implemented by the avro and orc readers
+type typedFileReader interface {
+	// SchemaInfo returns the schema of the file, one field per top-level column
+	SchemaInfo() *ParquetSchemaInfo
+	// ReadRows calls fnc with the values of columns (field index) of each row
+	// stored in the byte range [start, end) of the file, use end = -1 for the end of the file.
+	// Returns nil when fnc returns errStopReading.
+	ReadRows(start, end int64, columns []int, fnc func(row []any) error) error
+}
+
+var errStopReading = errors.New("stop reading")
+
+// typedDecimal is a decimal value read from a typed file
+type typedDecimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+func (d typedDecimal) String() string {
+	return formatDecimalTxt(d.unscaled, d.scale)
+}
+
+func (d typedDecimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// formatDecimalTxt returns the text of the decimal unscaled * 10^-scale
+func formatDecimalTxt(unscaled *big.Int, scale int32) string {
+	txt := unscaled.String()
+	if scale <= 0 {
+		if scale < 0 && unscaled.Sign() != 0 {
+			txt += strings.Repeat("0", int(-scale))
+		}
+		return txt
+	}
+	sign := ""
+	if strings.HasPrefix(txt, "-") {
+		sign = "-"
+		txt = txt[1:]
+	}
+	if len(txt) <= int(scale) {
+		txt = strings.Repeat("0", int(scale)-len(txt)+1) + txt
+	}
+	ipos := len(txt) - int(scale)
+	return sign + txt[:ipos] + "." + txt[ipos:]
+}
+
+// typedValueToTxt converts the value read from a typed file to text,
+// apply castToRdfTxtFnc when not nil.
+func typedValueToTxt(v any, fieldInfo *FieldInfo, trimStrings bool, castToRdfTxtFnc *CastToRdfTxtFnc) (any, error) {
+	var value string
+	switch vv := v.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		if vv {
+			value = "1"
+		} else {
+			value = "0"
+		}
+	case int32:
+		value = strconv.Itoa(int(vv))
+	case int64:
+		value = strconv.FormatInt(vv, 10)
+	case float32:
+		value = strconv.FormatFloat(float64(vv), 'g', -1, 32)
+	case float64:
+		value = strconv.FormatFloat(vv, 'g', -1, 64)
+	case string:
+		value = vv
+		if trimStrings {
+			value = strings.TrimSpace(value)
+		}
+	case []byte:
+		value = string(vv)
+		if trimStrings {
+			value = strings.TrimSpace(value)
+		}
+	case time.Time:
+		if fieldInfo != nil && fieldInfo.Type == "date32" {
+			value = vv.Format("2006-01-02")
+		} else {
+			value = vv.UTC().Format("2006-01-02T15:04:05.999999999")
+		}
+	case typedDecimal:
+		value = vv.String()
+	default:
+		// nested values
+		b, err := json.Marshal(vv)
+		if err != nil {
+			return nil, fmt.Errorf("while converting nested value to json: %v", err)
+		}
+		value = string(b)
+	}
+	if castToRdfTxtFnc == nil {
+		if len(value) == 0 {
+			return nil, nil
+		}
+		return value, nil
+	}
+	return castToRdfTxtFnc.Cast(value)
+}
+
+// shardByteRange returns the byte range of shard ishard of nbrShards of a file of size fileSize,
+// returns (0, -1) for the full file when nbrShards <= 1.
+func shardByteRange(fileSize int64, ishard, nbrShards int) (int64, int64) {
+	if nbrShards <= 1 {
+		return 0, -1
+	}
+	start := fileSize * int64(ishard) / int64(nbrShards)
+	end := fileSize * int64(ishard+1) / int64(nbrShards)
+	if ishard == nbrShards-1 {
+		end = -1
+	}
+	return start, end
+}
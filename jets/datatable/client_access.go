@@ -0,0 +1,314 @@
+package datatable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/artisoft-io/jetstore/jets/user"
+	"github.com/jackc/pgx/v5"
+)
+
+// This file contains the enforcement of the client access of the users (see user.ClientScope).
+// A user restricted to some clients:
+//   - reads only the rows of its clients: tables with a client column are filtered on client
+//     (and org when the table has one), tables with a session_id column (input and domain
+//     tables) are filtered on the sessions of its clients from session_registry
+//   - can only insert, update and delete rows of its clients
+//   - reads only the rows of its clients with raw queries: the query is wrapped as a subquery
+//     filtered on its client (and org) or session_id column, a raw query reading a table having
+//     a client or session_id column without returning it is rejected
+//   - cannot use the query tool and the actions affecting all clients, e.g. purge data
+
+// clientKeyTable is a table with a client column where rows are updated or deleted by key
+type clientKeyTable struct {
+	table  string
+	hasOrg bool
+}
+
+// clientKeyStmts are the statements updating or deleting rows by key, the client of the
+// existing row must be accessible to the user
+var clientKeyStmts = map[string]clientKeyTable{
+	"update/source_config":   {table: "source_config", hasOrg: true},
+	"delete/source_config":   {table: "source_config", hasOrg: true},
+	"update/process_input":   {table: "process_input", hasOrg: true},
+	"update2/process_input":  {table: "process_input", hasOrg: true},
+	"update/rule_configv2":   {table: "rule_configv2"},
+	"delete/rule_configv2":   {table: "rule_configv2"},
+	"update/pipeline_config": {table: "pipeline_config"},
+	"delete/pipeline_config": {table: "pipeline_config"},
+}
+
+// pipelineConfigKeyColumns are the columns of the statements referencing pipeline_config rows
+// by key, the client of the referenced pipeline configs must be accessible to the user
+var pipelineConfigKeyColumns = map[string][]string{
+	"pipeline_dependency":        {"pipeline_config_key", "depends_on_pipeline_config_key"},
+	"delete/pipeline_dependency": {"pipeline_config_key", "depends_on_pipeline_config_key"},
+}
+
+// getRequestUser returns the user of token for the client access checks
+func (ctx *DataTableContext) getRequestUser(token string) (*user.User, error) {
+	u, err := user.GetUserByToken(ctx.Dbpool, token)
+	if err != nil {
+		log.Printf("while GetUserByToken: %v", err)
+		return nil, errors.New("error: unauthorized, cannot get user info")
+	}
+	return u, nil
+}
+
+// VerifyAllClientsAccess returns a *user.ClientAccessError when the user of token is restricted
+// to some clients, for actions affecting all clients
+func (ctx *DataTableContext) VerifyAllClientsAccess(action, token string) error {
+	u, err := ctx.getRequestUser(token)
+	if err != nil {
+		return err
+	}
+	if u.IsClientRestricted() {
+		return &user.ClientAccessError{UserEmail: u.Email, Action: action}
+	}
+	return nil
+}
+
+// VerifySessionClientAccess returns a *user.ClientAccessError when the user of token does not have
+// access to the client of the pipeline execution with sessionId
+func (ctx *DataTableContext) VerifySessionClientAccess(action, sessionId, token string) error {
+	u, err := ctx.getRequestUser(token)
+	if err != nil {
+		return err
+	}
+	if !u.IsClientRestricted() {
+		return nil
+	}
+	var client string
+	err = ctx.Dbpool.QueryRow(context.Background(),
+		"SELECT client FROM jetsapi.pipeline_execution_status WHERE session_id = $1", sessionId).Scan(&client)
+	if err != nil {
+		return fmt.Errorf("while reading the client of session %s: %v", sessionId, err)
+	}
+	return u.VerifyClientAccess(action, client, "")
+}
+
+// verifyRowsClientAccess verifies the user has access to the client of each row of dataTableAction
+func (ctx *DataTableContext) verifyRowsClientAccess(dataTableAction *DataTableAction, u *user.User) error {
+	if !u.IsClientRestricted() {
+		return nil
+	}
+	table := dataTableAction.FromClauses[0].Table
+	keyTable, byKey := clientKeyStmts[table]
+	for irow := range dataTableAction.Data {
+		row := dataTableAction.Data[irow]
+		if value, hasClient := row["client"]; hasClient {
+			// A null or non-string client is not accessible to the user
+			client, _ := value.(string)
+			org, _ := row["org"].(string)
+			if err := u.VerifyClientAccess(table, client, org); err != nil {
+				return err
+			}
+		}
+		// Check the client of the existing row
+		if byKey && row["key"] != nil {
+			if err := ctx.verifyKeyClientAccess(table, keyTable, row["key"], u); err != nil {
+				return err
+			}
+		}
+		// Check the client of the referenced pipeline configs
+		for _, column := range pipelineConfigKeyColumns[table] {
+			if row[column] == nil {
+				continue
+			}
+			if err := ctx.verifyKeyClientAccess(table, clientKeyTable{table: "pipeline_config"}, row[column], u); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// verifyKeyClientAccess verifies the user has access to the client of the row of keyTable with key,
+// a missing row is not verified
+func (ctx *DataTableContext) verifyKeyClientAccess(action string, keyTable clientKeyTable, key any, u *user.User) error {
+	orgColumn := "''"
+	if keyTable.hasOrg {
+		orgColumn = "org"
+	}
+	var client, org string
+	stmt := fmt.Sprintf("SELECT client, %s FROM %s WHERE key = $1",
+		orgColumn, pgx.Identifier{"jetsapi", keyTable.table}.Sanitize())
+	err := ctx.Dbpool.QueryRow(context.Background(), stmt, key).Scan(&client, &org)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("while reading the client of %s with key %v: %v", keyTable.table, key, err)
+	}
+	return u.VerifyClientAccess(action, client, org)
+}
+
+// restrictToClientScope adds the where conditions restricting the rows of the tables of
+// dataTableAction to the clients of the user
+func (ctx *DataTableContext) restrictToClientScope(dataTableAction *DataTableAction, u *user.User) error {
+	if !u.IsClientRestricted() {
+		return nil
+	}
+	for i := range dataTableAction.FromClauses {
+		fc := &dataTableAction.FromClauses[i]
+		schemaName := fc.Schema
+		if schemaName == "" {
+			// Tables of a WITH clause are restricted by the tables they are joined with
+			if slices.ContainsFunc(dataTableAction.WithClauses, func(wc WithClause) bool { return wc.Name == fc.Table }) {
+				continue
+			}
+			schemaName = "public"
+		}
+		columns, err := ctx.getClientScopeColumns(schemaName, fc.Table)
+		if err != nil {
+			return err
+		}
+		tableRef := fc.Table
+		if fc.AsTable != "" {
+			tableRef = fc.AsTable
+		}
+		condition := clientScopeCondition(u, tableRef, columns)
+		if condition != "" {
+			dataTableAction.clientScopeClauses = append(dataTableAction.clientScopeClauses, condition)
+		}
+	}
+	return nil
+}
+
+// clientScopeCondition returns the sql condition restricting the rows of tableRef to the clients
+// of the user, using the client, org and session_id columns of the table. Returns an empty string
+// when the user is not restricted or the table has none of these columns.
+func clientScopeCondition(u *user.User, tableRef string, columns map[string]bool) string {
+	switch {
+	case !u.IsClientRestricted():
+		return ""
+	case columns["client"] && columns["org"]:
+		return u.ClientScopeSql(pgx.Identifier{tableRef, "client"}.Sanitize(), pgx.Identifier{tableRef, "org"}.Sanitize())
+	case columns["client"]:
+		return u.ClientScopeSql(pgx.Identifier{tableRef, "client"}.Sanitize(), "")
+	case columns["session_id"]:
+		return fmt.Sprintf("%s IN (SELECT session_id FROM jetsapi.session_registry WHERE %s)",
+			pgx.Identifier{tableRef, "session_id"}.Sanitize(), u.ClientScopeSql("client", ""))
+	}
+	return ""
+}
+
+// restrictRawQuery returns the raw query restricted to the clients of the user, see clientScopedRawQuery.
+// The query is returned unchanged when the user is not restricted.
+func (ctx *DataTableContext) restrictRawQuery(action, query string, u *user.User) (string, error) {
+	if !u.IsClientRestricted() {
+		return query, nil
+	}
+	query = strings.TrimRight(strings.TrimSpace(query), "; \t\n")
+	// The columns returned by the query
+	rows, err := ctx.Dbpool.Query(context.Background(), fmt.Sprintf("SELECT * FROM (%s) AS raw_query LIMIT 0", query))
+	if err != nil {
+		return "", fmt.Errorf("while reading the columns of raw query: %v", err)
+	}
+	columns := make(map[string]bool)
+	for _, fd := range rows.FieldDescriptions() {
+		columns[string(fd.Name)] = true
+	}
+	rows.Close()
+	// The tables read by the query, from its plan
+	var plan []byte
+	err = ctx.Dbpool.QueryRow(context.Background(), "EXPLAIN (VERBOSE, FORMAT JSON) "+query).Scan(&plan)
+	if err != nil {
+		return "", fmt.Errorf("while reading the plan of raw query: %v", err)
+	}
+	relations, err := planRelations(plan)
+	if err != nil {
+		return "", err
+	}
+	readsClientTable := false
+	for _, relation := range relations {
+		tableColumns, err := ctx.getClientScopeColumns(relation[0], relation[1])
+		if err != nil {
+			return "", err
+		}
+		if len(tableColumns) > 0 {
+			readsClientTable = true
+			break
+		}
+	}
+	return clientScopedRawQuery(action, query, columns, readsClientTable, u)
+}
+
+// clientScopedRawQuery returns the raw query wrapped as a subquery filtered on the clients of the
+// user, columns are the columns returned by the query. Returns a *user.ClientAccessError when
+// the query reads a table having a client or session_id column (readsClientTable) without
+// returning a column to filter on. The query is always wrapped for a restricted user, so only
+// select statements are accepted.
+func clientScopedRawQuery(action, query string, columns map[string]bool, readsClientTable bool, u *user.User) (string, error) {
+	if !u.IsClientRestricted() {
+		return query, nil
+	}
+	condition := clientScopeCondition(u, "raw_query", columns)
+	if condition == "" {
+		if readsClientTable {
+			return "", &user.ClientAccessError{UserEmail: u.Email, Action: action}
+		}
+		return fmt.Sprintf("SELECT * FROM (%s) AS raw_query", query), nil
+	}
+	return fmt.Sprintf("SELECT * FROM (%s) AS raw_query WHERE %s", query, condition), nil
+}
+
+// planRelations returns the schema and name of the relations of a query plan in json
+// format (EXPLAIN (VERBOSE, FORMAT JSON)), views are expanded to their tables
+func planRelations(plan []byte) ([][2]string, error) {
+	var nodes []map[string]any
+	if err := json.Unmarshal(plan, &nodes); err != nil {
+		return nil, fmt.Errorf("while parsing the plan of raw query: %v", err)
+	}
+	relations := make([][2]string, 0)
+	var visit func(node map[string]any)
+	visit = func(node map[string]any) {
+		if name, ok := node["Relation Name"].(string); ok {
+			schemaName, _ := node["Schema"].(string)
+			relation := [2]string{schemaName, name}
+			if !slices.Contains(relations, relation) {
+				relations = append(relations, relation)
+			}
+		}
+		if root, ok := node["Plan"].(map[string]any); ok {
+			visit(root)
+		}
+		children, _ := node["Plans"].([]any)
+		for _, child := range children {
+			if childNode, ok := child.(map[string]any); ok {
+				visit(childNode)
+			}
+		}
+	}
+	for _, node := range nodes {
+		visit(node)
+	}
+	return relations, nil
+}
+
+// getClientScopeColumns returns the columns of table used to restrict the rows to the
+// clients of the user: client, org and session_id
+func (ctx *DataTableContext) getClientScopeColumns(schemaName, table string) (map[string]bool, error) {
+	columns := make(map[string]bool)
+	rows, err := ctx.Dbpool.Query(context.Background(),
+		`SELECT column_name FROM information_schema.columns
+		 WHERE table_schema = $1 AND table_name = $2 AND column_name IN ('client', 'org', 'session_id')`,
+		schemaName, table)
+	if err != nil {
+		return nil, fmt.Errorf("while reading the columns of table %s.%s: %v", schemaName, table, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var column string
+		if err = rows.Scan(&column); err != nil {
+			return nil, fmt.Errorf("while scanning the columns of table %s.%s: %v", schemaName, table, err)
+		}
+		columns[column] = true
+	}
+	return columns, nil
+}
@@ -0,0 +1,132 @@
+package datatable
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/artisoft-io/jetstore/jets/user"
+)
+
+func newRestrictedUser(scopes ...user.ClientScope) *user.User {
+	u := user.NewUser("user@client.com")
+	u.SetClientScopes(scopes)
+	return u
+}
+
+func TestClientScopeCondition(t *testing.T) {
+	u := newRestrictedUser(user.ClientScope{Client: "ACME", Org: "East"})
+	tests := []struct {
+		columns  map[string]bool
+		expected string
+	}{
+		{map[string]bool{"client": true, "org": true, "session_id": true},
+			`(("t"."client" = 'ACME' AND "t"."org" IN ('East')))`},
+		{map[string]bool{"client": true, "session_id": true}, `("t"."client" = 'ACME')`},
+		{map[string]bool{"session_id": true},
+			`"t"."session_id" IN (SELECT session_id FROM jetsapi.session_registry WHERE (client = 'ACME'))`},
+		{map[string]bool{}, ""},
+	}
+	for _, tt := range tests {
+		if got := clientScopeCondition(u, "t", tt.columns); got != tt.expected {
+			t.Errorf("clientScopeCondition(%v): expected %s, got %s", tt.columns, tt.expected, got)
+		}
+	}
+	if got := clientScopeCondition(user.NewUser("user@client.com"), "t", map[string]bool{"client": true}); got != "" {
+		t.Errorf("Expecting no condition for a user not restricted, got %s", got)
+	}
+}
+
+func TestClientScopedRawQuery(t *testing.T) {
+	u := newRestrictedUser(user.ClientScope{Client: "ACME"})
+	query := "SELECT client FROM jetsapi.client_registry ORDER BY client ASC LIMIT 150"
+
+	// Not restricted: unchanged
+	got, err := clientScopedRawQuery("raw_query", query, map[string]bool{"client": true}, true, user.NewUser("user@client.com"))
+	if err != nil || got != query {
+		t.Errorf("Expecting the query unchanged, got %s, %v", got, err)
+	}
+
+	// Filtered on the client column
+	got, err = clientScopedRawQuery("raw_query", query, map[string]bool{"client": true}, true, u)
+	expected := `SELECT * FROM (` + query + `) AS raw_query WHERE ("raw_query"."client" = 'ACME')`
+	if err != nil || got != expected {
+		t.Errorf("Expecting %s, got %s, %v", expected, got, err)
+	}
+
+	// Not reading a table scoped by client
+	query = "SELECT process_name, key FROM jetsapi.process_config ORDER BY process_name ASC LIMIT 100"
+	got, err = clientScopedRawQuery("raw_query", query, map[string]bool{"process_name": true, "key": true}, false, u)
+	expected = `SELECT * FROM (` + query + `) AS raw_query`
+	if err != nil || got != expected {
+		t.Errorf("Expecting %s, got %s, %v", expected, got, err)
+	}
+
+	// Reading a table scoped by client without returning the client
+	query = "SELECT rete_session_triples FROM jetsapi.process_errors WHERE key = 1"
+	_, err = clientScopedRawQuery("raw_query", query, map[string]bool{"rete_session_triples": true}, true, u)
+	var accessErr *user.ClientAccessError
+	if !errors.As(err, &accessErr) {
+		t.Errorf("Expecting a *user.ClientAccessError, got %v", err)
+	}
+}
+
+func TestPlanRelations(t *testing.T) {
+	plan := []byte(`[{"Plan": {"Node Type": "Hash Join", "Plans": [
+		{"Node Type": "Seq Scan", "Relation Name": "process_errors", "Schema": "jetsapi", "Alias": "pe"},
+		{"Node Type": "Hash", "Plans": [
+			{"Node Type": "Seq Scan", "Relation Name": "session_registry", "Schema": "jetsapi", "Alias": "sr"},
+			{"Node Type": "Seq Scan", "Relation Name": "process_errors", "Schema": "jetsapi", "Alias": "pe2"}
+		]}
+	]}}]`)
+	relations, err := planRelations(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][2]string{{"jetsapi", "process_errors"}, {"jetsapi", "session_registry"}}
+	if !slices.Equal(relations, expected) {
+		t.Errorf("Expecting %v, got %v", expected, relations)
+	}
+	if _, err = planRelations([]byte("QUERY PLAN")); err == nil {
+		t.Error("Expecting an error for an invalid plan")
+	}
+}
+
+// The rows below are verified without reading the database: the tables are not updated by key
+func TestVerifyRowsClientAccess(t *testing.T) {
+	ctx := &DataTableContext{}
+	u := newRestrictedUser(user.ClientScope{Client: "ACME", Org: "East"})
+	action := func(table string, rows ...map[string]any) *DataTableAction {
+		return &DataTableAction{FromClauses: []FromClause{{Table: table}}, Data: rows}
+	}
+	tests := []struct {
+		name    string
+		action  *DataTableAction
+		allowed bool
+	}{
+		{"accessible client", action("source_config", map[string]any{"client": "ACME", "org": "East"}), true},
+		{"accessible client, any org", action("pipeline_config", map[string]any{"client": "ACME"}), true},
+		{"inaccessible org", action("source_config", map[string]any{"client": "ACME", "org": "West"}), false},
+		{"inaccessible client", action("pipeline_config",
+			map[string]any{"client": "ACME"}, map[string]any{"client": "Initech"}), false},
+		{"null client", action("pipeline_config", map[string]any{"client": nil}), false},
+		{"no client column", action("process_config", map[string]any{"process_name": "p"}), true},
+		{"no key", action("update/pipeline_config", map[string]any{"client": "ACME"}), true},
+		{"no pipeline config keys", action("pipeline_dependency", map[string]any{"trigger_condition": OnSuccessTrigger}), true},
+	}
+	for _, tt := range tests {
+		err := ctx.verifyRowsClientAccess(tt.action, u)
+		if tt.allowed && err != nil {
+			t.Errorf("%s: expecting access, got %v", tt.name, err)
+		}
+		var accessErr *user.ClientAccessError
+		if !tt.allowed && !errors.As(err, &accessErr) {
+			t.Errorf("%s: expecting a *user.ClientAccessError, got %v", tt.name, err)
+		}
+	}
+
+	// Not restricted
+	if err := ctx.verifyRowsClientAccess(action("pipeline_config", map[string]any{"client": "Initech"}), user.NewUser("admin")); err != nil {
+		t.Errorf("Expecting access for a user not restricted, got %v", err)
+	}
+}
@@ -80,6 +80,13 @@ type DataTableAction struct {
 	// other non-query properties
 	SkipThrottling bool                     `json:"skipThrottling"`
 	Data           []map[string]interface{} `json:"data"`
+	// Parameterized binds the values and not_in_values of the where clauses as query
+	// arguments rather than sql literals, see visitWhereClause
+	Parameterized bool `json:"-"`
+	// conditions restricting the rows to the clients of the user, see client_access.go
+	clientScopeClauses []string
+	// query arguments of the parameterized where clauses
+	queryArgs []any
 }
 type Column struct {
 	Table        string `json:"table"`
@@ -319,7 +326,10 @@ func parseWcValue(value string) []string {
 	}
 }
 
-func visitWhereClause(buf *strings.Builder, wc *WhereClause) {
+// visitWhereClause writes the condition of wc in buf. When args is not nil, the values
+// and not_in_values are bound as query arguments appended to args, they are compared as is:
+// they are not parsed as a pg array and NULL is not a null value.
+func visitWhereClause(buf *strings.Builder, wc *WhereClause, args *[]any) {
 	if wc.OrWith != nil {
 		buf.WriteString("( ")
 	}
@@ -337,18 +347,37 @@ func visitWhereClause(buf *strings.Builder, wc *WhereClause) {
 		wcValues = wc.Values
 		inOp = " IN ("
 		eqOp = " = '"
-		if len(wc.Values) == 1 {
+		if len(wc.Values) == 1 && args == nil {
 			wcValues = parseWcValue(wc.Values[0])
 		}
 	case len(wc.NotInValues) > 0:
 		inOp = " NOT IN ("
 		eqOp = " != '"
 		wcValues = wc.NotInValues
-		if len(wc.NotInValues) == 1 {
+		if len(wc.NotInValues) == 1 && args == nil {
 			wcValues = parseWcValue(wc.NotInValues[0])
 		}
 	}
 	switch {
+	case args != nil && len(wcValues) > 0:
+		switch {
+		case len(wcValues) > 1:
+			buf.WriteString(inOp)
+		case len(wc.Values) > 0:
+			buf.WriteString(" = ")
+		default:
+			buf.WriteString(" != ")
+		}
+		for j := range wcValues {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			*args = append(*args, wcValues[j])
+			buf.WriteString(fmt.Sprintf("$%d", len(*args)))
+		}
+		if len(wcValues) > 1 {
+			buf.WriteString(") ")
+		}
 	case len(wc.Like) > 0:
 		buf.WriteString(" LIKE ")
 		buf.WriteString("'")
@@ -396,16 +425,21 @@ func visitWhereClause(buf *strings.Builder, wc *WhereClause) {
 	}
 	if wc.OrWith != nil {
 		buf.WriteString(" OR ")
-		visitWhereClause(buf, wc.OrWith)
+		visitWhereClause(buf, wc.OrWith, args)
 		buf.WriteString(" )")
 	}
 }
 
 func (dtq *DataTableAction) makeWhereClause() string {
-	if len(dtq.WhereClauses) == 0 {
+	if len(dtq.WhereClauses) == 0 && len(dtq.clientScopeClauses) == 0 {
 		return ""
 	}
 	var buf strings.Builder
+	var args *[]any
+	if dtq.Parameterized {
+		dtq.queryArgs = make([]any, 0)
+		args = &dtq.queryArgs
+	}
 	buf.WriteString(" WHERE ")
 	isFirst := true
 	for i := range dtq.WhereClauses {
@@ -413,7 +447,15 @@ func (dtq *DataTableAction) makeWhereClause() string {
 			buf.WriteString(" AND ")
 		}
 		isFirst = false
-		visitWhereClause(&buf, &dtq.WhereClauses[i])
+		visitWhereClause(&buf, &dtq.WhereClauses[i], args)
+	}
+	// Restrict the rows to the clients of the user
+	for _, clause := range dtq.clientScopeClauses {
+		if !isFirst {
+			buf.WriteString(" AND ")
+		}
+		isFirst = false
+		buf.WriteString(clause)
 	}
 	return buf.String()
 }
@@ -466,8 +508,27 @@ func (ctx *DataTableContext) VerifyUserPermission(sqlStmt *SqlInsertDefinition,
 // These are queries to load reference data for widget, e.g. dropdown list of items
 func (ctx *DataTableContext) ExecRawQuery(dataTableAction *DataTableAction, token string) (results *map[string]interface{}, httpStatus int, err error) {
 	// fmt.Println("*** ExecRawQuery called, query:",dataTableAction.RawQuery)
+	// Restrict the query to the clients of the user, the query tool is not available
+	// to users restricted to some clients
+	requestUser, err2 := ctx.getRequestUser(token)
+	if err2 != nil {
+		httpStatus = http.StatusUnauthorized
+		err = err2
+		return
+	}
+	if dataTableAction.Action == "raw_query_tool" && requestUser.IsClientRestricted() {
+		httpStatus = http.StatusUnauthorized
+		err = &user.ClientAccessError{UserEmail: requestUser.Email, Action: dataTableAction.Action}
+		return
+	}
+	query, err2 := ctx.restrictRawQuery(dataTableAction.Action, dataTableAction.RawQuery, requestUser)
+	if err2 != nil {
+		httpStatus = rawQueryErrorStatus(err2)
+		err = err2
+		return
+	}
 
-	resultRows, columnDefs, err2 := execQuery(ctx.Dbpool, dataTableAction, &dataTableAction.RawQuery)
+	resultRows, columnDefs, err2 := execQuery(ctx.Dbpool, dataTableAction, &query)
 
 	if err2 != nil {
 		httpStatus = http.StatusInternalServerError
@@ -483,6 +544,19 @@ func (ctx *DataTableContext) ExecRawQuery(dataTableAction *DataTableAction, toke
 	return
 }
 
+// rawQueryErrorStatus returns the http status of the error restricting a raw query
+func rawQueryErrorStatus(err error) int {
+	var accessErr *user.ClientAccessError
+	switch {
+	case errors.As(err, &accessErr):
+		return http.StatusUnauthorized
+	case strings.Contains(err.Error(), "SQLSTATE"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (ctx *DataTableContext) ExecDataManagementStatement(dataTableAction *DataTableAction, token string) (results *map[string]interface{}, httpStatus int, err error) {
 	// fmt.Println("*** ExecDataManagementStatement called, query:",dataTableAction.RawQuery)
 	_, err2 := ctx.VerifyUserPermission(&SqlInsertDefinition{Capability: "workspace_ide"}, token)
@@ -511,10 +585,22 @@ func (ctx *DataTableContext) ExecDataManagementStatement(dataTableAction *DataTa
 // These are queries to load reference data for widget, e.g. dropdown list of items
 func (ctx *DataTableContext) ExecRawQueryMap(dataTableAction *DataTableAction, token string) (results *map[string]interface{}, httpStatus int, err error) {
 	// fmt.Println("ExecRawQueryMap:")
+	// Restrict the queries to the clients of the user
+	requestUser, err := ctx.getRequestUser(token)
+	if err != nil {
+		httpStatus = http.StatusUnauthorized
+		return
+	}
 	resultMap := make(map[string]interface{}, len(dataTableAction.RawQueryMap))
 	for k, v := range dataTableAction.RawQueryMap {
 		// fmt.Println("Query:",v)
-		resultRows, _, err2 := execQuery(ctx.Dbpool, dataTableAction, &v)
+		query, err2 := ctx.restrictRawQuery(dataTableAction.Action, v, requestUser)
+		if err2 != nil {
+			httpStatus = rawQueryErrorStatus(err2)
+			err = err2
+			return
+		}
+		resultRows, _, err2 := execQuery(ctx.Dbpool, dataTableAction, &query)
 		if err2 != nil {
 			if strings.Contains(err2.Error(), "SQLSTATE") {
 				httpStatus = http.StatusBadRequest
@@ -676,13 +762,19 @@ func (ctx *DataTableContext) InsertRows(dataTableAction *DataTableAction, token
 		err = errors.New("error: unknown table")
 		return
 	}
-	_, err2 := ctx.VerifyUserPermission(sqlStmt, token)
+	requestUser, err2 := ctx.VerifyUserPermission(sqlStmt, token)
 	if err2 != nil {
 		httpStatus = http.StatusUnauthorized
 		log.Printf("while VerifyUserPermission: %v", err2)
 		err = errors.New("error: unauthorized, cannot get user info or does not have permission")
 		return
 	}
+	// Check the user has access to the clients of the rows
+	err = ctx.verifyRowsClientAccess(dataTableAction, requestUser)
+	if err != nil {
+		httpStatus = http.StatusUnauthorized
+		return
+	}
 
 	// Check if we delegate to InsertPipelineExecutionStatus
 	switch dataTableAction.FromClauses[0].Table {
@@ -756,6 +848,12 @@ func (ctx *DataTableContext) InsertRows(dataTableAction *DataTableAction, token
 				}
 				dataTableAction.Data[irow]["encrypted_roles"] = encryptedRoles
 			}
+		case dataTableAction.FromClauses[0].Table == "pipeline_dependency":
+			// Validate the trigger condition and that the dependency does not create a cycle
+			httpStatus, err = ctx.validatePipelineDependency(dataTableAction.Data[irow])
+			if err != nil {
+				return
+			}
 		}
 		if !dbUpdateDone {
 			// Proceed at doing the db update
@@ -795,7 +893,7 @@ func execQuery(dbpool *pgxpool.Pool, dataTableAction *DataTableAction, query *st
 	// fmt.Print("\n*** UI Query:\n", *query, "\n\n")
 	resultRows := make([][]interface{}, 0, dataTableAction.Limit)
 	var columnDefs []DataTableColumnDef
-	rows, err := dbpool.Query(context.Background(), *query)
+	rows, err := dbpool.Query(context.Background(), *query, dataTableAction.queryArgs...)
 	if err != nil {
 		log.Printf("While executing dataTable query: %v", err)
 		return nil, nil, err
@@ -960,6 +1058,16 @@ func (ctx *DataTableContext) DoReadAction(dataTableAction *DataTableAction, toke
 	// }
 	// //*
 
+	// Restrict the rows to the clients of the user
+	u, err := ctx.getRequestUser(token)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+	err = ctx.restrictToClientScope(dataTableAction, u)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
 	// Build the query
 	query, stmt := dataTableAction.buildQuery()
 
@@ -995,7 +1103,7 @@ gotRolesPos:
 
 	// get the total nbr of row
 	var totalRowCount int
-	err = ctx.Dbpool.QueryRow(context.Background(), stmt).Scan(&totalRowCount)
+	err = ctx.Dbpool.QueryRow(context.Background(), stmt, dataTableAction.queryArgs...).Scan(&totalRowCount)
 	if err != nil {
 		return nil, http.StatusInternalServerError,
 			fmt.Errorf("while getting total row count from tables %s: %v", dataTableAction.FromClauses[0].Table, err)
@@ -0,0 +1,66 @@
+package datatable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildQuery_Parameterized(t *testing.T) {
+	dtq := &DataTableAction{
+		Action:      "read",
+		Columns:     []Column{{Column: "key"}, {Column: "client"}},
+		FromClauses: []FromClause{{Schema: "jetsapi", Table: "pipeline_config"}},
+		WhereClauses: []WhereClause{
+			{Column: "client", Values: []string{"O'Neil"}},
+			{Column: "process_name", Values: []string{"{a,b}", "NULL"}},
+			{Column: "status", NotInValues: []string{"failed"},
+				OrWith: &WhereClause{Column: "automated", Values: []string{"1"}}},
+		},
+		SortColumn:    "key",
+		SortAscending: true,
+		Limit:         10,
+		Parameterized: true,
+	}
+	query, stmt := dtq.buildQuery()
+	expectedWhere := ` WHERE "client" = $1 AND "process_name" IN ($2, $3)  AND ( "status" != $4 OR "automated" = $5 )`
+	expectedQuery := ` SELECT  "key"::text, "client"::text FROM "jetsapi"."pipeline_config" ` + expectedWhere +
+		` ORDER BY "key" LIMIT 10 OFFSET 0`
+	if query != expectedQuery {
+		t.Errorf("expecting query\n%s\ngot\n%s", expectedQuery, query)
+	}
+	expectedStmt := ` SELECT count(*) FROM "jetsapi"."pipeline_config" ` + expectedWhere
+	if stmt != expectedStmt {
+		t.Errorf("expecting stmt\n%s\ngot\n%s", expectedStmt, stmt)
+	}
+	expectedArgs := []any{"O'Neil", "{a,b}", "NULL", "failed", "1"}
+	if !reflect.DeepEqual(dtq.queryArgs, expectedArgs) {
+		t.Errorf("expecting args %v, got %v", expectedArgs, dtq.queryArgs)
+	}
+
+	// The arguments are not accumulated when the query is built again
+	dtq.buildQuery()
+	if !reflect.DeepEqual(dtq.queryArgs, expectedArgs) {
+		t.Errorf("expecting args %v after rebuilding the query, got %v", expectedArgs, dtq.queryArgs)
+	}
+}
+
+func TestBuildQuery_Literals(t *testing.T) {
+	dtq := &DataTableAction{
+		Action:      "read",
+		Columns:     []Column{{Column: "key"}},
+		FromClauses: []FromClause{{Schema: "jetsapi", Table: "pipeline_config"}},
+		WhereClauses: []WhereClause{
+			{Column: "client", Values: []string{"{a,b}"}},
+			{Column: "process_name", Values: []string{"NULL"}},
+		},
+		Limit: 10,
+	}
+	query, _ := dtq.buildQuery()
+	expected := ` SELECT  "key"::text FROM "jetsapi"."pipeline_config"  WHERE "client" IN ('a', 'b')  AND "process_name" is NULL  LIMIT 10 OFFSET 0`
+	if query != expected {
+		t.Errorf("expecting query\n%s\ngot\n%s", expected, query)
+	}
+	if len(dtq.queryArgs) != 0 {
+		t.Errorf("expecting no query arguments, got %v", dtq.queryArgs)
+	}
+}
@@ -0,0 +1,520 @@
+package datatable
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Pipeline dependencies: triggers between pipeline_config entries, in table jetsapi.pipeline_dependency.
+// The pipeline pipeline_config_key depends on the pipeline depends_on_pipeline_config_key,
+// for the same client and source period, with trigger_condition:
+//   - on_success: the upstream pipeline completed, with status completed, errors or recovered
+//   - on_failure: the upstream pipeline failed, with status failed or interrupted
+// A pipeline with several dependencies (fan-in) is started when all of them are satisfied
+// by the latest execution of each upstream pipeline for the source period.
+// The pipelines with dependencies are not started when their inputs are registered,
+// they are started by StartDependentPipelines when their upstream pipelines complete.
+
+const (
+	OnSuccessTrigger = "on_success"
+	OnFailureTrigger = "on_failure"
+)
+
+var successStatuses = []string{"completed", "errors", "recovered"}
+var failureStatuses = []string{"failed", "interrupted"}
+
+// isTriggerSatisfied returns true when status of the upstream pipeline satisfies the trigger condition
+func isTriggerSatisfied(condition, status string) bool {
+	switch condition {
+	case OnSuccessTrigger:
+		return slices.Contains(successStatuses, status)
+	case OnFailureTrigger:
+		return slices.Contains(failureStatuses, status)
+	}
+	return false
+}
+
+// triggerCondition returns the trigger condition satisfied by the status of an upstream
+// pipeline execution, empty when the pipeline is not done
+func triggerCondition(status string) string {
+	switch {
+	case slices.Contains(successStatuses, status):
+		return OnSuccessTrigger
+	case slices.Contains(failureStatuses, status):
+		return OnFailureTrigger
+	}
+	return ""
+}
+
+// createsCycle returns true when pcKey depending on dependsOnKey creates a cycle, i.e. when
+// pcKey is dependsOnKey or one of its upstream pipelines. dependencies are the upstream
+// pipeline configs by pipeline config key.
+func createsCycle(dependencies map[int][]int, pcKey, dependsOnKey int) bool {
+	visited := map[int]bool{dependsOnKey: true}
+	stack := []int{dependsOnKey}
+	for len(stack) > 0 {
+		key := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if key == pcKey {
+			return true
+		}
+		for _, upstreamKey := range dependencies[key] {
+			if !visited[upstreamKey] {
+				visited[upstreamKey] = true
+				stack = append(stack, upstreamKey)
+			}
+		}
+	}
+	return false
+}
+
+// validatePipelineDependency validates a row inserted in pipeline_dependency: the trigger
+// condition, defaults to on_success, and the dependency must not create a cycle
+func (ctx *DataTableContext) validatePipelineDependency(row map[string]any) (int, error) {
+	condition, _ := row["trigger_condition"].(string)
+	switch condition {
+	case "":
+		row["trigger_condition"] = OnSuccessTrigger
+	case OnSuccessTrigger, OnFailureTrigger:
+	default:
+		return http.StatusBadRequest, fmt.Errorf("error: invalid trigger_condition '%s', expecting %s or %s",
+			condition, OnSuccessTrigger, OnFailureTrigger)
+	}
+	pcKey, err := strconv.Atoi(fmt.Sprintf("%v", row["pipeline_config_key"]))
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("error: invalid pipeline_config_key: %v", err)
+	}
+	dependsOnKey, err := strconv.Atoi(fmt.Sprintf("%v", row["depends_on_pipeline_config_key"]))
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("error: invalid depends_on_pipeline_config_key: %v", err)
+	}
+	if pcKey == dependsOnKey {
+		return http.StatusBadRequest, fmt.Errorf("error: pipeline config %d cannot depend on itself", pcKey)
+	}
+	// The pipeline must not be upstream of the pipeline it depends on
+	dependencies, err := ctx.getPipelineDependencies()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("while checking pipeline dependency for cycles: %v", err)
+	}
+	if createsCycle(dependencies, pcKey, dependsOnKey) {
+		return http.StatusBadRequest, fmt.Errorf(
+			"error: pipeline config %d cannot depend on pipeline config %d, it would create a cycle", pcKey, dependsOnKey)
+	}
+	row["pipeline_config_key"] = pcKey
+	row["depends_on_pipeline_config_key"] = dependsOnKey
+	return http.StatusOK, nil
+}
+
+// getPipelineDependencies returns the upstream pipeline configs by pipeline config key
+func (ctx *DataTableContext) getPipelineDependencies() (map[int][]int, error) {
+	stmt := `SELECT pipeline_config_key, depends_on_pipeline_config_key FROM jetsapi.pipeline_dependency`
+	rows, err := ctx.Dbpool.Query(context.Background(), stmt)
+	if err != nil {
+		return nil, fmt.Errorf("while reading the pipeline dependencies: %v", err)
+	}
+	defer rows.Close()
+	dependencies := make(map[int][]int)
+	for rows.Next() {
+		var pcKey, dependsOnKey int
+		if err = rows.Scan(&pcKey, &dependsOnKey); err != nil {
+			return nil, fmt.Errorf("while scanning the pipeline dependencies: %v", err)
+		}
+		dependencies[pcKey] = append(dependencies[pcKey], dependsOnKey)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("while reading the pipeline dependencies: %v", err)
+	}
+	return dependencies, nil
+}
+
+// StartDependentPipelines starts the pipelines depending on the pipeline execution peKey
+// when all their dependencies are satisfied for the client and source period of peKey
+func (ctx *DataTableContext) StartDependentPipelines(peKey int) error {
+	var pcKey, sourcePeriodKey int
+	var client, status, sessionId string
+	stmt := `SELECT pipeline_config_key, client, source_period_key, status, session_id
+		FROM jetsapi.pipeline_execution_status WHERE key = $1`
+	err := ctx.Dbpool.QueryRow(context.Background(), stmt, peKey).
+		Scan(&pcKey, &client, &sourcePeriodKey, &status, &sessionId)
+	if err != nil {
+		return fmt.Errorf("while reading pipeline execution status with key %d: %v", peKey, err)
+	}
+	condition := triggerCondition(status)
+	if condition == "" {
+		return nil
+	}
+	// The pipelines triggered by the status of this pipeline execution
+	stmt = `SELECT pipeline_config_key FROM jetsapi.pipeline_dependency
+		WHERE depends_on_pipeline_config_key = $1 AND trigger_condition = $2`
+	rows, err := ctx.Dbpool.Query(context.Background(), stmt, pcKey, condition)
+	if err != nil {
+		return fmt.Errorf("while reading the pipelines depending on pipeline config %d: %v", pcKey, err)
+	}
+	downstreamKeys := make([]int, 0)
+	for rows.Next() {
+		var key int
+		if err = rows.Scan(&key); err != nil {
+			rows.Close()
+			return fmt.Errorf("while scanning pipeline dependency: %v", err)
+		}
+		downstreamKeys = append(downstreamKeys, key)
+	}
+	rows.Close()
+	if len(downstreamKeys) == 0 {
+		return nil
+	}
+
+	baseSessionId := time.Now().UnixMilli()
+	for _, downstreamKey := range downstreamKeys {
+		ready, latestUpstreamKey, err := ctx.areDependenciesSatisfied(downstreamKey, client, sourcePeriodKey)
+		if err != nil {
+			return err
+		}
+		if !ready {
+			log.Printf("%s Pipeline config %d is waiting on its other dependencies", sessionId, downstreamKey)
+			continue
+		}
+		tx, err := ctx.beginPipelineStart(&baseSessionId)
+		if err != nil {
+			return fmt.Errorf("while starting transaction to start pipeline config %d: %v", downstreamKey, err)
+		}
+		_, err = startDependentPipelineOnce(tx, downstreamKey, client, sourcePeriodKey, latestUpstreamKey, sessionId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// areDependenciesSatisfied returns true when the latest execution of every upstream pipeline
+// of pcKey for client and sourcePeriodKey satisfies its trigger condition, with the key of the
+// most recent of these executions
+func (ctx *DataTableContext) areDependenciesSatisfied(pcKey int, client string, sourcePeriodKey int) (bool, int, error) {
+	stmt := `SELECT pd.trigger_condition, pe.key, pe.status
+		FROM jetsapi.pipeline_dependency pd
+		LEFT JOIN LATERAL (
+			SELECT key, status FROM jetsapi.pipeline_execution_status
+			WHERE pipeline_config_key = pd.depends_on_pipeline_config_key
+				AND client = $2 AND source_period_key = $3
+			ORDER BY key DESC LIMIT 1) pe ON true
+		WHERE pd.pipeline_config_key = $1`
+	rows, err := ctx.Dbpool.Query(context.Background(), stmt, pcKey, client, sourcePeriodKey)
+	if err != nil {
+		return false, 0, fmt.Errorf("while reading the dependencies of pipeline config %d: %v", pcKey, err)
+	}
+	defer rows.Close()
+	upstreams := make([]upstreamExecution, 0)
+	for rows.Next() {
+		var condition string
+		var peKey sql.NullInt64
+		var status sql.NullString
+		if err = rows.Scan(&condition, &peKey, &status); err != nil {
+			return false, 0, fmt.Errorf("while scanning the dependencies of pipeline config %d: %v", pcKey, err)
+		}
+		upstreams = append(upstreams, upstreamExecution{
+			condition: condition,
+			peKey:     int(peKey.Int64),
+			status:    status.String,
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return false, 0, fmt.Errorf("while reading the dependencies of pipeline config %d: %v", pcKey, err)
+	}
+	ready, latestUpstreamKey := dependenciesReady(upstreams)
+	return ready, latestUpstreamKey, nil
+}
+
+// upstreamExecution is the latest execution of the upstream pipeline of a dependency with
+// trigger condition, peKey is 0 when the upstream pipeline did not run for the source period
+type upstreamExecution struct {
+	condition string
+	peKey     int
+	status    string
+}
+
+// dependenciesReady returns true when every upstream execution satisfies the trigger condition
+// of its dependency (fan-in), with the key of the most recent of these executions
+func dependenciesReady(upstreams []upstreamExecution) (bool, int) {
+	if len(upstreams) == 0 {
+		return false, 0
+	}
+	var latestUpstreamKey int
+	for _, upstream := range upstreams {
+		if upstream.peKey == 0 || !isTriggerSatisfied(upstream.condition, upstream.status) {
+			return false, 0
+		}
+		latestUpstreamKey = max(latestUpstreamKey, upstream.peKey)
+	}
+	return true, latestUpstreamKey
+}
+
+// pipelineStartTx is the transaction starting a dependent pipeline, the advisory lock taken
+// by lock is held until the transaction is committed or rolled back
+type pipelineStartTx interface {
+	lock(lockKey string) error
+	// isStarted returns true when pcKey has an execution more recent than latestUpstreamKey
+	isStarted(pcKey int, client string, sourcePeriodKey, latestUpstreamKey int) (bool, error)
+	start(pcKey int, client string, sourcePeriodKey int) error
+	commit() error
+	rollback()
+}
+
+// startDependentPipelineOnce starts the pipeline pcKey only once for the latest executions of its
+// upstream pipelines, latestUpstreamKey being the most recent of them. The upstream pipelines of a
+// fan-in complete concurrently, the check and the start are serialized by a transaction-level
+// advisory lock on pcKey, client and source period. Returns true when the pipeline is started.
+func startDependentPipelineOnce(tx pipelineStartTx, pcKey int, client string, sourcePeriodKey, latestUpstreamKey int,
+	sessionId string) (bool, error) {
+	// The lock is released when the transaction ends
+	defer tx.rollback()
+	lockKey := fmt.Sprintf("pipeline_dependency/%d/%s/%d", pcKey, client, sourcePeriodKey)
+	err := tx.lock(lockKey)
+	if err != nil {
+		return false, fmt.Errorf("while locking the start of pipeline config %d: %v", pcKey, err)
+	}
+	isStarted, err := tx.isStarted(pcKey, client, sourcePeriodKey, latestUpstreamKey)
+	if err != nil {
+		return false, fmt.Errorf("while checking if pipeline config %d is started: %v", pcKey, err)
+	}
+	if isStarted {
+		return false, nil
+	}
+	log.Printf("%s Starting pipeline config %d, its dependencies are satisfied", sessionId, pcKey)
+	// The pipeline execution is inserted and committed while holding the lock
+	err = tx.start(pcKey, client, sourcePeriodKey)
+	if err != nil {
+		return false, fmt.Errorf("while starting pipeline config %d: %v", pcKey, err)
+	}
+	return true, tx.commit()
+}
+
+// dbPipelineStartTx is the pipelineStartTx of the database
+type dbPipelineStartTx struct {
+	ctx           *DataTableContext
+	tx            pgx.Tx
+	baseSessionId *int64
+}
+
+func (ctx *DataTableContext) beginPipelineStart(baseSessionId *int64) (*dbPipelineStartTx, error) {
+	tx, err := ctx.Dbpool.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &dbPipelineStartTx{ctx: ctx, tx: tx, baseSessionId: baseSessionId}, nil
+}
+
+func (t *dbPipelineStartTx) lock(lockKey string) error {
+	_, err := t.tx.Exec(context.Background(), "SELECT pg_advisory_xact_lock(hashtextextended($1, 0))", lockKey)
+	return err
+}
+
+func (t *dbPipelineStartTx) isStarted(pcKey int, client string, sourcePeriodKey, latestUpstreamKey int) (bool, error) {
+	var isStarted bool
+	stmt := `SELECT EXISTS (SELECT 1 FROM jetsapi.pipeline_execution_status
+		WHERE pipeline_config_key = $1 AND client = $2 AND source_period_key = $3 AND key > $4)`
+	err := t.tx.QueryRow(context.Background(), stmt, pcKey, client, sourcePeriodKey, latestUpstreamKey).Scan(&isStarted)
+	return isStarted, err
+}
+
+func (t *dbPipelineStartTx) start(pcKey int, client string, sourcePeriodKey int) error {
+	return t.ctx.startDependentPipeline(pcKey, client, sourcePeriodKey, t.baseSessionId)
+}
+
+func (t *dbPipelineStartTx) commit() error {
+	return t.tx.Commit(context.Background())
+}
+
+func (t *dbPipelineStartTx) rollback() {
+	t.tx.Rollback(context.Background())
+}
+
+// startDependentPipeline submits the pipeline pcKey using the latest inputs of the source period
+func (ctx *DataTableContext) startDependentPipeline(pcKey int, client string, sourcePeriodKey int, baseSessionId *int64) error {
+	var processName, mainObjectType string
+	var mainProcessInputKey int
+	var mergedProcessInputKeys []int
+	stmt := `SELECT process_name, main_object_type, main_process_input_key, merged_process_input_keys
+		FROM jetsapi.pipeline_config WHERE key = $1`
+	err := ctx.Dbpool.QueryRow(context.Background(), stmt, pcKey).
+		Scan(&processName, &mainObjectType, &mainProcessInputKey, &mergedProcessInputKeys)
+	if err != nil {
+		return fmt.Errorf("while reading pipeline config: %v", err)
+	}
+	pairs, err := getLatestInputRegistryKeys(ctx.Dbpool, sourcePeriodKey,
+		append([]int{mainProcessInputKey}, mergedProcessInputKeys...))
+	if err != nil {
+		return fmt.Errorf("while getting the latest input registry keys: %v", err)
+	}
+	pi2ir := make(map[int]int)
+	for _, pair := range pairs {
+		pi2ir[pair[0]] = pair[1]
+	}
+	mainIr, ok := pi2ir[mainProcessInputKey]
+	if !ok {
+		return fmt.Errorf("error: no input registered for main process input %d in source period %d",
+			mainProcessInputKey, sourcePeriodKey)
+	}
+	mergeIrs := make([]int, 0, len(mergedProcessInputKeys))
+	for _, piKey := range mergedProcessInputKeys {
+		irKey, ok := pi2ir[piKey]
+		if !ok {
+			return fmt.Errorf("error: no input registered for merged process input %d in source period %d",
+				piKey, sourcePeriodKey)
+		}
+		mergeIrs = append(mergeIrs, irKey)
+	}
+	fileKey, err := getFileKeyForInputRegistry(ctx.Dbpool, mainIr)
+	if err != nil {
+		return err
+	}
+	sessionId, err := reserveSessionId(ctx.Dbpool, baseSessionId)
+	if err != nil {
+		return err
+	}
+	data := map[string]any{
+		"pipeline_config_key":        strconv.Itoa(pcKey),
+		"process_name":               processName,
+		"client":                     client,
+		"main_object_type":           mainObjectType,
+		"main_input_registry_key":    mainIr,
+		"merged_input_registry_keys": mergeIrs,
+		"input_session_id":           nil,
+		"session_id":                 sessionId,
+		"source_period_key":          sourcePeriodKey,
+		"status":                     "submitted",
+		"user_email":                 "system",
+		"serverCompletedMetric":      "autoServerCompleted",
+		"serverFailedMetric":         "autoServerFailed",
+		"file_key":                   fileKey,
+	}
+	if len(fileKey) > 0 {
+		data["main_input_file_key"] = fileKey
+	}
+	// Start the pipeline by inserting into pipeline_execution_status, the dependency
+	// was authorized when it was inserted in pipeline_dependency
+	dataTableAction := DataTableAction{
+		Action:         "insert_rows",
+		FromClauses:    []FromClause{{Schema: "jetsapi", Table: "pipeline_execution_status"}},
+		Data:           []map[string]any{data},
+		SkipThrottling: len(fileKey) == 0,
+	}
+	_, _, err = ctx.InsertPipelineExecutionStatus(&dataTableAction, 0, &map[string]any{}, "")
+	return err
+}
+
+// GetPipelineDependencyGraph returns the dependency graph of the pipeline of the execution
+// with session_id, for the client and source period of the execution:
+//   - nodes: the pipeline configs connected to the pipeline by dependencies, with their latest execution
+//   - edges: the dependencies, from the upstream to the downstream pipeline config
+func (ctx *DataTableContext) GetPipelineDependencyGraph(dataTableAction *DataTableAction, token string) (*map[string]any, int, error) {
+	_, err := ctx.VerifyUserPermission(&SqlInsertDefinition{Capability: "jetstore_read"}, token)
+	if err != nil {
+		log.Printf("while VerifyUserPermission: %v", err)
+		return nil, http.StatusUnauthorized, errors.New("error: unauthorized, cannot get user info or does not have permission")
+	}
+	if len(dataTableAction.Data) == 0 {
+		return nil, http.StatusBadRequest, errors.New("error: missing session_id")
+	}
+	sessionId, ok := dataTableAction.Data[0]["session_id"].(string)
+	if !ok {
+		return nil, http.StatusBadRequest, errors.New("error: missing session_id")
+	}
+	err = ctx.VerifySessionClientAccess("pipeline_dependency_graph", sessionId, token)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+	var pcKey, sourcePeriodKey int
+	var client string
+	stmt := `SELECT pipeline_config_key, client, source_period_key
+		FROM jetsapi.pipeline_execution_status WHERE session_id = $1`
+	err = ctx.Dbpool.QueryRow(context.Background(), stmt, sessionId).Scan(&pcKey, &client, &sourcePeriodKey)
+	if err != nil {
+		return nil, http.StatusNotFound, fmt.Errorf("while reading pipeline execution of session %s: %v", sessionId, err)
+	}
+
+	// The pipeline configs connected to pcKey, with their latest execution
+	stmt = `WITH RECURSIVE graph(key) AS (
+			SELECT $1::int
+			UNION
+			SELECT CASE WHEN pd.pipeline_config_key = g.key
+				THEN pd.depends_on_pipeline_config_key ELSE pd.pipeline_config_key END
+			FROM jetsapi.pipeline_dependency pd, graph g
+			WHERE pd.pipeline_config_key = g.key OR pd.depends_on_pipeline_config_key = g.key
+		)
+		SELECT g.key, pc.process_name, pe.key, pe.session_id, pe.status
+		FROM graph g
+		JOIN jetsapi.pipeline_config pc ON pc.key = g.key
+		LEFT JOIN LATERAL (
+			SELECT key, session_id, status FROM jetsapi.pipeline_execution_status
+			WHERE pipeline_config_key = g.key AND client = $2 AND source_period_key = $3
+			ORDER BY key DESC LIMIT 1) pe ON true
+		ORDER BY g.key`
+	rows, err := ctx.Dbpool.Query(context.Background(), stmt, pcKey, client, sourcePeriodKey)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("while reading the pipeline dependency graph: %v", err)
+	}
+	nodes := make([]map[string]any, 0)
+	nodeKeys := make([]int, 0)
+	for rows.Next() {
+		var key int
+		var processName string
+		var peKey sql.NullInt64
+		var peSessionId, status sql.NullString
+		if err = rows.Scan(&key, &processName, &peKey, &peSessionId, &status); err != nil {
+			rows.Close()
+			return nil, http.StatusInternalServerError, fmt.Errorf("while scanning the pipeline dependency graph: %v", err)
+		}
+		node := map[string]any{
+			"pipeline_config_key": key,
+			"process_name":        processName,
+		}
+		if peKey.Valid {
+			node["pipeline_execution_status_key"] = peKey.Int64
+			node["session_id"] = peSessionId.String
+			node["status"] = status.String
+		}
+		nodes = append(nodes, node)
+		nodeKeys = append(nodeKeys, key)
+	}
+	rows.Close()
+
+	// The dependencies between the pipeline configs of the graph
+	stmt = `SELECT depends_on_pipeline_config_key, pipeline_config_key, trigger_condition
+		FROM jetsapi.pipeline_dependency
+		WHERE pipeline_config_key = ANY($1)
+		ORDER BY depends_on_pipeline_config_key, pipeline_config_key`
+	rows, err = ctx.Dbpool.Query(context.Background(), stmt, nodeKeys)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("while reading the pipeline dependencies: %v", err)
+	}
+	defer rows.Close()
+	edges := make([]map[string]any, 0)
+	for rows.Next() {
+		var from, to int
+		var condition string
+		if err = rows.Scan(&from, &to, &condition); err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("while scanning the pipeline dependencies: %v", err)
+		}
+		edges = append(edges, map[string]any{
+			"from":              from,
+			"to":                to,
+			"trigger_condition": condition,
+		})
+	}
+	return &map[string]any{
+		"pipeline_config_key": pcKey,
+		"client":              client,
+		"source_period_key":   sourcePeriodKey,
+		"nodes":               nodes,
+		"edges":               edges,
+	}, http.StatusOK, nil
+}
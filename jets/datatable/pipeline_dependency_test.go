@@ -0,0 +1,294 @@
+package datatable
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIsTriggerSatisfied(t *testing.T) {
+	tests := []struct {
+		condition string
+		status    string
+		expected  bool
+	}{
+		{OnSuccessTrigger, "completed", true},
+		{OnSuccessTrigger, "errors", true},
+		{OnSuccessTrigger, "recovered", true},
+		{OnSuccessTrigger, "failed", false},
+		{OnSuccessTrigger, "interrupted", false},
+		{OnSuccessTrigger, "submitted", false},
+		{OnFailureTrigger, "failed", true},
+		{OnFailureTrigger, "interrupted", true},
+		{OnFailureTrigger, "completed", false},
+		{OnFailureTrigger, "errors", false},
+		{OnFailureTrigger, "submitted", false},
+		{"", "completed", false},
+		{"on_completion", "completed", false},
+	}
+	for _, tt := range tests {
+		if got := isTriggerSatisfied(tt.condition, tt.status); got != tt.expected {
+			t.Errorf("isTriggerSatisfied(%q, %q): expected %v, got %v", tt.condition, tt.status, tt.expected, got)
+		}
+	}
+}
+
+func TestTriggerCondition(t *testing.T) {
+	tests := map[string]string{
+		"completed":   OnSuccessTrigger,
+		"errors":      OnSuccessTrigger,
+		"recovered":   OnSuccessTrigger,
+		"failed":      OnFailureTrigger,
+		"interrupted": OnFailureTrigger,
+		"submitted":   "",
+		"":            "",
+	}
+	for status, expected := range tests {
+		if got := triggerCondition(status); got != expected {
+			t.Errorf("triggerCondition(%q): expected %q, got %q", status, expected, got)
+		}
+	}
+}
+
+func TestDependenciesReady(t *testing.T) {
+	tests := []struct {
+		name      string
+		upstreams []upstreamExecution
+		ready     bool
+		latestKey int
+	}{
+		{"single dependency", []upstreamExecution{
+			{OnSuccessTrigger, 10, "completed"}}, true, 10},
+		{"fan-in satisfied", []upstreamExecution{
+			{OnSuccessTrigger, 10, "completed"},
+			{OnSuccessTrigger, 12, "errors"},
+			{OnFailureTrigger, 11, "failed"}}, true, 12},
+		{"fan-in waiting on a running upstream", []upstreamExecution{
+			{OnSuccessTrigger, 10, "completed"},
+			{OnSuccessTrigger, 12, "submitted"}}, false, 0},
+		{"fan-in waiting on an upstream that did not run", []upstreamExecution{
+			{OnSuccessTrigger, 10, "completed"},
+			{OnSuccessTrigger, 0, ""}}, false, 0},
+		{"trigger condition not satisfied", []upstreamExecution{
+			{OnSuccessTrigger, 10, "completed"},
+			{OnFailureTrigger, 12, "completed"}}, false, 0},
+		{"no dependency", nil, false, 0},
+	}
+	for _, tt := range tests {
+		ready, latestKey := dependenciesReady(tt.upstreams)
+		if ready != tt.ready || latestKey != tt.latestKey {
+			t.Errorf("%s: expected (%v, %d), got (%v, %d)", tt.name, tt.ready, tt.latestKey, ready, latestKey)
+		}
+	}
+}
+
+func TestCreatesCycle(t *testing.T) {
+	// 4 depends on 2 and 3 (fan-in), 2 and 3 depend on 1, 5 depends on 4
+	dependencies := map[int][]int{
+		2: {1},
+		3: {1},
+		4: {2, 3},
+		5: {4},
+	}
+	tests := []struct {
+		pcKey, dependsOnKey int
+		expected            bool
+	}{
+		{6, 5, false},
+		{3, 2, false},
+		{1, 6, false},
+		{1, 2, true},
+		{1, 5, true},
+		{2, 4, true},
+		{3, 3, true},
+		{4, 1, false},
+	}
+	for _, tt := range tests {
+		if got := createsCycle(dependencies, tt.pcKey, tt.dependsOnKey); got != tt.expected {
+			t.Errorf("createsCycle(%d depends on %d): expected %v, got %v", tt.pcKey, tt.dependsOnKey, tt.expected, got)
+		}
+	}
+}
+
+// fakePipelineStartDb holds the pipeline executions and the advisory locks of fakePipelineStartTx
+type fakePipelineStartDb struct {
+	mu         sync.Mutex
+	locks      map[string]*sync.Mutex
+	executions []int
+	nextKey    int
+	startErr   error
+	// time to insert the pipeline execution, to let the concurrent starts overlap
+	startDelay time.Duration
+}
+
+func (db *fakePipelineStartDb) begin() *fakePipelineStartTx {
+	return &fakePipelineStartTx{db: db}
+}
+
+// fakePipelineStartTx serializes the transactions with the same lock key, as pg_advisory_xact_lock
+type fakePipelineStartTx struct {
+	db      *fakePipelineStartDb
+	lockKey string
+	locked  *sync.Mutex
+	started []int
+}
+
+func (tx *fakePipelineStartTx) lock(lockKey string) error {
+	tx.db.mu.Lock()
+	if tx.db.locks == nil {
+		tx.db.locks = make(map[string]*sync.Mutex)
+	}
+	l := tx.db.locks[lockKey]
+	if l == nil {
+		l = &sync.Mutex{}
+		tx.db.locks[lockKey] = l
+	}
+	tx.db.mu.Unlock()
+	l.Lock()
+	tx.lockKey, tx.locked = lockKey, l
+	return nil
+}
+
+func (tx *fakePipelineStartTx) isStarted(pcKey int, client string, sourcePeriodKey, latestUpstreamKey int) (bool, error) {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	for _, key := range tx.db.executions {
+		if key > latestUpstreamKey {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (tx *fakePipelineStartTx) start(pcKey int, client string, sourcePeriodKey int) error {
+	if tx.db.startErr != nil {
+		return tx.db.startErr
+	}
+	time.Sleep(tx.db.startDelay)
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.nextKey++
+	tx.started = append(tx.started, tx.db.nextKey)
+	return nil
+}
+
+func (tx *fakePipelineStartTx) commit() error {
+	tx.db.mu.Lock()
+	tx.db.executions = append(tx.db.executions, tx.started...)
+	tx.started = nil
+	tx.db.mu.Unlock()
+	tx.unlock()
+	return nil
+}
+
+func (tx *fakePipelineStartTx) rollback() {
+	tx.started = nil
+	tx.unlock()
+}
+
+func (tx *fakePipelineStartTx) unlock() {
+	if tx.locked != nil {
+		tx.locked.Unlock()
+		tx.locked = nil
+	}
+}
+
+func TestStartDependentPipelineOnce(t *testing.T) {
+	db := &fakePipelineStartDb{nextKey: 100, startDelay: 5 * time.Millisecond}
+	// The upstream pipelines of a fan-in complete concurrently, they all see the
+	// dependencies satisfied by their latest executions, the most recent being 20
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	startCount := 0
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started, err := startDependentPipelineOnce(db.begin(), 4, "ACME", 1, 20, "session")
+			if err != nil {
+				t.Error(err)
+			}
+			if started {
+				mu.Lock()
+				startCount++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if startCount != 1 || len(db.executions) != 1 {
+		t.Fatalf("expecting the pipeline to be started once, started %d times with executions %v", startCount, db.executions)
+	}
+
+	// A new execution of an upstream pipeline starts the pipeline again
+	started, err := startDependentPipelineOnce(db.begin(), 4, "ACME", 1, 150, "session")
+	if err != nil || !started || len(db.executions) != 2 {
+		t.Errorf("expecting the pipeline to be started for the new upstream execution, got %v, %v", started, err)
+	}
+
+	// A failed start is rolled back and releases the lock
+	db.startErr = errors.New("no input registered")
+	started, err = startDependentPipelineOnce(db.begin(), 4, "ACME", 1, 300, "session")
+	if err == nil || started || len(db.executions) != 2 {
+		t.Errorf("expecting the start to fail, got %v, %v", started, err)
+	}
+	db.startErr = nil
+	started, err = startDependentPipelineOnce(db.begin(), 4, "ACME", 1, 300, "session")
+	if err != nil || !started {
+		t.Errorf("expecting the pipeline to be started after the failed start, got %v, %v", started, err)
+	}
+}
+
+// The cases below are rejected before checking for cycles in the database
+func TestValidatePipelineDependency_InvalidTriggerCondition(t *testing.T) {
+	ctx := &DataTableContext{}
+	row := map[string]any{
+		"pipeline_config_key":            "1",
+		"depends_on_pipeline_config_key": "2",
+		"trigger_condition":              "on_completion",
+	}
+	status, err := ctx.validatePipelineDependency(row)
+	if err == nil {
+		t.Fatal("Expected an error for an invalid trigger_condition")
+	}
+	if status != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, status)
+	}
+}
+
+func TestValidatePipelineDependency_InvalidKey(t *testing.T) {
+	ctx := &DataTableContext{}
+	row := map[string]any{
+		"pipeline_config_key":            "1",
+		"depends_on_pipeline_config_key": "abc",
+		"trigger_condition":              OnFailureTrigger,
+	}
+	status, err := ctx.validatePipelineDependency(row)
+	if err == nil {
+		t.Fatal("Expected an error for an invalid depends_on_pipeline_config_key")
+	}
+	if status != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, status)
+	}
+}
+
+func TestValidatePipelineDependency_SelfDependency(t *testing.T) {
+	ctx := &DataTableContext{}
+	row := map[string]any{
+		"pipeline_config_key":            "3",
+		"depends_on_pipeline_config_key": 3,
+	}
+	status, err := ctx.validatePipelineDependency(row)
+	if err == nil {
+		t.Fatal("Expected an error for a pipeline config depending on itself")
+	}
+	if status != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, status)
+	}
+	// The trigger condition defaults to on_success
+	if row["trigger_condition"] != OnSuccessTrigger {
+		t.Errorf("Expected trigger_condition %s, got %v", OnSuccessTrigger, row["trigger_condition"])
+	}
+}
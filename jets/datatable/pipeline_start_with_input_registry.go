@@ -15,6 +15,7 @@ import (
 // Start process based on matching criteria:
 //   - find pipelines that are ready to start with the input_registry key.
 //   - Pipeline must have automated flag on
+//   - Pipeline with dependencies are started by their dependencies (see StartDependentPipelines)
 //
 // Note: the argument inputSessionId is the inputRegistryKey sessionId
 func (ctx *DataTableContext) StartPipelinesForInputRegistryV2(inputRegistryKey, sourcePeriodKey int,
@@ -216,6 +217,7 @@ func getPipelineConfig(dbpool *pgxpool.Pool, processInputKeys []int) ([]pipeline
 	buf.WriteString(`SELECT distinct key, process_name, main_process_input_key, merged_process_input_keys
 	  FROM jetsapi.pipeline_config
 	  WHERE automated = 1
+    AND NOT EXISTS (SELECT 1 FROM jetsapi.pipeline_dependency pd WHERE pd.pipeline_config_key = pipeline_config.key)
     AND (main_process_input_key IN (`)
 	buf.WriteString(piKeysString)
 	buf.WriteString(") OR merged_process_input_keys && ARRAY[")
@@ -2,6 +2,7 @@ package datatable
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -93,6 +94,11 @@ var jetsS3SchemaTriggers string = os.Getenv("JETS_s3_SCHEMA_TRIGGERS")
 
 // Submit Schema Event to S3 (which will call RegisterFileKEys as side effect)
 func (ctx *DataTableContext) PutSchemaEventToS3(action *RegisterFileKeyAction, token string) (*map[string]any, int, error) {
+	// The user must have access to the clients of the schema events
+	requestUser, err := ctx.getRequestUser(token)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
 	for irow := range action.Data {
 		var schemaProviderJson string
 		e := action.Data[irow]["event"]
@@ -100,7 +106,19 @@ func (ctx *DataTableContext) PutSchemaEventToS3(action *RegisterFileKeyAction, t
 		if e != nil && key != nil {
 			schemaProviderJson = e.(string)
 			if len(schemaProviderJson) > 0 {
-				err := awsi.UploadBufToS3("", fmt.Sprintf("%s/%v", jetsS3SchemaTriggers, key), []byte(schemaProviderJson))
+				var schemaEvent struct {
+					Client string `json:"client"`
+					Org    string `json:"org"`
+				}
+				err = json.Unmarshal([]byte(schemaProviderJson), &schemaEvent)
+				if err != nil {
+					return nil, http.StatusBadRequest, fmt.Errorf("while parsing schema event: %v", err)
+				}
+				err = requestUser.VerifyClientAccess("put_schema_event_to_s3", schemaEvent.Client, schemaEvent.Org)
+				if err != nil {
+					return nil, http.StatusUnauthorized, err
+				}
+				err = awsi.UploadBufToS3("", fmt.Sprintf("%s/%v", jetsS3SchemaTriggers, key), []byte(schemaProviderJson))
 				if err != nil {
 					return nil, http.StatusInternalServerError, fmt.Errorf("while calling UploadBufToS3: %v", err)
 				}
@@ -117,6 +135,11 @@ func (ctx *DataTableContext) RegisterFileKeys(registerFileKeyAction *RegisterFil
 	if !ok {
 		return nil, http.StatusInternalServerError, errors.New("error cannot find file_key_staging stmt")
 	}
+	// The user must have access to the clients of the file keys
+	requestUser, err := ctx.getRequestUser(token)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
 	sentinelFileName := os.Getenv("JETS_SENTINEL_FILE_NAME")
 	baseSessionId := time.Now().UnixMilli()
 	var sessionId, stmt string
@@ -175,6 +198,11 @@ NextKey:
 			}
 		}
 		ctx.updateFileKeyComponentCase(&fileKeyObject)
+		fkClient, _ := fileKeyObject["client"].(string)
+		fkOrg, _ := fileKeyObject["org"].(string)
+		if err = requestUser.VerifyClientAccess("register_file_key", fkClient, fkOrg); err != nil {
+			return nil, http.StatusUnauthorized, err
+		}
 
 		// Inserting source_period, do retry logic in case of a race condition
 		retry := 0
@@ -178,6 +178,22 @@ var sqlInsertStmts = map[string]*SqlInsertDefinition{
 			"max_rete_sessions_saved", "rule_config_json", "source_period_type", "user_email"},
 		Capability: "client_config",
 	},
+	// pipeline dependency
+	"pipeline_dependency": {
+		Stmt: `INSERT INTO jetsapi.pipeline_dependency
+			(pipeline_config_key, depends_on_pipeline_config_key, trigger_condition, user_email)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT ON CONSTRAINT pipeline_dependency_unique_cstraint
+			DO UPDATE SET (trigger_condition, user_email, last_update) =
+			(EXCLUDED.trigger_condition, EXCLUDED.user_email, DEFAULT)`,
+		ColumnKeys: []string{"pipeline_config_key", "depends_on_pipeline_config_key", "trigger_condition", "user_email"},
+		Capability: "client_config",
+	},
+	"delete/pipeline_dependency": {
+		Stmt: `DELETE FROM jetsapi.pipeline_dependency WHERE pipeline_config_key = $1 AND depends_on_pipeline_config_key = $2`,
+		ColumnKeys: []string{"pipeline_config_key", "depends_on_pipeline_config_key"},
+		Capability: "client_config",
+	},
 
 	// pipeline_execution_status
 	"pipeline_execution_status": {
@@ -227,6 +243,25 @@ var sqlInsertStmts = map[string]*SqlInsertDefinition{
 		Capability: "none",
 	},
 
+	// User Admin: restrict a user or a role to a client, org is empty for all orgs of the client
+	"client_access": {
+		Stmt: `INSERT INTO jetsapi.client_access (principal_type, principal, client, org, user_email)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT ON CONSTRAINT client_access_unique_cstraint
+		DO UPDATE SET (user_email, last_update) = (EXCLUDED.user_email, DEFAULT)`,
+		ColumnKeys: []string{"principal_type", "principal", "client", "org", "user_email"},
+		AdminOnly:  true,
+		Capability: "none",
+	},
+
+	// User Admin: delete client access of a user or a role
+	"delete/client_access": {
+		Stmt:       `DELETE FROM jetsapi.client_access WHERE principal_type = $1 AND principal = $2 AND client = $3 AND org = $4`,
+		ColumnKeys: []string{"principal_type", "principal", "client", "org"},
+		AdminOnly:  true,
+		Capability: "none",
+	},
+
 	// User Git Profile
 	"update/user_git_profile": {
 		Stmt: `UPDATE jetsapi.users SET
@@ -396,6 +396,14 @@ func (ca *StatusUpdate) CoordinateWork() error {
 		log.Printf("%s %s\n", sessionId, err)
 		return err
 	}
+	// Start the pipelines triggered by the status of this pipeline execution, whatever the
+	// outcome of the steps below. Deferred so the outputs registered below are available to them
+	defer func() {
+		ctx := NewDataTableContext(ca.Dbpool, ca.UsingSshTunnel, ca.UsingSshTunnel, nil, nil)
+		if err := ctx.StartDependentPipelines(ca.PeKey); err != nil {
+			log.Printf("%s Warning: while starting dependent pipelines: %v", sessionId, err)
+		}
+	}()
 	var isJetsLoader bool
 
 	if ca.CpipesMode {
@@ -0,0 +1,230 @@
+package jrtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+)
+
+// Data model of the JetRule unit test files, with extension .jrtest
+//
+// A .jrtest file is a json document listing the test cases of a main rule file:
+//
+//	{
+//	  "main_rule_file": "jet_rules/claims_main.jr",
+//	  "test_cases": [
+//	    {
+//	      "name": "open claim is flagged",
+//	      "rule_config": [{"jets:key": "cfg1", "hc:threshold": {"type": "int", "value": "10"}}],
+//	      "input_triples": [
+//	        ["c1", "rdf:type", "hc:Claim"],
+//	        ["c1", "hc:amount", 25.5],
+//	        ["c1", "hc:code", {"type": "text", "value": "A01"}]
+//	      ],
+//	      "expected_triples": [["c1", "hc:flag", "hc:Yes"]],
+//	      "absent_triples": [["c1", "hc:status", "?"]]
+//	    }
+//	  ]
+//	}
+//
+// The terms of the triples are:
+//   - a string: a resource name, e.g. "hc:Claim".
+//   - a json number: an int literal when integral, a double literal otherwise.
+//   - a json bool: a bool literal (int literal 0 or 1).
+//   - a json object {"type": "text", "value": "A01"}, the type is one of: resource,
+//     text, int, long, double, bool, date, datetime, null.
+//   - in expected_triples and absent_triples only, a string starting with '?' is a
+//     wildcard matching any node, e.g. ["c1", "hc:status", "?"] or ["?c", "rdf:type", "hc:Claim"].
+//
+// rule_config has the same format as the rule_config of the jetrules pipeline spec, each
+// element is asserted to the meta graph with subject jets:key (or a generated resource).
+// expected_triples must be in the rdf session after ExecuteRules and absent_triples must not.
+
+type TestFile struct {
+	FileName     string     `json:"-"`
+	MainRuleFile string     `json:"main_rule_file"`
+	TestCases    []TestCase `json:"test_cases"`
+}
+
+type TestCase struct {
+	Name            string           `json:"name"`
+	RuleConfig      []map[string]any `json:"rule_config,omitempty"`
+	InputTriples    [][]any          `json:"input_triples,omitempty"`
+	ExpectedTriples [][]any          `json:"expected_triples,omitempty"`
+	AbsentTriples   [][]any          `json:"absent_triples,omitempty"`
+}
+
+// LoadTestFile reads and validates a .jrtest file
+func LoadTestFile(fpath string) (*TestFile, error) {
+	data, err := os.ReadFile(fpath)
+	if err != nil {
+		return nil, fmt.Errorf("while reading test file %s: %v", fpath, err)
+	}
+	testFile, err := ParseTestFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("in test file %s: %v", fpath, err)
+	}
+	testFile.FileName = fpath
+	return testFile, nil
+}
+
+// ParseTestFile parses and validates the json content of a .jrtest file
+func ParseTestFile(data []byte) (*TestFile, error) {
+	var testFile TestFile
+	err := json.Unmarshal(data, &testFile)
+	if err != nil {
+		return nil, fmt.Errorf("while unmarshaling test file: %v", err)
+	}
+	if testFile.MainRuleFile == "" {
+		return nil, fmt.Errorf("error: main_rule_file is required")
+	}
+	if len(testFile.TestCases) == 0 {
+		return nil, fmt.Errorf("error: test file has no test_cases")
+	}
+	names := make(map[string]bool)
+	for i := range testFile.TestCases {
+		tc := &testFile.TestCases[i]
+		if tc.Name == "" {
+			return nil, fmt.Errorf("error: test case %d has no name", i+1)
+		}
+		if names[tc.Name] {
+			return nil, fmt.Errorf("error: duplicate test case name '%s'", tc.Name)
+		}
+		names[tc.Name] = true
+		if len(tc.ExpectedTriples) == 0 && len(tc.AbsentTriples) == 0 {
+			return nil, fmt.Errorf("error: test case '%s' has no expected_triples or absent_triples", tc.Name)
+		}
+		for _, triples := range [][][]any{tc.InputTriples, tc.ExpectedTriples, tc.AbsentTriples} {
+			for _, t3 := range triples {
+				if len(t3) != 3 {
+					return nil, fmt.Errorf("error: test case '%s' has invalid triple %v, expecting 3 terms", tc.Name, t3)
+				}
+			}
+		}
+	}
+	return &testFile, nil
+}
+
+// isWildcard returns true when term is a string starting with '?'
+func isWildcard(term any) bool {
+	s, ok := term.(string)
+	return ok && strings.HasPrefix(s, "?")
+}
+
+// newNode makes the rdf node for a triple term, see the model above for the term format
+func newNode(rm *rdf.ResourceManager, term any) (*rdf.Node, error) {
+	var node *rdf.Node
+	var err error
+	switch vv := term.(type) {
+	case string:
+		node = rm.NewResource(vv)
+	case float64:
+		if vv == math.Trunc(vv) && math.Abs(vv) < math.MaxInt32 {
+			node = rm.NewIntLiteral(int(vv))
+		} else {
+			node = rm.NewDoubleLiteral(vv)
+		}
+	case bool:
+		node = rm.NewBoolLiteral(vv)
+	case nil:
+		node = rdf.Null()
+	case map[string]any:
+		rdfType, _ := vv["type"].(string)
+		var value string
+		switch v := vv["value"].(type) {
+		case string:
+			value = v
+		case nil:
+		default:
+			value = fmt.Sprintf("%v", v)
+		}
+		node, err = parseTypedNode(rm, value, rdfType)
+	default:
+		err = fmt.Errorf("error: invalid triple term %v of type %T", term, term)
+	}
+	if err == nil && node == nil {
+		err = fmt.Errorf("error: cannot create rdf node for term %v, resource manager is locked", term)
+	}
+	return node, err
+}
+
+// parseTypedNode makes the rdf node of value with type rdfType, types are as in
+// the rule_config of the jetrules pipeline spec
+func parseTypedNode(rm *rdf.ResourceManager, value, rdfType string) (*rdf.Node, error) {
+	switch rdfType {
+	case "null":
+		return rdf.Null(), nil
+	case "resource":
+		return rm.NewResource(value), nil
+	case "text", "":
+		return rm.NewTextLiteral(value), nil
+	case "int", "long":
+		var v int
+		_, err := fmt.Sscan(value, &v)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing int value '%s': %v", value, err)
+		}
+		return rm.NewIntLiteral(v), nil
+	case "double":
+		var v float64
+		_, err := fmt.Sscan(value, &v)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing double value '%s': %v", value, err)
+		}
+		return rm.NewDoubleLiteral(v), nil
+	case "bool":
+		if len(value) == 0 {
+			return rm.NewBoolLiteral(false), nil
+		}
+		switch strings.ToLower(value[0:1]) {
+		case "t", "1", "y":
+			return rm.NewBoolLiteral(true), nil
+		case "f", "0", "n":
+			return rm.NewBoolLiteral(false), nil
+		}
+		return nil, fmt.Errorf("error: value is not bool: %s", value)
+	case "date":
+		d, err := rdf.ParseDate(value)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing date value '%s': %v", value, err)
+		}
+		return rm.NewDateLiteral(rdf.LDate{Date: d}), nil
+	case "datetime":
+		d, err := rdf.ParseDatetime(value)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing datetime value '%s': %v", value, err)
+		}
+		return rm.NewDatetimeLiteral(rdf.LDatetime{Datetime: d}), nil
+	}
+	return nil, fmt.Errorf("error: unknown rdf type '%s' for value '%s'", rdfType, value)
+}
+
+// termString returns the term as in the rule files, used in the test reports
+func termString(term any) string {
+	switch vv := term.(type) {
+	case string:
+		return vv
+	case map[string]any:
+		rdfType, _ := vv["type"].(string)
+		switch rdfType {
+		case "resource", "":
+			return fmt.Sprintf("%v", vv["value"])
+		case "text":
+			return fmt.Sprintf("\"%v\"", vv["value"])
+		case "null":
+			return "null"
+		}
+		return fmt.Sprintf("%s(%v)", rdfType, vv["value"])
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%v", term)
+}
+
+func tripleString(t3 []any) string {
+	return fmt.Sprintf("(%s %s %s)", termString(t3[0]), termString(t3[1]), termString(t3[2]))
+}
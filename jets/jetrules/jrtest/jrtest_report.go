@@ -0,0 +1,137 @@
+package jrtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Test reports: a text summary for the console and a JUnit xml report,
+// understood by the CI tools (xUnit format)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnitReport writes the JUnit xml report of the suites to w
+func WriteJUnitReport(w io.Writer, suites []*SuiteResult) error {
+	report := junitTestSuites{}
+	var totalTime float64
+	for _, suite := range suites {
+		tests, failures, errors := suite.Counts()
+		js := junitTestSuite{
+			Name:     suite.FileName,
+			Tests:    tests,
+			Failures: failures,
+			Errors:   errors,
+			Time:     fmt.Sprintf("%.3f", suite.Duration.Seconds()),
+		}
+		if suite.Error != nil {
+			// The main rule file did not compile
+			js.Tests++
+			js.TestCases = append(js.TestCases, junitTestCase{
+				Name:      "compile " + suite.MainRuleFile,
+				ClassName: suite.MainRuleFile,
+				Time:      js.Time,
+				Error:     &junitMessage{Message: "compilation failed", Type: "error", Text: suite.Error.Error()},
+			})
+		}
+		for _, r := range suite.Results {
+			tc := junitTestCase{
+				Name:      r.Name,
+				ClassName: suite.MainRuleFile,
+				Time:      fmt.Sprintf("%.3f", r.Duration.Seconds()),
+			}
+			switch {
+			case r.Error != nil:
+				tc.Error = &junitMessage{Message: "test case error", Type: "error", Text: r.Error.Error()}
+			case len(r.Failures) > 0:
+				tc.Failure = &junitMessage{
+					Message: fmt.Sprintf("%d triple(s) not as expected", len(r.Failures)),
+					Type:    "failure",
+					Text:    strings.Join(r.Failures, "\n"),
+				}
+			}
+			js.TestCases = append(js.TestCases, tc)
+		}
+		report.Tests += js.Tests
+		report.Failures += js.Failures
+		report.Errors += js.Errors
+		totalTime += suite.Duration.Seconds()
+		report.Suites = append(report.Suites, js)
+	}
+	report.Time = fmt.Sprintf("%.3f", totalTime)
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(report)
+	if err != nil {
+		return fmt.Errorf("while encoding junit report: %v", err)
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// WriteSummary writes a text summary of the suites to w, with the diffs of the
+// failed test cases. Returns true when all test cases passed.
+func WriteSummary(w io.Writer, suites []*SuiteResult) bool {
+	var nbrTests, nbrPassed, nbrFailed int
+	for _, suite := range suites {
+		if suite.Error != nil {
+			nbrFailed++
+			fmt.Fprintf(w, "ERROR %s: %v\n", suite.FileName, suite.Error)
+			continue
+		}
+		for _, r := range suite.Results {
+			nbrTests++
+			switch {
+			case r.Error != nil:
+				nbrFailed++
+				fmt.Fprintf(w, "ERROR %s / %s: %v\n", suite.FileName, r.Name, r.Error)
+			case len(r.Failures) > 0:
+				nbrFailed++
+				fmt.Fprintf(w, "FAIL  %s / %s\n", suite.FileName, r.Name)
+				for _, f := range r.Failures {
+					fmt.Fprintf(w, "      %s\n", f)
+				}
+			default:
+				nbrPassed++
+				fmt.Fprintf(w, "ok    %s / %s (%.3fs)\n", suite.FileName, r.Name, r.Duration.Seconds())
+			}
+		}
+	}
+	fmt.Fprintf(w, "%d test case(s), %d passed, %d failed\n", nbrTests, nbrPassed, nbrFailed)
+	return nbrFailed == 0
+}
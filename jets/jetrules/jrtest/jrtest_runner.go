@@ -0,0 +1,288 @@
+package jrtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/artisoft-io/jetstore/jets/compilerv2/compiler"
+	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+	"github.com/artisoft-io/jetstore/jets/jetrules/rete"
+	"github.com/google/uuid"
+)
+
+// Runner executing the test cases of .jrtest files with the go rete engine.
+// Each test case runs in its own ReteMetaStoreFactory, built from the compiled
+// jetrule model of the main rule file, so the rule_config of a test case
+// does not leak into the next one.
+
+// ModelLoader returns the compiled jetrule model of a main rule file,
+// e.g. by compiling the rule file with compilerv2
+type ModelLoader func(mainRuleFile string) (*rete.JetruleModel, error)
+
+// CompilerModelLoader returns the ModelLoader compiling the main rule files with compilerv2,
+// the main rule files are relative to workspacePath
+func CompilerModelLoader(workspacePath string, trace, autoAddResources bool) ModelLoader {
+	return func(mainRuleFile string) (*rete.JetruleModel, error) {
+		log.Println("Compiling main rule file:", mainRuleFile)
+		jrCompiler := compiler.NewCompiler(workspacePath, mainRuleFile, false, trace, autoAddResources)
+		err := jrCompiler.Compile()
+		if err != nil {
+			return nil, err
+		}
+		if jrCompiler.ErrorLog().Len() > 0 {
+			return nil, fmt.Errorf("compilation errors:\n%s", jrCompiler.ErrorLog().String())
+		}
+		return jrCompiler.JetRuleModel(), nil
+	}
+}
+
+type Runner struct {
+	loadModel ModelLoader
+	// workspaceControl of the workspace, nil when not available
+	workspaceControl *rete.WorkspaceControl
+	// lookupDbPath is the sqlite file of the lookup tables, default is
+	// $WORKSPACES_HOME/$WORKSPACE/lookup.db
+	lookupDbPath string
+	// compiled model as json, keyed by main rule file
+	models map[string][]byte
+}
+
+// TestResult is the outcome of a test case, Failures contains the diffs
+// between the expected and the actual triples, Error is set when the test case
+// could not run.
+type TestResult struct {
+	Name     string
+	Failures []string
+	Error    error
+	Duration time.Duration
+}
+
+func (r *TestResult) Passed() bool {
+	return r.Error == nil && len(r.Failures) == 0
+}
+
+// SuiteResult is the outcome of a test file, Error is set when the main
+// rule file could not be compiled.
+type SuiteResult struct {
+	FileName     string
+	MainRuleFile string
+	Results      []*TestResult
+	Error        error
+	Duration     time.Duration
+}
+
+func (s *SuiteResult) Counts() (tests, failures, errors int) {
+	for _, r := range s.Results {
+		tests++
+		switch {
+		case r.Error != nil:
+			errors++
+		case len(r.Failures) > 0:
+			failures++
+		}
+	}
+	if s.Error != nil {
+		errors++
+	}
+	return
+}
+
+func NewRunner(loadModel ModelLoader) *Runner {
+	return &Runner{
+		loadModel: loadModel,
+		models:    make(map[string][]byte),
+	}
+}
+
+// SetWorkspaceControl sets the workspace control used to build the rete meta stores
+func (r *Runner) SetWorkspaceControl(workspaceControl *rete.WorkspaceControl) {
+	r.workspaceControl = workspaceControl
+}
+
+// SetLookupDbPath sets the sqlite file of the lookup tables used by the rules
+func (r *Runner) SetLookupDbPath(lookupDbPath string) {
+	r.lookupDbPath = lookupDbPath
+}
+
+// RunFile runs all the test cases of testFile
+func (r *Runner) RunFile(testFile *TestFile) *SuiteResult {
+	start := time.Now()
+	suite := &SuiteResult{
+		FileName:     testFile.FileName,
+		MainRuleFile: testFile.MainRuleFile,
+	}
+	_, err := r.getModel(testFile.MainRuleFile)
+	if err != nil {
+		suite.Error = err
+		suite.Duration = time.Since(start)
+		return suite
+	}
+	for i := range testFile.TestCases {
+		suite.Results = append(suite.Results, r.RunTestCase(testFile.MainRuleFile, &testFile.TestCases[i]))
+	}
+	suite.Duration = time.Since(start)
+	return suite
+}
+
+// getModel returns the compiled model of mainRuleFile as json, compiled once per runner
+func (r *Runner) getModel(mainRuleFile string) ([]byte, error) {
+	data, ok := r.models[mainRuleFile]
+	if ok {
+		return data, nil
+	}
+	model, err := r.loadModel(mainRuleFile)
+	if err != nil {
+		return nil, fmt.Errorf("while compiling main rule file %s: %v", mainRuleFile, err)
+	}
+	data, err = json.Marshal(model)
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling the jetrule model of %s: %v", mainRuleFile, err)
+	}
+	r.models[mainRuleFile] = data
+	return data, nil
+}
+
+// RunTestCase asserts the rule config and the input triples, executes the rules
+// and checks the expected and absent triples
+func (r *Runner) RunTestCase(mainRuleFile string, tc *TestCase) *TestResult {
+	start := time.Now()
+	result := &TestResult{Name: tc.Name}
+	result.Failures, result.Error = r.runTestCase(mainRuleFile, tc)
+	result.Duration = time.Since(start)
+	return result
+}
+
+func (r *Runner) runTestCase(mainRuleFile string, tc *TestCase) ([]string, error) {
+	data, err := r.getModel(mainRuleFile)
+	if err != nil {
+		return nil, err
+	}
+	model := &rete.JetruleModel{}
+	err = json.Unmarshal(data, model)
+	if err != nil {
+		return nil, fmt.Errorf("while unmarshaling the jetrule model of %s: %v", mainRuleFile, err)
+	}
+	factory, err := rete.NewReteMetaStoreFactoryFromModels(r.workspaceControl, r.lookupDbPath,
+		map[string]*rete.JetruleModel{mainRuleFile: model})
+	if err != nil {
+		return nil, fmt.Errorf("while building the rete meta store of %s: %v", mainRuleFile, err)
+	}
+	// Rule config goes to the meta graph, must be done before creating the rdf session
+	// since the meta resource manager is locked by the rdf session
+	err = assertRuleConfig(factory.ResourceMgr, factory.MetaGraph, tc.RuleConfig)
+	if err != nil {
+		return nil, err
+	}
+	rdfSession := rdf.NewRdfSession(factory.ResourceMgr, factory.MetaGraph)
+	rm := rdfSession.ResourceMgr
+	for _, t3 := range tc.InputTriples {
+		s, p, o, err := newTriple(rm, t3, false)
+		if err != nil {
+			return nil, fmt.Errorf("while asserting input triple %s: %v", tripleString(t3), err)
+		}
+		_, err = rdfSession.Insert(s, p, o)
+		if err != nil {
+			return nil, fmt.Errorf("while asserting input triple %s: %v", tripleString(t3), err)
+		}
+	}
+	reteSession := rete.NewReteSession(rdfSession)
+	reteSession.Initialize(factory.MetaStoreLookup[mainRuleFile])
+	defer reteSession.Done()
+	err = reteSession.ExecuteRules()
+	if err != nil {
+		return nil, fmt.Errorf("while executing rules: %v", err)
+	}
+
+	// Compare the rdf session with the expected and absent triples
+	var failures []string
+	for _, t3 := range tc.ExpectedTriples {
+		s, p, o, err := newTriple(rm, t3, true)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing expected triple %s: %v", tripleString(t3), err)
+		}
+		if len(findTriples(rdfSession, s, p, o)) == 0 {
+			msg := fmt.Sprintf("missing expected triple %s", tripleString(t3))
+			if s != nil && p != nil {
+				if actual := findTriples(rdfSession, s, p, nil); len(actual) > 0 {
+					msg = fmt.Sprintf("%s, found %v", msg, actual)
+				}
+			}
+			failures = append(failures, msg)
+		}
+	}
+	for _, t3 := range tc.AbsentTriples {
+		s, p, o, err := newTriple(rm, t3, true)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing absent triple %s: %v", tripleString(t3), err)
+		}
+		if actual := findTriples(rdfSession, s, p, o); len(actual) > 0 {
+			failures = append(failures, fmt.Sprintf("unexpected triple %s, found %v", tripleString(t3), actual))
+		}
+	}
+	return failures, nil
+}
+
+// newTriple makes the nodes of t3, wildcard terms are nil when allowWildcard
+func newTriple(rm *rdf.ResourceManager, t3 []any, allowWildcard bool) (s, p, o *rdf.Node, err error) {
+	nodes := make([]*rdf.Node, 3)
+	for i := range t3 {
+		if isWildcard(t3[i]) {
+			if !allowWildcard {
+				return nil, nil, nil, fmt.Errorf("error: wildcard %v not allowed in input triples", t3[i])
+			}
+			continue
+		}
+		nodes[i], err = newNode(rm, t3[i])
+		if err != nil {
+			return
+		}
+	}
+	return nodes[0], nodes[1], nodes[2], nil
+}
+
+// findTriples returns the triples of the rdf session matching (s, p, o) as text
+func findTriples(rdfSession *rdf.RdfSession, s, p, o *rdf.Node) []string {
+	var triples []string
+	itor := rdfSession.FindSPO(s, p, o)
+	for t3 := range itor.Itor {
+		triples = append(triples, rdf.ToString(&t3))
+	}
+	itor.Done()
+	return triples
+}
+
+// assertRuleConfig asserts the rule config to the meta graph, as done by the jetrules
+// pipeline: the subject is jets:key, or a generated resource, and string values are text
+func assertRuleConfig(rm *rdf.ResourceManager, metaGraph *rdf.RdfGraph, ruleConfig []map[string]any) error {
+	for _, rc := range ruleConfig {
+		subjectTxt := uuid.New().String()
+		if key, ok := rc["jets:key"]; ok {
+			switch vv := key.(type) {
+			case string:
+				subjectTxt = vv
+			case map[string]any:
+				subjectTxt = fmt.Sprintf("%v", vv["value"])
+			}
+		}
+		subject := rm.NewResource(subjectTxt)
+		for predicateTxt, value := range rc {
+			var object *rdf.Node
+			var err error
+			if v, ok := value.(string); ok {
+				object = rm.NewTextLiteral(v)
+			} else {
+				object, err = newNode(rm, value)
+				if err != nil {
+					return fmt.Errorf("while asserting rule_config %s: %v", predicateTxt, err)
+				}
+			}
+			_, err = metaGraph.Insert(subject, rm.NewResource(predicateTxt), object)
+			if err != nil {
+				return fmt.Errorf("while asserting rule_config %s: %v", predicateTxt, err)
+			}
+		}
+	}
+	return nil
+}
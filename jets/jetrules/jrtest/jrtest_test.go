@@ -0,0 +1,188 @@
+package jrtest
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+// This file contains test cases for the JetRule unit test framework
+// The main rule files in testdata are compiled with compilerv2
+
+var testdataLoader = CompilerModelLoader("testdata", false, false)
+
+func TestRunTestFile(t *testing.T) {
+	testFile, err := LoadTestFile("testdata/rete_test1.jrtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	suite := NewRunner(testdataLoader).RunFile(testFile)
+	if suite.Error != nil {
+		t.Fatal(suite.Error)
+	}
+	for _, r := range suite.Results {
+		if !r.Passed() {
+			t.Errorf("test case '%s' failed: %v %v", r.Name, r.Error, r.Failures)
+		}
+	}
+	var buf bytes.Buffer
+	if !WriteSummary(&buf, []*SuiteResult{suite}) {
+		t.Errorf("expecting summary to pass:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "2 test case(s), 2 passed, 0 failed") {
+		t.Errorf("unexpected summary:\n%s", buf.String())
+	}
+}
+
+func TestRunTestCaseFailures(t *testing.T) {
+	runner := NewRunner(testdataLoader)
+	data := `{
+		"main_rule_file": "rete_test1.jr",
+		"test_cases": [{
+			"name": "wrong expectations",
+			"rule_config": [{"jets:key": "cfg", "abc:limit": {"type": "int", "value": "5"}}],
+			"input_triples": [
+				["c1", "rdf:type", "abc:RuleConfig"],
+				["c1", "abc:RelatedTo", "c2"],
+				["c1", "abc:OutputUnit", 0]
+			],
+			"expected_triples": [["c1", "abc:OutputUnit", 3], ["c2", "?", "?"]],
+			"absent_triples": [["c1", "abc:OutputUnit", 1]]
+		}, {
+			"name": "invalid term",
+			"input_triples": [["c1", "abc:date", {"type": "date", "value": "not a date"}]],
+			"expected_triples": [["c1", "abc:OutputUnit", 1]]
+		}]
+	}`
+	testFile, err := ParseTestFile([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	suite := runner.RunFile(testFile)
+	r := suite.Results[0]
+	if r.Error != nil || len(r.Failures) != 3 {
+		t.Fatalf("expecting 3 failures, got %v %v", r.Error, r.Failures)
+	}
+	if !strings.Contains(r.Failures[0], "missing expected triple (c1 abc:OutputUnit 3), found") {
+		t.Errorf("unexpected failure: %s", r.Failures[0])
+	}
+	if !strings.Contains(r.Failures[2], "unexpected triple (c1 abc:OutputUnit 1)") {
+		t.Errorf("unexpected failure: %s", r.Failures[2])
+	}
+	if suite.Results[1].Error == nil {
+		t.Error("expecting an error for the invalid date")
+	}
+	tests, failures, errors := suite.Counts()
+	if tests != 2 || failures != 1 || errors != 1 {
+		t.Errorf("unexpected counts: %d, %d, %d", tests, failures, errors)
+	}
+
+	// JUnit report
+	var buf bytes.Buffer
+	err = WriteJUnitReport(&buf, []*SuiteResult{suite})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report junitTestSuites
+	err = xml.Unmarshal(buf.Bytes(), &report)
+	if err != nil {
+		t.Fatalf("invalid junit report: %v\n%s", err, buf.String())
+	}
+	if report.Tests != 2 || report.Failures != 1 || report.Errors != 1 || len(report.Suites[0].TestCases) != 2 {
+		t.Errorf("unexpected junit report:\n%s", buf.String())
+	}
+	if report.Suites[0].TestCases[0].Failure == nil || report.Suites[0].TestCases[1].Error == nil {
+		t.Errorf("unexpected junit test cases:\n%s", buf.String())
+	}
+}
+
+func TestRunTestFileCompileError(t *testing.T) {
+	testFile, err := ParseTestFile([]byte(`{"main_rule_file": "missing.jr",
+		"test_cases": [{"name": "t1", "expected_triples": [["a", "b", "c"]]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	suite := NewRunner(testdataLoader).RunFile(testFile)
+	if suite.Error == nil || len(suite.Results) != 0 {
+		t.Fatalf("expecting a compile error")
+	}
+	var buf bytes.Buffer
+	if WriteSummary(&buf, []*SuiteResult{suite}) {
+		t.Error("expecting summary to fail")
+	}
+}
+
+func TestParseTestFileErrors(t *testing.T) {
+	tests := []string{
+		`{"test_cases": [{"name": "t1", "expected_triples": [["a", "b", "c"]]}]}`,
+		`{"main_rule_file": "a.jr"}`,
+		`{"main_rule_file": "a.jr", "test_cases": [{"expected_triples": [["a", "b", "c"]]}]}`,
+		`{"main_rule_file": "a.jr", "test_cases": [{"name": "t1"}]}`,
+		`{"main_rule_file": "a.jr", "test_cases": [{"name": "t1", "expected_triples": [["a", "b"]]}]}`,
+		`{"main_rule_file": "a.jr", "test_cases": [{"name": "t1", "expected_triples": [["a", "b", "c"]]},
+			{"name": "t1", "expected_triples": [["a", "b", "c"]]}]}`,
+	}
+	for i, data := range tests {
+		if _, err := ParseTestFile([]byte(data)); err == nil {
+			t.Errorf("test %d: expecting an error", i)
+		}
+	}
+}
+
+func TestRunTestFileLookupDb(t *testing.T) {
+	workspacePath := t.TempDir()
+	err := os.WriteFile(filepath.Join(workspacePath, "lookup_test.jr"), []byte(`
+		lookup_table acme:StateLookup {
+			$csv_file = "lookups/state.csv",
+			$key = ["STATE"],
+			$columns = ["STATE" as text, "NAME" as text]
+		};
+		resource abc:RuleConfig = "abc:RuleConfig";
+		resource OutputUnit = "abc:OutputUnit";
+		[R01]: (?config rdf:type abc:RuleConfig) -> (?config OutputUnit 1);`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// lookup.db of the workspace
+	db, err := sql.Open("sqlite", filepath.Join(workspacePath, "lookup.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE "acme:StateLookup" (__key__ INTEGER, "jets:key" TEXT, "STATE" TEXT, "NAME" TEXT);
+		INSERT INTO "acme:StateLookup" VALUES (0, 'NY', 'NY', 'New York')`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testFile, err := ParseTestFile([]byte(`{"main_rule_file": "lookup_test.jr",
+		"test_cases": [{"name": "t1", "input_triples": [["c1", "rdf:type", "abc:RuleConfig"]],
+			"expected_triples": [["c1", "abc:OutputUnit", 1]]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	runner := NewRunner(CompilerModelLoader(workspacePath, false, false))
+	runner.SetLookupDbPath(filepath.Join(workspacePath, "lookup.db"))
+	suite := runner.RunFile(testFile)
+	if suite.Error != nil {
+		t.Fatal(suite.Error)
+	}
+	if !suite.Results[0].Passed() {
+		t.Fatalf("expecting the test case to pass using the lookup.db of the workspace, got %v %v",
+			suite.Results[0].Error, suite.Results[0].Failures)
+	}
+
+	// The lookup table is not found in another lookup.db
+	runner = NewRunner(CompilerModelLoader(workspacePath, false, false))
+	runner.SetLookupDbPath(filepath.Join(t.TempDir(), "lookup.db"))
+	suite = runner.RunFile(testFile)
+	if suite.Results[0].Error == nil || !strings.Contains(suite.Results[0].Error.Error(), "acme:StateLookup") {
+		t.Errorf("expecting an error for the missing lookup table, got %v", suite.Results[0].Error)
+	}
+}
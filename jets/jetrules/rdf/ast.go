@@ -276,6 +276,91 @@ func (v *Node) MarshalBinary() ([]byte, error) {
 	}
 }
 
+// UnmarshalBinary is the inverse of MarshalBinary, data is decoded according to the
+// type of v.Value which must be set to a value of the node type, e.g. BlankNode{} or int(0),
+// since the bytes of MarshalBinary are not tagged unambiguously.
+func (v *Node) UnmarshalBinary(data []byte) error {
+	if v == nil {
+		return fmt.Errorf("error: UnmarshalBinary called with null rdf.Node")
+	}
+	// fixed returns the 8 bytes value of the 9 bytes (BlankNode) or 11 bytes (numeric) layout
+	fixed := func(tag string) (uint64, bool) {
+		if len(data) != len(tag)+8 || string(data[:len(tag)]) != tag {
+			return 0, false
+		}
+		var u uint64
+		for _, b := range data[len(tag):] {
+			u = u<<8 | uint64(b)
+		}
+		return u, true
+	}
+	// tagged returns data without its trailing tag
+	tagged := func(tag byte) ([]byte, bool) {
+		if len(data) == 0 || data[len(data)-1] != tag {
+			return nil, false
+		}
+		return data[:len(data)-1], true
+	}
+	switch v.Value.(type) {
+	case BlankNode:
+		if u, ok := fixed("B"); ok {
+			v.Value = BlankNode{Key: int(u)}
+			return nil
+		}
+	case NamedResource:
+		if name, ok := tagged('R'); ok {
+			v.Value = NamedResource{Name: string(name)}
+			return nil
+		}
+	case LDate:
+		if md, ok := tagged('D'); ok {
+			t := new(time.Time)
+			if err := t.UnmarshalBinary(md); err != nil {
+				return fmt.Errorf("while unmarshalling date rdf.Node: %v", err)
+			}
+			v.Value = LDate{Date: t}
+			return nil
+		}
+	case LDatetime:
+		if mt, ok := tagged('T'); ok {
+			t := new(time.Time)
+			if err := t.UnmarshalBinary(mt); err != nil {
+				return fmt.Errorf("while unmarshalling datetime rdf.Node: %v", err)
+			}
+			v.Value = LDatetime{Datetime: t}
+			return nil
+		}
+	case int:
+		if u, ok := fixed("I00"); ok {
+			v.Value = int(u)
+			return nil
+		}
+	case uint:
+		if u, ok := fixed("U00"); ok {
+			v.Value = uint(u)
+			return nil
+		}
+	case float64:
+		if u, ok := fixed("F64"); ok {
+			v.Value = math.Float64frombits(u)
+			return nil
+		}
+	case string:
+		if s, ok := tagged('S'); ok {
+			v.Value = string(s)
+			return nil
+		}
+	case RdfNull:
+		if string(data) == "RDFNULL" {
+			return nil
+		}
+	default:
+		return fmt.Errorf("error: unknown type for rdf.Node in UnmarshalBinary: %v",
+			reflect.TypeOf(v.Value))
+	}
+	return fmt.Errorf("error: invalid %s data for rdf.Node in UnmarshalBinary", v.GetTypeName())
+}
+
 type Triple = [3]*Node
 
 // func (t *Triple) String() string {
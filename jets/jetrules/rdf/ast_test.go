@@ -182,3 +182,26 @@ func TestMarshalBinary(t *testing.T) {
 		t.Errorf("Unexpected number of distinct hashed values, got %d, expecting 16", len(hashed))
 	}
 }
+
+func TestUnmarshalBinary(t *testing.T) {
+	d := DD("2024-06-20")
+	dt := DDT("2024-06-20T10:20:30.5")
+	for _, node := range []*Node{Null(), BN(1101123), R("hc:claim"), d, dt, I(-5), UI(7), F(1.05), S("text"), S("")} {
+		data, err := node.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := &Node{Value: snapshotNodeValues[byte(node.GetType())]}
+		if err = n.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%s: %v", node.GetTypeName(), err)
+		}
+		if n.GetType() != node.GetType() || n.String() != node.String() {
+			t.Errorf("Expecting %s %s, got %s %s", node.GetTypeName(), node, n.GetTypeName(), n)
+		}
+	}
+	// The data must match the node type
+	data, _ := S("text").MarshalBinary()
+	if err := (&Node{Value: 0}).UnmarshalBinary(data); err == nil {
+		t.Error("Expecting an error unmarshalling a string as an int")
+	}
+}
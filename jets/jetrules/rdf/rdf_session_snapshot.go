@@ -0,0 +1,195 @@
+package rdf
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// This file contains the binary snapshot of the asserted and inferred graphs of RdfSession.
+// The meta graph is not part of the snapshot, it is provided when restoring the session.
+//
+// Snapshot format:
+//    - header: "JRSS" followed by the format version byte
+//    - node table: uvarint count, then for each node:
+//        type byte (see Node.GetType), uvarint length, Node.MarshalBinary bytes
+//    - asserted graph then inferred graph: uvarint triple count, then for each triple:
+//        uvarint index of s, p, o in the node table
+
+const snapshotMagic = "JRSS"
+const snapshotVersion byte = 1
+
+// Graphs of the snapshot, in order
+var snapshotGraphs = []string{"ASSERTED", "INFERRED"}
+
+// Snapshot writes the asserted and inferred graphs of the session to w
+func (rs *RdfSession) Snapshot(w io.Writer) error {
+	// Collect the nodes and triples of the graphs
+	nodeIdx := make(map[*Node]uint64)
+	nodes := make([]*Node, 0, 256)
+	graphs := []*RdfGraph{rs.AssertedGraph, rs.InferredGraph}
+	triples := make([][]Triple, len(graphs))
+	for i, g := range graphs {
+		triples[i] = make([]Triple, 0, g.Size())
+		itor := g.Find()
+		for t3 := range itor.Itor {
+			for _, n := range t3 {
+				if _, ok := nodeIdx[n]; !ok {
+					nodeIdx[n] = uint64(len(nodes))
+					nodes = append(nodes, n)
+				}
+			}
+			triples[i] = append(triples[i], t3)
+		}
+		itor.Done()
+	}
+
+	bw := bufio.NewWriter(w)
+	buf := make([]byte, binary.MaxVarintLen64)
+	writeUvarint := func(v uint64) error {
+		_, err := bw.Write(buf[:binary.PutUvarint(buf, v)])
+		return err
+	}
+	if _, err := bw.WriteString(snapshotMagic); err != nil {
+		return fmt.Errorf("while writing rdf session snapshot header: %v", err)
+	}
+	if err := bw.WriteByte(snapshotVersion); err != nil {
+		return fmt.Errorf("while writing rdf session snapshot header: %v", err)
+	}
+	if err := writeUvarint(uint64(len(nodes))); err != nil {
+		return fmt.Errorf("while writing rdf session snapshot node table: %v", err)
+	}
+	for _, n := range nodes {
+		data, err := n.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("while marshalling node %s for rdf session snapshot: %v", n, err)
+		}
+		if err = bw.WriteByte(byte(n.GetType())); err != nil {
+			return fmt.Errorf("while writing rdf session snapshot node table: %v", err)
+		}
+		if err = writeUvarint(uint64(len(data))); err != nil {
+			return fmt.Errorf("while writing rdf session snapshot node table: %v", err)
+		}
+		if _, err = bw.Write(data); err != nil {
+			return fmt.Errorf("while writing rdf session snapshot node table: %v", err)
+		}
+	}
+	for i := range graphs {
+		if err := writeUvarint(uint64(len(triples[i]))); err != nil {
+			return fmt.Errorf("while writing rdf session snapshot of graph %s: %v", snapshotGraphs[i], err)
+		}
+		for _, t3 := range triples[i] {
+			for _, n := range t3 {
+				if err := writeUvarint(nodeIdx[n]); err != nil {
+					return fmt.Errorf("while writing rdf session snapshot of graph %s: %v", snapshotGraphs[i], err)
+				}
+			}
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("while writing rdf session snapshot: %v", err)
+	}
+	return nil
+}
+
+// RestoreSession returns a new RdfSession with the asserted and inferred graphs read
+// from the snapshot in r, rootRm and metaGraph are as in NewRdfSession
+func RestoreSession(r io.Reader, rootRm *ResourceManager, metaGraph *RdfGraph) (*RdfSession, error) {
+	rs := NewRdfSession(rootRm, metaGraph)
+	if rs == nil {
+		return nil, fmt.Errorf("error: RestoreSession called with nil ResourceManager or meta graph")
+	}
+	br := bufio.NewReader(r)
+	header := make([]byte, len(snapshotMagic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("while reading rdf session snapshot header: %v", err)
+	}
+	if string(header[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, fmt.Errorf("error: not an rdf session snapshot")
+	}
+	if header[len(snapshotMagic)] != snapshotVersion {
+		return nil, fmt.Errorf("error: unsupported rdf session snapshot version %d", header[len(snapshotMagic)])
+	}
+
+	// Read the node table
+	count, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("while reading rdf session snapshot node table: %v", err)
+	}
+	nodes := make([]*Node, 0, count)
+	for i := uint64(0); i < count; i++ {
+		nodeType, err := br.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("while reading rdf session snapshot node table: %v", err)
+		}
+		length, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("while reading rdf session snapshot node table: %v", err)
+		}
+		data := make([]byte, length)
+		if _, err = io.ReadFull(br, data); err != nil {
+			return nil, fmt.Errorf("while reading rdf session snapshot node table: %v", err)
+		}
+		n, err := unmarshalNode(nodeType, data)
+		if err != nil {
+			return nil, fmt.Errorf("while reading node %d of rdf session snapshot: %v", i, err)
+		}
+		nodes = append(nodes, rs.ResourceMgr.ReifyResource(n))
+	}
+
+	// Read the triples of the asserted and inferred graphs
+	for k, g := range []*RdfGraph{rs.AssertedGraph, rs.InferredGraph} {
+		count, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("while reading rdf session snapshot of graph %s: %v", snapshotGraphs[k], err)
+		}
+		for i := uint64(0); i < count; i++ {
+			var t3 Triple
+			for j := range t3 {
+				idx, err := binary.ReadUvarint(br)
+				if err != nil {
+					return nil, fmt.Errorf("while reading rdf session snapshot of graph %s: %v", snapshotGraphs[k], err)
+				}
+				if idx >= uint64(len(nodes)) {
+					return nil, fmt.Errorf("error: invalid node index %d in rdf session snapshot of graph %s", idx, snapshotGraphs[k])
+				}
+				t3[j] = nodes[idx]
+			}
+			if _, err = g.Insert(t3[0], t3[1], t3[2]); err != nil {
+				return nil, fmt.Errorf("while inserting triple in graph %s: %v", snapshotGraphs[k], err)
+			}
+		}
+	}
+	return rs, nil
+}
+
+// snapshotNodeValues are the values of each node type (see Node.GetType) that
+// Node.UnmarshalBinary decodes into
+var snapshotNodeValues = map[byte]any{
+	0:  RdfNull{},
+	1:  BlankNode{},
+	2:  NamedResource{},
+	5:  int(0),
+	6:  uint(0),
+	7:  float64(0),
+	8:  "",
+	9:  LDate{},
+	10: LDatetime{},
+}
+
+// unmarshalNode returns the node of type nodeType from the bytes of Node.MarshalBinary
+func unmarshalNode(nodeType byte, data []byte) (*Node, error) {
+	value, ok := snapshotNodeValues[nodeType]
+	if !ok {
+		return nil, fmt.Errorf("error: unknown node type %d in rdf session snapshot", nodeType)
+	}
+	n := &Node{Value: value}
+	if err := n.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	if n.IsNull() {
+		return Null(), nil
+	}
+	return n, nil
+}
@@ -0,0 +1,98 @@
+package rdf
+
+import (
+	"bytes"
+	"testing"
+)
+
+// This file contains test cases for the snapshot of RdfSession
+func TestRdfSessionSnapshot(t *testing.T) {
+	rootRm := NewResourceManager(nil)
+	metaGraph := NewMetaRdfGraph(rootRm)
+	rdfType := rootRm.NewResource("rdf:type")
+	claim := rootRm.NewResource("hc:Claim")
+	metaGraph.Insert(claim, rdfType, rootRm.NewResource("owl:Class"))
+
+	rdfSession := NewRdfSession(rootRm, metaGraph)
+	rm := rdfSession.ResourceMgr
+	c1 := rm.NewResource("c1")
+	bn := rm.NewBNode()
+	p := rm.NewResource("p")
+	rdfSession.Insert(c1, rdfType, claim)
+	rdfSession.Insert(c1, p, bn)
+	rdfSession.Insert(bn, p, rm.NewIntLiteral(-5))
+	rdfSession.Insert(bn, p, rm.NewUIntLiteral(7))
+	rdfSession.Insert(bn, p, rm.NewDoubleLiteral(3.14))
+	rdfSession.Insert(bn, p, rm.NewTextLiteral("hello"))
+	rdfSession.Insert(bn, p, Null())
+	date, err := NewLDate("2024-03-15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datetime, err := NewLDatetime("2024-03-15 10:20:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdfSession.InferredGraph.Insert(c1, p, rm.NewDateLiteral(date))
+	rdfSession.InferredGraph.Insert(c1, p, rm.NewDatetimeLiteral(datetime))
+
+	var buf bytes.Buffer
+	if err = rdfSession.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := RestoreSession(&buf, rootRm, metaGraph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.AssertedGraph.Size() != rdfSession.AssertedGraph.Size() {
+		t.Errorf("Expecting %d asserted triples, got %d", rdfSession.AssertedGraph.Size(), restored.AssertedGraph.Size())
+	}
+	if restored.InferredGraph.Size() != rdfSession.InferredGraph.Size() {
+		t.Errorf("Expecting %d inferred triples, got %d", rdfSession.InferredGraph.Size(), restored.InferredGraph.Size())
+	}
+	rm2 := restored.ResourceMgr
+	c1 = rm2.NewResource("c1")
+	p = rm2.NewResource("p")
+	bn2 := rm2.GetBNode(bn.Key())
+	switch {
+	case bn2 == nil:
+		t.Fatalf("Expecting blank node %d in restored session", bn.Key())
+	case !restored.AssertedGraph.Contains(c1, rdfType, claim):
+		t.Error("Expecting (c1 rdf:type hc:Claim) in restored asserted graph")
+	case !restored.AssertedGraph.Contains(c1, p, bn2):
+		t.Error("Expecting (c1 p bnode) in restored asserted graph")
+	case !restored.AssertedGraph.Contains(bn2, p, rm2.NewIntLiteral(-5)):
+		t.Error("Expecting int literal in restored asserted graph")
+	case !restored.AssertedGraph.Contains(bn2, p, rm2.NewUIntLiteral(7)):
+		t.Error("Expecting uint literal in restored asserted graph")
+	case !restored.AssertedGraph.Contains(bn2, p, rm2.NewDoubleLiteral(3.14)):
+		t.Error("Expecting double literal in restored asserted graph")
+	case !restored.AssertedGraph.Contains(bn2, p, rm2.NewTextLiteral("hello")):
+		t.Error("Expecting text literal in restored asserted graph")
+	case !restored.AssertedGraph.Contains(bn2, p, Null()):
+		t.Error("Expecting null in restored asserted graph")
+	case !restored.InferredGraph.Contains(c1, p, rm2.NewDateLiteral(date)):
+		t.Error("Expecting date literal in restored inferred graph")
+	case !restored.InferredGraph.Contains(c1, p, rm2.NewDatetimeLiteral(datetime)):
+		t.Error("Expecting datetime literal in restored inferred graph")
+	case !restored.Contains(claim, rdfType, rootRm.NewResource("owl:Class")):
+		t.Error("Expecting the meta graph in restored session")
+	}
+	// New blank nodes must not collide with the restored ones
+	if rm2.NewBNode().Key() == bn.Key() {
+		t.Error("Expecting a new blank node key after restore")
+	}
+}
+
+func TestRdfSessionSnapshotErr(t *testing.T) {
+	rootRm := NewResourceManager(nil)
+	metaGraph := NewMetaRdfGraph(rootRm)
+	_, err := RestoreSession(bytes.NewReader([]byte("JRXX\x01")), rootRm, metaGraph)
+	if err == nil {
+		t.Error("Expecting an error with invalid snapshot header")
+	}
+	_, err = RestoreSession(bytes.NewReader([]byte("JRSS\x01\x01\x02\x05")), rootRm, metaGraph)
+	if err == nil {
+		t.Error("Expecting an error with truncated snapshot")
+	}
+}
@@ -161,7 +161,11 @@ func (rm *ResourceManager) CreateBNode(key int) *Node {
 		return nil
 	}
 	r := BN(key)
-	rm.bnodeMap[rm.lastBnodeKey] = r
+	rm.bnodeMap[key] = r
+	// Keep NewBNode from reusing the key
+	if key >= rm.lastBnodeKey {
+		rm.lastBnodeKey = key + 1
+	}
 	return r
 }
 
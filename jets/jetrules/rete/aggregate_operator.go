@@ -0,0 +1,107 @@
+package rete
+
+import (
+	"fmt"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+)
+
+// AggregateOperator is the aggregate of an aggregate antecedent, e.g. for the aggregation
+// antecedent (?n = count(?line where (?c hc:has_line ?line))) or for the consequent term
+//
+//	(?c hc:nbr_lines count(?line where (?c hc:has_line ?line)))
+//
+// the aggregate antecedent is (?c hc:has_line ?line) with the count aggregate.
+// The beta relation of an aggregate antecedent has one beta row per parent beta row
+// rather than one beta row per matching triple. The beta row is initialized with
+// the parent beta row and the virtual triple (nil, nil, aggregate value).
+// The aggregate rows are maintained incrementally by the ReteSession,
+// see rete_session_aggregate.go
+type AggregateOperator struct {
+	Operator string
+	// position of the aggregated variable in the matching triples
+	VarPos int
+}
+
+func NewAggregateOperator(operator string, varPos int) (*AggregateOperator, error) {
+	switch operator {
+	case "count", "sum", "min", "max":
+	default:
+		return nil, fmt.Errorf("error: unknown aggregate operator '%s'", operator)
+	}
+	if varPos < 0 || varPos > 2 {
+		return nil, fmt.Errorf("error: invalid aggregated variable position %d for aggregate %s", varPos, operator)
+	}
+	return &AggregateOperator{
+		Operator: operator,
+		VarPos:   varPos,
+	}, nil
+}
+
+func (op *AggregateOperator) String() string {
+	return op.Operator
+}
+
+// Eval returns the aggregate of the triples of itor:
+//   - count: the number of triples, 0 when there are no triples
+//   - sum: the sum of the aggregated values, 0 when there are no triples
+//   - min, max: the min / max of the aggregated values, nil when there are no triples
+//
+// The null values are skipped. The count and sum are literals of the rdf session
+// resource manager so that an unchanged aggregate has the same value.
+func (op *AggregateOperator) Eval(reteSession *ReteSession, row *BetaRow, itor *rdf.RdfSessionIterator) *rdf.Node {
+	defer itor.Done()
+	switch op.Operator {
+	case "count":
+		count := 0
+		for range itor.Itor {
+			count++
+		}
+		return reteSession.RdfSession.ResourceMgr.NewIntLiteral(count)
+	case "sum":
+		addOp := NewAddOp()
+		sumValue := rdf.I(0)
+		for t3 := range itor.Itor {
+			v := t3[op.VarPos]
+			if v == nil || v == rdf.Null() {
+				continue
+			}
+			sumValue = addOp.Eval(reteSession, row, sumValue, v)
+			if sumValue == nil {
+				// not a numeric value
+				return nil
+			}
+		}
+		// Use the session literal so that the aggregate rows with the same value are equal
+		switch v := sumValue.Value.(type) {
+		case int:
+			return reteSession.RdfSession.ResourceMgr.NewIntLiteral(v)
+		case float64:
+			return reteSession.RdfSession.ResourceMgr.NewDoubleLiteral(v)
+		}
+		return sumValue
+	default:
+		// visitor is for: ll > rr, see MinMaxOp
+		gt := NewGtOp()
+		isMin := op.Operator == "min"
+		var result *rdf.Node
+		for t3 := range itor.Itor {
+			v := t3[op.VarPos]
+			if v == nil || v == rdf.Null() {
+				continue
+			}
+			if result == nil {
+				result = v
+				continue
+			}
+			ll, rr := v, result
+			if isMin {
+				ll, rr = result, v
+			}
+			if gt.Eval(reteSession, row, ll, rr).Bool() {
+				result = v
+			}
+		}
+		return result
+	}
+}
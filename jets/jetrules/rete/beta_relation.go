@@ -50,6 +50,7 @@ func (br *BetaRelation) InsertBetaRow(rs *ReteSession, row *BetaRow) {
 	// }
 	inserted, row := br.AllRows.Put(row)
 	if inserted {
+		rs.profileBetaRow(br.NdVertex.Vertex)
 		if row.NdVertex.HasConsequentTerms() {
 			// Flag row as new and pending to infer triples
 			row.Status = kInserted
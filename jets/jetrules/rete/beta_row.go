@@ -23,6 +23,9 @@ type BetaRow struct {
 	Status   BetaRowStatus
 	Data     []*rdf.Node
 	h        uint64
+
+	// provenance is set when the rete session is in provenance mode
+	provenance *provenanceInfo
 }
 
 func NewBetaRow(vertex *NodeVertex, size int) *BetaRow {
@@ -15,13 +15,22 @@ type LookupTableManager struct {
 	LookupTableMap map[string]LookupTable
 }
 
+// DefaultLookupDbPath returns the path of the sqlite file lookup.db located in the workspace root
+func DefaultLookupDbPath() string {
+	return fmt.Sprintf("%s/%s/lookup.db", workspaceHome, wprefix)
+}
+
 // NewLookupTableManager creates the LookupTableManager which is used in the context of a
 // single rete network (main rule file or a rule sequence).
 // This manager opens the DB connection to the sqlite file lookup.db located in the workspace root.
 // This connection is used by the lookup table of type 'sqlite3' (default type when not specified).
 func NewLookupTableManager(rmgr *rdf.ResourceManager, metaGraph *rdf.RdfGraph, jetruleModel *JetruleModel) (*LookupTableManager, error) {
-	// Open the connection to the lookup.db
-	dbPath := fmt.Sprintf("%s/%s/lookup.db", workspaceHome, wprefix)
+	return NewLookupTableManagerWithDb(rmgr, metaGraph, jetruleModel, DefaultLookupDbPath())
+}
+
+// NewLookupTableManagerWithDb creates the LookupTableManager using the sqlite file dbPath
+// for the lookup tables of type 'sqlite3'.
+func NewLookupTableManagerWithDb(rmgr *rdf.ResourceManager, metaGraph *rdf.RdfGraph, jetruleModel *JetruleModel, dbPath string) (*LookupTableManager, error) {
 	var lookupDb *sql.DB
 	var err error
 
@@ -17,6 +17,8 @@ type NodeVertex struct {
 	RowInitializer       *BetaRowInitializer
 	AntecedentQueryKey   int
 	AssociatedRules      []string
+	// Aggregate is set for aggregate antecedents, see AggregateOperator
+	Aggregate *AggregateOperator
 }
 
 func NewNodeVertex(vertex int, parent *NodeVertex, isNeg bool, salience int,
@@ -50,6 +52,10 @@ func (node *NodeVertex) HasExpression() bool {
 	return node.FilterExpr != nil
 }
 
+func (node *NodeVertex) IsAggregate() bool {
+	return node.Aggregate != nil
+}
+
 // Children as descendent NodeVertex (antecedent AlphaNode)
 func (node *NodeVertex) HasChildren() bool {
 	return len(node.ChildAlphaNodes) > 0
@@ -56,6 +56,7 @@ func (cm *ReteCallback) tripleUpdated(s, p, o *rdf.Node, isInserted bool) {
 		return
 	}
 
+	cm.reteSession.profileAlphaCallback(cm.vertex)
 	if cm.forFilterTerm {
 		cm.reteSession.TripleUpdatedForFilter(cm.vertex, s, p, o, isInserted)
 	} else {
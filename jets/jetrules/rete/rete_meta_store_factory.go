@@ -38,6 +38,7 @@ func init() {
 // Note: single MetaGraph for all reteMetaStores
 type ReteMetaStoreFactory struct {
 	WorkspaceCtrl     *WorkspaceControl
+	LookupDbPath      string
 	MainRuleFileNames []string
 	ResourceMgr       *rdf.ResourceManager
 	MetaGraph         *rdf.RdfGraph
@@ -53,6 +54,7 @@ type ReteMetaStoreFactory struct {
 type ReteBuilderContext struct {
 	ResourceMgr     *rdf.ResourceManager
 	WorkspaceCtrl   *WorkspaceControl
+	LookupDbPath    string
 	MetaGraph       *rdf.RdfGraph
 	ResourcesLookup map[int]*rdf.Node
 	VariablesLookup map[int]*VarInfo
@@ -87,6 +89,7 @@ func NewReteMetaStoreFactory(jetRuleName string) (*ReteMetaStoreFactory, error)
 	resourceManager := rdf.NewResourceManager(nil)
 	factory := &ReteMetaStoreFactory{
 		WorkspaceCtrl:     workspaceControl,
+		LookupDbPath:      DefaultLookupDbPath(),
 		MainRuleFileNames: workspaceControl.RuleFileNames(jetRuleName),
 		ResourceMgr:       resourceManager,
 		MetaGraph:         rdf.NewMetaRdfGraph(resourceManager),
@@ -148,6 +151,40 @@ func NewReteMetaStoreFactory(jetRuleName string) (*ReteMetaStoreFactory, error)
 	return factory, nil
 }
 
+// Create the factory from jetrule models already in memory, e.g. compiled by compilerv2,
+// rather than loading the json files from the workspace build directory.
+// Argument models is keyed by main rule file name.
+// Argument lookupDbPath is the sqlite file of the lookup tables, DefaultLookupDbPath() when empty.
+// Note: the models are modified while building the ReteMetaStore, do not reuse them.
+func NewReteMetaStoreFactoryFromModels(workspaceControl *WorkspaceControl, lookupDbPath string, models map[string]*JetruleModel) (*ReteMetaStoreFactory, error) {
+	if len(models) == 0 {
+		return nil, fmt.Errorf("error: NewReteMetaStoreFactoryFromModels requires at least one jetrule model")
+	}
+	if lookupDbPath == "" {
+		lookupDbPath = DefaultLookupDbPath()
+	}
+	resourceManager := rdf.NewResourceManager(nil)
+	factory := &ReteMetaStoreFactory{
+		WorkspaceCtrl:     workspaceControl,
+		LookupDbPath:      lookupDbPath,
+		MainRuleFileNames: make([]string, 0, len(models)),
+		ResourceMgr:       resourceManager,
+		MetaGraph:         rdf.NewMetaRdfGraph(resourceManager),
+		MetaStoreLookup:   make(map[string]*ReteMetaStore),
+		ReteModelLookup:   models,
+	}
+	for ruleFileName := range models {
+		factory.MainRuleFileNames = append(factory.MainRuleFileNames, ruleFileName)
+	}
+	sort.Strings(factory.MainRuleFileNames)
+	err := factory.initialize()
+	if err != nil {
+		log.Printf("while loading the ReteMetaStore from jetrule model:%v\n", err)
+		return nil, err
+	}
+	return factory, nil
+}
+
 // Transform the jetrule models into a set of ReteMetaStore
 func (factory *ReteMetaStoreFactory) initialize() error {
 	// Note: single ResourceManager for all reteMetaStores
@@ -157,6 +194,7 @@ func (factory *ReteMetaStoreFactory) initialize() error {
 		builderContext := &ReteBuilderContext{
 			ResourceMgr:     factory.ResourceMgr,
 			WorkspaceCtrl:   factory.WorkspaceCtrl,
+			LookupDbPath:    factory.LookupDbPath,
 			MetaGraph:       factory.MetaGraph,
 			ResourcesLookup: make(map[int]*rdf.Node),
 			VariablesLookup: make(map[int]*VarInfo),
@@ -200,7 +238,7 @@ func (ctx *ReteBuilderContext) BuildReteMetaStore() (*ReteMetaStore, error) {
 	}
 
 	// Load LookupTableManager
-	ctx.LookupTables, err = NewLookupTableManager(ctx.ResourceMgr, ctx.MetaGraph, ctx.JetruleModel)
+	ctx.LookupTables, err = NewLookupTableManagerWithDb(ctx.ResourceMgr, ctx.MetaGraph, ctx.JetruleModel, ctx.LookupDbPath)
 	if err != nil {
 		return nil, fmt.Errorf("while calling LoadLookupTables for ruleUri %s: %v", ctx.MainRuleUri, err)
 	}
@@ -483,9 +521,16 @@ func (ctx *ReteBuilderContext) loadNodeVertices() error {
 		if len(reteNode.Salience) > 0 {
 			salience = slices.Min(reteNode.Salience)
 		}
-		ctx.NodeVertices = append(ctx.NodeVertices,
-			NewNodeVertex(reteNode.Vertex, parent, reteNode.IsNot, salience, filterExpr,
-				reteNode.NormalizedLabel, reteNode.Rules, brInitializer))
+		nodeVertex := NewNodeVertex(reteNode.Vertex, parent, reteNode.IsNot, salience, filterExpr,
+			reteNode.NormalizedLabel, reteNode.Rules, brInitializer)
+		// Aggregate antecedent
+		if reteNode.Aggregate != nil {
+			nodeVertex.Aggregate, err = NewAggregateOperator(reteNode.Aggregate.Operator, reteNode.Aggregate.VarPos)
+			if err != nil {
+				return fmt.Errorf("while making the aggregate for NodeVertex at %d: %v", reteNode.Vertex, err)
+			}
+		}
+		ctx.NodeVertices = append(ctx.NodeVertices, nodeVertex)
 	}
 	return nil
 }
@@ -110,6 +110,8 @@ type LookupTableDataInfo struct {
 // IsValid indicates if the rule passed validation, if not it won't be used in the rete network
 type JetruleNode struct {
 	Name            string            `json:"name,omitempty"`
+	// Name of the authored rule when the rule is generated by the expansion of its or blocks
+	BranchOf        string            `json:"branch_of,omitempty"`
 	Properties      map[string]string `json:"properties,omitempty"`
 	Optimization    bool              `json:"optimization,omitzero"`
 	Salience        int               `json:"salience,omitzero"`
@@ -136,6 +138,8 @@ type JetruleNode struct {
 // SubjectKey, PredicateKey, ObjectKey are the keys of the resources in the model.
 // ObjectExpr is an expression node that represents the object if it is an expression.
 // Filter is an expression node that represents the filter applied to the antecedent.
+// Aggregate is the aggregate term of an aggregation antecedent or of a consequent, or the
+// aggregate of an aggregate antecedent of the rete network (see AggregateNode).
 type RuleTerm struct {
 	Type               string          `json:"type,omitempty"`
 	IsNot              bool            `json:"isNot,omitzero"`
@@ -156,6 +160,33 @@ type RuleTerm struct {
 	ObjectKey          int             `json:"object_key,omitzero"`
 	ObjectExpr         *ExpressionNode `json:"obj_expr,omitempty"`
 	Filter             *ExpressionNode `json:"filter,omitempty"`
+	Aggregate          *AggregateNode  `json:"aggregate,omitempty"`
+}
+
+// AggregateNode represents an aggregate term, e.g. of the aggregation antecedent:
+//
+//	(?n = count(?line where (?c hc:has_line ?line)))
+//
+// or the object of the consequent:
+//
+//	(?c hc:nbr_lines count(?line where (?c hc:has_line ?line)))
+//
+// Operator is one of count, sum, min, max.
+// VarKey is the key of the aggregated variable (?line).
+// ResultKey is the key of the variable holding the aggregate value (?n), for the
+// aggregate term of a consequent it is a var created for the aggregate antecedent.
+// SubjectKey, PredicateKey, ObjectKey are the keys of the resources of the where triple.
+// The rete network has a dedicated aggregate antecedent for the aggregate term, this antecedent
+// is the where triple, its Aggregate node has the Operator, ResultKey and VarPos, the position
+// of the aggregated variable in the where triple (0 = subject, 1 = predicate, 2 = object).
+type AggregateNode struct {
+	Operator     string `json:"operator,omitempty"`
+	VarKey       int    `json:"var_key,omitzero"`
+	VarPos       int    `json:"var_pos,omitzero"`
+	ResultKey    int    `json:"result_key,omitzero"`
+	SubjectKey   int    `json:"subject_key,omitzero"`
+	PredicateKey int    `json:"predicate_key,omitzero"`
+	ObjectKey    int    `json:"object_key,omitzero"`
 }
 
 func (rt *RuleTerm) UniqueKey() string {
@@ -19,6 +19,10 @@ type ReteSession struct {
 	pendingComputeConsequent *BetaRowPriorityQueue
 	maxVertexVisits          int
 	maxVertexVisitReached    bool
+	provenance               *provenanceStore
+	profile                  *sessionProfile
+	// aggregate beta row by parent beta row, for the aggregate antecedents
+	aggregateRows map[aggregateKey]*BetaRow
 }
 
 type VisitCount struct {
@@ -76,6 +80,7 @@ func (rs *ReteSession) Initialize(ms *ReteMetaStore) {
 	// Initializing the BetaRelations
 	// Initialize the VertexVisits
 	rs.VertexVisits = make([]VisitCount, len(ms.NodeVertices))
+	rs.aggregateRows = make(map[aggregateKey]*BetaRow)
 	rs.betaRelations = make([]*BetaRelation, len(ms.NodeVertices))
 	for i := range ms.NodeVertices {
 		nodeVertex := ms.NodeVertices[i]
@@ -130,6 +135,7 @@ func (rs *ReteSession) Initialize(ms *ReteMetaStore) {
 }
 
 func (rs *ReteSession) Done() {
+	rs.mergeProfile()
 	rs.RdfSession.AssertedGraph.CallbackMgr.ClearCallbacks()
 	rs.RdfSession.InferredGraph.CallbackMgr.ClearCallbacks()
 }
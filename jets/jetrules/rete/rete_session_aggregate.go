@@ -0,0 +1,131 @@
+package rete
+
+import (
+	"log"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+)
+
+// ReteSession functions for the aggregate antecedents, see AggregateOperator.
+// The aggregate beta row of each parent beta row is kept in aggregateRows so that
+// it can be retracted when the aggregate value changes or when the parent beta row
+// is retracted, the aggregate value being computed from the current state of the
+// rdf session.
+
+type aggregateKey struct {
+	vertex    int
+	parentRow *BetaRow
+}
+
+// newAggregateRow returns the aggregate beta row of parentRow, returns nil when the
+// aggregate has no value (e.g. min/max without matching triples) or when the row
+// does not pass the filter of the node
+func (rs *ReteSession) newAggregateRow(alphaNode *AlphaNode, parentRow *BetaRow) *BetaRow {
+	nodeVertex := alphaNode.NdVertex
+	value := nodeVertex.Aggregate.Eval(rs, parentRow, alphaNode.FindMatchingTriples(rs, parentRow))
+	if value == nil {
+		return nil
+	}
+	betaRowInitializer := nodeVertex.RowInitializer
+	betaRow := NewBetaRow(nodeVertex, betaRowInitializer.RowSize())
+	// initialize the beta row with parent_row and the virtual triple holding the aggregate value
+	t3 := rdf.Triple{nil, nil, value}
+	betaRow.Initialize(betaRowInitializer, parentRow, &t3)
+	rs.trackProvenance(betaRow, parentRow, &t3)
+	if nodeVertex.HasExpression() && !nodeVertex.FilterExpr.EvalFilter(rs, betaRow) {
+		return nil
+	}
+	return betaRow
+}
+
+// insertAggregateRow inserts the aggregate beta row of parentRow in betaRelation,
+// called when visiting the rete network in inferring mode.
+// The row is not recomputed if the parent row already has its aggregate row,
+// the aggregate rows are updated by TripleUpdatedForAggregate.
+func (rs *ReteSession) insertAggregateRow(alphaNode *AlphaNode, betaRelation *BetaRelation, parentRow *BetaRow) {
+	key := aggregateKey{vertex: alphaNode.NdVertex.Vertex, parentRow: parentRow}
+	if _, ok := rs.aggregateRows[key]; ok {
+		return
+	}
+	betaRow := rs.newAggregateRow(alphaNode, parentRow)
+	if betaRow == nil {
+		return
+	}
+	betaRelation.InsertBetaRow(rs, betaRow)
+	rs.aggregateRows[key] = betaRow
+}
+
+// removeAggregateRow removes the aggregate beta row of parentRow from betaRelation,
+// called when visiting the rete network in retracting mode
+func (rs *ReteSession) removeAggregateRow(vertex int, betaRelation *BetaRelation, parentRow *BetaRow) {
+	key := aggregateKey{vertex: vertex, parentRow: parentRow}
+	betaRow, ok := rs.aggregateRows[key]
+	if !ok {
+		return
+	}
+	delete(rs.aggregateRows, key)
+	betaRelation.RemoveBetaRow(rs, betaRow)
+}
+
+// clearAggregateRows forget the aggregate beta rows of vertex, the rows must be
+// removed from the beta relation by the caller
+func (rs *ReteSession) clearAggregateRows(vertex int) {
+	for key := range rs.aggregateRows {
+		if key.vertex == vertex {
+			delete(rs.aggregateRows, key)
+		}
+	}
+}
+
+// This is called by TripleUpdated for an aggregate antecedent, in response to a triple
+// matching the aggregate antecedent that is inserted or deleted from the rdf graph.
+// The aggregate of the parent beta rows matching the triple are recomputed, the
+// aggregate beta row is retracted and the new one is inserted when the value changed.
+func (rs *ReteSession) TripleUpdatedForAggregate(vertex int, s, p, o *rdf.Node) {
+	betaRelation := rs.GetBetaRelation(vertex)
+	nodeVertex := rs.ms.NodeVertices[vertex]
+	parentBetaRelation := rs.GetBetaRelation(nodeVertex.ParentNodeVertex.Vertex)
+	alphaNode := rs.GetAlphaNode(vertex)
+
+	// Collect the parent rows first since propagating the changes may update the indexes
+	parentRows := make([]*BetaRow, 0)
+	for parentRow, valid := range alphaNode.FindMatchingRows(parentBetaRelation, s, p, o) {
+		if valid {
+			parentRows = append(parentRows, parentRow)
+		}
+	}
+	for _, parentRow := range parentRows {
+		key := aggregateKey{vertex: vertex, parentRow: parentRow}
+		currentRow := rs.aggregateRows[key]
+		betaRow := rs.newAggregateRow(alphaNode, parentRow)
+		if currentRow == nil && betaRow == nil {
+			continue
+		}
+		if currentRow != nil && betaRow != nil && currentRow.Eq(betaRow) {
+			// aggregate value unchanged
+			continue
+		}
+		// Retract the current aggregate row and propagate down the network
+		if currentRow != nil {
+			delete(rs.aggregateRows, key)
+			betaRelation.RemoveBetaRow(rs, currentRow)
+			if betaRelation.HasPendingRows() {
+				err := rs.VisitReteGraph(vertex, false)
+				if err != nil {
+					log.Panicf("while calling VisitReteGraph(retracting) from aggregate vertex %d: %v", vertex, err)
+				}
+			}
+		}
+		// Insert the new aggregate row and propagate down the network
+		if betaRow != nil {
+			betaRelation.InsertBetaRow(rs, betaRow)
+			rs.aggregateRows[key] = betaRow
+			if betaRelation.HasPendingRows() {
+				err := rs.VisitReteGraph(vertex, true)
+				if err != nil {
+					log.Panicf("while calling VisitReteGraph(inferring) from aggregate vertex %d: %v", vertex, err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package rete
+
+import (
+	"testing"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+)
+
+// This file contains test cases for the aggregate antecedents
+
+// aggregateTestNetwork builds the rete network for the rules:
+//
+//	[r1]: (?c rdf:type hc:Claim) -> (?c hc:nbr_lines count(?line where (?c hc:has_line ?line)));
+//	[r2]: (?c rdf:type hc:Claim) -> (?c hc:max_amount max(?amt where (?c hc:amount ?amt)));
+//	[r3]: (?c rdf:type hc:Claim) -> (?c hc:total sum(?amt where (?c hc:amount ?amt)));
+func aggregateTestNetwork(t *testing.T, rm *rdf.ResourceManager, metaGraph *rdf.RdfGraph) *ReteMetaStore {
+	rdfType := rm.NewResource("rdf:type")
+	claim := rm.NewResource("hc:Claim")
+	hasLine := rm.NewResource("hc:has_line")
+	nbrLines := rm.NewResource("hc:nbr_lines")
+	amount := rm.NewResource("hc:amount")
+	maxAmount := rm.NewResource("hc:max_amount")
+	total := rm.NewResource("hc:total")
+
+	newAggregate := func(operator string) *AggregateOperator {
+		op, err := NewAggregateOperator(operator, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return op
+	}
+	v0 := NewNodeVertex(0, nil, false, 0, nil, "(* * *)", nil, nil)
+	v1 := NewNodeVertex(1, v0, false, 100, nil, "(?c rdf:type hc:Claim)", nil,
+		NewBetaRowInitializer([]int{0 | brcTriple}, []string{"?c"}))
+	v2 := NewNodeVertex(2, v1, false, 100, nil, "count(?line where (?c hc:has_line ?line))", []string{"r1"},
+		NewBetaRowInitializer([]int{0 | brcParentNode, 2 | brcTriple}, []string{"?c", "?agg02"}))
+	v2.Aggregate = newAggregate("count")
+	v3 := NewNodeVertex(3, v1, false, 100, nil, "max(?amt where (?c hc:amount ?amt))", []string{"r2"},
+		NewBetaRowInitializer([]int{0 | brcParentNode, 2 | brcTriple}, []string{"?c", "?agg03"}))
+	v3.Aggregate = newAggregate("max")
+	v4 := NewNodeVertex(4, v1, false, 100, nil, "sum(?amt where (?c hc:amount ?amt))", []string{"r3"},
+		NewBetaRowInitializer([]int{0 | brcParentNode, 2 | brcTriple}, []string{"?c", "?agg04"}))
+	v4.Aggregate = newAggregate("sum")
+	alphaNodes := []*AlphaNode{
+		NewRootAlphaNode(v0),
+		NewAlphaNode(&FVariable{"?c"}, &FConstant{rdfType}, &FConstant{claim}, v1, true, "(?c rdf:type hc:Claim)"),
+		NewAlphaNode(&FBinded{0}, &FConstant{hasLine}, &FVariable{"?line"}, v2, true, v2.NormalizedLabel),
+		NewAlphaNode(&FBinded{0}, &FConstant{amount}, &FVariable{"?amt"}, v3, true, v3.NormalizedLabel),
+		NewAlphaNode(&FBinded{0}, &FConstant{amount}, &FVariable{"?amt"}, v4, true, v4.NormalizedLabel),
+		NewAlphaNode(&FBinded{0}, &FConstant{nbrLines}, &FBinded{1}, v2, false, "(?c hc:nbr_lines ?agg02)"),
+		NewAlphaNode(&FBinded{0}, &FConstant{maxAmount}, &FBinded{1}, v3, false, "(?c hc:max_amount ?agg03)"),
+		NewAlphaNode(&FBinded{0}, &FConstant{total}, &FBinded{1}, v4, false, "(?c hc:total ?agg04)"),
+	}
+	for _, an := range alphaNodes {
+		switch {
+		case an.IsAntecedent:
+			an.NdVertex.ParentNodeVertex.AddChildAlphaNode(an)
+		case an.IsConsequent:
+			an.NdVertex.AddConsequentTerm(an)
+		}
+	}
+	config := map[string]string{}
+	ms, err := NewReteMetaStore(rm, metaGraph, nil, alphaNodes, []*NodeVertex{v0, v1, v2, v3, v4}, &config, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ms
+}
+
+// objects returns the objects of (s, p, ?o)
+func objects(rdfSession *rdf.RdfSession, s, p *rdf.Node) []*rdf.Node {
+	var result []*rdf.Node
+	itor := rdfSession.FindSP(s, p)
+	for t3 := range itor.Itor {
+		result = append(result, t3[2])
+	}
+	itor.Done()
+	return result
+}
+
+func TestNewAggregateOperator(t *testing.T) {
+	if _, err := NewAggregateOperator("avg", 2); err == nil {
+		t.Error("expecting error for unknown aggregate operator")
+	}
+	if _, err := NewAggregateOperator("count", 3); err == nil {
+		t.Error("expecting error for invalid variable position")
+	}
+}
+
+func TestReteSessionAggregate(t *testing.T) {
+	metaMgr := rdf.NewResourceManager(nil)
+	metaGraph := rdf.NewRdfGraph("META")
+	ms := aggregateTestNetwork(t, metaMgr, metaGraph)
+	rdfSession := rdf.NewRdfSession(metaMgr, metaGraph)
+	rm := rdfSession.ResourceMgr
+	rdfType := rm.NewResource("rdf:type")
+	claim := rm.NewResource("hc:Claim")
+	hasLine := rm.NewResource("hc:has_line")
+	nbrLines := rm.NewResource("hc:nbr_lines")
+	amount := rm.NewResource("hc:amount")
+	maxAmount := rm.NewResource("hc:max_amount")
+	total := rm.NewResource("hc:total")
+	c1 := rm.NewResource("c1")
+	c2 := rm.NewResource("c2")
+	rdfSession.Insert(c1, rdfType, claim)
+	rdfSession.Insert(c1, hasLine, rm.NewResource("l1"))
+	rdfSession.Insert(c1, hasLine, rm.NewResource("l2"))
+	rdfSession.Insert(c1, amount, rm.NewIntLiteral(10))
+	rdfSession.Insert(c1, amount, rm.NewIntLiteral(25))
+	rdfSession.Insert(c2, rdfType, claim)
+
+	reteSession := NewReteSession(rdfSession)
+	reteSession.Initialize(ms)
+	execute := func() {
+		t.Helper()
+		if err := reteSession.ExecuteRules(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect := func(s, p *rdf.Node, expected *rdf.Node) {
+		t.Helper()
+		values := objects(rdfSession, s, p)
+		switch {
+		case expected == nil && len(values) > 0:
+			t.Errorf("expecting no (%s %s ?o), got %v", s, p, values)
+		case expected != nil && (len(values) != 1 || values[0] != expected):
+			t.Errorf("expecting (%s %s %s), got %v", s, p, expected, values)
+		}
+	}
+	execute()
+	expect(c1, nbrLines, rm.NewIntLiteral(2))
+	expect(c2, nbrLines, rm.NewIntLiteral(0))
+	expect(c1, maxAmount, rm.NewIntLiteral(25))
+	expect(c2, maxAmount, nil)
+	expect(c1, total, rm.NewIntLiteral(35))
+	expect(c2, total, rm.NewIntLiteral(0))
+
+	// Inserting triples matching the aggregate antecedents
+	rdfSession.Insert(c1, hasLine, rm.NewResource("l3"))
+	rdfSession.Insert(c2, amount, rm.NewIntLiteral(5))
+	rdfSession.Insert(c1, amount, rm.NewIntLiteral(40))
+	execute()
+	expect(c1, nbrLines, rm.NewIntLiteral(3))
+	expect(c2, maxAmount, rm.NewIntLiteral(5))
+	expect(c1, maxAmount, rm.NewIntLiteral(40))
+	expect(c1, total, rm.NewIntLiteral(75))
+	expect(c2, total, rm.NewIntLiteral(5))
+
+	// Retracting triples matching the aggregate antecedents
+	rdfSession.Erase(c1, hasLine, rm.NewResource("l1"))
+	rdfSession.Erase(c1, amount, rm.NewIntLiteral(40))
+	rdfSession.Erase(c2, amount, rm.NewIntLiteral(5))
+	execute()
+	expect(c1, nbrLines, rm.NewIntLiteral(2))
+	expect(c1, maxAmount, rm.NewIntLiteral(25))
+	expect(c2, maxAmount, nil)
+	expect(c1, total, rm.NewIntLiteral(35))
+	expect(c2, total, rm.NewIntLiteral(0))
+
+	// Retracting the parent row retracts the aggregates
+	rdfSession.Erase(c2, rdfType, claim)
+	execute()
+	expect(c2, nbrLines, nil)
+	expect(c2, total, nil)
+	expect(c1, nbrLines, rm.NewIntLiteral(2))
+	for key := range reteSession.aggregateRows {
+		if key.parentRow.Data[0] == c2 {
+			t.Errorf("expecting no aggregate rows for c2 at vertex %d", key.vertex)
+		}
+	}
+}
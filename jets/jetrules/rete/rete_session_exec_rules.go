@@ -95,6 +95,8 @@ func (rs *ReteSession) VisitReteGraph(fromVertex int, isInferring bool) error {
 				return fmt.Errorf("error: got nil childBetaRelation at vertex %d (VisitReteGraph)", childVertex)
 			}
 
+			rs.profileEnter(childVertex)
+
 			// Clear the pending rows in current_relation, since they were for the last pass
 			childBetaRelation.ClearPendingRows()
 
@@ -121,6 +123,16 @@ func (rs *ReteSession) VisitReteGraph(fromVertex int, isInferring bool) error {
 				// for each triple from the rdf graph matching the AlphaNode
 				// compute the BetaRow to infer or retract
 				parentBetaRow := itor.GetRow()
+				// Aggregate antecedent: one beta row per parent row with the aggregate of the matching triples
+				if childAlphaNode.NdVertex.IsAggregate() {
+					if isInferring {
+						rs.insertAggregateRow(childAlphaNode, childBetaRelation, parentBetaRow)
+					} else {
+						rs.removeAggregateRow(childVertex, childBetaRelation, parentBetaRow)
+					}
+					itor.Next()
+					continue
+				}
 				t3Itor := childAlphaNode.FindMatchingTriples(rs, parentBetaRow)
 
 				// Process the returned iterator t3_itor accordingly if AlphaNode is a negation
@@ -144,8 +156,10 @@ func (rs *ReteSession) VisitReteGraph(fromVertex int, isInferring bool) error {
 						err := childBetaRow.Initialize(betaRowInitializer, parentBetaRow, &t3)
 						if err != nil {
 							t3Itor.Done()
+							rs.profileExit()
 							return fmt.Errorf("while initializing BetaRow with NilTriple: %v", err)
 						}
+						rs.trackProvenance(childBetaRow, parentBetaRow, &t3)
 						// evaluate the current_relation filter if any
 						keepIt := true
 						if childAlphaNode.NdVertex.HasExpression() {
@@ -173,6 +187,7 @@ func (rs *ReteSession) VisitReteGraph(fromVertex int, isInferring bool) error {
 						childBetaRow := NewBetaRow(childAlphaNode.NdVertex, betaRowInitializer.RowSize())
 						// initialize the beta row with parent_row and t3
 						childBetaRow.Initialize(betaRowInitializer, parentBetaRow, &t3)
+						rs.trackProvenance(childBetaRow, parentBetaRow, &t3)
 						// evaluate the current_relation filter if any
 						keepIt := true
 						if childAlphaNode.NdVertex.HasExpression() {
@@ -194,6 +209,8 @@ func (rs *ReteSession) VisitReteGraph(fromVertex int, isInferring bool) error {
 				itor.Next()
 			}
 
+			rs.profileExit()
+
 			// Mark current beta node as activated (if was not already) and push it on the stack so to visit it's childrens
 			childBetaRelation.IsActivated = true
 			stack.Push(childVertex)
@@ -229,8 +246,6 @@ func (rs *ReteSession) ComputeConsequentTriples() error {
 		if betaRelation == nil {
 			return fmt.Errorf("error: got nil beta relation for vertex %d", vertex)
 		}
-		//*TODO Log infer/retract event here to trace inferrence process (aka explain why)
-		//*TODO Track how many times a rule infer/retract triples here (aka rule stat collector)
 
 		// Check for max visit allowed for a vertex
 		currentVisit := &rs.VertexVisits[vertex]
@@ -240,6 +255,8 @@ func (rs *ReteSession) ComputeConsequentTriples() error {
 			return fmt.Errorf("error: max vertex visit reached")
 		}
 
+		rs.profileEnter(vertex)
+		rs.profileConsequent(vertex, betaRow.IsInserted())
 		if betaRow.IsInserted() {
 			// Infer consequent triples
 			currentVisit.InferCount += 1
@@ -251,14 +268,19 @@ func (rs *ReteSession) ComputeConsequentTriples() error {
 				// }
 				_, err := rs.RdfSession.InsertInferred(t3[0], t3[1], t3[2])
 				if err != nil {
+					rs.profileExit()
 					return fmt.Errorf("while calling ReteSession.InsertInferred (ComputeConsequentTriples) @ vertex %d: %v", vertex, err)
 				}
+				if rs.provenance != nil && rs.RdfSession.InferredGraph.Contains(t3[0], t3[1], t3[2]) {
+					rs.recordInferred(t3, betaRow)
+				}
 			}
 			// Mark row as Processed
 			betaRow.Status = kProcessed
 		} else {
 			// beta_row status must be kDeleted, meaning retracting mode
 			if !betaRow.IsDeleted() {
+				rs.profileExit()
 				return fmt.Errorf("error: invalid beta row at vertex %d, expecting status kDeleted (ComputeConsequentTriples)", vertex)
 			}
 			// Retract consequent triples
@@ -271,12 +293,15 @@ func (rs *ReteSession) ComputeConsequentTriples() error {
 				// }
 				_, err := rs.RdfSession.Retract(t3[0], t3[1], t3[2])
 				if err != nil {
+					rs.profileExit()
 					return fmt.Errorf("while calling ReteSession.Retract (ComputeConsequentTriples): %v", err)
 				}
+				rs.recordRetracted(t3, betaRow)
 			}
-			// Remove row from beta node
-			betaRelation.RemoveBetaRow(rs, betaRow)
+			// Remove row from beta node, the row is marked kDeleted so RemoveBetaRow would skip it
+			betaRelation.AllRows.Erase(betaRow)
 			betaRow.Status = kProcessed
 		}
+		rs.profileExit()
 	}
 }
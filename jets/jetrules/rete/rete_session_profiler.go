@@ -0,0 +1,267 @@
+package rete
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Rule execution profiler, opt-in mode of the ReteSession.
+// When enabled, the ReteSession measures per NodeVertex:
+//   - the wall time spent computing the beta relation and the consequent triples of the vertex,
+//     the time is exclusive: the time spent in the vertices visited as a result of the
+//     inferred triples is charged to those vertices,
+//   - the number of beta rows inserted in the beta relation of the vertex,
+//   - the number of alpha node callbacks, i.e. the triples inserted or deleted notified to the vertex,
+//   - the number of times the vertex inferred or retracted its consequent triples.
+// The session counters are merged into the ReteProfiler when the session is Done, so
+// a ReteProfiler aggregates the profile of all the sessions of a run, it is safe for
+// concurrent use.
+
+// ReteProfiler collects the profile of rete sessions, by ruleset
+type ReteProfiler struct {
+	mu       sync.Mutex
+	profiles map[string]*ReteProfile
+}
+
+// ReteProfile is the profile of a ruleset, aggregated over SessionCount rete sessions
+type ReteProfile struct {
+	Ruleset      string
+	SessionCount int
+	Vertices     []VertexProfile
+}
+
+// VertexProfile is the profile of a NodeVertex, Rules are the rules associated with the vertex
+type VertexProfile struct {
+	Vertex         int
+	Rules          []string
+	Duration       time.Duration
+	BetaRows       int64
+	AlphaCallbacks int64
+	InferCount     int64
+	RetractCount   int64
+}
+
+// RuleProfile is the profile of a rule, sum of the profile of the vertices associated with the rule
+type RuleProfile struct {
+	Rule           string
+	Duration       time.Duration
+	BetaRows       int64
+	AlphaCallbacks int64
+	InferCount     int64
+	RetractCount   int64
+}
+
+// sessionProfile is the profile of a single rete session, it is not shared across goroutines
+type sessionProfile struct {
+	profiler *ReteProfiler
+	ruleset  string
+	vertices []VertexProfile
+	// stack of the vertices being timed, the time is charged to the top of the stack
+	stack []int
+	mark  time.Time
+}
+
+func NewReteProfiler() *ReteProfiler {
+	return &ReteProfiler{
+		profiles: make(map[string]*ReteProfile),
+	}
+}
+
+// EnableProfiler turns on the profiler, the profile of the session is merged into profiler
+// under ruleset when the session is Done. Must be called after Initialize and before ExecuteRules.
+func (rs *ReteSession) EnableProfiler(profiler *ReteProfiler, ruleset string) {
+	if profiler == nil || rs.ms == nil || rs.profile != nil {
+		return
+	}
+	vertices := make([]VertexProfile, len(rs.ms.NodeVertices))
+	for i, nodeVertex := range rs.ms.NodeVertices {
+		vertices[i].Vertex = i
+		vertices[i].Rules = nodeVertex.AssociatedRules
+	}
+	rs.profile = &sessionProfile{
+		profiler: profiler,
+		ruleset:  ruleset,
+		vertices: vertices,
+		stack:    make([]int, 0, 16),
+	}
+}
+
+func (rs *ReteSession) IsProfilerEnabled() bool {
+	return rs.profile != nil
+}
+
+// profileEnter starts charging the time to vertex, until the matching profileExit
+func (rs *ReteSession) profileEnter(vertex int) {
+	p := rs.profile
+	if p == nil {
+		return
+	}
+	now := time.Now()
+	if n := len(p.stack); n > 0 {
+		p.vertices[p.stack[n-1]].Duration += now.Sub(p.mark)
+	}
+	p.stack = append(p.stack, vertex)
+	p.mark = now
+}
+
+// profileExit charges the time to the current vertex and resumes the previous one
+func (rs *ReteSession) profileExit() {
+	p := rs.profile
+	if p == nil || len(p.stack) == 0 {
+		return
+	}
+	now := time.Now()
+	n := len(p.stack)
+	p.vertices[p.stack[n-1]].Duration += now.Sub(p.mark)
+	p.stack = p.stack[:n-1]
+	p.mark = now
+}
+
+func (rs *ReteSession) profileBetaRow(vertex int) {
+	if rs.profile != nil {
+		rs.profile.vertices[vertex].BetaRows++
+	}
+}
+
+func (rs *ReteSession) profileAlphaCallback(vertex int) {
+	if rs.profile != nil {
+		rs.profile.vertices[vertex].AlphaCallbacks++
+	}
+}
+
+func (rs *ReteSession) profileConsequent(vertex int, isInferring bool) {
+	if rs.profile == nil {
+		return
+	}
+	if isInferring {
+		rs.profile.vertices[vertex].InferCount++
+	} else {
+		rs.profile.vertices[vertex].RetractCount++
+	}
+}
+
+// mergeProfile merges the profile of the session into the profiler, called by Done
+func (rs *ReteSession) mergeProfile() {
+	p := rs.profile
+	if p == nil {
+		return
+	}
+	rs.profile = nil
+	p.profiler.merge(p.ruleset, p.vertices)
+}
+
+func (p *ReteProfiler) merge(ruleset string, vertices []VertexProfile) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	profile := p.profiles[ruleset]
+	if profile == nil {
+		p.profiles[ruleset] = &ReteProfile{
+			Ruleset:      ruleset,
+			SessionCount: 1,
+			Vertices:     slices.Clone(vertices),
+		}
+		return
+	}
+	profile.SessionCount++
+	for i := range vertices {
+		if i >= len(profile.Vertices) {
+			profile.Vertices = append(profile.Vertices, vertices[i])
+			continue
+		}
+		v := &profile.Vertices[i]
+		v.Duration += vertices[i].Duration
+		v.BetaRows += vertices[i].BetaRows
+		v.AlphaCallbacks += vertices[i].AlphaCallbacks
+		v.InferCount += vertices[i].InferCount
+		v.RetractCount += vertices[i].RetractCount
+	}
+}
+
+// Profiles returns a copy of the profiles collected so far, sorted by ruleset
+func (p *ReteProfiler) Profiles() []*ReteProfile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	profiles := make([]*ReteProfile, 0, len(p.profiles))
+	for _, profile := range p.profiles {
+		profiles = append(profiles, &ReteProfile{
+			Ruleset:      profile.Ruleset,
+			SessionCount: profile.SessionCount,
+			Vertices:     slices.Clone(profile.Vertices),
+		})
+	}
+	slices.SortFunc(profiles, func(lhs, rhs *ReteProfile) int {
+		return strings.Compare(lhs.Ruleset, rhs.Ruleset)
+	})
+	return profiles
+}
+
+// RuleProfiles returns the profile by rule, sorted by decreasing duration.
+// A vertex shared by several rules is counted in each of the rules.
+func (rp *ReteProfile) RuleProfiles() []RuleProfile {
+	byRule := make(map[string]*RuleProfile)
+	for i := range rp.Vertices {
+		v := &rp.Vertices[i]
+		for _, rule := range v.Rules {
+			r := byRule[rule]
+			if r == nil {
+				r = &RuleProfile{Rule: rule}
+				byRule[rule] = r
+			}
+			r.Duration += v.Duration
+			r.BetaRows += v.BetaRows
+			r.AlphaCallbacks += v.AlphaCallbacks
+			r.InferCount += v.InferCount
+			r.RetractCount += v.RetractCount
+		}
+	}
+	result := make([]RuleProfile, 0, len(byRule))
+	for _, r := range byRule {
+		result = append(result, *r)
+	}
+	slices.SortFunc(result, func(lhs, rhs RuleProfile) int {
+		if c := cmp.Compare(rhs.Duration, lhs.Duration); c != 0 {
+			return c
+		}
+		return strings.Compare(lhs.Rule, rhs.Rule)
+	})
+	return result
+}
+
+// Table returns the profile as the lines of a text table, the rules of each ruleset
+// followed by its vertices, sorted by decreasing duration. Vertices without activity
+// are omitted.
+func (p *ReteProfiler) Table() []string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ruleset\tsessions\tkind\tname\ttime\tbeta_rows\talpha_callbacks\tinferred\tretracted")
+	for _, profile := range p.Profiles() {
+		for _, r := range profile.RuleProfiles() {
+			fmt.Fprintf(w, "%s\t%d\trule\t%s\t%v\t%d\t%d\t%d\t%d\n", profile.Ruleset, profile.SessionCount,
+				r.Rule, r.Duration, r.BetaRows, r.AlphaCallbacks, r.InferCount, r.RetractCount)
+		}
+		vertices := slices.Clone(profile.Vertices)
+		slices.SortStableFunc(vertices, func(lhs, rhs VertexProfile) int {
+			return cmp.Compare(rhs.Duration, lhs.Duration)
+		})
+		for _, v := range vertices {
+			if v.Duration == 0 && v.BetaRows == 0 && v.AlphaCallbacks == 0 {
+				continue
+			}
+			name := strconv.Itoa(v.Vertex)
+			if len(v.Rules) > 0 {
+				name = fmt.Sprintf("%d (%s)", v.Vertex, strings.Join(v.Rules, ","))
+			}
+			fmt.Fprintf(w, "%s\t%d\tvertex\t%s\t%v\t%d\t%d\t%d\t%d\n", profile.Ruleset, profile.SessionCount,
+				name, v.Duration, v.BetaRows, v.AlphaCallbacks, v.InferCount, v.RetractCount)
+		}
+	}
+	w.Flush()
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+}
@@ -0,0 +1,77 @@
+package rete
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+)
+
+// This file contains test cases for the rule execution profiler
+
+func TestReteSessionProfiler(t *testing.T) {
+	metaMgr := rdf.NewResourceManager(nil)
+	metaGraph := rdf.NewRdfGraph("META")
+	ms := provenanceTestNetwork(metaMgr, metaGraph)
+	profiler := NewReteProfiler()
+
+	// Execute the rules on 2 sessions
+	for range 2 {
+		rdfSession := rdf.NewRdfSession(metaMgr, metaGraph)
+		rm := rdfSession.ResourceMgr
+		c1 := rm.NewResource("c1")
+		c2 := rm.NewResource("c2")
+		rdfSession.Insert(c1, rm.NewResource("rdf:type"), rm.NewResource("hc:Claim"))
+		rdfSession.Insert(c1, rm.NewResource("hc:code"), rm.NewTextLiteral("A"))
+		rdfSession.Insert(c2, rm.NewResource("rdf:type"), rm.NewResource("hc:Claim"))
+
+		reteSession := NewReteSession(rdfSession)
+		reteSession.Initialize(ms)
+		reteSession.EnableProfiler(profiler, "test.jr")
+		if !reteSession.IsProfilerEnabled() {
+			t.Fatal("expecting the profiler to be enabled")
+		}
+		if err := reteSession.ExecuteRules(); err != nil {
+			t.Fatal(err)
+		}
+		reteSession.Done()
+		if reteSession.IsProfilerEnabled() {
+			t.Error("expecting the profile to be merged by Done")
+		}
+	}
+
+	profiles := profiler.Profiles()
+	if len(profiles) != 1 {
+		t.Fatalf("expecting 1 profile, got %d", len(profiles))
+	}
+	profile := profiles[0]
+	if profile.Ruleset != "test.jr" || profile.SessionCount != 2 {
+		t.Errorf("unexpected profile: %s with %d sessions", profile.Ruleset, profile.SessionCount)
+	}
+	// (?c rdf:type hc:Claim) has c1 and c2 in each session
+	if profile.Vertices[1].BetaRows != 4 {
+		t.Errorf("expecting 4 beta rows at vertex 1, got %d", profile.Vertices[1].BetaRows)
+	}
+	// (c1 hc:status hc:Open) is inferred in each session and notified to vertex 3
+	if profile.Vertices[3].AlphaCallbacks != 2 {
+		t.Errorf("expecting 2 alpha callbacks at vertex 3, got %d", profile.Vertices[3].AlphaCallbacks)
+	}
+	rules := profile.RuleProfiles()
+	if len(rules) != 2 {
+		t.Fatalf("expecting 2 rule profiles, got %d", len(rules))
+	}
+	for _, r := range rules {
+		if r.InferCount != 2 || r.RetractCount != 0 {
+			t.Errorf("rule %s: expecting 2 inferred and 0 retracted, got %d and %d", r.Rule, r.InferCount, r.RetractCount)
+		}
+	}
+
+	table := profiler.Table()
+	if !strings.HasPrefix(table[0], "ruleset") {
+		t.Errorf("expecting the table header, got %s", table[0])
+	}
+	// header, 2 rules and the 4 vertices below the head vertex
+	if len(table) != 7 {
+		t.Errorf("expecting 7 lines in the table, got:\n%s", strings.Join(table, "\n"))
+	}
+}
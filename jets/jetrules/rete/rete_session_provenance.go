@@ -0,0 +1,208 @@
+package rete
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+)
+
+// Rule firing provenance, opt-in mode of the ReteSession.
+// When enabled, each BetaRow keeps its parent row and the triple it matched, and
+// each triple inserted in the InferredGraph records the BetaRows that inferred it.
+// This allows to explain an inferred triple as a derivation tree: the rules that
+// inferred it, the rule variable bindings and the antecedent triples, recursively.
+// The derivations are removed when the triple is retracted by the rule.
+
+// Provenance source of a triple
+const (
+	ProvenanceAsserted = "asserted"
+	ProvenanceMeta     = "meta"
+	ProvenanceInferred = "inferred"
+	// Triple is on the current derivation path, not expanded again
+	ProvenanceCycle = "cycle"
+	// Triple not in the rdf session
+	ProvenanceUnknown = "unknown"
+)
+
+// Max depth of the derivation tree
+const maxExplainDepth = 64
+
+type provenanceStore struct {
+	derivations map[rdf.Triple][]*BetaRow
+}
+
+// provenanceInfo is kept on the BetaRow when provenance is enabled
+type provenanceInfo struct {
+	parent  *BetaRow
+	matched rdf.Triple
+}
+
+// Explanation is the derivation tree of a triple
+type Explanation struct {
+	Triple      rdf.Triple
+	Source      string
+	Derivations []*Derivation
+}
+
+// Derivation is one firing of a rule that inferred the triple.
+// NotExists contains the negated antecedent terms that were satisfied.
+type Derivation struct {
+	Vertex      int
+	Rules       []string
+	Bindings    []Binding
+	Antecedents []*Explanation
+	NotExists   []string
+}
+
+type Binding struct {
+	Variable string
+	Value    *rdf.Node
+}
+
+// EnableProvenance turns on the provenance mode, must be called before ExecuteRules.
+func (rs *ReteSession) EnableProvenance() {
+	if rs.provenance == nil {
+		rs.provenance = &provenanceStore{
+			derivations: make(map[rdf.Triple][]*BetaRow),
+		}
+	}
+}
+
+func (rs *ReteSession) IsProvenanceEnabled() bool {
+	return rs.provenance != nil
+}
+
+// trackProvenance keeps the parent row and the matched triple of row, when provenance is enabled
+func (rs *ReteSession) trackProvenance(row, parentRow *BetaRow, t3 *rdf.Triple) {
+	if rs.provenance == nil {
+		return
+	}
+	row.provenance = &provenanceInfo{
+		parent:  parentRow,
+		matched: *t3,
+	}
+}
+
+// recordInferred records that row inferred t3
+func (rs *ReteSession) recordInferred(t3 *rdf.Triple, row *BetaRow) {
+	if rs.provenance == nil || t3 == nil {
+		return
+	}
+	rs.provenance.derivations[*t3] = append(rs.provenance.derivations[*t3], row)
+}
+
+// recordRetracted removes the derivation of t3 by row
+func (rs *ReteSession) recordRetracted(t3 *rdf.Triple, row *BetaRow) {
+	if rs.provenance == nil || t3 == nil {
+		return
+	}
+	rows := rs.provenance.derivations[*t3]
+	if i := slices.Index(rows, row); i >= 0 {
+		rows = slices.Delete(rows, i, i+1)
+	}
+	if len(rows) == 0 {
+		delete(rs.provenance.derivations, *t3)
+	} else {
+		rs.provenance.derivations[*t3] = rows
+	}
+}
+
+// Explain returns the derivation tree of the triples matching (s, p, o),
+// a nil s, p or o match any node, e.g. Explain(nil, hc:status, X) explains why
+// entities have hc:status X. Returns an error if provenance is not enabled.
+func (rs *ReteSession) Explain(s, p, o *rdf.Node) ([]*Explanation, error) {
+	if rs.provenance == nil {
+		return nil, fmt.Errorf("error: provenance is not enabled on the rete session")
+	}
+	var triples []rdf.Triple
+	itor := rs.RdfSession.FindSPO(s, p, o)
+	for t3 := range itor.Itor {
+		triples = append(triples, t3)
+	}
+	itor.Done()
+	explanations := make([]*Explanation, 0, len(triples))
+	for i := range triples {
+		explanations = append(explanations, rs.explainTriple(triples[i], make(map[rdf.Triple]bool), 0))
+	}
+	return explanations, nil
+}
+
+func (rs *ReteSession) explainTriple(t3 rdf.Triple, path map[rdf.Triple]bool, depth int) *Explanation {
+	e := &Explanation{Triple: t3}
+	s, p, o := t3[0], t3[1], t3[2]
+	switch {
+	case rs.RdfSession.MetaGraph.Contains(s, p, o):
+		e.Source = ProvenanceMeta
+		return e
+	case rs.RdfSession.AssertedGraph.Contains(s, p, o):
+		e.Source = ProvenanceAsserted
+		return e
+	case !rs.RdfSession.InferredGraph.Contains(s, p, o):
+		e.Source = ProvenanceUnknown
+		return e
+	case path[t3] || depth >= maxExplainDepth:
+		e.Source = ProvenanceCycle
+		return e
+	}
+	e.Source = ProvenanceInferred
+	path[t3] = true
+	defer delete(path, t3)
+	for _, row := range rs.provenance.derivations[t3] {
+		d := &Derivation{
+			Vertex: row.NdVertex.Vertex,
+			Rules:  row.NdVertex.AssociatedRules,
+		}
+		if row.NdVertex.RowInitializer != nil {
+			for i, label := range row.NdVertex.RowInitializer.Labels {
+				d.Bindings = append(d.Bindings, Binding{Variable: label, Value: row.Get(i)})
+			}
+		}
+		// Walk up the beta rows to collect the antecedent terms, in rule order
+		var antecedents []rdf.Triple
+		for r := row; r != nil && r.provenance != nil && !r.NdVertex.IsHead(); r = r.provenance.parent {
+			switch {
+			case r.NdVertex.IsNegation:
+				d.NotExists = append(d.NotExists, r.NdVertex.String())
+			case r.NdVertex.IsAggregate():
+				// the aggregate value is in the bindings, the virtual triple is not an antecedent
+			default:
+				antecedents = append(antecedents, r.provenance.matched)
+			}
+		}
+		slices.Reverse(antecedents)
+		slices.Reverse(d.NotExists)
+		for _, a := range antecedents {
+			d.Antecedents = append(d.Antecedents, rs.explainTriple(a, path, depth+1))
+		}
+		e.Derivations = append(e.Derivations, d)
+	}
+	return e
+}
+
+// String returns the derivation tree as indented text
+func (e *Explanation) String() string {
+	var buf strings.Builder
+	e.write(&buf, 0)
+	return buf.String()
+}
+
+func (e *Explanation) write(buf *strings.Builder, indent int) {
+	pad := strings.Repeat("  ", indent)
+	buf.WriteString(fmt.Sprintf("%s%s [%s]\n", pad, rdf.ToString(&e.Triple), e.Source))
+	for _, d := range e.Derivations {
+		bindings := make([]string, 0, len(d.Bindings))
+		for _, b := range d.Bindings {
+			bindings = append(bindings, fmt.Sprintf("%s=%v", b.Variable, b.Value))
+		}
+		buf.WriteString(fmt.Sprintf("%s  by rule %s at vertex %d with %s\n",
+			pad, strings.Join(d.Rules, ","), d.Vertex, strings.Join(bindings, ", ")))
+		for _, a := range d.Antecedents {
+			a.write(buf, indent+2)
+		}
+		for _, n := range d.NotExists {
+			buf.WriteString(fmt.Sprintf("%s    not %s\n", pad, n))
+		}
+	}
+}
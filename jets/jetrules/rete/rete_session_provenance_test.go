@@ -0,0 +1,145 @@
+package rete
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
+)
+
+// This file contains test cases for the rule firing provenance
+
+// provenanceTestNetwork builds the rete network for the rules:
+//
+//	[r1]: (?c rdf:type hc:Claim).(?c hc:code ?x) -> (?c hc:status hc:Open);
+//	[r2]: (?c hc:status hc:Open).not(?c hc:closed ?y) -> (?c hc:flag hc:Yes);
+func provenanceTestNetwork(rm *rdf.ResourceManager, metaGraph *rdf.RdfGraph) *ReteMetaStore {
+	rdfType := rm.NewResource("rdf:type")
+	claim := rm.NewResource("hc:Claim")
+	code := rm.NewResource("hc:code")
+	status := rm.NewResource("hc:status")
+	open := rm.NewResource("hc:Open")
+	closed := rm.NewResource("hc:closed")
+	flag := rm.NewResource("hc:flag")
+	yes := rm.NewResource("hc:Yes")
+
+	v0 := NewNodeVertex(0, nil, false, 0, nil, "(* * *)", nil, nil)
+	v1 := NewNodeVertex(1, v0, false, 100, nil, "(?c rdf:type hc:Claim)", nil,
+		NewBetaRowInitializer([]int{0 | brcTriple}, []string{"?c"}))
+	v2 := NewNodeVertex(2, v1, false, 100, nil, "(?c hc:code ?x)", []string{"r1"},
+		NewBetaRowInitializer([]int{0 | brcParentNode, 2 | brcTriple}, []string{"?c", "?x"}))
+	v3 := NewNodeVertex(3, v0, false, 100, nil, "(?c hc:status hc:Open)", nil,
+		NewBetaRowInitializer([]int{0 | brcTriple}, []string{"?c"}))
+	v4 := NewNodeVertex(4, v3, true, 100, nil, "(?c hc:closed ?y)", []string{"r2"},
+		NewBetaRowInitializer([]int{0 | brcParentNode}, []string{"?c"}))
+	alphaNodes := []*AlphaNode{
+		NewRootAlphaNode(v0),
+		NewAlphaNode(&FVariable{"?c"}, &FConstant{rdfType}, &FConstant{claim}, v1, true, "(?c rdf:type hc:Claim)"),
+		NewAlphaNode(&FBinded{0}, &FConstant{code}, &FVariable{"?x"}, v2, true, "(?c hc:code ?x)"),
+		NewAlphaNode(&FVariable{"?c"}, &FConstant{status}, &FConstant{open}, v3, true, "(?c hc:status hc:Open)"),
+		NewAlphaNode(&FBinded{0}, &FConstant{closed}, &FVariable{"?y"}, v4, true, "(?c hc:closed ?y)"),
+		NewAlphaNode(&FBinded{0}, &FConstant{status}, &FConstant{open}, v2, false, "(?c hc:status hc:Open)"),
+		NewAlphaNode(&FBinded{0}, &FConstant{flag}, &FConstant{yes}, v4, false, "(?c hc:flag hc:Yes)"),
+	}
+	for _, an := range alphaNodes {
+		switch {
+		case an.IsAntecedent:
+			an.NdVertex.ParentNodeVertex.AddChildAlphaNode(an)
+		case an.IsConsequent:
+			an.NdVertex.AddConsequentTerm(an)
+		}
+	}
+	config := map[string]string{}
+	ms, _ := NewReteMetaStore(rm, metaGraph, nil, alphaNodes, []*NodeVertex{v0, v1, v2, v3, v4}, &config, nil, nil)
+	return ms
+}
+
+func TestReteSessionProvenance(t *testing.T) {
+	metaMgr := rdf.NewResourceManager(nil)
+	metaGraph := rdf.NewRdfGraph("META")
+	ms := provenanceTestNetwork(metaMgr, metaGraph)
+	rdfSession := rdf.NewRdfSession(metaMgr, metaGraph)
+	rm := rdfSession.ResourceMgr
+	c1 := rm.NewResource("c1")
+	c2 := rm.NewResource("c2")
+	rdfSession.Insert(c1, rm.NewResource("rdf:type"), rm.NewResource("hc:Claim"))
+	rdfSession.Insert(c1, rm.NewResource("hc:code"), rm.NewTextLiteral("A"))
+	rdfSession.Insert(c2, rm.NewResource("rdf:type"), rm.NewResource("hc:Claim"))
+
+	reteSession := NewReteSession(rdfSession)
+	reteSession.Initialize(ms)
+	if _, err := reteSession.Explain(nil, nil, nil); err == nil {
+		t.Error("expecting error when provenance is not enabled")
+	}
+	reteSession.EnableProvenance()
+	if err := reteSession.ExecuteRules(); err != nil {
+		t.Fatal(err)
+	}
+	flag := rm.NewResource("hc:flag")
+	if !rdfSession.Contains(c1, flag, rm.NewResource("hc:Yes")) {
+		t.Fatal("expecting (c1 hc:flag hc:Yes) to be inferred")
+	}
+
+	explanations, err := reteSession.Explain(nil, flag, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(explanations) != 1 {
+		t.Fatalf("expecting 1 explanation, got %d", len(explanations))
+	}
+	e := explanations[0]
+	if e.Source != ProvenanceInferred || len(e.Derivations) != 1 {
+		t.Fatalf("unexpected explanation:\n%s", e)
+	}
+	d := e.Derivations[0]
+	if d.Rules[0] != "r2" || len(d.Antecedents) != 1 || len(d.NotExists) != 1 {
+		t.Fatalf("unexpected derivation of hc:flag:\n%s", e)
+	}
+	if len(d.Bindings) != 1 || d.Bindings[0].Variable != "?c" || d.Bindings[0].Value != c1 {
+		t.Errorf("unexpected bindings: %v", d.Bindings)
+	}
+	// the antecedent hc:status is inferred by r1 from asserted triples
+	status := d.Antecedents[0]
+	if status.Source != ProvenanceInferred || len(status.Derivations) != 1 {
+		t.Fatalf("unexpected explanation of hc:status:\n%s", e)
+	}
+	d = status.Derivations[0]
+	if d.Rules[0] != "r1" || len(d.Antecedents) != 2 {
+		t.Fatalf("unexpected derivation of hc:status:\n%s", e)
+	}
+	for _, a := range d.Antecedents {
+		if a.Source != ProvenanceAsserted || a.Triple[0] != c1 {
+			t.Errorf("expecting asserted antecedent, got:\n%s", a)
+		}
+	}
+	if d.Antecedents[1].Triple[1] != rm.NewResource("hc:code") {
+		t.Errorf("expecting antecedents in rule order, got:\n%s", e)
+	}
+	text := e.String()
+	for _, s := range []string{"by rule r2", "by rule r1", "[asserted]", "not (?c hc:closed ?y)", "?x=A"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("expecting '%s' in explanation:\n%s", s, text)
+		}
+	}
+
+	// Retracting: closing the claim retracts the flag and its derivation
+	rdfSession.Insert(c1, rm.NewResource("hc:closed"), rm.NewTextLiteral("Y"))
+	if err := reteSession.ExecuteRules(); err != nil {
+		t.Fatal(err)
+	}
+	if rdfSession.ContainsSP(c1, flag) {
+		t.Fatal("expecting (c1 hc:flag hc:Yes) to be retracted")
+	}
+	explanations, _ = reteSession.Explain(c1, flag, nil)
+	if len(explanations) != 0 {
+		t.Errorf("expecting no explanation, got %d", len(explanations))
+	}
+	if n := len(reteSession.provenance.derivations); n != 1 {
+		t.Errorf("expecting 1 recorded derivation, got %d", n)
+	}
+	// Asserted triple
+	explanations, _ = reteSession.Explain(c2, nil, nil)
+	if len(explanations) != 1 || explanations[0].Source != ProvenanceAsserted {
+		t.Errorf("expecting asserted triple, got %v", explanations)
+	}
+}
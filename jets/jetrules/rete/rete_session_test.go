@@ -3,6 +3,8 @@ package rete
 import (
 	"container/heap"
 	"testing"
+
+	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
 )
 
 func TestBetaRowPriorityQueue(t *testing.T) {
@@ -23,4 +25,61 @@ func TestBetaRowPriorityQueue(t *testing.T) {
 			heap.Push(&pq, br2)
 		}
 	}
-}
\ No newline at end of file
+}
+
+// A consequent row that is retracted must be removed from its beta relation so
+// that the triples are inferred again when the row is inserted again, the rule is:
+//
+//	[r1]: (?c hc:code ?x) -> (?c hc:coded ?x);
+func TestReteSessionReinsertRetractedRow(t *testing.T) {
+	metaMgr := rdf.NewResourceManager(nil)
+	metaGraph := rdf.NewRdfGraph("META")
+	code := metaMgr.NewResource("hc:code")
+	coded := metaMgr.NewResource("hc:coded")
+	v0 := NewNodeVertex(0, nil, false, 0, nil, "(* * *)", nil, nil)
+	v1 := NewNodeVertex(1, v0, false, 100, nil, "(?c hc:code ?x)", []string{"r1"},
+		NewBetaRowInitializer([]int{0 | brcTriple, 2 | brcTriple}, []string{"?c", "?x"}))
+	alphaNodes := []*AlphaNode{
+		NewRootAlphaNode(v0),
+		NewAlphaNode(&FVariable{"?c"}, &FConstant{code}, &FVariable{"?x"}, v1, true, "(?c hc:code ?x)"),
+		NewAlphaNode(&FBinded{0}, &FConstant{coded}, &FBinded{1}, v1, false, "(?c hc:coded ?x)"),
+	}
+	v0.AddChildAlphaNode(alphaNodes[1])
+	v1.AddConsequentTerm(alphaNodes[2])
+	config := map[string]string{}
+	ms, err := NewReteMetaStore(metaMgr, metaGraph, nil, alphaNodes, []*NodeVertex{v0, v1}, &config, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdfSession := rdf.NewRdfSession(metaMgr, metaGraph)
+	rm := rdfSession.ResourceMgr
+	c1 := rm.NewResource("c1")
+	a := rm.NewTextLiteral("A")
+	reteSession := NewReteSession(rdfSession)
+	reteSession.Initialize(ms)
+	defer reteSession.Done()
+	execute := func() {
+		t.Helper()
+		if err := reteSession.ExecuteRules(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rdfSession.Insert(c1, code, a)
+	execute()
+	if !rdfSession.Contains(c1, coded, a) {
+		t.Fatal("expecting (c1 hc:coded \"A\")")
+	}
+	rdfSession.Erase(c1, code, a)
+	execute()
+	if rdfSession.Contains(c1, coded, a) {
+		t.Fatal("expecting (c1 hc:coded \"A\") to be retracted")
+	}
+	if reteSession.GetBetaRelation(1).AllRows.Size() != 0 {
+		t.Errorf("expecting the retracted row to be removed from the beta relation")
+	}
+	rdfSession.Insert(c1, code, a)
+	execute()
+	if !rdfSession.Contains(c1, coded, a) {
+		t.Error("expecting (c1 hc:coded \"A\") to be inferred again")
+	}
+}
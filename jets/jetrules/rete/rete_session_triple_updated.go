@@ -13,6 +13,8 @@ func (rs *ReteSession) TripleUpdated(vertex int, s, p, o *rdf.Node, isInserted b
 	if vertex >= len(rs.ms.NodeVertices) {
 		log.Panic("ReteSession.TripleUpdated called with invalid vertex:", vertex)
 	}
+	rs.profileEnter(vertex)
+	defer rs.profileExit()
 	// //**
 	// if vertex == 42 {
 	// 	log.Printf("vertex %d TripleUpdated: %s, inserted? %v", vertex, rdf.ToString(&[3]*rdf.Node{s, p, o}), isInserted)
@@ -28,6 +30,11 @@ func (rs *ReteSession) TripleUpdated(vertex int, s, p, o *rdf.Node, isInserted b
 		return
 	}
 	nodeVertex := rs.ms.NodeVertices[vertex]
+	if nodeVertex.IsAggregate() {
+		// The aggregate of the matching parent rows are recomputed
+		rs.TripleUpdatedForAggregate(vertex, s, p, o)
+		return
+	}
 	parentBetaRelation := rs.GetBetaRelation(nodeVertex.ParentNodeVertex.Vertex)
 
 	// Get an iterator over all applicable rows from the parent beta node
@@ -51,6 +58,7 @@ func (rs *ReteSession) TripleUpdated(vertex int, s, p, o *rdf.Node, isInserted b
 
 		// initialize the beta row with parent_row and t3
 		betaRow.Initialize(betaRowInitializer, parentRow, &t3)
+		rs.trackProvenance(betaRow, parentRow, &t3)
 
 		// evaluate the nodeVertex filter if any
 		keepIt := true
@@ -103,6 +111,8 @@ func (rs *ReteSession) TripleUpdatedForFilter(vertex int, _, _, _ *rdf.Node, _ b
 	if vertex >= len(rs.ms.NodeVertices) {
 		log.Panic("ReteSession.TripleUpdatedForFilter called with invalid vertex:", vertex)
 	}
+	rs.profileEnter(vertex)
+	defer rs.profileExit()
 	// //**
 	// log.Println("TripleUpdatedForFilter: called for vertex",vertex)
 
@@ -132,6 +142,7 @@ func (rs *ReteSession) TripleUpdatedForFilter(vertex int, _, _, _ *rdf.Node, _ b
 		for _, row := range l {
 			betaRelation.RemoveBetaRow(rs, row)
 		}
+		rs.clearAggregateRows(vertex)
 		// Propagate down the network to retract the removed beta rows
 		if betaRelation.HasPendingRows() {
 			err := rs.VisitReteGraph(vertex, false)
@@ -154,6 +165,11 @@ func (rs *ReteSession) TripleUpdatedForFilter(vertex int, _, _, _ *rdf.Node, _ b
 		// for each triple from the rdf graph matching the AlphaNode
 		// compute the BetaRow to infer or retract
 		parentBetaRow := parentRowsItor.GetRow()
+		if alphaNode.NdVertex.IsAggregate() {
+			rs.insertAggregateRow(alphaNode, betaRelation, parentBetaRow)
+			parentRowsItor.Next()
+			continue
+		}
 		t3Itor := alphaNode.FindMatchingTriples(rs, parentBetaRow)
 
 		// Process the returned iterator t3_itor accordingly if AlphaNode is a negation
@@ -172,6 +188,7 @@ func (rs *ReteSession) TripleUpdatedForFilter(vertex int, _, _, _ *rdf.Node, _ b
 				if err != nil {
 					log.Panicf("while initializing BetaRow with NilTriple ()TripleUpdatedForFilter): %v", err)
 				}
+				rs.trackProvenance(betaRow, parentBetaRow, &t3)
 				// evaluate the current alpha node filter if any
 				keepIt := true
 				if alphaNode.NdVertex.HasExpression() {
@@ -189,6 +206,7 @@ func (rs *ReteSession) TripleUpdatedForFilter(vertex int, _, _, _ *rdf.Node, _ b
 				betaRow := NewBetaRow(alphaNode.NdVertex, betaRowInitializer.RowSize())
 				// initialize the beta row with parent_row and t3
 				betaRow.Initialize(betaRowInitializer, parentBetaRow, &t3)
+				rs.trackProvenance(betaRow, parentBetaRow, &t3)
 				// evaluate the current_relation filter if any
 				keepIt := true
 				if alphaNode.NdVertex.HasExpression() {
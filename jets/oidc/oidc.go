@@ -0,0 +1,360 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+// OpenID Connect authorization code flow for the single sign-on of the apiserver.
+// The provider is discovered from the issuer (/.well-known/openid-configuration),
+// the id token returned by the token endpoint is verified with the keys of the
+// provider (jwks_uri) and the groups of the user are mapped to jetstore roles.
+//
+// Configuration, from env:
+//   JETS_OIDC_ISSUER         issuer url, sso is disabled when not set
+//   JETS_OIDC_CLIENT_ID      client id registered with the identity provider
+//   JETS_OIDC_CLIENT_SECRET  client secret
+//   JETS_OIDC_REDIRECT_URL   callback url of the apiserver, e.g. https://jetstore.example.com/oidc/callback
+//   JETS_OIDC_SCOPES         scopes, space separated (default "openid email profile")
+//   JETS_OIDC_GROUPS_CLAIM   claim of the id token with the user groups (default "groups")
+//   JETS_OIDC_GROUP_ROLES    json mapping of group to roles, e.g. {"jetstore-admins": ["admin"], "analysts": ["read"]}
+
+type Config struct {
+	Issuer       string
+	ClientId     string
+	ClientSecret string
+	RedirectUrl  string
+	Scopes       []string
+	GroupsClaim  string
+	GroupRoles   map[string][]string
+}
+
+// ConfigFromEnv returns the sso configuration from env, returns nil when sso is not configured
+func ConfigFromEnv() (*Config, error) {
+	config := &Config{
+		Issuer:       strings.TrimSuffix(os.Getenv("JETS_OIDC_ISSUER"), "/"),
+		ClientId:     os.Getenv("JETS_OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("JETS_OIDC_CLIENT_SECRET"),
+		RedirectUrl:  os.Getenv("JETS_OIDC_REDIRECT_URL"),
+		Scopes:       strings.Fields(os.Getenv("JETS_OIDC_SCOPES")),
+		GroupsClaim:  os.Getenv("JETS_OIDC_GROUPS_CLAIM"),
+		GroupRoles:   make(map[string][]string),
+	}
+	if config.Issuer == "" {
+		return nil, nil
+	}
+	if config.ClientId == "" || config.RedirectUrl == "" {
+		return nil, errors.New("error: JETS_OIDC_CLIENT_ID and JETS_OIDC_REDIRECT_URL are required when JETS_OIDC_ISSUER is set")
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = "groups"
+	}
+	if groupRoles := os.Getenv("JETS_OIDC_GROUP_ROLES"); groupRoles != "" {
+		if err := json.Unmarshal([]byte(groupRoles), &config.GroupRoles); err != nil {
+			return nil, fmt.Errorf("while parsing JETS_OIDC_GROUP_ROLES: %v", err)
+		}
+	}
+	return config, nil
+}
+
+// RolesFromGroups returns the roles mapped to groups, sorted and without duplicates
+func (config *Config) RolesFromGroups(groups []string) []string {
+	roles := make([]string, 0)
+	for _, group := range groups {
+		for _, role := range config.GroupRoles[group] {
+			if !slices.Contains(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+	slices.Sort(roles)
+	return roles
+}
+
+// Identity is the user identity from the verified id token
+type Identity struct {
+	Subject string
+	Email   string
+	Name    string
+	Groups  []string
+}
+
+type Provider struct {
+	config                *Config
+	httpClient            *http.Client
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+	mu                    sync.Mutex
+	keys                  map[string]*rsa.PublicKey
+}
+
+// NewProvider discovers the provider of config.Issuer, httpClient may be nil
+func NewProvider(ctx context.Context, config *Config, httpClient *http.Client) (*Provider, error) {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	p := &Provider{config: config, httpClient: httpClient}
+	var discovery struct {
+		Issuer string `json:"issuer"`
+	}
+	body, err := p.get(ctx, config.Issuer+"/.well-known/openid-configuration")
+	if err != nil {
+		return nil, fmt.Errorf("while reading openid configuration: %v", err)
+	}
+	if err = json.Unmarshal(body, p); err == nil {
+		err = json.Unmarshal(body, &discovery)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("while parsing openid configuration: %v", err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != config.Issuer {
+		return nil, fmt.Errorf("error: openid configuration issuer %s does not match %s", discovery.Issuer, config.Issuer)
+	}
+	if p.AuthorizationEndpoint == "" || p.TokenEndpoint == "" || p.JwksUri == "" {
+		return nil, errors.New("error: openid configuration is missing authorization_endpoint, token_endpoint or jwks_uri")
+	}
+	return p, nil
+}
+
+// Config returns the configuration of the provider
+func (p *Provider) Config() *Config {
+	return p.config
+}
+
+func (p *Provider) get(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error: %s returned status %d", u, resp.StatusCode)
+	}
+	return body, nil
+}
+
+// AuthCodeUrl returns the url of the authorization endpoint to redirect the user to
+func (p *Provider) AuthCodeUrl(state, nonce string) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.config.ClientId)
+	params.Set("redirect_uri", p.config.RedirectUrl)
+	params.Set("scope", strings.Join(p.config.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	sep := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.AuthorizationEndpoint + sep + params.Encode()
+}
+
+// Exchange exchanges the authorization code for the tokens, returns the raw id token
+func (p *Provider) Exchange(ctx context.Context, code string) (string, error) {
+	params := url.Values{}
+	params.Set("grant_type", "authorization_code")
+	params.Set("code", code)
+	params.Set("redirect_uri", p.config.RedirectUrl)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenEndpoint, strings.NewReader(params.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.config.ClientId), url.QueryEscape(p.config.ClientSecret))
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("while calling token endpoint: %v", err)
+	}
+	defer resp.Body.Close()
+	var tokens struct {
+		IdToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return "", fmt.Errorf("while reading token endpoint response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || tokens.Error != "" {
+		return "", fmt.Errorf("error: token endpoint returned status %d: %s %s",
+			resp.StatusCode, tokens.Error, tokens.ErrorDescription)
+	}
+	if tokens.IdToken == "" {
+		return "", errors.New("error: token endpoint did not return an id_token")
+	}
+	return tokens.IdToken, nil
+}
+
+// VerifyIdToken verifies the signature, issuer, audience, expiration and nonce of
+// rawIdToken and returns the identity of the user
+func (p *Provider) VerifyIdToken(ctx context.Context, rawIdToken, nonce string) (*Identity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIdToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.publicKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512"}),
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithAudience(p.config.ClientId),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute))
+	if err != nil {
+		return nil, fmt.Errorf("while verifying id token: %v", err)
+	}
+	if n, _ := claims["nonce"].(string); n == "" || n != nonce {
+		return nil, errors.New("error: id token nonce does not match")
+	}
+	if verified, ok := claims["email_verified"].(bool); ok && !verified {
+		return nil, errors.New("error: id token email is not verified")
+	}
+	identity := &Identity{}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	if identity.Email == "" {
+		return nil, errors.New("error: id token has no email claim")
+	}
+	if identity.Name == "" {
+		identity.Name = identity.Email
+	}
+	switch groups := claims[p.config.GroupsClaim].(type) {
+	case string:
+		identity.Groups = []string{groups}
+	case []any:
+		for _, group := range groups {
+			if g, ok := group.(string); ok {
+				identity.Groups = append(identity.Groups, g)
+			}
+		}
+	}
+	return identity, nil
+}
+
+// publicKey returns the key kid of the provider, the keys are read again when kid
+// is not known to support the key rotation of the provider
+func (p *Provider) publicKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key := p.findKey(kid); key != nil {
+		return key, nil
+	}
+	if err := p.loadKeys(ctx); err != nil {
+		return nil, err
+	}
+	if key := p.findKey(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("error: unknown id token key '%s'", kid)
+}
+
+// findKey returns the key kid, or the only key of the provider when kid is empty
+func (p *Provider) findKey(kid string) *rsa.PublicKey {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key
+		}
+	}
+	return p.keys[kid]
+}
+
+func (p *Provider) loadKeys(ctx context.Context) error {
+	body, err := p.get(ctx, p.JwksUri)
+	if err != nil {
+		return fmt.Errorf("while reading jwks: %v", err)
+	}
+	var jwks struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err = json.Unmarshal(body, &jwks); err != nil {
+		return fmt.Errorf("while parsing jwks: %v", err)
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return fmt.Errorf("while decoding jwks key %s: %v", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return fmt.Errorf("while decoding jwks key %s: %v", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	p.keys = keys
+	return nil
+}
+
+// NewState returns the state and the nonce of a login, the state is a short-lived
+// token signed with secret that carries the nonce
+func NewState(secret string) (string, string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	nonce := hex.EncodeToString(b)
+	claims := jwt.MapClaims{
+		"nonce": nonce,
+		"exp":   time.Now().Add(10 * time.Minute).Unix(),
+	}
+	state, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		return "", "", err
+	}
+	return state, nonce, nil
+}
+
+// VerifyState returns the nonce of state when it is valid
+func VerifyState(secret, state string) (string, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(state, claims, func(token *jwt.Token) (any, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithExpirationRequired())
+	if err != nil {
+		return "", fmt.Errorf("while verifying login state: %v", err)
+	}
+	nonce, _ := claims["nonce"].(string)
+	if nonce == "" {
+		return "", errors.New("error: login state has no nonce")
+	}
+	return nonce, nil
+}
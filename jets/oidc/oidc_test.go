@@ -0,0 +1,158 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+// This file contains test cases for the oidc login flow against a mock identity provider
+
+// mockIdp is a local identity provider issuing id tokens signed with key
+type mockIdp struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	nonce  string
+	claims jwt.MapClaims
+}
+
+func newMockIdp(t *testing.T) *mockIdp {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &mockIdp{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": "k1",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientId, clientSecret, _ := r.BasicAuth()
+		if r.FormValue("code") != "code1" || clientId != "jetstore" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims)
+		token.Header["kid"] = "k1"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			t.Error(err)
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken, "access_token": "at"})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func TestOidcLogin(t *testing.T) {
+	idp := newMockIdp(t)
+	config := &Config{
+		Issuer:       idp.server.URL,
+		ClientId:     "jetstore",
+		ClientSecret: "secret",
+		RedirectUrl:  "http://localhost:8080/oidc/callback",
+		Scopes:       []string{"openid", "email"},
+		GroupsClaim:  "groups",
+		GroupRoles: map[string][]string{
+			"analysts": {"read"},
+			"admins":   {"read", "admin"},
+		},
+	}
+	ctx := context.Background()
+	p, err := NewProvider(ctx, config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, nonce, err := NewState("api-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := VerifyState("api-secret", state); err != nil || n != nonce {
+		t.Fatalf("expecting nonce %s from state, got %s: %v", nonce, n, err)
+	}
+	if _, err := VerifyState("other-secret", state); err == nil {
+		t.Error("expecting invalid state with another secret")
+	}
+	authUrl, err := url.Parse(p.AuthCodeUrl(state, nonce))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authUrl.Path != "/authorize" || authUrl.Query().Get("client_id") != "jetstore" ||
+		authUrl.Query().Get("state") != state || authUrl.Query().Get("nonce") != nonce {
+		t.Errorf("unexpected authorization url: %s", authUrl)
+	}
+
+	idp.claims = jwt.MapClaims{
+		"iss":    idp.server.URL,
+		"aud":    "jetstore",
+		"sub":    "u1",
+		"email":  "jane@example.com",
+		"name":   "Jane",
+		"groups": []string{"analysts", "admins", "other"},
+		"nonce":  nonce,
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}
+	idToken, err := p.Exchange(ctx, "code1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := p.VerifyIdToken(ctx, idToken, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Email != "jane@example.com" || identity.Name != "Jane" || len(identity.Groups) != 3 {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+	if roles := config.RolesFromGroups(identity.Groups); !slices.Equal(roles, []string{"admin", "read"}) {
+		t.Errorf("unexpected roles: %v", roles)
+	}
+
+	// Invalid logins
+	if _, err := p.Exchange(ctx, "bad-code"); err == nil {
+		t.Error("expecting an error with an invalid code")
+	}
+	if _, err := p.VerifyIdToken(ctx, idToken, "other-nonce"); err == nil {
+		t.Error("expecting an error with another nonce")
+	}
+	idp.claims["aud"] = "other-client"
+	idToken, _ = p.Exchange(ctx, "code1")
+	if _, err := p.VerifyIdToken(ctx, idToken, nonce); err == nil {
+		t.Error("expecting an error with another audience")
+	}
+	idp.claims["aud"] = "jetstore"
+	idp.claims["exp"] = time.Now().Add(-time.Hour).Unix()
+	idToken, _ = p.Exchange(ctx, "code1")
+	if _, err := p.VerifyIdToken(ctx, idToken, nonce); err == nil {
+		t.Error("expecting an error with an expired id token")
+	}
+}
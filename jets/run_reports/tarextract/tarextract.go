@@ -70,11 +70,13 @@ func extractFile(localFileName string, tarReader *tar.Reader) error {
 	return nil
 }
 
-func CreateTarGz(basePath string, inputPaths []string, outputPath string) error {
+// CreateTarGz archives inputPaths into outputPath, the files of the input folders
+// that are in excludePaths are not archived
+func CreateTarGz(basePath string, inputPaths []string, outputPath string, excludePaths ...string) error {
 
 	// zip the file, make sure it is compressed for faster speed
 	var buf bytes.Buffer
-	err := compress(basePath, inputPaths, &buf)
+	err := compress(basePath, inputPaths, excludePaths, &buf)
 	if err != nil {
 		return err
 	}
@@ -84,16 +86,20 @@ func CreateTarGz(basePath string, inputPaths []string, outputPath string) error
 }
 
 // inputPaths full path, they will be saved relative to basePath in the archive
-func compress(basePath string, inputPaths []string, buf io.Writer) error {
+func compress(basePath string, inputPaths, excludePaths []string, buf io.Writer) error {
 	zr := gzip.NewWriter(buf)
 	defer zr.Close()
 	tw := tar.NewWriter(zr)
 	defer tw.Close()
 	var err error
+	excluded := make(map[string]bool)
+	for _, path := range excludePaths {
+		excluded[filepath.Clean(path)] = true
+	}
 
 	for _, path := range inputPaths {
 		if strings.HasSuffix(path, "/") {
-			err = addFolder(basePath, path[:len(path)-1], tw)
+			err = addFolder(basePath, path[:len(path)-1], excluded, tw)
 		} else {
 			err = addFile(basePath, path, tw)
 		}
@@ -105,12 +111,15 @@ func compress(basePath string, inputPaths []string, buf io.Writer) error {
 	return nil
 }
 
-func addFolder(basePath, src string, tw *tar.Writer) error {
+func addFolder(basePath, src string, excluded map[string]bool, tw *tar.Writer) error {
 
 	return filepath.Walk(src, func(file string, fi os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
+		if excluded[filepath.Clean(file)] {
+			return nil
+		}
 
 		header, err := tar.FileInfoHeader(fi, file)
 		if err != nil {
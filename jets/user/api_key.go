@@ -0,0 +1,301 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// API keys of the service accounts, for machine-to-machine access.
+// An API key has the form jtk_<key_id>_<secret>, the key_id identifies the key and
+// the secret is stored hashed in jetsapi.api_keys. A key is limited to a subset of the
+// capabilities of role_capability, it is long-lived and can be revoked.
+// The api server exchanges a valid API key for a token of the service account, the
+// token carries the key_id so the capabilities of the key are used for the request.
+
+const ApiKeyPrefix = "jtk_"
+
+// ServiceAccountPrefix is the prefix of the user email of the service accounts
+const ServiceAccountPrefix = "svc:"
+
+// ApiKeyLastUsedInterval is the resolution of last_used of the api keys, the last use
+// of a key is recorded at most once per interval by each api server
+var ApiKeyLastUsedInterval = 5 * time.Minute
+
+// apiKeyUsage tracks when the last use of the api keys was recorded
+type apiKeyUsage struct {
+	mu           sync.Mutex
+	lastRecorded map[string]time.Time
+}
+
+var apiKeyLastUsed = &apiKeyUsage{lastRecorded: make(map[string]time.Time)}
+
+// shouldRecord returns true when the use of keyId at now must be recorded in the db,
+// it is recorded when it was not recorded in the last ApiKeyLastUsedInterval
+func (u *apiKeyUsage) shouldRecord(keyId string, now time.Time) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if last, ok := u.lastRecorded[keyId]; ok && now.Sub(last) < ApiKeyLastUsedInterval {
+		return false
+	}
+	u.lastRecorded[keyId] = now
+	return true
+}
+
+type ApiKey struct {
+	KeyId          string     `json:"key_id"`
+	ServiceAccount string     `json:"service_account"`
+	Description    string     `json:"description"`
+	Capabilities   []string   `json:"capabilities"`
+	IsRevoked      int        `json:"is_revoked"`
+	LastUsed       *time.Time `json:"last_used"`
+	CreatedBy      string     `json:"user_email"`
+	keyHash        string
+}
+
+// IsApiKey returns true when token is an API key rather than a jwt token
+func IsApiKey(token string) bool {
+	return strings.HasPrefix(token, ApiKeyPrefix)
+}
+
+// ServiceAccountEmail returns the user email of the service account
+func ServiceAccountEmail(serviceAccount string) string {
+	return ServiceAccountPrefix + serviceAccount
+}
+
+func hashApiKeySecret(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// parseApiKey returns the key_id and the secret of apiKey
+func parseApiKey(apiKey string) (string, string, error) {
+	parts := strings.Split(strings.TrimPrefix(apiKey, ApiKeyPrefix), "_")
+	if !IsApiKey(apiKey) || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.New("error: invalid api key")
+	}
+	return parts[0], parts[1], nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// newApiKeySecret returns a new api key with its key_id and the hash of its secret
+func newApiKeySecret() (string, string, string, error) {
+	keyId, err := randomHex(8)
+	if err != nil {
+		return "", "", "", fmt.Errorf("while generating api key id: %v", err)
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return "", "", "", fmt.Errorf("while generating api key secret: %v", err)
+	}
+	return fmt.Sprintf("%s%s_%s", ApiKeyPrefix, keyId, secret), keyId, hashApiKeySecret(secret), nil
+}
+
+// verifyApiKeyCapabilities returns an error when capabilities is empty or is not a
+// subset of knownCapabilities
+func verifyApiKeyCapabilities(capabilities, knownCapabilities []string) error {
+	if len(capabilities) == 0 {
+		return errors.New("error: an api key requires at least one capability")
+	}
+	for _, capability := range capabilities {
+		if !slices.Contains(knownCapabilities, capability) {
+			return fmt.Errorf("error: unknown capability '%s'", capability)
+		}
+	}
+	return nil
+}
+
+// CreateApiKey creates an API key for the service account, limited to capabilities, which
+// must be capabilities of role_capability. Returns the API key, it is not stored and cannot
+// be retrieved later.
+func CreateApiKey(dbpool *pgxpool.Pool, serviceAccount, description string, capabilities []string, createdBy string) (string, *ApiKey, error) {
+	if serviceAccount == "" || strings.ContainsAny(serviceAccount, " \t") {
+		return "", nil, errors.New("error: service account name is required and must not contain spaces")
+	}
+	// Validate the capabilities against role_capability
+	rows, err := dbpool.Query(context.Background(), "SELECT DISTINCT capability FROM jetsapi.role_capability")
+	if err != nil {
+		return "", nil, fmt.Errorf("while reading capabilities: %v", err)
+	}
+	knownCapabilities := make([]string, 0)
+	for rows.Next() {
+		var capability string
+		if err = rows.Scan(&capability); err != nil {
+			rows.Close()
+			return "", nil, fmt.Errorf("while scanning capability: %v", err)
+		}
+		knownCapabilities = append(knownCapabilities, capability)
+	}
+	rows.Close()
+	if err = verifyApiKeyCapabilities(capabilities, knownCapabilities); err != nil {
+		return "", nil, err
+	}
+
+	apiKey, keyId, keyHash, err := newApiKeySecret()
+	if err != nil {
+		return "", nil, err
+	}
+	key := &ApiKey{
+		KeyId:          keyId,
+		ServiceAccount: serviceAccount,
+		Description:    description,
+		Capabilities:   capabilities,
+		CreatedBy:      createdBy,
+	}
+	stmt := `INSERT INTO jetsapi.api_keys (key_id, key_hash, service_account, description, capabilities, user_email)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err = dbpool.Exec(context.Background(), stmt, keyId, keyHash,
+		serviceAccount, description, capabilities, createdBy)
+	if err != nil {
+		return "", nil, fmt.Errorf("while inserting api key: %v", err)
+	}
+	return apiKey, key, nil
+}
+
+// getApiKey returns the api key with keyId
+func getApiKey(dbpool *pgxpool.Pool, keyId string) (*ApiKey, error) {
+	key := &ApiKey{KeyId: keyId}
+	stmt := `SELECT key_hash, service_account, description, capabilities, is_revoked, last_used, user_email
+		FROM jetsapi.api_keys WHERE key_id = $1`
+	err := dbpool.QueryRow(context.Background(), stmt, keyId).Scan(&key.keyHash, &key.ServiceAccount,
+		&key.Description, &key.Capabilities, &key.IsRevoked, &key.LastUsed, &key.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// verifyApiKeySecret returns an error when secret does not match the hash of key or
+// when key is revoked
+func verifyApiKeySecret(key *ApiKey, secret string) error {
+	if subtle.ConstantTimeCompare([]byte(key.keyHash), []byte(hashApiKeySecret(secret))) != 1 {
+		return errors.New("error: invalid api key")
+	}
+	if key.IsRevoked != 0 {
+		return errors.New("error: api key is revoked")
+	}
+	return nil
+}
+
+// ValidateApiKey returns the api key when apiKey is valid and not revoked, and
+// records its last use, see ApiKeyLastUsedInterval
+func ValidateApiKey(dbpool *pgxpool.Pool, apiKey string) (*ApiKey, error) {
+	keyId, secret, err := parseApiKey(apiKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := getApiKey(dbpool, keyId)
+	if err != nil {
+		log.Printf("while reading api key %s: %v", keyId, err)
+		return nil, errors.New("error: invalid api key")
+	}
+	if err = verifyApiKeySecret(key, secret); err != nil {
+		return nil, err
+	}
+	if apiKeyLastUsed.shouldRecord(keyId, time.Now()) {
+		_, err = dbpool.Exec(context.Background(),
+			"UPDATE jetsapi.api_keys SET last_used = now() WHERE key_id = $1", keyId)
+		if err != nil {
+			log.Printf("while updating last use of api key %s: %v", keyId, err)
+		}
+	}
+	return key, nil
+}
+
+// RevokeApiKey revokes the api key with keyId
+func RevokeApiKey(dbpool *pgxpool.Pool, keyId string) error {
+	tag, err := dbpool.Exec(context.Background(),
+		"UPDATE jetsapi.api_keys SET (is_revoked, last_update) = (1, DEFAULT) WHERE key_id = $1", keyId)
+	if err != nil {
+		return fmt.Errorf("while revoking api key %s: %v", keyId, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("error: api key %s not found", keyId)
+	}
+	return nil
+}
+
+// ListApiKeys returns the api keys, without their hash
+func ListApiKeys(dbpool *pgxpool.Pool) ([]*ApiKey, error) {
+	stmt := `SELECT key_id, service_account, description, capabilities, is_revoked, last_used, user_email
+		FROM jetsapi.api_keys ORDER BY service_account, key_id`
+	rows, err := dbpool.Query(context.Background(), stmt)
+	if err != nil {
+		return nil, fmt.Errorf("while reading api keys: %v", err)
+	}
+	defer rows.Close()
+	keys := make([]*ApiKey, 0)
+	for rows.Next() {
+		key := &ApiKey{}
+		err = rows.Scan(&key.KeyId, &key.ServiceAccount, &key.Description, &key.Capabilities,
+			&key.IsRevoked, &key.LastUsed, &key.CreatedBy)
+		if err != nil {
+			return nil, fmt.Errorf("while scanning api key: %v", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// CreateApiKeyToken returns the token of the service account of key, valid for the
+// duration of a request
+func CreateApiKeyToken(key *ApiKey) (string, error) {
+	claims := jwt.MapClaims{}
+	claims["authorized"] = true
+	claims["email"] = ServiceAccountEmail(key.ServiceAccount)
+	claims["api_key_id"] = key.KeyId
+	claims["exp"] = time.Now().Add(time.Minute * time.Duration(TokenExpiration)).Unix()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(ApiSecret))
+}
+
+// newServiceAccountUser returns the user of the service account of key, limited to the
+// capabilities of key
+func newServiceAccountUser(key *ApiKey) *User {
+	u := NewUser(ServiceAccountEmail(key.ServiceAccount))
+	u.Name = key.ServiceAccount
+	u.IsActive = 1
+	for _, capability := range key.Capabilities {
+		u.capabilities[capability] = true
+	}
+	return u
+}
+
+// getServiceAccountUser returns the user of the service account of the api key keyId,
+// with the capabilities of the api key
+func getServiceAccountUser(dbpool *pgxpool.Pool, keyId string) (*User, error) {
+	key, err := getApiKey(dbpool, keyId)
+	if err != nil {
+		log.Println("while reading api key from db:", err)
+		return nil, errors.New("invalid api key")
+	}
+	if key.IsRevoked != 0 {
+		return nil, errors.New("api key is revoked")
+	}
+	u := newServiceAccountUser(key)
+	// Clients the service account is restricted to
+	if err = u.loadClientScopes(dbpool); err != nil {
+		log.Println("while loading client access of service account:", err)
+		return nil, errors.New("error retreiving client access")
+	}
+	return u, nil
+}
@@ -0,0 +1,122 @@
+package user
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewApiKeySecret(t *testing.T) {
+	apiKey, keyId, keyHash, err := newApiKeySecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsApiKey(apiKey) || !strings.HasPrefix(apiKey, ApiKeyPrefix+keyId+"_") {
+		t.Errorf("unexpected api key format %s for key id %s", apiKey, keyId)
+	}
+	id, secret, err := parseApiKey(apiKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != keyId || len(secret) != 64 {
+		t.Errorf("expecting key id %s and a 64 chars secret, got %s and %s", keyId, id, secret)
+	}
+	if keyHash != hashApiKeySecret(secret) || strings.Contains(keyHash, secret) {
+		t.Error("expecting the hash of the secret")
+	}
+	other, _, _, _ := newApiKeySecret()
+	if other == apiKey {
+		t.Error("expecting a new api key on each call")
+	}
+}
+
+func TestParseApiKey_Invalid(t *testing.T) {
+	for _, apiKey := range []string{
+		"",
+		"abc_def",
+		"jtk_",
+		"jtk_abc",
+		"jtk_abc_",
+		"jtk__def",
+		"jtk_abc_def_ghi",
+		"Bearer jtk_abc_def",
+	} {
+		if _, _, err := parseApiKey(apiKey); err == nil {
+			t.Errorf("expecting an error for api key %q", apiKey)
+		}
+	}
+}
+
+func TestVerifyApiKeySecret(t *testing.T) {
+	key := &ApiKey{KeyId: "0123456789abcdef", keyHash: hashApiKeySecret("secret")}
+	if err := verifyApiKeySecret(key, "secret"); err != nil {
+		t.Errorf("expecting a valid api key, got %v", err)
+	}
+	if err := verifyApiKeySecret(key, "Secret"); err == nil || err.Error() != "error: invalid api key" {
+		t.Errorf("expecting invalid api key, got %v", err)
+	}
+	if err := verifyApiKeySecret(key, ""); err == nil {
+		t.Error("expecting invalid api key for an empty secret")
+	}
+
+	// A revoked key is refused, but the secret is checked first so a wrong secret
+	// does not tell the key is revoked
+	key.IsRevoked = 1
+	if err := verifyApiKeySecret(key, "secret"); err == nil || err.Error() != "error: api key is revoked" {
+		t.Errorf("expecting revoked api key, got %v", err)
+	}
+	if err := verifyApiKeySecret(key, "wrong"); err == nil || err.Error() != "error: invalid api key" {
+		t.Errorf("expecting invalid api key, got %v", err)
+	}
+}
+
+func TestVerifyApiKeyCapabilities(t *testing.T) {
+	known := []string{"client_config", "run_pipelines", "workspace_ide"}
+	if err := verifyApiKeyCapabilities([]string{"run_pipelines"}, known); err != nil {
+		t.Errorf("expecting valid capabilities, got %v", err)
+	}
+	if err := verifyApiKeyCapabilities(nil, known); err == nil {
+		t.Error("expecting an error for an api key without capability")
+	}
+	if err := verifyApiKeyCapabilities([]string{"run_pipelines", "jetstore_admin"}, known); err == nil ||
+		!strings.Contains(err.Error(), "jetstore_admin") {
+		t.Errorf("expecting unknown capability error, got %v", err)
+	}
+}
+
+func TestNewServiceAccountUser(t *testing.T) {
+	key := &ApiKey{KeyId: "0123456789abcdef", ServiceAccount: "loader",
+		Capabilities: []string{"run_pipelines"}}
+	u := newServiceAccountUser(key)
+	if u.Email != "svc:loader" || u.IsAdmin() || u.IsActive != 1 {
+		t.Errorf("unexpected service account user %s", u.Email)
+	}
+	if !u.HasCapability("run_pipelines") {
+		t.Error("expecting the capability of the api key")
+	}
+	for _, capability := range []string{"client_config", "workspace_ide", "jetstore_admin", ""} {
+		if u.HasCapability(capability) {
+			t.Errorf("expecting the service account to be limited to the api key, got %s", capability)
+		}
+	}
+}
+
+func TestApiKeyUsage(t *testing.T) {
+	usage := &apiKeyUsage{lastRecorded: make(map[string]time.Time)}
+	now := time.Now()
+	if !usage.shouldRecord("key1", now) {
+		t.Error("expecting the first use to be recorded")
+	}
+	if usage.shouldRecord("key1", now.Add(time.Second)) {
+		t.Error("expecting a use within the interval not to be recorded")
+	}
+	if !usage.shouldRecord("key2", now.Add(time.Second)) {
+		t.Error("expecting the first use of another key to be recorded")
+	}
+	if !usage.shouldRecord("key1", now.Add(ApiKeyLastUsedInterval)) {
+		t.Error("expecting a use after the interval to be recorded")
+	}
+	if usage.shouldRecord("key1", now.Add(ApiKeyLastUsedInterval+time.Second)) {
+		t.Error("expecting the interval to restart from the last recorded use")
+	}
+}
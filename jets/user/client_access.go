@@ -0,0 +1,147 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Client access: a user can be restricted to a set of clients, and optionally to some
+// orgs of these clients, using table jetsapi.client_access. The entries apply to a user
+// (principal_type 'user', principal is the user email) or to all users having a role
+// (principal_type 'role'). A user without entries, directly or by its roles, is not
+// restricted, the admin is never restricted.
+
+// ClientScope is a client the user can access, Org is empty for all orgs of the client
+type ClientScope struct {
+	Client string `json:"client"`
+	Org    string `json:"org"`
+}
+
+// ClientAccessError is returned when the user does not have access to a client, Client is
+// empty when the action requires access to all clients
+type ClientAccessError struct {
+	UserEmail string
+	Action    string
+	Client    string
+	Org       string
+}
+
+func (e *ClientAccessError) Error() string {
+	if e.Client == "" {
+		return fmt.Sprintf("error: unauthorized, %s requires access to all clients", e.Action)
+	}
+	if e.Org == "" {
+		return fmt.Sprintf("error: unauthorized, user does not have access to client '%s'", e.Client)
+	}
+	return fmt.Sprintf("error: unauthorized, user does not have access to client '%s' org '%s'", e.Client, e.Org)
+}
+
+// loadClientScopes reads the client scopes of the user, from the user and its roles
+func (u *User) loadClientScopes(dbpool *pgxpool.Pool) error {
+	roles := make([]string, 0, len(u.roles))
+	for role := range u.roles {
+		roles = append(roles, role)
+	}
+	stmt := `SELECT DISTINCT client, org FROM jetsapi.client_access
+		WHERE (principal_type = 'user' AND principal = $1) OR (principal_type = 'role' AND principal = ANY($2))
+		ORDER BY client, org`
+	rows, err := dbpool.Query(context.Background(), stmt, u.Email, roles)
+	if err != nil {
+		return fmt.Errorf("while reading client access: %v", err)
+	}
+	defer rows.Close()
+	u.clientScopes = make([]ClientScope, 0)
+	for rows.Next() {
+		var scope ClientScope
+		if err = rows.Scan(&scope.Client, &scope.Org); err != nil {
+			return fmt.Errorf("while scanning client access: %v", err)
+		}
+		u.clientScopes = append(u.clientScopes, scope)
+	}
+	return nil
+}
+
+// IsClientRestricted returns true when the user is restricted to the clients of ClientScopes
+func (u *User) IsClientRestricted() bool {
+	return !u.IsAdmin() && len(u.clientScopes) > 0
+}
+
+// ClientScopes returns the clients the user is restricted to, empty when not restricted
+func (u *User) ClientScopes() []ClientScope {
+	if !u.IsClientRestricted() {
+		return []ClientScope{}
+	}
+	return u.clientScopes
+}
+
+// SetClientScopes sets the clients the user is restricted to, used when the client scopes
+// are not read from jetsapi.client_access
+func (u *User) SetClientScopes(scopes []ClientScope) {
+	u.clientScopes = scopes
+}
+
+// CanAccessClient returns true when the user has access to client and org, org is
+// ignored when empty (access to at least one org of the client)
+func (u *User) CanAccessClient(client, org string) bool {
+	if !u.IsClientRestricted() {
+		return true
+	}
+	for _, scope := range u.clientScopes {
+		if scope.Client == client && (scope.Org == "" || org == "" || scope.Org == org) {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyClientAccess returns a *ClientAccessError when the user does not have access to client and org
+func (u *User) VerifyClientAccess(action, client, org string) error {
+	if u.CanAccessClient(client, org) {
+		return nil
+	}
+	return &ClientAccessError{UserEmail: u.Email, Action: action, Client: client, Org: org}
+}
+
+// ClientScopeSql returns the sql condition restricting clientColumn and orgColumn, which are
+// sanitized column names, to the client scopes of the user. orgColumn is empty when the table
+// has no org column. Returns an empty string when the user is not restricted.
+func (u *User) ClientScopeSql(clientColumn, orgColumn string) string {
+	if !u.IsClientRestricted() {
+		return ""
+	}
+	// orgs by client, nil for all orgs
+	orgsByClient := make(map[string][]string)
+	clients := make([]string, 0)
+	for _, scope := range u.clientScopes {
+		orgs, ok := orgsByClient[scope.Client]
+		if !ok {
+			clients = append(clients, scope.Client)
+			orgs = make([]string, 0)
+		}
+		if scope.Org == "" || orgColumn == "" || orgs == nil {
+			orgsByClient[scope.Client] = nil
+		} else {
+			orgsByClient[scope.Client] = append(orgs, sqlLiteral(scope.Org))
+		}
+	}
+	slices.Sort(clients)
+	conditions := make([]string, 0, len(clients))
+	for _, client := range clients {
+		orgs := orgsByClient[client]
+		if orgs == nil {
+			conditions = append(conditions, fmt.Sprintf("%s = %s", clientColumn, sqlLiteral(client)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("(%s = %s AND %s IN (%s))",
+				clientColumn, sqlLiteral(client), orgColumn, strings.Join(orgs, ",")))
+		}
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+func sqlLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,91 @@
+package user
+
+import (
+	"errors"
+	"testing"
+)
+
+func newRestrictedUser(scopes ...ClientScope) *User {
+	u := NewUser("user@client.com")
+	u.SetClientScopes(scopes)
+	return u
+}
+
+func TestCanAccessClient(t *testing.T) {
+	u := newRestrictedUser(ClientScope{Client: "ACME"}, ClientScope{Client: "Globex", Org: "East"})
+	tests := []struct {
+		client   string
+		org      string
+		expected bool
+	}{
+		{"ACME", "", true},
+		{"ACME", "North", true},
+		{"Globex", "East", true},
+		{"Globex", "", true},
+		{"Globex", "West", false},
+		{"Initech", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := u.CanAccessClient(tt.client, tt.org); got != tt.expected {
+			t.Errorf("CanAccessClient(%q, %q): expected %v, got %v", tt.client, tt.org, tt.expected, got)
+		}
+	}
+}
+
+func TestCanAccessClient_NotRestricted(t *testing.T) {
+	u := NewUser("user@client.com")
+	if u.IsClientRestricted() || !u.CanAccessClient("Initech", "West") {
+		t.Error("Expecting a user without client scopes to access all clients")
+	}
+	admin := newRestrictedUser(ClientScope{Client: "ACME"})
+	admin.Email = AdminEmail
+	if admin.IsClientRestricted() || !admin.CanAccessClient("Initech", "") {
+		t.Error("Expecting the admin to access all clients")
+	}
+	if len(admin.ClientScopes()) != 0 {
+		t.Errorf("Expecting no client scopes for the admin, got %v", admin.ClientScopes())
+	}
+}
+
+func TestVerifyClientAccess(t *testing.T) {
+	u := newRestrictedUser(ClientScope{Client: "ACME"})
+	if err := u.VerifyClientAccess("update/source_config", "ACME", ""); err != nil {
+		t.Errorf("Expecting access to ACME, got %v", err)
+	}
+	err := u.VerifyClientAccess("update/source_config", "Initech", "West")
+	var accessErr *ClientAccessError
+	if !errors.As(err, &accessErr) {
+		t.Fatalf("Expecting a *ClientAccessError, got %v", err)
+	}
+	if accessErr.Client != "Initech" || accessErr.Org != "West" {
+		t.Errorf("Unexpected ClientAccessError: %+v", accessErr)
+	}
+}
+
+func TestClientScopeSql(t *testing.T) {
+	tests := []struct {
+		name      string
+		scopes    []ClientScope
+		orgColumn string
+		expected  string
+	}{
+		{"not restricted", nil, `"org"`, ""},
+		{"clients", []ClientScope{{Client: "Globex"}, {Client: "ACME"}}, `"org"`,
+			`("client" = 'ACME' OR "client" = 'Globex')`},
+		{"orgs", []ClientScope{{Client: "ACME", Org: "East"}, {Client: "ACME", Org: "West"}}, `"org"`,
+			`(("client" = 'ACME' AND "org" IN ('East','West')))`},
+		{"all orgs wins", []ClientScope{{Client: "ACME", Org: "East"}, {Client: "ACME"}, {Client: "ACME", Org: "West"}}, `"org"`,
+			`("client" = 'ACME')`},
+		{"no org column", []ClientScope{{Client: "ACME", Org: "East"}}, "",
+			`("client" = 'ACME')`},
+		{"quoted literal", []ClientScope{{Client: "O'Brien"}}, "",
+			`("client" = 'O''Brien')`},
+	}
+	for _, tt := range tests {
+		u := newRestrictedUser(tt.scopes...)
+		if got := u.ClientScopeSql(`"client"`, tt.orgColumn); got != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected, got)
+		}
+	}
+}
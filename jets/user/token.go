@@ -89,6 +89,26 @@ func ExtractTokenID(token string) (string, error) {
 	return "", nil
 }
 
+// ExtractApiKeyId returns the api key id of the token of a service account, see
+// CreateApiKeyToken, returns empty when token is not the token of a service account
+func ExtractApiKeyId(token string) string {
+	jwtToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(ApiSecret), nil
+	})
+	if err != nil || !jwtToken.Valid {
+		return ""
+	}
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	keyId, _ := claims["api_key_id"].(string)
+	return keyId
+}
+
 //Pretty display the claims nicely in the terminal
 func Pretty(data interface{}) {
 	b, err := json.MarshalIndent(data, "", " ")
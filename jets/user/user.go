@@ -27,6 +27,7 @@ type User struct {
 	DevMode        string    `json:"dev_mode"`
 	roles          map[string]bool
 	capabilities   map[string]bool
+	clientScopes   []ClientScope
 	IsActive       int        `json:"is_active"`
 	UserGitProfile GitProfile `json:"gitProfile"`
 }
@@ -153,6 +154,41 @@ func (u *User) InsertUser(dbpool *pgxpool.Pool) error {
 	return nil
 }
 
+// UpsertSsoUser creates the user on first sso login, otherwise updates the user's name
+// and roles from the identity provider. The user of a new account is active and gets a
+// random password, so it cannot login with a local password. The user's active status
+// of an existing account is not changed.
+// An existing local account (auth_provider is not sso) is not taken over by the sso login,
+// it must be linked by an administrator by setting its auth_provider to sso.
+func UpsertSsoUser(dbpool *pgxpool.Pool, name, email string, roles []string) (*User, error) {
+	if email == AdminEmail {
+		return nil, errors.New("error: the administrator must use the local login")
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, fmt.Errorf("while generating password of sso user: %v", err)
+	}
+	password, err := Hash(secret)
+	if err != nil {
+		return nil, fmt.Errorf("while hashing password of sso user: %v", err)
+	}
+	stmt := `INSERT INTO jetsapi.users (name, user_email, password, encrypted_roles, is_active, auth_provider)
+		VALUES ($1, $2, $3, $4, 1, 'sso')
+		ON CONFLICT (user_email) DO UPDATE SET (name, encrypted_roles, last_update) =
+			(EXCLUDED.name, EXCLUDED.encrypted_roles, DEFAULT)
+		WHERE users.auth_provider = 'sso'`
+	tag, err := dbpool.Exec(context.Background(), stmt, name, email, string(password), roles)
+	if err != nil {
+		log.Println("while upserting sso user in db:", err)
+		return nil, errors.New("unknown error while saving sso user")
+	}
+	if tag.RowsAffected() == 0 {
+		log.Printf("sso login refused for %s: the account is a local account not linked to sso", email)
+		return nil, errors.New("error: the account is a local account, it must be linked to sso by an administrator")
+	}
+	return GetUserByEmail(dbpool, email)
+}
+
 func GetUserByEmail(dbpool *pgxpool.Pool, email string) (*User, error) {
 	// select from db
 	u := NewUser(email)
@@ -202,11 +238,24 @@ func GetUserByEmail(dbpool *pgxpool.Pool, email string) (*User, error) {
 			u.capabilities[capability] = true
 		}
 	}
-
+	// Clients the user is restricted to
+	if err = u.loadClientScopes(dbpool); err != nil {
+		log.Println("while loading client access of user:", err)
+		return nil, errors.New("error retreiving client access")
+	}
 	return u, nil
 }
 
 func GetUserByToken(dbpool *pgxpool.Pool, token string) (*User, error) {
+	// Token of a service account, the user has the capabilities of the api key
+	if keyId := ExtractApiKeyId(token); keyId != "" {
+		user, err := getServiceAccountUser(dbpool, keyId)
+		if err != nil {
+			log.Println("while getServiceAccountUser", err.Error())
+			return nil, errors.New("error: unauthorized, cannot get service account info")
+		}
+		return user, nil
+	}
 	// Get user info
 	userEmail, err := ExtractTokenID(token)
 	if err != nil {
@@ -68,8 +68,53 @@ func compileWorkspaceV2(dbpool *pgxpool.Pool, workspaceControl *rete.WorkspaceCo
 	var lookupTables []*rete.LookupTableNode
 
 	workspacePath := fmt.Sprintf("%s/%s", workspaceHome, workspaceName)
+
+	// Load the build cache, the main rule files that did not change since the last
+	// compilation, including their imports, are not recompiled: their compiled model
+	// is reloaded and saved to the new workspace.db.
+	// The cache is a build output, it is kept in the build directory and it is not
+	// packaged in workspace.tgz
+	buildCachePath := fmt.Sprintf("%s/%s", buildDir, buildCacheFileName)
+	buildCache := compiler.LoadBuildCache(buildCachePath)
+	for name := range buildCache.RuleSets {
+		if !mainRuleFiles[name] {
+			buildCache.RemoveRuleSet(name)
+		}
+	}
+	compilerOptions := fmt.Sprintf("trace=%v,auto_add_resources=%v",
+		workspaceControl.UseTraceMode, workspaceControl.AutoAddResources)
+	// The files imported by several main rule files are hashed once
+	fileHashes := make(map[string]string)
+	hashFile := func(fileName string) (string, error) {
+		if h, ok := fileHashes[fileName]; ok {
+			return h, nil
+		}
+		data, err := os.ReadFile(fmt.Sprintf("%s/%s", workspacePath, fileName))
+		if err != nil {
+			return "", err
+		}
+		h := compiler.ContentHash(string(data))
+		fileHashes[fileName] = h
+		return h, nil
+	}
+
 	for name := range mainRuleFiles {
 		// name is the file path relative to workspace home
+		if buildCache.IsUpToDate(name, compilerOptions, hashFile) && isRuleSetBuilt(name) {
+			cachedModel, err := compiler.LoadModel(workspacePath, name)
+			if err == nil {
+				fmt.Fprintf(&buf, "Rule file is up to date: %s\n", name)
+				err = compiler.SaveModelToWorkspaceDB(workspacePath, cachedModel)
+				if err != nil {
+					return buf.String(), fmt.Errorf("while saving model of rule file '%s': %w", name, err)
+				}
+				classes = append(classes, cachedModel.Classes...)
+				tables = append(tables, cachedModel.Tables...)
+				lookupTables = append(lookupTables, cachedModel.LookupTables...)
+				continue
+			}
+			log.Printf("Recompiling rule file '%s', the cached model is not available: %v", name, err)
+		}
 		fmt.Fprintf(&buf, "Compiling rule file: %s\n", name)
 		jrCompiler = compiler.NewCompiler(
 			workspacePath, name, false, workspaceControl.UseTraceMode,
@@ -192,6 +237,9 @@ func compileWorkspaceV2(dbpool *pgxpool.Pool, workspaceControl *rete.WorkspaceCo
 		encoder = json.NewEncoder(file)
 		encoder.Encode(tripleModel)
 		file.Close()
+
+		// Rule file compiled, record the files it read in the build cache
+		buildCache.SetRuleSet(name, compilerOptions, jrCompiler.SourceFileHashes())
 	}
 
 	// Add all rule sequences
@@ -212,12 +260,47 @@ func compileWorkspaceV2(dbpool *pgxpool.Pool, workspaceControl *rete.WorkspaceCo
 
 	// All files are now compiled
 	buf.WriteString("All main rule files compiled, now package the lookup.db file\n")
-	err = PackageLookupTablesToSqlite(lookupTables)
+	// Package only the lookup tables that changed since the last compilation,
+	// all of them when lookup.db does not exist
+	_, err = os.Stat(fmt.Sprintf("%s/%s/lookup.db", workspaceHome, wprefix))
+	hasLookupDb := err == nil
+	changedLookupTables := make([]*rete.LookupTableNode, 0, len(lookupTables))
+	lookupTableNames := make(map[string]bool)
+	for _, lookupTbl := range lookupTables {
+		lookupTableNames[lookupTbl.Name] = true
+		hash, err := lookupTableHash(lookupTbl)
+		if err != nil {
+			return buf.String(), err
+		}
+		if hasLookupDb && buildCache.IsLookupTableUpToDate(lookupTbl.Name, hash) {
+			continue
+		}
+		buildCache.SetLookupTable(lookupTbl.Name, hash)
+		changedLookupTables = append(changedLookupTables, lookupTbl)
+	}
+	err = PackageLookupTablesToSqlite(changedLookupTables)
 	if err != nil {
 		log.Println("Error packaging lookup tables to SQLite:", err)
 		return buf.String(), err
 	}
-	buf.WriteString("Lookup tables packaged into lookup.db\n")
+	fmt.Fprintf(&buf, "%d lookup table(s) packaged into lookup.db\n", len(changedLookupTables))
+	// Remove the lookup tables deleted from the workspace
+	deletedLookupTables := buildCache.PruneLookupTables(lookupTableNames)
+	if hasLookupDb {
+		err = DropLookupTablesFromSqlite(deletedLookupTables)
+		if err != nil {
+			log.Println("Error dropping deleted lookup tables from SQLite:", err)
+			return buf.String(), err
+		}
+		fmt.Fprintf(&buf, "%d deleted lookup table(s) dropped from lookup.db\n", len(deletedLookupTables))
+	}
+
+	// Save the build cache for the next compilation
+	err = buildCache.Save(buildCachePath)
+	if err != nil {
+		log.Println("Warning: the build cache is not saved, the next compilation will be a full build:", err)
+		os.Remove(buildCachePath)
+	}
 
 	// Archive reports
 	inputPath := []string{fmt.Sprintf("%s/%s/reports/", workspaceHome, workspaceName)}
@@ -289,7 +372,8 @@ func compileWorkspaceV2(dbpool *pgxpool.Pool, workspaceControl *rete.WorkspaceCo
 	}
 	outputPath = fmt.Sprintf("%s/%s/workspace.tgz", workspaceHome, workspaceName)
 	buf.WriteString("\nArchiving the build and cpipes config directories\n")
-	err = tarextract.CreateTarGz(fmt.Sprintf("%s/%s", workspaceHome, workspaceName), inputPath, outputPath)
+	err = tarextract.CreateTarGz(fmt.Sprintf("%s/%s", workspaceHome, workspaceName), inputPath, outputPath,
+		buildCachePath)
 	if err != nil {
 		buf.WriteString("Error:")
 		buf.WriteString(err.Error())
@@ -327,3 +411,17 @@ func compileWorkspaceV2(dbpool *pgxpool.Pool, workspaceControl *rete.WorkspaceCo
 
 	return buf.String(), err
 }
+
+// buildCacheFileName is the name of the build cache in the build directory
+const buildCacheFileName = "build_cache.json"
+
+// isRuleSetBuilt returns true when the build files of the main rule file name exist
+func isRuleSetBuilt(name string) bool {
+	for _, suffix := range []string{"rete.json", "model.json", "config.json", "triples.json"} {
+		fpath := fmt.Sprintf("%s/%s/build/%s.%s", workspaceHome, wprefix, strings.TrimSuffix(name, ".jr"), suffix)
+		if _, err := os.Stat(fpath); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -2,12 +2,14 @@ package workspace
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"strconv"
 	"strings"
 
+	"github.com/artisoft-io/jetstore/jets/compilerv2/compiler"
 	"github.com/artisoft-io/jetstore/jets/csv"
 	"github.com/artisoft-io/jetstore/jets/jetrules/rdf"
 	"github.com/artisoft-io/jetstore/jets/jetrules/rete"
@@ -201,6 +203,52 @@ func PackageLookupTablesToSqlite(lookupTables []*rete.LookupTableNode) error {
 	return nil
 }
 
+// DropLookupTablesFromSqlite drops the lookup tables that were deleted from the workspace
+// from lookup.db, their indexes are dropped with them
+func DropLookupTablesFromSqlite(names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	dbPath := fmt.Sprintf("%s/%s/lookup.db", workspaceHome, wprefix)
+	lookupDb, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return fmt.Errorf("while opening lookup.db: %v", err)
+	}
+	defer lookupDb.Close()
+	for _, name := range names {
+		log.Printf("Dropping deleted lookup table %s", name)
+		_, err = lookupDb.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS "%s"`, name))
+		if err != nil {
+			return fmt.Errorf("while dropping lookup table %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// lookupTableHash returns the hash of the definition and of the csv file of the lookup table,
+// used by the build cache to repackage only the lookup tables that changed
+func lookupTableHash(lookupTbl *rete.LookupTableNode) (string, error) {
+	definition, err := json.Marshal(&rete.LookupTableNode{
+		Columns: lookupTbl.Columns,
+		CsvFile: lookupTbl.CsvFile,
+		Key:     lookupTbl.Key,
+		Name:    lookupTbl.Name,
+		Type:    lookupTbl.Type,
+	})
+	if err != nil {
+		return "", fmt.Errorf("while converting lookup table %s to json: %v", lookupTbl.Name, err)
+	}
+	var data []byte
+	if len(lookupTbl.CsvFile) > 0 {
+		filePath := fmt.Sprintf("%s/%s/%s", workspaceHome, wprefix, lookupTbl.CsvFile)
+		data, err = os.ReadFile(filePath)
+		if err != nil {
+			return "", fmt.Errorf("while reading lookup table csv file %s: %v", filePath, err)
+		}
+	}
+	return compiler.ContentHash(string(definition) + compiler.ContentHash(string(data))), nil
+}
+
 func quote(s string) string {
 	if "NULL" == strings.ToUpper(s) {
 		return "NULL"
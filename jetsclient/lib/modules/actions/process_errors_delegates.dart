@@ -27,7 +27,7 @@ Future<String?> processErrorsActions(BuildContext context,
         'action': 'raw_query',
       };
       rawQuery['query'] =
-          "SELECT rete_session_triples FROM jetsapi.process_errors WHERE key = $key";
+          "SELECT rete_session_triples, session_id FROM jetsapi.process_errors WHERE key = $key";
       final rows = await queryJetsDataModel(
           context, formState, ServerEPs.dataTableEP, json.encode(rawQuery));
       if (rows == null) {
@@ -55,7 +55,7 @@ Future<String?> processErrorsActions(BuildContext context,
         'action': 'raw_query',
       };
       rawQuery['query'] =
-          "SELECT rete_session_triples FROM jetsapi.process_errors WHERE key = $key";
+          "SELECT rete_session_triples, session_id FROM jetsapi.process_errors WHERE key = $key";
       final rows = await queryJetsDataModel(
           context, formState, ServerEPs.dataTableEP, json.encode(rawQuery));
       if (rows == null) {
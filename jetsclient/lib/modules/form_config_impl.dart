@@ -416,7 +416,7 @@ final Map<String, FormConfig> _formConfigurations = {
     ],
     queries: {
       "inputFieldsQuery":
-          "SELECT subject, predicate, object, rdf_type FROM jetsapi.rule_config WHERE client = '{client}' AND process_name = '{process_name}' ORDER BY subject ASC, predicate ASC, object ASC LIMIT 300",
+          "SELECT subject, predicate, object, rdf_type, client FROM jetsapi.rule_config WHERE client = '{client}' AND process_name = '{process_name}' ORDER BY subject ASC, predicate ASC, object ASC LIMIT 300",
     },
     inputFieldsQuery: "inputFieldsQuery",
     stateKeyPredicates: [FSK.client, FSK.processName],
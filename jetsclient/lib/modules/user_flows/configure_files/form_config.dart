@@ -65,7 +65,7 @@ final Map<String, FormConfig> _formConfigurations = {
               DropdownItemConfig(label: 'No Organization', value: ''),
             ],
             dropdownItemsQuery:
-                "SELECT org FROM jetsapi.client_org_registry WHERE client = '{client}' ORDER BY org ASC LIMIT 100",
+                "SELECT org, client FROM jetsapi.client_org_registry WHERE client = '{client}' ORDER BY org ASC LIMIT 100",
             stateKeyPredicates: [FSK.client]),
       ],
       [